@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // reminder time zones in images without the zoneinfo database
 
 	"github.com/xsqrty/notes/internal/api/prometheus"
 	"github.com/xsqrty/notes/internal/api/rest"
@@ -52,13 +53,24 @@ func main() {
 		}
 	}()
 
-	deps := app.NewDeps(cfg, log, pool)
+	blobs, err := app.NewBlobStore(&cfg.Blob)
+	if err != nil {
+		panic(fmt.Errorf("blob store: %w", err))
+	}
+
+	deps := app.NewDeps(cfg, log, pool, blobs)
 	defer func() {
 		if err := deps.Close(); err != nil {
 			panic(fmt.Errorf("close deps error: %w", err))
 		}
 	}()
 
+	if cfg.Policy.File != "" {
+		if err := deps.Policies.LoadPolicyFile(cfg.Policy.File); err != nil {
+			panic(fmt.Errorf("policies loader: %w", err))
+		}
+	}
+
 	rest := rest.NewRest(deps)
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	server := &http.Server{
@@ -70,6 +82,40 @@ func main() {
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	if cfg.Policy.File != "" {
+		go deps.Policies.WatchPolicyFile(ctx, cfg.Policy.File, cfg.Policy.ReloadInterval, func(err error) {
+			log.Error().Err(err).Msg("Policies reload error")
+		})
+	}
+
+	go deps.Events.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Outbox dispatch error")
+	})
+
+	go deps.Webhooks.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Webhook delivery error")
+	})
+
+	go deps.Service.StreamService.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Note stream error")
+	})
+
+	go deps.Service.CollabService.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Note collaboration error")
+	})
+
+	go deps.Service.NoteImportService.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Note import error")
+	})
+
+	go deps.Service.ReminderService.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Reminder error")
+	})
+
+	go deps.Service.NotificationService.Run(ctx, func(err error) {
+		log.Error().Err(err).Msg("Notifications cleanup error")
+	})
+
 	err = httpgs.NewGracefulShutdown(ctx).
 		OnMessage(func(name, message string) {
 			log.Info().Msg(fmt.Sprintf("%s: %s", name, message))
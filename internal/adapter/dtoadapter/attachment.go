@@ -0,0 +1,30 @@
+package dtoadapter
+
+import (
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// AttachmentToResponseDto converts an attachment.Attachment model to a dto.AttachmentResponse.
+func AttachmentToResponseDto(a *attachment.Attachment) *dto.AttachmentResponse {
+	return &dto.AttachmentResponse{
+		ID:          a.ID,
+		NoteID:      a.NoteID,
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// AttachmentsToListResponseDto converts the attachments of a note to a dto.AttachmentListResponse.
+func AttachmentsToListResponseDto(attachments []*attachment.Attachment) *dto.AttachmentListResponse {
+	rows := make([]*dto.AttachmentResponse, len(attachments))
+	for i := range attachments {
+		rows[i] = AttachmentToResponseDto(attachments[i])
+	}
+
+	return &dto.AttachmentListResponse{
+		Rows: rows,
+	}
+}
@@ -0,0 +1,48 @@
+package dtoadapter
+
+import (
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// AuditEventToResponseDto converts an audit.Event model to a dto.AuditEventResponse.
+func AuditEventToResponseDto(e *audit.Event) *dto.AuditEventResponse {
+	return &dto.AuditEventResponse{
+		ID:         e.ID,
+		Seq:        e.Seq,
+		Action:     string(e.Action),
+		ActorID:    e.ActorID.UUID,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID.UUID,
+		Details:    e.Details,
+		RequestID:  e.RequestID.UUID,
+		IP:         e.IP,
+		UserAgent:  e.UserAgent,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+// AuditSearchToResponseDto converts a search result containing audit events into an AuditSearchResponse DTO.
+func AuditSearchToResponseDto(res *search.Result[audit.Event]) *dto.AuditSearchResponse {
+	rows := make([]*dto.AuditEventResponse, len(res.Rows))
+	for i := range res.Rows {
+		rows[i] = AuditEventToResponseDto(res.Rows[i])
+	}
+
+	return &dto.AuditSearchResponse{
+		TotalRows: res.TotalRows,
+		Rows:      rows,
+	}
+}
+
+// AuditVerificationToResponseDto converts an audit.Verification model to a dto.AuditVerificationResponse.
+func AuditVerificationToResponseDto(v *audit.Verification) *dto.AuditVerificationResponse {
+	return &dto.AuditVerificationResponse{
+		Valid:     v.Valid,
+		Checked:   v.Checked,
+		BrokenSeq: v.BrokenSeq,
+	}
+}
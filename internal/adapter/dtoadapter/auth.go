@@ -17,9 +17,10 @@ func LoginRequestDtoToEntity(request *dto.LoginRequest) *auth.Login {
 // SignUpRequestDtoToEntity converts a SignUpRequest DTO to an auth.SignUp entity.
 func SignUpRequestDtoToEntity(request *dto.SignUpRequest) *auth.SignUp {
 	return &auth.SignUp{
-		Email:    request.Email,
-		Name:     request.Name,
-		Password: request.Password,
+		Email:      request.Email,
+		Name:       request.Name,
+		Password:   request.Password,
+		InviteCode: request.InviteCode,
 	}
 }
 
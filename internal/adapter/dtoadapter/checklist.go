@@ -0,0 +1,72 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// ChecklistItemRequestDtoToItemData converts a dto.ChecklistItemRequest to the checklist.ItemData of the note.
+func ChecklistItemRequestDtoToItemData(noteID uuid.UUID, request *dto.ChecklistItemRequest) *checklist.ItemData {
+	return &checklist.ItemData{
+		NoteID:     noteID,
+		Text:       request.Text,
+		AssigneeID: uuid.NullUUID{UUID: request.AssigneeID, Valid: request.AssigneeID != uuid.Nil},
+		DueAt:      request.DueAt,
+	}
+}
+
+// ChecklistItemToResponseDto converts a checklist.Item model to a dto.ChecklistItemResponse.
+func ChecklistItemToResponseDto(item *checklist.Item) *dto.ChecklistItemResponse {
+	var assigneeID *uuid.UUID
+	if item.AssigneeID.Valid {
+		assigneeID = &item.AssigneeID.UUID
+	}
+
+	return &dto.ChecklistItemResponse{
+		ID:         item.ID,
+		NoteID:     item.NoteID,
+		Position:   item.Position,
+		Text:       item.Text,
+		Done:       item.Done,
+		AssigneeID: assigneeID,
+		DueAt:      time.Time(item.DueAt),
+		DoneAt:     time.Time(item.DoneAt),
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  time.Time(item.UpdatedAt),
+	}
+}
+
+// ChecklistToResponseDto converts the checklist items of a note to a dto.ChecklistResponse.
+func ChecklistToResponseDto(items []*checklist.Item) *dto.ChecklistResponse {
+	total, done := checklist.Count(items)
+	res := &dto.ChecklistResponse{
+		Total:      total,
+		Done:       done,
+		Completion: checklist.Completion(total, done),
+		Items:      make([]*dto.ChecklistItemResponse, len(items)),
+	}
+
+	for i := range items {
+		res.Items[i] = ChecklistItemToResponseDto(items[i])
+	}
+
+	return res
+}
+
+// NoteChecklistToResponseDto converts the checklist completion of a note to a dto.NoteChecklistResponse, notes
+// without checklist items have none.
+func NoteChecklistToResponseDto(n *note.Note) *dto.NoteChecklistResponse {
+	if n.ChecklistTotal == 0 {
+		return nil
+	}
+
+	return &dto.NoteChecklistResponse{
+		Total:      n.ChecklistTotal,
+		Done:       n.ChecklistDone,
+		Completion: checklist.Completion(n.ChecklistTotal, n.ChecklistDone),
+	}
+}
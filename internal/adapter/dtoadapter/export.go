@@ -0,0 +1,17 @@
+package dtoadapter
+
+import (
+	"cmp"
+
+	"github.com/xsqrty/notes/internal/domain/export"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/pkg/pdf"
+)
+
+// ExportPDFRequestDtoToRequest converts an ExportPDFRequest DTO to an export.PDFRequest model.
+func ExportPDFRequestDtoToRequest(request *dto.ExportPDFRequest) *export.PDFRequest {
+	return &export.PDFRequest{
+		IDs:      request.IDs,
+		PageSize: cmp.Or(pdf.PageSize(request.PageSize), export.DefaultPageSize),
+	}
+}
@@ -0,0 +1,44 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// GraphToResponseDto converts a graph.Graph model to a dto.GraphResponse.
+func GraphToResponseDto(g *graph.Graph) *dto.GraphResponse {
+	nodes := make([]*dto.GraphNodeResponse, len(g.Nodes))
+	for i, n := range g.Nodes {
+		var orgID *uuid.UUID
+		if n.OrgID.Valid {
+			orgID = &n.OrgID.UUID
+		}
+
+		nodes[i] = &dto.GraphNodeResponse{
+			ID:        n.ID,
+			Name:      n.Name,
+			OrgID:     orgID,
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: time.Time(n.UpdatedAt),
+		}
+	}
+
+	edges := make([]*dto.GraphEdgeResponse, len(g.Edges))
+	for i, e := range g.Edges {
+		edges[i] = &dto.GraphEdgeResponse{
+			Source: e.Source,
+			Target: e.Target,
+			Kind:   string(e.Kind),
+			Weight: e.Weight,
+		}
+	}
+
+	return &dto.GraphResponse{
+		Nodes:     nodes,
+		Edges:     edges,
+		Truncated: g.Truncated,
+	}
+}
@@ -0,0 +1,46 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// InviteCodeRequestDtoToCreateData converts an InviteCodeRequest DTO to a CreateData model for invitation code creation.
+func InviteCodeRequestDtoToCreateData(request *dto.InviteCodeRequest) *invite.CreateData {
+	return &invite.CreateData{
+		Email:     request.Email,
+		RoleLabel: role.Label(request.RoleLabel),
+		MaxUses:   request.MaxUses,
+		ExpiresAt: request.ExpiresAt,
+	}
+}
+
+// InviteCodeToResponseDto converts an invite.Code model to a dto.InviteCodeResponse transferring specific fields.
+func InviteCodeToResponseDto(c *invite.Code) *dto.InviteCodeResponse {
+	return &dto.InviteCodeResponse{
+		ID:        c.ID,
+		Code:      c.Code,
+		Email:     c.Email,
+		RoleLabel: c.RoleLabel,
+		MaxUses:   c.MaxUses,
+		Uses:      c.Uses,
+		ExpiresAt: time.Time(c.ExpiresAt),
+		CreatedBy: c.CreatedBy,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// InviteCodesToListResponseDto converts a list of invitation codes into an InviteCodeListResponse DTO.
+func InviteCodesToListResponseDto(codes []*invite.Code) *dto.InviteCodeListResponse {
+	rows := make([]*dto.InviteCodeResponse, len(codes))
+	for i := range codes {
+		rows[i] = InviteCodeToResponseDto(codes[i])
+	}
+
+	return &dto.InviteCodeListResponse{
+		Rows: rows,
+	}
+}
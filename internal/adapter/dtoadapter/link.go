@@ -0,0 +1,57 @@
+package dtoadapter
+
+import (
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// LinkNoteToResponseDto converts a linked note.Note model to a dto.LinkNoteResponse.
+func LinkNoteToResponseDto(n *note.Note) *dto.LinkNoteResponse {
+	return &dto.LinkNoteResponse{
+		ID:   n.ID,
+		Name: n.Name,
+	}
+}
+
+// LinksToListResponseDto converts the resolved links of a note to a dto.LinkListResponse.
+func LinksToListResponseDto(links []*link.Resolved) *dto.LinkListResponse {
+	rows := make([]*dto.LinkResponse, len(links))
+	for i, l := range links {
+		rows[i] = &dto.LinkResponse{Target: l.Target, Dangling: l.Note == nil}
+		if l.Note != nil {
+			rows[i].Note = LinkNoteToResponseDto(l.Note)
+		}
+	}
+
+	return &dto.LinkListResponse{
+		Rows: rows,
+	}
+}
+
+// BacklinksToListResponseDto converts the notes linking to a note to a dto.BacklinkListResponse.
+func BacklinksToListResponseDto(notes []*note.Note) *dto.BacklinkListResponse {
+	rows := make([]*dto.LinkNoteResponse, len(notes))
+	for i := range notes {
+		rows[i] = LinkNoteToResponseDto(notes[i])
+	}
+
+	return &dto.BacklinkListResponse{
+		Rows: rows,
+	}
+}
+
+// DanglingLinksToListResponseDto converts the dangling links to a dto.DanglingLinkListResponse.
+func DanglingLinksToListResponseDto(links []*link.Dangling) *dto.DanglingLinkListResponse {
+	rows := make([]*dto.DanglingLinkResponse, len(links))
+	for i, l := range links {
+		rows[i] = &dto.DanglingLinkResponse{
+			Source: LinkNoteToResponseDto(l.Source),
+			Target: l.Target,
+		}
+	}
+
+	return &dto.DanglingLinkListResponse{
+		Rows: rows,
+	}
+}
@@ -1,43 +1,148 @@
 package dtoadapter
 
 import (
+	"cmp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/xsqrty/notes/internal/domain/note"
 	"github.com/xsqrty/notes/internal/domain/search"
 	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
 )
 
+// snippetLength is the max number of characters of the note text shown in search results.
+const snippetLength = 200
+
 // NoteRequestDtoToCreateData converts a NoteRequest DTO to a CreateData model for note creation.
 func NoteRequestDtoToCreateData(request *dto.NoteRequest) *note.CreateData {
 	return &note.CreateData{
-		Name: request.Name,
-		Text: request.Text,
+		Name:   request.Name,
+		Text:   request.Text,
+		Format: note.Format(request.Format),
+		OrgID:  uuid.NullUUID{UUID: request.OrgID, Valid: request.OrgID != uuid.Nil},
 	}
 }
 
 // NoteRequestDtoToUpdateData converts a NoteRequest DTO and ID into an UpdateData structure for note updates.
 func NoteRequestDtoToUpdateData(id uuid.UUID, request *dto.NoteRequest) *note.UpdateData {
 	return &note.UpdateData{
-		ID:   id,
-		Name: request.Name,
-		Text: request.Text,
+		ID:          id,
+		Name:        request.Name,
+		Text:        request.Text,
+		Format:      note.Format(request.Format),
+		BaseVersion: request.BaseVersion,
+	}
+}
+
+// NoteToPatchDocumentDto converts a note.Note model to the NotePatchDocument DTO patches are applied to.
+func NoteToPatchDocumentDto(n *note.Note) *dto.NotePatchDocument {
+	return &dto.NotePatchDocument{
+		Name:      n.Name,
+		Text:      n.Text,
+		Format:    string(n.Format),
+		Pinned:    n.Pinned,
+		Archived:  n.Archived,
+		Favourite: n.Favourite,
+	}
+}
+
+// NotePatchDocumentDtoToPatchData converts the patched NotePatchDocument DTO to a PatchData structure.
+func NotePatchDocumentDtoToPatchData(doc *dto.NotePatchDocument) *note.PatchData {
+	format := note.Format(doc.Format)
+	return &note.PatchData{
+		Name:      &doc.Name,
+		Text:      &doc.Text,
+		Format:    &format,
+		Pinned:    &doc.Pinned,
+		Archived:  &doc.Archived,
+		Favourite: &doc.Favourite,
+	}
+}
+
+// NoteBatchRequestDtoToRequest converts a NoteBatchRequest DTO to a BatchRequest, atomic unless requested otherwise.
+func NoteBatchRequestDtoToRequest(request *dto.NoteBatchRequest) *note.BatchRequest {
+	ops := make([]*note.BatchOp, len(request.Operations))
+	for i, o := range request.Operations {
+		fields := &dto.NoteRequest{
+			Name:        o.Name,
+			Text:        o.Text,
+			Format:      o.Format,
+			OrgID:       o.OrgID,
+			BaseVersion: o.BaseVersion,
+		}
+
+		ops[i] = &note.BatchOp{Kind: note.BatchKind(o.Op), ID: o.ID}
+		switch ops[i].Kind {
+		case note.BatchCreate:
+			ops[i].Create = NoteRequestDtoToCreateData(fields)
+		case note.BatchUpdate:
+			ops[i].Update = NoteRequestDtoToUpdateData(o.ID, fields)
+		}
+	}
+
+	return &note.BatchRequest{
+		Mode: note.BatchMode(cmp.Or(request.Mode, string(note.BatchAtomic))),
+		Ops:  ops,
 	}
 }
 
 // NoteToResponseDto converts a note.Note model to a dto.NoteResponse transferring specific fields.
 func NoteToResponseDto(note *note.Note) *dto.NoteResponse {
+	var orgID *uuid.UUID
+	if note.OrgID.Valid {
+		orgID = &note.OrgID.UUID
+	}
+
 	return &dto.NoteResponse{
 		ID:        note.ID,
 		Name:      note.Name,
 		Text:      note.Text,
+		Format:    string(note.Format),
 		UserID:    note.UserId,
+		OrgID:     orgID,
+		Version:   note.Version,
+		Pinned:    note.Pinned,
+		Archived:  note.Archived,
+		Favourite: note.Favourite,
+		Checklist: NoteChecklistToResponseDto(note),
 		CreatedAt: note.CreatedAt,
 		UpdatedAt: time.Time(note.UpdatedAt),
 	}
 }
 
+// NoteMergeConflictToResponseDto converts the merge conflict of the note update into a NoteConflictResponse DTO.
+func NoteMergeConflictToResponseDto(err *note.MergeConflictError) *dto.NoteConflictResponse {
+	conflicts := make([]*dto.NoteConflictResponseHunk, len(err.Conflicts))
+	for i, c := range err.Conflicts {
+		conflicts[i] = &dto.NoteConflictResponseHunk{
+			Field:  string(c.Field),
+			Base:   c.Base,
+			Client: c.Client,
+			Server: c.Server,
+		}
+	}
+
+	return &dto.NoteConflictResponse{
+		Error:     errx.New(errx.CodeConflict, "Note was changed concurrently, changes can not be merged"),
+		Note:      NoteToResponseDto(err.Note),
+		Conflicts: conflicts,
+	}
+}
+
+// NoteRenderedToResponseDto converts the rendered note into a NoteRenderResponse DTO.
+func NoteRenderedToResponseDto(rendered *note.Rendered) *dto.NoteRenderResponse {
+	return &dto.NoteRenderResponse{
+		ID:        rendered.NoteID,
+		Version:   rendered.Version,
+		Format:    string(rendered.Format),
+		HTML:      rendered.HTML,
+		PlainText: rendered.PlainText,
+	}
+}
+
 // NoteSearchToResponseDto converts a search result containing notes into a NoteSearchResponse DTO.
 // It iterates over the rows in the search result, converting each note into a NoteResponse DTO using NoteToResponseDto.
 // Returns a NoteSearchResponse with the total rows and the converted rows.
@@ -45,6 +150,7 @@ func NoteSearchToResponseDto(res *search.Result[note.Note]) *dto.NoteSearchRespo
 	rows := make([]*dto.NoteResponse, len(res.Rows))
 	for i := range res.Rows {
 		rows[i] = NoteToResponseDto(res.Rows[i])
+		rows[i].Snippet = snippet(res.Rows[i].PlainText)
 	}
 
 	return &dto.NoteSearchResponse{
@@ -52,3 +158,17 @@ func NoteSearchToResponseDto(res *search.Result[note.Note]) *dto.NoteSearchRespo
 		Rows:      rows,
 	}
 }
+
+// snippet returns the beginning of the plain text cut at a word boundary.
+func snippet(text string) string {
+	if utf8.RuneCountInString(text) <= snippetLength {
+		return text
+	}
+
+	cut := []rune(text)[:snippetLength]
+	if i := strings.LastIndexByte(string(cut), ' '); i > 0 {
+		return string(cut)[:i] + "…"
+	}
+
+	return string(cut) + "…"
+}
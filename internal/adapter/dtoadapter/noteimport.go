@@ -0,0 +1,54 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// NoteImportToResponseDto converts a noteimport.Job model to a dto.NoteImportResponse.
+func NoteImportToResponseDto(j *noteimport.Job) *dto.NoteImportResponse {
+	return &dto.NoteImportResponse{
+		ID:         j.ID,
+		Name:       j.Name,
+		Format:     string(j.Format),
+		Size:       j.Size,
+		Status:     string(j.Status),
+		Total:      j.Total,
+		Processed:  j.Processed,
+		Created:    j.Created,
+		Skipped:    j.Skipped,
+		Failed:     j.Failed,
+		Error:      j.Error,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  time.Time(j.StartedAt),
+		FinishedAt: time.Time(j.FinishedAt),
+	}
+}
+
+// NoteImportItemToResponseDto converts a noteimport.Item model to a dto.NoteImportItemResponse.
+func NoteImportItemToResponseDto(it *noteimport.Item) *dto.NoteImportItemResponse {
+	return &dto.NoteImportItemResponse{
+		ID:       it.ID,
+		Position: it.Position,
+		Source:   it.Source,
+		Status:   string(it.Status),
+		NoteID:   it.NoteID.UUID,
+		Error:    it.Error,
+	}
+}
+
+// NoteImportItemsToResponseDto converts a page of import items into a NoteImportItemListResponse DTO.
+func NoteImportItemsToResponseDto(res *search.Result[noteimport.Item]) *dto.NoteImportItemListResponse {
+	rows := make([]*dto.NoteImportItemResponse, len(res.Rows))
+	for i := range res.Rows {
+		rows[i] = NoteImportItemToResponseDto(res.Rows[i])
+	}
+
+	return &dto.NoteImportItemListResponse{
+		TotalRows: res.TotalRows,
+		Rows:      rows,
+	}
+}
@@ -0,0 +1,58 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// NotificationToResponseDto converts a notification.Notification model to a dto.NotificationResponse.
+func NotificationToResponseDto(n *notification.Notification) *dto.NotificationResponse {
+	return &dto.NotificationResponse{
+		ID:        n.ID,
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Body:      n.Body,
+		NoteID:    n.NoteID.UUID,
+		Read:      !time.Time(n.ReadAt).IsZero(),
+		CreatedAt: n.CreatedAt,
+		ReadAt:    time.Time(n.ReadAt),
+	}
+}
+
+// NotificationPageToResponseDto converts a notification.Page to a dto.NotificationListResponse.
+func NotificationPageToResponseDto(page *notification.Page) *dto.NotificationListResponse {
+	rows := make([]*dto.NotificationResponse, len(page.Rows))
+	for i := range page.Rows {
+		rows[i] = NotificationToResponseDto(page.Rows[i])
+	}
+
+	return &dto.NotificationListResponse{
+		Unread:     page.Unread,
+		NextCursor: page.Next.String(),
+		HasMore:    page.HasMore,
+		Rows:       rows,
+	}
+}
+
+// NotificationPreferencesRequestDtoToMap converts a dto.NotificationPreferencesRequest to the enabled types.
+func NotificationPreferencesRequestDtoToMap(request *dto.NotificationPreferencesRequest) map[notification.Type]bool {
+	enabled := make(map[notification.Type]bool, len(request.Preferences))
+	for t, value := range request.Preferences {
+		enabled[notification.Type(t)] = value
+	}
+
+	return enabled
+}
+
+// NotificationPreferencesToResponseDto converts the notification preferences to a
+// dto.NotificationPreferencesResponse.
+func NotificationPreferencesToResponseDto(prefs []*notification.Preference) *dto.NotificationPreferencesResponse {
+	res := make([]*dto.NotificationPreferenceResponse, len(prefs))
+	for i, p := range prefs {
+		res[i] = &dto.NotificationPreferenceResponse{Type: string(p.Type), Enabled: p.Enabled}
+	}
+
+	return &dto.NotificationPreferencesResponse{Preferences: res}
+}
@@ -0,0 +1,104 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// OrgRequestDtoToCreateData converts an OrgRequest DTO to a CreateData model for organisation creation.
+func OrgRequestDtoToCreateData(request *dto.OrgRequest) *org.CreateData {
+	return &org.CreateData{
+		Name: request.Name,
+	}
+}
+
+// OrgRequestDtoToUpdateData converts an OrgRequest DTO and ID into an UpdateData structure for organisation updates.
+func OrgRequestDtoToUpdateData(id uuid.UUID, request *dto.OrgRequest) *org.UpdateData {
+	return &org.UpdateData{
+		ID:   id,
+		Name: request.Name,
+	}
+}
+
+// OrgMemberRequestDtoToMemberData converts an OrgMemberRequest DTO into a MemberData structure.
+func OrgMemberRequestDtoToMemberData(orgID, userID uuid.UUID, request *dto.OrgMemberRequest) *org.MemberData {
+	return &org.MemberData{
+		OrgID:  orgID,
+		UserID: userID,
+		Role:   org.MemberRole(request.Role),
+	}
+}
+
+// OrgInvitationRequestDtoToInviteData converts an OrgInvitationRequest DTO into an InviteData structure.
+func OrgInvitationRequestDtoToInviteData(orgID uuid.UUID, request *dto.OrgInvitationRequest) *org.InviteData {
+	return &org.InviteData{
+		OrgID: orgID,
+		Email: request.Email,
+		Role:  org.MemberRole(request.Role),
+	}
+}
+
+// OrgToResponseDto converts an org.Org model to a dto.OrgResponse.
+func OrgToResponseDto(o *org.Org) *dto.OrgResponse {
+	return &dto.OrgResponse{
+		ID:        o.ID,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: time.Time(o.UpdatedAt),
+	}
+}
+
+// OrgsToListResponseDto converts a list of org.Org models to a dto.OrgListResponse.
+func OrgsToListResponseDto(orgs []*org.Org) *dto.OrgListResponse {
+	rows := make([]*dto.OrgResponse, len(orgs))
+	for i := range orgs {
+		rows[i] = OrgToResponseDto(orgs[i])
+	}
+
+	return &dto.OrgListResponse{Rows: rows}
+}
+
+// OrgMemberToResponseDto converts an org.Member model to a dto.OrgMemberResponse.
+func OrgMemberToResponseDto(m *org.Member) *dto.OrgMemberResponse {
+	return &dto.OrgMemberResponse{
+		OrgID:     m.OrgID,
+		UserID:    m.UserID,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// OrgMembersToListResponseDto converts a list of org.Member models to a dto.OrgMemberListResponse.
+func OrgMembersToListResponseDto(members []*org.Member) *dto.OrgMemberListResponse {
+	rows := make([]*dto.OrgMemberResponse, len(members))
+	for i := range members {
+		rows[i] = OrgMemberToResponseDto(members[i])
+	}
+
+	return &dto.OrgMemberListResponse{Rows: rows}
+}
+
+// OrgInvitationToResponseDto converts an org.Invitation model to a dto.OrgInvitationResponse.
+func OrgInvitationToResponseDto(i *org.Invitation) *dto.OrgInvitationResponse {
+	return &dto.OrgInvitationResponse{
+		ID:        i.ID,
+		OrgID:     i.OrgID,
+		Email:     i.Email,
+		Role:      string(i.Role),
+		InvitedBy: i.InvitedBy,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+// OrgInvitationsToListResponseDto converts a list of org.Invitation models to a dto.OrgInvitationListResponse.
+func OrgInvitationsToListResponseDto(invitations []*org.Invitation) *dto.OrgInvitationListResponse {
+	rows := make([]*dto.OrgInvitationResponse, len(invitations))
+	for i := range invitations {
+		rows[i] = OrgInvitationToResponseDto(invitations[i])
+	}
+
+	return &dto.OrgInvitationListResponse{Rows: rows}
+}
@@ -0,0 +1,65 @@
+package dtoadapter
+
+import (
+	"github.com/xsqrty/notes/internal/domain/policy"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// PolicyToResponseDto converts an rbac.Policy to a dto.PolicyResponse.
+func PolicyToResponseDto(p *rbac.Policy) *dto.PolicyResponse {
+	conditions := make([]*dto.PolicyConditionResponse, len(p.Conditions))
+	for i, c := range p.Conditions {
+		conditions[i] = &dto.PolicyConditionResponse{
+			Attribute: c.Attribute,
+			Operator:  string(c.Operator),
+			Value:     c.Value,
+			Ref:       c.Ref,
+		}
+	}
+
+	return &dto.PolicyResponse{
+		Name:        p.Name,
+		Description: p.Description,
+		Effect:      string(p.Effect),
+		Resource:    p.Resource,
+		Operations:  p.Operations,
+		Conditions:  conditions,
+	}
+}
+
+// PoliciesToListResponseDto converts a list of rbac.Policy to a dto.PolicyListResponse.
+func PoliciesToListResponseDto(policies []rbac.Policy) *dto.PolicyListResponse {
+	rows := make([]*dto.PolicyResponse, len(policies))
+	for i := range policies {
+		rows[i] = PolicyToResponseDto(&policies[i])
+	}
+
+	return &dto.PolicyListResponse{Rows: rows}
+}
+
+// PolicyExplainRequestDtoToExplainData converts a PolicyExplainRequest DTO into policy.ExplainData.
+// The operation is expected to be validated by the request DTO.
+func PolicyExplainRequestDtoToExplainData(request *dto.PolicyExplainRequest) (*policy.ExplainData, error) {
+	op, err := rbac.ParseOperation(request.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy.ExplainData{
+		Resource:   request.Resource,
+		Operation:  op,
+		ResourceID: request.ResourceID,
+		UserID:     request.UserID,
+	}, nil
+}
+
+// ExplanationToResponseDto converts a policy.Explanation to a dto.PolicyExplainResponse.
+func ExplanationToResponseDto(e *policy.Explanation) *dto.PolicyExplainResponse {
+	return &dto.PolicyExplainResponse{
+		Granted:    e.Granted,
+		Effect:     string(e.Decision.Effect),
+		Policy:     e.Decision.Policy,
+		Attributes: e.Attributes,
+	}
+}
@@ -0,0 +1,38 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// ReminderRequestDtoToSetData converts a dto.ReminderRequest to the reminder.SetData of the note.
+func ReminderRequestDtoToSetData(noteID uuid.UUID, request *dto.ReminderRequest) *reminder.SetData {
+	return &reminder.SetData{
+		NoteID:   noteID,
+		RemindAt: request.RemindAt,
+		TimeZone: request.TimeZone,
+		Rule:     request.Rule,
+	}
+}
+
+// ReminderToResponseDto converts a reminder.Reminder model to a dto.ReminderResponse.
+func ReminderToResponseDto(r *reminder.Reminder) *dto.ReminderResponse {
+	return &dto.ReminderResponse{
+		ID:           r.ID,
+		NoteID:       r.NoteID,
+		RemindAt:     r.RemindAt,
+		TimeZone:     r.TimeZone,
+		Rule:         r.Rule,
+		Status:       string(r.Status),
+		NextAt:       time.Time(r.NextAt),
+		SnoozedUntil: time.Time(r.SnoozedUntil),
+		DueAt:        time.Time(r.DueAt),
+		FiredAt:      time.Time(r.FiredAt),
+		Fired:        r.Fired,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    time.Time(r.UpdatedAt),
+	}
+}
@@ -0,0 +1,82 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// RoleRequestDtoToCreateData converts a RoleRequest DTO to a CreateData model for role creation.
+func RoleRequestDtoToCreateData(request *dto.RoleRequest) *role.CreateData {
+	return &role.CreateData{
+		Description: request.Description,
+		Label:       role.Label(request.Label),
+		Permissions: stringsToPermissions(request.Permissions),
+	}
+}
+
+// RoleRequestDtoToUpdateData converts a RoleRequest DTO and ID into an UpdateData structure for role updates.
+func RoleRequestDtoToUpdateData(id uuid.UUID, request *dto.RoleRequest) *role.UpdateData {
+	return &role.UpdateData{
+		ID:          id,
+		Description: request.Description,
+		Label:       role.Label(request.Label),
+		Permissions: stringsToPermissions(request.Permissions),
+	}
+}
+
+// RoleToResponseDto converts a role.Role model to a dto.RoleResponse transferring specific fields.
+func RoleToResponseDto(r *role.Role) *dto.RoleResponse {
+	return &dto.RoleResponse{
+		ID:          r.ID,
+		Description: r.Description,
+		Label:       r.Label,
+		Permissions: r.Permissions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   time.Time(r.UpdatedAt),
+	}
+}
+
+// RolesToListResponseDto converts a list of roles into a RoleListResponse DTO.
+func RolesToListResponseDto(roles []*role.Role) *dto.RoleListResponse {
+	rows := make([]*dto.RoleResponse, len(roles))
+	for i := range roles {
+		rows[i] = RoleToResponseDto(roles[i])
+	}
+
+	return &dto.RoleListResponse{
+		Rows: rows,
+	}
+}
+
+// PermissionsToListResponseDto converts a list of permissions into a PermissionListResponse DTO.
+func PermissionsToListResponseDto(permissions []role.Permission) *dto.PermissionListResponse {
+	rows := make([]string, len(permissions))
+	for i := range permissions {
+		rows[i] = string(permissions[i])
+	}
+
+	return &dto.PermissionListResponse{
+		Rows: rows,
+	}
+}
+
+// stringsToPermissions converts a slice of strings to a slice of role.Permission values.
+func stringsToPermissions(permissions []string) []role.Permission {
+	res := make([]role.Permission, len(permissions))
+	for i := range permissions {
+		res[i] = role.Permission(permissions[i])
+	}
+
+	return res
+}
+
+// AssignmentToResponseDto converts a role.Assignment to a dto.RoleAssignmentResponse.
+func AssignmentToResponseDto(data *role.Assignment) *dto.RoleAssignmentResponse {
+	return &dto.RoleAssignmentResponse{
+		RoleID: data.RoleID,
+		UserID: data.UserID,
+	}
+}
@@ -0,0 +1,29 @@
+package dtoadapter
+
+import (
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/stream"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// NoteEventToResponseDto converts a stream.Event model to a dto.NoteEventResponse.
+func NoteEventToResponseDto(e *stream.Event) *dto.NoteEventResponse {
+	var orgID *uuid.UUID
+	if e.Note.OrgID != uuid.Nil {
+		orgID = &e.Note.OrgID
+	}
+
+	return &dto.NoteEventResponse{
+		ID:        e.ID,
+		Type:      string(e.Type),
+		CreatedAt: e.CreatedAt,
+		Note: &dto.NoteEventNoteResponse{
+			ID:        e.Note.ID,
+			Name:      e.Note.Name,
+			UserID:    e.Note.UserID,
+			OrgID:     orgID,
+			CreatedAt: e.Note.CreatedAt,
+			UpdatedAt: e.Note.UpdatedAt,
+		},
+	}
+}
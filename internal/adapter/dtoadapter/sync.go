@@ -0,0 +1,68 @@
+package dtoadapter
+
+import (
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/notesync"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// SyncRequestDtoToRequest converts a SyncRequest DTO and the parsed token to a notesync.Request.
+func SyncRequestDtoToRequest(request *dto.SyncRequest, token notesync.Token) *notesync.Request {
+	changes := make([]*notesync.Change, len(request.Changes))
+	for i, change := range request.Changes {
+		changes[i] = &notesync.Change{
+			Kind:        notesync.ChangeKind(change.Op),
+			NoteID:      change.ID,
+			BaseVersion: change.BaseVersion,
+			Name:        change.Name,
+			Text:        change.Text,
+			OrgID:       uuid.NullUUID{UUID: change.OrgID, Valid: change.OrgID != uuid.Nil},
+		}
+	}
+
+	return &notesync.Request{Token: token, Changes: changes}
+}
+
+// SyncToResponseDto converts a notesync.Response to a SyncResponse DTO, conflicts carry the change of the request.
+func SyncToResponseDto(res *notesync.Response, request *dto.SyncRequest) *dto.SyncResponse {
+	results := make([]*dto.SyncResultResponse, len(res.Results))
+	for i, result := range res.Results {
+		results[i] = &dto.SyncResultResponse{
+			Status:  string(result.Status),
+			ID:      result.NoteID,
+			Version: result.Version,
+		}
+
+		if result.Status == notesync.StatusConflict {
+			results[i].Server = SyncChangeToResponseDto(result.Server)
+			results[i].Client = request.Changes[i]
+		}
+	}
+
+	changes := make([]*dto.SyncChangeResponse, len(res.Changes))
+	for i, change := range res.Changes {
+		changes[i] = SyncChangeToResponseDto(change)
+	}
+
+	return &dto.SyncResponse{
+		Results: results,
+		Changes: changes,
+		Token:   res.Token.String(),
+		HasMore: res.HasMore,
+	}
+}
+
+// SyncChangeToResponseDto converts a notesync.ServerChange to a SyncChangeResponse DTO.
+func SyncChangeToResponseDto(change *notesync.ServerChange) *dto.SyncChangeResponse {
+	res := &dto.SyncChangeResponse{
+		ID:      change.NoteID,
+		Version: change.Version,
+		Deleted: change.Deleted,
+	}
+
+	if change.Note != nil {
+		res.Note = NoteToResponseDto(change.Note)
+	}
+
+	return res
+}
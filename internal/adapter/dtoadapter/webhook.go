@@ -0,0 +1,101 @@
+package dtoadapter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/internal/dto"
+)
+
+// WebhookRequestDtoToCreateData converts a WebhookRequest DTO to a CreateData model for endpoint registration.
+func WebhookRequestDtoToCreateData(request *dto.WebhookRequest) *webhook.CreateData {
+	return &webhook.CreateData{
+		URL:        request.URL,
+		EventTypes: stringsToEventTypes(request.EventTypes),
+	}
+}
+
+// WebhookUpdateRequestDtoToUpdateData converts a WebhookUpdateRequest DTO to an UpdateData model for the endpoint.
+func WebhookUpdateRequestDtoToUpdateData(id uuid.UUID, request *dto.WebhookUpdateRequest) *webhook.UpdateData {
+	return &webhook.UpdateData{
+		ID:         id,
+		URL:        request.URL,
+		EventTypes: stringsToEventTypes(request.EventTypes),
+		Enabled:    request.Enabled,
+	}
+}
+
+// WebhookToResponseDto converts a webhook.Endpoint model to a dto.WebhookResponse omitting the secret.
+func WebhookToResponseDto(e *webhook.Endpoint) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:         e.ID,
+		URL:        e.URL,
+		EventTypes: e.EventTypes,
+		Enabled:    e.Enabled,
+		Failures:   e.Failures,
+		DisabledAt: time.Time(e.DisabledAt),
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  time.Time(e.UpdatedAt),
+	}
+}
+
+// WebhookToCreatedResponseDto converts a newly created webhook.Endpoint model to a dto.WebhookResponse with the secret.
+func WebhookToCreatedResponseDto(e *webhook.Endpoint) *dto.WebhookResponse {
+	res := WebhookToResponseDto(e)
+	res.Secret = e.Secret
+	return res
+}
+
+// WebhooksToListResponseDto converts a list of webhook endpoints into a WebhookListResponse DTO.
+func WebhooksToListResponseDto(endpoints []*webhook.Endpoint) *dto.WebhookListResponse {
+	rows := make([]*dto.WebhookResponse, len(endpoints))
+	for i := range endpoints {
+		rows[i] = WebhookToResponseDto(endpoints[i])
+	}
+
+	return &dto.WebhookListResponse{
+		Rows: rows,
+	}
+}
+
+// WebhookDeliveryToResponseDto converts a webhook.Delivery model to a dto.WebhookDeliveryResponse.
+func WebhookDeliveryToResponseDto(d *webhook.Delivery) *dto.WebhookDeliveryResponse {
+	return &dto.WebhookDeliveryResponse{
+		ID:            d.ID,
+		EventID:       d.EventID.UUID,
+		EventType:     string(d.EventType),
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		ResponseCode:  d.ResponseCode,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		DeliveredAt:   time.Time(d.DeliveredAt),
+	}
+}
+
+// WebhookDeliveriesToResponseDto converts a page of webhook deliveries into a WebhookDeliveryListResponse DTO.
+func WebhookDeliveriesToResponseDto(res *search.Result[webhook.Delivery]) *dto.WebhookDeliveryListResponse {
+	rows := make([]*dto.WebhookDeliveryResponse, len(res.Rows))
+	for i := range res.Rows {
+		rows[i] = WebhookDeliveryToResponseDto(res.Rows[i])
+	}
+
+	return &dto.WebhookDeliveryListResponse{
+		TotalRows: res.TotalRows,
+		Rows:      rows,
+	}
+}
+
+// stringsToEventTypes converts the list of strings to the list of event types.
+func stringsToEventTypes(types []string) []event.Type {
+	res := make([]event.Type, len(types))
+	for i := range types {
+		res[i] = event.Type(types[i])
+	}
+
+	return res
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+const (
+	// attachmentFormField is the name of the multipart form field carrying the uploaded file.
+	attachmentFormField = "file"
+	// attachmentFormOverhead is the allowance for the multipart framing over the max file size.
+	attachmentFormOverhead = 64 << 10
+)
+
+// AttachmentHandler is responsible for handling HTTP requests related to files attached to notes.
+type AttachmentHandler struct {
+	deps *app.Deps
+}
+
+// NewAttachmentHandler initializes and returns a new instance of AttachmentHandler with the provided dependencies.
+func NewAttachmentHandler(deps *app.Deps) *AttachmentHandler {
+	return &AttachmentHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for attachments of the note.
+func (h *AttachmentHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Post("/", h.Upload)
+	router.Get("/", h.List)
+	router.Get("/{attachmentID}", h.Download)
+	router.Delete("/{attachmentID}", h.Delete)
+	return router
+}
+
+// Upload handler
+//
+//	@Summary		Upload attachment
+//	@Description	Attach the file of the multipart form field "file" to the note. The content type is detected
+//	@Description	from the content. Files are limited in size, as well as the total size of the files of the user.
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Note id"
+//	@Param			file	formData	file	true	"Attached file"
+//	@Success		201		{object}	dto.AttachmentResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		413		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/attachments [post]
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "upload attachment")
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Attachment.MaxFileSize)+attachmentFormOverhead)
+	form, err := r.MultipartReader()
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("upload attachment handler parse form")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Multipart form is expected"))
+		return
+	}
+
+	for {
+		part, err := form.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errx.New(errx.CodeBadRequest, "File is required")
+			}
+
+			middleware.Log(r).Debug().Err(err).Msg("upload attachment handler parse form")
+			httpio.Error(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if part.FormName() != attachmentFormField {
+			continue
+		}
+
+		res, err := h.deps.Service.AttachmentService.Upload(r.Context(), user, &attachment.UploadData{
+			NoteID:  noteID,
+			Name:    part.FileName(),
+			Content: part,
+		})
+		if err != nil {
+			h.error(w, r, "upload attachment", err)
+			return
+		}
+
+		httpio.Json(w, http.StatusCreated, dtoadapter.AttachmentToResponseDto(res))
+		return
+	}
+}
+
+// List handler
+//
+//	@Summary		List attachments
+//	@Description	Get attachments of the note oldest first
+//	@Tags			Attachments
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.AttachmentListResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/attachments [get]
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "list attachments")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.AttachmentService.List(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "list attachments", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.AttachmentsToListResponseDto(res))
+}
+
+// Download handler
+//
+//	@Summary		Download attachment
+//	@Description	Stream the attached file, range requests are supported. The file is always served as a download.
+//	@Tags			Attachments
+//	@Produce		octet-stream
+//	@Param			id				path		string	true	"Note id"
+//	@Param			attachmentID	path		string	true	"Attachment id"
+//	@Success		200				{file}		file
+//	@Success		206				{file}		file
+//	@Failure		400				{object}	httpio.ErrorResponse
+//	@Failure		401				{object}	httpio.ErrorResponse
+//	@Failure		403				{object}	httpio.ErrorResponse
+//	@Failure		404				{object}	httpio.ErrorResponse
+//	@Failure		416				{string}	string
+//	@Failure		500				{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/attachments/{attachmentID} [get]
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "download attachment")
+	if !ok {
+		return
+	}
+
+	id, ok := h.attachmentID(w, r, "download attachment")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.AttachmentService.Download(r.Context(), user, noteID, id)
+	if err != nil {
+		h.error(w, r, "download attachment", err)
+		return
+	}
+	defer res.Content.Close() // nolint: errcheck
+
+	w.Header().Set("Content-Type", res.Attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": res.Attachment.Name,
+	}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", strconv.Quote(res.Attachment.ID.String()))
+	http.ServeContent(w, r, res.Attachment.Name, res.Attachment.CreatedAt, res.Content)
+}
+
+// Delete handler
+//
+//	@Summary		Delete attachment
+//	@Description	Delete the attachment with its file
+//	@Tags			Attachments
+//	@Produce		json
+//	@Param			id				path		string	true	"Note id"
+//	@Param			attachmentID	path		string	true	"Attachment id"
+//	@Success		200				{object}	dto.AttachmentResponse
+//	@Failure		400				{object}	httpio.ErrorResponse
+//	@Failure		401				{object}	httpio.ErrorResponse
+//	@Failure		403				{object}	httpio.ErrorResponse
+//	@Failure		404				{object}	httpio.ErrorResponse
+//	@Failure		500				{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/attachments/{attachmentID} [delete]
+func (h *AttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "delete attachment")
+	if !ok {
+		return
+	}
+
+	id, ok := h.attachmentID(w, r, "delete attachment")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.AttachmentService.Delete(r.Context(), user, noteID, id)
+	if err != nil {
+		h.error(w, r, "delete attachment", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.AttachmentToResponseDto(res))
+}
+
+// userAndID extracts the authenticated user and the note id from the request, writing the error response on failure.
+func (h *AttachmentHandler) userAndID(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// attachmentID extracts the attachment id from the request, writing the error response on failure.
+func (h *AttachmentHandler) attachmentID(w http.ResponseWriter, r *http.Request, action string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "attachmentID"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse attachment id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// error writes the error response matching the attachment service error.
+func (h *AttachmentHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, note.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, note.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler note not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+	case errors.Is(err, attachment.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler attachment not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Attachment is not found"))
+	case errors.Is(err, attachment.ErrFileTooLarge), errors.As(err, &maxBytesErr):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler file too large", action)
+		maxBytes := strconv.FormatInt(int64(h.deps.Config.Attachment.MaxFileSize), 10)
+		httpio.Error(w, http.StatusRequestEntityTooLarge, errx.NewOptional(
+			errx.CodeBodyTooLarge,
+			"File limit "+maxBytes+" bytes is exceeded",
+			map[string]string{"max_bytes": maxBytes},
+		))
+	case errors.Is(err, attachment.ErrQuotaExceeded):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler quota exceeded", action)
+		maxBytes := strconv.FormatInt(int64(h.deps.Config.Attachment.MaxUserSize), 10)
+		httpio.Error(w, http.StatusRequestEntityTooLarge, errx.NewOptional(
+			errx.CodeQuotaExceeded,
+			"Attachments limit "+maxBytes+" bytes of the user is exceeded",
+			map[string]string{"max_bytes": maxBytes},
+		))
+	case errors.Is(err, attachment.ErrEmptyFile):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler empty file", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "File is empty"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
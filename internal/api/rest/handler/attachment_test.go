@@ -0,0 +1,358 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_attachment"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+)
+
+type attachmentDeps struct {
+	service *mock_attachment.Service
+	mw      *mock_middleware.JWTAuthentication
+}
+
+// nopSeekCloser adapts the reader of the test content to the downloaded content.
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
+
+func TestAttachmentHandler_List(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	a := &attachment.Attachment{
+		ID:          uuid.Must(uuid.NewV7()),
+		NoteID:      noteID,
+		Name:        "photo.png",
+		ContentType: "image/png",
+		Size:        1024,
+		CreatedAt:   time.Now().UTC(),
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.AttachmentListResponse, *attachmentDeps]{
+		{
+			Name:       "successful_list",
+			ID:         noteID.String(),
+			StatusCode: http.StatusOK,
+			Expected: &dto.AttachmentListResponse{
+				Rows: []*dto.AttachmentResponse{
+					{
+						ID:          a.ID,
+						NoteID:      a.NoteID,
+						Name:        a.Name,
+						ContentType: a.ContentType,
+						Size:        a.Size,
+						CreatedAt:   a.CreatedAt,
+					},
+				},
+			},
+			Mocker: func(_ struct{}, d *attachmentDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().List(mock.Anything, u, noteID).Return([]*attachment.Attachment{a}, nil).Once()
+			},
+		},
+		{
+			Name:       "bad_id",
+			ID:         "bad",
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *attachmentDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "note_not_found",
+			ID:         noteID.String(),
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *attachmentDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().List(mock.Anything, u, noteID).Return(nil, note.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         noteID.String(),
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ struct{}, d *attachmentDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().List(mock.Anything, u, noteID).Return(nil, note.ErrOperationForbiddenForUser).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_attachment.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodGet, fmt.Sprintf("/api/v1/notes/%s/attachments", tc.ID), func() *attachmentDeps {
+				return &attachmentDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *attachmentDeps) http.HandlerFunc {
+				return NewAttachmentHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.AttachmentService = service
+				})).List
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestAttachmentHandler_Upload(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	a := &attachment.Attachment{
+		ID:          uuid.Must(uuid.NewV7()),
+		NoteID:      noteID,
+		UserID:      u.ID,
+		Name:        "notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Size:        5,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	cases := []struct {
+		name         string
+		field        string
+		serviceErr   error
+		statusCode   int
+		expectedCode string
+	}{
+		{
+			name:       "successful_upload",
+			field:      "file",
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:         "file_required",
+			field:        "other",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "file_too_large",
+			field:        "file",
+			serviceErr:   attachment.ErrFileTooLarge,
+			statusCode:   http.StatusRequestEntityTooLarge,
+			expectedCode: errx.CodeBodyTooLarge,
+		},
+		{
+			name:         "quota_exceeded",
+			field:        "file",
+			serviceErr:   attachment.ErrQuotaExceeded,
+			statusCode:   http.StatusRequestEntityTooLarge,
+			expectedCode: errx.CodeQuotaExceeded,
+		},
+		{
+			name:         "not_granted",
+			field:        "file",
+			serviceErr:   note.ErrOperationForbiddenForUser,
+			statusCode:   http.StatusForbidden,
+			expectedCode: errx.CodeForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_attachment.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			if tc.field == "file" {
+				service.EXPECT().Upload(mock.Anything, u, mock.Anything).
+					RunAndReturn(func(
+						_ context.Context,
+						_ *user.User,
+						data *attachment.UploadData,
+					) (*attachment.Attachment, error) {
+						content, err := io.ReadAll(data.Content)
+						require.NoError(t, err)
+						require.Equal(t, noteID, data.NoteID)
+						require.Equal(t, "notes.txt", data.Name)
+						require.Equal(t, "hello", string(content))
+						if tc.serviceErr != nil {
+							return nil, tc.serviceErr
+						}
+
+						return a, nil
+					}).Once()
+			}
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			require.NoError(t, form.WriteField("comment", "skipped"))
+			part, err := form.CreateFormFile(tc.field, "notes.txt")
+			require.NoError(t, err)
+			_, err = part.Write([]byte("hello"))
+			require.NoError(t, err)
+			require.NoError(t, form.Close())
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/notes/"+noteID.String()+"/attachments", &body)
+			r.Header.Set("Content-Type", form.FormDataContentType())
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.AttachmentService = service
+				deps.Config.Attachment.MaxFileSize = 1 << 20
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewAttachmentHandler(deps).Upload)).
+				ServeHTTP(w, testutil.AddUrlParams(r, map[string]string{"id": noteID.String()}))
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expectedCode == "" {
+				var res dto.AttachmentResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+				require.Equal(t, a.ID, res.ID)
+				return
+			}
+
+			var res httpio.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			require.Equal(t, tc.expectedCode, res.Error.Code)
+		})
+	}
+}
+
+func TestAttachmentHandler_Download(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	a := &attachment.Attachment{
+		ID:          uuid.Must(uuid.NewV7()),
+		NoteID:      noteID,
+		UserID:      u.ID,
+		Name:        "отчёт.html",
+		ContentType: "text/html; charset=utf-8",
+		Size:        26,
+		CreatedAt:   time.Now().UTC(),
+	}
+	content := "abcdefghijklmnopqrstuvwxyz"
+
+	cases := []struct {
+		name        string
+		rangeHeader string
+		serviceErr  error
+		statusCode  int
+		expected    string
+	}{
+		{
+			name:       "successful_download",
+			statusCode: http.StatusOK,
+			expected:   content,
+		},
+		{
+			name:        "range_download",
+			rangeHeader: "bytes=10-14",
+			statusCode:  http.StatusPartialContent,
+			expected:    "klmno",
+		},
+		{
+			name:       "not_found",
+			serviceErr: attachment.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_attachment.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			if tc.serviceErr != nil {
+				service.EXPECT().Download(mock.Anything, u, noteID, a.ID).Return(nil, tc.serviceErr).Once()
+			} else {
+				service.EXPECT().Download(mock.Anything, u, noteID, a.ID).Return(&attachment.Download{
+					Attachment: a,
+					Content:    nopSeekCloser{strings.NewReader(content)},
+				}, nil).Once()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/notes/attachments", nil)
+			if tc.rangeHeader != "" {
+				r.Header.Set("Range", tc.rangeHeader)
+			}
+
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.AttachmentService = service
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewAttachmentHandler(deps).Download)).
+				ServeHTTP(w, testutil.AddUrlParams(r, map[string]string{
+					"id":           noteID.String(),
+					"attachmentID": a.ID.String(),
+				}))
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.serviceErr != nil {
+				return
+			}
+
+			require.Equal(t, tc.expected, w.Body.String())
+			require.Equal(t, a.ContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			require.Equal(t, "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.html",
+				w.Header().Get("Content-Disposition"))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// AuditHandler is responsible for handling HTTP requests related to the audit log inspection.
+type AuditHandler struct {
+	deps *app.Deps
+}
+
+// NewAuditHandler initializes and returns a new instance of AuditHandler with the provided dependencies.
+func NewAuditHandler(deps *app.Deps) *AuditHandler {
+	return &AuditHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for the audit log inspection.
+func (h *AuditHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.Search)
+	router.Get("/verify", h.Verify)
+	return router
+}
+
+// Search handler
+//
+//	@Summary		Search audit events
+//	@Description	Get audit events newest first, filtered by actor, action, target and time range
+//	@Tags			Audit
+//	@Produce		json
+//	@Param			actor_id	query		string	false	"Actor id"
+//	@Param			action		query		string	false	"Action, e.g. auth.login"
+//	@Param			target_type	query		string	false	"Target type, e.g. note"
+//	@Param			target_id	query		string	false	"Target id"
+//	@Param			from		query		string	false	"Lower bound of creation time (RFC3339)"
+//	@Param			to			query		string	false	"Upper bound of creation time, exclusive (RFC3339)"
+//	@Param			limit		query		int		false	"Limit"
+//	@Param			offset		query		int		false	"Offset"
+//	@Success		200			{object}	dto.AuditSearchResponse
+//	@Failure		400			{object}	httpio.ErrorResponse
+//	@Failure		401			{object}	httpio.ErrorResponse
+//	@Failure		403			{object}	httpio.ErrorResponse
+//	@Failure		500			{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/audit [get]
+func (h *AuditHandler) Search(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("search audit events handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	filter, err := parseAuditFilter(r.URL.Query())
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("search audit events handler parse query")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.AuditService.Search(r.Context(), user, filter)
+	if err != nil {
+		if errors.Is(err, audit.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("search audit events forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't search audit events")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.AuditSearchToResponseDto(res))
+}
+
+// Verify handler
+//
+//	@Summary		Verify audit log
+//	@Description	Check the hash chain of the audit log and report the first tampered event
+//	@Tags			Audit
+//	@Produce		json
+//	@Success		200	{object}	dto.AuditVerificationResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/audit/verify [get]
+func (h *AuditHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("verify audit events handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	res, err := h.deps.Service.AuditService.Verify(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, audit.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("verify audit events forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't verify audit events")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.AuditVerificationToResponseDto(res))
+}
+
+// parseAuditFilter builds the audit log filter from the query parameters, absent parameters are left zero.
+func parseAuditFilter(q url.Values) (*audit.Filter, error) {
+	f := &audit.Filter{
+		Action:     audit.Action(q.Get("action")),
+		TargetType: q.Get("target_type"),
+	}
+
+	var err error
+	if v := q.Get("actor_id"); v != "" {
+		if f.ActorID, err = uuid.Parse(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("target_id"); v != "" {
+		if f.TargetID, err = uuid.Parse(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("from"); v != "" {
+		if f.From, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("to"); v != "" {
+		if f.To, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if f.Offset, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
@@ -68,7 +68,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 // SignUp handler
 //
 //	@Summary		Sign up
-//	@Description	Register a new user
+//	@Description	Register a new user, the invitation code is required when the registration is invite-only
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
@@ -76,6 +76,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 //	@Success		201		{object}	dto.TokenResponse
 //	@Failure		400		{object}	httpio.ErrorResponse
 //	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
 //	@Failure		500		{object}	httpio.ErrorResponse
 //	@Router			/auth/signup [post]
 func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
@@ -91,9 +92,16 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	tokens, err := h.deps.Service.AuthService.SignUp(r.Context(), dtoadapter.SignUpRequestDtoToEntity(&request))
 	if err != nil {
 		middleware.Log(r).Debug().Err(err).Msg("signup handler")
-		if errors.Is(err, auth.ErrEmailAlreadyExists) {
+		switch {
+		case errors.Is(err, auth.ErrEmailAlreadyExists):
 			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeEmailExists, "Email already exists"))
-		} else {
+		case errors.Is(err, auth.ErrRegistrationClosed):
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeRegistrationClosed, "Registration is closed"))
+		case errors.Is(err, auth.ErrInviteCodeRequired):
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeInviteCodeRequired, "Invitation code is required"))
+		case errors.Is(err, auth.ErrInviteCodeInvalid):
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeInviteCodeInvalid, "Invitation code is invalid"))
+		default:
 			httpio.Error(w, http.StatusInternalServerError, err)
 		}
 		return
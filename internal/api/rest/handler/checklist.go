@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// ChecklistHandler is responsible for handling HTTP requests related to checklists of notes.
+type ChecklistHandler struct {
+	deps *app.Deps
+}
+
+// NewChecklistHandler initializes and returns a new instance of ChecklistHandler with the provided dependencies.
+func NewChecklistHandler(deps *app.Deps) *ChecklistHandler {
+	return &ChecklistHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for the checklist of the note.
+func (h *ChecklistHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/", h.Add)
+	router.Put("/order", h.Reorder)
+	router.Put("/{itemID}", h.Update)
+	router.Delete("/{itemID}", h.Delete)
+	router.Put("/{itemID}/done", h.Done)
+	router.Delete("/{itemID}/done", h.Undone)
+	return router
+}
+
+// List handler
+//
+//	@Summary		Get checklist
+//	@Description	Get the checklist items of the note in order with the completion percentage
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.ChecklistResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist [get]
+func (h *ChecklistHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "list checklist")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.ChecklistService.List(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "list checklist", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ChecklistToResponseDto(res))
+}
+
+// Add handler
+//
+//	@Summary		Add checklist item
+//	@Description	Append an item to the checklist of the note, the assignee must be able to read the note
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Note id"
+//	@Param			request	body		dto.ChecklistItemRequest	true	"Item"
+//	@Success		201		{object}	dto.ChecklistItemResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		409		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist [post]
+func (h *ChecklistHandler) Add(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "add checklist item")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.ChecklistItemRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("add checklist item handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.ChecklistService.Add(
+		r.Context(),
+		user,
+		dtoadapter.ChecklistItemRequestDtoToItemData(noteID, &request),
+	)
+	if err != nil {
+		h.error(w, r, "add checklist item", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusCreated, dtoadapter.ChecklistItemToResponseDto(res))
+}
+
+// Update handler
+//
+//	@Summary		Update checklist item
+//	@Description	Set the text, the assignee and the due date of the checklist item, omitted assignee and due date
+//	@Description	are cleared
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Note id"
+//	@Param			item_id	path		string						true	"Item id"
+//	@Param			request	body		dto.ChecklistItemRequest	true	"Item"
+//	@Success		200		{object}	dto.ChecklistItemResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist/{item_id} [put]
+func (h *ChecklistHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user, noteID, itemID, ok := h.userAndIDs(w, r, "update checklist item")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.ChecklistItemRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update checklist item handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.ChecklistService.Update(
+		r.Context(),
+		user,
+		itemID,
+		dtoadapter.ChecklistItemRequestDtoToItemData(noteID, &request),
+	)
+	if err != nil {
+		h.error(w, r, "update checklist item", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ChecklistItemToResponseDto(res))
+}
+
+// Done handler
+//
+//	@Summary		Complete checklist item
+//	@Description	Mark the checklist item done
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id		path		string	true	"Note id"
+//	@Param			item_id	path		string	true	"Item id"
+//	@Success		200		{object}	dto.ChecklistItemResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist/{item_id}/done [put]
+func (h *ChecklistHandler) Done(w http.ResponseWriter, r *http.Request) {
+	h.setDone(w, r, true, "complete checklist item")
+}
+
+// Undone handler
+//
+//	@Summary		Reopen checklist item
+//	@Description	Mark the checklist item not done
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id		path		string	true	"Note id"
+//	@Param			item_id	path		string	true	"Item id"
+//	@Success		200		{object}	dto.ChecklistItemResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist/{item_id}/done [delete]
+func (h *ChecklistHandler) Undone(w http.ResponseWriter, r *http.Request) {
+	h.setDone(w, r, false, "reopen checklist item")
+}
+
+// Reorder handler
+//
+//	@Summary		Reorder checklist
+//	@Description	Order the checklist items of the note as listed, the ids must list every item of the note once
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Note id"
+//	@Param			request	body		dto.ChecklistOrderRequest	true	"Order"
+//	@Success		200		{object}	dto.ChecklistResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist/order [put]
+func (h *ChecklistHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "reorder checklist")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.ChecklistOrderRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("reorder checklist handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.ChecklistService.Reorder(r.Context(), user, noteID, request.IDs)
+	if err != nil {
+		h.error(w, r, "reorder checklist", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ChecklistToResponseDto(res))
+}
+
+// Delete handler
+//
+//	@Summary		Delete checklist item
+//	@Description	Delete the item from the checklist of the note
+//	@Tags			Checklists
+//	@Produce		json
+//	@Param			id		path		string	true	"Note id"
+//	@Param			item_id	path		string	true	"Item id"
+//	@Success		200		{object}	dto.ChecklistItemResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/checklist/{item_id} [delete]
+func (h *ChecklistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user, noteID, itemID, ok := h.userAndIDs(w, r, "delete checklist item")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.ChecklistService.Delete(r.Context(), user, noteID, itemID)
+	if err != nil {
+		h.error(w, r, "delete checklist item", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ChecklistItemToResponseDto(res))
+}
+
+// setDone marks the checklist item of the request done or not done.
+func (h *ChecklistHandler) setDone(w http.ResponseWriter, r *http.Request, done bool, action string) {
+	user, noteID, itemID, ok := h.userAndIDs(w, r, action)
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.ChecklistService.SetDone(r.Context(), user, noteID, itemID, done)
+	if err != nil {
+		h.error(w, r, action, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ChecklistItemToResponseDto(res))
+}
+
+// userAndID extracts the authenticated user and the note id from the request, writing the error response on failure.
+func (h *ChecklistHandler) userAndID(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// userAndIDs extracts the authenticated user, the note id and the item id from the request, writing the error
+// response on failure.
+func (h *ChecklistHandler) userAndIDs(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+) (*user.User, uuid.UUID, uuid.UUID, bool) {
+	u, noteID, ok := h.userAndID(w, r, action)
+	if !ok {
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+
+	itemID, err := uuid.Parse(chi.URLParam(r, "itemID"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse item id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return u, noteID, itemID, true
+}
+
+// error writes the error response matching the checklist service error.
+func (h *ChecklistHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, note.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, note.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler note not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+	case errors.Is(err, checklist.ErrItemNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler item not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Checklist item is not found"))
+	case errors.Is(err, checklist.ErrInvalidAssignee):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler invalid assignee", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Assignee can't read the note"))
+	case errors.Is(err, checklist.ErrInvalidOrder):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler invalid order", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Order must list every item once"))
+	case errors.Is(err, checklist.ErrTooManyItems):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler too many items", action)
+		maxItems := strconv.Itoa(h.deps.Config.Checklist.MaxItems)
+		httpio.Error(w, http.StatusConflict, errx.NewOptional(
+			errx.CodeQuotaExceeded,
+			"Checklist limit of "+maxItems+" items is exceeded",
+			map[string]string{"max_items": maxItems},
+		))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
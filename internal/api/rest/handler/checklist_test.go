@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_checklist"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+	"github.com/xsqrty/op/driver"
+)
+
+type checklistDeps struct {
+	service *mock_checklist.Service
+	mw      *mock_middleware.JWTAuthentication
+}
+
+func TestChecklistHandler_Add(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	assigneeID := uuid.Must(uuid.NewV7())
+	dueAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	item := &checklist.Item{
+		ID:         uuid.Must(uuid.NewV7()),
+		NoteID:     noteID,
+		Position:   2,
+		Text:       "Book flights",
+		AssigneeID: uuid.NullUUID{UUID: assigneeID, Valid: true},
+		DueAt:      driver.ZeroTime(dueAt),
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	req := &dto.ChecklistItemRequest{Text: item.Text, AssigneeID: assigneeID, DueAt: dueAt}
+	data := &checklist.ItemData{NoteID: noteID, Text: item.Text, AssigneeID: item.AssigneeID, DueAt: dueAt}
+
+	cases := []testutil.HandlerCase[*dto.ChecklistItemRequest, *dto.ChecklistItemResponse, *checklistDeps]{
+		{
+			Name:       "successful_add",
+			ID:         noteID.String(),
+			Req:        req,
+			StatusCode: http.StatusCreated,
+			Expected:   dtoadapter.ChecklistItemToResponseDto(item),
+			Mocker: func(_ *dto.ChecklistItemRequest, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Add(mock.Anything, u, data).Return(item, nil).Once()
+			},
+		},
+		{
+			Name:       "empty_text",
+			ID:         noteID.String(),
+			Req:        &dto.ChecklistItemRequest{},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeValidation,
+				},
+			},
+			Mocker: func(_ *dto.ChecklistItemRequest, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "invalid_assignee",
+			ID:         noteID.String(),
+			Req:        req,
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ *dto.ChecklistItemRequest, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Add(mock.Anything, u, data).Return(nil, checklist.ErrInvalidAssignee).Once()
+			},
+		},
+		{
+			Name:       "too_many_items",
+			ID:         noteID.String(),
+			Req:        req,
+			StatusCode: http.StatusConflict,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeQuotaExceeded,
+				},
+			},
+			Mocker: func(_ *dto.ChecklistItemRequest, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Add(mock.Anything, u, data).Return(nil, checklist.ErrTooManyItems).Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         noteID.String(),
+			Req:        req,
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ *dto.ChecklistItemRequest, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Add(mock.Anything, u, data).Return(nil, note.ErrOperationForbiddenForUser).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_checklist.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPost, fmt.Sprintf("/api/v1/notes/%s/checklist", tc.ID), func() *checklistDeps {
+				return &checklistDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *checklistDeps) http.HandlerFunc {
+				return NewChecklistHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.ChecklistService = service
+				})).Add
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestChecklistHandler_Done(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	itemID := uuid.Must(uuid.NewV7())
+	item := &checklist.Item{
+		ID:        itemID,
+		NoteID:    noteID,
+		Text:      "Book flights",
+		Done:      true,
+		DoneAt:    driver.ZeroTime(time.Now().UTC().Truncate(time.Second)),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.ChecklistItemResponse, *checklistDeps]{
+		{
+			Name:       "successful_done",
+			ID:         noteID.String(),
+			Params:     map[string]string{"itemID": itemID.String()},
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.ChecklistItemToResponseDto(item),
+			Mocker: func(_ struct{}, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().SetDone(mock.Anything, u, noteID, itemID, true).Return(item, nil).Once()
+			},
+		},
+		{
+			Name:       "item_param_error",
+			ID:         noteID.String(),
+			Params:     map[string]string{"itemID": "1"},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "item_not_found",
+			ID:         noteID.String(),
+			Params:     map[string]string{"itemID": itemID.String()},
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *checklistDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().SetDone(mock.Anything, u, noteID, itemID, true).
+					Return(nil, checklist.ErrItemNotFound).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_checklist.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			url := fmt.Sprintf("/api/v1/notes/%s/checklist/%s/done", tc.ID, tc.Params["itemID"])
+			tc.Run(t, http.MethodPut, url, func() *checklistDeps {
+				return &checklistDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *checklistDeps) http.HandlerFunc {
+				return NewChecklistHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.ChecklistService = service
+				})).Done
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/export"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/pkg/pdf"
+)
+
+// ExportHandler is responsible for handling HTTP requests exporting notes.
+type ExportHandler struct {
+	deps *app.Deps
+}
+
+// NewExportHandler initializes and returns a new instance of ExportHandler with the provided dependencies.
+func NewExportHandler(deps *app.Deps) *ExportHandler {
+	return &ExportHandler{deps}
+}
+
+// PDF handler
+//
+//	@Summary		Export note to PDF
+//	@Description	Export the note to a PDF document with its name, timestamps and text
+//	@Tags			Export
+//	@Produce		application/pdf
+//	@Param			id			path		string	true	"Note id"
+//	@Param			page_size	query		string	false	"Page size: a4 (default), a5, letter, legal"
+//	@Success		200			{file}		file
+//	@Failure		400			{object}	httpio.ErrorResponse
+//	@Failure		401			{object}	httpio.ErrorResponse
+//	@Failure		403			{object}	httpio.ErrorResponse
+//	@Failure		404			{object}	httpio.ErrorResponse
+//	@Failure		500			{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/export.pdf [get]
+func (h *ExportHandler) PDF(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("export pdf handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("export pdf handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	pageSize := export.DefaultPageSize
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		pageSize, err = pdf.ParsePageSize(v)
+		if err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("export pdf handler parse page size")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown page size"))
+			return
+		}
+	}
+
+	var doc bytes.Buffer
+	file, err := h.deps.Service.ExportService.PDF(r.Context(), user, &doc, &export.PDFRequest{
+		IDs:      []uuid.UUID{id},
+		PageSize: pageSize,
+	})
+	if err != nil {
+		h.error(w, r, "export pdf", err)
+		return
+	}
+
+	h.writePDF(w, file, &doc)
+}
+
+// BulkPDF handler
+//
+//	@Summary		Export notes to PDF
+//	@Description	Export up to 100 notes to a single PDF document in the requested order, opening with a table of
+//	@Description	contents. Every note starts on a new page. The export fails if any of the notes may not be read.
+//	@Tags			Export
+//	@Accept			json
+//	@Produce		application/pdf
+//	@Param			request	body		dto.ExportPDFRequest	true	"Export request"
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/export.pdf [post]
+func (h *ExportHandler) BulkPDF(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("bulk export pdf handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.ExportPDFRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("bulk export pdf handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var doc bytes.Buffer
+	file, err := h.deps.Service.ExportService.PDF(
+		r.Context(),
+		user,
+		&doc,
+		dtoadapter.ExportPDFRequestDtoToRequest(&request),
+	)
+	if err != nil {
+		h.error(w, r, "bulk export pdf", err)
+		return
+	}
+
+	h.writePDF(w, file, &doc)
+}
+
+// Notes handler
+//
+//	@Summary		Export notes
+//	@Description	Export the personal notes matching the search filters, all of them without filters, in the order
+//	@Description	of creation. The markdown format is a zip archive of Markdown files named after the notes, with
+//	@Description	the names and the times of the notes in front matter. The json format is an array of notes and the
+//	@Description	ndjson format is a note per line. The files are streamed and can be imported again.
+//	@Tags			Export
+//	@Produce		application/zip,application/json,application/x-ndjson
+//	@Param			format	query		string	false	"Format: markdown (default), json, ndjson"
+//	@Param			filters	query		string	false	"Search filters as JSON, as of the note search"
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/export [get]
+func (h *ExportHandler) Notes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("export notes handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	req := &export.NotesRequest{Format: export.DefaultFormat}
+	if v := r.URL.Query().Get("format"); v != "" {
+		if req.Format, err = export.ParseFormat(v); err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("export notes handler parse format")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown format"))
+			return
+		}
+	}
+
+	if v := r.URL.Query().Get("filters"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Filters); err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("export notes handler parse filters")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad filters"))
+			return
+		}
+	}
+
+	stream, err := h.deps.Service.ExportService.Notes(r.Context(), user, req)
+	if err != nil {
+		h.error(w, r, "export notes", err)
+		return
+	}
+
+	h.writeHeader(w, stream.File)
+	if err := stream.Write(w); err != nil {
+		middleware.Log(r).Error().Err(err).Msg("couldn't write exported notes")
+	}
+}
+
+// writePDF writes the document as a download of the file.
+func (h *ExportHandler) writePDF(w http.ResponseWriter, file *export.File, doc *bytes.Buffer) {
+	w.Header().Set("Content-Length", strconv.Itoa(doc.Len()))
+	h.writeHeader(w, file)
+	doc.WriteTo(w) // nolint: errcheck, gosec
+}
+
+// writeHeader starts the response with the download of the file.
+func (h *ExportHandler) writeHeader(w http.ResponseWriter, file *export.File) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": file.Name,
+	}))
+	w.WriteHeader(http.StatusOK)
+}
+
+// error writes the error response matching the export service error.
+func (h *ExportHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, note.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, note.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler note not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+	case errors.Is(err, note.ErrSearchBadRequest), errors.Is(err, export.ErrUnknownFormat):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler bad request", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
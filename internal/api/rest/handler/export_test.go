@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/export"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_export"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/pkg/pdf"
+	"github.com/xsqrty/notes/tests/testutil"
+)
+
+func TestExportHandler_PDF(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+
+	cases := []struct {
+		name         string
+		pageSize     string
+		expectedSize pdf.PageSize
+		serviceErr   error
+		statusCode   int
+		expectedCode string
+	}{
+		{
+			name:         "successful_export",
+			expectedSize: pdf.A4,
+			statusCode:   http.StatusOK,
+		},
+		{
+			name:         "page_size",
+			pageSize:     "Letter",
+			expectedSize: pdf.Letter,
+			statusCode:   http.StatusOK,
+		},
+		{
+			name:         "unknown_page_size",
+			pageSize:     "a0",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "not_found",
+			expectedSize: pdf.A4,
+			serviceErr:   note.ErrNotFound,
+			statusCode:   http.StatusNotFound,
+			expectedCode: errx.CodeNotFound,
+		},
+		{
+			name:         "not_granted",
+			expectedSize: pdf.A4,
+			serviceErr:   note.ErrOperationForbiddenForUser,
+			statusCode:   http.StatusForbidden,
+			expectedCode: errx.CodeForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_export.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			if tc.expectedSize != "" {
+				req := &export.PDFRequest{IDs: []uuid.UUID{id}, PageSize: tc.expectedSize}
+				service.EXPECT().PDF(mock.Anything, u, mock.Anything, req).
+					RunAndReturn(func(
+						_ context.Context,
+						_ *user.User,
+						w io.Writer,
+						_ *export.PDFRequest,
+					) (*export.File, error) {
+						if tc.serviceErr != nil {
+							return nil, tc.serviceErr
+						}
+
+						_, err := w.Write([]byte("%PDF-1.3"))
+						return &export.File{Name: "Заметка.pdf", ContentType: "application/pdf"}, err
+					}).Once()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/notes/"+id.String()+"/export.pdf", nil)
+			if tc.pageSize != "" {
+				r.URL.RawQuery = "page_size=" + tc.pageSize
+			}
+
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.ExportService = service
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewExportHandler(deps).PDF)).
+				ServeHTTP(w, testutil.AddUrlParams(r, map[string]string{"id": id.String()}))
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expectedCode != "" {
+				var res httpio.ErrorResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+				require.Equal(t, tc.expectedCode, res.Error.Code)
+				return
+			}
+
+			require.Equal(t, "%PDF-1.3", w.Body.String())
+			require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+			require.Equal(t, "8", w.Header().Get("Content-Length"))
+			require.Equal(t, "attachment; filename*=utf-8''%D0%97%D0%B0%D0%BC%D0%B5%D1%82%D0%BA%D0%B0.pdf",
+				w.Header().Get("Content-Disposition"))
+		})
+	}
+}
+
+func TestExportHandler_Notes(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+
+	cases := []struct {
+		name         string
+		query        string
+		expected     *export.NotesRequest
+		serviceErr   error
+		statusCode   int
+		expectedCode string
+	}{
+		{
+			name:       "successful_export",
+			query:      `format=ndjson&filters={"name":"Plan"}`,
+			expected:   &export.NotesRequest{Format: export.FormatNDJSON, Filters: search.Filters{"name": "Plan"}},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "default_format",
+			expected:   &export.NotesRequest{Format: export.FormatMarkdown},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "unknown_format",
+			query:        "format=pdf",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "bad_filters",
+			query:        "filters=name",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "disallowed_filter",
+			query:        `filters={"text":"Plan"}`,
+			expected:     &export.NotesRequest{Format: export.FormatMarkdown, Filters: search.Filters{"text": "Plan"}},
+			serviceErr:   note.ErrSearchBadRequest,
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "not_granted",
+			expected:     &export.NotesRequest{Format: export.FormatMarkdown},
+			serviceErr:   note.ErrOperationForbiddenForUser,
+			statusCode:   http.StatusForbidden,
+			expectedCode: errx.CodeForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_export.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			switch {
+			case tc.serviceErr != nil:
+				service.EXPECT().Notes(mock.Anything, u, tc.expected).Return(nil, tc.serviceErr).Once()
+			case tc.expected != nil:
+				service.EXPECT().Notes(mock.Anything, u, tc.expected).Return(&export.Stream{
+					File: &export.File{Name: "notes.ndjson", ContentType: "application/x-ndjson"},
+					Write: func(w io.Writer) error {
+						_, err := io.WriteString(w, "{\"name\":\"Plan\"}\n")
+						return err
+					},
+				}, nil).Once()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/notes/export", nil)
+			r.URL.RawQuery = tc.query
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.ExportService = service
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewExportHandler(deps).Notes)).ServeHTTP(w, r)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expectedCode != "" {
+				var res httpio.ErrorResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+				require.Equal(t, tc.expectedCode, res.Error.Code)
+				return
+			}
+
+			require.Equal(t, "{\"name\":\"Plan\"}\n", w.Body.String())
+			require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+			require.Equal(t, "attachment; filename=notes.ndjson", w.Header().Get("Content-Disposition"))
+		})
+	}
+}
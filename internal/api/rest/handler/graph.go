@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// GraphHandler is responsible for handling HTTP requests related to the note graph.
+type GraphHandler struct {
+	deps *app.Deps
+}
+
+// NewGraphHandler initializes and returns a new instance of GraphHandler with the provided dependencies.
+func NewGraphHandler(deps *app.Deps) *GraphHandler {
+	return &GraphHandler{deps}
+}
+
+// Graph handler
+//
+//	@Summary		Get note graph
+//	@Description	Get the notes the user may read and their connections: links between notes and, with
+//	@Description	the similarity, notes sharing keywords. Without the note_id the latest notes are taken, with
+//	@Description	the note_id the notes up to the depth of connections from the note. Nodes and edges are limited,
+//	@Description	truncated is set when some were left out.
+//	@Tags			Notes
+//	@Produce		json
+//	@Param			note_id		query		string	false	"Note id of the neighbourhood"
+//	@Param			depth		query		int		false	"Depth of the neighbourhood, 1 by default"
+//	@Param			similarity	query		number	false	"Least similarity of similar notes, 0 to 1"
+//	@Param			limit		query		int		false	"Limit of nodes"
+//	@Success		200			{object}	dto.GraphResponse
+//	@Failure		400			{object}	httpio.ErrorResponse
+//	@Failure		401			{object}	httpio.ErrorResponse
+//	@Failure		403			{object}	httpio.ErrorResponse
+//	@Failure		404			{object}	httpio.ErrorResponse
+//	@Failure		500			{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/graph [get]
+func (h *GraphHandler) Graph(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("get note graph handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	req, err := parseGraphRequest(r.URL.Query())
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("get note graph handler parse query")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.GraphService.Build(r.Context(), user, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, graph.ErrInvalidRequest):
+			middleware.Log(r).Debug().Err(err).Msg("get note graph handler invalid request")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		case errors.Is(err, note.ErrOperationForbiddenForUser):
+			middleware.Log(r).Error().Err(err).Msg("get note graph forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+		case errors.Is(err, note.ErrNotFound):
+			middleware.Log(r).Debug().Err(err).Msg("get note graph handler note not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+		default:
+			middleware.Log(r).Error().Err(err).Msg("couldn't get note graph")
+			httpio.Error(w, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.GraphToResponseDto(res))
+}
+
+// parseGraphRequest parses the graph request from the query parameters.
+func parseGraphRequest(q url.Values) (*graph.Request, error) {
+	req := &graph.Request{}
+	if v := q.Get("note_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+
+		req.NoteID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	var err error
+	if v := q.Get("depth"); v != "" {
+		if req.Depth, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("similarity"); v != "" {
+		if req.Similarity, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
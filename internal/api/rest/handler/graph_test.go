@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_graph"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+)
+
+type graphDeps struct {
+	service *mock_graph.Service
+	mw      *mock_middleware.JWTAuthentication
+}
+
+func TestGraphHandler_Graph(t *testing.T) {
+	t.Parallel()
+
+	root := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Trip", CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	linked := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Budget", CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	g := &graph.Graph{
+		Nodes: []*note.Note{root, linked},
+		Edges: []*graph.Edge{{Source: root.ID, Target: linked.ID, Kind: graph.EdgeLink, Weight: 1}},
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	neighbourhood := &graph.Request{NoteID: uuid.NullUUID{UUID: root.ID, Valid: true}, Depth: 2, Similarity: 0.4}
+
+	cases := []struct {
+		testutil.HandlerCase[struct{}, *dto.GraphResponse, *graphDeps]
+		query string
+	}{
+		{
+			HandlerCase: testutil.HandlerCase[struct{}, *dto.GraphResponse, *graphDeps]{
+				Name:       "successful_graph",
+				StatusCode: http.StatusOK,
+				Expected:   dtoadapter.GraphToResponseDto(g),
+				Mocker: func(_ struct{}, d *graphDeps) {
+					d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+					d.service.EXPECT().Build(mock.Anything, u, &graph.Request{Limit: 20}).Return(g, nil).Once()
+				},
+			},
+			query: "?limit=20",
+		},
+		{
+			HandlerCase: testutil.HandlerCase[struct{}, *dto.GraphResponse, *graphDeps]{
+				Name:       "successful_neighbourhood",
+				StatusCode: http.StatusOK,
+				Expected:   dtoadapter.GraphToResponseDto(g),
+				Mocker: func(_ struct{}, d *graphDeps) {
+					d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+					d.service.EXPECT().Build(mock.Anything, u, neighbourhood).Return(g, nil).Once()
+				},
+			},
+			query: "?note_id=" + root.ID.String() + "&depth=2&similarity=0.4",
+		},
+		{
+			HandlerCase: testutil.HandlerCase[struct{}, *dto.GraphResponse, *graphDeps]{
+				Name:       "query_error",
+				StatusCode: http.StatusBadRequest,
+				ExpectedErr: &httpio.ErrorResponse{
+					Error: &errx.CodeError{
+						Code: errx.CodeBadRequest,
+					},
+				},
+				Mocker: func(_ struct{}, d *graphDeps) {
+					d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				},
+			},
+			query: "?note_id=1",
+		},
+		{
+			HandlerCase: testutil.HandlerCase[struct{}, *dto.GraphResponse, *graphDeps]{
+				Name:       "invalid_request",
+				StatusCode: http.StatusBadRequest,
+				ExpectedErr: &httpio.ErrorResponse{
+					Error: &errx.CodeError{
+						Code: errx.CodeBadRequest,
+					},
+				},
+				Mocker: func(_ struct{}, d *graphDeps) {
+					d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+					d.service.EXPECT().Build(mock.Anything, u, &graph.Request{Similarity: 2}).
+						Return(nil, graph.ErrInvalidRequest).Once()
+				},
+			},
+			query: "?similarity=2",
+		},
+		{
+			HandlerCase: testutil.HandlerCase[struct{}, *dto.GraphResponse, *graphDeps]{
+				Name:       "note_not_found",
+				StatusCode: http.StatusNotFound,
+				ExpectedErr: &httpio.ErrorResponse{
+					Error: &errx.CodeError{
+						Code: errx.CodeNotFound,
+					},
+				},
+				Mocker: func(_ struct{}, d *graphDeps) {
+					d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+					d.service.EXPECT().Build(mock.Anything, u, neighbourhood).Return(nil, note.ErrNotFound).Once()
+				},
+			},
+			query: "?note_id=" + root.ID.String() + "&depth=2&similarity=0.4",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_graph.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodGet, "/api/v1/notes/graph"+tc.query, func() *graphDeps {
+				return &graphDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *graphDeps) http.HandlerFunc {
+				return NewGraphHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.GraphService = service
+				})).Graph
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// InviteHandler is responsible for handling HTTP requests related to invitation codes administration.
+type InviteHandler struct {
+	deps *app.Deps
+}
+
+// NewInviteHandler initializes and returns a new instance of InviteHandler with the provided dependencies.
+func NewInviteHandler(deps *app.Deps) *InviteHandler {
+	return &InviteHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for invitation codes administration.
+func (h *InviteHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/", h.Create)
+	router.Delete("/{id}", h.Revoke)
+	return router
+}
+
+// List handler
+//
+//	@Summary		List invitation codes
+//	@Description	Get all invitation codes
+//	@Tags			Invitations
+//	@Produce		json
+//	@Success		200	{object}	dto.InviteCodeListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/invites [get]
+func (h *InviteHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list invitation codes handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	codes, err := h.deps.Service.InviteService.List(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, invite.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("list invitation codes forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't list invitation codes")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.InviteCodesToListResponseDto(codes))
+}
+
+// Create handler
+//
+//	@Summary		Create invitation code
+//	@Description	Mint new invitation code, optionally bound to email, limited by uses and expiration time
+//	@Tags			Invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.InviteCodeRequest	true	"Create invitation code request"
+//	@Success		201		{object}	dto.InviteCodeResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/invites [post]
+func (h *InviteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("create invitation code handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.InviteCodeRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("create invitation code handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.InviteService.Create(
+		r.Context(),
+		user,
+		dtoadapter.InviteCodeRequestDtoToCreateData(&request),
+	)
+	if err != nil {
+		if errors.Is(err, invite.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("create invitation code forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, invite.ErrUnknownRoleLabel) {
+			middleware.Log(r).Debug().Err(err).Msg("create invitation code unknown role label")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeUnknownRoleLabel, "Unknown role label"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't create invitation code")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusCreated, dtoadapter.InviteCodeToResponseDto(res))
+}
+
+// Revoke handler
+//
+//	@Summary		Revoke invitation code
+//	@Description	Delete invitation code by id
+//	@Tags			Invitations
+//	@Produce		json
+//	@Param			id	path		string	true	"Invitation code id"
+//	@Success		200	{object}	dto.InviteCodeResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/invites/{id} [delete]
+func (h *InviteHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("revoke invitation code handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("revoke invitation code handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.InviteService.Revoke(r.Context(), user, id)
+	if err != nil {
+		if errors.Is(err, invite.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("revoke invitation code forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, invite.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("revoke invitation code handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Invitation code is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't revoke invitation code")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.InviteCodeToResponseDto(res))
+}
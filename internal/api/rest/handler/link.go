@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// LinkHandler is responsible for handling HTTP requests related to links between notes.
+type LinkHandler struct {
+	deps *app.Deps
+}
+
+// NewLinkHandler initializes and returns a new instance of LinkHandler with the provided dependencies.
+func NewLinkHandler(deps *app.Deps) *LinkHandler {
+	return &LinkHandler{deps}
+}
+
+// Links handler
+//
+//	@Summary		Get note links
+//	@Description	Get the [[Title]] and [[id]] links written in the note resolved to the linked notes.
+//	@Description	Links to missing notes and to notes the user may not read are dangling.
+//	@Description	Links are kept shortly after the note is saved.
+//	@Tags			Links
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.LinkListResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/links [get]
+func (h *LinkHandler) Links(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "get note links")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.LinkService.Links(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "get note links", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.LinksToListResponseDto(res))
+}
+
+// Backlinks handler
+//
+//	@Summary		Get note backlinks
+//	@Description	Get the notes linking to the note, only the notes the user may read are listed
+//	@Tags			Links
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.BacklinkListResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/backlinks [get]
+func (h *LinkHandler) Backlinks(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "get note backlinks")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.LinkService.Backlinks(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "get note backlinks", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.BacklinksToListResponseDto(res))
+}
+
+// Dangling handler
+//
+//	@Summary		Get dangling links
+//	@Description	Get the links resolving to no note written in the notes the user may read
+//	@Tags			Links
+//	@Produce		json
+//	@Success		200	{object}	dto.DanglingLinkListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/links/dangling [get]
+func (h *LinkHandler) Dangling(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("get dangling links handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	res, err := h.deps.Service.LinkService.Dangling(r.Context(), user)
+	if err != nil {
+		h.error(w, r, "get dangling links", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.DanglingLinksToListResponseDto(res))
+}
+
+// userAndID extracts the authenticated user and the note id from the request, writing the error response on failure.
+func (h *LinkHandler) userAndID(w http.ResponseWriter, r *http.Request, action string) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// error writes the error response matching the link service error.
+func (h *LinkHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, note.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, note.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler note not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_link"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+)
+
+type linkDeps struct {
+	service *mock_link.Service
+	mw      *mock_middleware.JWTAuthentication
+}
+
+func TestLinkHandler_Backlinks(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	backlinks := []*note.Note{
+		{ID: uuid.Must(uuid.NewV7()), Name: "Plans"},
+		{ID: uuid.Must(uuid.NewV7()), Name: "Budget"},
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.BacklinkListResponse, *linkDeps]{
+		{
+			Name:       "successful_backlinks",
+			ID:         noteID.String(),
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.BacklinksToListResponseDto(backlinks),
+			Mocker: func(_ struct{}, d *linkDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Backlinks(mock.Anything, u, noteID).Return(backlinks, nil).Once()
+			},
+		},
+		{
+			Name:       "id_param_error",
+			ID:         "1",
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *linkDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "not_found",
+			ID:         noteID.String(),
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *linkDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Backlinks(mock.Anything, u, noteID).Return(nil, note.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         noteID.String(),
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ struct{}, d *linkDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Backlinks(mock.Anything, u, noteID).
+					Return(nil, note.ErrOperationForbiddenForUser).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_link.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodGet, fmt.Sprintf("/api/v1/notes/%s/backlinks", tc.ID), func() *linkDeps {
+				return &linkDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *linkDeps) http.HandlerFunc {
+				return NewLinkHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.LinkService = service
+				})).Backlinks
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
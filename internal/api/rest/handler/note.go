@@ -1,21 +1,51 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
 	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/collab"
 	"github.com/xsqrty/notes/internal/domain/note"
 	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/stream"
 	"github.com/xsqrty/notes/internal/dto"
 	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/crdt"
 	"github.com/xsqrty/notes/pkg/httputil/httpio"
 	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/pkg/jsonpatch"
 )
 
+const (
+	// mergePatchType is the media type of JSON Merge Patches.
+	mergePatchType = "application/merge-patch+json"
+	// jsonPatchType is the media type of JSON Patches.
+	jsonPatchType = "application/json-patch+json"
+)
+
+// notePatchers apply the patches of notes by the media type.
+var notePatchers = map[string]func(doc, patch []byte) ([]byte, error){
+	mergePatchType: jsonpatch.MergePatch,
+	jsonPatchType:  jsonpatch.Apply,
+}
+
+// errPatchedNote is returned for patches resulting in invalid notes.
+var errPatchedNote = errors.New("patched note is invalid")
+
 // NoteHandler is responsible for handling HTTP requests related to notes.
 type NoteHandler struct {
 	deps *app.Deps
@@ -28,12 +58,32 @@ func NewNoteHandler(deps *app.Deps) *NoteHandler {
 
 // Routes initialize and return a new chi.Mux router with configured routes for note handling operations.
 func (h *NoteHandler) Routes() *chi.Mux {
+	exports := NewExportHandler(h.deps)
+	links := NewLinkHandler(h.deps)
 	router := chi.NewRouter()
 	router.Post("/", h.Create)
 	router.Post("/search", h.Search)
+	router.Post("/batch", h.Batch)
+	router.Get("/events", h.Events)
 	router.Get("/{id}", h.Get)
+	router.Get("/{id}/render", h.Render)
+	router.Get("/{id}/collab", h.Collab)
 	router.Put("/{id}", h.Update)
+	router.Patch("/{id}", h.Patch)
+	router.Put("/{id}/flags/{flag}", h.SetFlag)
+	router.Delete("/{id}/flags/{flag}", h.ClearFlag)
 	router.Delete("/{id}", h.Delete)
+	router.Get("/export", exports.Notes)
+	router.Post("/export.pdf", exports.BulkPDF)
+	router.Get("/{id}/export.pdf", exports.PDF)
+	router.Get("/links/dangling", links.Dangling)
+	router.Get("/graph", NewGraphHandler(h.deps).Graph)
+	router.Get("/{id}/links", links.Links)
+	router.Get("/{id}/backlinks", links.Backlinks)
+	router.Mount("/import", NewNoteImportHandler(h.deps).Routes())
+	router.Mount("/{id}/attachments", NewAttachmentHandler(h.deps).Routes())
+	router.Mount("/{id}/reminder", NewReminderHandler(h.deps).Routes())
+	router.Mount("/{id}/checklist", NewChecklistHandler(h.deps).Routes())
 	return router
 }
 
@@ -88,6 +138,58 @@ func (h *NoteHandler) Get(w http.ResponseWriter, r *http.Request) { // nolint: d
 	httpio.Json(w, http.StatusOK, dtoadapter.NoteToResponseDto(n))
 }
 
+// Render handler
+//
+//	@Summary		Render note
+//	@Description	Get the text of the note rendered to sanitized HTML according to its format
+//	@Tags			Notes
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.NoteRenderResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/render [get]
+func (h *NoteHandler) Render(w http.ResponseWriter, r *http.Request) { // nolint: dupl
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("render note handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("render note handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	rendered, err := h.deps.Service.NoteService.Render(r.Context(), user, id)
+	if err != nil {
+		if errors.Is(err, note.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("render note forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, note.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("render note handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't render note")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NoteRenderedToResponseDto(rendered))
+}
+
 // Create handler
 //
 //	@Summary		Create note
@@ -137,16 +239,19 @@ func (h *NoteHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 // Update handler
 //
-//	@Summary		Create note
-//	@Description	Create new note
+//	@Summary		Update note
+//	@Description	Update note by id, changes made to an outdated base_version are merged with the current note
 //	@Tags			Notes
 //	@Accept			json
 //	@Produce		json
 //	@Param			id		path		string			true	"Note id"
-//	@Param			request	body		dto.NoteRequest	true	"Create note request"
+//	@Param			request	body		dto.NoteRequest	true	"Update note request"
 //	@Success		200		{object}	dto.NoteResponse
 //	@Failure		400		{object}	httpio.ErrorResponse
 //	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		409		{object}	dto.NoteConflictResponse
 //	@Failure		500		{object}	httpio.ErrorResponse
 //	@Security		AccessTokenAuth
 //	@Router			/notes/{id} [put]
@@ -188,6 +293,19 @@ func (h *NoteHandler) Update(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		var conflictErr *note.MergeConflictError
+		if errors.As(err, &conflictErr) {
+			middleware.Log(r).Debug().Err(err).Msg("update note handler merge conflict")
+			httpio.Json(w, http.StatusConflict, dtoadapter.NoteMergeConflictToResponseDto(conflictErr))
+			return
+		}
+
+		if errors.Is(err, note.ErrVersionConflict) {
+			middleware.Log(r).Debug().Err(err).Msg("update note handler version conflict")
+			httpio.Error(w, http.StatusConflict, errx.New(errx.CodeConflict, "Note was changed concurrently"))
+			return
+		}
+
 		middleware.Log(r).Error().Err(err).Msg("couldn't update note")
 		httpio.Error(w, http.StatusInternalServerError, err)
 		return
@@ -196,6 +314,179 @@ func (h *NoteHandler) Update(w http.ResponseWriter, r *http.Request) {
 	httpio.Json(w, http.StatusOK, dtoadapter.NoteToResponseDto(n))
 }
 
+// Patch handler
+//
+//	@Summary		Patch note
+//	@Description	Partially update note by id with a JSON Merge Patch (application/merge-patch+json) or a JSON
+//	@Description	Patch (application/json-patch+json) of the note document with the name, text, format and flags.
+//	@Description	The patch is applied to the current note, the patched document is validated as on update and only
+//	@Description	the changed fields are written. Failed test operations of JSON Patches are conflicts.
+//	@Tags			Notes
+//	@Accept			application/merge-patch+json,application/json-patch+json
+//	@Produce		json
+//	@Param			id		path		string					true	"Note id"
+//	@Param			request	body		dto.NotePatchDocument	true	"Merge patch or JSON Patch"
+//	@Success		200		{object}	dto.NoteResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		409		{object}	httpio.ErrorResponse
+//	@Failure		415		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id} [patch]
+func (h *NoteHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("patch note handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("patch note handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	apply, ok := notePatchers[mediaType]
+	if err != nil || !ok {
+		middleware.Log(r).Debug().Str("content_type", mediaType).Msg("patch note handler unsupported media type")
+		w.Header().Set("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		httpio.Error(
+			w,
+			http.StatusUnsupportedMediaType,
+			errx.New(errx.CodeUnsupportedMedia, "Unsupported patch type"),
+		)
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("patch note handler read patch")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	n, err := h.deps.Service.NoteService.Patch(r.Context(), user, id, func(n *note.Note) (*note.PatchData, error) {
+		return patchNote(n, patch, apply)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, note.ErrOperationForbiddenForUser):
+			middleware.Log(r).Error().Err(err).Msg("patch note forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+		case errors.Is(err, note.ErrNotFound):
+			middleware.Log(r).Debug().Err(err).Msg("patch note handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+		case errors.Is(err, jsonpatch.ErrTestFailed):
+			middleware.Log(r).Debug().Err(err).Msg("patch note handler test failed")
+			httpio.Error(w, http.StatusConflict, errx.New(errx.CodeConflict, "Patch test failed"))
+		case errors.Is(err, note.ErrVersionConflict):
+			middleware.Log(r).Debug().Err(err).Msg("patch note handler version conflict")
+			httpio.Error(w, http.StatusConflict, errx.New(errx.CodeConflict, "Note was changed concurrently"))
+		case errors.Is(err, jsonpatch.ErrInvalidPatch):
+			middleware.Log(r).Debug().Err(err).Msg("patch note handler invalid patch")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Invalid patch"))
+		case errors.Is(err, errPatchedNote):
+			middleware.Log(r).Debug().Err(err).Msg("patch note handler invalid note")
+			httpio.Error(w, http.StatusBadRequest, err)
+		default:
+			middleware.Log(r).Error().Err(err).Msg("couldn't patch note")
+			httpio.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NoteToResponseDto(n))
+}
+
+// SetFlag handler
+//
+//	@Summary		Set note flag
+//	@Description	Set the pinned, archived or favourite flag of the note by id. Pinned notes are listed first and
+//	@Description	archived notes are left out by searches unless filtered by archived. The update time is kept.
+//	@Tags			Notes
+//	@Produce		json
+//	@Param			id		path		string	true	"Note id"
+//	@Param			flag	path		string	true	"Flag: pinned, archived, favourite"
+//	@Success		200		{object}	dto.NoteResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/flags/{flag} [put]
+func (h *NoteHandler) SetFlag(w http.ResponseWriter, r *http.Request) {
+	h.setFlag(w, r, "set note flag", true)
+}
+
+// ClearFlag handler
+//
+//	@Summary		Clear note flag
+//	@Description	Clear the pinned, archived or favourite flag of the note by id. The update time is kept.
+//	@Tags			Notes
+//	@Produce		json
+//	@Param			id		path		string	true	"Note id"
+//	@Param			flag	path		string	true	"Flag: pinned, archived, favourite"
+//	@Success		200		{object}	dto.NoteResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/flags/{flag} [delete]
+func (h *NoteHandler) ClearFlag(w http.ResponseWriter, r *http.Request) {
+	h.setFlag(w, r, "clear note flag", false)
+}
+
+// setFlag sets the flag of the note named in the path to the value.
+func (h *NoteHandler) setFlag(w http.ResponseWriter, r *http.Request, action string, value bool) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	flag, err := note.ParseFlag(chi.URLParam(r, "flag"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse flag", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown flag"))
+		return
+	}
+
+	n, err := h.deps.Service.NoteService.SetFlag(r.Context(), user, id, flag, value)
+	if err != nil {
+		switch {
+		case errors.Is(err, note.ErrOperationForbiddenForUser):
+			middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+		case errors.Is(err, note.ErrNotFound):
+			middleware.Log(r).Debug().Err(err).Msgf("%s handler not found", action)
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+		default:
+			middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+			httpio.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NoteToResponseDto(n))
+}
+
 // Delete handler
 //
 //	@Summary		Delete note
@@ -247,6 +538,68 @@ func (h *NoteHandler) Delete(w http.ResponseWriter, r *http.Request) { // nolint
 	httpio.Json(w, http.StatusOK, dtoadapter.NoteToResponseDto(n))
 }
 
+// Batch handler
+//
+//	@Summary		Batch note operations
+//	@Description	Apply create, update and delete operations at once, up to BATCH_MAX_OPERATIONS of them. Every
+//	@Description	operation is checked before any is applied. Atomic batches, the default, apply all the
+//	@Description	operations in a single transaction or none of them: operations not applied because another one
+//	@Description	failed have status 424. Best-effort batches apply every operation on its own. Results are in the
+//	@Description	order of the operations, with the status code and the error the operation would have on its own.
+//	@Tags			Notes
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.NoteBatchRequest	true	"Batch request"
+//	@Success		200		{object}	dto.NoteBatchResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		413		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/batch [post]
+func (h *NoteHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("batch notes handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.NoteBatchRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Batch.LimitReq)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("batch notes handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if maxOps := h.deps.Config.Batch.MaxOperations; len(request.Operations) > maxOps {
+		middleware.Log(r).Debug().Int("operations", len(request.Operations)).Msg("batch notes handler too many ops")
+		httpio.Error(w, http.StatusBadRequest, errx.NewOptional(
+			errx.CodeBadRequest,
+			"Too many operations",
+			map[string]string{"max_operations": strconv.Itoa(maxOps)},
+		))
+		return
+	}
+
+	req := dtoadapter.NoteBatchRequestDtoToRequest(&request)
+	results, err := h.deps.Service.NoteService.Batch(r.Context(), user, req)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("couldn't batch notes")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := &dto.NoteBatchResponse{Results: make([]*dto.NoteBatchResultResponse, len(results))}
+	for i, result := range results {
+		res.Results[i] = h.batchResult(r, req.Ops[i], result)
+	}
+
+	httpio.Json(w, http.StatusOK, res)
+}
+
 // Search handler
 //
 //	@Summary		Search notes
@@ -299,3 +652,282 @@ func (h *NoteHandler) Search(w http.ResponseWriter, r *http.Request) {
 
 	httpio.Json(w, http.StatusOK, dtoadapter.NoteSearchToResponseDto(res))
 }
+
+// Events handler
+//
+//	@Summary		Stream note changes
+//	@Description	Stream created, updated and deleted notes readable by the user as server-sent events.
+//	@Description	Events carry the note without the text. Pass the Last-Event-ID header to resume after
+//	@Description	reconnecting, the "reset" event reports that the missed events are no longer available.
+//	@Tags			Notes
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		string	false	"Id of the last received event"
+//	@Success		200				{object}	dto.NoteEventResponse
+//	@Failure		400				{object}	httpio.ErrorResponse
+//	@Failure		401				{object}	httpio.ErrorResponse
+//	@Failure		500				{object}	httpio.ErrorResponse
+//	@Failure		503				{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/events [get]
+func (h *NoteHandler) Events(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("note events handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	var lastEventID uuid.NullUUID
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		lastEventID.UUID, err = uuid.Parse(v)
+		if err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("note events handler parse last event id")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+			return
+		}
+
+		lastEventID.Valid = true
+	}
+
+	sub, err := h.deps.Service.StreamService.Subscribe(r.Context(), user, lastEventID)
+	if err != nil {
+		if errors.Is(err, stream.ErrClosed) {
+			middleware.Log(r).Debug().Err(err).Msg("note events handler stream closed")
+			httpio.Error(w, http.StatusServiceUnavailable, errx.New(errx.CodeUnavailable, "Service unavailable"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't subscribe to note events")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer sub.Close()
+
+	if err := httpio.Stream(w); err != nil {
+		middleware.Log(r).Error().Err(err).Msg("note events handler start stream")
+		return
+	}
+
+	if sub.Missed() {
+		if err := httpio.Event(w, "", "reset", struct{}{}); err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("note events handler write reset")
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.deps.Config.Stream.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			err = httpio.Comment(w, "heartbeat")
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			err = httpio.Event(w, e.ID.String(), string(e.Type), dtoadapter.NoteEventToResponseDto(e))
+		}
+
+		if err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("note events handler write event")
+			return
+		}
+	}
+}
+
+// Collab handler
+//
+//	@Summary		Edit note collaboratively
+//	@Description	Upgrade to a WebSocket exchanging JSON messages to edit the note together with other users.
+//	@Description	The first "init" message carries the document elements, the site to insert elements with and
+//	@Description	the peers. Clients send "update" messages with CRDT operations and "presence" messages with
+//	@Description	their cursor, and receive "update", "presence", "leave" and "error" messages. Users who may only
+//	@Description	read the note join as viewers.
+//	@Tags			Notes
+//	@Param			id	path	string	true	"Note id"
+//	@Success		101
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Failure		503	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/collab [get]
+func (h *NoteHandler) Collab(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("note collab handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("note collab handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	sess, err := h.deps.Service.CollabService.Join(r.Context(), user, id)
+	if err != nil {
+		if errors.Is(err, note.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("note collab forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, note.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("note collab not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note not found"))
+			return
+		}
+
+		if errors.Is(err, collab.ErrClosed) {
+			middleware.Log(r).Debug().Err(err).Msg("note collab closed")
+			httpio.Error(w, http.StatusServiceUnavailable, errx.New(errx.CodeUnavailable, "Service unavailable"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't join note collab")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer sess.Close()
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: originPatterns(h.deps.Config.Cors.AllowedOrigins),
+	})
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("note collab handler accept")
+		return
+	}
+	defer conn.CloseNow() // nolint: errcheck
+
+	conn.SetReadLimit(int64(h.deps.Config.Server.LimitReqJson))
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	go func() {
+		defer cancel()
+		h.readCollab(ctx, r, conn, sess)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sess.Messages():
+			if !ok {
+				// the session fell behind or the service stopped, the client joins again
+				conn.Close(websocket.StatusTryAgainLater, "session ended") // nolint: errcheck
+				return
+			}
+
+			if err := wsjson.Write(ctx, conn, msg); err != nil {
+				middleware.Log(r).Debug().Err(err).Msg("note collab handler write message")
+				return
+			}
+		}
+	}
+}
+
+// readCollab passes the messages of the client to the session until the connection fails.
+// Rejected messages are reported to the client with an error message.
+func (h *NoteHandler) readCollab(ctx context.Context, r *http.Request, conn *websocket.Conn, sess collab.Session) {
+	for {
+		var msg collab.ClientMessage
+		if err := wsjson.Read(ctx, conn, &msg); err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("note collab handler read message")
+			return
+		}
+
+		err := sess.Handle(ctx, &msg)
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, collab.ErrReadOnly) && !errors.Is(err, collab.ErrInvalidMessage) &&
+			!errors.Is(err, collab.ErrTextTooLong) && !errors.Is(err, crdt.ErrInvalidOp) {
+			middleware.Log(r).Error().Err(err).Msg("couldn't handle note collab message")
+			return
+		}
+
+		middleware.Log(r).Debug().Err(err).Msg("note collab handler rejected message")
+		reply := &collab.ServerMessage{Type: collab.MessageError, Error: err.Error()}
+		if err := wsjson.Write(ctx, conn, reply); err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("note collab handler write error")
+			return
+		}
+	}
+}
+
+// batchResult returns the outcome of the batch operation with the status code and the error the operation
+// would have on its own.
+func (h *NoteHandler) batchResult(
+	r *http.Request,
+	o *note.BatchOp,
+	result *note.BatchResult,
+) *dto.NoteBatchResultResponse {
+	if result.Err == nil {
+		status := http.StatusOK
+		if o.Kind == note.BatchCreate {
+			status = http.StatusCreated
+		}
+
+		return &dto.NoteBatchResultResponse{Status: status, Note: dtoadapter.NoteToResponseDto(result.Note)}
+	}
+
+	status, codeErr := http.StatusInternalServerError, errx.NewUnknown(result.Err.Error())
+	switch {
+	case errors.Is(result.Err, note.ErrNotFound):
+		status, codeErr = http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found")
+	case errors.Is(result.Err, note.ErrOperationForbiddenForUser):
+		status, codeErr = http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed")
+	case errors.Is(result.Err, note.ErrBatchDuplicate):
+		status, codeErr = http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Note is changed by another operation")
+	case errors.Is(result.Err, note.ErrMergeConflict), errors.Is(result.Err, note.ErrVersionConflict):
+		status, codeErr = http.StatusConflict, errx.New(errx.CodeConflict, "Note was changed concurrently")
+	case errors.Is(result.Err, note.ErrBatchAborted):
+		status, codeErr = http.StatusFailedDependency, errx.New(errx.CodeAborted, "Batch is aborted")
+	default:
+		middleware.Log(r).Error().Err(result.Err).Msgf("couldn't %s note in batch", o.Kind)
+	}
+
+	return &dto.NoteBatchResultResponse{Status: status, Error: codeErr}
+}
+
+// patchNote applies the patch to the note document and returns the fields of the patched document.
+func patchNote(n *note.Note, patch []byte, apply func(doc, patch []byte) ([]byte, error)) (*note.PatchData, error) {
+	doc, err := json.Marshal(dtoadapter.NoteToPatchDocumentDto(n))
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := apply(doc, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := httpio.Parse[dto.NotePatchDocument](io.NopCloser(bytes.NewReader(patched)))
+	if err != nil {
+		return nil, errors.Join(errPatchedNote, err)
+	}
+
+	return dtoadapter.NotePatchDocumentDtoToPatchData(&res), nil
+}
+
+// originPatterns returns the host patterns of the allowed CORS origins.
+func originPatterns(origins []string) []string {
+	patterns := make([]string, len(origins))
+	for i, origin := range origins {
+		patterns[i] = strings.TrimPrefix(strings.TrimPrefix(origin, "https://"), "http://")
+	}
+
+	return patterns
+}
@@ -1,23 +1,31 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
 	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/config"
 	"github.com/xsqrty/notes/internal/domain/note"
 	"github.com/xsqrty/notes/internal/domain/search"
 	"github.com/xsqrty/notes/internal/domain/user"
 	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
 	"github.com/xsqrty/notes/mocks/app/mock_app"
 	"github.com/xsqrty/notes/mocks/domain/mock_note"
 	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/config/size"
 	"github.com/xsqrty/notes/pkg/httputil/httpio"
 	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
 	"github.com/xsqrty/notes/tests/testutil"
@@ -150,6 +158,88 @@ func TestNoteHandler_Get(t *testing.T) { // nolint: dupl
 	}
 }
 
+func TestNoteHandler_Render(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+	rendered := &note.Rendered{
+		NoteID:    id,
+		Version:   3,
+		Format:    note.FormatMarkdown,
+		HTML:      "<p><strong>bold</strong></p>\n",
+		PlainText: "bold",
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.NoteRenderResponse, *noteDeps]{
+		{
+			Name:       "successful_render",
+			ID:         id.String(),
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.NoteRenderedToResponseDto(rendered),
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Render(mock.Anything, u, id).Return(rendered, nil).Once()
+			},
+		},
+		{
+			Name:       "note_not_found",
+			ID:         id.String(),
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Render(mock.Anything, u, id).Return(nil, note.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         id.String(),
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Render(mock.Anything, u, id).Return(nil, note.ErrOperationForbiddenForUser).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_note.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodGet, fmt.Sprintf("/api/v1/notes/%s/render", tc.ID), func() *noteDeps {
+				return &noteDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *noteDeps) http.HandlerFunc {
+				return NewNoteHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.NoteService = service
+				})).Render
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
 func TestNoteHandler_Create(t *testing.T) {
 	t.Parallel()
 
@@ -378,6 +468,52 @@ func TestNoteHandler_Update(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name:       "version_conflict",
+			ID:         id.String(),
+			StatusCode: http.StatusConflict,
+			Req: &dto.NoteRequest{
+				Name: name,
+				Text: text,
+			},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeConflict,
+				},
+			},
+			Mocker: func(req *dto.NoteRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Update(mock.Anything, u, dtoadapter.NoteRequestDtoToUpdateData(id, req)).
+					Return(nil, note.ErrVersionConflict).
+					Once()
+			},
+		},
+		{
+			Name:       "merge_conflict",
+			ID:         id.String(),
+			StatusCode: http.StatusConflict,
+			Req: &dto.NoteRequest{
+				Name:        name,
+				Text:        text,
+				BaseVersion: 1,
+			},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeConflict,
+				},
+			},
+			Mocker: func(req *dto.NoteRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Update(mock.Anything, u, dtoadapter.NoteRequestDtoToUpdateData(id, req)).
+					Return(nil, &note.MergeConflictError{
+						Note:      n,
+						Conflicts: []note.Conflict{{Field: note.FieldText, Base: "a", Client: "b", Server: "c"}},
+					}).
+					Once()
+			},
+		},
 		{
 			Name:       "unknown_error",
 			ID:         id.String(),
@@ -442,6 +578,150 @@ func TestNoteHandler_Update(t *testing.T) {
 	}
 }
 
+func TestNoteHandler_Patch(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	n := &note.Note{
+		ID:     uuid.Must(uuid.NewV7()),
+		Name:   "Weekly plan",
+		Text:   "Call the bank",
+		Format: note.FormatPlain,
+		UserId: u.ID,
+	}
+
+	cases := []struct {
+		name           string
+		contentType    string
+		patch          string
+		serviceErr     error
+		statusCode     int
+		expectedCode   string
+		expectedName   string
+		expectedFormat string
+	}{
+		{
+			name:           "merge_patch",
+			contentType:    "application/merge-patch+json",
+			patch:          `{"name": "Monthly plan"}`,
+			statusCode:     http.StatusOK,
+			expectedName:   "Monthly plan",
+			expectedFormat: "plain",
+		},
+		{
+			name:        "json_patch",
+			contentType: "application/json-patch+json; charset=utf-8",
+			patch: `[
+				{"op": "test", "path": "/name", "value": "Weekly plan"},
+				{"op": "replace", "path": "/format", "value": "markdown"}
+			]`,
+			statusCode:     http.StatusOK,
+			expectedName:   "Weekly plan",
+			expectedFormat: "markdown",
+		},
+		{
+			name:         "test_failed",
+			contentType:  "application/json-patch+json",
+			patch:        `[{"op": "test", "path": "/name", "value": "Daily plan"}]`,
+			statusCode:   http.StatusConflict,
+			expectedCode: errx.CodeConflict,
+		},
+		{
+			name:         "invalid_patch",
+			contentType:  "application/json-patch+json",
+			patch:        `[{"op": "replace", "path": "/tags/0", "value": "home"}]`,
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "invalid_note",
+			contentType:  "application/merge-patch+json",
+			patch:        `{"name": "Plan", "text": null}`,
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeValidation,
+		},
+		{
+			name:         "unsupported_media_type",
+			contentType:  "application/json",
+			patch:        `{"name": "Monthly plan"}`,
+			statusCode:   http.StatusUnsupportedMediaType,
+			expectedCode: errx.CodeUnsupportedMedia,
+		},
+		{
+			name:         "note_not_found",
+			contentType:  "application/merge-patch+json",
+			patch:        `{"name": "Monthly plan"}`,
+			serviceErr:   note.ErrNotFound,
+			statusCode:   http.StatusNotFound,
+			expectedCode: errx.CodeNotFound,
+		},
+		{
+			name:         "version_conflict",
+			contentType:  "application/merge-patch+json",
+			patch:        `{"name": "Monthly plan"}`,
+			serviceErr:   note.ErrVersionConflict,
+			statusCode:   http.StatusConflict,
+			expectedCode: errx.CodeConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_note.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			if tc.statusCode != http.StatusUnsupportedMediaType {
+				service.EXPECT().Patch(mock.Anything, u, n.ID, mock.Anything).
+					RunAndReturn(func(
+						_ context.Context,
+						_ *user.User,
+						_ uuid.UUID,
+						patcher note.Patcher,
+					) (*note.Note, error) {
+						if tc.serviceErr != nil {
+							return nil, tc.serviceErr
+						}
+
+						patched := *n
+						data, err := patcher(&patched)
+						if err != nil {
+							return nil, err
+						}
+
+						patched.Name, patched.Text, patched.Format = *data.Name, *data.Text, *data.Format
+						return &patched, nil
+					}).Once()
+			}
+
+			r := httptest.NewRequest(http.MethodPatch, "/api/v1/notes/"+n.ID.String(), strings.NewReader(tc.patch))
+			r.Header.Set("Content-Type", tc.contentType)
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.NoteService = service
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewNoteHandler(deps).Patch)).
+				ServeHTTP(w, testutil.AddUrlParams(r, map[string]string{"id": n.ID.String()}))
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expectedCode == "" {
+				var res dto.NoteResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+				require.Equal(t, tc.expectedName, res.Name)
+				require.Equal(t, n.Text, res.Text)
+				require.Equal(t, tc.expectedFormat, res.Format)
+				return
+			}
+
+			var res httpio.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			require.Equal(t, tc.expectedCode, res.Error.Code)
+		})
+	}
+}
+
 func TestNoteHandler_Delete(t *testing.T) { // nolint: dupl
 	t.Parallel()
 
@@ -567,6 +847,250 @@ func TestNoteHandler_Delete(t *testing.T) { // nolint: dupl
 	}
 }
 
+func TestNoteHandler_SetFlag(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+	n := &note.Note{
+		ID:     id,
+		Name:   gofakeit.Name(),
+		Text:   gofakeit.Sentence(6),
+		Pinned: true,
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	pinned := map[string]string{"flag": "pinned"}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.NoteResponse, *noteDeps]{
+		{
+			Name:       "successful_set",
+			ID:         id.String(),
+			Params:     pinned,
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.NoteToResponseDto(n),
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().SetFlag(mock.Anything, u, id, note.FieldPinned, true).Return(n, nil).Once()
+			},
+		},
+		{
+			Name:       "user_unauthorized",
+			ID:         id.String(),
+			Params:     pinned,
+			StatusCode: http.StatusUnauthorized,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnauthorized,
+				},
+			},
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(nil, errors.New("no user")).Once()
+			},
+		},
+		{
+			Name:       "unknown_flag",
+			ID:         id.String(),
+			Params:     map[string]string{"flag": "hidden"},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "note_not_found",
+			ID:         id.String(),
+			Params:     pinned,
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					SetFlag(mock.Anything, u, id, note.FieldPinned, true).
+					Return(nil, note.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         id.String(),
+			Params:     pinned,
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ struct{}, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					SetFlag(mock.Anything, u, id, note.FieldPinned, true).
+					Return(nil, note.ErrOperationForbiddenForUser).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_note.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPut, fmt.Sprintf("/api/v1/notes/%s/flags/pinned", tc.ID), func() *noteDeps {
+				return &noteDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *noteDeps) http.HandlerFunc {
+				return NewNoteHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.NoteService = service
+				})).SetFlag
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestNoteHandler_Batch(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	created := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: gofakeit.Name(), Text: gofakeit.Sentence(5)}
+	deleted := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: gofakeit.Name(), Text: gofakeit.Sentence(5)}
+	ops := []*dto.NoteBatchOpRequest{
+		{Op: "create", Name: created.Name, Text: created.Text},
+		{Op: "delete", ID: deleted.ID},
+		{Op: "update", ID: uuid.Must(uuid.NewV7()), Name: gofakeit.Name(), Text: gofakeit.Sentence(5)},
+	}
+
+	cases := []testutil.HandlerCase[*dto.NoteBatchRequest, *dto.NoteBatchResponse, *noteDeps]{
+		{
+			Name:       "successful_batch",
+			StatusCode: http.StatusOK,
+			Req:        &dto.NoteBatchRequest{Mode: "best_effort", Operations: ops},
+			Expected: &dto.NoteBatchResponse{Results: []*dto.NoteBatchResultResponse{
+				{Status: http.StatusCreated, Note: dtoadapter.NoteToResponseDto(created)},
+				{Status: http.StatusOK, Note: dtoadapter.NoteToResponseDto(deleted)},
+				{Status: http.StatusNotFound, Error: errx.New(errx.CodeNotFound, "Note is not found")},
+			}},
+			Mocker: func(req *dto.NoteBatchRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Batch(mock.Anything, u, dtoadapter.NoteBatchRequestDtoToRequest(req)).
+					Return([]*note.BatchResult{{Note: created}, {Note: deleted}, {Err: note.ErrNotFound}}, nil).
+					Once()
+			},
+		},
+		{
+			Name:       "atomic_aborted",
+			StatusCode: http.StatusOK,
+			Req:        &dto.NoteBatchRequest{Operations: ops[:2]},
+			Expected: &dto.NoteBatchResponse{Results: []*dto.NoteBatchResultResponse{
+				{Status: http.StatusFailedDependency, Error: errx.New(errx.CodeAborted, "Batch is aborted")},
+				{Status: http.StatusForbidden, Error: errx.New(errx.CodeForbidden, "Operation disallowed")},
+			}},
+			Mocker: func(req *dto.NoteBatchRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Batch(mock.Anything, u, mock.MatchedBy(func(r *note.BatchRequest) bool {
+						return r.Mode == note.BatchAtomic && len(r.Ops) == 2
+					})).
+					Return([]*note.BatchResult{
+						{Err: note.ErrBatchAborted},
+						{Err: note.ErrOperationForbiddenForUser},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			Name:       "too_many_operations",
+			StatusCode: http.StatusBadRequest,
+			Req:        &dto.NoteBatchRequest{Operations: append(ops, ops[0])},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(req *dto.NoteBatchRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "request_error",
+			StatusCode: http.StatusBadRequest,
+			Req:        &dto.NoteBatchRequest{Operations: []*dto.NoteBatchOpRequest{{Op: "update"}}},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeValidation,
+				},
+			},
+			Mocker: func(req *dto.NoteBatchRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "unknown_error",
+			StatusCode: http.StatusInternalServerError,
+			Req:        &dto.NoteBatchRequest{Operations: ops},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnknown,
+				},
+			},
+			Mocker: func(req *dto.NoteBatchRequest, d *noteDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Batch(mock.Anything, u, dtoadapter.NoteBatchRequestDtoToRequest(req)).
+					Return(nil, errors.New("unknown error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_note.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPost, "/api/v1/notes/batch", func() *noteDeps {
+				return &noteDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *noteDeps) http.HandlerFunc {
+				return NewNoteHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.NoteService = service
+					deps.Config.Batch = config.BatchConfig{MaxOperations: 3, LimitReq: size.Bytes(1 << 20)}
+				})).Batch
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
 func TestNoteHandler_Search(t *testing.T) {
 	t.Parallel()
 
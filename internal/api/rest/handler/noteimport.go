@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/pkg/notefile"
+)
+
+// noteImportFormField is the name of the multipart form field carrying the uploaded file of notes.
+const noteImportFormField = "file"
+
+// NoteImportHandler is responsible for handling HTTP requests importing notes.
+type NoteImportHandler struct {
+	deps *app.Deps
+}
+
+// NewNoteImportHandler initializes and returns a new instance of NoteImportHandler with the provided dependencies.
+func NewNoteImportHandler(deps *app.Deps) *NoteImportHandler {
+	return &NoteImportHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for note imports.
+func (h *NoteImportHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Post("/", h.Start)
+	router.Get("/{id}", h.Get)
+	router.Get("/{id}/items", h.Items)
+	return router
+}
+
+// Start handler
+//
+//	@Summary		Import notes
+//	@Description	Queue the import of the file of the multipart form field "file": a zip archive of Markdown files
+//	@Description	with optional front matter (title, created, updated), an Evernote ENEX export or a JSON array of
+//	@Description	notes. The format is detected by the file extension unless it is given. The notes are created in
+//	@Description	the background, poll the import for its progress. Notes imported before are skipped, and uploading
+//	@Description	the file of an unfinished import again returns that import.
+//	@Tags			Import
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			format	query		string	false	"Format: markdown, enex, json"
+//	@Param			file	formData	file	true	"File of notes"
+//	@Success		202		{object}	dto.NoteImportResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		413		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/import [post]
+func (h *NoteImportHandler) Start(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("start import handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	var format notefile.Format
+	if v := r.URL.Query().Get("format"); v != "" {
+		if format, err = notefile.ParseFormat(v); err != nil {
+			middleware.Log(r).Debug().Err(err).Msg("start import handler parse format")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown format"))
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Import.MaxFileSize)+attachmentFormOverhead)
+	form, err := r.MultipartReader()
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("start import handler parse form")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Multipart form is expected"))
+		return
+	}
+
+	for {
+		part, err := form.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errx.New(errx.CodeBadRequest, "File is required")
+			}
+
+			middleware.Log(r).Debug().Err(err).Msg("start import handler parse form")
+			httpio.Error(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if part.FormName() != noteImportFormField {
+			continue
+		}
+
+		res, err := h.deps.Service.NoteImportService.Start(r.Context(), user, &noteimport.UploadData{
+			Name:    part.FileName(),
+			Format:  format,
+			Content: part,
+		})
+		if err != nil {
+			h.error(w, r, "start import", err)
+			return
+		}
+
+		httpio.Json(w, http.StatusAccepted, dtoadapter.NoteImportToResponseDto(res))
+		return
+	}
+}
+
+// Get handler
+//
+//	@Summary		Get import
+//	@Description	Get the import with its progress: the total number of notes in the file and the numbers of
+//	@Description	processed, created, skipped and failed ones
+//	@Tags			Import
+//	@Produce		json
+//	@Param			id	path		string	true	"Import id"
+//	@Success		200	{object}	dto.NoteImportResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/import/{id} [get]
+func (h *NoteImportHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "get import")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.NoteImportService.Get(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "get import", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NoteImportToResponseDto(res))
+}
+
+// Items handler
+//
+//	@Summary		List import items
+//	@Description	Get the outcomes of the imported notes in the order of the file, failed items carry the error
+//	@Tags			Import
+//	@Produce		json
+//	@Param			id		path		string	true	"Import id"
+//	@Param			status	query		string	false	"Status: created, skipped, failed"
+//	@Param			limit	query		int		false	"Limit"
+//	@Param			offset	query		int		false	"Offset"
+//	@Success		200		{object}	dto.NoteImportItemListResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/import/{id}/items [get]
+func (h *NoteImportHandler) Items(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "list import items")
+	if !ok {
+		return
+	}
+
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("list import items handler parse query")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	status := noteimport.ItemStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", noteimport.ItemCreated, noteimport.ItemSkipped, noteimport.ItemFailed:
+	default:
+		middleware.Log(r).Debug().Msg("list import items handler unknown status")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown status"))
+		return
+	}
+
+	res, err := h.deps.Service.NoteImportService.Items(r.Context(), user, id, status, limit, offset)
+	if err != nil {
+		h.error(w, r, "list import items", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NoteImportItemsToResponseDto(res))
+}
+
+// userAndID extracts the authenticated user and the import id from the request, writing the error response on failure.
+func (h *NoteImportHandler) userAndID(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// error writes the error response matching the note import service error.
+func (h *NoteImportHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, noteimport.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler import not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Import is not found"))
+	case errors.Is(err, notefile.ErrUnknownFormat):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler unknown format", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(
+			errx.CodeBadRequest,
+			"Unknown format, upload a .zip, .enex or .json file or set the format",
+		))
+	case errors.Is(err, noteimport.ErrFileTooLarge), errors.As(err, &maxBytesErr):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler file too large", action)
+		maxBytes := strconv.FormatInt(int64(h.deps.Config.Import.MaxFileSize), 10)
+		httpio.Error(w, http.StatusRequestEntityTooLarge, errx.NewOptional(
+			errx.CodeBodyTooLarge,
+			"File limit "+maxBytes+" bytes is exceeded",
+			map[string]string{"max_bytes": maxBytes},
+		))
+	case errors.Is(err, noteimport.ErrEmptyFile):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler empty file", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "File is empty"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_noteimport"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/pkg/notefile"
+	"github.com/xsqrty/notes/tests/testutil"
+)
+
+func TestNoteImportHandler_Start(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	j := &noteimport.Job{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    u.ID,
+		Name:      "notes.txt",
+		Format:    notefile.JSON,
+		Size:      2,
+		Status:    noteimport.StatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	cases := []struct {
+		name         string
+		field        string
+		query        string
+		format       notefile.Format
+		serviceErr   error
+		statusCode   int
+		expectedCode string
+	}{
+		{
+			name:       "successful_start",
+			field:      "file",
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:       "format_is_set",
+			field:      "file",
+			query:      "?format=json",
+			format:     notefile.JSON,
+			statusCode: http.StatusAccepted,
+		},
+		{
+			name:         "unknown_format_query",
+			field:        "file",
+			query:        "?format=txt",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "file_required",
+			field:        "other",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "unknown_format",
+			field:        "file",
+			serviceErr:   notefile.ErrUnknownFormat,
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "file_too_large",
+			field:        "file",
+			serviceErr:   noteimport.ErrFileTooLarge,
+			statusCode:   http.StatusRequestEntityTooLarge,
+			expectedCode: errx.CodeBodyTooLarge,
+		},
+		{
+			name:         "empty_file",
+			field:        "file",
+			serviceErr:   noteimport.ErrEmptyFile,
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_noteimport.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			if tc.field == "file" && tc.query != "?format=txt" {
+				service.EXPECT().Start(mock.Anything, u, mock.Anything).
+					RunAndReturn(func(
+						_ context.Context,
+						_ *user.User,
+						data *noteimport.UploadData,
+					) (*noteimport.Job, error) {
+						content, err := io.ReadAll(data.Content)
+						require.NoError(t, err)
+						require.Equal(t, "notes.txt", data.Name)
+						require.Equal(t, tc.format, data.Format)
+						require.Equal(t, "[]", string(content))
+						if tc.serviceErr != nil {
+							return nil, tc.serviceErr
+						}
+
+						return j, nil
+					}).Once()
+			}
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			require.NoError(t, form.WriteField("comment", "skipped"))
+			part, err := form.CreateFormFile(tc.field, "notes.txt")
+			require.NoError(t, err)
+			_, err = part.Write([]byte("[]"))
+			require.NoError(t, err)
+			require.NoError(t, form.Close())
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/notes/import"+tc.query, &body)
+			r.Header.Set("Content-Type", form.FormDataContentType())
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.NoteImportService = service
+				deps.Config.Import.MaxFileSize = 1 << 20
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewNoteImportHandler(deps).Start)).ServeHTTP(w, r)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expectedCode == "" {
+				var res dto.NoteImportResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+				require.Equal(t, j.ID, res.ID)
+				require.Equal(t, "json", res.Format)
+				require.Equal(t, "pending", res.Status)
+				return
+			}
+
+			var res httpio.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			require.Equal(t, tc.expectedCode, res.Error.Code)
+		})
+	}
+}
+
+func TestNoteImportHandler_Items(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	jobID := uuid.Must(uuid.NewV7())
+	noteID := uuid.Must(uuid.NewV7())
+	items := []*noteimport.Item{
+		{
+			ID:       uuid.Must(uuid.NewV7()),
+			JobID:    jobID,
+			Position: 0,
+			Source:   "notes/first.md",
+			Status:   noteimport.ItemCreated,
+			NoteID:   uuid.NullUUID{UUID: noteID, Valid: true},
+		},
+		{
+			ID:       uuid.Must(uuid.NewV7()),
+			JobID:    jobID,
+			Position: 1,
+			Source:   "notes/second.md",
+			Status:   noteimport.ItemFailed,
+			Error:    "text is longer than 10 characters",
+		},
+	}
+
+	cases := []struct {
+		name         string
+		id           string
+		query        string
+		status       noteimport.ItemStatus
+		serviceErr   error
+		statusCode   int
+		expectedCode string
+		expected     *dto.NoteImportItemListResponse
+	}{
+		{
+			name:       "successful_list",
+			id:         jobID.String(),
+			query:      "?limit=10",
+			statusCode: http.StatusOK,
+			expected: &dto.NoteImportItemListResponse{
+				TotalRows: 2,
+				Rows: []*dto.NoteImportItemResponse{
+					{
+						ID:       items[0].ID,
+						Position: 0,
+						Source:   "notes/first.md",
+						Status:   "created",
+						NoteID:   noteID,
+					},
+					{
+						ID:       items[1].ID,
+						Position: 1,
+						Source:   "notes/second.md",
+						Status:   "failed",
+						Error:    "text is longer than 10 characters",
+					},
+				},
+			},
+		},
+		{
+			name:       "status_filter",
+			id:         jobID.String(),
+			query:      "?limit=10&status=skipped",
+			status:     noteimport.ItemSkipped,
+			statusCode: http.StatusOK,
+			expected: &dto.NoteImportItemListResponse{
+				Rows: []*dto.NoteImportItemResponse{},
+			},
+		},
+		{
+			name:         "unknown_status",
+			id:           jobID.String(),
+			query:        "?status=done",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "bad_id",
+			id:           "bad",
+			statusCode:   http.StatusBadRequest,
+			expectedCode: errx.CodeBadRequest,
+		},
+		{
+			name:         "not_found",
+			id:           jobID.String(),
+			query:        "?limit=10",
+			serviceErr:   noteimport.ErrNotFound,
+			statusCode:   http.StatusNotFound,
+			expectedCode: errx.CodeNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_noteimport.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+			mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			switch {
+			case tc.serviceErr != nil:
+				service.EXPECT().Items(mock.Anything, u, jobID, tc.status, uint64(10), uint64(0)).
+					Return(nil, tc.serviceErr).Once()
+			case tc.expected != nil:
+				res := &search.Result[noteimport.Item]{TotalRows: tc.expected.TotalRows}
+				if tc.status == "" {
+					res.Rows = items
+				}
+
+				service.EXPECT().Items(mock.Anything, u, jobID, tc.status, uint64(10), uint64(0)).
+					Return(res, nil).Once()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/notes/import/"+tc.id+"/items"+tc.query, nil)
+			w := httptest.NewRecorder()
+			deps := mock_app.NewDeps(t, func(deps *app.Deps) {
+				deps.JWTAuthentication = mw
+				deps.Service.NoteImportService = service
+			})
+			middleware.Logger(deps.Logger)(http.HandlerFunc(NewNoteImportHandler(deps).Items)).
+				ServeHTTP(w, testutil.AddUrlParams(r, map[string]string{"id": tc.id}))
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.expectedCode == "" {
+				var res dto.NoteImportItemListResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+				require.Equal(t, tc.expected, &res)
+				return
+			}
+
+			var res httpio.ErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			require.Equal(t, tc.expectedCode, res.Error.Code)
+		})
+	}
+}
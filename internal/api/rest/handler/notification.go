@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// NotificationHandler is responsible for handling HTTP requests related to the notifications inbox of the user.
+type NotificationHandler struct {
+	deps *app.Deps
+}
+
+// NewNotificationHandler initializes and returns a new instance of NotificationHandler with the provided dependencies.
+func NewNotificationHandler(deps *app.Deps) *NotificationHandler {
+	return &NotificationHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for the notifications inbox.
+func (h *NotificationHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/read-all", h.MarkAllRead)
+	router.Get("/preferences", h.Preferences)
+	router.Put("/preferences", h.SetPreferences)
+	router.Post("/{id}/read", h.MarkRead)
+	return router
+}
+
+// List handler
+//
+//	@Summary		List notifications
+//	@Description	Get the notifications of the user newest first with the unread count. The next page is requested
+//	@Description	with the next_cursor of the previous page.
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			cursor	query		string	false	"Cursor of the page"
+//	@Param			limit	query		int		false	"Limit"
+//	@Param			unread	query		bool	false	"Unread notifications only"
+//	@Success		200		{object}	dto.NotificationListResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notifications [get]
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list notifications handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	req, err := parseNotificationListRequest(r.URL.Query())
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("list notifications handler parse query")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.NotificationService.List(r.Context(), user, req)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("couldn't list notifications")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NotificationPageToResponseDto(res))
+}
+
+// MarkRead handler
+//
+//	@Summary		Mark notification read
+//	@Description	Mark the notification of the user read
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			id	path		string	true	"Notification id"
+//	@Success		200	{object}	dto.NotificationResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("mark notification read handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("mark notification read handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.NotificationService.MarkRead(r.Context(), user, id)
+	if err != nil {
+		if errors.Is(err, notification.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("mark notification read handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Notification is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't mark notification read")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NotificationToResponseDto(res))
+}
+
+// MarkAllRead handler
+//
+//	@Summary		Mark all notifications read
+//	@Description	Mark all unread notifications of the user read
+//	@Tags			Notifications
+//	@Produce		json
+//	@Success		200	{object}	dto.NotificationMarkAllReadResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("mark all notifications read handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	marked, err := h.deps.Service.NotificationService.MarkAllRead(r.Context(), user)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("couldn't mark all notifications read")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, &dto.NotificationMarkAllReadResponse{Marked: marked})
+}
+
+// Preferences handler
+//
+//	@Summary		Get notification preferences
+//	@Description	Get the preferences of the user for every notification type
+//	@Tags			Notifications
+//	@Produce		json
+//	@Success		200	{object}	dto.NotificationPreferencesResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notifications/preferences [get]
+func (h *NotificationHandler) Preferences(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("get notification preferences handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	res, err := h.deps.Service.NotificationService.Preferences(r.Context(), user)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("couldn't get notification preferences")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NotificationPreferencesToResponseDto(res))
+}
+
+// SetPreferences handler
+//
+//	@Summary		Set notification preferences
+//	@Description	Enable or disable notification types for the user, other types are left as they are. Types are
+//	@Description	reminder, org_invite and new_login.
+//	@Tags			Notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.NotificationPreferencesRequest	true	"Preferences"
+//	@Success		200		{object}	dto.NotificationPreferencesResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notifications/preferences [put]
+func (h *NotificationHandler) SetPreferences(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("set notification preferences handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.NotificationPreferencesRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("set notification preferences handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.NotificationService.SetPreferences(
+		r.Context(),
+		user,
+		dtoadapter.NotificationPreferencesRequestDtoToMap(&request),
+	)
+	if err != nil {
+		if errors.Is(err, notification.ErrUnknownType) {
+			middleware.Log(r).Debug().Err(err).Msg("set notification preferences handler unknown type")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown notification type"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't set notification preferences")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NotificationPreferencesToResponseDto(res))
+}
+
+// parseNotificationListRequest builds the notifications page request from the query parameters.
+func parseNotificationListRequest(q url.Values) (*notification.ListRequest, error) {
+	cursor, err := notification.ParseCursor(q.Get("cursor"))
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notification.ListRequest{Cursor: cursor}
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := q.Get("unread"); v != "" {
+		if req.UnreadOnly, err = strconv.ParseBool(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
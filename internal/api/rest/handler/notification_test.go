@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_notification"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+	"github.com/xsqrty/op/driver"
+)
+
+type notificationDeps struct {
+	service *mock_notification.Service
+	mw      *mock_middleware.JWTAuthentication
+}
+
+func TestNotificationHandler_MarkRead(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	n := &notification.Notification{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    u.ID,
+		Type:      notification.TypeOrgInvite,
+		Title:     "Invitation to " + gofakeit.Company(),
+		Body:      gofakeit.Sentence(8),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		ReadAt:    driver.ZeroTime(time.Now().UTC().Truncate(time.Second)),
+	}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.NotificationResponse, *notificationDeps]{
+		{
+			Name:       "successful_mark_read",
+			ID:         n.ID.String(),
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.NotificationToResponseDto(n),
+			Mocker: func(_ struct{}, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().MarkRead(mock.Anything, u, n.ID).Return(n, nil).Once()
+			},
+		},
+		{
+			Name:       "user_unauthorized",
+			ID:         n.ID.String(),
+			StatusCode: http.StatusUnauthorized,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnauthorized,
+				},
+			},
+			Mocker: func(_ struct{}, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(nil, errors.New("no user")).Once()
+			},
+		},
+		{
+			Name:       "id_param_error",
+			ID:         "1",
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "notification_not_found",
+			ID:         n.ID.String(),
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().MarkRead(mock.Anything, u, n.ID).Return(nil, notification.ErrNotFound).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_notification.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPost, fmt.Sprintf("/api/v1/notifications/%s/read", tc.ID), func() *notificationDeps {
+				return &notificationDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *notificationDeps) http.HandlerFunc {
+				return NewNotificationHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.NotificationService = service
+				})).MarkRead
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestNotificationHandler_SetPreferences(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	prefs := []*notification.Preference{
+		{UserID: u.ID, Type: notification.TypeReminder, Enabled: true},
+		{UserID: u.ID, Type: notification.TypeOrgInvite, Enabled: true},
+		{UserID: u.ID, Type: notification.TypeNewLogin, Enabled: false},
+	}
+
+	cases := []testutil.HandlerCase[
+		*dto.NotificationPreferencesRequest,
+		*dto.NotificationPreferencesResponse,
+		*notificationDeps,
+	]{
+		{
+			Name:       "successful_set",
+			Req:        &dto.NotificationPreferencesRequest{Preferences: map[string]bool{"new_login": false}},
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.NotificationPreferencesToResponseDto(prefs),
+			Mocker: func(_ *dto.NotificationPreferencesRequest, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					SetPreferences(mock.Anything, u, map[notification.Type]bool{notification.TypeNewLogin: false}).
+					Return(prefs, nil).Once()
+			},
+		},
+		{
+			Name:       "empty_preferences",
+			Req:        &dto.NotificationPreferencesRequest{},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeValidation,
+				},
+			},
+			Mocker: func(_ *dto.NotificationPreferencesRequest, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "unknown_type",
+			Req:        &dto.NotificationPreferencesRequest{Preferences: map[string]bool{"digest": true}},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ *dto.NotificationPreferencesRequest, d *notificationDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					SetPreferences(mock.Anything, u, map[notification.Type]bool{"digest": true}).
+					Return(nil, notification.ErrUnknownType).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_notification.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPut, "/api/v1/notifications/preferences", func() *notificationDeps {
+				return &notificationDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *notificationDeps) http.HandlerFunc {
+				return NewNotificationHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.NotificationService = service
+				})).SetPreferences
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
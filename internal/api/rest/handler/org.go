@@ -0,0 +1,618 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// OrgHandler is responsible for handling HTTP requests related to organisations.
+type OrgHandler struct {
+	deps *app.Deps
+}
+
+// NewOrgHandler initializes and returns a new instance of OrgHandler with the provided dependencies.
+func NewOrgHandler(deps *app.Deps) *OrgHandler {
+	return &OrgHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for organisations.
+func (h *OrgHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/", h.Create)
+	router.Get("/invitations", h.MyInvitations)
+	router.Post("/invitations/{invitation_id}/accept", h.AcceptInvitation)
+	router.Get("/{id}", h.Get)
+	router.Put("/{id}", h.Update)
+	router.Delete("/{id}", h.Delete)
+	router.Get("/{id}/members", h.Members)
+	router.Put("/{id}/members/{user_id}", h.UpdateMember)
+	router.Delete("/{id}/members/{user_id}", h.RemoveMember)
+	router.Get("/{id}/invitations", h.Invitations)
+	router.Post("/{id}/invitations", h.Invite)
+	router.Delete("/{id}/invitations/{invitation_id}", h.RevokeInvitation)
+	router.Post("/{id}/notes/search", h.SearchNotes)
+	return router
+}
+
+// List handler
+//
+//	@Summary		List organisations
+//	@Description	Get organisations the user is a member of
+//	@Tags			Organisations
+//	@Produce		json
+//	@Success		200	{object}	dto.OrgListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs [get]
+func (h *OrgHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list orgs handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	orgs, err := h.deps.Service.OrgService.List(r.Context(), user)
+	if err != nil {
+		h.error(w, r, "list orgs", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgsToListResponseDto(orgs))
+}
+
+// Create handler
+//
+//	@Summary		Create organisation
+//	@Description	Create new organisation owned by the user
+//	@Tags			Organisations
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.OrgRequest	true	"Create organisation request"
+//	@Success		201		{object}	dto.OrgResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs [post]
+func (h *OrgHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("create org handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.OrgRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("create org handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.Create(r.Context(), user, dtoadapter.OrgRequestDtoToCreateData(&request))
+	if err != nil {
+		h.error(w, r, "create org", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusCreated, dtoadapter.OrgToResponseDto(res))
+}
+
+// Get handler
+//
+//	@Summary		Get organisation
+//	@Description	Get organisation by id
+//	@Tags			Organisations
+//	@Produce		json
+//	@Param			id	path		string	true	"Organisation id"
+//	@Success		200	{object}	dto.OrgResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id} [get]
+func (h *OrgHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "get org")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.Get(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "get org", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgToResponseDto(res))
+}
+
+// Update handler
+//
+//	@Summary		Update organisation
+//	@Description	Update organisation name
+//	@Tags			Organisations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Organisation id"
+//	@Param			request	body		dto.OrgRequest	true	"Update organisation request"
+//	@Success		200		{object}	dto.OrgResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id} [put]
+func (h *OrgHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "update org")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.OrgRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update org handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.Update(r.Context(), user, dtoadapter.OrgRequestDtoToUpdateData(id, &request))
+	if err != nil {
+		h.error(w, r, "update org", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgToResponseDto(res))
+}
+
+// Delete handler
+//
+//	@Summary		Delete organisation
+//	@Description	Delete organisation with all its notes
+//	@Tags			Organisations
+//	@Produce		json
+//	@Param			id	path		string	true	"Organisation id"
+//	@Success		200	{object}	dto.OrgResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id} [delete]
+func (h *OrgHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "delete org")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.Delete(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "delete org", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgToResponseDto(res))
+}
+
+// Members handler
+//
+//	@Summary		List organisation members
+//	@Description	Get members of the organisation
+//	@Tags			Organisations
+//	@Produce		json
+//	@Param			id	path		string	true	"Organisation id"
+//	@Success		200	{object}	dto.OrgMemberListResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/members [get]
+func (h *OrgHandler) Members(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "list org members")
+	if !ok {
+		return
+	}
+
+	members, err := h.deps.Service.OrgService.Members(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "list org members", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgMembersToListResponseDto(members))
+}
+
+// UpdateMember handler
+//
+//	@Summary		Update organisation member
+//	@Description	Change role of the organisation member
+//	@Tags			Organisations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Organisation id"
+//	@Param			user_id	path		string					true	"User id"
+//	@Param			request	body		dto.OrgMemberRequest	true	"Update member request"
+//	@Success		200		{object}	dto.OrgMemberResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		409		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/members/{user_id} [put]
+func (h *OrgHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "update org member")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update org member handler parse user id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	request, err := httpio.Parse[dto.OrgMemberRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update org member handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.UpdateMember(
+		r.Context(),
+		user,
+		dtoadapter.OrgMemberRequestDtoToMemberData(id, userID, &request),
+	)
+	if err != nil {
+		h.error(w, r, "update org member", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgMemberToResponseDto(res))
+}
+
+// RemoveMember handler
+//
+//	@Summary		Remove organisation member
+//	@Description	Remove the member from the organisation, members may remove themselves to leave it
+//	@Tags			Organisations
+//	@Param			id		path	string	true	"Organisation id"
+//	@Param			user_id	path	string	true	"User id"
+//	@Success		204
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		409	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/members/{user_id} [delete]
+func (h *OrgHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "remove org member")
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("remove org member handler parse user id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	if err := h.deps.Service.OrgService.RemoveMember(r.Context(), user, id, userID); err != nil {
+		h.error(w, r, "remove org member", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Invitations handler
+//
+//	@Summary		List organisation invitations
+//	@Description	Get pending invitations of the organisation
+//	@Tags			Organisations
+//	@Produce		json
+//	@Param			id	path		string	true	"Organisation id"
+//	@Success		200	{object}	dto.OrgInvitationListResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/invitations [get]
+func (h *OrgHandler) Invitations(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "list org invitations")
+	if !ok {
+		return
+	}
+
+	invitations, err := h.deps.Service.OrgService.Invitations(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "list org invitations", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgInvitationsToListResponseDto(invitations))
+}
+
+// Invite handler
+//
+//	@Summary		Invite to organisation
+//	@Description	Invite the user with the email to the organisation
+//	@Tags			Organisations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Organisation id"
+//	@Param			request	body		dto.OrgInvitationRequest	true	"Invitation request"
+//	@Success		201		{object}	dto.OrgInvitationResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		409		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/invitations [post]
+func (h *OrgHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "invite to org")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.OrgInvitationRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("invite to org handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.Invite(
+		r.Context(),
+		user,
+		dtoadapter.OrgInvitationRequestDtoToInviteData(id, &request),
+	)
+	if err != nil {
+		h.error(w, r, "invite to org", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusCreated, dtoadapter.OrgInvitationToResponseDto(res))
+}
+
+// RevokeInvitation handler
+//
+//	@Summary		Revoke organisation invitation
+//	@Description	Remove pending invitation of the organisation
+//	@Tags			Organisations
+//	@Param			id				path	string	true	"Organisation id"
+//	@Param			invitation_id	path	string	true	"Invitation id"
+//	@Success		204
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/invitations/{invitation_id} [delete]
+func (h *OrgHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "revoke org invitation")
+	if !ok {
+		return
+	}
+
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitation_id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("revoke org invitation handler parse invitation id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	if err := h.deps.Service.OrgService.RevokeInvitation(r.Context(), user, id, invitationID); err != nil {
+		h.error(w, r, "revoke org invitation", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MyInvitations handler
+//
+//	@Summary		List user invitations
+//	@Description	Get pending organisation invitations sent to the email of the user
+//	@Tags			Organisations
+//	@Produce		json
+//	@Success		200	{object}	dto.OrgInvitationListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/invitations [get]
+func (h *OrgHandler) MyInvitations(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list user invitations handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	invitations, err := h.deps.Service.OrgService.MyInvitations(r.Context(), user)
+	if err != nil {
+		h.error(w, r, "list user invitations", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgInvitationsToListResponseDto(invitations))
+}
+
+// AcceptInvitation handler
+//
+//	@Summary		Accept organisation invitation
+//	@Description	Join the organisation by the invitation sent to the email of the user
+//	@Tags			Organisations
+//	@Produce		json
+//	@Param			invitation_id	path		string	true	"Invitation id"
+//	@Success		200				{object}	dto.OrgMemberResponse
+//	@Failure		400				{object}	httpio.ErrorResponse
+//	@Failure		401				{object}	httpio.ErrorResponse
+//	@Failure		404				{object}	httpio.ErrorResponse
+//	@Failure		409				{object}	httpio.ErrorResponse
+//	@Failure		500				{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/invitations/{invitation_id}/accept [post]
+func (h *OrgHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("accept org invitation handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitation_id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("accept org invitation handler parse invitation id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.OrgService.AcceptInvitation(r.Context(), user, invitationID)
+	if err != nil {
+		h.error(w, r, "accept org invitation", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.OrgMemberToResponseDto(res))
+}
+
+// SearchNotes handler
+//
+//	@Summary		Search organisation notes
+//	@Description	Search notes owned by the organisation
+//	@Tags			Organisations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Organisation id"
+//	@Param			request	body		search.Request	true	"Search request"
+//	@Success		200		{object}	dto.NoteSearchResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/orgs/{id}/notes/search [post]
+func (h *OrgHandler) SearchNotes(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "search org notes")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[search.Request](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("search org notes handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.NoteService.SearchByOrg(r.Context(), user, id, &request)
+	if err != nil {
+		if errors.Is(err, note.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("search org notes forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, note.ErrSearchBadRequest) {
+			middleware.Log(r).Debug().Err(err).Msg("search org notes bad request")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+			return
+		}
+
+		h.error(w, r, "search org notes", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.NoteSearchToResponseDto(res))
+}
+
+// userAndID authenticates the request and parses the organisation id, writing the error response on failure.
+func (h *OrgHandler) userAndID(w http.ResponseWriter, r *http.Request, action string) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// error writes the error response matching the organisation service error.
+func (h *OrgHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, org.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, org.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler org not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Organisation is not found"))
+	case errors.Is(err, org.ErrMemberNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler member not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Member is not found"))
+	case errors.Is(err, org.ErrInvitationNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler invitation not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Invitation is not found"))
+	case errors.Is(err, org.ErrAlreadyMember):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler already member", action)
+		httpio.Error(w, http.StatusConflict, errx.New(errx.CodeAlreadyMember, "User is already a member"))
+	case errors.Is(err, org.ErrLastOwner):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler last owner", action)
+		httpio.Error(w, http.StatusConflict, errx.New(errx.CodeLastOwner, "Organisation must keep an owner"))
+	case errors.Is(err, org.ErrUnknownMemberRole):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler unknown role", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown member role"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
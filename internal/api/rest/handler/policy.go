@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/policy"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// PolicyHandler is responsible for handling HTTP requests related to access policies inspection.
+type PolicyHandler struct {
+	deps *app.Deps
+}
+
+// NewPolicyHandler initializes and returns a new instance of PolicyHandler with the provided dependencies.
+func NewPolicyHandler(deps *app.Deps) *PolicyHandler {
+	return &PolicyHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for access policies.
+func (h *PolicyHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/explain", h.Explain)
+	return router
+}
+
+// List handler
+//
+//	@Summary		List policies
+//	@Description	Get currently loaded access policies
+//	@Tags			Policies
+//	@Produce		json
+//	@Success		200	{object}	dto.PolicyListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/policies [get]
+func (h *PolicyHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list policies handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	policies, err := h.deps.Service.PolicyService.List(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, policy.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("list policies forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't list policies")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.PoliciesToListResponseDto(policies))
+}
+
+// Explain handler
+//
+//	@Summary		Explain access decision
+//	@Description	Dry run of an operation reporting the access decision and the matched policy
+//	@Tags			Policies
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.PolicyExplainRequest	true	"Explain request"
+//	@Success		200		{object}	dto.PolicyExplainResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/policies/explain [post]
+func (h *PolicyHandler) Explain(w http.ResponseWriter, r *http.Request) {
+	currentUser, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("explain policies handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.PolicyExplainRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("explain policies handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := dtoadapter.PolicyExplainRequestDtoToExplainData(&request)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("explain policies handler bad operation")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown operation"))
+		return
+	}
+
+	res, err := h.deps.Service.PolicyService.Explain(r.Context(), currentUser, data)
+	if err != nil {
+		if errors.Is(err, policy.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("explain policies forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, policy.ErrUnknownResource) {
+			middleware.Log(r).Debug().Err(err).Msg("explain policies unknown resource")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown resource"))
+			return
+		}
+
+		if errors.Is(err, user.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("explain policies user not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "User is not found"))
+			return
+		}
+
+		if errors.Is(err, note.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("explain policies note not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't explain policies")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ExplanationToResponseDto(res))
+}
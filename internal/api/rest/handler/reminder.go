@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// ReminderHandler is responsible for handling HTTP requests related to reminders of notes.
+type ReminderHandler struct {
+	deps *app.Deps
+}
+
+// NewReminderHandler initializes and returns a new instance of ReminderHandler with the provided dependencies.
+func NewReminderHandler(deps *app.Deps) *ReminderHandler {
+	return &ReminderHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for the reminder of the note.
+func (h *ReminderHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.Get)
+	router.Put("/", h.Set)
+	router.Delete("/", h.Delete)
+	router.Post("/snooze", h.Snooze)
+	router.Post("/dismiss", h.Dismiss)
+	return router
+}
+
+// Get handler
+//
+//	@Summary		Get reminder
+//	@Description	Get the reminder of the note set by the user
+//	@Tags			Reminders
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.ReminderResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/reminder [get]
+func (h *ReminderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "get reminder")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.ReminderService.Get(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "get reminder", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ReminderToResponseDto(res))
+}
+
+// Set handler
+//
+//	@Summary		Set reminder
+//	@Description	Set the reminder of the note, replacing the previous reminder of the user. The reminder fires at
+//	@Description	remind_at, repeated by the RFC 5545 rrule in the IANA time_zone (UTC by default).
+//	@Tags			Reminders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Note id"
+//	@Param			request	body		dto.ReminderRequest	true	"Reminder"
+//	@Success		200		{object}	dto.ReminderResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/reminder [put]
+func (h *ReminderHandler) Set(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "set reminder")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.ReminderRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("set reminder handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.ReminderService.Set(
+		r.Context(),
+		user,
+		dtoadapter.ReminderRequestDtoToSetData(noteID, &request),
+	)
+	if err != nil {
+		h.error(w, r, "set reminder", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ReminderToResponseDto(res))
+}
+
+// Delete handler
+//
+//	@Summary		Delete reminder
+//	@Description	Delete the reminder of the note set by the user
+//	@Tags			Reminders
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.ReminderResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/reminder [delete]
+func (h *ReminderHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "delete reminder")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.ReminderService.Delete(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "delete reminder", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ReminderToResponseDto(res))
+}
+
+// Snooze handler
+//
+//	@Summary		Snooze reminder
+//	@Description	Fire the last firing of the reminder again at the given time. Recurring reminders keep firing
+//	@Description	at their next occurrences.
+//	@Tags			Reminders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Note id"
+//	@Param			request	body		dto.ReminderSnoozeRequest	true	"Snooze"
+//	@Success		200		{object}	dto.ReminderResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		409		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/reminder/snooze [post]
+func (h *ReminderHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "snooze reminder")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.ReminderSnoozeRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("snooze reminder handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.ReminderService.Snooze(r.Context(), user, noteID, request.Until)
+	if err != nil {
+		h.error(w, r, "snooze reminder", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ReminderToResponseDto(res))
+}
+
+// Dismiss handler
+//
+//	@Summary		Dismiss reminder
+//	@Description	Cancel the snooze of the reminder and the pending one-off reminder. Recurring reminders keep
+//	@Description	firing at their next occurrences until deleted.
+//	@Tags			Reminders
+//	@Produce		json
+//	@Param			id	path		string	true	"Note id"
+//	@Success		200	{object}	dto.ReminderResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/notes/{id}/reminder/dismiss [post]
+func (h *ReminderHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	user, noteID, ok := h.userAndID(w, r, "dismiss reminder")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.ReminderService.Dismiss(r.Context(), user, noteID)
+	if err != nil {
+		h.error(w, r, "dismiss reminder", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.ReminderToResponseDto(res))
+}
+
+// userAndID extracts the authenticated user and the note id from the request, writing the error response on failure.
+func (h *ReminderHandler) userAndID(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// error writes the error response matching the reminder service error.
+func (h *ReminderHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, note.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, note.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler note not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Note is not found"))
+	case errors.Is(err, reminder.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler reminder not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Reminder is not found"))
+	case errors.Is(err, reminder.ErrInvalidRule):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler invalid rule", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Recurrence rule is invalid"))
+	case errors.Is(err, reminder.ErrInvalidTimeZone):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler invalid time zone", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Time zone is unknown"))
+	case errors.Is(err, reminder.ErrNoOccurrence):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler no occurrence", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Reminder has no future occurrence"))
+	case errors.Is(err, reminder.ErrSnoozeInPast):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler snooze in past", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Snooze time must be in the future"))
+	case errors.Is(err, reminder.ErrNotFired):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler not fired", action)
+		httpio.Error(w, http.StatusConflict, errx.New(errx.CodeConflict, "Reminder has not fired yet"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
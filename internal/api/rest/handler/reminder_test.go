@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_reminder"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+	"github.com/xsqrty/op/driver"
+)
+
+type reminderDeps struct {
+	service *mock_reminder.Service
+	mw      *mock_middleware.JWTAuthentication
+}
+
+func TestReminderHandler_Set(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	remindAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	r := &reminder.Reminder{
+		ID:        uuid.Must(uuid.NewV7()),
+		NoteID:    noteID,
+		RemindAt:  remindAt,
+		TimeZone:  "Europe/Berlin",
+		Rule:      "FREQ=WEEKLY;BYDAY=MO,FR",
+		Status:    reminder.StatusScheduled,
+		NextAt:    driver.ZeroTime(remindAt),
+		DueAt:     driver.ZeroTime(remindAt),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+	data := &reminder.SetData{NoteID: noteID, RemindAt: remindAt, TimeZone: r.TimeZone, Rule: r.Rule}
+
+	cases := []testutil.HandlerCase[*dto.ReminderRequest, *dto.ReminderResponse, *reminderDeps]{
+		{
+			Name:       "successful_set",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderRequest{RemindAt: remindAt, TimeZone: r.TimeZone, Rule: r.Rule},
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.ReminderToResponseDto(r),
+			Mocker: func(_ *dto.ReminderRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Set(mock.Anything, u, data).Return(r, nil).Once()
+			},
+		},
+		{
+			Name:       "user_unauthorized",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderRequest{RemindAt: remindAt},
+			StatusCode: http.StatusUnauthorized,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnauthorized,
+				},
+			},
+			Mocker: func(_ *dto.ReminderRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(nil, errors.New("no user")).Once()
+			},
+		},
+		{
+			Name:       "unknown_time_zone",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderRequest{RemindAt: remindAt, TimeZone: "Mars/Olympus"},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeValidation,
+				},
+			},
+			Mocker: func(_ *dto.ReminderRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "invalid_rule",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderRequest{RemindAt: remindAt, TimeZone: r.TimeZone, Rule: r.Rule},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ *dto.ReminderRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Set(mock.Anything, u, data).Return(nil, reminder.ErrInvalidRule).Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderRequest{RemindAt: remindAt, TimeZone: r.TimeZone, Rule: r.Rule},
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ *dto.ReminderRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Set(mock.Anything, u, data).Return(nil, note.ErrOperationForbiddenForUser).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_reminder.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPut, fmt.Sprintf("/api/v1/notes/%s/reminder", tc.ID), func() *reminderDeps {
+				return &reminderDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *reminderDeps) http.HandlerFunc {
+				return NewReminderHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.ReminderService = service
+				})).Set
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestReminderHandler_Snooze(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	r := &reminder.Reminder{
+		ID:           uuid.Must(uuid.NewV7()),
+		NoteID:       noteID,
+		RemindAt:     time.Now().UTC().Truncate(time.Second),
+		TimeZone:     "UTC",
+		Status:       reminder.StatusScheduled,
+		SnoozedUntil: driver.ZeroTime(until),
+		DueAt:        driver.ZeroTime(until),
+		FiredAt:      driver.ZeroTime(time.Now().UTC().Truncate(time.Second)),
+		Fired:        1,
+	}
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  gofakeit.Name(),
+		Email: gofakeit.Email(),
+	}
+
+	cases := []testutil.HandlerCase[*dto.ReminderSnoozeRequest, *dto.ReminderResponse, *reminderDeps]{
+		{
+			Name:       "successful_snooze",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderSnoozeRequest{Until: until},
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.ReminderToResponseDto(r),
+			Mocker: func(_ *dto.ReminderSnoozeRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Snooze(mock.Anything, u, noteID, until).Return(r, nil).Once()
+			},
+		},
+		{
+			Name:       "not_fired",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderSnoozeRequest{Until: until},
+			StatusCode: http.StatusConflict,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeConflict,
+				},
+			},
+			Mocker: func(_ *dto.ReminderSnoozeRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Snooze(mock.Anything, u, noteID, until).Return(nil, reminder.ErrNotFired).Once()
+			},
+		},
+		{
+			Name:       "reminder_not_found",
+			ID:         noteID.String(),
+			Req:        &dto.ReminderSnoozeRequest{Until: until},
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ *dto.ReminderSnoozeRequest, d *reminderDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Snooze(mock.Anything, u, noteID, until).Return(nil, reminder.ErrNotFound).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_reminder.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPost, fmt.Sprintf("/api/v1/notes/%s/reminder/snooze", tc.ID), func() *reminderDeps {
+				return &reminderDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *reminderDeps) http.HandlerFunc {
+				return NewReminderHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.ReminderService = service
+				})).Snooze
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
@@ -0,0 +1,437 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// RoleHandler is responsible for handling HTTP requests related to roles administration.
+type RoleHandler struct {
+	deps *app.Deps
+}
+
+// NewRoleHandler initializes and returns a new instance of RoleHandler with the provided dependencies.
+func NewRoleHandler(deps *app.Deps) *RoleHandler {
+	return &RoleHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for roles administration.
+func (h *RoleHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/", h.Create)
+	router.Get("/permissions", h.Permissions)
+	router.Get("/{id}", h.Get)
+	router.Put("/{id}", h.Update)
+	router.Delete("/{id}", h.Delete)
+	router.Put("/{id}/users/{user_id}", h.Assign)
+	router.Delete("/{id}/users/{user_id}", h.Unassign)
+	return router
+}
+
+// List handler
+//
+//	@Summary		List roles
+//	@Description	Get all roles
+//	@Tags			Roles
+//	@Produce		json
+//	@Success		200	{object}	dto.RoleListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles [get]
+func (h *RoleHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list roles handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	roles, err := h.deps.Service.RoleService.List(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("list roles forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't list roles")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.RolesToListResponseDto(roles))
+}
+
+// Permissions handler
+//
+//	@Summary		List permissions
+//	@Description	Get all permissions known to the application
+//	@Tags			Roles
+//	@Produce		json
+//	@Success		200	{object}	dto.PermissionListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles/permissions [get]
+func (h *RoleHandler) Permissions(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list permissions handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	permissions, err := h.deps.Service.RoleService.Permissions(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("list permissions forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't list permissions")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.PermissionsToListResponseDto(permissions))
+}
+
+// Get handler
+//
+//	@Summary		Get role
+//	@Description	Get role by id
+//	@Tags			Roles
+//	@Produce		json
+//	@Param			id	path		string	true	"Role id"
+//	@Success		200	{object}	dto.RoleResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles/{id} [get]
+func (h *RoleHandler) Get(w http.ResponseWriter, r *http.Request) { // nolint: dupl
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("get role handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("get role handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.RoleService.Get(r.Context(), user, id)
+	if err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("get role forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, role.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("get role handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Role is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't get role")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.RoleToResponseDto(res))
+}
+
+// Create handler
+//
+//	@Summary		Create role
+//	@Description	Create new role
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.RoleRequest	true	"Create role request"
+//	@Success		201		{object}	dto.RoleResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles [post]
+func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("create role handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.RoleRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("create role handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.RoleService.Create(r.Context(), user, dtoadapter.RoleRequestDtoToCreateData(&request))
+	if err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("create role forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, role.ErrUnknownPermission) {
+			middleware.Log(r).Debug().Err(err).Msg("create role unknown permission")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeUnknownPermission, "Unknown permission"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't create role")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusCreated, dtoadapter.RoleToResponseDto(res))
+}
+
+// Update handler
+//
+//	@Summary		Update role
+//	@Description	Update role description, label and permissions
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Role id"
+//	@Param			request	body		dto.RoleRequest	true	"Update role request"
+//	@Success		200		{object}	dto.RoleResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles/{id} [put]
+func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("update role handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update role handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	request, err := httpio.Parse[dto.RoleRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update role handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.RoleService.Update(r.Context(), user, dtoadapter.RoleRequestDtoToUpdateData(id, &request))
+	if err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("update role forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, role.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("update role handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Role is not found"))
+			return
+		}
+
+		if errors.Is(err, role.ErrUnknownPermission) {
+			middleware.Log(r).Debug().Err(err).Msg("update role unknown permission")
+			httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeUnknownPermission, "Unknown permission"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't update role")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.RoleToResponseDto(res))
+}
+
+// Delete handler
+//
+//	@Summary		Delete role
+//	@Description	Delete role by id
+//	@Tags			Roles
+//	@Produce		json
+//	@Param			id	path		string	true	"Role id"
+//	@Success		200	{object}	dto.RoleResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		403	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles/{id} [delete]
+func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request) { // nolint: dupl
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("delete role handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("delete role handler parse id")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.RoleService.Delete(r.Context(), user, id)
+	if err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msg("delete role forbidden")
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, role.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msg("delete role handler not found")
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Role is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msg("couldn't delete role")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.RoleToResponseDto(res))
+}
+
+// Assign handler
+//
+//	@Summary		Assign role
+//	@Description	Assign role to user
+//	@Tags			Roles
+//	@Produce		json
+//	@Param			id		path		string	true	"Role id"
+//	@Param			user_id	path		string	true	"User id"
+//	@Success		200		{object}	dto.RoleAssignmentResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles/{id}/users/{user_id} [put]
+func (h *RoleHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	h.assignment(w, r, "assign", h.deps.Service.RoleService.Assign)
+}
+
+// Unassign handler
+//
+//	@Summary		Unassign role
+//	@Description	Unassign role from user
+//	@Tags			Roles
+//	@Produce		json
+//	@Param			id		path		string	true	"Role id"
+//	@Param			user_id	path		string	true	"User id"
+//	@Success		200		{object}	dto.RoleAssignmentResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		403		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/admin/roles/{id}/users/{user_id} [delete]
+func (h *RoleHandler) Unassign(w http.ResponseWriter, r *http.Request) {
+	h.assignment(w, r, "unassign", h.deps.Service.RoleService.Unassign)
+}
+
+// assignment parses the role and user identifiers and applies the given assignment action.
+func (h *RoleHandler) assignment(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	apply func(ctx context.Context, u *user.User, data *role.Assignment) error,
+) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s role handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	roleID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s role handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s role handler parse user id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	data := &role.Assignment{RoleID: roleID, UserID: userID}
+	if err := apply(r.Context(), u, data); err != nil {
+		if errors.Is(err, role.ErrOperationForbiddenForUser) {
+			middleware.Log(r).Error().Err(err).Msgf("%s role forbidden", action)
+			httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+			return
+		}
+
+		if errors.Is(err, role.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msgf("%s role handler role not found", action)
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Role is not found"))
+			return
+		}
+
+		if errors.Is(err, user.ErrNotFound) {
+			middleware.Log(r).Debug().Err(err).Msgf("%s role handler user not found", action)
+			httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "User is not found"))
+			return
+		}
+
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s role", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.AssignmentToResponseDto(data))
+}
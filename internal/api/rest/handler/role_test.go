@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/mocks/app/mock_app"
+	"github.com/xsqrty/notes/mocks/domain/mock_role"
+	"github.com/xsqrty/notes/mocks/middleware/mock_middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+	"github.com/xsqrty/notes/tests/testutil"
+)
+
+type roleDeps struct {
+	mw      *mock_middleware.JWTAuthentication
+	service *mock_role.Service
+}
+
+func TestRoleHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	r := &role.Role{
+		ID:          uuid.Must(uuid.NewV7()),
+		Description: gofakeit.Sentence(3),
+		Permissions: []string{string(note.PermissionRead)},
+	}
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []testutil.HandlerCase[*dto.RoleRequest, *dto.RoleResponse, *roleDeps]{
+		{
+			Name:       "successful_create",
+			StatusCode: http.StatusCreated,
+			Req: &dto.RoleRequest{
+				Description: r.Description,
+				Permissions: r.Permissions,
+			},
+			Expected: dtoadapter.RoleToResponseDto(r),
+			Mocker: func(req *dto.RoleRequest, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Create(mock.Anything, u, dtoadapter.RoleRequestDtoToCreateData(req)).Return(r, nil).Once()
+			},
+		},
+		{
+			Name:       "validation_error",
+			StatusCode: http.StatusBadRequest,
+			Req:        &dto.RoleRequest{},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeValidation,
+				},
+			},
+			Mocker: func(_ *dto.RoleRequest, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "unknown_permission",
+			StatusCode: http.StatusBadRequest,
+			Req: &dto.RoleRequest{
+				Permissions: []string{"notes.unknown"},
+			},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnknownPermission,
+				},
+			},
+			Mocker: func(req *dto.RoleRequest, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Create(mock.Anything, u, dtoadapter.RoleRequestDtoToCreateData(req)).
+					Return(nil, role.ErrUnknownPermission).
+					Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			StatusCode: http.StatusForbidden,
+			Req: &dto.RoleRequest{
+				Permissions: r.Permissions,
+			},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(req *dto.RoleRequest, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().
+					Create(mock.Anything, u, dtoadapter.RoleRequestDtoToCreateData(req)).
+					Return(nil, role.ErrOperationForbiddenForUser).
+					Once()
+			},
+		},
+		{
+			Name:       "user_unauthorized",
+			StatusCode: http.StatusUnauthorized,
+			Req: &dto.RoleRequest{
+				Permissions: r.Permissions,
+			},
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnauthorized,
+				},
+			},
+			Mocker: func(_ *dto.RoleRequest, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(nil, errors.New("no user")).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_role.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodPost, "/api/v1/admin/roles", func() *roleDeps {
+				return &roleDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *roleDeps) http.HandlerFunc {
+				return NewRoleHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.RoleService = service
+				})).Create
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestRoleHandler_Delete(t *testing.T) { // nolint: dupl
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+	r := &role.Role{
+		ID:          id,
+		Description: gofakeit.Sentence(3),
+	}
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.RoleResponse, *roleDeps]{
+		{
+			Name:       "successful_delete",
+			ID:         id.String(),
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.RoleToResponseDto(r),
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Delete(mock.Anything, u, id).Return(r, nil).Once()
+			},
+		},
+		{
+			Name:       "param_error",
+			ID:         "1",
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "role_not_found",
+			ID:         id.String(),
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Delete(mock.Anything, u, id).Return(nil, role.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:       "unknown_error",
+			ID:         id.String(),
+			StatusCode: http.StatusInternalServerError,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeUnknown,
+				},
+			},
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Delete(mock.Anything, u, id).Return(nil, errors.New("unknown error")).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_role.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			tc.Run(t, http.MethodDelete, fmt.Sprintf("/api/v1/admin/roles/%s", tc.ID), func() *roleDeps {
+				return &roleDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *roleDeps) http.HandlerFunc {
+				return NewRoleHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.RoleService = service
+				})).Delete
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
+
+func TestRoleHandler_Assign(t *testing.T) {
+	t.Parallel()
+
+	roleID := uuid.Must(uuid.NewV7())
+	userID := uuid.Must(uuid.NewV7())
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+	data := &role.Assignment{RoleID: roleID, UserID: userID}
+
+	cases := []testutil.HandlerCase[struct{}, *dto.RoleAssignmentResponse, *roleDeps]{
+		{
+			Name:       "successful_assign",
+			ID:         roleID.String(),
+			Params:     map[string]string{"user_id": userID.String()},
+			StatusCode: http.StatusOK,
+			Expected:   dtoadapter.AssignmentToResponseDto(data),
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Assign(mock.Anything, u, data).Return(nil).Once()
+			},
+		},
+		{
+			Name:       "user_param_error",
+			ID:         roleID.String(),
+			Params:     map[string]string{"user_id": "1"},
+			StatusCode: http.StatusBadRequest,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeBadRequest,
+				},
+			},
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+			},
+		},
+		{
+			Name:       "user_not_found",
+			ID:         roleID.String(),
+			Params:     map[string]string{"user_id": userID.String()},
+			StatusCode: http.StatusNotFound,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeNotFound,
+				},
+			},
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Assign(mock.Anything, u, data).Return(user.ErrNotFound).Once()
+			},
+		},
+		{
+			Name:       "not_granted",
+			ID:         roleID.String(),
+			Params:     map[string]string{"user_id": userID.String()},
+			StatusCode: http.StatusForbidden,
+			ExpectedErr: &httpio.ErrorResponse{
+				Error: &errx.CodeError{
+					Code: errx.CodeForbidden,
+				},
+			},
+			Mocker: func(_ struct{}, d *roleDeps) {
+				d.mw.EXPECT().GetUser(mock.Anything).Return(u, nil).Once()
+				d.service.EXPECT().Assign(mock.Anything, u, data).Return(role.ErrOperationForbiddenForUser).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_role.NewService(t)
+			mw := mock_middleware.NewJWTAuthentication(t)
+
+			url := fmt.Sprintf("/api/v1/admin/roles/%s/users/%s", tc.ID, tc.Params["user_id"])
+			tc.Run(t, http.MethodPut, url, func() *roleDeps {
+				return &roleDeps{
+					service: service,
+					mw:      mw,
+				}
+			}, func(d *roleDeps) http.HandlerFunc {
+				return NewRoleHandler(mock_app.NewDeps(t, func(deps *app.Deps) {
+					deps.JWTAuthentication = mw
+					deps.Service.RoleService = service
+				})).Assign
+			})
+
+			mock.AssertExpectationsForObjects(t, service, mw)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/notesync"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// SyncHandler is responsible for handling HTTP requests of offline clients syncing their notes.
+type SyncHandler struct {
+	deps *app.Deps
+}
+
+// NewSyncHandler initializes and returns a new instance of SyncHandler with the provided dependencies.
+func NewSyncHandler(deps *app.Deps) *SyncHandler {
+	return &SyncHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for note sync.
+func (h *SyncHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Post("/", h.Sync)
+	return router
+}
+
+// Sync handler
+//
+//	@Summary		Sync notes
+//	@Description	Apply local changes of an offline client and get the server changes since the last sync.
+//	@Description	Updates and deletions carry the base version of the note; a note changed on the server since then
+//	@Description	is not changed and is reported as a conflict with both versions. Server changes include
+//	@Description	deleted notes. The client passes the returned token to the next sync and syncs again while
+//	@Description	has_more is set.
+//	@Tags			Sync
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.SyncRequest	true	"Sync request"
+//	@Success		200		{object}	dto.SyncResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/sync [post]
+func (h *SyncHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("sync handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.SyncRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Sync.LimitReq)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("sync handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := notesync.ParseToken(request.Token)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("sync handler parse token")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Invalid sync token"))
+		return
+	}
+
+	res, err := h.deps.Service.NoteSyncService.Sync(
+		r.Context(),
+		user,
+		dtoadapter.SyncRequestDtoToRequest(&request, token),
+	)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("couldn't sync notes")
+		httpio.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.SyncToResponseDto(res, &request))
+}
@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
+	"github.com/xsqrty/notes/internal/app"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/internal/dto"
+	"github.com/xsqrty/notes/internal/middleware"
+	"github.com/xsqrty/notes/pkg/httputil/httpio"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
+)
+
+// WebhookHandler is responsible for handling HTTP requests related to webhook endpoints.
+type WebhookHandler struct {
+	deps *app.Deps
+}
+
+// NewWebhookHandler initializes and returns a new instance of WebhookHandler with the provided dependencies.
+func NewWebhookHandler(deps *app.Deps) *WebhookHandler {
+	return &WebhookHandler{deps}
+}
+
+// Routes initialize and return a new chi.Mux router with configured routes for webhook endpoints.
+func (h *WebhookHandler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+	router.Get("/", h.List)
+	router.Post("/", h.Create)
+	router.Get("/{id}", h.Get)
+	router.Put("/{id}", h.Update)
+	router.Delete("/{id}", h.Delete)
+	router.Get("/{id}/deliveries", h.Deliveries)
+	router.Post("/{id}/test", h.SendTest)
+	return router
+}
+
+// List handler
+//
+//	@Summary		List webhooks
+//	@Description	Get webhook endpoints of the user
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Success		200	{object}	dto.WebhookListResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks [get]
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("list webhooks handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	endpoints, err := h.deps.Service.WebhookService.List(r.Context(), user)
+	if err != nil {
+		h.error(w, r, "list webhooks", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.WebhooksToListResponseDto(endpoints))
+}
+
+// Create handler
+//
+//	@Summary		Create webhook
+//	@Description	Register webhook endpoint subscribed to note events, the signing secret is returned only once
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.WebhookRequest	true	"Create webhook request"
+//	@Success		201		{object}	dto.WebhookResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks [post]
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msg("create webhook handler unauthorized")
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return
+	}
+
+	request, err := httpio.Parse[dto.WebhookRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("create webhook handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.WebhookService.Create(
+		r.Context(),
+		user,
+		dtoadapter.WebhookRequestDtoToCreateData(&request),
+	)
+	if err != nil {
+		h.error(w, r, "create webhook", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusCreated, dtoadapter.WebhookToCreatedResponseDto(res))
+}
+
+// Get handler
+//
+//	@Summary		Get webhook
+//	@Description	Get webhook endpoint by id
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id	path		string	true	"Webhook id"
+//	@Success		200	{object}	dto.WebhookResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks/{id} [get]
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "get webhook")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.WebhookService.Get(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "get webhook", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.WebhookToResponseDto(res))
+}
+
+// Update handler
+//
+//	@Summary		Update webhook
+//	@Description	Update webhook endpoint URL, event types and state, enabling the endpoint resets its failures
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Webhook id"
+//	@Param			request	body		dto.WebhookUpdateRequest	true	"Update webhook request"
+//	@Success		200		{object}	dto.WebhookResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks/{id} [put]
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "update webhook")
+	if !ok {
+		return
+	}
+
+	request, err := httpio.Parse[dto.WebhookUpdateRequest](
+		http.MaxBytesReader(w, r.Body, int64(h.deps.Config.Server.LimitReqJson)),
+	)
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("update webhook handler parse request")
+		httpio.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := h.deps.Service.WebhookService.Update(
+		r.Context(),
+		user,
+		dtoadapter.WebhookUpdateRequestDtoToUpdateData(id, &request),
+	)
+	if err != nil {
+		h.error(w, r, "update webhook", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.WebhookToResponseDto(res))
+}
+
+// Delete handler
+//
+//	@Summary		Delete webhook
+//	@Description	Delete webhook endpoint with its delivery log
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id	path		string	true	"Webhook id"
+//	@Success		200	{object}	dto.WebhookResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "delete webhook")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.WebhookService.Delete(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "delete webhook", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.WebhookToResponseDto(res))
+}
+
+// Deliveries handler
+//
+//	@Summary		List webhook deliveries
+//	@Description	Get delivery log of the webhook endpoint newest first
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id		path		string	true	"Webhook id"
+//	@Param			limit	query		int		false	"Limit"
+//	@Param			offset	query		int		false	"Offset"
+//	@Success		200		{object}	dto.WebhookDeliveryListResponse
+//	@Failure		400		{object}	httpio.ErrorResponse
+//	@Failure		401		{object}	httpio.ErrorResponse
+//	@Failure		404		{object}	httpio.ErrorResponse
+//	@Failure		500		{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "list webhook deliveries")
+	if !ok {
+		return
+	}
+
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msg("list webhook deliveries handler parse query")
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return
+	}
+
+	res, err := h.deps.Service.WebhookService.Deliveries(r.Context(), user, id, limit, offset)
+	if err != nil {
+		h.error(w, r, "list webhook deliveries", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.WebhookDeliveriesToResponseDto(res))
+}
+
+// SendTest handler
+//
+//	@Summary		Send test webhook
+//	@Description	Post test event to the webhook endpoint right away and return the delivery result
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Param			id	path		string	true	"Webhook id"
+//	@Success		200	{object}	dto.WebhookDeliveryResponse
+//	@Failure		400	{object}	httpio.ErrorResponse
+//	@Failure		401	{object}	httpio.ErrorResponse
+//	@Failure		404	{object}	httpio.ErrorResponse
+//	@Failure		500	{object}	httpio.ErrorResponse
+//	@Security		AccessTokenAuth
+//	@Router			/webhooks/{id}/test [post]
+func (h *WebhookHandler) SendTest(w http.ResponseWriter, r *http.Request) {
+	user, id, ok := h.userAndID(w, r, "send test webhook")
+	if !ok {
+		return
+	}
+
+	res, err := h.deps.Service.WebhookService.SendTest(r.Context(), user, id)
+	if err != nil {
+		h.error(w, r, "send test webhook", err)
+		return
+	}
+
+	httpio.Json(w, http.StatusOK, dtoadapter.WebhookDeliveryToResponseDto(res))
+}
+
+// userAndID extracts the authenticated user and the webhook id from the request, writing the error response on failure.
+func (h *WebhookHandler) userAndID(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+) (*user.User, uuid.UUID, bool) {
+	u, err := h.deps.JWTAuthentication.GetUser(r)
+	if err != nil {
+		middleware.Log(r).Error().Err(err).Msgf("%s handler unauthorized", action)
+		httpio.Error(w, http.StatusUnauthorized, middleware.ErrUnauthorized)
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler parse id", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Bad request"))
+		return nil, uuid.Nil, false
+	}
+
+	return u, id, true
+}
+
+// error writes the error response matching the webhook service error.
+func (h *WebhookHandler) error(w http.ResponseWriter, r *http.Request, action string, err error) {
+	switch {
+	case errors.Is(err, webhook.ErrOperationForbiddenForUser):
+		middleware.Log(r).Error().Err(err).Msgf("%s forbidden", action)
+		httpio.Error(w, http.StatusForbidden, errx.New(errx.CodeForbidden, "Operation disallowed"))
+	case errors.Is(err, webhook.ErrNotFound):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler webhook not found", action)
+		httpio.Error(w, http.StatusNotFound, errx.New(errx.CodeNotFound, "Webhook is not found"))
+	case errors.Is(err, webhook.ErrUnknownEventType):
+		middleware.Log(r).Debug().Err(err).Msgf("%s handler unknown event type", action)
+		httpio.Error(w, http.StatusBadRequest, errx.New(errx.CodeBadRequest, "Unknown event type"))
+	default:
+		middleware.Log(r).Error().Err(err).Msgf("couldn't %s", action)
+		httpio.Error(w, http.StatusInternalServerError, err)
+	}
+}
+
+// parsePage parses the limit and offset query parameters, absent parameters are left zero.
+func parsePage(q url.Values) (uint64, uint64, error) {
+	var limit, offset uint64
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return limit, offset, nil
+}
@@ -54,6 +54,15 @@ func (r *rest) Routes() *chi.Mux {
 	router.Mount("/auth", handler.NewAuthHandler(r.deps).Routes())
 	router.Mount("/healthcheck", handler.NewHealthCheckHandler(r.deps).Routes())
 	router.With(r.deps.JWTAuthentication.Verify).Mount("/notes", handler.NewNoteHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/orgs", handler.NewOrgHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/webhooks", handler.NewWebhookHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/sync", handler.NewSyncHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).
+		Mount("/notifications", handler.NewNotificationHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/admin/roles", handler.NewRoleHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/admin/policies", handler.NewPolicyHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/admin/invites", handler.NewInviteHandler(r.deps).Routes())
+	router.With(r.deps.JWTAuthentication.Verify).Mount("/admin/audit", handler.NewAuditHandler(r.deps).Routes())
 
 	entrypoint := chi.NewRouter()
 	entrypoint.Use(cors.Handler(cors.Options{
@@ -66,6 +75,7 @@ func (r *rest) Routes() *chi.Mux {
 
 	entrypoint.Use(middleware.Metrics(r.deps.Metrics.Http))
 	entrypoint.Use(middleware.RequestID)
+	entrypoint.Use(middleware.AuditSource)
 	entrypoint.Use(middleware.Logger(r.deps.Logger))
 	entrypoint.Use(middleware.Recover)
 	entrypoint.Mount(Entrypoint, router)
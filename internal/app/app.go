@@ -1,18 +1,49 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
 	"github.com/xsqrty/notes/internal/config"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/domain/audit"
 	"github.com/xsqrty/notes/internal/domain/auth"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/notes/internal/domain/collab"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/export"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/domain/link"
 	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/notesync"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/policy"
+	"github.com/xsqrty/notes/internal/domain/reminder"
 	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/stream"
+	"github.com/xsqrty/notes/internal/domain/tx"
 	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/domain/webhook"
 	"github.com/xsqrty/notes/internal/guards"
 	"github.com/xsqrty/notes/internal/logger"
 	"github.com/xsqrty/notes/internal/metrics"
 	"github.com/xsqrty/notes/internal/middleware"
 	"github.com/xsqrty/notes/internal/repository"
 	"github.com/xsqrty/notes/internal/service"
+	"github.com/xsqrty/notes/pkg/blob"
+	"github.com/xsqrty/notes/pkg/config/blobstore"
+	"github.com/xsqrty/notes/pkg/config/notifier"
+	"github.com/xsqrty/notes/pkg/lru"
+	"github.com/xsqrty/notes/pkg/mail"
 	"github.com/xsqrty/notes/pkg/passwd"
+	"github.com/xsqrty/notes/pkg/pgnotify"
+	"github.com/xsqrty/notes/pkg/rbac"
 	"github.com/xsqrty/op/db"
 )
 
@@ -24,64 +55,403 @@ type Deps struct {
 	Repository        ReposSet
 	Service           ServicesSet
 	Metrics           appMetrics
+	Policies          *rbac.PolicyEngine
+	Events            event.Dispatcher
+	Webhooks          webhook.Sender
+	collabNotifier    *pgnotify.Notifier
 }
 
 // appMetrics is a structure that holds metrics-related data for the application.
 type appMetrics struct {
-	Http *metrics.HttpMetrics
+	Http  *metrics.HttpMetrics
+	Cache *metrics.CacheMetrics
 }
 
 // ReposSet contains the main repositories used by the application.
 type ReposSet struct {
-	RoleRepository role.Repository
-	UserRepository user.Repository
-	NoteRepository note.Repository
+	RoleRepository         role.Repository
+	UserRepository         user.Repository
+	NoteRepository         note.Repository
+	OrgRepository          org.Repository
+	InviteRepository       invite.Repository
+	AuditRepository        audit.Repository
+	EventRepository        event.Repository
+	WebhookRepository      webhook.Repository
+	CollabRepository       collab.Repository
+	SyncRepository         notesync.Repository
+	AttachmentRepository   attachment.Repository
+	ImportRepository       noteimport.Repository
+	ReminderRepository     reminder.Repository
+	NotificationRepository notification.Repository
+	DeviceRepository       user.DeviceRepository
+	ChecklistRepository    checklist.Repository
+	LinkRepository         link.Repository
+	GraphRepository        graph.Repository
 }
 
 // ServicesSet contains the main services used by the application.
 type ServicesSet struct {
-	AuthService auth.Service
-	NoteService note.Service
+	AuthService         auth.Service
+	NoteService         note.Service
+	RoleService         role.Service
+	PolicyService       policy.Service
+	OrgService          org.Service
+	InviteService       invite.Service
+	AuditService        audit.Service
+	WebhookService      webhook.Service
+	StreamService       stream.Service
+	CollabService       collab.Service
+	NoteSyncService     notesync.Service
+	AttachmentService   attachment.Service
+	ExportService       export.Service
+	NoteImportService   noteimport.Service
+	ReminderService     reminder.Service
+	NotificationService notification.Service
+	ChecklistService    checklist.Service
+	LinkService         link.Service
+	GraphService        graph.Service
 }
 
 // NewDeps initializes and returns a Deps struct populated with configuration, logger, repositories, services, and metrics.
-func NewDeps(config *config.Config, log *logger.Logger, pool db.ConnPool) *Deps {
+func NewDeps(
+	config *config.Config,
+	log *logger.Logger,
+	pool db.ConnPool,
+	blobs attachment.BlobStore,
+) *Deps {
+	txManager := tx.WithHooks(pool)
+	cacheMetrics := metrics.NewCacheMetrics(config.Metrics)
 	roleRepo := repository.NewRoleRepository(pool)
+	if config.Cache.Enabled {
+		roleRepo = repository.NewCachedRoleRepository(
+			roleRepo,
+			lru.New[uuid.UUID, []role.Permission](config.Cache.Size, config.Cache.TTL),
+			cacheMetrics,
+		)
+	}
+
 	userRepo := repository.NewUserRepo(pool)
 	noteRepo := repository.NewNoteRepo(pool)
+	orgRepo := repository.NewOrgRepository(pool)
+	inviteRepo := repository.NewInviteRepository(pool)
+	auditRepo := repository.NewAuditRepository(pool)
+	eventRepo := repository.NewEventRepository(pool)
+	webhookRepo := repository.NewWebhookRepository(pool)
+	collabRepo := repository.NewCollabRepository(pool)
+	syncRepo := repository.NewNoteSyncRepository(pool)
+	attachmentRepo := repository.NewAttachmentRepository(pool)
+	importRepo := repository.NewNoteImportRepository(pool)
+	reminderRepo := repository.NewReminderRepository(pool)
+	notificationRepo := repository.NewNotificationRepository(pool)
+	deviceRepo := repository.NewDeviceRepository(pool)
+	checklistRepo := repository.NewChecklistRepository(pool)
+	linkRepo := repository.NewLinkRepository(pool)
+	graphRepo := repository.NewGraphRepository(pool)
+	collabNotifier := pgnotify.NewNotifier(config.DB.DSN, collab.Channel)
 
 	jwtAuth := middleware.NewJWTAuthentication(&config.Auth, userRepo)
 	passGenerator := passwd.NewPasswordGenerator(config.Auth.PasswordCost)
+	permissions := role.NewRegistry(slices.Concat(
+		role.Permissions(),
+		note.Permissions(),
+		policy.Permissions(),
+		invite.Permissions(),
+		audit.Permissions(),
+	)...)
+	policies := rbac.NewPolicyEngine()
+	noteGuard := guards.NewNoteGuarder(roleRepo, orgRepo, policies)
+
+	events := service.NewEventDispatcher(&service.EventDispatcherDeps{
+		TxManager:     txManager,
+		EventRepo:     eventRepo,
+		PollInterval:  config.Outbox.PollInterval,
+		BatchSize:     config.Outbox.BatchSize,
+		MaxAttempts:   config.Outbox.MaxAttempts,
+		RetryDelay:    config.Outbox.RetryDelay,
+		MaxRetryDelay: config.Outbox.MaxRetryDelay,
+		ClaimTimeout:  config.Outbox.ClaimTimeout,
+	})
+	webhooks := service.NewWebhookSender(&service.WebhookSenderDeps{
+		TxManager:     txManager,
+		WebhookRepo:   webhookRepo,
+		Client:        &http.Client{Timeout: config.Webhook.Timeout},
+		PollInterval:  config.Webhook.PollInterval,
+		BatchSize:     config.Webhook.BatchSize,
+		MaxAttempts:   config.Webhook.MaxAttempts,
+		RetryDelay:    config.Webhook.RetryDelay,
+		MaxRetryDelay: config.Webhook.MaxRetryDelay,
+		DisableAfter:  config.Webhook.DisableAfter,
+	})
+	webhookService := service.NewWebhookService(&service.WebhookServiceDeps{
+		WebhookRepo:  webhookRepo,
+		WebhookGuard: guards.NewWebhookGuarder(),
+		Sender:       webhooks,
+		UserRepo:     userRepo,
+		OrgRepo:      orgRepo,
+		NoteGuard:    noteGuard,
+	})
+	events.Subscribe("webhooks", webhookService.HandleEvent, webhook.EventTypes()...)
+	noteService := service.NewNoteService(&service.NoteServiceDeps{
+		TxManager:   txManager,
+		NoteRepo:    noteRepo,
+		NoteGuard:   noteGuard,
+		Audit:       auditRepo,
+		Events:      eventRepo,
+		RenderCache: lru.New[note.RenderKey, *note.Rendered](config.Render.CacheSize, config.Render.CacheTTL),
+	})
+	attachmentService := service.NewAttachmentService(&service.AttachmentServiceDeps{
+		TxManager:      txManager,
+		AttachmentRepo: attachmentRepo,
+		NoteRepo:       noteRepo,
+		NoteGuard:      noteGuard,
+		Blobs:          blobs,
+		MaxFileSize:    int64(config.Attachment.MaxFileSize),
+		MaxUserSize:    int64(config.Attachment.MaxUserSize),
+	})
+	events.Subscribe("attachments", attachmentService.HandleEvent, event.TypeNoteDeleted)
+	linkService := service.NewLinkService(&service.LinkServiceDeps{
+		LinkRepo:  linkRepo,
+		NoteRepo:  noteRepo,
+		NoteGuard: noteGuard,
+		Events:    eventRepo,
+		TxManager: txManager,
+	})
+	events.Subscribe(
+		"links",
+		linkService.HandleEvent,
+		event.TypeNoteCreated,
+		event.TypeNoteUpdated,
+		event.TypeNoteDeleted,
+	)
+	if slices.Contains(config.Reminder.Notifiers, notifier.Email) {
+		mailer := mail.NewSMTP(mail.SMTPConfig{
+			Addr:     config.Mail.SMTPAddr,
+			Username: config.Mail.Username,
+			Password: config.Mail.Password,
+			From:     config.Mail.From,
+			Timeout:  config.Mail.Timeout,
+		})
+		events.Subscribe("reminder_emails", service.NewReminderEmailHandler(mailer), event.TypeReminderEmail)
+	}
+	notificationService := service.NewNotificationService(&service.NotificationServiceDeps{
+		TxManager:        txManager,
+		NotificationRepo: notificationRepo,
+		PageSize:         config.Notification.PageSize,
+		MaxPageSize:      config.Notification.MaxPageSize,
+		Retention:        config.Notification.Retention,
+		CleanupInterval:  config.Notification.CleanupInterval,
+	})
 
 	return &Deps{
 		Logger:            log,
 		Config:            config,
 		JWTAuthentication: jwtAuth,
 		Repository: ReposSet{
-			RoleRepository: roleRepo,
-			UserRepository: userRepo,
-			NoteRepository: noteRepo,
+			RoleRepository:         roleRepo,
+			UserRepository:         userRepo,
+			NoteRepository:         noteRepo,
+			OrgRepository:          orgRepo,
+			InviteRepository:       inviteRepo,
+			AuditRepository:        auditRepo,
+			EventRepository:        eventRepo,
+			WebhookRepository:      webhookRepo,
+			CollabRepository:       collabRepo,
+			SyncRepository:         syncRepo,
+			AttachmentRepository:   attachmentRepo,
+			ImportRepository:       importRepo,
+			ReminderRepository:     reminderRepo,
+			NotificationRepository: notificationRepo,
+			DeviceRepository:       deviceRepo,
+			ChecklistRepository:    checklistRepo,
+			LinkRepository:         linkRepo,
+			GraphRepository:        graphRepo,
 		},
 		Service: ServicesSet{
 			AuthService: service.NewAuthService(&service.AuthServiceDeps{
-				TxManager: pool,
+				TxManager:    txManager,
+				RoleRepo:     roleRepo,
+				UserRepo:     userRepo,
+				InviteRepo:   inviteRepo,
+				Tokenizer:    jwtAuth,
+				PassGen:      passGenerator,
+				Audit:        auditRepo,
+				Events:       eventRepo,
+				DeviceRepo:   deviceRepo,
+				Notifier:     notificationService,
+				Registration: config.Auth.Registration,
+				OnError: func(err error) {
+					log.Warn().Err(err).Msg("Sign-in device error")
+				},
+			}),
+			NoteService: noteService,
+			RoleService: service.NewRoleService(&service.RoleServiceDeps{
+				TxManager: txManager,
 				RoleRepo:  roleRepo,
 				UserRepo:  userRepo,
-				Tokenizer: jwtAuth,
-				PassGen:   passGenerator,
+				RoleGuard: guards.NewRoleGuarder(roleRepo),
+				Registry:  permissions,
+				Audit:     auditRepo,
+			}),
+			PolicyService: service.NewPolicyService(&service.PolicyServiceDeps{
+				Engine:         policies,
+				PolicyGuard:    guards.NewPolicyGuarder(roleRepo),
+				UserRepo:       userRepo,
+				NoteRepo:       noteRepo,
+				NoteGuard:      noteGuard,
+				NoteAttributer: guards.NewNoteAttributer(roleRepo, orgRepo),
+			}),
+			OrgService: service.NewOrgService(&service.OrgServiceDeps{
+				TxManager: txManager,
+				OrgRepo:   orgRepo,
+				UserRepo:  userRepo,
+				OrgGuard:  guards.NewOrgGuarder(orgRepo),
+				Audit:     auditRepo,
+				Notifier:  notificationService,
+			}),
+			InviteService: service.NewInviteService(&service.InviteServiceDeps{
+				TxManager:   txManager,
+				InviteRepo:  inviteRepo,
+				RoleRepo:    roleRepo,
+				InviteGuard: guards.NewInviteGuarder(roleRepo),
+				Audit:       auditRepo,
+			}),
+			AuditService: service.NewAuditService(&service.AuditServiceDeps{
+				AuditRepo:  auditRepo,
+				AuditGuard: guards.NewAuditGuarder(roleRepo),
+			}),
+			WebhookService: webhookService,
+			StreamService: service.NewNoteStream(&service.NoteStreamDeps{
+				Listener:   pgnotify.NewListener(config.DB.DSN, stream.Channel),
+				NoteGuard:  noteGuard,
+				ReplaySize: config.Stream.ReplaySize,
+				BufferSize: config.Stream.BufferSize,
+				RetryDelay: config.Stream.RetryDelay,
+			}),
+			CollabService: service.NewCollabService(&service.CollabServiceDeps{
+				TxManager:       txManager,
+				NoteRepo:        noteRepo,
+				NoteGuard:       noteGuard,
+				CollabRepo:      collabRepo,
+				Events:          eventRepo,
+				Notifier:        collabNotifier,
+				Listener:        pgnotify.NewListener(config.DB.DSN, collab.Channel),
+				CompactInterval: config.Collab.CompactInterval,
+				MaxTextLength:   config.Collab.MaxTextLength,
+				BufferSize:      config.Collab.BufferSize,
+				RetryDelay:      config.Collab.RetryDelay,
 			}),
-			NoteService: service.NewNoteService(&service.NoteServiceDeps{
-				NoteRepo:  noteRepo,
-				NoteGuard: guards.NewNoteGuarder(roleRepo),
+			NoteSyncService: service.NewNoteSyncService(&service.NoteSyncServiceDeps{
+				Notes:     noteService,
+				SyncRepo:  syncRepo,
+				NoteGuard: noteGuard,
+				PageSize:  config.Sync.PageSize,
+			}),
+			AttachmentService: attachmentService,
+			ExportService:     service.NewExportService(&service.ExportServiceDeps{Notes: noteService}),
+			NoteImportService: service.NewNoteImportService(&service.NoteImportServiceDeps{
+				TxManager:     txManager,
+				ImportRepo:    importRepo,
+				UserRepo:      userRepo,
+				Notes:         noteService,
+				Blobs:         blobs,
+				MaxFileSize:   int64(config.Import.MaxFileSize),
+				MaxNoteSize:   int64(config.Import.MaxNoteSize),
+				MaxNotes:      config.Import.MaxNotes,
+				MaxTextLength: config.Import.MaxTextLength,
+				MaxAttempts:   config.Import.MaxAttempts,
+				PollInterval:  config.Import.PollInterval,
+				ClaimTimeout:  config.Import.ClaimTimeout,
+			}),
+			ReminderService: service.NewReminderService(&service.ReminderServiceDeps{
+				TxManager:    txManager,
+				ReminderRepo: reminderRepo,
+				UserRepo:     userRepo,
+				NoteRepo:     noteRepo,
+				NoteGuard:    noteGuard,
+				Notifiers:    reminderNotifiers(config, notificationService, eventRepo),
+				PollInterval: config.Reminder.PollInterval,
+				BatchSize:    config.Reminder.BatchSize,
+				RetryDelay:   config.Reminder.RetryDelay,
+				MaxAttempts:  config.Reminder.MaxAttempts,
+			}),
+			NotificationService: notificationService,
+			ChecklistService: service.NewChecklistService(&service.ChecklistServiceDeps{
+				TxManager:     txManager,
+				ChecklistRepo: checklistRepo,
+				NoteRepo:      noteRepo,
+				UserRepo:      userRepo,
+				NoteGuard:     noteGuard,
+				Audit:         auditRepo,
+				Events:        eventRepo,
+				MaxItems:      config.Checklist.MaxItems,
+			}),
+			LinkService: linkService,
+			GraphService: service.NewGraphService(&service.GraphServiceDeps{
+				GraphRepo:    graphRepo,
+				NoteGuard:    noteGuard,
+				Nodes:        config.Graph.Nodes,
+				MaxNodes:     config.Graph.MaxNodes,
+				MaxEdges:     config.Graph.MaxEdges,
+				MaxDepth:     config.Graph.MaxDepth,
+				SimilarLimit: config.Graph.SimilarLimit,
 			}),
 		},
 		Metrics: appMetrics{
-			Http: metrics.NewHttpMetrics(config.Metrics),
+			Http:  metrics.NewHttpMetrics(config.Metrics),
+			Cache: cacheMetrics,
 		},
+		Policies:       policies,
+		Events:         events,
+		Webhooks:       webhooks,
+		collabNotifier: collabNotifier,
+	}
+}
+
+// NewBlobStore creates the blob store of attachment files selected by the configuration.
+func NewBlobStore(config *config.BlobConfig) (attachment.BlobStore, error) {
+	switch config.Store {
+	case blobstore.S3:
+		return blob.NewS3(blob.S3Config{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+		}, &http.Client{})
+	case blobstore.FS:
+		return blob.NewFS(config.FSDir)
+	default:
+		return nil, fmt.Errorf("unknown blob store: %s", config.Store)
 	}
 }
 
 // Close releases Deps resources.
 func (d *Deps) Close() error {
-	return d.JWTAuthentication.Close()
+	err := d.JWTAuthentication.Close()
+	if d.collabNotifier != nil {
+		err = errors.Join(err, d.collabNotifier.Close())
+	}
+
+	return err
+}
+
+// reminderNotifiers returns the notifiers delivering fired reminders configured for the application.
+func reminderNotifiers(
+	config *config.Config,
+	notifications notification.Notifier,
+	events event.Publisher,
+) []reminder.Notifier {
+	notifiers := make([]reminder.Notifier, 0, len(config.Reminder.Notifiers))
+	for _, kind := range config.Reminder.Notifiers {
+		switch kind {
+		case notifier.Inbox:
+			notifiers = append(notifiers, service.NewInboxNotifier(notifications))
+		case notifier.Webhook:
+			notifiers = append(notifiers, service.NewWebhookNotifier(events))
+		case notifier.Email:
+			notifiers = append(notifiers, service.NewEmailNotifier(events))
+		}
+	}
+
+	return notifiers
 }
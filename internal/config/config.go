@@ -1,29 +1,51 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/spf13/pflag"
+	"github.com/xsqrty/notes/pkg/config/blobstore"
 	"github.com/xsqrty/notes/pkg/config/formatter"
 	"github.com/xsqrty/notes/pkg/config/mode"
+	"github.com/xsqrty/notes/pkg/config/notifier"
+	"github.com/xsqrty/notes/pkg/config/registration"
 	"github.com/xsqrty/notes/pkg/config/size"
 	"github.com/xsqrty/notes/pkg/help"
 )
 
 // Config is a central configuration for the application, defining environment-based settings and services' parameters.
 type Config struct {
-	Mode    mode.Mode `env:"MODE" envDefault:"dev" envDescription:"Application mode: dev, prod"`
-	DB      DBConfig
-	Auth    AuthConfig
-	Server  ServerConfig
-	Logger  LoggerConfig
-	Cors    CorsConfig
-	Swag    SwagConfig
-	Metrics MetricsConfig
-	Version string
-	AppName string
+	Mode         mode.Mode `env:"MODE" envDefault:"dev" envDescription:"Application mode: dev, prod"`
+	DB           DBConfig
+	Auth         AuthConfig
+	Cache        PermissionsCacheConfig
+	Policy       PolicyConfig
+	Outbox       OutboxConfig
+	Webhook      WebhookConfig
+	Stream       StreamConfig
+	Collab       CollabConfig
+	Sync         SyncConfig
+	Render       RenderConfig
+	Blob         BlobConfig
+	Attachment   AttachmentConfig
+	Import       ImportConfig
+	Batch        BatchConfig
+	Reminder     ReminderConfig
+	Mail         MailConfig
+	Notification NotificationConfig
+	Checklist    ChecklistConfig
+	Graph        GraphConfig
+	Server       ServerConfig
+	Logger       LoggerConfig
+	Cors         CorsConfig
+	Swag         SwagConfig
+	Metrics      MetricsConfig
+	Version      string
+	AppName      string
 }
 
 // MetricsConfig represents the configuration for metrics.
@@ -53,9 +75,146 @@ type CorsConfig struct {
 
 // AuthConfig holds authentication-related configuration settings.
 type AuthConfig struct {
-	AccessTokenExp  time.Duration `env:"ACCESS_TOKEN_EXPIRES"  envDefault:"15m" envDescription:"Access token expiration"`
-	RefreshTokenExp time.Duration `env:"REFRESH_TOKEN_EXPIRES" envDefault:"1h"  envDescription:"Refresh token expiration"`
-	PasswordCost    int           `env:"PASSWORD_COST"         envDefault:"8"   envDescription:"Password cost"`
+	AccessTokenExp  time.Duration     `env:"ACCESS_TOKEN_EXPIRES"  envDefault:"15m"  envDescription:"Access token expiration"`
+	RefreshTokenExp time.Duration     `env:"REFRESH_TOKEN_EXPIRES" envDefault:"1h"   envDescription:"Refresh token expiration"`
+	PasswordCost    int               `env:"PASSWORD_COST"         envDefault:"8"    envDescription:"Password cost"`
+	Registration    registration.Mode `env:"REGISTRATION_MODE"     envDefault:"open" envDescription:"Registration mode: open, invite_only, closed"`
+}
+
+// PolicyConfig holds settings of the declarative access policies.
+type PolicyConfig struct {
+	File           string        `env:"POLICY_FILE"            envDefault:""    envDescription:"Access policies JSON file"`
+	ReloadInterval time.Duration `env:"POLICY_RELOAD_INTERVAL" envDefault:"10s" envDescription:"Access policies file reload check interval"`
+}
+
+// OutboxConfig holds settings of the domain events outbox dispatcher.
+type OutboxConfig struct {
+	PollInterval  time.Duration `env:"OUTBOX_POLL_INTERVAL"   envDefault:"1s"  envDescription:"Outbox poll interval"`
+	BatchSize     uint64        `env:"OUTBOX_BATCH_SIZE"      envDefault:"100" envDescription:"Outbox events dispatched at once"`
+	MaxAttempts   int           `env:"OUTBOX_MAX_ATTEMPTS"    envDefault:"10"  envDescription:"Outbox delivery attempts before dead-lettering"`
+	RetryDelay    time.Duration `env:"OUTBOX_RETRY_DELAY"     envDefault:"1s"  envDescription:"Outbox delay before the first retry"`
+	MaxRetryDelay time.Duration `env:"OUTBOX_MAX_RETRY_DELAY" envDefault:"10m" envDescription:"Outbox max delay between retries"`
+	ClaimTimeout  time.Duration `env:"OUTBOX_CLAIM_TIMEOUT"   envDefault:"5m"  envDescription:"Outbox event redelivered after claim silence"`
+}
+
+// WebhookConfig holds settings of the outgoing webhooks.
+type WebhookConfig struct {
+	PollInterval  time.Duration `env:"WEBHOOK_POLL_INTERVAL"   envDefault:"1s"  envDescription:"Webhook deliveries poll interval"`
+	BatchSize     uint64        `env:"WEBHOOK_BATCH_SIZE"      envDefault:"20"  envDescription:"Webhook deliveries sent at once"`
+	Timeout       time.Duration `env:"WEBHOOK_TIMEOUT"         envDefault:"10s" envDescription:"Webhook request timeout"`
+	MaxAttempts   int           `env:"WEBHOOK_MAX_ATTEMPTS"    envDefault:"8"   envDescription:"Webhook delivery attempts"`
+	RetryDelay    time.Duration `env:"WEBHOOK_RETRY_DELAY"     envDefault:"10s" envDescription:"Webhook delay before the first retry"`
+	MaxRetryDelay time.Duration `env:"WEBHOOK_MAX_RETRY_DELAY" envDefault:"1h"  envDescription:"Webhook max delay between retries"`
+	DisableAfter  int           `env:"WEBHOOK_DISABLE_AFTER"   envDefault:"20"  envDescription:"Webhook consecutive failed attempts disabling the endpoint"`
+}
+
+// StreamConfig holds settings of the real-time note change stream.
+type StreamConfig struct {
+	HeartbeatInterval time.Duration `env:"STREAM_HEARTBEAT_INTERVAL" envDefault:"15s"  envDescription:"Note stream heartbeat interval"`
+	ReplaySize        int           `env:"STREAM_REPLAY_SIZE"        envDefault:"1000" envDescription:"Note stream events kept for resume"`
+	BufferSize        int           `env:"STREAM_BUFFER_SIZE"        envDefault:"100"  envDescription:"Note stream events queued per subscriber"`
+	RetryDelay        time.Duration `env:"STREAM_RETRY_DELAY"        envDefault:"1s"   envDescription:"Note stream delay before listening again"`
+}
+
+// CollabConfig holds settings of the collaborative note editing.
+type CollabConfig struct {
+	CompactInterval time.Duration `env:"COLLAB_COMPACT_INTERVAL" envDefault:"10s"  envDescription:"Collaborative document compaction interval"`
+	MaxTextLength   int           `env:"COLLAB_MAX_TEXT_LENGTH"  envDefault:"2000" envDescription:"Collaborative note text max length"`
+	BufferSize      int           `env:"COLLAB_BUFFER_SIZE"      envDefault:"256"  envDescription:"Collaboration messages queued per editor"`
+	RetryDelay      time.Duration `env:"COLLAB_RETRY_DELAY"      envDefault:"1s"   envDescription:"Collaboration delay before listening again"`
+}
+
+// SyncConfig holds settings of the offline note sync.
+type SyncConfig struct {
+	PageSize uint64     `env:"SYNC_PAGE_SIZE" envDefault:"500" envDescription:"Server changes returned per sync"`
+	LimitReq size.Bytes `env:"SYNC_LIMIT_REQ" envDefault:"1mb" envDescription:"Limit sync request size"`
+}
+
+// RenderConfig holds settings of the in-process cache of rendered notes.
+type RenderConfig struct {
+	CacheSize int           `env:"RENDER_CACHE_SIZE" envDefault:"1000" envDescription:"Rendered notes cache max entries"`
+	CacheTTL  time.Duration `env:"RENDER_CACHE_TTL"  envDefault:"1h"   envDescription:"Rendered notes cache entry TTL"`
+}
+
+// BlobConfig holds settings of the blob store keeping attachment files.
+type BlobConfig struct {
+	Store       blobstore.Kind `env:"BLOB_STORE"         envDefault:"fs"         envDescription:"Blob store: fs, s3"`
+	FSDir       string         `env:"BLOB_FS_DIR"        envDefault:"data/blobs" envDescription:"Blob store directory (fs)"`
+	S3Endpoint  string         `env:"BLOB_S3_ENDPOINT"   envDefault:""           envDescription:"Blob store endpoint url (s3)"`
+	S3Region    string         `env:"BLOB_S3_REGION"     envDefault:"us-east-1"  envDescription:"Blob store region (s3)"`
+	S3Bucket    string         `env:"BLOB_S3_BUCKET"     envDefault:"notes"      envDescription:"Blob store bucket (s3)"`
+	S3AccessKey string         `env:"BLOB_S3_ACCESS_KEY" envDefault:""           envDescription:"Blob store access key (s3)"`
+	S3SecretKey string         `env:"BLOB_S3_SECRET_KEY" envDefault:""           envDescription:"Blob store secret key (s3)"`
+}
+
+// AttachmentConfig holds the size limits of attachment files.
+type AttachmentConfig struct {
+	MaxFileSize size.Bytes `env:"ATTACHMENT_MAX_FILE_SIZE" envDefault:"10mb"  envDescription:"Attachment file max size"`
+	MaxUserSize size.Bytes `env:"ATTACHMENT_MAX_USER_SIZE" envDefault:"100mb" envDescription:"Attachments total max size per user"`
+}
+
+// ImportConfig holds settings of the background import of notes.
+type ImportConfig struct {
+	MaxFileSize   size.Bytes    `env:"IMPORT_MAX_FILE_SIZE"   envDefault:"50mb"  envDescription:"Import file max size"`
+	MaxNoteSize   size.Bytes    `env:"IMPORT_MAX_NOTE_SIZE"   envDefault:"1mb"   envDescription:"Imported Markdown file max size"`
+	MaxNotes      int           `env:"IMPORT_MAX_NOTES"       envDefault:"10000" envDescription:"Notes max count per import"`
+	MaxTextLength int           `env:"IMPORT_MAX_TEXT_LENGTH" envDefault:"2000"  envDescription:"Imported note text max length"`
+	MaxAttempts   int           `env:"IMPORT_MAX_ATTEMPTS"    envDefault:"5"     envDescription:"Import job claims before failing"`
+	PollInterval  time.Duration `env:"IMPORT_POLL_INTERVAL"   envDefault:"1s"    envDescription:"Import jobs poll interval"`
+	ClaimTimeout  time.Duration `env:"IMPORT_CLAIM_TIMEOUT"   envDefault:"1m"    envDescription:"Import job resumed after worker silence"`
+}
+
+// BatchConfig holds the limits of batches of note operations.
+type BatchConfig struct {
+	MaxOperations int        `env:"BATCH_MAX_OPERATIONS" envDefault:"100" envDescription:"Operations max count per note batch"`
+	LimitReq      size.Bytes `env:"BATCH_LIMIT_REQ"      envDefault:"1mb" envDescription:"Limit note batch request size"`
+}
+
+// ReminderConfig represents the configuration for note reminders.
+type ReminderConfig struct {
+	PollInterval time.Duration   `env:"REMINDER_POLL_INTERVAL" envDefault:"10s"           envDescription:"Due reminders poll interval"`
+	BatchSize    int             `env:"REMINDER_BATCH_SIZE"    envDefault:"100"           envDescription:"Reminders fired per poll at most"`
+	RetryDelay   time.Duration   `env:"REMINDER_RETRY_DELAY"   envDefault:"1m"            envDescription:"Reminder delay before firing again after a failed notification"`
+	MaxAttempts  int             `env:"REMINDER_MAX_ATTEMPTS"  envDefault:"5"             envDescription:"Reminder failed firings before the occurrence is skipped"`
+	Notifiers    []notifier.Kind `env:"REMINDER_NOTIFIERS"     envDefault:"inbox,webhook" envDescription:"Reminder notifiers: inbox, webhook, email"`
+}
+
+// MailConfig represents the configuration for sending emails.
+type MailConfig struct {
+	SMTPAddr string        `env:"MAIL_SMTP_ADDR"     envDefault:""                envDescription:"SMTP server host:port, required by email notifications"`
+	Username string        `env:"MAIL_SMTP_USERNAME" envDefault:""                envDescription:"SMTP username"`
+	Password string        `env:"MAIL_SMTP_PASSWORD" envDefault:""                envDescription:"SMTP password"`
+	From     string        `env:"MAIL_FROM"          envDefault:"notes@localhost" envDescription:"Sender email address"`
+	Timeout  time.Duration `env:"MAIL_TIMEOUT"       envDefault:"10s"             envDescription:"Email sending timeout"`
+}
+
+// NotificationConfig represents the configuration for the in-app notifications inbox.
+type NotificationConfig struct {
+	PageSize        uint64        `env:"NOTIFICATION_PAGE_SIZE"        envDefault:"20"    envDescription:"Default notifications page size"`
+	MaxPageSize     uint64        `env:"NOTIFICATION_MAX_PAGE_SIZE"    envDefault:"100"   envDescription:"Max notifications page size"`
+	Retention       time.Duration `env:"NOTIFICATION_RETENTION"        envDefault:"2160h" envDescription:"Notifications older than the retention are removed"`
+	CleanupInterval time.Duration `env:"NOTIFICATION_CLEANUP_INTERVAL" envDefault:"1h"    envDescription:"Old notifications cleanup interval"`
+}
+
+// ChecklistConfig holds the limits of checklists of notes.
+type ChecklistConfig struct {
+	MaxItems int `env:"CHECKLIST_MAX_ITEMS" envDefault:"200" envDescription:"Checklist items max count per note"`
+}
+
+// GraphConfig holds the limits of the note graph.
+type GraphConfig struct {
+	Nodes        int `env:"GRAPH_NODES"         envDefault:"100"  envDescription:"Default nodes count of the note graph"`
+	MaxNodes     int `env:"GRAPH_MAX_NODES"     envDefault:"500"  envDescription:"Note graph nodes max count"`
+	MaxEdges     int `env:"GRAPH_MAX_EDGES"     envDefault:"2000" envDescription:"Note graph edges max count"`
+	MaxDepth     int `env:"GRAPH_MAX_DEPTH"     envDefault:"3"    envDescription:"Note graph neighbourhood max depth"`
+	SimilarLimit int `env:"GRAPH_SIMILAR_LIMIT" envDefault:"20"   envDescription:"Similar notes looked up per note of the neighbourhood"`
+}
+
+// PermissionsCacheConfig holds settings of the in-process cache of users' permissions.
+type PermissionsCacheConfig struct {
+	Enabled bool          `env:"PERMISSIONS_CACHE_ENABLED" envDefault:"true"  envDescription:"Enable permissions cache"`
+	Size    int           `env:"PERMISSIONS_CACHE_SIZE"    envDefault:"10000" envDescription:"Permissions cache max entries"`
+	TTL     time.Duration `env:"PERMISSIONS_CACHE_TTL"     envDefault:"30s"   envDescription:"Permissions cache entry TTL"`
 }
 
 // LoggerConfig represents the configuration settings for the logger.
@@ -128,6 +287,10 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
+	if slices.Contains(config.Reminder.Notifiers, notifier.Email) && config.Mail.SMTPAddr == "" {
+		return nil, errors.New("parse config: email reminders require MAIL_SMTP_ADDR")
+	}
+
 	config.Version = Version
 	config.AppName = AppName
 
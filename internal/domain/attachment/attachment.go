@@ -0,0 +1,52 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound      = errors.New("attachment not found")
+	ErrFileTooLarge  = errors.New("attachment file is too large")
+	ErrQuotaExceeded = errors.New("attachments quota of user is exceeded")
+	ErrEmptyFile     = errors.New("attachment file is empty")
+)
+
+// Attachment represents a file attached to a note. The content is kept in the blob store under the key.
+type Attachment struct {
+	ID          uuid.UUID `op:"id,primary"`
+	NoteID      uuid.UUID `op:"note_id"`
+	UserID      uuid.UUID `op:"user_id"`
+	Name        string    `op:"name"`
+	ContentType string    `op:"content_type"`
+	Size        int64     `op:"size"`
+	CreatedAt   time.Time `op:"created_at"`
+}
+
+// Usage represents the total size of the files attached by the user.
+type Usage struct {
+	UserID uuid.UUID `op:"user_id"`
+	Size   int64     `op:"size"`
+}
+
+// UploadData represents the file uploaded to the note.
+type UploadData struct {
+	NoteID  uuid.UUID
+	Name    string
+	Content io.Reader
+}
+
+// Download represents the attachment opened for reading.
+type Download struct {
+	Attachment *Attachment
+	Content    io.ReadSeekCloser
+}
+
+// Key returns the key of the attachment content in the blob store.
+func (a *Attachment) Key() string {
+	return fmt.Sprintf("notes/%s/%s", a.NoteID, a.ID)
+}
@@ -0,0 +1,14 @@
+package attachment
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore defines the storage of attachment files. Opened blobs are seekable, so they can be served
+// with range requests.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,17 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines methods for managing attachments of notes.
+type Repository interface {
+	GetByID(ctx context.Context, noteID uuid.UUID, id uuid.UUID) (*Attachment, error)
+	GetByNote(ctx context.Context, noteID uuid.UUID) ([]*Attachment, error)
+	GetUsage(ctx context.Context, userID uuid.UUID) (*Usage, error)
+	LockUsage(ctx context.Context, userID uuid.UUID) error
+	Save(ctx context.Context, a *Attachment) error
+	Delete(ctx context.Context, a *Attachment) error
+}
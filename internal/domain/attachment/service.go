@@ -0,0 +1,19 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service attachments service interface. Attachments follow the access to their notes: users reading the note
+// list and download attachments, users updating the note upload and delete them.
+type Service interface {
+	Upload(ctx context.Context, user *user.User, data *UploadData) (*Attachment, error)
+	List(ctx context.Context, user *user.User, noteID uuid.UUID) ([]*Attachment, error)
+	Download(ctx context.Context, user *user.User, noteID uuid.UUID, id uuid.UUID) (*Download, error)
+	Delete(ctx context.Context, user *user.User, noteID uuid.UUID, id uuid.UUID) (*Attachment, error)
+	HandleEvent(ctx context.Context, e *event.Event) error
+}
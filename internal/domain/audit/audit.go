@@ -0,0 +1,197 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/role"
+)
+
+// Action represents the kind of the audited event.
+type Action string
+
+var ErrOperationForbiddenForUser = errors.New("audit operation is forbidden for user")
+
+const (
+	// ActionLogin is recorded when the user logs in.
+	ActionLogin Action = "auth.login"
+	// ActionLoginFailed is recorded when the login attempt fails.
+	ActionLoginFailed Action = "auth.login_failed"
+	// ActionSignUp is recorded when the user signs up.
+	ActionSignUp Action = "auth.signup"
+	// ActionNoteCreate is recorded when the note is created.
+	ActionNoteCreate Action = "note.create"
+	// ActionNoteUpdate is recorded when the note is updated.
+	ActionNoteUpdate Action = "note.update"
+	// ActionNoteFlag is recorded when the flags of the note are changed without changing its content.
+	ActionNoteFlag Action = "note.flag"
+	// ActionNoteChecklist is recorded when the checklist of the note is changed.
+	ActionNoteChecklist Action = "note.checklist"
+	// ActionNoteDelete is recorded when the note is deleted.
+	ActionNoteDelete Action = "note.delete"
+	// ActionOrgInvite is recorded when the organisation is shared with an invited email.
+	ActionOrgInvite Action = "org.invite"
+	// ActionOrgInviteRevoke is recorded when the organisation invitation is revoked.
+	ActionOrgInviteRevoke Action = "org.invite_revoke"
+	// ActionOrgInviteAccept is recorded when the user joins the organisation by invitation.
+	ActionOrgInviteAccept Action = "org.invite_accept"
+	// ActionOrgMemberUpdate is recorded when the role of the organisation member is changed.
+	ActionOrgMemberUpdate Action = "org.member_update"
+	// ActionOrgMemberRemove is recorded when the member leaves or is removed from the organisation.
+	ActionOrgMemberRemove Action = "org.member_remove"
+	// ActionRoleCreate is recorded when the role is created.
+	ActionRoleCreate Action = "role.create"
+	// ActionRoleUpdate is recorded when the role is updated.
+	ActionRoleUpdate Action = "role.update"
+	// ActionRoleDelete is recorded when the role is deleted.
+	ActionRoleDelete Action = "role.delete"
+	// ActionRoleAssign is recorded when the role is assigned to the user.
+	ActionRoleAssign Action = "role.assign"
+	// ActionRoleUnassign is recorded when the role is unassigned from the user.
+	ActionRoleUnassign Action = "role.unassign"
+	// ActionInviteCodeCreate is recorded when the invitation code is created.
+	ActionInviteCodeCreate Action = "invite_code.create"
+	// ActionInviteCodeRevoke is recorded when the invitation code is revoked.
+	ActionInviteCodeRevoke Action = "invite_code.revoke"
+)
+
+const (
+	// TargetUser represents users as the target of events.
+	TargetUser = "user"
+	// TargetNote represents notes as the target of events.
+	TargetNote = "note"
+	// TargetOrg represents organisations as the target of events.
+	TargetOrg = "org"
+	// TargetRole represents roles as the target of events.
+	TargetRole = "role"
+	// TargetInviteCode represents invitation codes as the target of events.
+	TargetInviteCode = "invite_code"
+)
+
+const (
+	// PermissionRead grants the ability to read and verify the audit log.
+	PermissionRead role.Permission = "audit.read"
+)
+
+// sourceKey is a context key used to store the source of the audited request.
+type sourceKey struct{}
+
+// Source describes where the audited request came from.
+type Source struct {
+	RequestID uuid.UUID
+	IP        string
+	UserAgent string
+}
+
+// Event represents an append-only entry of the audit log.
+// Every event holds the hash of the previous one, so modifying or removing an entry breaks the chain.
+type Event struct {
+	ID         uuid.UUID     `op:"id,primary"`
+	Seq        int64         `op:"seq"`
+	Action     Action        `op:"action"`
+	ActorID    uuid.NullUUID `op:"actor_id"`
+	TargetType string        `op:"target_type"`
+	TargetID   uuid.NullUUID `op:"target_id"`
+	Details    string        `op:"details"`
+	RequestID  uuid.NullUUID `op:"request_id"`
+	IP         string        `op:"ip"`
+	UserAgent  string        `op:"user_agent"`
+	PrevHash   string        `op:"prev_hash"`
+	Hash       string        `op:"hash"`
+	CreatedAt  time.Time     `op:"created_at"`
+}
+
+// Head represents the last event of the hash chain.
+type Head struct {
+	ID   int    `op:"id,primary"`
+	Seq  int64  `op:"seq"`
+	Hash string `op:"hash"`
+}
+
+// Filter represents the criteria of the audit log query. Zero values are ignored.
+type Filter struct {
+	ActorID    uuid.UUID
+	Action     Action
+	TargetType string
+	TargetID   uuid.UUID
+	From       time.Time
+	To         time.Time
+	Limit      uint64
+	Offset     uint64
+}
+
+// Verification represents the result of the hash chain check.
+// BrokenSeq is the sequence number of the first event not matching the chain.
+type Verification struct {
+	Valid     bool
+	Checked   int64
+	BrokenSeq int64
+}
+
+// WithSource returns a copy of the context carrying the source of the request.
+func WithSource(ctx context.Context, s Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, s)
+}
+
+// SourceFromContext returns the source of the request stored in the context, or zero Source if it is absent.
+func SourceFromContext(ctx context.Context) Source {
+	s, _ := ctx.Value(sourceKey{}).(Source)
+	return s
+}
+
+// NewEvent creates an event of the action performed by the actor on the target, filled with the request source.
+// The creation time is truncated to the database precision to keep the hash reproducible.
+func NewEvent(ctx context.Context, action Action, actor uuid.UUID, targetType string, target uuid.UUID) *Event {
+	src := SourceFromContext(ctx)
+	return &Event{
+		Action:     action,
+		ActorID:    uuid.NullUUID{UUID: actor, Valid: actor != uuid.Nil},
+		TargetType: targetType,
+		TargetID:   uuid.NullUUID{UUID: target, Valid: target != uuid.Nil},
+		RequestID:  uuid.NullUUID{UUID: src.RequestID, Valid: src.RequestID != uuid.Nil},
+		IP:         src.IP,
+		UserAgent:  src.UserAgent,
+		CreatedAt:  time.Now().Truncate(time.Microsecond),
+	}
+}
+
+// ComputeHash returns the SHA-256 hash of the event content chained with the previous hash.
+func (e *Event) ComputeHash() string {
+	fields := []string{
+		e.PrevHash,
+		strconv.FormatInt(e.Seq, 10),
+		e.ID.String(),
+		string(e.Action),
+		nullUUIDString(e.ActorID),
+		e.TargetType,
+		nullUUIDString(e.TargetID),
+		e.Details,
+		nullUUIDString(e.RequestID),
+		e.IP,
+		e.UserAgent,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Permissions returns the list of permissions related to the audit log.
+func Permissions() []role.Permission {
+	return []role.Permission{PermissionRead}
+}
+
+// nullUUIDString returns the string form of the valid identifier or an empty string.
+func nullUUIDString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+
+	return id.UUID.String()
+}
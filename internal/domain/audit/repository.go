@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/search"
+)
+
+// Recorder defines a method for appending events to the audit log.
+// Record joins the transaction of the context, so the event is stored only if the audited change is committed.
+// The event is appended right before the transaction is committed.
+type Recorder interface {
+	Record(ctx context.Context, e *Event) error
+}
+
+// Repository defines methods for appending and querying the audit log.
+type Repository interface {
+	Record(ctx context.Context, e *Event) error
+	Search(ctx context.Context, f *Filter) (*search.Result[Event], error)
+	GetChain(ctx context.Context, afterSeq int64, limit uint64) ([]*Event, error)
+	GetHead(ctx context.Context) (*Head, error)
+}
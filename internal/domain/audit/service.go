@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service audit log service interface
+type Service interface {
+	Search(ctx context.Context, user *user.User, f *Filter) (*search.Result[Event], error)
+	Verify(ctx context.Context, user *user.User) (*Verification, error)
+}
@@ -9,6 +9,9 @@ import (
 var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrPasswordIncorrect  = errors.New("password incorrect")
+	ErrRegistrationClosed = errors.New("registration is closed")
+	ErrInviteCodeRequired = errors.New("invitation code is required")
+	ErrInviteCodeInvalid  = errors.New("invitation code is invalid")
 )
 
 // Tokenizer defines methods for creating access and refresh tokens for user authentication.
@@ -30,10 +33,12 @@ type Login struct {
 }
 
 // SignUp represents the structure used to hold user registration details.
+// InviteCode is required when the registration is invite-only.
 type SignUp struct {
-	Name     string
-	Email    string
-	Password string
+	Name       string
+	Email      string
+	Password   string
+	InviteCode string
 }
 
 // Tokens represent a pair of access and refresh tokens associated with a user.
@@ -0,0 +1,60 @@
+package checklist
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/op/driver"
+)
+
+var (
+	ErrItemNotFound    = errors.New("checklist item not found")
+	ErrTooManyItems    = errors.New("checklist has too many items")
+	ErrInvalidOrder    = errors.New("checklist order doesn't list the items of the note")
+	ErrInvalidAssignee = errors.New("checklist item assignee can't read the note")
+)
+
+// Item represents an item of the checklist of the note. Items are ordered by the position, AssigneeID is a user
+// reading the note and DoneAt is the time the item was done.
+type Item struct {
+	ID         uuid.UUID       `op:"id,primary"`
+	NoteID     uuid.UUID       `op:"note_id"`
+	Position   int             `op:"position"`
+	Text       string          `op:"text"`
+	Done       bool            `op:"done"`
+	AssigneeID uuid.NullUUID   `op:"assignee_id"`
+	DueAt      driver.ZeroTime `op:"due_at"`
+	DoneAt     driver.ZeroTime `op:"done_at"`
+	CreatedAt  time.Time       `op:"created_at"`
+	UpdatedAt  driver.ZeroTime `op:"updated_at"`
+}
+
+// ItemData represents the fields of the item set by the user. Invalid AssigneeID and zero DueAt leave the item
+// unassigned and without a due date.
+type ItemData struct {
+	NoteID     uuid.UUID
+	Text       string
+	AssigneeID uuid.NullUUID
+	DueAt      time.Time
+}
+
+// Count returns the number of the items and the number of the items done.
+func Count(items []*Item) (total, done int) {
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+
+	return len(items), done
+}
+
+// Completion returns the percentage of the items done, rounded down. Checklists without items are 0% done.
+func Completion(total, done int) int {
+	if total == 0 {
+		return 0
+	}
+
+	return done * 100 / total
+}
@@ -0,0 +1,15 @@
+package checklist
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines methods for managing checklist items of notes.
+type Repository interface {
+	Lock(ctx context.Context, noteID uuid.UUID) error
+	GetByNote(ctx context.Context, noteID uuid.UUID) ([]*Item, error)
+	Save(ctx context.Context, item *Item) error
+	Delete(ctx context.Context, item *Item) error
+}
@@ -0,0 +1,20 @@
+package checklist
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service note checklists service interface. Users reading the note read its checklist, users updating the note
+// add, change, reorder, complete and delete the items. Every change saves the completion of the checklist with
+// the note, keeping the version of the note, and publishes the note update.
+type Service interface {
+	List(ctx context.Context, user *user.User, noteID uuid.UUID) ([]*Item, error)
+	Add(ctx context.Context, user *user.User, data *ItemData) (*Item, error)
+	Update(ctx context.Context, user *user.User, id uuid.UUID, data *ItemData) (*Item, error)
+	SetDone(ctx context.Context, user *user.User, noteID uuid.UUID, id uuid.UUID, done bool) (*Item, error)
+	Reorder(ctx context.Context, user *user.User, noteID uuid.UUID, ids []uuid.UUID) ([]*Item, error)
+	Delete(ctx context.Context, user *user.User, noteID uuid.UUID, id uuid.UUID) (*Item, error)
+}
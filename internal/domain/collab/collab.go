@@ -0,0 +1,100 @@
+package collab
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/pkg/crdt"
+)
+
+// MessageType represents the type of the message exchanged with the editors and between the instances.
+type MessageType string
+
+// Channel is the name of the Postgres notification channel carrying collaboration notices between the instances.
+const Channel = "note_collab"
+
+var (
+	ErrReadOnly       = errors.New("collaboration session is read-only")
+	ErrInvalidMessage = errors.New("invalid collaboration message")
+	ErrTextTooLong    = errors.New("collaborative note text is too long")
+	ErrStateNotFound  = errors.New("collaboration state not found")
+	ErrUpdateNotFound = errors.New("collaboration update not found")
+	ErrClosed         = errors.New("collaboration is closed")
+)
+
+const (
+	// MessageInit is sent to the editor on join with the document and the peers.
+	MessageInit MessageType = "init"
+	// MessageUpdate carries operations on the document.
+	MessageUpdate MessageType = "update"
+	// MessagePresence carries the peer which joined or moved its cursor.
+	MessagePresence MessageType = "presence"
+	// MessageLeave carries the session of the peer which left.
+	MessageLeave MessageType = "leave"
+	// MessageError reports the rejected message of the editor.
+	MessageError MessageType = "error"
+	// MessageSync asks the other instances to announce their peers of the note.
+	MessageSync MessageType = "sync"
+)
+
+// Cursor is the selection of a peer. The anchor and the head are the elements the selection ends follow.
+type Cursor struct {
+	Anchor crdt.ID `json:"anchor"`
+	Head   crdt.ID `json:"head"`
+}
+
+// Peer is an editor connected to the note.
+type Peer struct {
+	SessionID uuid.UUID `json:"session_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	CanEdit   bool      `json:"can_edit"`
+	Cursor    *Cursor   `json:"cursor,omitempty"`
+}
+
+// ClientMessage is a message sent by the editor: an update of the document or the presence of its cursor.
+type ClientMessage struct {
+	Type   MessageType `json:"type"`
+	Ops    []crdt.Op   `json:"ops,omitempty"`
+	Cursor *Cursor     `json:"cursor,omitempty"`
+}
+
+// ServerMessage is a message sent to the editor.
+// The init message carries the session, the site the editor inserts elements with, the document and the peers.
+type ServerMessage struct {
+	Type      MessageType    `json:"type"`
+	SessionID uuid.UUID      `json:"session_id,omitzero"`
+	Site      string         `json:"site,omitempty"`
+	CanEdit   bool           `json:"can_edit,omitempty"`
+	Elements  []crdt.Element `json:"elements,omitempty"`
+	Ops       []crdt.Op      `json:"ops,omitempty"`
+	Peers     []*Peer        `json:"peers,omitempty"`
+	Peer      *Peer          `json:"peer,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// Notice is a notification exchanged between the instances hosting editors of the same note.
+type Notice struct {
+	Type      MessageType `json:"type"`
+	NoteID    uuid.UUID   `json:"note_id"`
+	Origin    uuid.UUID   `json:"origin"`
+	UpdateID  uuid.UUID   `json:"update_id,omitzero"`
+	SessionID uuid.UUID   `json:"session_id,omitzero"`
+	Peer      *Peer       `json:"peer,omitempty"`
+}
+
+// State is the document of the note compacted from the updates, with the elements encoded in the document order.
+type State struct {
+	NoteID    uuid.UUID `op:"note_id,primary"`
+	Elements  []byte    `op:"elements"`
+	UpdatedAt time.Time `op:"updated_at"`
+}
+
+// Update is a batch of operations of an editor which is not compacted into the state yet.
+type Update struct {
+	ID        uuid.UUID `op:"id,primary"`
+	NoteID    uuid.UUID `op:"note_id"`
+	Ops       []byte    `op:"ops"`
+	CreatedAt time.Time `op:"created_at"`
+}
@@ -0,0 +1,20 @@
+package collab
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+)
+
+// Repository defines methods for storing the collaborative documents of notes.
+type Repository interface {
+	Lock(ctx context.Context) error
+	GetNote(ctx context.Context, id uuid.UUID) (*note.Note, error)
+	GetState(ctx context.Context, noteID uuid.UUID) (*State, error)
+	SaveState(ctx context.Context, s *State) error
+	GetUpdate(ctx context.Context, id uuid.UUID) (*Update, error)
+	GetUpdates(ctx context.Context, noteID uuid.UUID) ([]*Update, error)
+	SaveUpdate(ctx context.Context, u *Update) error
+	DeleteUpdates(ctx context.Context, ids []uuid.UUID) error
+}
@@ -0,0 +1,35 @@
+package collab
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service defines methods for collaborative editing of notes.
+type Service interface {
+	Join(ctx context.Context, user *user.User, noteID uuid.UUID) (Session, error)
+	Run(ctx context.Context, onError func(error))
+}
+
+// Session defines methods of a single editor connected to a note.
+type Session interface {
+	// Messages returns the channel of messages for the editor, starting with the init message.
+	// The channel is closed when the session ends.
+	Messages() <-chan *ServerMessage
+	// Handle applies the message of the editor.
+	Handle(ctx context.Context, msg *ClientMessage) error
+	Close()
+}
+
+// Notifier defines methods for sending notices to the other instances.
+type Notifier interface {
+	Notify(ctx context.Context, payload []byte) error
+}
+
+// Listener defines methods for receiving notices of the other instances.
+type Listener interface {
+	// Listen passes payloads of the notices to handle until the context is done or the listening fails.
+	Listen(ctx context.Context, handle func(payload []byte)) error
+}
@@ -0,0 +1,12 @@
+package event
+
+import (
+	"context"
+)
+
+// Dispatcher defines methods for delivering outbox events to the in-process subscribers.
+type Dispatcher interface {
+	Subscribe(name string, h Handler, types ...Type)
+	Dispatch(ctx context.Context) (int, error)
+	Run(ctx context.Context, onError func(error))
+}
@@ -0,0 +1,158 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/op/driver"
+)
+
+// Type represents the kind of domain event.
+type Type string
+
+const (
+	// TypeNoteCreated is published when a note is created.
+	TypeNoteCreated Type = "note.created"
+	// TypeNoteUpdated is published when a note is updated.
+	TypeNoteUpdated Type = "note.updated"
+	// TypeNoteDeleted is published when a note is deleted.
+	TypeNoteDeleted Type = "note.deleted"
+	// TypeUserSignedUp is published when a user signs up.
+	TypeUserSignedUp Type = "user.signed_up"
+	// TypeReminderFired is published when a note reminder fires.
+	TypeReminderFired Type = "reminder.fired"
+	// TypeReminderEmail is published when a note reminder fires with the email of the user to send after commit.
+	TypeReminderEmail Type = "reminder.email"
+)
+
+// Status represents the delivery state of an outbox event.
+type Status string
+
+const (
+	// StatusPending marks events waiting for delivery or for the next retry.
+	StatusPending Status = "pending"
+	// StatusDelivered marks events delivered to all subscribers.
+	StatusDelivered Status = "delivered"
+	// StatusDead marks events which exhausted delivery attempts.
+	StatusDead Status = "dead"
+)
+
+// Handler represents a subscriber callback. Events are delivered at least once, so handlers must be idempotent.
+type Handler func(ctx context.Context, e *Event) error
+
+// Event represents a domain event stored in the outbox.
+// Events of the same aggregate are delivered in the order they were published.
+type Event struct {
+	ID          uuid.UUID       `op:"id,primary"`
+	AggregateID uuid.UUID       `op:"aggregate_id"`
+	Type        Type            `op:"type"`
+	Payload     []byte          `op:"payload"`
+	Status      Status          `op:"status"`
+	Attempts    int             `op:"attempts"`
+	LastError   string          `op:"last_error"`
+	AvailableAt time.Time       `op:"available_at"`
+	CreatedAt   time.Time       `op:"created_at"`
+	ProcessedAt driver.ZeroTime `op:"processed_at"`
+}
+
+// NotePayload represents the payload of the note events.
+type NotePayload struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	OrgID     uuid.UUID `json:"org_id,omitzero"`
+	Name      string    `json:"name"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// UserPayload represents the payload of the user events.
+type UserPayload struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReminderPayload represents the payload of the reminder events. UserID is the user who set the reminder.
+type ReminderPayload struct {
+	ID       uuid.UUID `json:"id"`
+	NoteID   uuid.UUID `json:"note_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	NoteName string    `json:"note_name"`
+	RemindAt time.Time `json:"remind_at"`
+	Rule     string    `json:"rule,omitempty"`
+	FiredAt  time.Time `json:"fired_at"`
+}
+
+// EmailPayload represents the payload of the email events, the message sent to the address.
+type EmailPayload struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// New creates a pending event of the aggregate with the JSON encoded payload.
+func New(typ Type, aggregateID uuid.UUID, payload any) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s event payload: %w", typ, err)
+	}
+
+	now := time.Now()
+	return &Event{
+		AggregateID: aggregateID,
+		Type:        typ,
+		Payload:     data,
+		Status:      StatusPending,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}, nil
+}
+
+// NewNoteEvent creates an event of the note aggregate.
+func NewNoteEvent(typ Type, n *note.Note) (*Event, error) {
+	return New(typ, n.ID, &NotePayload{
+		ID:        n.ID,
+		UserID:    n.UserId,
+		OrgID:     n.OrgID.UUID,
+		Name:      n.Name,
+		Text:      n.Text,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: time.Time(n.UpdatedAt),
+	})
+}
+
+// NewUserSignedUpEvent creates an event of the signed up user.
+func NewUserSignedUpEvent(u *user.User) (*Event, error) {
+	return New(TypeUserSignedUp, u.ID, &UserPayload{
+		ID:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: u.CreatedAt,
+	})
+}
+
+// NewReminderFiredEvent creates an event of the fired reminder.
+func NewReminderFiredEvent(n *reminder.Notification) (*Event, error) {
+	return New(TypeReminderFired, n.Reminder.ID, &ReminderPayload{
+		ID:       n.Reminder.ID,
+		NoteID:   n.Note.ID,
+		UserID:   n.User.ID,
+		NoteName: n.Note.Name,
+		RemindAt: n.Reminder.RemindAt,
+		Rule:     n.Reminder.Rule,
+		FiredAt:  n.FiredAt,
+	})
+}
+
+// Decode unmarshals the JSON payload of the event into v.
+func (e *Event) Decode(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
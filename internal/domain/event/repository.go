@@ -0,0 +1,19 @@
+package event
+
+import (
+	"context"
+)
+
+// Publisher defines a method for writing events to the outbox.
+// Publish joins the transaction of the context, so events are stored only if the change is committed.
+type Publisher interface {
+	Publish(ctx context.Context, events ...*Event) error
+}
+
+// Repository defines methods for managing the outbox.
+type Repository interface {
+	Publish(ctx context.Context, events ...*Event) error
+	Lock(ctx context.Context) error
+	GetPending(ctx context.Context, limit uint64) ([]*Event, error)
+	Save(ctx context.Context, e *Event) error
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/pkg/pdf"
+)
+
+// DefaultPageSize is the page size of PDF documents without the size requested.
+const DefaultPageSize = pdf.A4
+
+// Format represents the format of the file of exported notes.
+type Format string
+
+const (
+	// FormatMarkdown is a zip archive of Markdown files with the names and the times of the notes in front matter.
+	FormatMarkdown Format = "markdown"
+	// FormatJSON is an array of note objects.
+	FormatJSON Format = "json"
+	// FormatNDJSON is a note object per line.
+	FormatNDJSON Format = "ndjson"
+)
+
+// DefaultFormat is the format of exports without the format requested.
+const DefaultFormat = FormatMarkdown
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// File describes the exported file written by the service.
+type File struct {
+	Name        string
+	ContentType string
+}
+
+// PDFRequest represents the notes exported to a single PDF document, in the order of the document.
+type PDFRequest struct {
+	IDs      []uuid.UUID
+	PageSize pdf.PageSize
+}
+
+// NotesRequest represents the personal notes of the user matching the search filters, exported to a single file
+// in the order of creation.
+type NotesRequest struct {
+	Format  Format
+	Filters search.Filters
+}
+
+// Stream represents the export of notes checked to be allowed, Write streams the file to w page by page.
+// Errors of Write come after the start of the file, so the written file is incomplete.
+type Stream struct {
+	File  *File
+	Write func(w io.Writer) error
+}
+
+// ParseFormat parses a string and returns it as a Format if it matches predefined formats, otherwise it returns
+// ErrUnknownFormat.
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatMarkdown, FormatJSON, FormatNDJSON:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
@@ -0,0 +1,15 @@
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service defines methods for exporting notes. Exported notes are read through the note service, so a note the user
+// may not read fails the whole export.
+type Service interface {
+	PDF(ctx context.Context, user *user.User, w io.Writer, req *PDFRequest) (*File, error)
+	Notes(ctx context.Context, user *user.User, req *NotesRequest) (*Stream, error)
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+)
+
+var ErrInvalidRequest = errors.New("invalid graph request")
+
+// EdgeKind is the kind of the connection between notes.
+type EdgeKind string
+
+const (
+	// EdgeLink connects the note to the note it links to.
+	EdgeLink EdgeKind = "link"
+	// EdgeSimilar connects notes sharing keywords, both ways.
+	EdgeSimilar EdgeKind = "similar"
+)
+
+// Request represents the graph requested by the user. Without NoteID the graph of the latest notes is built,
+// with NoteID the neighbourhood of the note up to Depth connections away, zero is one connection. Similarity
+// is the least keywords similarity of connected similar notes, zero leaves similar notes out. The limit of nodes
+// is bounded by the service, zero is the default limit.
+type Request struct {
+	NoteID     uuid.NullUUID
+	Depth      int
+	Similarity float64
+	Limit      int
+}
+
+// Edge represents the connection of the source note to the target note. Weight is the similarity of similar
+// notes and 1 for links.
+type Edge struct {
+	Source uuid.UUID
+	Target uuid.UUID
+	Kind   EdgeKind
+	Weight float64
+}
+
+// Graph represents the notes the user may read and their connections. Nodes carry no text. Truncated is set
+// when nodes or edges were left out by the limits.
+type Graph struct {
+	Nodes     []*note.Note
+	Edges     []*Edge
+	Truncated bool
+}
@@ -0,0 +1,19 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Repository defines methods for reading the note graph. Nodes are the notes visible to the user read without
+// the text, GetLinks returns the links from or to the notes.
+type Repository interface {
+	GetNodes(ctx context.Context, user *user.User, limit uint64) ([]*note.Note, error)
+	GetNodesByIDs(ctx context.Context, user *user.User, ids []uuid.UUID) ([]*note.Note, error)
+	GetSimilar(ctx context.Context, user *user.User, terms []string, limit uint64) ([]*note.Note, error)
+	GetLinks(ctx context.Context, ids []uuid.UUID) ([]*link.Link, error)
+}
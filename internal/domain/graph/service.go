@@ -0,0 +1,13 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service note graph service interface. The graph holds only the notes the user may read, connections through
+// other notes are left out.
+type Service interface {
+	Build(ctx context.Context, user *user.User, req *Request) (*Graph, error)
+}
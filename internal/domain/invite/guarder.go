@@ -0,0 +1,13 @@
+package invite
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// Guarder defines an interface for determining if a user has permission to perform an operation on invitation codes.
+type Guarder interface {
+	IsGranted(ctx context.Context, op rbac.Operation, code *Code, user *user.User) (bool, error)
+}
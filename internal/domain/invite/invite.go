@@ -0,0 +1,76 @@
+package invite
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/op/driver"
+)
+
+var (
+	ErrNotFound                  = errors.New("invitation code not found")
+	ErrUnknownRoleLabel          = errors.New("unknown role label")
+	ErrOperationForbiddenForUser = errors.New("invitation code operation is forbidden for user")
+)
+
+const (
+	// PermissionCreate grants the ability to create invitation codes.
+	PermissionCreate role.Permission = "invites.create"
+	// PermissionDelete grants the ability to revoke invitation codes.
+	PermissionDelete role.Permission = "invites.delete"
+	// PermissionRead grants the ability to read invitation codes.
+	PermissionRead role.Permission = "invites.read"
+)
+
+// Code represents an invitation code allowing to sign up when the registration is invite-only.
+// Empty Email allows any email, zero MaxUses allows unlimited uses and zero ExpiresAt never expires.
+type Code struct {
+	ID        uuid.UUID       `op:"id,primary"`
+	Code      string          `op:"code"`
+	Email     string          `op:"email"`
+	RoleLabel string          `op:"role_label"`
+	MaxUses   int             `op:"max_uses"`
+	Uses      int             `op:"uses"`
+	ExpiresAt driver.ZeroTime `op:"expires_at"`
+	CreatedBy uuid.UUID       `op:"created_by"`
+	CreatedAt time.Time       `op:"created_at"`
+}
+
+// Use represents a single consumption of the invitation code. Number is unique per code.
+type Use struct {
+	ID        uuid.UUID `op:"id,primary"`
+	CodeID    uuid.UUID `op:"code_id"`
+	Number    int       `op:"number"`
+	UserID    uuid.UUID `op:"user_id"`
+	CreatedAt time.Time `op:"created_at"`
+}
+
+// CreateData represents the data required to create a new invitation code.
+type CreateData struct {
+	Email     string
+	RoleLabel role.Label
+	MaxUses   int
+	ExpiresAt time.Time
+}
+
+// IsUsable reports whether the code may be used to sign up with the email at the given time.
+func (c *Code) IsUsable(email string, now time.Time) bool {
+	if c.Email != "" && !strings.EqualFold(c.Email, email) {
+		return false
+	}
+
+	if c.MaxUses > 0 && c.Uses >= c.MaxUses {
+		return false
+	}
+
+	expiresAt := time.Time(c.ExpiresAt)
+	return expiresAt.IsZero() || now.Before(expiresAt)
+}
+
+// Permissions returns the list of permissions related to invitation codes.
+func Permissions() []role.Permission {
+	return []role.Permission{PermissionRead, PermissionCreate, PermissionDelete}
+}
@@ -0,0 +1,18 @@
+package invite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines methods for managing invitation codes and their uses.
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Code, error)
+	GetByCode(ctx context.Context, code string) (*Code, error)
+	Lock(ctx context.Context, code string) error
+	GetAll(ctx context.Context) ([]*Code, error)
+	Save(ctx context.Context, c *Code) error
+	Delete(ctx context.Context, c *Code) error
+	SaveUse(ctx context.Context, u *Use) error
+}
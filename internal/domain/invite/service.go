@@ -0,0 +1,15 @@
+package invite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service invitation codes service interface
+type Service interface {
+	List(ctx context.Context, user *user.User) ([]*Code, error)
+	Create(ctx context.Context, user *user.User, data *CreateData) (*Code, error)
+	Revoke(ctx context.Context, user *user.User, id uuid.UUID) (*Code, error)
+}
@@ -0,0 +1,53 @@
+package link
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+)
+
+var ErrTargetNotFound = errors.New("link target not found")
+
+// Link represents a wiki-style link written in the text of the source note. Target is the identifier or the title
+// of the linked note as written, TargetID is the note the link resolves to and is invalid for dangling links.
+// UserID and OrgID are the scope of the source note titles resolve in.
+type Link struct {
+	ID        uuid.UUID     `op:"id,primary"`
+	SourceID  uuid.UUID     `op:"source_id"`
+	Target    string        `op:"target"`
+	TargetID  uuid.NullUUID `op:"target_id"`
+	UserID    uuid.UUID     `op:"user_id"`
+	OrgID     uuid.NullUUID `op:"org_id"`
+	CreatedAt time.Time     `op:"created_at"`
+}
+
+// Scope represents the notes titles resolve in: personal notes of the user, or notes of the organisation.
+type Scope struct {
+	UserID uuid.UUID
+	OrgID  uuid.NullUUID
+}
+
+// Resolved represents the link of the note resolved for the user. Note is nil for dangling links, links to notes
+// the user may not read are dangling for the user.
+type Resolved struct {
+	Target string
+	Note   *note.Note
+}
+
+// Dangling represents the link of the source note resolving to no note.
+type Dangling struct {
+	Source *note.Note
+	Target string
+}
+
+// ByTitle reports whether the link refers to the target note by the title rather than the identifier.
+func (l *Link) ByTitle() bool {
+	return uuid.Validate(l.Target) != nil
+}
+
+// Scope returns the scope of the source note of the link.
+func (l *Link) Scope() Scope {
+	return Scope{UserID: l.UserID, OrgID: l.OrgID}
+}
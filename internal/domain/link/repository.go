@@ -0,0 +1,23 @@
+package link
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Repository defines methods for managing links between notes. GetNotes reads the notes regardless of their
+// visibility, so it is only used to maintain the links.
+type Repository interface {
+	GetBySource(ctx context.Context, sourceID uuid.UUID) ([]*Link, error)
+	GetByTarget(ctx context.Context, targetID uuid.UUID) ([]*Link, error)
+	GetDangling(ctx context.Context, user *user.User) ([]*Link, error)
+	GetDanglingByTarget(ctx context.Context, scope Scope, target string) ([]*Link, error)
+	FindByTitle(ctx context.Context, scope Scope, title string) (uuid.UUID, error)
+	FindByID(ctx context.Context, scope Scope, id uuid.UUID) (uuid.UUID, error)
+	GetNotes(ctx context.Context, ids []uuid.UUID) ([]*note.Note, error)
+	Save(ctx context.Context, l *Link) error
+	Delete(ctx context.Context, l *Link) error
+}
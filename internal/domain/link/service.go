@@ -0,0 +1,19 @@
+package link
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service note links service interface. Links are kept from the note events: written links are resolved when
+// the note is saved, links by the title follow the renamed note. Only the notes the user may read are resolved.
+type Service interface {
+	Links(ctx context.Context, user *user.User, noteID uuid.UUID) ([]*Resolved, error)
+	Backlinks(ctx context.Context, user *user.User, noteID uuid.UUID) ([]*note.Note, error)
+	Dangling(ctx context.Context, user *user.User) ([]*Dangling, error)
+	HandleEvent(ctx context.Context, e *event.Event) error
+}
@@ -0,0 +1,66 @@
+package note
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBatchAborted   = errors.New("note operation is not applied, the batch is aborted")
+	ErrBatchDuplicate = errors.New("note is changed by another operation of the batch")
+)
+
+// BatchMode is the way the operations of a batch are applied.
+type BatchMode string
+
+const (
+	// BatchAtomic applies all the operations in a single transaction, nothing is applied if any operation fails.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort applies every operation on its own, failed operations do not stop the others.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// BatchKind is the kind of the operation of a batch.
+type BatchKind string
+
+const (
+	BatchCreate BatchKind = "create"
+	BatchUpdate BatchKind = "update"
+	BatchDelete BatchKind = "delete"
+)
+
+// BatchOp is an operation of a batch: creations carry Create, updates carry Update and deletions carry the ID
+// of the deleted note.
+type BatchOp struct {
+	Kind   BatchKind
+	ID     uuid.UUID
+	Create *CreateData
+	Update *UpdateData
+}
+
+// NoteID returns the identifier of the note changed by the operation, uuid.Nil for creations.
+func (o *BatchOp) NoteID() uuid.UUID {
+	switch o.Kind {
+	case BatchUpdate:
+		return o.Update.ID
+	case BatchDelete:
+		return o.ID
+	default:
+		return uuid.Nil
+	}
+}
+
+// BatchRequest represents the operations of a batch applied in the given mode.
+type BatchRequest struct {
+	Mode BatchMode
+	Ops  []*BatchOp
+}
+
+// BatchResult is the outcome of the operation of a batch: the created, updated or deleted note, or the error
+// of the operation. Operations not applied because of the failure of another operation of an atomic batch
+// fail with ErrBatchAborted.
+type BatchResult struct {
+	Note *Note
+	Err  error
+}
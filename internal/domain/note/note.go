@@ -2,6 +2,7 @@ package note
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,10 @@ var (
 	ErrNotFound                  = errors.New("note not found")
 	ErrSearchBadRequest          = errors.New("search bad request")
 	ErrOperationForbiddenForUser = errors.New("note operation is forbidden for user")
+	ErrVersionConflict           = errors.New("note version conflict")
+	ErrRevisionNotFound          = errors.New("note revision not found")
+	ErrMergeConflict             = errors.New("note merge conflict")
+	ErrUnknownFlag               = errors.New("unknown note flag")
 )
 
 const (
@@ -26,25 +31,150 @@ const (
 	PermissionRead role.Permission = "notes.read"
 )
 
-// Note structure
+// Format is the content format of the note text.
+type Format string
+
+const (
+	FormatPlain    Format = "plain"
+	FormatMarkdown Format = "markdown"
+)
+
+// Note structure. PlainText is the text rendered according to the format without the markup, used for snippets.
+// Pinned notes are listed first and archived notes are left out by searches, favourite notes are marked
+// by the user. ChecklistTotal and ChecklistDone count the checklist items of the note, HasOpenItems is set while
+// some of them are not done. Terms are the keywords of the plain text, notes sharing keywords are similar.
 type Note struct {
-	ID        uuid.UUID       `op:"id,primary"`
-	Name      string          `op:"name"`
-	Text      string          `op:"text"`
-	UserId    uuid.UUID       `op:"user_id"`
-	CreatedAt time.Time       `op:"created_at"`
-	UpdatedAt driver.ZeroTime `op:"updated_at"`
+	ID             uuid.UUID       `op:"id,primary"`
+	Name           string          `op:"name"`
+	Text           string          `op:"text"`
+	Format         Format          `op:"format"`
+	PlainText      string          `op:"plain_text"`
+	Terms          []string        `op:"terms"`
+	UserId         uuid.UUID       `op:"user_id"`
+	OrgID          uuid.NullUUID   `op:"org_id"`
+	Version        int64           `op:"version"`
+	Pinned         bool            `op:"pinned"`
+	Archived       bool            `op:"archived"`
+	Favourite      bool            `op:"favourite"`
+	ChecklistTotal int             `op:"checklist_total"`
+	ChecklistDone  int             `op:"checklist_done"`
+	HasOpenItems   bool            `op:"has_open_items"`
+	CreatedAt      time.Time       `op:"created_at"`
+	UpdatedAt      driver.ZeroTime `op:"updated_at"`
+}
+
+// MaxTerms is the number of the keywords kept with the note.
+const MaxTerms = 16
+
+// RenderKey identifies the rendered version of the note.
+type RenderKey struct {
+	NoteID  uuid.UUID
+	Version int64
+}
+
+// Rendered is the text of the note version rendered to sanitized HTML.
+type Rendered struct {
+	NoteID    uuid.UUID
+	Version   int64
+	Format    Format
+	HTML      string
+	PlainText string
+}
+
+// Revision is a previous version of the note kept as the base of three-way merges.
+type Revision struct {
+	NoteID    uuid.UUID `op:"note_id"`
+	Version   int64     `op:"version"`
+	Name      string    `op:"name"`
+	Text      string    `op:"text"`
+	CreatedAt time.Time `op:"created_at"`
+}
+
+// Field is a field of the note changed by updates.
+type Field string
+
+const (
+	FieldName      Field = "name"
+	FieldText      Field = "text"
+	FieldFormat    Field = "format"
+	FieldPinned    Field = "pinned"
+	FieldArchived  Field = "archived"
+	FieldFavourite Field = "favourite"
+	FieldChecklist Field = "checklist"
+)
+
+// Conflict is a region of the field changed differently by the update and by the current version of the note.
+type Conflict struct {
+	Field  Field
+	Base   string
+	Client string
+	Server string
+}
+
+// MergeConflictError is returned when the update made to an outdated version of the note can not be merged
+// with the current version. It holds the current note and the conflicting regions.
+type MergeConflictError struct {
+	Note      *Note
+	Conflicts []Conflict
+}
+
+// Error implements the error interface.
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%s (%d conflicts, version %d)", ErrMergeConflict, len(e.Conflicts), e.Note.Version)
+}
+
+// Unwrap returns ErrMergeConflict.
+func (e *MergeConflictError) Unwrap() error {
+	return ErrMergeConflict
 }
 
 // UpdateData represents the data required to update an existing note.
+// Non-zero BaseVersion is the version the update was made to, changes made since are merged with the update.
+// Empty Format keeps the format of the note.
 type UpdateData struct {
-	ID   uuid.UUID
-	Name string
-	Text string
+	ID          uuid.UUID
+	Name        string
+	Text        string
+	Format      Format
+	BaseVersion int64
 }
 
-// CreateData represents the data required to create a new note.
+// PatchData represents the fields of the note changed by a partial update, nil fields are kept.
+type PatchData struct {
+	Name      *string
+	Text      *string
+	Format    *Format
+	Pinned    *bool
+	Archived  *bool
+	Favourite *bool
+}
+
+// Patcher returns the partial update of the current version of the note.
+type Patcher func(n *Note) (*PatchData, error)
+
+// CreateData represents the data required to create a new note. Valid OrgID makes the note owned by the organisation.
+// Empty Format stands for FormatPlain. Zero CreatedAt stands for the current time, non-zero times are set
+// by imports keeping the times of the original notes.
 type CreateData struct {
-	Name string
-	Text string
+	Name      string
+	Text      string
+	Format    Format
+	OrgID     uuid.NullUUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ParseFlag parses a string and returns it as the Field of a flag of the note, otherwise it returns ErrUnknownFlag.
+func ParseFlag(flag string) (Field, error) {
+	switch Field(flag) {
+	case FieldPinned, FieldArchived, FieldFavourite:
+		return Field(flag), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+}
+
+// Permissions returns the list of permissions related to notes.
+func Permissions() []role.Permission {
+	return []role.Permission{PermissionRead, PermissionCreate, PermissionUpdate, PermissionDelete}
 }
@@ -9,10 +9,15 @@ import (
 )
 
 // Repository defines the interface for managing Note entities.
+// Reads are restricted to the notes visible to the user: personal notes of the user and notes of its organisations.
 type Repository interface {
-	GetByID(ctx context.Context, id uuid.UUID) (*Note, error)
+	GetByID(ctx context.Context, user *user.User, id uuid.UUID) (*Note, error)
+	GetByIDs(ctx context.Context, user *user.User, ids []uuid.UUID) ([]*Note, error)
 	IDExists(ctx context.Context, id uuid.UUID) (bool, error)
 	Save(ctx context.Context, n *Note) error
+	SaveFields(ctx context.Context, n *Note, fields []Field) error
+	GetRevision(ctx context.Context, noteID uuid.UUID, version int64) (*Revision, error)
 	Delete(ctx context.Context, n *Note) error
 	SearchByUser(ctx context.Context, u *user.User, r *search.Request) (*search.Result[Note], error)
+	SearchByOrg(ctx context.Context, u *user.User, orgID uuid.UUID, r *search.Request) (*search.Result[Note], error)
 }
@@ -11,8 +11,18 @@ import (
 // Service notes service interface
 type Service interface {
 	Get(ctx context.Context, user *user.User, id uuid.UUID) (*Note, error)
+	Render(ctx context.Context, user *user.User, id uuid.UUID) (*Rendered, error)
 	Create(ctx context.Context, user *user.User, data *CreateData) (*Note, error)
 	Update(ctx context.Context, user *user.User, data *UpdateData) (*Note, error)
+	Patch(ctx context.Context, user *user.User, id uuid.UUID, patcher Patcher) (*Note, error)
+	SetFlag(ctx context.Context, user *user.User, id uuid.UUID, flag Field, value bool) (*Note, error)
 	Delete(ctx context.Context, user *user.User, id uuid.UUID) (*Note, error)
+	Batch(ctx context.Context, user *user.User, req *BatchRequest) ([]*BatchResult, error)
 	Search(ctx context.Context, user *user.User, req *search.Request) (*search.Result[Note], error)
+	SearchByOrg(
+		ctx context.Context,
+		user *user.User,
+		orgID uuid.UUID,
+		req *search.Request,
+	) (*search.Result[Note], error)
 }
@@ -0,0 +1,108 @@
+package noteimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/pkg/notefile"
+	"github.com/xsqrty/op/driver"
+)
+
+// Status represents the state of an import job.
+type Status string
+
+// ItemStatus represents the outcome of importing a note of the job.
+type ItemStatus string
+
+var (
+	ErrNotFound     = errors.New("import not found")
+	ErrFileTooLarge = errors.New("import file is too large")
+	ErrEmptyFile    = errors.New("import file is empty")
+)
+
+const (
+	// StatusPending marks jobs waiting for a worker.
+	StatusPending Status = "pending"
+	// StatusRunning marks jobs imported by a worker. Jobs of crashed workers are resumed once their claim expires.
+	StatusRunning Status = "running"
+	// StatusCompleted marks jobs which went through all the notes, some of the notes may have failed.
+	StatusCompleted Status = "completed"
+	// StatusFailed marks jobs stopped by a broken file or by a user not allowed to create notes.
+	StatusFailed Status = "failed"
+)
+
+const (
+	// ItemCreated reports the note is created.
+	ItemCreated ItemStatus = "created"
+	// ItemSkipped reports the same note was imported before, the item refers to that note.
+	ItemSkipped ItemStatus = "skipped"
+	// ItemFailed reports the note can not be read or created.
+	ItemFailed ItemStatus = "failed"
+)
+
+// Job represents an uploaded file of notes imported in the background. The file is kept in the blob store under
+// the key until the job finishes. Checksum is the hex SHA-256 of the file. Attempts counts the claims of the job.
+type Job struct {
+	ID           uuid.UUID       `op:"id,primary"`
+	UserID       uuid.UUID       `op:"user_id"`
+	Name         string          `op:"name"`
+	Format       notefile.Format `op:"format"`
+	Checksum     string          `op:"checksum"`
+	Size         int64           `op:"size"`
+	Status       Status          `op:"status"`
+	Total        int             `op:"total"`
+	Processed    int             `op:"processed"`
+	Created      int             `op:"created"`
+	Skipped      int             `op:"skipped"`
+	Failed       int             `op:"failed"`
+	Error        string          `op:"error"`
+	Attempts     int             `op:"attempts"`
+	ClaimedUntil time.Time       `op:"claimed_until"`
+	CreatedAt    time.Time       `op:"created_at"`
+	StartedAt    driver.ZeroTime `op:"started_at"`
+	FinishedAt   driver.ZeroTime `op:"finished_at"`
+}
+
+// Item represents the outcome of importing a note of the job, Position is its index in the file. Source is the path
+// of the note in the archive or its title. NoteID is the created or the previously imported note, it is cleared
+// when the note is deleted.
+type Item struct {
+	ID        uuid.UUID     `op:"id,primary"`
+	JobID     uuid.UUID     `op:"job_id"`
+	UserID    uuid.UUID     `op:"user_id"`
+	Position  int           `op:"position"`
+	Source    string        `op:"source"`
+	Status    ItemStatus    `op:"status"`
+	NoteID    uuid.NullUUID `op:"note_id"`
+	Error     string        `op:"error"`
+	CreatedAt time.Time     `op:"created_at"`
+}
+
+// Imported represents a note created by an import. Hash identifies the user and the content of the note,
+// so the same note is not imported twice. It is removed along with the note, so a deleted note may be imported again.
+type Imported struct {
+	Hash      string    `op:"hash,primary"`
+	UserID    uuid.UUID `op:"user_id"`
+	NoteID    uuid.UUID `op:"note_id"`
+	CreatedAt time.Time `op:"created_at"`
+}
+
+// UploadData represents the uploaded file of notes. Empty Format is detected by the extension of the name.
+type UploadData struct {
+	Name    string
+	Format  notefile.Format
+	Content io.Reader
+}
+
+// Key returns the key of the uploaded file in the blob store.
+func (j *Job) Key() string {
+	return fmt.Sprintf("imports/%s/%s", j.UserID, j.ID)
+}
+
+// Finished reports whether the job is completed or failed.
+func (j *Job) Finished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed
+}
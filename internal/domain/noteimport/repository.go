@@ -0,0 +1,28 @@
+package noteimport
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/search"
+)
+
+// Repository defines methods for managing import jobs and their items.
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	GetUnfinishedByChecksum(ctx context.Context, userID uuid.UUID, checksum string) (*Job, error)
+	GetDue(ctx context.Context, now time.Time) (*Job, error)
+	Lock(ctx context.Context) error
+	Save(ctx context.Context, j *Job) error
+	GetItems(
+		ctx context.Context,
+		j *Job,
+		status ItemStatus,
+		limit uint64,
+		offset uint64,
+	) (*search.Result[Item], error)
+	SaveItem(ctx context.Context, it *Item) error
+	GetImported(ctx context.Context, hash string) (*Imported, error)
+	SaveImported(ctx context.Context, imp *Imported) error
+}
@@ -0,0 +1,25 @@
+package noteimport
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service note imports service interface. Start queues the uploaded file, Run imports queued files in
+// the background, creating every note through the note service on behalf of the user who uploaded the file.
+type Service interface {
+	Start(ctx context.Context, user *user.User, data *UploadData) (*Job, error)
+	Get(ctx context.Context, user *user.User, id uuid.UUID) (*Job, error)
+	Items(
+		ctx context.Context,
+		user *user.User,
+		id uuid.UUID,
+		status ItemStatus,
+		limit uint64,
+		offset uint64,
+	) (*search.Result[Item], error)
+	Run(ctx context.Context, onError func(error))
+}
@@ -0,0 +1,137 @@
+package notesync
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+)
+
+// ChangeKind represents the kind of change made to a note by the client.
+type ChangeKind string
+
+// Status represents the outcome of applying a client change.
+type Status string
+
+var (
+	ErrInvalidToken    = errors.New("invalid sync token")
+	ErrVersionNotFound = errors.New("note version not found")
+)
+
+const (
+	// ChangeCreate creates a new note.
+	ChangeCreate ChangeKind = "create"
+	// ChangeUpdate updates the name and the text of the note.
+	ChangeUpdate ChangeKind = "update"
+	// ChangeDelete deletes the note.
+	ChangeDelete ChangeKind = "delete"
+)
+
+const (
+	// StatusApplied reports the change is applied.
+	StatusApplied Status = "applied"
+	// StatusConflict reports the note has changed on the server since the base version of the change.
+	StatusConflict Status = "conflict"
+	// StatusNotFound reports the note does not exist or is not visible to the user.
+	StatusNotFound Status = "not_found"
+	// StatusForbidden reports the user is not allowed to make the change.
+	StatusForbidden Status = "forbidden"
+)
+
+// Change is a local change of the client. Updates and deletions carry the server version of the note
+// the client has changed, creations carry the organisation owning the new note, if any.
+type Change struct {
+	Kind        ChangeKind
+	NoteID      uuid.UUID
+	BaseVersion int64
+	Name        string
+	Text        string
+	OrgID       uuid.NullUUID
+}
+
+// ServerChange is the current state of a note on the server. Deleted changes are tombstones without the note,
+// they are also reported for notes the user is no longer allowed to read.
+type ServerChange struct {
+	NoteID  uuid.UUID
+	Version int64
+	Deleted bool
+	Note    *note.Note
+}
+
+// Result is the outcome of a client change, in the order of the changes. Conflicts carry the server state of the note.
+type Result struct {
+	Status  Status
+	NoteID  uuid.UUID
+	Version int64
+	Server  *ServerChange
+}
+
+// Request is a batch of client changes and the token of the last sync.
+type Request struct {
+	Token   Token
+	Changes []*Change
+}
+
+// Response carries the outcome of the client changes, the server changes since the token and the next token.
+// HasMore reports the server changes are paged, the client syncs again with the token to get the next page.
+type Response struct {
+	Results []*Result
+	Changes []*ServerChange
+	Token   Token
+	HasMore bool
+}
+
+// Version is the server version of a note, tracked by the database on every write including deletion.
+// TxID is the identifier of the transaction of the last write, ordering the changes for sync.
+type Version struct {
+	NoteID    uuid.UUID     `op:"note_id,primary"`
+	UserID    uuid.UUID     `op:"user_id"`
+	OrgID     uuid.NullUUID `op:"org_id"`
+	Version   int64         `op:"version"`
+	Deleted   bool          `op:"deleted"`
+	TxID      int64         `op:"txid"`
+	ChangedAt time.Time     `op:"changed_at"`
+}
+
+// Cursor is the position in the changes ordered by the transaction and the note.
+type Cursor struct {
+	TxID   int64     `json:"t"`
+	NoteID uuid.UUID `json:"n"`
+}
+
+// Token is the sync position of the client. The zero token syncs all notes.
+// Horizon is set while the changes are paged: transactions from the horizon on may have committed after the first
+// page was read, so the client continues from the horizon when the last page is reached.
+type Token struct {
+	Cursor
+	Horizon int64 `json:"h,omitempty"`
+}
+
+// ParseToken decodes the token sent by the client, the empty string is the zero token.
+func ParseToken(s string) (Token, error) {
+	var t Token
+	if s == "" {
+		return t, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if err := json.Unmarshal(data, &t); err != nil {
+		return t, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return t, nil
+}
+
+// String encodes the token for the client.
+func (t Token) String() string {
+	data, _ := json.Marshal(t) // a struct of numbers and a uuid always encodes
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,20 @@
+package notesync
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Repository defines methods for reading the server versions of notes.
+type Repository interface {
+	// Horizon returns the oldest transaction which may be not committed yet.
+	Horizon(ctx context.Context) (int64, error)
+	// GetVersion returns the version of the note visible to the user, including the tombstones of deleted notes.
+	GetVersion(ctx context.Context, user *user.User, noteID uuid.UUID) (*Version, error)
+	// GetChanges returns the versions of the notes visible to the user changed after the cursor in the cursor order.
+	GetChanges(ctx context.Context, user *user.User, after Cursor, limit uint64) ([]*Version, error)
+	GetNotes(ctx context.Context, ids []uuid.UUID) ([]*note.Note, error)
+}
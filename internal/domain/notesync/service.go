@@ -0,0 +1,12 @@
+package notesync
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service defines methods for syncing notes of offline clients.
+type Service interface {
+	Sync(ctx context.Context, user *user.User, req *Request) (*Response, error)
+}
@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/op/driver"
+)
+
+// Type represents the kind of notification.
+type Type string
+
+var (
+	ErrNotFound      = errors.New("notification not found")
+	ErrUnknownType   = errors.New("unknown notification type")
+	ErrInvalidCursor = errors.New("invalid notifications cursor")
+)
+
+const (
+	// TypeReminder is the notification of a fired note reminder.
+	TypeReminder Type = "reminder"
+	// TypeOrgInvite is the notification of an invitation to the organisation sharing its notes.
+	TypeOrgInvite Type = "org_invite"
+	// TypeNewLogin is the notification of a login to the account from a device not seen before.
+	TypeNewLogin Type = "new_login"
+)
+
+// Notification represents a message of the in-app inbox of the user. NoteID refers to the note the notification
+// is about, it is cleared when the note is deleted.
+type Notification struct {
+	ID        uuid.UUID       `op:"id,primary"`
+	UserID    uuid.UUID       `op:"user_id"`
+	Type      Type            `op:"type"`
+	Title     string          `op:"title"`
+	Body      string          `op:"body"`
+	NoteID    uuid.NullUUID   `op:"note_id"`
+	CreatedAt time.Time       `op:"created_at"`
+	ReadAt    driver.ZeroTime `op:"read_at"`
+}
+
+// Preference represents the choice of the user to receive notifications of the type. Types without a preference
+// are enabled.
+type Preference struct {
+	ID        uuid.UUID `op:"id,primary"`
+	UserID    uuid.UUID `op:"user_id"`
+	Type      Type      `op:"type"`
+	Enabled   bool      `op:"enabled"`
+	UpdatedAt time.Time `op:"updated_at"`
+}
+
+// Cursor is the position in the notifications of the user ordered newest first. The zero cursor is the start.
+type Cursor struct {
+	ID uuid.UUID `json:"i"`
+}
+
+// ListRequest represents the page of notifications requested by the user. The limit is bounded by the service,
+// zero is the default page size.
+type ListRequest struct {
+	Cursor     Cursor
+	Limit      uint64
+	UnreadOnly bool
+}
+
+// Page represents the page of notifications with the unread count of the user. Next is the cursor of the
+// following page, set when HasMore is reported.
+type Page struct {
+	Rows    []*Notification
+	Unread  uint64
+	Next    Cursor
+	HasMore bool
+}
+
+// Types returns all notification types.
+func Types() []Type {
+	return []Type{TypeReminder, TypeOrgInvite, TypeNewLogin}
+}
+
+// ParseType returns the notification type of the name or ErrUnknownType.
+func ParseType(name string) (Type, error) {
+	for _, t := range Types() {
+		if string(t) == name {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q", ErrUnknownType, name)
+}
+
+// ParseCursor decodes the cursor sent by the client, the empty string is the zero cursor.
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return c, nil
+}
+
+// String encodes the cursor for the client, the zero cursor is the empty string.
+func (c Cursor) String() string {
+	if c.ID == uuid.Nil {
+		return ""
+	}
+
+	data, _ := json.Marshal(c) // a struct of a uuid always encodes
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,20 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines methods for managing notifications of the users and their preferences.
+type Repository interface {
+	Save(ctx context.Context, n *Notification) error
+	GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*Notification, error)
+	GetPage(ctx context.Context, userID uuid.UUID, after Cursor, unreadOnly bool, limit uint64) ([]*Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (uint64, error)
+	MarkAllRead(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]*Preference, error)
+	SavePreference(ctx context.Context, p *Preference) error
+}
@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Notifier delivers notifications to the inbox of their users, unless the user disabled the type.
+// Notify joins the transaction of the context, so notifications are stored only if the change is committed.
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// Service notifications inbox service interface. Users read and mark their own notifications and set the
+// preferences of the types. Run removes notifications older than the retention in the background.
+type Service interface {
+	Notify(ctx context.Context, n *Notification) error
+	List(ctx context.Context, user *user.User, req *ListRequest) (*Page, error)
+	MarkRead(ctx context.Context, user *user.User, id uuid.UUID) (*Notification, error)
+	MarkAllRead(ctx context.Context, user *user.User) (int64, error)
+	Preferences(ctx context.Context, user *user.User) ([]*Preference, error)
+	SetPreferences(ctx context.Context, user *user.User, enabled map[Type]bool) ([]*Preference, error)
+	Run(ctx context.Context, onError func(error))
+}
@@ -0,0 +1,112 @@
+package org
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/op/driver"
+)
+
+// MemberRole represents the role of a user within an organisation.
+type MemberRole string
+
+var (
+	ErrNotFound                  = errors.New("organisation not found")
+	ErrMemberNotFound            = errors.New("organisation member not found")
+	ErrInvitationNotFound        = errors.New("organisation invitation not found")
+	ErrAlreadyMember             = errors.New("user is already a member of the organisation")
+	ErrLastOwner                 = errors.New("organisation must keep at least one owner")
+	ErrUnknownMemberRole         = errors.New("unknown organisation member role")
+	ErrOperationForbiddenForUser = errors.New("organisation operation is forbidden for user")
+)
+
+const (
+	// MemberRoleOwner grants full control over the organisation, including its deletion.
+	MemberRoleOwner MemberRole = "owner"
+	// MemberRoleAdmin grants the ability to manage the organisation, its members and invitations.
+	MemberRoleAdmin MemberRole = "admin"
+	// MemberRoleMember grants the ability to work with the organisation notes.
+	MemberRoleMember MemberRole = "member"
+)
+
+// Org represents an organisation owning shared notes.
+type Org struct {
+	ID        uuid.UUID       `op:"id,primary"`
+	Name      string          `op:"name"`
+	CreatedAt time.Time       `op:"created_at"`
+	UpdatedAt driver.ZeroTime `op:"updated_at"`
+}
+
+// Member represents a membership of a user in an organisation.
+type Member struct {
+	ID        uuid.UUID  `op:"id,primary"`
+	OrgID     uuid.UUID  `op:"org_id"`
+	UserID    uuid.UUID  `op:"user_id"`
+	Role      MemberRole `op:"role"`
+	CreatedAt time.Time  `op:"created_at"`
+}
+
+// Invitation represents a pending invitation of a user to an organisation by email.
+type Invitation struct {
+	ID        uuid.UUID  `op:"id,primary"`
+	OrgID     uuid.UUID  `op:"org_id"`
+	Email     string     `op:"email"`
+	Role      MemberRole `op:"role"`
+	InvitedBy uuid.UUID  `op:"invited_by"`
+	CreatedAt time.Time  `op:"created_at"`
+}
+
+// CreateData represents the data required to create a new organisation.
+type CreateData struct {
+	Name string
+}
+
+// UpdateData represents the data required to update an existing organisation.
+type UpdateData struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// InviteData represents the data required to invite a user to an organisation.
+type InviteData struct {
+	OrgID uuid.UUID
+	Email string
+	Role  MemberRole
+}
+
+// MemberData represents the data required to change the role of an organisation member.
+type MemberData struct {
+	OrgID  uuid.UUID
+	UserID uuid.UUID
+	Role   MemberRole
+}
+
+// IsValid reports whether the member role is known.
+func (r MemberRole) IsValid() bool {
+	switch r {
+	case MemberRoleOwner, MemberRoleAdmin, MemberRoleMember:
+		return true
+	}
+
+	return false
+}
+
+// AtLeast reports whether the member role grants at least the same rights as the given role.
+func (r MemberRole) AtLeast(role MemberRole) bool {
+	return r.rank() >= role.rank()
+}
+
+// rank returns the weight of the member role, unknown roles have no rights.
+func (r MemberRole) rank() int {
+	switch r {
+	case MemberRoleOwner:
+		return 3
+	case MemberRoleAdmin:
+		return 2
+	case MemberRoleMember:
+		return 1
+	}
+
+	return 0
+}
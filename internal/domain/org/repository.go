@@ -0,0 +1,26 @@
+package org
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Repository defines methods for managing organisations, their members and invitations.
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Org, error)
+	GetByUser(ctx context.Context, user *user.User) ([]*Org, error)
+	Save(ctx context.Context, o *Org) error
+	Delete(ctx context.Context, o *Org) error
+	GetMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*Member, error)
+	GetMembers(ctx context.Context, o *Org) ([]*Member, error)
+	CountMembersByRole(ctx context.Context, o *Org, role MemberRole) (int64, error)
+	SaveMember(ctx context.Context, m *Member) error
+	DeleteMember(ctx context.Context, m *Member) error
+	GetInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	GetInvitations(ctx context.Context, o *Org) ([]*Invitation, error)
+	GetInvitationsByEmail(ctx context.Context, email string) ([]*Invitation, error)
+	SaveInvitation(ctx context.Context, i *Invitation) error
+	DeleteInvitation(ctx context.Context, i *Invitation) error
+}
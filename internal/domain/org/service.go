@@ -0,0 +1,25 @@
+package org
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service organisations service interface
+type Service interface {
+	List(ctx context.Context, user *user.User) ([]*Org, error)
+	Get(ctx context.Context, user *user.User, id uuid.UUID) (*Org, error)
+	Create(ctx context.Context, user *user.User, data *CreateData) (*Org, error)
+	Update(ctx context.Context, user *user.User, data *UpdateData) (*Org, error)
+	Delete(ctx context.Context, user *user.User, id uuid.UUID) (*Org, error)
+	Members(ctx context.Context, user *user.User, id uuid.UUID) ([]*Member, error)
+	UpdateMember(ctx context.Context, user *user.User, data *MemberData) (*Member, error)
+	RemoveMember(ctx context.Context, user *user.User, orgID uuid.UUID, userID uuid.UUID) error
+	Invite(ctx context.Context, user *user.User, data *InviteData) (*Invitation, error)
+	Invitations(ctx context.Context, user *user.User, id uuid.UUID) ([]*Invitation, error)
+	RevokeInvitation(ctx context.Context, user *user.User, orgID uuid.UUID, id uuid.UUID) error
+	MyInvitations(ctx context.Context, user *user.User) ([]*Invitation, error)
+	AcceptInvitation(ctx context.Context, user *user.User, id uuid.UUID) (*Member, error)
+}
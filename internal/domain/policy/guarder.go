@@ -0,0 +1,13 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// Guarder defines an interface for determining if a user has permission to perform an operation on policies.
+type Guarder interface {
+	IsGranted(ctx context.Context, op rbac.Operation, policy *rbac.Policy, user *user.User) (bool, error)
+}
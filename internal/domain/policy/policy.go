@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+var (
+	ErrUnknownResource           = errors.New("unknown policy resource")
+	ErrOperationForbiddenForUser = errors.New("policy operation is forbidden for user")
+)
+
+const (
+	// ResourceNote represents the resource type of notes in policies.
+	ResourceNote = "note"
+)
+
+const (
+	// PermissionRead grants the ability to read policies and explain access decisions.
+	PermissionRead role.Permission = "policies.read"
+)
+
+// ExplainData represents the operation to be evaluated in a dry run.
+// Zero ResourceID evaluates the operation without a concrete resource, zero UserID evaluates it for the requester.
+type ExplainData struct {
+	Resource   string
+	Operation  rbac.Operation
+	ResourceID uuid.UUID
+	UserID     uuid.UUID
+}
+
+// Explanation represents the result of a dry run, including the matched policy and the evaluated attributes.
+type Explanation struct {
+	Granted    bool
+	Decision   rbac.Decision
+	Attributes rbac.Attributes
+}
+
+// Permissions returns the list of permissions related to policies.
+func Permissions() []role.Permission {
+	return []role.Permission{PermissionRead}
+}
@@ -0,0 +1,14 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// Service access policies service interface
+type Service interface {
+	List(ctx context.Context, user *user.User) ([]rbac.Policy, error)
+	Explain(ctx context.Context, user *user.User, data *ExplainData) (*Explanation, error)
+}
@@ -0,0 +1,88 @@
+package reminder
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/op/driver"
+)
+
+// Status represents the state of a reminder.
+type Status string
+
+var (
+	ErrNotFound        = errors.New("reminder not found")
+	ErrInvalidRule     = errors.New("invalid reminder recurrence rule")
+	ErrInvalidTimeZone = errors.New("unknown reminder time zone")
+	ErrNoOccurrence    = errors.New("reminder has no occurrence in the future")
+	ErrNotFired        = errors.New("reminder has not fired yet")
+	ErrSnoozeInPast    = errors.New("reminder is snoozed until a time in the past")
+)
+
+const (
+	// StatusScheduled marks reminders waiting for the due time.
+	StatusScheduled Status = "scheduled"
+	// StatusFired marks reminders which fired with nothing scheduled afterwards, they may still be snoozed.
+	StatusFired Status = "fired"
+	// StatusDismissed marks reminders dismissed by the user with nothing scheduled afterwards.
+	StatusDismissed Status = "dismissed"
+)
+
+// Reminder represents a reminder of the note set by the user, each user has one reminder per note. RemindAt is
+// the first occurrence, repeated by the recurrence rule (RFC 5545 RRULE) in the time zone when the rule is set.
+// NextAt is the next occurrence, SnoozedUntil is the end of the snooze of the last firing, and DueAt is the earliest
+// of the two, the time the reminder fires. Attempts counts the failed firings of the due occurrence.
+type Reminder struct {
+	ID           uuid.UUID       `op:"id,primary"`
+	NoteID       uuid.UUID       `op:"note_id"`
+	UserID       uuid.UUID       `op:"user_id"`
+	RemindAt     time.Time       `op:"remind_at"`
+	TimeZone     string          `op:"time_zone"`
+	Rule         string          `op:"rule"`
+	Status       Status          `op:"status"`
+	NextAt       driver.ZeroTime `op:"next_at"`
+	SnoozedUntil driver.ZeroTime `op:"snoozed_until"`
+	DueAt        driver.ZeroTime `op:"due_at"`
+	FiredAt      driver.ZeroTime `op:"fired_at"`
+	Fired        int             `op:"fired"`
+	Attempts     int             `op:"attempts"`
+	LastError    string          `op:"last_error"`
+	CreatedAt    time.Time       `op:"created_at"`
+	UpdatedAt    driver.ZeroTime `op:"updated_at"`
+}
+
+// Schedule sets the due time to the earliest of the next occurrence and the end of the snooze. Reminders with
+// nothing scheduled are left in the idle status.
+func (r *Reminder) Schedule(idle Status) {
+	next, snoozed := time.Time(r.NextAt), time.Time(r.SnoozedUntil)
+	due := next
+	if due.IsZero() || (!snoozed.IsZero() && snoozed.Before(due)) {
+		due = snoozed
+	}
+
+	r.DueAt = driver.ZeroTime(due)
+	r.Status = StatusScheduled
+	if due.IsZero() {
+		r.Status = idle
+	}
+}
+
+// SetData represents the data required to set the reminder of the note. The time zone is an IANA name, UTC when
+// empty, the rule is empty for reminders firing once.
+type SetData struct {
+	NoteID   uuid.UUID
+	RemindAt time.Time
+	TimeZone string
+	Rule     string
+}
+
+// Notification represents the fired reminder of the note delivered to the user who set it.
+type Notification struct {
+	Reminder *Reminder
+	User     *user.User
+	Note     *note.Note
+	FiredAt  time.Time
+}
@@ -0,0 +1,16 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines methods for managing reminders of notes.
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Reminder, error)
+	GetByNote(ctx context.Context, noteID uuid.UUID, userID uuid.UUID) (*Reminder, error)
+	GetDue(ctx context.Context) (*Reminder, error)
+	Save(ctx context.Context, r *Reminder) error
+	Delete(ctx context.Context, r *Reminder) error
+}
@@ -0,0 +1,32 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service note reminders service interface. Reminders follow the access to their notes: users reading the note
+// set, snooze, dismiss and delete their own reminder of the note. Run fires due reminders in the background.
+type Service interface {
+	Get(ctx context.Context, user *user.User, noteID uuid.UUID) (*Reminder, error)
+	Set(ctx context.Context, user *user.User, data *SetData) (*Reminder, error)
+	Snooze(ctx context.Context, user *user.User, noteID uuid.UUID, until time.Time) (*Reminder, error)
+	Dismiss(ctx context.Context, user *user.User, noteID uuid.UUID) (*Reminder, error)
+	Delete(ctx context.Context, user *user.User, noteID uuid.UUID) (*Reminder, error)
+	Run(ctx context.Context, onError func(error))
+}
+
+// Notifier delivers the notifications of fired reminders. Notifiers are called within the transaction firing
+// the reminder and only write to the database, so every firing is delivered exactly once. Channels outside
+// the database, like email, are published to the outbox and delivered after the firing is committed.
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// Mailer sends plain text emails.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
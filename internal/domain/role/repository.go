@@ -3,11 +3,19 @@ package role
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/xsqrty/notes/internal/domain/user"
 )
 
 // Repository defines methods for managing user roles and permissions within the system.
 type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Role, error)
+	GetAll(ctx context.Context) ([]*Role, error)
+	Save(ctx context.Context, r *Role) error
+	Delete(ctx context.Context, r *Role) error
+	AttachUser(ctx context.Context, r *Role, user *user.User) error
+	DetachUser(ctx context.Context, r *Role, user *user.User) error
 	AttachUserRolesByLabel(ctx context.Context, label Label, user *user.User) error
 	HasPermissions(ctx context.Context, permissions []Permission, user *user.User) (bool, error)
+	GetUserPermissions(ctx context.Context, user *user.User) ([]Permission, error)
 }
@@ -1,6 +1,8 @@
 package role
 
 import (
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,9 +16,28 @@ type (
 	Permission string
 )
 
+var (
+	ErrNotFound                  = errors.New("role not found")
+	ErrUnknownPermission         = errors.New("unknown permission")
+	ErrOperationForbiddenForUser = errors.New("role operation is forbidden for user")
+)
+
 const (
 	// LabelOnCreated represents a predefined label for actions triggered when a resource is created.
 	LabelOnCreated Label = "on_created"
+	// LabelAdmin represents a predefined label of the administrator role.
+	LabelAdmin Label = "admin"
+)
+
+const (
+	// PermissionCreate grants the ability to create roles.
+	PermissionCreate Permission = "roles.create"
+	// PermissionDelete grants the ability to delete roles.
+	PermissionDelete Permission = "roles.delete"
+	// PermissionUpdate grants the ability to update roles and assign them to users.
+	PermissionUpdate Permission = "roles.update"
+	// PermissionRead grants the ability to read roles.
+	PermissionRead Permission = "roles.read"
 )
 
 // Role represents a user role in the system, containing metadata and associated permissions.
@@ -36,3 +57,59 @@ type UserRelation struct {
 	UserID    uuid.UUID `op:"user_id"`
 	CreatedAt time.Time `op:"created_at"`
 }
+
+// CreateData represents the data required to create a new role.
+type CreateData struct {
+	Description string
+	Label       Label
+	Permissions []Permission
+}
+
+// UpdateData represents the data required to update an existing role.
+type UpdateData struct {
+	ID          uuid.UUID
+	Description string
+	Label       Label
+	Permissions []Permission
+}
+
+// Assignment represents the relation between a role and a user to be created or removed.
+type Assignment struct {
+	RoleID uuid.UUID
+	UserID uuid.UUID
+}
+
+// Registry is a set of permissions known to the application.
+type Registry map[Permission]struct{}
+
+// Permissions returns the list of permissions related to roles administration.
+func Permissions() []Permission {
+	return []Permission{PermissionRead, PermissionCreate, PermissionUpdate, PermissionDelete}
+}
+
+// NewRegistry creates a Registry containing the given permissions.
+func NewRegistry(permissions ...Permission) Registry {
+	r := make(Registry, len(permissions))
+	for _, p := range permissions {
+		r[p] = struct{}{}
+	}
+
+	return r
+}
+
+// Has reports whether the permission is known to the registry.
+func (r Registry) Has(p Permission) bool {
+	_, ok := r[p]
+	return ok
+}
+
+// List returns the permissions of the registry sorted alphabetically.
+func (r Registry) List() []Permission {
+	res := make([]Permission, 0, len(r))
+	for p := range r {
+		res = append(res, p)
+	}
+
+	slices.Sort(res)
+	return res
+}
@@ -0,0 +1,20 @@
+package role
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service roles administration service interface
+type Service interface {
+	List(ctx context.Context, user *user.User) ([]*Role, error)
+	Get(ctx context.Context, user *user.User, id uuid.UUID) (*Role, error)
+	Create(ctx context.Context, user *user.User, data *CreateData) (*Role, error)
+	Update(ctx context.Context, user *user.User, data *UpdateData) (*Role, error)
+	Delete(ctx context.Context, user *user.User, id uuid.UUID) (*Role, error)
+	Assign(ctx context.Context, user *user.User, data *Assignment) error
+	Unassign(ctx context.Context, user *user.User, data *Assignment) error
+	Permissions(ctx context.Context, user *user.User) ([]Permission, error)
+}
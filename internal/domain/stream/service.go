@@ -0,0 +1,30 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service defines methods for streaming note changes to the subscribed users.
+type Service interface {
+	Subscribe(ctx context.Context, user *user.User, lastEventID uuid.NullUUID) (Subscription, error)
+	Run(ctx context.Context, onError func(error))
+}
+
+// Subscription defines methods of a single stream subscriber.
+type Subscription interface {
+	// Events returns the channel of events readable by the subscriber. The channel is closed when the subscriber
+	// falls behind or the stream is interrupted, the subscriber is expected to resume with the last event id.
+	Events() <-chan *Event
+	// Missed reports whether events after the last event id are no longer available for replay.
+	Missed() bool
+	Close()
+}
+
+// Listener defines methods for receiving the published note events.
+type Listener interface {
+	// Listen passes payloads of the published events to handle until the context is done or the listening fails.
+	Listen(ctx context.Context, handle func(payload []byte)) error
+}
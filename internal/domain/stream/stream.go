@@ -0,0 +1,23 @@
+package stream
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+)
+
+// Channel is the name of the Postgres notification channel carrying note events.
+const Channel = "note_events"
+
+var ErrClosed = errors.New("note stream is closed")
+
+// Event is a note change delivered to the stream subscribers.
+// The note carries its attributes without the text, which is fetched by the note id.
+type Event struct {
+	ID        uuid.UUID         `json:"id"`
+	Type      event.Type        `json:"type"`
+	CreatedAt time.Time         `json:"created_at"`
+	Note      event.NotePayload `json:"note"`
+}
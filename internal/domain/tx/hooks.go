@@ -0,0 +1,109 @@
+package tx
+
+import (
+	"context"
+	"sync"
+)
+
+// hooksKey is a context key used to store the hooks of the enclosing transaction.
+type hooksKey struct{}
+
+// hooks holds the functions run in the enclosing transaction before it is committed and once it is committed.
+type hooks struct {
+	mu     sync.Mutex
+	before []func(context.Context) error
+	fns    []func()
+}
+
+// hooksManager is a Manager decorator running the before-commit and after-commit hooks of the outermost
+// transaction.
+type hooksManager struct {
+	Manager
+}
+
+// WithHooks wraps the Manager so the functions registered by BeforeCommit run at the end of the outermost
+// transaction and the functions registered by AfterCommit run once it is committed. Nested transactions join
+// the hooks of the outermost one.
+func WithHooks(m Manager) Manager {
+	return &hooksManager{Manager: m}
+}
+
+// Transact runs fn in a transaction, running the before-commit hooks once fn succeeds and the after-commit hooks
+// when the outermost transaction is committed.
+func (m *hooksManager) Transact(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+		return m.Manager.Transact(ctx, fn)
+	}
+
+	h := &hooks{}
+	err := m.Manager.Transact(context.WithValue(ctx, hooksKey{}, h), func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		return h.runBefore(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	fns := h.fns
+	h.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+
+	return nil
+}
+
+// runBefore runs the before-commit hooks in the order they were registered, including the hooks registered
+// by the hooks themselves. The first error stops the hooks and rolls the transaction back.
+func (h *hooks) runBefore(ctx context.Context) error {
+	for {
+		h.mu.Lock()
+		if len(h.before) == 0 {
+			h.mu.Unlock()
+			return nil
+		}
+
+		fn := h.before[0]
+		h.before = h.before[1:]
+		h.mu.Unlock()
+
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// BeforeCommit registers fn to run in the transaction of the context once the work of the transaction is done,
+// right before it is committed. An error of fn rolls the transaction back. Outside of a transaction fn runs
+// at once and its error is returned.
+func BeforeCommit(ctx context.Context, fn func(context.Context) error) error {
+	h, ok := ctx.Value(hooksKey{}).(*hooks)
+	if !ok {
+		return fn(ctx)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.before = append(h.before, fn)
+	return nil
+}
+
+// AfterCommit registers fn to run once the transaction of the context is committed, fn is dropped
+// if the transaction is rolled back. Outside of a transaction fn runs at once.
+func AfterCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(hooksKey{}).(*hooks)
+	if !ok {
+		fn()
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fns = append(h.fns, fn)
+}
@@ -0,0 +1,29 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrDeviceNotFound = errors.New("device not found")
+
+// Device represents a client the user signed in from, identified by the fingerprint of its user agent.
+type Device struct {
+	ID          uuid.UUID `op:"id,primary"`
+	UserID      uuid.UUID `op:"user_id"`
+	Fingerprint string    `op:"fingerprint"`
+	UserAgent   string    `op:"user_agent"`
+	IP          string    `op:"ip"`
+	CreatedAt   time.Time `op:"created_at"`
+	LastSeenAt  time.Time `op:"last_seen_at"`
+}
+
+// DeviceFingerprint returns the fingerprint of the device with the user agent.
+func DeviceFingerprint(userAgent string) string {
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:])
+}
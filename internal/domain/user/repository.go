@@ -13,3 +13,10 @@ type Repository interface {
 	EmailExists(ctx context.Context, email string) (bool, error)
 	Save(ctx context.Context, u *User) error
 }
+
+// DeviceRepository defines methods for managing the devices the users signed in from.
+type DeviceRepository interface {
+	GetDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (*Device, error)
+	HasDevices(ctx context.Context, userID uuid.UUID) (bool, error)
+	SaveDevice(ctx context.Context, d *Device) error
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Repository defines methods for managing webhook endpoints and their deliveries.
+type Repository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*Endpoint, error)
+	GetByUser(ctx context.Context, user *user.User) ([]*Endpoint, error)
+	GetEnabledByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*Endpoint, error)
+	Save(ctx context.Context, e *Endpoint) error
+	Delete(ctx context.Context, e *Endpoint) error
+	Lock(ctx context.Context) error
+	GetDeliveries(ctx context.Context, e *Endpoint, limit uint64, offset uint64) (*search.Result[Delivery], error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit uint64) ([]*Delivery, error)
+	SaveDelivery(ctx context.Context, d *Delivery) error
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+)
+
+// Service webhooks service interface
+type Service interface {
+	List(ctx context.Context, user *user.User) ([]*Endpoint, error)
+	Get(ctx context.Context, user *user.User, id uuid.UUID) (*Endpoint, error)
+	Create(ctx context.Context, user *user.User, data *CreateData) (*Endpoint, error)
+	Update(ctx context.Context, user *user.User, data *UpdateData) (*Endpoint, error)
+	Delete(ctx context.Context, user *user.User, id uuid.UUID) (*Endpoint, error)
+	Deliveries(
+		ctx context.Context,
+		user *user.User,
+		id uuid.UUID,
+		limit uint64,
+		offset uint64,
+	) (*search.Result[Delivery], error)
+	SendTest(ctx context.Context, user *user.User, id uuid.UUID) (*Delivery, error)
+	HandleEvent(ctx context.Context, e *event.Event) error
+}
+
+// Sender defines methods for posting deliveries to endpoints.
+type Sender interface {
+	Send(ctx context.Context, e *Endpoint, d *Delivery) error
+	Run(ctx context.Context, onError func(error))
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/op/driver"
+)
+
+// DeliveryStatus represents the state of a webhook delivery.
+type DeliveryStatus string
+
+var (
+	ErrNotFound                  = errors.New("webhook not found")
+	ErrUnknownEventType          = errors.New("unknown webhook event type")
+	ErrOperationForbiddenForUser = errors.New("webhook operation is forbidden for user")
+)
+
+// TypeTest is the type of the test event sent on demand to check the endpoint.
+const TypeTest event.Type = "webhook.test"
+
+const (
+	// DeliveryPending marks deliveries waiting for the first attempt or for the next retry.
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySucceeded marks deliveries accepted by the endpoint with a 2xx response.
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed marks deliveries which exhausted attempts or were not retried.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Endpoint represents a URL of the user receiving the subscribed events.
+// Failures counts consecutive failed attempts, the endpoint is disabled when it reaches the configured limit.
+type Endpoint struct {
+	ID         uuid.UUID       `op:"id,primary"`
+	UserID     uuid.UUID       `op:"user_id"`
+	URL        string          `op:"url"`
+	Secret     string          `op:"secret"`
+	EventTypes []string        `op:"event_types"`
+	Enabled    bool            `op:"enabled"`
+	Failures   int             `op:"failures"`
+	DisabledAt driver.ZeroTime `op:"disabled_at"`
+	CreatedAt  time.Time       `op:"created_at"`
+	UpdatedAt  driver.ZeroTime `op:"updated_at"`
+}
+
+// Delivery represents an event sent to an endpoint, along with the result of the last attempt.
+// Test deliveries have no EventID.
+type Delivery struct {
+	ID            uuid.UUID       `op:"id,primary"`
+	EndpointID    uuid.UUID       `op:"endpoint_id"`
+	EventID       uuid.NullUUID   `op:"event_id"`
+	EventType     event.Type      `op:"event_type"`
+	Payload       []byte          `op:"payload"`
+	Status        DeliveryStatus  `op:"status"`
+	Attempts      int             `op:"attempts"`
+	ResponseCode  int             `op:"response_code"`
+	LastError     string          `op:"last_error"`
+	NextAttemptAt time.Time       `op:"next_attempt_at"`
+	CreatedAt     time.Time       `op:"created_at"`
+	DeliveredAt   driver.ZeroTime `op:"delivered_at"`
+}
+
+// Message represents the JSON body posted to endpoints.
+type Message struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      event.Type `json:"type"`
+	CreatedAt time.Time  `json:"created_at"`
+	Data      any        `json:"data"`
+}
+
+// CreateData represents the data required to register a new endpoint.
+type CreateData struct {
+	URL        string
+	EventTypes []event.Type
+}
+
+// UpdateData represents the data required to update an existing endpoint.
+// Enabling the endpoint resets its failures.
+type UpdateData struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []event.Type
+	Enabled    bool
+}
+
+// Subscribed reports whether the endpoint receives events of the type.
+func (e *Endpoint) Subscribed(typ event.Type) bool {
+	return slices.Contains(e.EventTypes, string(typ))
+}
+
+// EventTypes returns the list of event types endpoints may subscribe to.
+func EventTypes() []event.Type {
+	return []event.Type{event.TypeNoteCreated, event.TypeNoteUpdated, event.TypeNoteDeleted, event.TypeReminderFired}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentResponse represents the response structure for a file attached to a note.
+type AttachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	NoteID      uuid.UUID `json:"note_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentListResponse represents the response containing the list of attachments of a note.
+type AttachmentListResponse struct {
+	Rows []*AttachmentResponse `json:"rows"`
+}
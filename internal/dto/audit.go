@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEventResponse represents the response structure for an audit log event.
+type AuditEventResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Seq        int64     `json:"seq"`
+	Action     string    `json:"action"`
+	ActorID    uuid.UUID `json:"actor_id,omitzero"`
+	TargetType string    `json:"target_type"`
+	TargetID   uuid.UUID `json:"target_id,omitzero"`
+	Details    string    `json:"details,omitempty"`
+	RequestID  uuid.UUID `json:"request_id,omitzero"`
+	IP         string    `json:"ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AuditSearchResponse represents the response for an audit log query containing the total rows and list of events.
+type AuditSearchResponse struct {
+	TotalRows uint64                `json:"total_rows"`
+	Rows      []*AuditEventResponse `json:"rows"`
+}
+
+// AuditVerificationResponse represents the result of the audit log hash chain verification.
+// BrokenSeq is the sequence number of the first event not matching the chain, it is omitted for the valid chain.
+type AuditVerificationResponse struct {
+	Valid     bool  `json:"valid"`
+	Checked   int64 `json:"checked"`
+	BrokenSeq int64 `json:"broken_seq,omitempty"`
+}
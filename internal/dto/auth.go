@@ -26,7 +26,8 @@ type TokenResponse struct {
 
 // SignUpRequest represents the data structure for user sign-up requests.
 type SignUpRequest struct {
-	Name     string `json:"name"     validate:"required,min=2"`
-	Email    string `json:"email"    validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8,max=20"`
+	Name       string `json:"name"        validate:"required,min=2"`
+	Email      string `json:"email"       validate:"required,email"`
+	Password   string `json:"password"    validate:"required,min=8,max=20"`
+	InviteCode string `json:"invite_code" validate:"max=100"`
 }
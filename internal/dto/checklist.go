@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecklistItemRequest represents the request structure for adding or changing a checklist item of a note.
+// Omitted assignee and due date leave the item unassigned and without a due date.
+type ChecklistItemRequest struct {
+	Text       string    `json:"text"                  validate:"required,max=500"`
+	AssigneeID uuid.UUID `json:"assignee_id,omitzero"`
+	DueAt      time.Time `json:"due_at,omitzero"`
+}
+
+// ChecklistOrderRequest represents the request structure for reordering the checklist of a note, it lists
+// the identifiers of all the items in the new order.
+type ChecklistOrderRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,min=1"`
+}
+
+// ChecklistItemResponse represents the response structure for a checklist item of a note.
+type ChecklistItemResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	NoteID     uuid.UUID  `json:"note_id"`
+	Position   int        `json:"position"`
+	Text       string     `json:"text"`
+	Done       bool       `json:"done"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	DueAt      time.Time  `json:"due_at,omitzero"`
+	DoneAt     time.Time  `json:"done_at,omitzero"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at,omitzero"`
+}
+
+// ChecklistResponse represents the checklist of a note with its completion percentage.
+type ChecklistResponse struct {
+	Total      int                      `json:"total"`
+	Done       int                      `json:"done"`
+	Completion int                      `json:"completion"`
+	Items      []*ChecklistItemResponse `json:"items"`
+}
+
+// NoteChecklistResponse represents the completion of the checklist of a note.
+type NoteChecklistResponse struct {
+	Total      int `json:"total"`
+	Done       int `json:"done"`
+	Completion int `json:"completion"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// ExportPDFRequest represents the notes exported to a single PDF document.
+type ExportPDFRequest struct {
+	IDs      []uuid.UUID `json:"ids"                 validate:"required,min=1,max=100,unique"`
+	PageSize string      `json:"page_size,omitempty" validate:"omitempty,oneof=a4 a5 letter legal"`
+}
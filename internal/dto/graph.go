@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GraphResponse represents the graph of notes and their connections. Truncated is set when nodes or edges were
+// left out by the limits.
+type GraphResponse struct {
+	Nodes     []*GraphNodeResponse `json:"nodes"`
+	Edges     []*GraphEdgeResponse `json:"edges"`
+	Truncated bool                 `json:"truncated"`
+}
+
+// GraphNodeResponse represents a note of the graph without the text, which is fetched by the note id.
+type GraphNodeResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	OrgID     *uuid.UUID `json:"org_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at,omitzero"`
+}
+
+// GraphEdgeResponse represents the connection of the source note to the target note. Kind is link or similar,
+// the weight is the similarity of similar notes and 1 for links.
+type GraphEdgeResponse struct {
+	Source uuid.UUID `json:"source"`
+	Target uuid.UUID `json:"target"`
+	Kind   string    `json:"kind"`
+	Weight float64   `json:"weight"`
+}
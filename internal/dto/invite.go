@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InviteCodeRequest represents the data required to create an invitation code.
+// Empty role label attaches the default role, zero max uses and expiration time make the code unlimited.
+type InviteCodeRequest struct {
+	Email     string    `json:"email"      validate:"omitempty,email"`
+	RoleLabel string    `json:"role_label" validate:"max=100"`
+	MaxUses   int       `json:"max_uses"   validate:"min=0"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// InviteCodeResponse represents the response structure for an invitation code.
+type InviteCodeResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Email     string    `json:"email,omitempty"`
+	RoleLabel string    `json:"role_label"`
+	MaxUses   int       `json:"max_uses"`
+	Uses      int       `json:"uses"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InviteCodeListResponse represents the response containing the list of invitation codes.
+type InviteCodeListResponse struct {
+	Rows []*InviteCodeResponse `json:"rows"`
+}
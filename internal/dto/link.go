@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// LinkResponse represents a link written in a note. Note is the linked note, it is omitted for dangling links.
+type LinkResponse struct {
+	Target   string            `json:"target"`
+	Dangling bool              `json:"dangling"`
+	Note     *LinkNoteResponse `json:"note,omitempty"`
+}
+
+// LinkNoteResponse represents a linked or linking note without the text, which is fetched by the note id.
+type LinkNoteResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// LinkListResponse represents the response containing the links written in a note.
+type LinkListResponse struct {
+	Rows []*LinkResponse `json:"rows"`
+}
+
+// BacklinkListResponse represents the response containing the notes linking to a note.
+type BacklinkListResponse struct {
+	Rows []*LinkNoteResponse `json:"rows"`
+}
+
+// DanglingLinkResponse represents a link of the source note resolving to no note.
+type DanglingLinkResponse struct {
+	Source *LinkNoteResponse `json:"source"`
+	Target string            `json:"target"`
+}
+
+// DanglingLinkListResponse represents the response containing the dangling links of the notes.
+type DanglingLinkListResponse struct {
+	Rows []*DanglingLinkResponse `json:"rows"`
+}
@@ -4,22 +4,82 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/xsqrty/notes/pkg/httputil/httpio/errx"
 )
 
 // NoteRequest represents the data required to create or update a note.
+// OrgID is used on creation only to make the note owned by the organisation.
+// BaseVersion is used on update only, it is the version of the note the changes were made to.
+// Format defaults to plain on creation and keeps the format of the note on update.
 type NoteRequest struct {
-	Name string `json:"name" validate:"required,min=5,max=200"`
-	Text string `json:"text" validate:"required,min=5,max=2000"`
+	Name        string    `json:"name"                   validate:"required,min=5,max=200"`
+	Text        string    `json:"text"                   validate:"required,min=5,max=2000"`
+	Format      string    `json:"format,omitempty"       validate:"omitempty,oneof=plain markdown"`
+	OrgID       uuid.UUID `json:"org_id,omitzero"`
+	BaseVersion int64     `json:"base_version,omitempty" validate:"omitempty,min=1"`
+}
+
+// NotePatchDocument represents the note document changed by patches, the patched document is validated
+// as NoteRequest. Removed flags are cleared.
+type NotePatchDocument struct {
+	Name      string `json:"name"      validate:"required,min=5,max=200"`
+	Text      string `json:"text"      validate:"required,min=5,max=2000"`
+	Format    string `json:"format"    validate:"required,oneof=plain markdown"`
+	Pinned    bool   `json:"pinned"`
+	Archived  bool   `json:"archived"`
+	Favourite bool   `json:"favourite"`
+}
+
+// NoteBatchRequest represents the operations of a batch. Atomic batches, the default, apply all the operations
+// or none of them; best_effort batches apply every operation on its own.
+type NoteBatchRequest struct {
+	Mode       string                `json:"mode,omitempty" validate:"omitempty,oneof=atomic best_effort"`
+	Operations []*NoteBatchOpRequest `json:"operations"     validate:"required,min=1,dive"`
+}
+
+// NoteBatchOpRequest represents an operation of a batch. Updates and deletions carry the id of the note,
+// creations and updates carry the fields of the note as of NoteRequest.
+type NoteBatchOpRequest struct {
+	Op          string    `json:"op"                     validate:"required,oneof=create update delete"`
+	ID          uuid.UUID `json:"id,omitzero"            validate:"required_unless=Op create"`
+	Name        string    `json:"name,omitempty"         validate:"required_unless=Op delete,omitempty,min=5,max=200"`
+	Text        string    `json:"text,omitempty"         validate:"required_unless=Op delete,omitempty,min=5,max=2000"`
+	Format      string    `json:"format,omitempty"       validate:"omitempty,oneof=plain markdown"`
+	OrgID       uuid.UUID `json:"org_id,omitzero"`
+	BaseVersion int64     `json:"base_version,omitempty" validate:"omitempty,min=1"`
+}
+
+// NoteBatchResponse represents the outcome of the operations of a batch, in the order of the operations.
+type NoteBatchResponse struct {
+	Results []*NoteBatchResultResponse `json:"results"`
+}
+
+// NoteBatchResultResponse represents the outcome of an operation with the status code the operation would have
+// on its own: the created, updated or deleted note, or the error.
+type NoteBatchResultResponse struct {
+	Status int             `json:"status"`
+	Note   *NoteResponse   `json:"note,omitempty"`
+	Error  *errx.CodeError `json:"error,omitempty"`
 }
 
 // NoteResponse represents the response structure for a note, including metadata and ownership details.
+// Snippet is set in search results only, it is the beginning of the rendered text without the markup.
+// Checklist is set for notes with checklist items.
 type NoteResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	Text      string    `json:"text"`
-	UserID    uuid.UUID `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name"`
+	Text      string                 `json:"text"`
+	Format    string                 `json:"format"`
+	Snippet   string                 `json:"snippet,omitempty"`
+	UserID    uuid.UUID              `json:"user_id"`
+	OrgID     *uuid.UUID             `json:"org_id,omitempty"`
+	Version   int64                  `json:"version"`
+	Pinned    bool                   `json:"pinned"`
+	Archived  bool                   `json:"archived"`
+	Favourite bool                   `json:"favourite"`
+	Checklist *NoteChecklistResponse `json:"checklist,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at,omitzero"`
 }
 
 // NoteSearchResponse represents the response for a note search query containing the total rows and list of notes.
@@ -27,3 +87,27 @@ type NoteSearchResponse struct {
 	TotalRows uint64          `json:"total_rows"`
 	Rows      []*NoteResponse `json:"rows"`
 }
+
+// NoteRenderResponse represents the text of the note version rendered to sanitized HTML.
+type NoteRenderResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Version   int64     `json:"version"`
+	Format    string    `json:"format"`
+	HTML      string    `json:"html"`
+	PlainText string    `json:"plain_text"`
+}
+
+// NoteConflictResponse represents the response for an update which can not be merged with the current note.
+type NoteConflictResponse struct {
+	Error     *errx.CodeError             `json:"error"`
+	Note      *NoteResponse               `json:"note"`
+	Conflicts []*NoteConflictResponseHunk `json:"conflicts"`
+}
+
+// NoteConflictResponseHunk represents a region of the note field changed differently by the client and the server.
+type NoteConflictResponseHunk struct {
+	Field  string `json:"field"`
+	Base   string `json:"base"`
+	Client string `json:"client"`
+	Server string `json:"server"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NoteImportResponse represents the response structure for an import job with its progress.
+type NoteImportResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Format     string    `json:"format"`
+	Size       int64     `json:"size"`
+	Status     string    `json:"status"`
+	Total      int       `json:"total"`
+	Processed  int       `json:"processed"`
+	Created    int       `json:"created"`
+	Skipped    int       `json:"skipped"`
+	Failed     int       `json:"failed"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	StartedAt  time.Time `json:"started_at,omitzero"`
+	FinishedAt time.Time `json:"finished_at,omitzero"`
+}
+
+// NoteImportItemResponse represents the response structure for the outcome of importing a note.
+type NoteImportItemResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Position int       `json:"position"`
+	Source   string    `json:"source"`
+	Status   string    `json:"status"`
+	NoteID   uuid.UUID `json:"note_id,omitzero"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// NoteImportItemListResponse represents the response containing the total rows and the page of import items.
+type NoteImportItemListResponse struct {
+	TotalRows uint64                    `json:"total_rows"`
+	Rows      []*NoteImportItemResponse `json:"rows"`
+}
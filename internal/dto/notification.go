@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationResponse represents the response structure for a notification of the inbox.
+type NotificationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body,omitempty"`
+	NoteID    uuid.UUID `json:"note_id,omitzero"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"created_at"`
+	ReadAt    time.Time `json:"read_at,omitzero"`
+}
+
+// NotificationListResponse represents the page of notifications with the unread count of the user.
+// NextCursor is the cursor of the following page, it is omitted on the last page.
+type NotificationListResponse struct {
+	Unread     uint64                  `json:"unread"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+	HasMore    bool                    `json:"has_more"`
+	Rows       []*NotificationResponse `json:"rows"`
+}
+
+// NotificationMarkAllReadResponse represents the number of notifications marked read.
+type NotificationMarkAllReadResponse struct {
+	Marked int64 `json:"marked"`
+}
+
+// NotificationPreferencesRequest represents the request structure for enabling and disabling notification types.
+type NotificationPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences" validate:"required,min=1"`
+}
+
+// NotificationPreferenceResponse represents the preference of the user for a notification type.
+type NotificationPreferenceResponse struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// NotificationPreferencesResponse represents the preferences of the user for every notification type.
+type NotificationPreferencesResponse struct {
+	Preferences []*NotificationPreferenceResponse `json:"preferences"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgRequest represents the data required to create or update an organisation.
+type OrgRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=200"`
+}
+
+// OrgResponse represents the response structure for an organisation.
+type OrgResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// OrgListResponse represents the response containing the list of organisations.
+type OrgListResponse struct {
+	Rows []*OrgResponse `json:"rows"`
+}
+
+// OrgMemberRequest represents the data required to change the role of an organisation member.
+type OrgMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+// OrgMemberResponse represents the response structure for an organisation member.
+type OrgMemberResponse struct {
+	OrgID     uuid.UUID `json:"org_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrgMemberListResponse represents the response containing the list of organisation members.
+type OrgMemberListResponse struct {
+	Rows []*OrgMemberResponse `json:"rows"`
+}
+
+// OrgInvitationRequest represents the data required to invite a user to an organisation.
+type OrgInvitationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role"  validate:"required,oneof=owner admin member"`
+}
+
+// OrgInvitationResponse represents the response structure for an organisation invitation.
+type OrgInvitationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	OrgID     uuid.UUID `json:"org_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy uuid.UUID `json:"invited_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrgInvitationListResponse represents the response containing the list of organisation invitations.
+type OrgInvitationListResponse struct {
+	Rows []*OrgInvitationResponse `json:"rows"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/google/uuid"
+
+// PolicyConditionResponse represents a single condition of an access policy.
+type PolicyConditionResponse struct {
+	Attribute string `json:"attribute"`
+	Operator  string `json:"operator"`
+	Value     any    `json:"value,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+}
+
+// PolicyResponse represents the response structure for an access policy.
+type PolicyResponse struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Effect      string                     `json:"effect"`
+	Resource    string                     `json:"resource"`
+	Operations  []string                   `json:"operations"`
+	Conditions  []*PolicyConditionResponse `json:"conditions"`
+}
+
+// PolicyListResponse represents the response containing the list of loaded access policies.
+type PolicyListResponse struct {
+	Rows []*PolicyResponse `json:"rows"`
+}
+
+// PolicyExplainRequest represents the operation to be evaluated in a policies dry run.
+type PolicyExplainRequest struct {
+	Resource   string    `json:"resource"    validate:"required,oneof=note"`
+	Operation  string    `json:"operation"   validate:"required,oneof=read create update delete"`
+	ResourceID uuid.UUID `json:"resource_id"`
+	UserID     uuid.UUID `json:"user_id"`
+}
+
+// PolicyExplainResponse represents the result of a policies dry run.
+type PolicyExplainResponse struct {
+	Granted    bool           `json:"granted"`
+	Effect     string         `json:"effect,omitempty"`
+	Policy     string         `json:"policy,omitempty"`
+	Attributes map[string]any `json:"attributes"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReminderRequest represents the request structure for setting the reminder of a note. The time zone is an IANA
+// name, UTC when empty, the rule is an RFC 5545 RRULE of recurring reminders.
+type ReminderRequest struct {
+	RemindAt time.Time `json:"remind_at" validate:"required"`
+	TimeZone string    `json:"time_zone" validate:"omitempty,timezone"`
+	Rule     string    `json:"rrule"     validate:"max=500"`
+}
+
+// ReminderSnoozeRequest represents the request structure for snoozing the fired reminder of a note.
+type ReminderSnoozeRequest struct {
+	Until time.Time `json:"until" validate:"required"`
+}
+
+// ReminderResponse represents the response structure for the reminder of a note.
+type ReminderResponse struct {
+	ID           uuid.UUID `json:"id"`
+	NoteID       uuid.UUID `json:"note_id"`
+	RemindAt     time.Time `json:"remind_at"`
+	TimeZone     string    `json:"time_zone"`
+	Rule         string    `json:"rrule,omitempty"`
+	Status       string    `json:"status"`
+	NextAt       time.Time `json:"next_at,omitzero"`
+	SnoozedUntil time.Time `json:"snoozed_until,omitzero"`
+	DueAt        time.Time `json:"due_at,omitzero"`
+	FiredAt      time.Time `json:"fired_at,omitzero"`
+	Fired        int       `json:"fired"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at,omitzero"`
+}
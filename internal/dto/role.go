@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleRequest represents the data required to create or update a role.
+type RoleRequest struct {
+	Description string   `json:"description" validate:"max=500"`
+	Label       string   `json:"label"       validate:"max=100"`
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+// RoleResponse represents the response structure for a role, including its permissions.
+type RoleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Description string    `json:"description"`
+	Label       string    `json:"label"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at,omitzero"`
+}
+
+// RoleListResponse represents the response containing the list of roles.
+type RoleListResponse struct {
+	Rows []*RoleResponse `json:"rows"`
+}
+
+// PermissionListResponse represents the response containing the list of known permissions.
+type PermissionListResponse struct {
+	Rows []string `json:"rows"`
+}
+
+// RoleAssignmentResponse represents the relation between a role and a user.
+type RoleAssignmentResponse struct {
+	RoleID uuid.UUID `json:"role_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NoteEventResponse represents the data of a note change sent over the note stream.
+type NoteEventResponse struct {
+	ID        uuid.UUID              `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Note      *NoteEventNoteResponse `json:"note"`
+}
+
+// NoteEventNoteResponse represents the changed note without the text, which is fetched by the note id.
+type NoteEventNoteResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	UserID    uuid.UUID  `json:"user_id"`
+	OrgID     *uuid.UUID `json:"org_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at,omitzero"`
+}
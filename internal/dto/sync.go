@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// SyncRequest represents the local changes of an offline client and the token of its last sync.
+type SyncRequest struct {
+	Token   string               `json:"token,omitempty" validate:"max=512"`
+	Changes []*SyncChangeRequest `json:"changes"         validate:"max=100,dive"`
+}
+
+// SyncChangeRequest represents a local change of a note. Updates and deletions carry the id of the note and
+// the version the client has changed, creations may carry the organisation owning the note.
+type SyncChangeRequest struct {
+	Op          string    `json:"op"                     validate:"required,oneof=create update delete"`
+	ID          uuid.UUID `json:"id,omitzero"            validate:"required_unless=Op create"`
+	BaseVersion int64     `json:"base_version,omitempty" validate:"required_unless=Op create,omitempty,min=1"`
+	Name        string    `json:"name,omitempty"         validate:"required_unless=Op delete,omitempty,min=5,max=200"`
+	Text        string    `json:"text,omitempty"         validate:"required_unless=Op delete,omitempty,min=5,max=2000"`
+	OrgID       uuid.UUID `json:"org_id,omitzero"`
+}
+
+// SyncResponse represents the outcome of the local changes, in the order of the changes,
+// and the server changes since the token. The client syncs again with the token while has_more is set.
+type SyncResponse struct {
+	Results []*SyncResultResponse `json:"results"`
+	Changes []*SyncChangeResponse `json:"changes"`
+	Token   string                `json:"token"`
+	HasMore bool                  `json:"has_more"`
+}
+
+// SyncResultResponse represents the outcome of a local change: applied, conflict, not_found or forbidden.
+// Conflicts carry the server state of the note and the local change.
+type SyncResultResponse struct {
+	Status  string              `json:"status"`
+	ID      uuid.UUID           `json:"id,omitzero"`
+	Version int64               `json:"version,omitempty"`
+	Server  *SyncChangeResponse `json:"server,omitempty"`
+	Client  *SyncChangeRequest  `json:"client,omitempty"`
+}
+
+// SyncChangeResponse represents the server state of a note, deleted notes are returned without the note.
+type SyncChangeResponse struct {
+	ID      uuid.UUID     `json:"id"`
+	Version int64         `json:"version"`
+	Deleted bool          `json:"deleted,omitempty"`
+	Note    *NoteResponse `json:"note,omitempty"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRequest represents the data required to register a webhook endpoint.
+type WebhookRequest struct {
+	URL        string   `json:"url"         validate:"required,http_url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=note.created note.updated note.deleted reminder.fired"`
+}
+
+// WebhookUpdateRequest represents the data required to update a webhook endpoint.
+type WebhookUpdateRequest struct {
+	URL        string   `json:"url"         validate:"required,http_url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=note.created note.updated note.deleted reminder.fired"`
+	Enabled    bool     `json:"enabled"`
+}
+
+// WebhookResponse represents the response structure for a webhook endpoint.
+// The secret is returned only when the endpoint is created.
+type WebhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Enabled    bool      `json:"enabled"`
+	Failures   int       `json:"failures"`
+	DisabledAt time.Time `json:"disabled_at,omitzero"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at,omitzero"`
+}
+
+// WebhookListResponse represents the response containing the list of webhook endpoints.
+type WebhookListResponse struct {
+	Rows []*WebhookResponse `json:"rows"`
+}
+
+// WebhookDeliveryResponse represents the response structure for a webhook delivery.
+type WebhookDeliveryResponse struct {
+	ID            uuid.UUID `json:"id"`
+	EventID       uuid.UUID `json:"event_id,omitzero"`
+	EventType     string    `json:"event_type"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	ResponseCode  int       `json:"response_code,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	DeliveredAt   time.Time `json:"delivered_at,omitzero"`
+}
+
+// WebhookDeliveryListResponse represents the response containing the total rows and the page of deliveries.
+type WebhookDeliveryListResponse struct {
+	TotalRows uint64                     `json:"total_rows"`
+	Rows      []*WebhookDeliveryResponse `json:"rows"`
+}
@@ -0,0 +1,24 @@
+package guards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NewAuditGuarder creates an audit.Guarder instance using RBAC logic to determine user permissions for the audit log.
+func NewAuditGuarder(roleRepo role.Repository) audit.Guarder {
+	return rbac.NewRBAC[*audit.Event, *user.User](
+		func(ctx context.Context, operation rbac.Operation, _ *audit.Event, u *user.User) (bool, error) {
+			switch operation {
+			case rbac.READ:
+				return roleRepo.HasPermissions(ctx, []role.Permission{audit.PermissionRead}, u)
+			}
+			return false, fmt.Errorf("audit operation %q (%d) is not described", operation, operation)
+		},
+	)
+}
@@ -0,0 +1,28 @@
+package guards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NewInviteGuarder creates an invite.Guarder instance using RBAC logic to determine user permissions for invitation codes.
+func NewInviteGuarder(roleRepo role.Repository) invite.Guarder {
+	return rbac.NewRBAC[*invite.Code, *user.User](
+		func(ctx context.Context, operation rbac.Operation, _ *invite.Code, u *user.User) (bool, error) {
+			switch operation {
+			case rbac.READ:
+				return roleRepo.HasPermissions(ctx, []role.Permission{invite.PermissionRead}, u)
+			case rbac.DELETE:
+				return roleRepo.HasPermissions(ctx, []role.Permission{invite.PermissionDelete}, u)
+			case rbac.CREATE:
+				return roleRepo.HasPermissions(ctx, []role.Permission{invite.PermissionCreate}, u)
+			}
+			return false, fmt.Errorf("invitation code operation %q (%d) is not described", operation, operation)
+		},
+	)
+}
@@ -2,35 +2,102 @@ package guards
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/policy"
 	"github.com/xsqrty/notes/internal/domain/role"
 	"github.com/xsqrty/notes/internal/domain/user"
 	"github.com/xsqrty/notes/pkg/rbac"
 )
 
 // NewNoteGuarder creates a note.Guarder instance using RBAC logic to determine user permissions for note operations.
-func NewNoteGuarder(roleRepo role.Repository) note.Guarder {
+// Notes owned by an organisation are evaluated within the organisation context.
+// Policies of the engine matching the operation take precedence over the built-in rules.
+func NewNoteGuarder(roleRepo role.Repository, orgRepo org.Repository, engine *rbac.PolicyEngine) note.Guarder {
 	return rbac.NewRBAC[*note.Note, *user.User](
-		func(ctx context.Context, operation rbac.Operation, n *note.Note, u *user.User) (bool, error) {
-			switch operation {
-			case rbac.READ:
-				return isNoteReadGranted(ctx, roleRepo, n, u)
-			case rbac.DELETE:
-				return isNoteDeleteGranted(ctx, roleRepo, n, u)
-			case rbac.UPDATE:
-				return isNoteUpdateGranted(ctx, roleRepo, n, u)
-			case rbac.CREATE:
-				return isNoteCreateGranted(ctx, roleRepo, n, u)
-			}
-			return false, fmt.Errorf("note operation %q (%d) is not described", operation, operation)
-		},
+		rbac.WithPolicies(
+			engine,
+			policy.ResourceNote,
+			NewNoteAttributer(roleRepo, orgRepo),
+			func(ctx context.Context, operation rbac.Operation, n *note.Note, u *user.User) (bool, error) {
+				switch operation {
+				case rbac.READ:
+					return isNoteReadGranted(ctx, roleRepo, orgRepo, n, u)
+				case rbac.DELETE:
+					return isNoteDeleteGranted(ctx, roleRepo, orgRepo, n, u)
+				case rbac.UPDATE:
+					return isNoteUpdateGranted(ctx, roleRepo, orgRepo, n, u)
+				case rbac.CREATE:
+					return isNoteCreateGranted(ctx, roleRepo, orgRepo, n, u)
+				}
+				return false, fmt.Errorf("note operation %q (%d) is not described", operation, operation)
+			},
+		),
 	)
 }
 
+// NewNoteAttributer creates an rbac.Attributer collecting the user, note and environment attributes for note policies.
+func NewNoteAttributer(roleRepo role.Repository, orgRepo org.Repository) rbac.Attributer[*note.Note, *user.User] {
+	return func(ctx context.Context, n *note.Note, u *user.User) (rbac.Attributes, error) {
+		permissions, err := roleRepo.GetUserPermissions(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("note attributes: %w (user %s)", err, u.ID)
+		}
+
+		now := time.Now()
+		attrs := rbac.Attributes{
+			"subject.id":          u.ID,
+			"subject.email":       u.Email,
+			"subject.permissions": permissionsToStrings(permissions),
+			"resource.type":       policy.ResourceNote,
+			"env.hour":            now.Hour(),
+			"env.weekday":         int(now.Weekday()),
+		}
+
+		if n != nil {
+			attrs["resource.id"] = n.ID
+			attrs["resource.user_id"] = n.UserId
+			attrs["resource.name"] = n.Name
+		}
+
+		if n != nil && n.OrgID.Valid {
+			attrs["resource.org_id"] = n.OrgID.UUID
+			m, err := orgRepo.GetMember(ctx, n.OrgID.UUID, u.ID)
+			if err != nil && !errors.Is(err, org.ErrMemberNotFound) {
+				return nil, fmt.Errorf("note attributes: %w (user %s)", err, u.ID)
+			}
+
+			if m != nil {
+				attrs["subject.org_role"] = string(m.Role)
+			}
+		}
+
+		return attrs, nil
+	}
+}
+
+// permissionsToStrings converts permissions into the list of strings comparable by policy conditions.
+func permissionsToStrings(permissions []role.Permission) []string {
+	res := make([]string, len(permissions))
+	for i := range permissions {
+		res[i] = string(permissions[i])
+	}
+
+	return res
+}
+
 // isNoteReadGranted determines if a user has the permission to read a note based on their roles and note ownership.
-func isNoteReadGranted(ctx context.Context, roleRepo role.Repository, n *note.Note, u *user.User) (bool, error) {
+func isNoteReadGranted(
+	ctx context.Context,
+	roleRepo role.Repository,
+	orgRepo org.Repository,
+	n *note.Note,
+	u *user.User,
+) (bool, error) {
 	has, err := roleRepo.HasPermissions(ctx, []role.Permission{note.PermissionRead}, u)
 	if !has {
 		return false, err
@@ -40,31 +107,53 @@ func isNoteReadGranted(ctx context.Context, roleRepo role.Repository, n *note.No
 		return true, nil
 	}
 
-	return n.UserId == u.ID, nil
+	return isNoteAccessible(ctx, orgRepo, n, u, org.MemberRoleMember)
 }
 
 // isNoteDeleteGranted checks if a user has the required permission and ownership to delete a specific note.
-func isNoteDeleteGranted(ctx context.Context, roleRepo role.Repository, n *note.Note, u *user.User) (bool, error) {
+func isNoteDeleteGranted(
+	ctx context.Context,
+	roleRepo role.Repository,
+	orgRepo org.Repository,
+	n *note.Note,
+	u *user.User,
+) (bool, error) {
 	has, err := roleRepo.HasPermissions(ctx, []role.Permission{note.PermissionDelete}, u)
 	if !has {
 		return false, err
 	}
 
-	return n.UserId == u.ID, nil
+	if n.OrgID.Valid && n.UserId != u.ID {
+		return isNoteAccessible(ctx, orgRepo, n, u, org.MemberRoleAdmin)
+	}
+
+	return isNoteAccessible(ctx, orgRepo, n, u, org.MemberRoleMember)
 }
 
 // isNoteUpdateGranted checks if a user is allowed to update a given note based on their permissions and ownership.
-func isNoteUpdateGranted(ctx context.Context, roleRepo role.Repository, n *note.Note, u *user.User) (bool, error) {
+func isNoteUpdateGranted(
+	ctx context.Context,
+	roleRepo role.Repository,
+	orgRepo org.Repository,
+	n *note.Note,
+	u *user.User,
+) (bool, error) {
 	has, err := roleRepo.HasPermissions(ctx, []role.Permission{note.PermissionUpdate}, u)
 	if !has {
 		return false, err
 	}
 
-	return n.UserId == u.ID, nil
+	return isNoteAccessible(ctx, orgRepo, n, u, org.MemberRoleMember)
 }
 
 // isNoteCreateGranted determines if a user is authorized to create a note based on roles, permissions, and note ownership.
-func isNoteCreateGranted(ctx context.Context, roleRepo role.Repository, n *note.Note, u *user.User) (bool, error) {
+func isNoteCreateGranted(
+	ctx context.Context,
+	roleRepo role.Repository,
+	orgRepo org.Repository,
+	n *note.Note,
+	u *user.User,
+) (bool, error) {
 	has, err := roleRepo.HasPermissions(ctx, []role.Permission{note.PermissionCreate}, u)
 	if !has {
 		return false, err
@@ -74,5 +163,25 @@ func isNoteCreateGranted(ctx context.Context, roleRepo role.Repository, n *note.
 		return true, nil
 	}
 
-	return n.UserId == u.ID, nil
+	if n.UserId != u.ID {
+		return false, nil
+	}
+
+	return isNoteAccessible(ctx, orgRepo, n, u, org.MemberRoleMember)
+}
+
+// isNoteAccessible checks that the note is a personal note of the user or the user is a member
+// of the note organisation with at least the given role.
+func isNoteAccessible(
+	ctx context.Context,
+	orgRepo org.Repository,
+	n *note.Note,
+	u *user.User,
+	role org.MemberRole,
+) (bool, error) {
+	if !n.OrgID.Valid {
+		return n.UserId == u.ID, nil
+	}
+
+	return hasOrgRole(ctx, orgRepo, n.OrgID.UUID, u, role)
 }
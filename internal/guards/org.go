@@ -0,0 +1,52 @@
+package guards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NewOrgGuarder creates an org.Guarder instance using RBAC logic to determine user rights within organisations.
+// Any user may create an organisation, other operations require the corresponding member role.
+func NewOrgGuarder(orgRepo org.Repository) org.Guarder {
+	return rbac.NewRBAC[*org.Org, *user.User](
+		func(ctx context.Context, operation rbac.Operation, o *org.Org, u *user.User) (bool, error) {
+			switch operation {
+			case rbac.CREATE:
+				return true, nil
+			case rbac.READ:
+				return hasOrgRole(ctx, orgRepo, o.ID, u, org.MemberRoleMember)
+			case rbac.UPDATE:
+				return hasOrgRole(ctx, orgRepo, o.ID, u, org.MemberRoleAdmin)
+			case rbac.DELETE:
+				return hasOrgRole(ctx, orgRepo, o.ID, u, org.MemberRoleOwner)
+			}
+			return false, fmt.Errorf("org operation %q (%d) is not described", operation, operation)
+		},
+	)
+}
+
+// hasOrgRole checks if the user is a member of the organisation with at least the given role.
+func hasOrgRole(
+	ctx context.Context,
+	orgRepo org.Repository,
+	orgID uuid.UUID,
+	u *user.User,
+	role org.MemberRole,
+) (bool, error) {
+	m, err := orgRepo.GetMember(ctx, orgID, u.ID)
+	if err != nil {
+		if errors.Is(err, org.ErrMemberNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return m.Role.AtLeast(role), nil
+}
@@ -0,0 +1,24 @@
+package guards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xsqrty/notes/internal/domain/policy"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NewPolicyGuarder creates a policy.Guarder instance using RBAC logic to determine user permissions for policies.
+func NewPolicyGuarder(roleRepo role.Repository) policy.Guarder {
+	return rbac.NewRBAC[*rbac.Policy, *user.User](
+		func(ctx context.Context, operation rbac.Operation, _ *rbac.Policy, u *user.User) (bool, error) {
+			switch operation {
+			case rbac.READ:
+				return roleRepo.HasPermissions(ctx, []role.Permission{policy.PermissionRead}, u)
+			}
+			return false, fmt.Errorf("policy operation %q (%d) is not described", operation, operation)
+		},
+	)
+}
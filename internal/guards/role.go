@@ -0,0 +1,29 @@
+package guards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NewRoleGuarder creates a role.Guarder instance using RBAC logic to determine user permissions for roles administration.
+func NewRoleGuarder(roleRepo role.Repository) role.Guarder {
+	return rbac.NewRBAC[*role.Role, *user.User](
+		func(ctx context.Context, operation rbac.Operation, _ *role.Role, u *user.User) (bool, error) {
+			switch operation {
+			case rbac.READ:
+				return roleRepo.HasPermissions(ctx, []role.Permission{role.PermissionRead}, u)
+			case rbac.DELETE:
+				return roleRepo.HasPermissions(ctx, []role.Permission{role.PermissionDelete}, u)
+			case rbac.UPDATE:
+				return roleRepo.HasPermissions(ctx, []role.Permission{role.PermissionUpdate}, u)
+			case rbac.CREATE:
+				return roleRepo.HasPermissions(ctx, []role.Permission{role.PermissionCreate}, u)
+			}
+			return false, fmt.Errorf("role operation %q (%d) is not described", operation, operation)
+		},
+	)
+}
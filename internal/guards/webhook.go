@@ -0,0 +1,26 @@
+package guards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NewWebhookGuarder creates a webhook.Guarder instance using RBAC logic to determine user rights on webhook endpoints.
+// Any user may register an endpoint, other operations are granted to the owner only.
+func NewWebhookGuarder() webhook.Guarder {
+	return rbac.NewRBAC[*webhook.Endpoint, *user.User](
+		func(_ context.Context, operation rbac.Operation, e *webhook.Endpoint, u *user.User) (bool, error) {
+			switch operation {
+			case rbac.CREATE:
+				return true, nil
+			case rbac.READ, rbac.UPDATE, rbac.DELETE:
+				return e.UserID == u.ID, nil
+			}
+			return false, fmt.Errorf("webhook operation %q (%d) is not described", operation, operation)
+		},
+	)
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/xsqrty/notes/internal/config"
+)
+
+// CacheMetrics represents metrics for tracking in-process cache efficiency.
+// Hits counts the lookups served from the cache.
+// Misses counts the lookups that required loading the value from the source.
+type CacheMetrics struct {
+	Hits   *prometheus.CounterVec
+	Misses *prometheus.CounterVec
+}
+
+// NewCacheMetrics initializes and returns an instance of CacheMetrics configured with the provided MetricsConfig.
+func NewCacheMetrics(cfg config.MetricsConfig) *CacheMetrics {
+	cacheMetrics := &CacheMetrics{}
+	cacheMetrics.Hits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "cache_hits_total",
+		Help:      "Total number of cache hits",
+		Namespace: cfg.Namespace,
+		Subsystem: cfg.Subsystem,
+	}, []string{"cache"})
+
+	cacheMetrics.Misses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "cache_misses_total",
+		Help:      "Total number of cache misses",
+		Namespace: cfg.Namespace,
+		Subsystem: cfg.Subsystem,
+	}, []string{"cache"})
+
+	return cacheMetrics
+}
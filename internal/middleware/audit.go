@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/xsqrty/notes/internal/domain/audit"
+)
+
+// AuditSource is middleware that attaches the request ID, client IP and user agent to the request context,
+// so that audit events written by the service layer can be attributed to the originating request.
+func AuditSource(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		ctx := audit.WithSource(r.Context(), audit.Source{
+			RequestID: GetRequestID(r),
+			IP:        ip,
+			UserAgent: r.UserAgent(),
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
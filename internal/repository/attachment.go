@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// attachmentRepo is a concrete implementation of the attachment.Repository interface using a database connection pool.
+type attachmentRepo struct {
+	qe db.ConnPool
+}
+
+// attachmentUsageLock represents the usage lock row of a user written only to take its lock.
+type attachmentUsageLock struct {
+	UserID   uuid.UUID `op:"user_id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// noteAttachmentsTableName represents the name of the database table for storing attachments of notes.
+	noteAttachmentsTableName = "note_attachments"
+	// noteAttachmentUsageViewName represents the name of the database view summing attachment sizes per user.
+	noteAttachmentUsageViewName = "note_attachment_usage"
+	// noteAttachmentLocksTableName represents the name of the database table serializing uploads of a user.
+	noteAttachmentLocksTableName = "note_attachment_locks"
+)
+
+// NewAttachmentRepository initializes and returns an attachment.Repository implementation using the connection pool.
+func NewAttachmentRepository(qe db.ConnPool) attachment.Repository {
+	return &attachmentRepo{qe: qe}
+}
+
+// GetByID retrieves the attachment of the note by the identifier.
+func (r *attachmentRepo) GetByID(ctx context.Context, noteID uuid.UUID, id uuid.UUID) (*attachment.Attachment, error) {
+	a, err := orm.Query[attachment.Attachment](
+		op.Select().From(noteAttachmentsTableName).Where(op.And{op.Eq("id", id), op.Eq("note_id", noteID)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get attachment by id: %w (note %s, attachment %s)",
+			repoutil.RedefineNoRowsError(err, attachment.ErrNotFound),
+			noteID,
+			id,
+		)
+	}
+
+	return a, nil
+}
+
+// GetByNote retrieves all attachments of the note ordered by creation time.
+func (r *attachmentRepo) GetByNote(ctx context.Context, noteID uuid.UUID) ([]*attachment.Attachment, error) {
+	attachments, err := orm.Query[attachment.Attachment](
+		op.Select().From(noteAttachmentsTableName).Where(op.Eq("note_id", noteID)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get attachments by note: %w (note %s)", err, noteID)
+	}
+
+	return attachments, nil
+}
+
+// GetUsage returns the total size of the files attached by the user.
+func (r *attachmentRepo) GetUsage(ctx context.Context, userID uuid.UUID) (*attachment.Usage, error) {
+	usage, err := orm.Query[attachment.Usage](
+		op.Select().From(noteAttachmentUsageViewName).Where(op.Eq("user_id", userID)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &attachment.Usage{UserID: userID}, nil
+		}
+
+		return nil, fmt.Errorf("get attachment usage: %w (user %s)", err, userID)
+	}
+
+	return usage, nil
+}
+
+// LockUsage takes the lock of the attachment usage of the user, which is held until the enclosing transaction ends.
+// It prevents concurrent uploads of the user from exceeding the quota together.
+func (r *attachmentRepo) LockUsage(ctx context.Context, userID uuid.UUID) error {
+	lock := &attachmentUsageLock{UserID: userID, LockedAt: time.Now()}
+	if err := orm.Put(noteAttachmentLocksTableName, lock).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("lock attachment usage: %w (user %s)", err, userID)
+	}
+
+	return nil
+}
+
+// Save stores the attachment in the database, generating a new UUID for the created attachment.
+func (r *attachmentRepo) Save(ctx context.Context, a *attachment.Attachment) error {
+	if a.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save attachment (generate uuid): %w", err)
+		}
+
+		a.ID = id
+	}
+
+	if err := orm.Put(noteAttachmentsTableName, a).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save attachment: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the attachment from the database.
+func (r *attachmentRepo) Delete(ctx context.Context, a *attachment.Attachment) error {
+	_, err := orm.Exec(op.Delete(noteAttachmentsTableName).Where(op.Eq("id", a.ID))).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete attachment: %w (attachment %s)", err, a.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// auditRepo is a concrete implementation of the audit.Repository interface using a database connection pool.
+type auditRepo struct {
+	qe db.ConnPool
+}
+
+// auditHeadLock represents the chain head row written only to take its lock.
+type auditHeadLock struct {
+	ID       int       `op:"id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// auditEventsTableName represents the name of the database table for storing audit events.
+	auditEventsTableName = "audit_events"
+	// auditHeadTableName represents the name of the database table for storing the head of the audit hash chain.
+	auditHeadTableName = "audit_head"
+	// auditHeadID is the identifier of the single row of the audit head table.
+	auditHeadID = 1
+)
+
+// NewAuditRepository initializes and returns an audit.Repository implementation using the provided connection pool.
+func NewAuditRepository(qe db.ConnPool) audit.Repository {
+	return &auditRepo{qe: qe}
+}
+
+// Record appends the event to the hash chain in the transaction of the context right before it is committed.
+// Writers are serialized by the lock of the chain head row, which is held until the transaction ends,
+// so appending last keeps audited transactions from waiting for each other while they do their work.
+func (r *auditRepo) Record(ctx context.Context, e *audit.Event) error {
+	return tx.BeforeCommit(ctx, func(ctx context.Context) error {
+		return r.append(ctx, e)
+	})
+}
+
+// append appends the event to the hash chain, in a transaction of its own outside of a transaction.
+func (r *auditRepo) append(ctx context.Context, e *audit.Event) error {
+	err := r.qe.Transact(ctx, func(ctx context.Context) error {
+		err := orm.Put(auditHeadTableName, &auditHeadLock{ID: auditHeadID, LockedAt: time.Now()}).With(ctx, r.qe)
+		if err != nil {
+			return fmt.Errorf("lock head: %w", err)
+		}
+
+		head, err := r.GetHead(ctx)
+		if err != nil {
+			return err
+		}
+
+		if e.ID == uuid.Nil {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("generate uuid: %w", err)
+			}
+
+			e.ID = id
+		}
+
+		e.Seq = head.Seq + 1
+		e.PrevHash = head.Hash
+		e.Hash = e.ComputeHash()
+
+		if err := orm.Put(auditEventsTableName, e).With(ctx, r.qe); err != nil {
+			return err
+		}
+
+		return orm.Put(auditHeadTableName, &audit.Head{ID: auditHeadID, Seq: e.Seq, Hash: e.Hash}).With(ctx, r.qe)
+	})
+	if err != nil {
+		return fmt.Errorf("record audit event: %w (action %s)", err, e.Action)
+	}
+
+	return nil
+}
+
+// Search retrieves the events matching the filter ordered from the newest.
+func (r *auditRepo) Search(ctx context.Context, f *audit.Filter) (*search.Result[audit.Event], error) {
+	res, err := orm.Paginate[audit.Event](auditEventsTableName, &orm.PaginateRequest{
+		Orders: []orm.PaginateOrder{{Key: "seq", Desc: true}},
+		Limit:  f.Limit,
+		Offset: f.Offset,
+	}).
+		WhiteList("seq").
+		Where(auditFilterToCondition(f)).
+		With(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("search audit events: %w", err)
+	}
+
+	return &search.Result[audit.Event]{
+		Rows:      res.Rows,
+		TotalRows: res.TotalRows,
+	}, nil
+}
+
+// GetChain retrieves up to limit events following the sequence number in the chain order.
+func (r *auditRepo) GetChain(ctx context.Context, afterSeq int64, limit uint64) ([]*audit.Event, error) {
+	events, err := orm.Query[audit.Event](
+		op.Select().From(auditEventsTableName).Where(op.Gt("seq", afterSeq)).OrderBy(op.Asc("seq")).Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get audit chain: %w (after %d)", err, afterSeq)
+	}
+
+	return events, nil
+}
+
+// GetHead retrieves the sequence number and the hash of the last event of the chain.
+func (r *auditRepo) GetHead(ctx context.Context) (*audit.Head, error) {
+	head, err := orm.Query[audit.Head](
+		op.Select("id", "seq", "hash").From(auditHeadTableName).Where(op.Eq("id", auditHeadID)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get audit head: %w", err)
+	}
+
+	return head, nil
+}
+
+// auditFilterToCondition converts the audit filter to a query condition ignoring zero criteria.
+// The condition always matches recorded events, so it stays valid when the filter is empty.
+func auditFilterToCondition(f *audit.Filter) op.And {
+	cond := op.And{op.Gt("seq", 0)}
+	if f.ActorID != uuid.Nil {
+		cond = append(cond, op.Eq("actor_id", f.ActorID))
+	}
+
+	if f.Action != "" {
+		cond = append(cond, op.Eq("action", f.Action))
+	}
+
+	if f.TargetType != "" {
+		cond = append(cond, op.Eq("target_type", f.TargetType))
+	}
+
+	if f.TargetID != uuid.Nil {
+		cond = append(cond, op.Eq("target_id", f.TargetID))
+	}
+
+	if !f.From.IsZero() {
+		cond = append(cond, op.Gte("created_at", f.From))
+	}
+
+	if !f.To.IsZero() {
+		cond = append(cond, op.Lt("created_at", f.To))
+	}
+
+	return cond
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// checklistRepo is a concrete implementation of the checklist.Repository interface using a database connection pool.
+type checklistRepo struct {
+	qe db.ConnPool
+}
+
+// checklistLock represents the checklist lock row of a note written only to take its lock.
+type checklistLock struct {
+	NoteID   uuid.UUID `op:"note_id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// noteChecklistItemsTableName represents the name of the database table for storing checklist items of notes.
+	noteChecklistItemsTableName = "note_checklist_items"
+	// noteChecklistLocksTableName represents the name of the database table serializing checklist changes of a note.
+	noteChecklistLocksTableName = "note_checklist_locks"
+)
+
+// NewChecklistRepository initializes and returns a checklist.Repository implementation using the connection pool.
+func NewChecklistRepository(qe db.ConnPool) checklist.Repository {
+	return &checklistRepo{qe: qe}
+}
+
+// Lock takes the checklist lock of the note, which is held until the enclosing transaction ends. It prevents
+// concurrent changes of the checklist from saving the completion counted from stale items.
+func (r *checklistRepo) Lock(ctx context.Context, noteID uuid.UUID) error {
+	lock := &checklistLock{NoteID: noteID, LockedAt: time.Now()}
+	if err := orm.Put(noteChecklistLocksTableName, lock).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("lock checklist: %w (note %s)", err, noteID)
+	}
+
+	return nil
+}
+
+// GetByNote retrieves the checklist items of the note ordered by the position.
+func (r *checklistRepo) GetByNote(ctx context.Context, noteID uuid.UUID) ([]*checklist.Item, error) {
+	items, err := orm.Query[checklist.Item](
+		op.Select().From(noteChecklistItemsTableName).Where(op.Eq("note_id", noteID)).OrderBy(op.Asc("position")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get checklist items by note: %w (note %s)", err, noteID)
+	}
+
+	return items, nil
+}
+
+// Save stores the given checklist item in the database, generating a new UUID for the created item.
+func (r *checklistRepo) Save(ctx context.Context, item *checklist.Item) error {
+	if item.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save checklist item (generate uuid): %w", err)
+		}
+
+		item.ID = id
+	}
+
+	if err := orm.Put(noteChecklistItemsTableName, item).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save checklist item: %w (note %s, item %s)", err, item.NoteID, item.ID)
+	}
+
+	return nil
+}
+
+// Delete removes the checklist item.
+func (r *checklistRepo) Delete(ctx context.Context, item *checklist.Item) error {
+	_, err := orm.Exec(
+		op.Delete(noteChecklistItemsTableName).Where(op.Eq("id", item.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete checklist item: %w (note %s, item %s)", err, item.NoteID, item.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/collab"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// collabRepo is a concrete implementation of the collab.Repository interface using a database connection pool.
+type collabRepo struct {
+	qe db.ConnPool
+}
+
+// collabLock represents the collaboration lock row written only to take its lock.
+type collabLock struct {
+	ID       int       `op:"id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// collabStatesTableName represents the name of the database table for storing compacted documents.
+	collabStatesTableName = "note_collab_states"
+	// collabUpdatesTableName represents the name of the database table for storing not compacted updates.
+	collabUpdatesTableName = "note_collab_updates"
+	// collabLockTableName represents the name of the database table serializing compactions.
+	collabLockTableName = "note_collab_lock"
+	// collabLockID is the identifier of the single row of the collaboration lock table.
+	collabLockID = 1
+)
+
+// NewCollabRepository initializes and returns a collab.Repository implementation using the provided connection pool.
+func NewCollabRepository(qe db.ConnPool) collab.Repository {
+	return &collabRepo{qe: qe}
+}
+
+// Lock takes the lock of the compaction, which is held until the enclosing transaction ends.
+// It prevents concurrent compactions from deleting updates folded into another state.
+func (r *collabRepo) Lock(ctx context.Context) error {
+	err := orm.Put(collabLockTableName, &collabLock{ID: collabLockID, LockedAt: time.Now()}).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("lock collab: %w", err)
+	}
+
+	return nil
+}
+
+// GetNote retrieves the note edited collaboratively by the identifier regardless of its visibility.
+func (r *collabRepo) GetNote(ctx context.Context, id uuid.UUID) (*note.Note, error) {
+	n, err := orm.Query[note.Note](
+		op.Select().From(notesTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get collab note: %w", repoutil.RedefineNoRowsError(err, note.ErrNotFound))
+	}
+
+	return n, nil
+}
+
+// GetState retrieves the compacted document of the note.
+func (r *collabRepo) GetState(ctx context.Context, noteID uuid.UUID) (*collab.State, error) {
+	s, err := orm.Query[collab.State](
+		op.Select().From(collabStatesTableName).Where(op.Eq("note_id", noteID)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get collab state: %w", repoutil.RedefineNoRowsError(err, collab.ErrStateNotFound))
+	}
+
+	return s, nil
+}
+
+// SaveState stores the compacted document of the note.
+func (r *collabRepo) SaveState(ctx context.Context, s *collab.State) error {
+	if err := orm.Put(collabStatesTableName, s).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save collab state: %w (note %s)", err, s.NoteID)
+	}
+
+	return nil
+}
+
+// GetUpdate retrieves the update by the identifier.
+func (r *collabRepo) GetUpdate(ctx context.Context, id uuid.UUID) (*collab.Update, error) {
+	u, err := orm.Query[collab.Update](
+		op.Select().From(collabUpdatesTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get collab update: %w", repoutil.RedefineNoRowsError(err, collab.ErrUpdateNotFound))
+	}
+
+	return u, nil
+}
+
+// GetUpdates retrieves the updates of the note not compacted yet in the saving order.
+func (r *collabRepo) GetUpdates(ctx context.Context, noteID uuid.UUID) ([]*collab.Update, error) {
+	updates, err := orm.Query[collab.Update](
+		op.Select().From(collabUpdatesTableName).Where(op.Eq("note_id", noteID)).OrderBy(op.Asc("id")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get collab updates: %w (note %s)", err, noteID)
+	}
+
+	return updates, nil
+}
+
+// SaveUpdate stores the update, generating a new time-ordered UUID for it.
+func (r *collabRepo) SaveUpdate(ctx context.Context, u *collab.Update) error {
+	if u.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save collab update (generate uuid): %w", err)
+		}
+
+		u.ID = id
+	}
+
+	if err := orm.Put(collabUpdatesTableName, u).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save collab update: %w (note %s)", err, u.NoteID)
+	}
+
+	return nil
+}
+
+// DeleteUpdates removes the updates compacted into the state.
+func (r *collabRepo) DeleteUpdates(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	_, err := orm.Exec(op.Delete(collabUpdatesTableName).Where(op.In("id", values...))).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete collab updates: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// deviceRepo is a concrete implementation of the user.DeviceRepository interface using a database connection pool.
+type deviceRepo struct {
+	qe db.ConnPool
+}
+
+// userDevicesTableName specifies the name of the database table used for storing devices of the users.
+const userDevicesTableName = "user_devices"
+
+// NewDeviceRepository initializes and returns a user.DeviceRepository implementation using the connection pool.
+func NewDeviceRepository(qe db.ConnPool) user.DeviceRepository {
+	return &deviceRepo{qe: qe}
+}
+
+// GetDevice retrieves the device of the user by the fingerprint.
+func (r *deviceRepo) GetDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (*user.Device, error) {
+	d, err := orm.Query[user.Device](
+		op.Select().
+			From(userDevicesTableName).
+			Where(op.And{op.Eq("user_id", userID), op.Eq("fingerprint", fingerprint)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get user device: %w (user %s)",
+			repoutil.RedefineNoRowsError(err, user.ErrDeviceNotFound),
+			userID,
+		)
+	}
+
+	return d, nil
+}
+
+// HasDevices reports whether the user signed in from any device before.
+func (r *deviceRepo) HasDevices(ctx context.Context, userID uuid.UUID) (bool, error) {
+	count, err := orm.Count(op.Select().From(userDevicesTableName).Where(op.Eq("user_id", userID))).With(ctx, r.qe)
+	if err != nil {
+		return false, fmt.Errorf("check user devices: %w (user %s)", err, userID)
+	}
+
+	return count > 0, nil
+}
+
+// SaveDevice stores the device of the user in the database, generating a new UUID for the created device.
+// The device of the user with the same fingerprint is updated instead, so concurrent logins from a new device
+// store it once.
+func (r *deviceRepo) SaveDevice(ctx context.Context, d *user.Device) error {
+	if d.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save user device (generate uuid): %w", err)
+		}
+
+		d.ID = id
+	}
+
+	_, err := orm.Exec(
+		op.Insert(userDevicesTableName, op.Inserting{
+			"id":           d.ID,
+			"user_id":      d.UserID,
+			"fingerprint":  d.Fingerprint,
+			"user_agent":   d.UserAgent,
+			"ip":           d.IP,
+			"created_at":   d.CreatedAt,
+			"last_seen_at": d.LastSeenAt,
+		}).OnConflict(op.Columns{"user_id", "fingerprint"}, op.DoUpdateSet{
+			"user_agent":   op.Excluded("user_agent"),
+			"ip":           op.Excluded("ip"),
+			"last_seen_at": op.Excluded("last_seen_at"),
+		}),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("save user device: %w (user %s)", err, d.UserID)
+	}
+
+	return nil
+}
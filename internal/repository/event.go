@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// eventRepo is a concrete implementation of the event.Repository interface using a database connection pool.
+type eventRepo struct {
+	qe db.ConnPool
+}
+
+// outboxLock represents the outbox lock row written only to take its lock.
+type outboxLock struct {
+	ID       int       `op:"id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// outboxEventsTableName represents the name of the database table for storing outbox events.
+	outboxEventsTableName = "outbox_events"
+	// outboxEventsDueViewName represents the name of the database view of the events due for delivery.
+	outboxEventsDueViewName = "outbox_events_due"
+	// outboxLockTableName represents the name of the database table serializing outbox dispatchers.
+	outboxLockTableName = "outbox_lock"
+	// outboxLockID is the identifier of the single row of the outbox lock table.
+	outboxLockID = 1
+)
+
+// NewEventRepository initializes and returns an event.Repository implementation using the provided connection pool.
+func NewEventRepository(qe db.ConnPool) event.Repository {
+	return &eventRepo{qe: qe}
+}
+
+// Publish stores the events in the outbox assigning time-ordered identifiers.
+func (r *eventRepo) Publish(ctx context.Context, events ...*event.Event) error {
+	for _, e := range events {
+		if e.ID == uuid.Nil {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("publish event (generate uuid): %w", err)
+			}
+
+			e.ID = id
+		}
+
+		if err := orm.Put(outboxEventsTableName, e).With(ctx, r.qe); err != nil {
+			return fmt.Errorf("publish event: %w (type %s, aggregate %s)", err, e.Type, e.AggregateID)
+		}
+	}
+
+	return nil
+}
+
+// Lock takes the lock of the outbox, which is held until the enclosing transaction ends.
+// It prevents concurrent dispatchers from breaking the delivery order.
+func (r *eventRepo) Lock(ctx context.Context) error {
+	err := orm.Put(outboxLockTableName, &outboxLock{ID: outboxLockID, LockedAt: time.Now()}).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("lock outbox: %w", err)
+	}
+
+	return nil
+}
+
+// GetPending retrieves up to limit pending events due for delivery in the publishing order. Events of
+// the aggregates with an earlier pending event which is not due yet are left out to keep the order.
+func (r *eventRepo) GetPending(ctx context.Context, limit uint64) ([]*event.Event, error) {
+	events, err := orm.Query[event.Event](
+		op.Select().
+			From(outboxEventsDueViewName).
+			OrderBy(op.Asc("id")).
+			Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get pending events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Save updates the delivery state of the event.
+func (r *eventRepo) Save(ctx context.Context, e *event.Event) error {
+	if err := orm.Put(outboxEventsTableName, e).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save event: %w (event %s)", err, e.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// graphRepo is a concrete implementation of the graph.Repository interface using a database connection pool.
+type graphRepo struct {
+	qe db.ConnPool
+}
+
+// graphNodeFields are the columns of the notes read as the nodes of the graph, the texts are left out.
+var graphNodeFields = []any{"id", "name", "user_id", "org_id", "terms", "created_at", "updated_at"}
+
+// NewGraphRepository initializes and returns a graph.Repository implementation using the connection pool.
+func NewGraphRepository(qe db.ConnPool) graph.Repository {
+	return &graphRepo{qe: qe}
+}
+
+// GetNodes retrieves up to the limit of the notes visible to the user, the latest created first.
+func (r *graphRepo) GetNodes(ctx context.Context, u *user.User, limit uint64) ([]*note.Note, error) {
+	visibility, err := (&noteRepo{r.qe}).visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get graph nodes: %w", err)
+	}
+
+	nodes, err := orm.Query[note.Note](
+		op.Select(graphNodeFields...).
+			From(notesTableName).
+			Where(visibility).
+			OrderBy(op.Desc("created_at")).
+			Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get graph nodes: %w (user %s)", err, u.ID)
+	}
+
+	return nodes, nil
+}
+
+// GetNodesByIDs retrieves the notes visible to the user among the identifiers, notes not found are left out.
+func (r *graphRepo) GetNodesByIDs(ctx context.Context, u *user.User, ids []uuid.UUID) ([]*note.Note, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	visibility, err := (&noteRepo{r.qe}).visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get graph nodes by ids: %w", err)
+	}
+
+	nodes, err := orm.Query[note.Note](
+		op.Select(graphNodeFields...).From(notesTableName).Where(op.And{op.In("id", uuidValues(ids)...), visibility}),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get graph nodes by ids: %w (user %s)", err, u.ID)
+	}
+
+	return nodes, nil
+}
+
+// GetSimilar retrieves up to the limit of the notes visible to the user sharing any of the terms.
+func (r *graphRepo) GetSimilar(ctx context.Context, u *user.User, terms []string, limit uint64) ([]*note.Note, error) {
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	visibility, err := (&noteRepo{r.qe}).visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get similar graph nodes: %w", err)
+	}
+
+	nodes, err := orm.Query[note.Note](
+		op.Select(graphNodeFields...).
+			From(notesTableName).
+			Where(op.And{op.Lc("terms", terms), visibility}).
+			OrderBy(op.Desc("created_at")).
+			Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get similar graph nodes: %w (user %s)", err, u.ID)
+	}
+
+	return nodes, nil
+}
+
+// GetLinks retrieves the links from or to the notes, dangling links included.
+func (r *graphRepo) GetLinks(ctx context.Context, ids []uuid.UUID) ([]*link.Link, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	values := uuidValues(ids)
+	links, err := orm.Query[link.Link](
+		op.Select().
+			From(noteLinksTableName).
+			Where(op.Or{op.In("source_id", values...), op.In("target_id", values...)}).
+			OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get graph links: %w", err)
+	}
+
+	return links, nil
+}
+
+// uuidValues returns the identifiers as the values of the query.
+func uuidValues(ids []uuid.UUID) []any {
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	return values
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// inviteRepo is a concrete implementation of the invite.Repository interface using a database connection pool.
+type inviteRepo struct {
+	qe db.ConnPool
+}
+
+const (
+	// inviteCodesTableName represents the name of the database table for storing invitation codes.
+	inviteCodesTableName = "invite_codes"
+	// inviteCodeUsesTableName represents the name of the database table for storing uses of invitation codes.
+	inviteCodeUsesTableName = "invite_code_uses"
+)
+
+// NewInviteRepository initializes and returns an invite.Repository implementation using the provided connection pool.
+func NewInviteRepository(qe db.ConnPool) invite.Repository {
+	return &inviteRepo{qe: qe}
+}
+
+// GetByID retrieves an invitation code from the database by the identifier.
+func (r *inviteRepo) GetByID(ctx context.Context, id uuid.UUID) (*invite.Code, error) {
+	c, err := orm.Query[invite.Code](
+		op.Select().From(inviteCodesTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get invitation code by id: %w", repoutil.RedefineNoRowsError(err, invite.ErrNotFound))
+	}
+
+	return c, nil
+}
+
+// GetByCode retrieves an invitation code from the database by its value.
+func (r *inviteRepo) GetByCode(ctx context.Context, code string) (*invite.Code, error) {
+	c, err := orm.Query[invite.Code](
+		op.Select().From(inviteCodesTableName).Where(op.Eq("code", code)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get invitation code: %w", repoutil.RedefineNoRowsError(err, invite.ErrNotFound))
+	}
+
+	return c, nil
+}
+
+// Lock locks the invitation code with the value until the enclosing transaction ends, so the uses of the code
+// are counted by one consumer at a time. Returns invite.ErrNotFound if there is no such code.
+func (r *inviteRepo) Lock(ctx context.Context, code string) error {
+	res, err := orm.Exec(
+		op.Update(inviteCodesTableName, op.Updates{"code": code}).Where(op.Eq("code", code)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("lock invitation code: %w", err)
+	}
+
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("lock invitation code: %w", invite.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetAll retrieves all invitation codes from the database ordered by creation time.
+func (r *inviteRepo) GetAll(ctx context.Context) ([]*invite.Code, error) {
+	codes, err := orm.Query[invite.Code](
+		op.Select().From(inviteCodesTableName).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get all invitation codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Save stores the given invitation code in the database, generating a new UUID for the created code.
+func (r *inviteRepo) Save(ctx context.Context, c *invite.Code) error {
+	if c.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save invitation code (generate uuid): %w", err)
+		}
+
+		c.ID = id
+	}
+
+	err := orm.Put(inviteCodesTableName, c).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("save invitation code: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the invitation code from the database. Its uses are removed by cascade.
+func (r *inviteRepo) Delete(ctx context.Context, c *invite.Code) error {
+	_, err := orm.Exec(
+		op.Delete(inviteCodesTableName).Where(op.Eq("id", c.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete invitation code: %w", err)
+	}
+
+	return nil
+}
+
+// SaveUse stores the use of the invitation code in the database. The use number is unique per code.
+func (r *inviteRepo) SaveUse(ctx context.Context, u *invite.Use) error {
+	if u.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save invitation code use (generate uuid): %w", err)
+		}
+
+		u.ID = id
+	}
+
+	err := orm.Put(inviteCodeUsesTableName, u).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("save invitation code use: %w (code %s)", err, u.CodeID)
+	}
+
+	return nil
+}
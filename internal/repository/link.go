@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// linkRepo is a concrete implementation of the link.Repository interface using a database connection pool.
+type linkRepo struct {
+	qe db.ConnPool
+}
+
+// noteLinksTableName represents the name of the database table for storing links between notes.
+const noteLinksTableName = "note_links"
+
+// NewLinkRepository initializes and returns a link.Repository implementation using the connection pool.
+func NewLinkRepository(qe db.ConnPool) link.Repository {
+	return &linkRepo{qe: qe}
+}
+
+// GetBySource retrieves the links written in the source note in the order they were created.
+func (r *linkRepo) GetBySource(ctx context.Context, sourceID uuid.UUID) ([]*link.Link, error) {
+	links, err := orm.Query[link.Link](
+		op.Select().From(noteLinksTableName).Where(op.Eq("source_id", sourceID)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get links by source: %w (note %s)", err, sourceID)
+	}
+
+	return links, nil
+}
+
+// GetByTarget retrieves the links resolving to the target note.
+func (r *linkRepo) GetByTarget(ctx context.Context, targetID uuid.UUID) ([]*link.Link, error) {
+	links, err := orm.Query[link.Link](
+		op.Select().From(noteLinksTableName).Where(op.Eq("target_id", targetID)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get links by target: %w (note %s)", err, targetID)
+	}
+
+	return links, nil
+}
+
+// GetDangling retrieves the dangling links of the notes visible to the user.
+func (r *linkRepo) GetDangling(ctx context.Context, u *user.User) ([]*link.Link, error) {
+	visibility, err := (&noteRepo{r.qe}).visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get dangling links: %w", err)
+	}
+
+	links, err := orm.Query[link.Link](
+		op.Select().
+			From(noteLinksTableName).
+			Where(op.And{op.IsNull("target_id"), visibility}).
+			OrderBy(op.Asc("source_id"), op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get dangling links: %w (user %s)", err, u.ID)
+	}
+
+	return links, nil
+}
+
+// GetDanglingByTarget retrieves the dangling links of the scope written with the target.
+func (r *linkRepo) GetDanglingByTarget(ctx context.Context, scope link.Scope, target string) ([]*link.Link, error) {
+	links, err := orm.Query[link.Link](
+		op.Select().
+			From(noteLinksTableName).
+			Where(op.And{op.IsNull("target_id"), op.Eq("target", target), inScope(scope)}),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get dangling links by target: %w (user %s)", err, scope.UserID)
+	}
+
+	return links, nil
+}
+
+// FindByTitle returns the identifier of the note of the scope with the title, the earliest created note
+// is taken among notes sharing the title. Returns link.ErrTargetNotFound if there is no such note.
+func (r *linkRepo) FindByTitle(ctx context.Context, scope link.Scope, title string) (uuid.UUID, error) {
+	n, err := orm.Query[note.Note](
+		op.Select("id").
+			From(notesTableName).
+			Where(op.And{op.Eq("name", title), inScope(scope)}).
+			OrderBy(op.Asc("created_at")).
+			Limit(1),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf(
+			"find note by title: %w (user %s)",
+			repoutil.RedefineNoRowsError(err, link.ErrTargetNotFound),
+			scope.UserID,
+		)
+	}
+
+	return n.ID, nil
+}
+
+// FindByID returns the identifier of the note of the scope with the identifier.
+// Returns link.ErrTargetNotFound if there is no such note.
+func (r *linkRepo) FindByID(ctx context.Context, scope link.Scope, id uuid.UUID) (uuid.UUID, error) {
+	n, err := orm.Query[note.Note](
+		op.Select("id").From(notesTableName).Where(op.And{op.Eq("id", id), inScope(scope)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf(
+			"find note by id: %w (user %s, note %s)",
+			repoutil.RedefineNoRowsError(err, link.ErrTargetNotFound),
+			scope.UserID,
+			id,
+		)
+	}
+
+	return n.ID, nil
+}
+
+// GetNotes retrieves the notes by the identifiers regardless of their visibility, missing notes are skipped.
+func (r *linkRepo) GetNotes(ctx context.Context, ids []uuid.UUID) ([]*note.Note, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	notes, err := orm.Query[note.Note](
+		op.Select().From(notesTableName).Where(op.In("id", values...)),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get linked notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// Save stores the given link in the database, generating a new UUID for the created link.
+func (r *linkRepo) Save(ctx context.Context, l *link.Link) error {
+	if l.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save link (generate uuid): %w", err)
+		}
+
+		l.ID = id
+	}
+
+	if err := orm.Put(noteLinksTableName, l).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save link: %w (note %s, link %s)", err, l.SourceID, l.ID)
+	}
+
+	return nil
+}
+
+// Delete removes the link.
+func (r *linkRepo) Delete(ctx context.Context, l *link.Link) error {
+	_, err := orm.Exec(op.Delete(noteLinksTableName).Where(op.Eq("id", l.ID))).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete link: %w (note %s, link %s)", err, l.SourceID, l.ID)
+	}
+
+	return nil
+}
+
+// inScope returns the condition restricting rows to the scope: personal rows of the user, or rows of the organisation.
+func inScope(scope link.Scope) op.And {
+	if scope.OrgID.Valid {
+		return op.And{op.Eq("org_id", scope.OrgID.UUID)}
+	}
+
+	return op.And{op.Eq("user_id", scope.UserID), op.IsNull("org_id")}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/xsqrty/notes/internal/adapter/dtoadapter"
 	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/org"
 	"github.com/xsqrty/notes/internal/domain/search"
 	"github.com/xsqrty/notes/internal/domain/user"
 	"github.com/xsqrty/notes/pkg/repoutil"
@@ -21,8 +24,14 @@ type noteRepo struct {
 	qe db.ConnPool
 }
 
-// notesTableName defines the name of the database table used to store note records.
-const notesTableName = "notes"
+const (
+	// notesTableName defines the name of the database table used to store note records.
+	notesTableName = "notes"
+	// noteRevisionsTableName defines the name of the database table used to store previous versions of the notes.
+	noteRevisionsTableName = "note_revisions"
+	// serializationFailureCode is the SQLSTATE raised by the database when the saved version of the note is stale.
+	serializationFailureCode = "40001"
+)
 
 // NewNoteRepo initializes and returns a note.Repository implementation using the provided database connection pool.
 func NewNoteRepo(qe db.ConnPool) note.Repository {
@@ -42,22 +51,121 @@ func (r *noteRepo) Save(ctx context.Context, n *note.Note) error {
 
 	err := orm.Put(notesTableName, n).With(ctx, r.qe)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == serializationFailureCode {
+			return fmt.Errorf("save note: %w (note %s, version %d)", note.ErrVersionConflict, n.ID, n.Version)
+		}
+
 		return fmt.Errorf("save note: %w", err)
 	}
 
 	return nil
 }
 
-// GetByID retrieves a note from the database by the identifier. Returns the note or an error if not found.
-func (r *noteRepo) GetByID(ctx context.Context, id uuid.UUID) (*note.Note, error) {
-	user, err := orm.Query[note.Note](
-		op.Select().From(notesTableName).Where(op.Eq("id", id)),
+// SaveFields stores the fields of the updated note along with its version and update time, leaving the other
+// columns as they are. The plain text and the terms are stored with the text and the format.
+func (r *noteRepo) SaveFields(ctx context.Context, n *note.Note, fields []note.Field) error {
+	updates := op.Updates{"version": n.Version, "updated_at": n.UpdatedAt}
+	for _, f := range fields {
+		switch f {
+		case note.FieldName:
+			updates["name"] = n.Name
+		case note.FieldText:
+			updates["text"] = n.Text
+			updates["plain_text"] = n.PlainText
+			updates["terms"] = n.Terms
+		case note.FieldFormat:
+			updates["format"] = n.Format
+			updates["plain_text"] = n.PlainText
+			updates["terms"] = n.Terms
+		case note.FieldPinned:
+			updates["pinned"] = n.Pinned
+		case note.FieldArchived:
+			updates["archived"] = n.Archived
+		case note.FieldFavourite:
+			updates["favourite"] = n.Favourite
+		case note.FieldChecklist:
+			updates["checklist_total"] = n.ChecklistTotal
+			updates["checklist_done"] = n.ChecklistDone
+			updates["has_open_items"] = n.HasOpenItems
+		}
+	}
+
+	res, err := orm.Exec(op.Update(notesTableName, updates).Where(op.Eq("id", n.ID))).With(ctx, r.qe)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == serializationFailureCode {
+			return fmt.Errorf("save note fields: %w (note %s, version %d)", note.ErrVersionConflict, n.ID, n.Version)
+		}
+
+		return fmt.Errorf("save note fields: %w (note %s)", err, n.ID)
+	}
+
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("save note fields: %w (note %s)", note.ErrNotFound, n.ID)
+	}
+
+	return nil
+}
+
+// GetRevision retrieves the version of the note kept for three-way merges.
+func (r *noteRepo) GetRevision(ctx context.Context, noteID uuid.UUID, version int64) (*note.Revision, error) {
+	rev, err := orm.Query[note.Revision](
+		op.Select().From(noteRevisionsTableName).Where(op.And{op.Eq("note_id", noteID), op.Eq("version", version)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get note revision: %w (note %s, version %d)",
+			repoutil.RedefineNoRowsError(err, note.ErrRevisionNotFound),
+			noteID,
+			version,
+		)
+	}
+
+	return rev, nil
+}
+
+// GetByID retrieves a note visible to the user by the identifier. Returns the note or an error if not found.
+func (r *noteRepo) GetByID(ctx context.Context, u *user.User, id uuid.UUID) (*note.Note, error) {
+	visibility, err := r.visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get note by id: %w", err)
+	}
+
+	n, err := orm.Query[note.Note](
+		op.Select().From(notesTableName).Where(op.And{op.Eq("id", id), visibility}),
 	).GetOne(ctx, r.qe)
 	if err != nil {
 		return nil, fmt.Errorf("get note by id: %w", repoutil.RedefineNoRowsError(err, note.ErrNotFound))
 	}
 
-	return user, nil
+	return n, nil
+}
+
+// GetByIDs retrieves the notes visible to the user among the identifiers, notes not found are left out.
+func (r *noteRepo) GetByIDs(ctx context.Context, u *user.User, ids []uuid.UUID) ([]*note.Note, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	visibility, err := r.visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get notes by ids: %w", err)
+	}
+
+	values := make([]any, len(ids))
+	for i := range ids {
+		values[i] = ids[i]
+	}
+
+	notes, err := orm.Query[note.Note](
+		op.Select().From(notesTableName).Where(op.And{op.In("id", values...), visibility}),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get notes by ids: %w (user %s)", err, u.ID)
+	}
+
+	return notes, nil
 }
 
 // Delete removes the specified note from the database based on ID.
@@ -83,34 +191,91 @@ func (r *noteRepo) IDExists(ctx context.Context, id uuid.UUID) (bool, error) {
 	return count > 0, nil
 }
 
-// SearchByUser retrieves notes associated with a specific user based on the search request parameters and pagination options.
+// SearchByUser retrieves personal notes of a specific user based on the search request parameters and pagination options.
 func (r *noteRepo) SearchByUser(
 	ctx context.Context,
 	u *user.User,
 	req *search.Request,
 ) (*search.Result[note.Note], error) {
-	res, err := orm.Paginate[note.Note](notesTableName, dtoadapter.SearchToPaginateRequest(req)).
-		WhiteList("id", "name", "created_at", "updated_at").
+	res, err := r.search(ctx, req, op.And{op.Eq("user_id", u.ID), op.IsNull("org_id")})
+	if err != nil {
+		return nil, fmt.Errorf("search note by user: %w", err)
+	}
+
+	return res, nil
+}
+
+// SearchByOrg retrieves notes of the organisation based on the search request parameters and pagination options.
+// Returns org.ErrNotFound if the user is not a member of the organisation.
+func (r *noteRepo) SearchByOrg(
+	ctx context.Context,
+	u *user.User,
+	orgID uuid.UUID,
+	req *search.Request,
+) (*search.Result[note.Note], error) {
+	orgIDs, err := r.memberOrgIDs(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("search note by org: %w", err)
+	}
+
+	if !slices.Contains(orgIDs, any(orgID)) {
+		return nil, fmt.Errorf("search note by org: %w (org %s)", org.ErrNotFound, orgID)
+	}
+
+	res, err := r.search(ctx, req, op.And{op.Eq("org_id", orgID)})
+	if err != nil {
+		return nil, fmt.Errorf("search note by org: %w (org %s)", err, orgID)
+	}
+
+	return res, nil
+}
+
+// search paginates notes matching the tenant condition according to the search request. Archived notes are left
+// out unless the filters refer to archived, and notes are ordered pinned first, then newest first, unless
+// the orders are given.
+func (r *noteRepo) search(ctx context.Context, req *search.Request, tenant op.And) (*search.Result[note.Note], error) {
+	if !filtersRefer(req.Filters, "archived") {
+		tenant = append(tenant, op.Eq("archived", false))
+	}
+
+	paginate := dtoadapter.SearchToPaginateRequest(req)
+	if len(paginate.Orders) == 0 {
+		paginate.Orders = []orm.PaginateOrder{{Key: "pinned", Desc: true}, {Key: "created_at", Desc: true}}
+	}
+
+	res, err := orm.Paginate[note.Note](notesTableName, paginate).
+		WhiteList("id", "name", "pinned", "archived", "favourite", "has_open_items", "created_at", "updated_at").
 		Fields(
 			op.As("id", op.Column("notes.id")),
 			op.As("name", op.Column("notes.name")),
 			op.As("text", op.Column("notes.text")),
+			op.As("format", op.Column("notes.format")),
+			op.As("plain_text", op.Column("notes.plain_text")),
+			op.As("terms", op.Column("notes.terms")),
 			op.As("user_id", op.Column("notes.user_id")),
+			op.As("org_id", op.Column("notes.org_id")),
+			op.As("version", op.Column("notes.version")),
+			op.As("pinned", op.Column("notes.pinned")),
+			op.As("archived", op.Column("notes.archived")),
+			op.As("favourite", op.Column("notes.favourite")),
+			op.As("checklist_total", op.Column("notes.checklist_total")),
+			op.As("checklist_done", op.Column("notes.checklist_done")),
+			op.As("has_open_items", op.Column("notes.has_open_items")),
 			op.As("created_at", op.Column("notes.created_at")),
 			op.As("updated_at", op.Column("notes.updated_at")),
 		).
-		Where(op.Eq("user_id", u.ID)).
+		Where(tenant).
 		With(ctx, r.qe)
 	if err != nil {
 		if errors.Is(err, orm.ErrFilterInvalid) {
-			return nil, fmt.Errorf("search note: invalid filter: %w", errors.Join(note.ErrSearchBadRequest, err))
+			return nil, fmt.Errorf("invalid filter: %w", errors.Join(note.ErrSearchBadRequest, err))
 		}
 
 		if errors.Is(err, orm.ErrDisallowedKey) {
-			return nil, fmt.Errorf("search note: disallowed key: %w", errors.Join(note.ErrSearchBadRequest, err))
+			return nil, fmt.Errorf("disallowed key: %w", errors.Join(note.ErrSearchBadRequest, err))
 		}
 
-		return nil, fmt.Errorf("search note by user: %w", err)
+		return nil, err
 	}
 
 	return &search.Result[note.Note]{
@@ -118,3 +283,66 @@ func (r *noteRepo) SearchByUser(
 		TotalRows: res.TotalRows,
 	}, nil
 }
+
+// filtersRefer reports whether the filters or the nested groups of the filters refer to the key.
+func filtersRefer(filters map[string]any, key string) bool {
+	for k, v := range filters {
+		if k == key {
+			return true
+		}
+
+		switch v := v.(type) {
+		case map[string]any:
+			if filtersRefer(v, key) {
+				return true
+			}
+		case []any:
+			for _, item := range v {
+				if group, ok := item.(map[string]any); ok && filtersRefer(group, key) {
+					return true
+				}
+			}
+		case []map[string]any:
+			for _, group := range v {
+				if filtersRefer(group, key) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// visibleTo returns the condition restricting notes to the personal notes of the user
+// and the notes of the organisations the user is a member of.
+func (r *noteRepo) visibleTo(ctx context.Context, u *user.User) (op.Or, error) {
+	visibility := op.Or{op.And{op.Eq("user_id", u.ID), op.IsNull("org_id")}}
+	orgIDs, err := r.memberOrgIDs(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orgIDs) > 0 {
+		visibility = append(visibility, op.In("org_id", orgIDs...))
+	}
+
+	return visibility, nil
+}
+
+// memberOrgIDs returns identifiers of the organisations the user is a member of.
+func (r *noteRepo) memberOrgIDs(ctx context.Context, u *user.User) ([]any, error) {
+	members, err := orm.Query[org.Member](
+		op.Select("org_id").From(orgMembersTableName).Where(op.Eq("user_id", u.ID)),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get member orgs: %w (user %s)", err, u.ID)
+	}
+
+	orgIDs := make([]any, len(members))
+	for i := range members {
+		orgIDs[i] = members[i].OrgID
+	}
+
+	return orgIDs, nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// noteImportRepo is a concrete implementation of the noteimport.Repository interface using a database connection pool.
+type noteImportRepo struct {
+	qe db.ConnPool
+}
+
+// noteImportLock represents the import lock row written only to take its lock.
+type noteImportLock struct {
+	ID       int       `op:"id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// noteImportJobsTableName represents the name of the database table for storing import jobs.
+	noteImportJobsTableName = "note_import_jobs"
+	// noteImportItemsTableName represents the name of the database table for storing outcomes of the job notes.
+	noteImportItemsTableName = "note_import_items"
+	// noteImportedTableName represents the name of the database table for storing hashes of imported notes.
+	noteImportedTableName = "note_imported"
+	// noteImportLockTableName represents the name of the database table serializing claims of due jobs.
+	noteImportLockTableName = "note_import_lock"
+	// noteImportLockID is the identifier of the single row of the import lock table.
+	noteImportLockID = 1
+)
+
+// NewNoteImportRepository initializes and returns a noteimport.Repository implementation using the connection pool.
+func NewNoteImportRepository(qe db.ConnPool) noteimport.Repository {
+	return &noteImportRepo{qe: qe}
+}
+
+// GetByID retrieves an import job from the database by the identifier.
+func (r *noteImportRepo) GetByID(ctx context.Context, id uuid.UUID) (*noteimport.Job, error) {
+	j, err := orm.Query[noteimport.Job](
+		op.Select().From(noteImportJobsTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get import by id: %w", repoutil.RedefineNoRowsError(err, noteimport.ErrNotFound))
+	}
+
+	return j, nil
+}
+
+// GetUnfinishedByChecksum retrieves the oldest pending or running import job of the user with the file checksum.
+func (r *noteImportRepo) GetUnfinishedByChecksum(
+	ctx context.Context,
+	userID uuid.UUID,
+	checksum string,
+) (*noteimport.Job, error) {
+	j, err := orm.Query[noteimport.Job](
+		op.Select().
+			From(noteImportJobsTableName).
+			Where(op.And{
+				op.Eq("user_id", userID),
+				op.Eq("checksum", checksum),
+				op.In("status", noteimport.StatusPending, noteimport.StatusRunning),
+			}).
+			OrderBy(op.Asc("created_at")).
+			Limit(1),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get unfinished import by checksum: %w (user %s)",
+			repoutil.RedefineNoRowsError(err, noteimport.ErrNotFound),
+			userID,
+		)
+	}
+
+	return j, nil
+}
+
+// GetDue retrieves the oldest pending or running import job whose claim has expired.
+func (r *noteImportRepo) GetDue(ctx context.Context, now time.Time) (*noteimport.Job, error) {
+	j, err := orm.Query[noteimport.Job](
+		op.Select().
+			From(noteImportJobsTableName).
+			Where(op.And{
+				op.In("status", noteimport.StatusPending, noteimport.StatusRunning),
+				op.Lte("claimed_until", now),
+			}).
+			OrderBy(op.Asc("created_at")).
+			Limit(1),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get due import: %w", repoutil.RedefineNoRowsError(err, noteimport.ErrNotFound))
+	}
+
+	return j, nil
+}
+
+// Lock takes the lock of the import jobs queue, which is held until the enclosing transaction ends.
+func (r *noteImportRepo) Lock(ctx context.Context) error {
+	err := orm.Put(noteImportLockTableName, &noteImportLock{ID: noteImportLockID, LockedAt: time.Now()}).
+		With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("lock imports: %w", err)
+	}
+
+	return nil
+}
+
+// Save stores the given import job in the database, generating a new UUID for the created job.
+func (r *noteImportRepo) Save(ctx context.Context, j *noteimport.Job) error {
+	if j.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save import (generate uuid): %w", err)
+		}
+
+		j.ID = id
+	}
+
+	if err := orm.Put(noteImportJobsTableName, j).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save import: %w (user %s)", err, j.UserID)
+	}
+
+	return nil
+}
+
+// GetItems retrieves the items of the job in the order of the file, empty status stands for items of any status.
+func (r *noteImportRepo) GetItems(
+	ctx context.Context,
+	j *noteimport.Job,
+	status noteimport.ItemStatus,
+	limit uint64,
+	offset uint64,
+) (*search.Result[noteimport.Item], error) {
+	where := op.And{op.Eq("job_id", j.ID)}
+	if status != "" {
+		where = append(where, op.Eq("status", status))
+	}
+
+	res, err := orm.Paginate[noteimport.Item](noteImportItemsTableName, &orm.PaginateRequest{
+		Orders: []orm.PaginateOrder{{Key: "position"}},
+		Limit:  limit,
+		Offset: offset,
+	}).
+		WhiteList("position").
+		Where(where).
+		With(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get import items: %w (import %s)", err, j.ID)
+	}
+
+	return &search.Result[noteimport.Item]{
+		Rows:      res.Rows,
+		TotalRows: res.TotalRows,
+	}, nil
+}
+
+// SaveItem stores the given item in the database, generating a new UUID for the created item.
+func (r *noteImportRepo) SaveItem(ctx context.Context, it *noteimport.Item) error {
+	if it.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save import item (generate uuid): %w", err)
+		}
+
+		it.ID = id
+	}
+
+	if err := orm.Put(noteImportItemsTableName, it).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save import item: %w (import %s)", err, it.JobID)
+	}
+
+	return nil
+}
+
+// GetImported retrieves the imported note by the hash of the user and the content.
+func (r *noteImportRepo) GetImported(ctx context.Context, hash string) (*noteimport.Imported, error) {
+	imp, err := orm.Query[noteimport.Imported](
+		op.Select().From(noteImportedTableName).Where(op.Eq("hash", hash)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get imported note: %w", repoutil.RedefineNoRowsError(err, noteimport.ErrNotFound))
+	}
+
+	return imp, nil
+}
+
+// SaveImported stores the imported note in the database.
+func (r *noteImportRepo) SaveImported(ctx context.Context, imp *noteimport.Imported) error {
+	if err := orm.Put(noteImportedTableName, imp).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save imported note: %w (user %s, note %s)", err, imp.UserID, imp.NoteID)
+	}
+
+	return nil
+}
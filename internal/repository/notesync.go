@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/notesync"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// noteSyncRepo is a concrete implementation of the notesync.Repository interface using a database connection pool.
+type noteSyncRepo struct {
+	qe db.ConnPool
+}
+
+// syncHorizon represents the row of the sync horizon view.
+type syncHorizon struct {
+	XMin int64 `op:"xmin"`
+}
+
+const (
+	// noteVersionsTableName represents the name of the database table for storing the server versions of notes.
+	noteVersionsTableName = "note_versions"
+	// noteSyncHorizonViewName represents the name of the database view returning the oldest running transaction.
+	noteSyncHorizonViewName = "note_sync_horizon"
+)
+
+// NewNoteSyncRepository initializes and returns a notesync.Repository implementation using the connection pool.
+func NewNoteSyncRepository(qe db.ConnPool) notesync.Repository {
+	return &noteSyncRepo{qe: qe}
+}
+
+// Horizon returns the oldest transaction which may be not committed yet.
+func (r *noteSyncRepo) Horizon(ctx context.Context) (int64, error) {
+	h, err := orm.Query[syncHorizon](op.Select("xmin").From(noteSyncHorizonViewName)).GetOne(ctx, r.qe)
+	if err != nil {
+		return 0, fmt.Errorf("get sync horizon: %w", err)
+	}
+
+	return h.XMin, nil
+}
+
+// GetVersion retrieves the server version of the note visible to the user, including deleted notes.
+// Versions of the notes invisible to the user are not found, so their existence is not revealed.
+func (r *noteSyncRepo) GetVersion(ctx context.Context, u *user.User, noteID uuid.UUID) (*notesync.Version, error) {
+	visibility, err := (&noteRepo{r.qe}).visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get note version: %w", err)
+	}
+
+	v, err := orm.Query[notesync.Version](
+		op.Select().From(noteVersionsTableName).Where(op.And{op.Eq("note_id", noteID), visibility}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get note version: %w (user %s, note %s)",
+			repoutil.RedefineNoRowsError(err, notesync.ErrVersionNotFound),
+			u.ID,
+			noteID,
+		)
+	}
+
+	return v, nil
+}
+
+// GetChanges retrieves the versions of the notes visible to the user changed after the cursor in the cursor order.
+func (r *noteSyncRepo) GetChanges(
+	ctx context.Context,
+	u *user.User,
+	after notesync.Cursor,
+	limit uint64,
+) ([]*notesync.Version, error) {
+	visibility, err := (&noteRepo{r.qe}).visibleTo(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get note changes: %w", err)
+	}
+
+	versions, err := orm.Query[notesync.Version](
+		op.Select().
+			From(noteVersionsTableName).
+			Where(op.And{
+				visibility,
+				op.Or{
+					op.Gt("txid", after.TxID),
+					op.And{op.Eq("txid", after.TxID), op.Gt("note_id", after.NoteID)},
+				},
+			}).
+			OrderBy(op.Asc("txid"), op.Asc("note_id")).
+			Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get note changes: %w (user %s)", err, u.ID)
+	}
+
+	return versions, nil
+}
+
+// GetNotes retrieves the notes by the identifiers regardless of their visibility, missing notes are skipped.
+func (r *noteSyncRepo) GetNotes(ctx context.Context, ids []uuid.UUID) ([]*note.Note, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	values := make([]any, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	notes, err := orm.Query[note.Note](
+		op.Select().From(notesTableName).Where(op.In("id", values...)),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get sync notes: %w", err)
+	}
+
+	return notes, nil
+}
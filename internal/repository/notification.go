@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// notificationRepo is a concrete implementation of the notification.Repository interface using a database connection
+// pool.
+type notificationRepo struct {
+	qe db.ConnPool
+}
+
+const (
+	// notificationsTableName represents the name of the database table for storing notifications of the users.
+	notificationsTableName = "notifications"
+	// notificationPreferencesTableName represents the name of the database table for storing notification preferences.
+	notificationPreferencesTableName = "notification_preferences"
+)
+
+// NewNotificationRepository initializes and returns a notification.Repository implementation using the connection pool.
+func NewNotificationRepository(qe db.ConnPool) notification.Repository {
+	return &notificationRepo{qe: qe}
+}
+
+// Save stores the given notification in the database, generating a new UUID for the created notification.
+func (r *notificationRepo) Save(ctx context.Context, n *notification.Notification) error {
+	if n.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save notification (generate uuid): %w", err)
+		}
+
+		n.ID = id
+	}
+
+	if err := orm.Put(notificationsTableName, n).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save notification: %w (user %s)", err, n.UserID)
+	}
+
+	return nil
+}
+
+// GetByID retrieves the notification of the user by the identifier.
+func (r *notificationRepo) GetByID(
+	ctx context.Context,
+	userID uuid.UUID,
+	id uuid.UUID,
+) (*notification.Notification, error) {
+	n, err := orm.Query[notification.Notification](
+		op.Select().From(notificationsTableName).Where(op.And{op.Eq("id", id), op.Eq("user_id", userID)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get notification by id: %w (user %s)",
+			repoutil.RedefineNoRowsError(err, notification.ErrNotFound),
+			userID,
+		)
+	}
+
+	return n, nil
+}
+
+// GetPage retrieves the notifications of the user after the cursor newest first. Identifiers are time ordered,
+// so the cursor is the identifier of the last notification of the previous page.
+func (r *notificationRepo) GetPage(
+	ctx context.Context,
+	userID uuid.UUID,
+	after notification.Cursor,
+	unreadOnly bool,
+	limit uint64,
+) ([]*notification.Notification, error) {
+	where := op.And{op.Eq("user_id", userID)}
+	if after.ID != uuid.Nil {
+		where = append(where, op.Lt("id", after.ID))
+	}
+
+	if unreadOnly {
+		where = append(where, op.IsNull("read_at"))
+	}
+
+	notifications, err := orm.Query[notification.Notification](
+		op.Select().From(notificationsTableName).Where(where).OrderBy(op.Desc("id")).Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get notifications page: %w (user %s)", err, userID)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread returns the number of unread notifications of the user.
+func (r *notificationRepo) CountUnread(ctx context.Context, userID uuid.UUID) (uint64, error) {
+	count, err := orm.Count(
+		op.Select().From(notificationsTableName).Where(op.And{op.Eq("user_id", userID), op.IsNull("read_at")}),
+	).With(ctx, r.qe)
+	if err != nil {
+		return 0, fmt.Errorf("count unread notifications: %w (user %s)", err, userID)
+	}
+
+	return count, nil
+}
+
+// MarkAllRead marks the unread notifications of the user read at the time and returns their number.
+func (r *notificationRepo) MarkAllRead(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error) {
+	res, err := orm.Exec(
+		op.Update(notificationsTableName, op.Updates{"read_at": at}).
+			Where(op.And{op.Eq("user_id", userID), op.IsNull("read_at")}),
+	).With(ctx, r.qe)
+	if err != nil {
+		return 0, fmt.Errorf("mark all notifications read: %w (user %s)", err, userID)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("mark all notifications read: %w (user %s)", err, userID)
+	}
+
+	return rows, nil
+}
+
+// DeleteBefore removes the notifications of all users created before the time and returns their number.
+func (r *notificationRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := orm.Exec(
+		op.Delete(notificationsTableName).Where(op.Lt("created_at", before)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return 0, fmt.Errorf("delete notifications: %w (before %s)", err, before)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete notifications: %w (before %s)", err, before)
+	}
+
+	return rows, nil
+}
+
+// GetPreferences retrieves the notification preferences set by the user.
+func (r *notificationRepo) GetPreferences(ctx context.Context, userID uuid.UUID) ([]*notification.Preference, error) {
+	prefs, err := orm.Query[notification.Preference](
+		op.Select().From(notificationPreferencesTableName).Where(op.Eq("user_id", userID)).OrderBy(op.Asc("type")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get notification preferences: %w (user %s)", err, userID)
+	}
+
+	return prefs, nil
+}
+
+// SavePreference stores the given notification preference, generating a new UUID for the created preference.
+func (r *notificationRepo) SavePreference(ctx context.Context, p *notification.Preference) error {
+	if p.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save notification preference (generate uuid): %w", err)
+		}
+
+		p.ID = id
+	}
+
+	if err := orm.Put(notificationPreferencesTableName, p).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save notification preference: %w (user %s, type %s)", err, p.UserID, p.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// orgRepo is a concrete implementation of the org.Repository interface using a database connection pool.
+type orgRepo struct {
+	qe db.ConnPool
+}
+
+const (
+	// orgsTableName represents the name of the database table for storing organisations.
+	orgsTableName = "orgs"
+	// orgMembersTableName represents the name of the database table for storing organisation members.
+	orgMembersTableName = "org_members"
+	// orgInvitationsTableName represents the name of the database table for storing organisation invitations.
+	orgInvitationsTableName = "org_invitations"
+)
+
+// NewOrgRepository initializes and returns an org.Repository implementation using the provided database connection pool.
+func NewOrgRepository(qe db.ConnPool) org.Repository {
+	return &orgRepo{qe: qe}
+}
+
+// GetByID retrieves an organisation from the database by the identifier.
+func (r *orgRepo) GetByID(ctx context.Context, id uuid.UUID) (*org.Org, error) {
+	o, err := orm.Query[org.Org](
+		op.Select().From(orgsTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get org by id: %w", repoutil.RedefineNoRowsError(err, org.ErrNotFound))
+	}
+
+	return o, nil
+}
+
+// GetByUser retrieves all organisations the user is a member of ordered by creation time.
+func (r *orgRepo) GetByUser(ctx context.Context, u *user.User) ([]*org.Org, error) {
+	orgs, err := orm.Query[org.Org](
+		op.Select(
+			op.As("id", op.Column("orgs.id")),
+			op.As("name", op.Column("orgs.name")),
+			op.As("created_at", op.Column("orgs.created_at")),
+			op.As("updated_at", op.Column("orgs.updated_at")),
+		).From(orgsTableName).
+			Join(orgMembersTableName, op.Eq("org_members.org_id", op.Column("orgs.id"))).
+			Where(op.Eq("org_members.user_id", u.ID)).
+			OrderBy(op.Asc("orgs.created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get orgs by user: %w (user %s)", err, u.ID)
+	}
+
+	return orgs, nil
+}
+
+// Save stores the given organisation in the database, generating a new UUID for the created organisation.
+func (r *orgRepo) Save(ctx context.Context, o *org.Org) error {
+	if o.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save org (generate uuid): %w", err)
+		}
+
+		o.ID = id
+	}
+
+	err := orm.Put(orgsTableName, o).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("save org: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the organisation from the database. Members, invitations and notes are removed by cascade.
+func (r *orgRepo) Delete(ctx context.Context, o *org.Org) error {
+	_, err := orm.Exec(
+		op.Delete(orgsTableName).Where(op.Eq("id", o.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete org: %w", err)
+	}
+
+	return nil
+}
+
+// GetMember retrieves the membership of the user in the organisation.
+func (r *orgRepo) GetMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*org.Member, error) {
+	m, err := orm.Query[org.Member](
+		op.Select().From(orgMembersTableName).Where(op.And{op.Eq("org_id", orgID), op.Eq("user_id", userID)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get org member: %w (org %s, user %s)",
+			repoutil.RedefineNoRowsError(err, org.ErrMemberNotFound),
+			orgID,
+			userID,
+		)
+	}
+
+	return m, nil
+}
+
+// GetMembers retrieves all members of the organisation ordered by joining time.
+func (r *orgRepo) GetMembers(ctx context.Context, o *org.Org) ([]*org.Member, error) {
+	members, err := orm.Query[org.Member](
+		op.Select().From(orgMembersTableName).Where(op.Eq("org_id", o.ID)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get org members: %w (org %s)", err, o.ID)
+	}
+
+	return members, nil
+}
+
+// CountMembersByRole returns the number of organisation members having the role.
+func (r *orgRepo) CountMembersByRole(ctx context.Context, o *org.Org, role org.MemberRole) (int64, error) {
+	count, err := orm.Count(
+		op.Select().From(orgMembersTableName).Where(op.And{op.Eq("org_id", o.ID), op.Eq("role", role)}),
+	).With(ctx, r.qe)
+	if err != nil {
+		return 0, fmt.Errorf("count org members: %w (org %s, role %s)", err, o.ID, role)
+	}
+
+	return int64(count), nil
+}
+
+// SaveMember stores the membership in the database, generating a new UUID for the created membership.
+func (r *orgRepo) SaveMember(ctx context.Context, m *org.Member) error {
+	if m.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save org member (generate uuid): %w", err)
+		}
+
+		m.ID = id
+	}
+
+	err := orm.Put(orgMembersTableName, m).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("save org member: %w (org %s, user %s)", err, m.OrgID, m.UserID)
+	}
+
+	return nil
+}
+
+// DeleteMember removes the membership from the database.
+func (r *orgRepo) DeleteMember(ctx context.Context, m *org.Member) error {
+	_, err := orm.Exec(
+		op.Delete(orgMembersTableName).Where(op.Eq("id", m.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete org member: %w (org %s, user %s)", err, m.OrgID, m.UserID)
+	}
+
+	return nil
+}
+
+// GetInvitation retrieves an invitation from the database by the identifier.
+func (r *orgRepo) GetInvitation(ctx context.Context, id uuid.UUID) (*org.Invitation, error) {
+	i, err := orm.Query[org.Invitation](
+		op.Select().From(orgInvitationsTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get org invitation: %w (invitation %s)",
+			repoutil.RedefineNoRowsError(err, org.ErrInvitationNotFound),
+			id,
+		)
+	}
+
+	return i, nil
+}
+
+// GetInvitations retrieves all pending invitations of the organisation ordered by creation time.
+func (r *orgRepo) GetInvitations(ctx context.Context, o *org.Org) ([]*org.Invitation, error) {
+	invitations, err := orm.Query[org.Invitation](
+		op.Select().From(orgInvitationsTableName).Where(op.Eq("org_id", o.ID)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get org invitations: %w (org %s)", err, o.ID)
+	}
+
+	return invitations, nil
+}
+
+// GetInvitationsByEmail retrieves all pending invitations sent to the email ordered by creation time.
+func (r *orgRepo) GetInvitationsByEmail(ctx context.Context, email string) ([]*org.Invitation, error) {
+	invitations, err := orm.Query[org.Invitation](
+		op.Select().From(orgInvitationsTableName).Where(op.Eq("email", email)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get org invitations by email: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// SaveInvitation stores the invitation in the database, generating a new UUID for the created invitation.
+func (r *orgRepo) SaveInvitation(ctx context.Context, i *org.Invitation) error {
+	if i.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save org invitation (generate uuid): %w", err)
+		}
+
+		i.ID = id
+	}
+
+	err := orm.Put(orgInvitationsTableName, i).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("save org invitation: %w (org %s)", err, i.OrgID)
+	}
+
+	return nil
+}
+
+// DeleteInvitation removes the invitation from the database.
+func (r *orgRepo) DeleteInvitation(ctx context.Context, i *org.Invitation) error {
+	_, err := orm.Exec(
+		op.Delete(orgInvitationsTableName).Where(op.Eq("id", i.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete org invitation: %w (org %s, invitation %s)", err, i.OrgID, i.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// reminderRepo is a concrete implementation of the reminder.Repository interface using a database connection pool.
+type reminderRepo struct {
+	qe db.ConnPool
+}
+
+const (
+	// noteRemindersTableName represents the name of the database table for storing reminders of notes.
+	noteRemindersTableName = "note_reminders"
+	// noteRemindersDueViewName represents the name of the database view locking due reminders, skipping the locked.
+	noteRemindersDueViewName = "note_reminders_due"
+)
+
+// NewReminderRepository initializes and returns a reminder.Repository implementation using the connection pool.
+func NewReminderRepository(qe db.ConnPool) reminder.Repository {
+	return &reminderRepo{qe: qe}
+}
+
+// GetByID retrieves a reminder from the database by the identifier.
+func (r *reminderRepo) GetByID(ctx context.Context, id uuid.UUID) (*reminder.Reminder, error) {
+	rem, err := orm.Query[reminder.Reminder](
+		op.Select().From(noteRemindersTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get reminder by id: %w", repoutil.RedefineNoRowsError(err, reminder.ErrNotFound))
+	}
+
+	return rem, nil
+}
+
+// GetByNote retrieves the reminder of the note set by the user.
+func (r *reminderRepo) GetByNote(ctx context.Context, noteID uuid.UUID, userID uuid.UUID) (*reminder.Reminder, error) {
+	rem, err := orm.Query[reminder.Reminder](
+		op.Select().From(noteRemindersTableName).Where(op.And{op.Eq("note_id", noteID), op.Eq("user_id", userID)}),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get reminder by note: %w (user %s, note %s)",
+			repoutil.RedefineNoRowsError(err, reminder.ErrNotFound),
+			userID,
+			noteID,
+		)
+	}
+
+	return rem, nil
+}
+
+// GetDue retrieves the earliest due reminder and locks it until the enclosing transaction ends. Reminders locked
+// by other transactions are skipped, so concurrent schedulers fire different reminders.
+func (r *reminderRepo) GetDue(ctx context.Context) (*reminder.Reminder, error) {
+	rem, err := orm.Query[reminder.Reminder](
+		op.Select().From(noteRemindersDueViewName).Limit(1),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get due reminder: %w", repoutil.RedefineNoRowsError(err, reminder.ErrNotFound))
+	}
+
+	return rem, nil
+}
+
+// Save stores the given reminder in the database, generating a new UUID for the created reminder.
+func (r *reminderRepo) Save(ctx context.Context, rem *reminder.Reminder) error {
+	if rem.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save reminder (generate uuid): %w", err)
+		}
+
+		rem.ID = id
+	}
+
+	if err := orm.Put(noteRemindersTableName, rem).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save reminder: %w (user %s, note %s)", err, rem.UserID, rem.NoteID)
+	}
+
+	return nil
+}
+
+// Delete removes the reminder.
+func (r *reminderRepo) Delete(ctx context.Context, rem *reminder.Reminder) error {
+	_, err := orm.Exec(
+		op.Delete(noteRemindersTableName).Where(op.Eq("id", rem.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete reminder: %w (user %s, note %s)", err, rem.UserID, rem.NoteID)
+	}
+
+	return nil
+}
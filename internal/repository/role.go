@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/xsqrty/notes/internal/domain/role"
 	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/repoutil"
 	"github.com/xsqrty/op"
 	"github.com/xsqrty/op/db"
 	"github.com/xsqrty/op/orm"
@@ -31,57 +33,115 @@ func NewRoleRepository(qe db.ConnPool) role.Repository {
 	return &roleRepo{qe: qe}
 }
 
-// AttachUserRolesByLabel associates roles with a user based on the provided label.
-func (rr *roleRepo) AttachUserRolesByLabel(ctx context.Context, label role.Label, u *user.User) error {
+// GetByID retrieves a role from the database by the identifier. Returns the role or an error if not found.
+func (rr *roleRepo) GetByID(ctx context.Context, id uuid.UUID) (*role.Role, error) {
+	r, err := orm.Query[role.Role](
+		op.Select().From(rolesTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, rr.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get role by id: %w", repoutil.RedefineNoRowsError(err, role.ErrNotFound))
+	}
+
+	return r, nil
+}
+
+// GetAll retrieves all roles from the database ordered by creation time.
+func (rr *roleRepo) GetAll(ctx context.Context) ([]*role.Role, error) {
 	roles, err := orm.Query[role.Role](
-		op.Select("id").From(rolesTableName).Where(op.Eq("label", label)),
+		op.Select().From(rolesTableName).OrderBy(op.Asc("created_at")),
 	).GetMany(ctx, rr.qe)
 	if err != nil {
-		return fmt.Errorf("attach roles with label for user (query roles) %w (label %s, user %s)", err, label, u.ID)
+		return nil, fmt.Errorf("get all roles: %w", err)
 	}
 
-	for _, r := range roles {
-		count, err := orm.Count(op.Select().From(rolesUsersTableName).Where(op.And{op.Eq("role_id", r.ID), op.Eq("user_id", u.ID)})).
-			With(ctx, rr.qe)
-		if err != nil {
-			return fmt.Errorf(
-				"attach roles with label for user (count roles) %w (label %s, user %s, role %s)",
-				err,
-				label,
-				u.ID,
-				r.ID,
-			)
-		}
-
-		if count > 0 {
-			continue
-		}
+	return roles, nil
+}
 
+// Save stores the given role in the database, generating a new UUID for the created role.
+func (rr *roleRepo) Save(ctx context.Context, r *role.Role) error {
+	if r.ID == uuid.Nil {
 		id, err := uuid.NewV7()
 		if err != nil {
-			return fmt.Errorf(
-				"attach roles with label for user (uuid generate) %w (label %s, user %s, role %s)",
-				err,
-				label,
-				u.ID,
-				r.ID,
-			)
+			return fmt.Errorf("save role (generate uuid): %w", err)
 		}
 
-		err = orm.Put(rolesUsersTableName, &role.UserRelation{
-			ID:        id,
-			UserID:    u.ID,
-			RoleID:    r.ID,
-			CreatedAt: time.Now(),
-		}).With(ctx, rr.qe)
-		if err != nil {
-			return fmt.Errorf(
-				"attach roles with label for user (put role) %w (label %s, user %s, role %s)",
-				err,
-				label,
-				u.ID,
-				r.ID,
-			)
+		r.ID = id
+	}
+
+	err := orm.Put(rolesTableName, r).With(ctx, rr.qe)
+	if err != nil {
+		return fmt.Errorf("save role: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the specified role from the database based on ID. User relations are removed by cascade.
+func (rr *roleRepo) Delete(ctx context.Context, r *role.Role) error {
+	_, err := orm.Exec(
+		op.Delete(rolesTableName).Where(op.Eq("id", r.ID)),
+	).With(ctx, rr.qe)
+	if err != nil {
+		return fmt.Errorf("delete role: %w", err)
+	}
+
+	return nil
+}
+
+// AttachUser associates the role with the user. Does nothing if the user already has the role.
+func (rr *roleRepo) AttachUser(ctx context.Context, r *role.Role, u *user.User) error {
+	count, err := orm.Count(op.Select().From(rolesUsersTableName).Where(op.And{op.Eq("role_id", r.ID), op.Eq("user_id", u.ID)})).
+		With(ctx, rr.qe)
+	if err != nil {
+		return fmt.Errorf("attach role to user (count roles) %w (user %s, role %s)", err, u.ID, r.ID)
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("attach role to user (uuid generate) %w (user %s, role %s)", err, u.ID, r.ID)
+	}
+
+	err = orm.Put(rolesUsersTableName, &role.UserRelation{
+		ID:        id,
+		UserID:    u.ID,
+		RoleID:    r.ID,
+		CreatedAt: time.Now(),
+	}).With(ctx, rr.qe)
+	if err != nil {
+		return fmt.Errorf("attach role to user (put role) %w (user %s, role %s)", err, u.ID, r.ID)
+	}
+
+	return nil
+}
+
+// DetachUser removes the association between the role and the user.
+func (rr *roleRepo) DetachUser(ctx context.Context, r *role.Role, u *user.User) error {
+	_, err := orm.Exec(
+		op.Delete(rolesUsersTableName).Where(op.And{op.Eq("role_id", r.ID), op.Eq("user_id", u.ID)}),
+	).With(ctx, rr.qe)
+	if err != nil {
+		return fmt.Errorf("detach role from user: %w (user %s, role %s)", err, u.ID, r.ID)
+	}
+
+	return nil
+}
+
+// AttachUserRolesByLabel associates roles with a user based on the provided label.
+func (rr *roleRepo) AttachUserRolesByLabel(ctx context.Context, label role.Label, u *user.User) error {
+	roles, err := orm.Query[role.Role](
+		op.Select("id").From(rolesTableName).Where(op.Eq("label", label)),
+	).GetMany(ctx, rr.qe)
+	if err != nil {
+		return fmt.Errorf("attach roles with label for user (query roles) %w (label %s, user %s)", err, label, u.ID)
+	}
+
+	for _, r := range roles {
+		if err := rr.AttachUser(ctx, r, u); err != nil {
+			return fmt.Errorf("attach roles with label for user: %w (label %s)", err, label)
 		}
 	}
 
@@ -99,8 +159,30 @@ func (rr *roleRepo) HasPermissions(ctx context.Context, permissions []role.Permi
 			}),
 	).With(ctx, rr.qe)
 	if err != nil {
-		return false, fmt.Errorf("check roles permissions %w (user %s, permissions %v)", err, u.ID, permissions)
+		return false, fmt.Errorf("check roles permissions: %w (user %s, permissions %v)", err, u.ID, permissions)
 	}
 
 	return count > 0, nil
 }
+
+// GetUserPermissions retrieves the effective set of permissions granted to the user by all of its roles.
+func (rr *roleRepo) GetUserPermissions(ctx context.Context, u *user.User) ([]role.Permission, error) {
+	roles, err := orm.Query[role.Role](
+		op.Select(op.As("permissions", op.Column("roles.permissions"))).From(rolesUsersTableName).
+			Join(rolesTableName, op.Eq("role_id", op.Column("roles.id"))).
+			Where(op.Eq("user_id", u.ID)),
+	).GetMany(ctx, rr.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get user permissions: %w (user %s)", err, u.ID)
+	}
+
+	var permissions []role.Permission
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			permissions = append(permissions, role.Permission(p))
+		}
+	}
+
+	slices.Sort(permissions)
+	return slices.Compact(permissions), nil
+}
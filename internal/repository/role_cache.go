@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/metrics"
+	"github.com/xsqrty/notes/pkg/lru"
+)
+
+// permissionsCacheName is the label value used for permissions cache metrics.
+const permissionsCacheName = "permissions"
+
+// cachedRoleRepo is a role.Repository decorator caching the effective permissions of users.
+// Every change of roles or role assignments invalidates the affected entries once the enclosing transaction
+// is committed, so entries refilled from the uncommitted state are dropped too. The TTL bounds staleness
+// of entries changed by other application instances.
+type cachedRoleRepo struct {
+	role.Repository
+	cache   *lru.Cache[uuid.UUID, []role.Permission]
+	metrics *metrics.CacheMetrics
+}
+
+// NewCachedRoleRepository wraps the role.Repository with a bounded in-process cache of users' permissions.
+func NewCachedRoleRepository(
+	repo role.Repository,
+	cache *lru.Cache[uuid.UUID, []role.Permission],
+	metrics *metrics.CacheMetrics,
+) role.Repository {
+	return &cachedRoleRepo{
+		Repository: repo,
+		cache:      cache,
+		metrics:    metrics,
+	}
+}
+
+// Save stores the role and invalidates the whole cache, as the role may be assigned to any user.
+func (cr *cachedRoleRepo) Save(ctx context.Context, r *role.Role) error {
+	if err := cr.Repository.Save(ctx, r); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(ctx, cr.cache.Purge)
+	return nil
+}
+
+// Delete removes the role and invalidates the whole cache, as the role may be assigned to any user.
+func (cr *cachedRoleRepo) Delete(ctx context.Context, r *role.Role) error {
+	if err := cr.Repository.Delete(ctx, r); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(ctx, cr.cache.Purge)
+	return nil
+}
+
+// AttachUser associates the role with the user and invalidates the cached permissions of the user.
+func (cr *cachedRoleRepo) AttachUser(ctx context.Context, r *role.Role, u *user.User) error {
+	if err := cr.Repository.AttachUser(ctx, r, u); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(ctx, func() { cr.cache.Delete(u.ID) })
+	return nil
+}
+
+// DetachUser removes the association between the role and the user and invalidates the cached permissions of the user.
+func (cr *cachedRoleRepo) DetachUser(ctx context.Context, r *role.Role, u *user.User) error {
+	if err := cr.Repository.DetachUser(ctx, r, u); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(ctx, func() { cr.cache.Delete(u.ID) })
+	return nil
+}
+
+// AttachUserRolesByLabel associates roles with the user by label and invalidates the cached permissions of the user.
+func (cr *cachedRoleRepo) AttachUserRolesByLabel(ctx context.Context, label role.Label, u *user.User) error {
+	if err := cr.Repository.AttachUserRolesByLabel(ctx, label, u); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(ctx, func() { cr.cache.Delete(u.ID) })
+	return nil
+}
+
+// HasPermissions checks if the user has at least one of the specified permissions using the cached permissions set.
+func (cr *cachedRoleRepo) HasPermissions(ctx context.Context, permissions []role.Permission, u *user.User) (bool, error) {
+	granted, err := cr.GetUserPermissions(ctx, u)
+	if err != nil {
+		return false, fmt.Errorf("check cached permissions: %w", err)
+	}
+
+	for _, p := range permissions {
+		if _, found := slices.BinarySearch(granted, p); found {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetUserPermissions returns the sorted effective permissions of the user, loading them from the repository on a cache miss.
+func (cr *cachedRoleRepo) GetUserPermissions(ctx context.Context, u *user.User) ([]role.Permission, error) {
+	if permissions, ok := cr.cache.Get(u.ID); ok {
+		cr.metrics.Hits.WithLabelValues(permissionsCacheName).Inc()
+		return permissions, nil
+	}
+
+	cr.metrics.Misses.WithLabelValues(permissionsCacheName).Inc()
+	permissions, err := cr.Repository.GetUserPermissions(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	cr.cache.Set(u.ID, permissions)
+	return permissions, nil
+}
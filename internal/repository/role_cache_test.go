@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/metrics"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_role"
+	"github.com/xsqrty/notes/pkg/lru"
+)
+
+func TestCachedRoleRepository_HasPermissions(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	r := &role.Role{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []struct {
+		name           string
+		invalidate     func(repo role.Repository) error
+		expectedMisses float64
+		expectedHits   float64
+		mocker         func(repo *mock_role.Repository)
+	}{
+		{
+			name:           "cached",
+			expectedMisses: 1,
+			expectedHits:   1,
+			mocker: func(repo *mock_role.Repository) {
+				repo.EXPECT().GetUserPermissions(mock.Anything, u).Return([]role.Permission{note.PermissionRead}, nil).Once()
+			},
+		},
+		{
+			name: "invalidated_by_attach",
+			invalidate: func(repo role.Repository) error {
+				return repo.AttachUser(context.Background(), r, u)
+			},
+			expectedMisses: 2,
+			mocker: func(repo *mock_role.Repository) {
+				repo.EXPECT().GetUserPermissions(mock.Anything, u).Return([]role.Permission{note.PermissionRead}, nil).Twice()
+				repo.EXPECT().AttachUser(mock.Anything, r, u).Return(nil).Once()
+			},
+		},
+		{
+			name: "invalidated_by_role_update",
+			invalidate: func(repo role.Repository) error {
+				return repo.Save(context.Background(), r)
+			},
+			expectedMisses: 2,
+			mocker: func(repo *mock_role.Repository) {
+				repo.EXPECT().GetUserPermissions(mock.Anything, u).Return([]role.Permission{note.PermissionRead}, nil).Twice()
+				repo.EXPECT().Save(mock.Anything, r).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_role.NewRepository(t)
+			tc.mocker(repo)
+
+			cacheMetrics := &metrics.CacheMetrics{
+				Hits:   prometheus.NewCounterVec(prometheus.CounterOpts{Name: "hits"}, []string{"cache"}),
+				Misses: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "misses"}, []string{"cache"}),
+			}
+
+			cached := NewCachedRoleRepository(repo, lru.New[uuid.UUID, []role.Permission](10, time.Minute), cacheMetrics)
+			has, err := cached.HasPermissions(context.Background(), []role.Permission{note.PermissionRead}, u)
+			require.NoError(t, err)
+			require.True(t, has)
+
+			if tc.invalidate != nil {
+				require.NoError(t, tc.invalidate(cached))
+			}
+
+			has, err = cached.HasPermissions(context.Background(), []role.Permission{note.PermissionUpdate}, u)
+			require.NoError(t, err)
+			require.False(t, has)
+
+			require.Equal(t, tc.expectedHits, testutil.ToFloat64(cacheMetrics.Hits.WithLabelValues(permissionsCacheName)))
+			require.Equal(t, tc.expectedMisses, testutil.ToFloat64(cacheMetrics.Misses.WithLabelValues(permissionsCacheName)))
+		})
+	}
+}
+
+func TestCachedRoleRepository_InvalidateAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	r := &role.Role{ID: uuid.Must(uuid.NewV7())}
+
+	repo := mock_role.NewRepository(t)
+	repo.EXPECT().GetUserPermissions(mock.Anything, u).Return([]role.Permission{note.PermissionRead}, nil).Twice()
+	repo.EXPECT().DetachUser(mock.Anything, r, u).Return(nil).Once()
+
+	cacheMetrics := &metrics.CacheMetrics{
+		Hits:   prometheus.NewCounterVec(prometheus.CounterOpts{Name: "hits"}, []string{"cache"}),
+		Misses: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "misses"}, []string{"cache"}),
+	}
+
+	cached := NewCachedRoleRepository(repo, lru.New[uuid.UUID, []role.Permission](10, time.Minute), cacheMetrics)
+	err := tx.WithHooks(mock_tx.NewMockTxManager()).Transact(context.Background(), func(ctx context.Context) error {
+		if err := cached.DetachUser(ctx, r, u); err != nil {
+			return err
+		}
+
+		_, err := cached.GetUserPermissions(context.Background(), u)
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = cached.GetUserPermissions(context.Background(), u)
+	require.NoError(t, err)
+	require.Equal(t, float64(2), testutil.ToFloat64(cacheMetrics.Misses.WithLabelValues(permissionsCacheName)))
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/pkg/repoutil"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/orm"
+)
+
+// webhookRepo is a concrete implementation of the webhook.Repository interface using a database connection pool.
+type webhookRepo struct {
+	qe db.ConnPool
+}
+
+// webhookLock represents the webhook lock row written only to take its lock.
+type webhookLock struct {
+	ID       int       `op:"id,primary"`
+	LockedAt time.Time `op:"locked_at"`
+}
+
+const (
+	// webhookEndpointsTableName represents the name of the database table for storing webhook endpoints.
+	webhookEndpointsTableName = "webhook_endpoints"
+	// webhookDeliveriesTableName represents the name of the database table for storing webhook deliveries.
+	webhookDeliveriesTableName = "webhook_deliveries"
+	// webhookLockTableName represents the name of the database table serializing claims of due deliveries.
+	webhookLockTableName = "webhook_lock"
+	// webhookLockID is the identifier of the single row of the webhook lock table.
+	webhookLockID = 1
+)
+
+// NewWebhookRepository initializes and returns a webhook.Repository implementation using the provided connection pool.
+func NewWebhookRepository(qe db.ConnPool) webhook.Repository {
+	return &webhookRepo{qe: qe}
+}
+
+// GetByID retrieves a webhook endpoint from the database by the identifier.
+func (r *webhookRepo) GetByID(ctx context.Context, id uuid.UUID) (*webhook.Endpoint, error) {
+	e, err := orm.Query[webhook.Endpoint](
+		op.Select().From(webhookEndpointsTableName).Where(op.Eq("id", id)),
+	).GetOne(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook by id: %w", repoutil.RedefineNoRowsError(err, webhook.ErrNotFound))
+	}
+
+	return e, nil
+}
+
+// GetByUser retrieves all webhook endpoints of the user ordered by creation time.
+func (r *webhookRepo) GetByUser(ctx context.Context, u *user.User) ([]*webhook.Endpoint, error) {
+	endpoints, err := orm.Query[webhook.Endpoint](
+		op.Select().From(webhookEndpointsTableName).Where(op.Eq("user_id", u.ID)).OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks by user: %w (user %s)", err, u.ID)
+	}
+
+	return endpoints, nil
+}
+
+// GetEnabledByUserIDs retrieves the enabled webhook endpoints of the users.
+func (r *webhookRepo) GetEnabledByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*webhook.Endpoint, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	endpoints, err := orm.Query[webhook.Endpoint](
+		op.Select().
+			From(webhookEndpointsTableName).
+			Where(op.And{op.In("user_id", uuidValues(userIDs)...), op.Eq("enabled", true)}).
+			OrderBy(op.Asc("created_at")),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get enabled webhooks by users: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// Save stores the given webhook endpoint in the database, generating a new UUID for the created endpoint.
+func (r *webhookRepo) Save(ctx context.Context, e *webhook.Endpoint) error {
+	if e.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save webhook (generate uuid): %w", err)
+		}
+
+		e.ID = id
+	}
+
+	if err := orm.Put(webhookEndpointsTableName, e).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the webhook endpoint along with its deliveries.
+func (r *webhookRepo) Delete(ctx context.Context, e *webhook.Endpoint) error {
+	_, err := orm.Exec(
+		op.Delete(webhookEndpointsTableName).Where(op.Eq("id", e.ID)),
+	).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Lock takes the lock of the deliveries queue, which is held until the enclosing transaction ends.
+func (r *webhookRepo) Lock(ctx context.Context) error {
+	err := orm.Put(webhookLockTableName, &webhookLock{ID: webhookLockID, LockedAt: time.Now()}).With(ctx, r.qe)
+	if err != nil {
+		return fmt.Errorf("lock webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries retrieves the deliveries of the endpoint ordered from the newest.
+func (r *webhookRepo) GetDeliveries(
+	ctx context.Context,
+	e *webhook.Endpoint,
+	limit uint64,
+	offset uint64,
+) (*search.Result[webhook.Delivery], error) {
+	res, err := orm.Paginate[webhook.Delivery](webhookDeliveriesTableName, &orm.PaginateRequest{
+		Orders: []orm.PaginateOrder{{Key: "id", Desc: true}},
+		Limit:  limit,
+		Offset: offset,
+	}).
+		WhiteList("id").
+		Where(op.Eq("endpoint_id", e.ID)).
+		With(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook deliveries: %w (webhook %s)", err, e.ID)
+	}
+
+	return &search.Result[webhook.Delivery]{
+		Rows:      res.Rows,
+		TotalRows: res.TotalRows,
+	}, nil
+}
+
+// GetDueDeliveries retrieves up to limit pending deliveries whose next attempt time has come.
+func (r *webhookRepo) GetDueDeliveries(ctx context.Context, now time.Time, limit uint64) ([]*webhook.Delivery, error) {
+	deliveries, err := orm.Query[webhook.Delivery](
+		op.Select().
+			From(webhookDeliveriesTableName).
+			Where(op.And{op.Eq("status", webhook.DeliveryPending), op.Lte("next_attempt_at", now)}).
+			OrderBy(op.Asc("next_attempt_at")).
+			Limit(limit),
+	).GetMany(ctx, r.qe)
+	if err != nil {
+		return nil, fmt.Errorf("get due webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// SaveDelivery stores the given delivery in the database, generating a new UUID for the created delivery.
+func (r *webhookRepo) SaveDelivery(ctx context.Context, d *webhook.Delivery) error {
+	if d.ID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("save webhook delivery (generate uuid): %w", err)
+		}
+
+		d.ID = id
+	}
+
+	if err := orm.Put(webhookDeliveriesTableName, d).With(ctx, r.qe); err != nil {
+		return fmt.Errorf("save webhook delivery: %w (webhook %s)", err, d.EndpointID)
+	}
+
+	return nil
+}
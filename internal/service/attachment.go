@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+const (
+	// attachmentSniffLength is the number of leading bytes used to detect the content type of uploaded files.
+	attachmentSniffLength = 512
+	// attachmentNameLength is the maximum number of characters kept of uploaded file names.
+	attachmentNameLength = 255
+	// attachmentDefaultName is the name of uploaded files without a usable name.
+	attachmentDefaultName = "file"
+)
+
+// AttachmentServiceDeps represents the dependencies required to construct an attachment service.
+type AttachmentServiceDeps struct {
+	TxManager      tx.Manager
+	AttachmentRepo attachment.Repository
+	NoteRepo       note.Repository
+	NoteGuard      note.Guarder
+	Blobs          attachment.BlobStore
+	MaxFileSize    int64
+	MaxUserSize    int64
+}
+
+// attachmentService is a struct that implements the attachment.Service interface for managing files of notes.
+type attachmentService struct {
+	tx             tx.Manager
+	attachmentRepo attachment.Repository
+	noteRepo       note.Repository
+	guard          note.Guarder
+	blobs          attachment.BlobStore
+	maxFileSize    int64
+	maxUserSize    int64
+}
+
+// NewAttachmentService initializes and returns a new implementation of the attachment.Service interface.
+func NewAttachmentService(deps *AttachmentServiceDeps) attachment.Service {
+	return &attachmentService{
+		tx:             deps.TxManager,
+		attachmentRepo: deps.AttachmentRepo,
+		noteRepo:       deps.NoteRepo,
+		guard:          deps.NoteGuard,
+		blobs:          deps.Blobs,
+		maxFileSize:    deps.MaxFileSize,
+		maxUserSize:    deps.MaxUserSize,
+	}
+}
+
+// Upload attaches the file to the note if the user may update the note. The file is spooled to a temporary file
+// to enforce the size limits before it reaches the blob store, and its content type is sniffed from the content.
+// The quota is checked again when the attachment is saved, as files of the user may have been uploaded meanwhile.
+func (s *attachmentService) Upload(
+	ctx context.Context,
+	u *user.User,
+	data *attachment.UploadData,
+) (*attachment.Attachment, error) {
+	n, err := s.getNote(ctx, rbac.UPDATE, u, data.NoteID)
+	if err != nil {
+		return nil, fmt.Errorf("upload attachment: %w", err)
+	}
+
+	usage, err := s.attachmentRepo.GetUsage(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("upload attachment: %w (user %s, note %s)", err, u.ID, n.ID)
+	}
+
+	file, size, err := s.spool(data.Content, min(s.maxFileSize, s.maxUserSize-usage.Size))
+	if err != nil {
+		return nil, fmt.Errorf("upload attachment: %w (user %s, note %s)", err, u.ID, n.ID)
+	}
+	defer os.Remove(file.Name()) // nolint: errcheck
+	defer file.Close()           // nolint: errcheck
+
+	contentType, err := sniff(file)
+	if err != nil {
+		return nil, fmt.Errorf("upload attachment: %w (user %s, note %s)", err, u.ID, n.ID)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("upload attachment (generate uuid): %w (user %s, note %s)", err, u.ID, n.ID)
+	}
+
+	a := &attachment.Attachment{
+		ID:          id,
+		NoteID:      n.ID,
+		UserID:      u.ID,
+		Name:        attachmentName(data.Name),
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.blobs.Put(ctx, a.Key(), file, a.Size, a.ContentType); err != nil {
+		return nil, fmt.Errorf("upload attachment: %w (user %s, note %s)", err, u.ID, n.ID)
+	}
+
+	if err := s.save(ctx, a); err != nil {
+		if delErr := s.blobs.Delete(ctx, a.Key()); delErr != nil {
+			err = errors.Join(err, delErr)
+		}
+
+		return nil, fmt.Errorf("upload attachment: %w (user %s, note %s)", err, u.ID, n.ID)
+	}
+
+	return a, nil
+}
+
+// List returns the attachments of the note if the user may read the note.
+func (s *attachmentService) List(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+) ([]*attachment.Attachment, error) {
+	if _, err := s.getNote(ctx, rbac.READ, u, noteID); err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+
+	attachments, err := s.attachmentRepo.GetByNote(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return attachments, nil
+}
+
+// Download opens the content of the attachment if the user may read the note. The caller closes the content.
+func (s *attachmentService) Download(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	id uuid.UUID,
+) (*attachment.Download, error) {
+	if _, err := s.getNote(ctx, rbac.READ, u, noteID); err != nil {
+		return nil, fmt.Errorf("download attachment: %w", err)
+	}
+
+	a, err := s.attachmentRepo.GetByID(ctx, noteID, id)
+	if err != nil {
+		return nil, fmt.Errorf("download attachment: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	content, err := s.blobs.Open(ctx, a.Key())
+	if err != nil {
+		return nil, fmt.Errorf("download attachment: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return &attachment.Download{Attachment: a, Content: content}, nil
+}
+
+// Delete removes the attachment and its content if the user may update the note.
+func (s *attachmentService) Delete(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	id uuid.UUID,
+) (*attachment.Attachment, error) {
+	if _, err := s.getNote(ctx, rbac.UPDATE, u, noteID); err != nil {
+		return nil, fmt.Errorf("delete attachment: %w", err)
+	}
+
+	a, err := s.attachmentRepo.GetByID(ctx, noteID, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete attachment: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if err := s.attachmentRepo.Delete(ctx, a); err != nil {
+		return nil, fmt.Errorf("delete attachment: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if err := s.blobs.Delete(ctx, a.Key()); err != nil {
+		return nil, fmt.Errorf("delete attachment: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return a, nil
+}
+
+// HandleEvent removes the attachments of deleted notes. Contents are deleted before the rows, so a failed
+// event is retried until nothing of the note is left.
+func (s *attachmentService) HandleEvent(ctx context.Context, ev *event.Event) error {
+	var payload event.NotePayload
+	if err := ev.Decode(&payload); err != nil {
+		return fmt.Errorf("handle attachment event: decode payload: %w (event %s)", err, ev.ID)
+	}
+
+	attachments, err := s.attachmentRepo.GetByNote(ctx, payload.ID)
+	if err != nil {
+		return fmt.Errorf("handle attachment event: %w (event %s)", err, ev.ID)
+	}
+
+	for _, a := range attachments {
+		if err := s.blobs.Delete(ctx, a.Key()); err != nil {
+			return fmt.Errorf("handle attachment event: %w (event %s)", err, ev.ID)
+		}
+
+		if err := s.attachmentRepo.Delete(ctx, a); err != nil {
+			return fmt.Errorf("handle attachment event: %w (event %s)", err, ev.ID)
+		}
+	}
+
+	return nil
+}
+
+// getNote retrieves the note if the operation on it is granted for the user.
+func (s *attachmentService) getNote(
+	ctx context.Context,
+	op rbac.Operation,
+	u *user.User,
+	noteID uuid.UUID,
+) (*note.Note, error) {
+	n, err := s.noteRepo.GetByID(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, noteID)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, op, n, u)
+	if err != nil {
+		return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf("%w (user %s, note %s)", note.ErrOperationForbiddenForUser, u.ID, noteID)
+	}
+
+	return n, nil
+}
+
+// save saves the attachment if it fits in the quota of the user. The usage of the user is locked,
+// so concurrent uploads are saved one by one.
+func (s *attachmentService) save(ctx context.Context, a *attachment.Attachment) error {
+	return s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.attachmentRepo.LockUsage(ctx, a.UserID); err != nil {
+			return err
+		}
+
+		usage, err := s.attachmentRepo.GetUsage(ctx, a.UserID)
+		if err != nil {
+			return err
+		}
+
+		if usage.Size+a.Size > s.maxUserSize {
+			return attachment.ErrQuotaExceeded
+		}
+
+		return s.attachmentRepo.Save(ctx, a)
+	})
+}
+
+// spool copies the content to a temporary file rewound to the start. Contents over the limit are rejected with
+// attachment.ErrFileTooLarge when the file alone is too large and attachment.ErrQuotaExceeded otherwise.
+func (s *attachmentService) spool(content io.Reader, limit int64) (*os.File, int64, error) {
+	file, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("create temp file: %w", err)
+	}
+
+	size, err := io.Copy(file, io.LimitReader(content, max(limit, 0)+1))
+	switch {
+	case err != nil:
+		err = fmt.Errorf("spool content: %w", err)
+	case size > s.maxFileSize:
+		err = attachment.ErrFileTooLarge
+	case size > limit:
+		err = attachment.ErrQuotaExceeded
+	case size == 0:
+		err = attachment.ErrEmptyFile
+	}
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()           // nolint: errcheck, gosec
+		os.Remove(file.Name()) // nolint: errcheck, gosec
+		return nil, 0, err
+	}
+
+	return file, size, nil
+}
+
+// sniff detects the content type of the file from its leading bytes and rewinds the file.
+func sniff(file io.ReadSeeker) (string, error) {
+	buf := make([]byte, attachmentSniffLength)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("sniff content type: %w", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("sniff content type: %w", err)
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// attachmentName returns the base name of the uploaded file, truncated to the maximum length.
+func attachmentName(name string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "." || name == "/" || name == "" || !utf8.ValidString(name) {
+		return attachmentDefaultName
+	}
+
+	if utf8.RuneCountInString(name) > attachmentNameLength {
+		name = string([]rune(name)[:attachmentNameLength])
+	}
+
+	return name
+}
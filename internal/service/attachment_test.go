@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_attachment"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+func TestAttachmentService_Upload(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	n := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 8)
+
+	cases := []struct {
+		name        string
+		fileName    string
+		content     string
+		usage       int64
+		uploaded    int64
+		granted     bool
+		saveErr     error
+		expectedErr error
+		expected    *attachment.Attachment
+	}{
+		{
+			name:     "successful_upload",
+			fileName: "../images\\photo.png",
+			content:  png,
+			usage:    50,
+			granted:  true,
+			expected: &attachment.Attachment{
+				NoteID:      n.ID,
+				UserID:      u.ID,
+				Name:        "photo.png",
+				ContentType: "image/png",
+				Size:        int64(len(png)),
+			},
+		},
+		{
+			name:    "text_without_name",
+			content: "plain text",
+			granted: true,
+			expected: &attachment.Attachment{
+				NoteID:      n.ID,
+				UserID:      u.ID,
+				Name:        "file",
+				ContentType: "text/plain; charset=utf-8",
+				Size:        10,
+			},
+		},
+		{
+			name:        "not_granted",
+			content:     "text",
+			expectedErr: note.ErrOperationForbiddenForUser,
+		},
+		{
+			name:        "file_too_large",
+			content:     strings.Repeat("x", 33),
+			granted:     true,
+			expectedErr: attachment.ErrFileTooLarge,
+		},
+		{
+			name:        "quota_exceeded",
+			content:     strings.Repeat("x", 20),
+			usage:       90,
+			granted:     true,
+			expectedErr: attachment.ErrQuotaExceeded,
+		},
+		{
+			name:        "quota_used_up",
+			content:     "x",
+			usage:       120,
+			granted:     true,
+			expectedErr: attachment.ErrQuotaExceeded,
+		},
+		{
+			name:        "empty_file",
+			granted:     true,
+			expectedErr: attachment.ErrEmptyFile,
+		},
+		{
+			name:        "quota_exceeded_by_concurrent_upload",
+			content:     "text",
+			usage:       50,
+			uploaded:    48,
+			granted:     true,
+			expectedErr: attachment.ErrQuotaExceeded,
+		},
+		{
+			name:        "save_failed",
+			content:     "text",
+			granted:     true,
+			saveErr:     errors.New("save failed"),
+			expectedErr: errors.New("save failed"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			noteRepo := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+			repo := mock_attachment.NewRepository(t)
+			blobs := mock_attachment.NewBlobStore(t)
+
+			noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, n, u).Return(tc.granted, nil).Once()
+			if tc.granted {
+				usage := &attachment.Usage{UserID: u.ID, Size: tc.usage}
+				repo.EXPECT().GetUsage(mock.Anything, u.ID).Return(usage, nil).Once()
+			}
+
+			var key string
+			if tc.expected != nil || tc.saveErr != nil || tc.uploaded > 0 {
+				blobs.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything, int64(len(tc.content)), mock.Anything).
+					RunAndReturn(func(_ context.Context, k string, r io.Reader, _ int64, _ string) error {
+						content, err := io.ReadAll(r)
+						require.NoError(t, err)
+						require.Equal(t, tc.content, string(content))
+						key = k
+						return nil
+					}).Once()
+				usage := &attachment.Usage{UserID: u.ID, Size: tc.usage + tc.uploaded}
+				repo.EXPECT().LockUsage(mock.Anything, u.ID).Return(nil).Once()
+				repo.EXPECT().GetUsage(mock.Anything, u.ID).Return(usage, nil).Once()
+				if tc.uploaded == 0 {
+					repo.EXPECT().Save(mock.Anything, mock.Anything).Return(tc.saveErr).Once()
+				}
+			}
+
+			if tc.saveErr != nil || tc.uploaded > 0 {
+				blobs.EXPECT().Delete(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, k string) error {
+						require.Equal(t, key, k)
+						return nil
+					}).Once()
+			}
+
+			service := NewAttachmentService(&AttachmentServiceDeps{
+				TxManager:      mock_tx.NewMockTxManager(),
+				AttachmentRepo: repo,
+				NoteRepo:       noteRepo,
+				NoteGuard:      guard,
+				Blobs:          blobs,
+				MaxFileSize:    32,
+				MaxUserSize:    100,
+			})
+
+			a, err := service.Upload(context.Background(), u, &attachment.UploadData{
+				NoteID:  n.ID,
+				Name:    tc.fileName,
+				Content: strings.NewReader(tc.content),
+			})
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, a.Key(), key)
+			require.NotEqual(t, uuid.Nil, a.ID)
+			require.False(t, a.CreatedAt.IsZero())
+
+			tc.expected.ID = a.ID
+			tc.expected.CreatedAt = a.CreatedAt
+			require.Equal(t, tc.expected, a)
+		})
+	}
+}
+
+func TestAttachmentService_HandleEvent(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.Must(uuid.NewV7())
+	attachments := []*attachment.Attachment{
+		{ID: uuid.Must(uuid.NewV7()), NoteID: noteID},
+		{ID: uuid.Must(uuid.NewV7()), NoteID: noteID},
+	}
+
+	repo := mock_attachment.NewRepository(t)
+	blobs := mock_attachment.NewBlobStore(t)
+	repo.EXPECT().GetByNote(mock.Anything, noteID).Return(attachments, nil).Once()
+	for _, a := range attachments {
+		blobs.EXPECT().Delete(mock.Anything, a.Key()).Return(nil).Once()
+		repo.EXPECT().Delete(mock.Anything, a).Return(nil).Once()
+	}
+
+	service := NewAttachmentService(&AttachmentServiceDeps{
+		AttachmentRepo: repo,
+		Blobs:          blobs,
+	})
+
+	ev, err := event.NewNoteEvent(event.TypeNoteDeleted, &note.Note{ID: noteID})
+	require.NoError(t, err)
+	require.NoError(t, service.HandleEvent(context.Background(), ev))
+}
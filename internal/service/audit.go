@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// auditVerifyBatchSize is the number of events loaded at once while verifying the hash chain.
+const auditVerifyBatchSize = 500
+
+// AuditServiceDeps represents the dependencies required to construct an audit log service.
+type AuditServiceDeps struct {
+	AuditRepo  audit.Repository
+	AuditGuard audit.Guarder
+}
+
+// auditService is a struct that implements the audit.Service interface for the audit log inspection.
+type auditService struct {
+	auditRepo audit.Repository
+	guard     audit.Guarder
+}
+
+// NewAuditService initializes and returns a new implementation of the audit.Service interface using the provided dependencies.
+func NewAuditService(deps *AuditServiceDeps) audit.Service {
+	return &auditService{
+		auditRepo: deps.AuditRepo,
+		guard:     deps.AuditGuard,
+	}
+}
+
+// Search returns the events matching the filter if the user is authorized to read the audit log.
+func (s *auditService) Search(ctx context.Context, u *user.User, f *audit.Filter) (*search.Result[audit.Event], error) {
+	if err := s.checkGranted(ctx, u); err != nil {
+		return nil, fmt.Errorf("search audit events: %w (user %s)", err, u.ID)
+	}
+
+	res, err := s.auditRepo.Search(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("search audit events: %w (user %s)", err, u.ID)
+	}
+
+	return res, nil
+}
+
+// Verify walks the whole hash chain and reports the first event which was modified, removed or inserted.
+func (s *auditService) Verify(ctx context.Context, u *user.User) (*audit.Verification, error) {
+	if err := s.checkGranted(ctx, u); err != nil {
+		return nil, fmt.Errorf("verify audit events: %w (user %s)", err, u.ID)
+	}
+
+	head, err := s.auditRepo.GetHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("verify audit events: %w (user %s)", err, u.ID)
+	}
+
+	res := &audit.Verification{Valid: true}
+	prev := &audit.Event{}
+	for prev.Seq < head.Seq {
+		events, err := s.auditRepo.GetChain(ctx, prev.Seq, auditVerifyBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("verify audit events: %w (user %s)", err, u.ID)
+		}
+
+		if len(events) == 0 {
+			return &audit.Verification{Checked: res.Checked, BrokenSeq: prev.Seq + 1}, nil
+		}
+
+		for _, e := range events {
+			if e.Seq > head.Seq {
+				return res, nil
+			}
+
+			if e.Seq != prev.Seq+1 || e.PrevHash != prev.Hash || e.Hash != e.ComputeHash() {
+				return &audit.Verification{Checked: res.Checked, BrokenSeq: prev.Seq + 1}, nil
+			}
+
+			res.Checked++
+			prev = e
+		}
+	}
+
+	if prev.Hash != head.Hash {
+		return &audit.Verification{Checked: res.Checked, BrokenSeq: head.Seq}, nil
+	}
+
+	return res, nil
+}
+
+// checkGranted returns audit.ErrOperationForbiddenForUser if reading the audit log is not granted for the user.
+func (s *auditService) checkGranted(ctx context.Context, u *user.User) error {
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, nil, u)
+	if err != nil {
+		return fmt.Errorf("check granted: %w", err)
+	}
+
+	if !granted {
+		return audit.ErrOperationForbiddenForUser
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_audit"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// newAuditRecorder returns an audit recorder accepting any events.
+func newAuditRecorder(t *testing.T) *mock_audit.Recorder {
+	recorder := mock_audit.NewRecorder(t)
+	recorder.EXPECT().Record(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return recorder
+}
+
+// newAuditChain returns the valid hash chain of n events.
+func newAuditChain(n int) []*audit.Event {
+	chain := make([]*audit.Event, n)
+	prevHash := ""
+	for i := range chain {
+		e := audit.NewEvent(context.Background(), audit.ActionNoteCreate, uuid.New(), audit.TargetNote, uuid.New())
+		e.ID = uuid.New()
+		e.Seq = int64(i + 1)
+		e.PrevHash = prevHash
+		e.Hash = e.ComputeHash()
+		prevHash = e.Hash
+		chain[i] = e
+	}
+
+	return chain
+}
+
+func TestAuditService_Verify(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []struct {
+		name        string
+		chain       func() []*audit.Event
+		expected    *audit.Verification
+		expectedErr string
+		granted     bool
+	}{
+		{
+			name:     "valid_chain",
+			chain:    func() []*audit.Event { return newAuditChain(3) },
+			expected: &audit.Verification{Valid: true, Checked: 3},
+			granted:  true,
+		},
+		{
+			name: "tampered_event",
+			chain: func() []*audit.Event {
+				chain := newAuditChain(3)
+				chain[1].Details = "tampered"
+				return chain
+			},
+			expected: &audit.Verification{Checked: 1, BrokenSeq: 2},
+			granted:  true,
+		},
+		{
+			name: "removed_event",
+			chain: func() []*audit.Event {
+				chain := newAuditChain(3)
+				return append(chain[:1], chain[2])
+			},
+			expected: &audit.Verification{Checked: 1, BrokenSeq: 2},
+			granted:  true,
+		},
+		{
+			name:        "not_granted",
+			chain:       func() []*audit.Event { return nil },
+			expectedErr: fmt.Sprintf("verify audit events: audit operation is forbidden for user (user %s)", u.ID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_audit.NewRepository(t)
+			guard := mock_audit.NewGuarder(t)
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, (*audit.Event)(nil), u).Return(tc.granted, nil).Once()
+			if tc.granted {
+				chain := tc.chain()
+				last := chain[len(chain)-1]
+				repo.EXPECT().GetHead(mock.Anything).Return(&audit.Head{Seq: last.Seq, Hash: last.Hash}, nil).Once()
+				repo.EXPECT().GetChain(mock.Anything, int64(0), uint64(auditVerifyBatchSize)).Return(chain, nil).Once()
+			}
+
+			service := NewAuditService(&AuditServiceDeps{AuditRepo: repo, AuditGuard: guard})
+			result, err := service.Verify(context.Background(), u)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+			mock.AssertExpectationsForObjects(t, repo, guard)
+		})
+	}
+}
@@ -2,60 +2,112 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
 	"github.com/xsqrty/notes/internal/domain/auth"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/domain/notification"
 	"github.com/xsqrty/notes/internal/domain/role"
 	"github.com/xsqrty/notes/internal/domain/tx"
 	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/config/registration"
 )
 
 // AuthServiceDeps defines dependencies required by the authService.
 type AuthServiceDeps struct {
-	UserRepo  user.Repository
-	RoleRepo  role.Repository
-	Tokenizer auth.Tokenizer
-	PassGen   auth.PasswordGenerator
-	TxManager tx.Manager
+	UserRepo     user.Repository
+	RoleRepo     role.Repository
+	InviteRepo   invite.Repository
+	Tokenizer    auth.Tokenizer
+	PassGen      auth.PasswordGenerator
+	TxManager    tx.Manager
+	Audit        audit.Recorder
+	Events       event.Publisher
+	DeviceRepo   user.DeviceRepository
+	Notifier     notification.Notifier
+	Registration registration.Mode
+	OnError      func(error)
 }
 
 // authService is a private implementation of the authentication service interface.
 type authService struct {
-	tokenizer auth.Tokenizer
-	roleRepo  role.Repository
-	userRepo  user.Repository
-	passGen   auth.PasswordGenerator
-	tx        tx.Manager
+	tokenizer    auth.Tokenizer
+	roleRepo     role.Repository
+	userRepo     user.Repository
+	inviteRepo   invite.Repository
+	passGen      auth.PasswordGenerator
+	tx           tx.Manager
+	audit        audit.Recorder
+	events       event.Publisher
+	deviceRepo   user.DeviceRepository
+	notifier     notification.Notifier
+	registration registration.Mode
+	onError      func(error)
 }
 
 // NewAuthService creates a new instance of auth.Service with necessary dependencies for authentication operations.
+// Errors recording the devices of the users, which don't fail the sign-in, are reported to OnError.
 func NewAuthService(deps *AuthServiceDeps) auth.Service {
+	onError := deps.OnError
+	if onError == nil {
+		onError = func(error) {}
+	}
+
 	return &authService{
-		tokenizer: deps.Tokenizer,
-		roleRepo:  deps.RoleRepo,
-		userRepo:  deps.UserRepo,
-		passGen:   deps.PassGen,
-		tx:        deps.TxManager,
+		tokenizer:    deps.Tokenizer,
+		roleRepo:     deps.RoleRepo,
+		userRepo:     deps.UserRepo,
+		inviteRepo:   deps.InviteRepo,
+		passGen:      deps.PassGen,
+		tx:           deps.TxManager,
+		audit:        deps.Audit,
+		events:       deps.Events,
+		deviceRepo:   deps.DeviceRepo,
+		notifier:     deps.Notifier,
+		registration: deps.Registration,
+		onError:      onError,
 	}
 }
 
 // Login authenticates the user using the provided credentials and returns the generated access and refresh tokens.
+// The user is notified of logins from devices not seen before, failing to record the device doesn't fail the login.
 func (s *authService) Login(ctx context.Context, login *auth.Login) (*auth.Tokens, error) {
 	u, err := s.userRepo.GetByEmail(ctx, login.Email)
 	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, s.loginFailed(ctx, uuid.Nil, login.Email, err)
+		}
+
 		return nil, fmt.Errorf("login: %w", err)
 	}
 
 	if !s.passGen.Compare(u.HashedPassword, login.Password) {
-		return nil, fmt.Errorf("login: %w", auth.ErrPasswordIncorrect)
+		return nil, s.loginFailed(ctx, u.ID, login.Email, auth.ErrPasswordIncorrect)
 	}
 
+	if err := s.audit.Record(ctx, audit.NewEvent(ctx, audit.ActionLogin, u.ID, audit.TargetUser, u.ID)); err != nil {
+		return nil, fmt.Errorf("login: %w", err)
+	}
+
+	s.signedIn(ctx, u)
 	return s.GenerateTokens(u)
 }
 
 // SignUp registers a new user with the provided data and generates authentication tokens. Returns tokens or an error.
+// The invitation code, if given, is consumed in the same transaction and defines the role attached to the user.
 func (s *authService) SignUp(ctx context.Context, data *auth.SignUp) (*auth.Tokens, error) {
+	switch {
+	case s.registration == registration.Closed:
+		return nil, fmt.Errorf("signup: %w", auth.ErrRegistrationClosed)
+	case s.registration == registration.InviteOnly && data.InviteCode == "":
+		return nil, fmt.Errorf("signup: %w", auth.ErrInviteCodeRequired)
+	}
+
 	isExist, err := s.userRepo.EmailExists(ctx, data.Email)
 	if err != nil {
 		return nil, fmt.Errorf("signup check email: %w", err)
@@ -82,20 +134,158 @@ func (s *authService) SignUp(ctx context.Context, data *auth.SignUp) (*auth.Toke
 			return fmt.Errorf("signup: %w", err)
 		}
 
-		err = s.roleRepo.AttachUserRolesByLabel(ctx, role.LabelOnCreated, user)
+		label := role.LabelOnCreated
+		if data.InviteCode != "" {
+			code, err := s.consumeInviteCode(ctx, data.InviteCode, user)
+			if err != nil {
+				return fmt.Errorf("signup: %w", err)
+			}
+
+			label = role.Label(code.RoleLabel)
+		}
+
+		err = s.roleRepo.AttachUserRolesByLabel(ctx, label, user)
 		if err != nil {
 			return fmt.Errorf("signup: %w", err)
 		}
 
+		err = s.audit.Record(ctx, audit.NewEvent(ctx, audit.ActionSignUp, user.ID, audit.TargetUser, user.ID))
+		if err != nil {
+			return fmt.Errorf("signup: %w", err)
+		}
+
+		e, err := event.NewUserSignedUpEvent(user)
+		if err != nil {
+			return fmt.Errorf("signup: %w", err)
+		}
+
+		if err := s.events.Publish(ctx, e); err != nil {
+			return fmt.Errorf("signup: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.signedIn(ctx, user)
 	return s.GenerateTokens(user)
 }
 
+// loginFailed records the failed login attempt and returns the login error caused by the reason.
+func (s *authService) loginFailed(ctx context.Context, actor uuid.UUID, email string, reason error) error {
+	e := audit.NewEvent(ctx, audit.ActionLoginFailed, actor, audit.TargetUser, actor)
+	e.Details = "email " + email
+	if err := s.audit.Record(ctx, e); err != nil {
+		return fmt.Errorf("login: %w", errors.Join(reason, err))
+	}
+
+	return fmt.Errorf("login: %w", reason)
+}
+
+// signedIn records the device the user signed in from in its own transaction, reporting errors to onError.
+func (s *authService) signedIn(ctx context.Context, u *user.User) {
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		return s.recordDevice(ctx, u)
+	})
+	if err != nil {
+		s.onError(fmt.Errorf("record device: %w (user %s)", err, u.ID))
+	}
+}
+
+// recordDevice remembers the device of the request the user signed in from and notifies the user when the device
+// was not seen before, unless it is the first device of the user. Requests without a user agent are not recorded.
+func (s *authService) recordDevice(ctx context.Context, u *user.User) error {
+	source := audit.SourceFromContext(ctx)
+	if source.UserAgent == "" {
+		return nil
+	}
+
+	now := time.Now()
+	fingerprint := user.DeviceFingerprint(source.UserAgent)
+	_, err := s.deviceRepo.GetDevice(ctx, u.ID, fingerprint)
+	seen := err == nil
+	if err != nil && !errors.Is(err, user.ErrDeviceNotFound) {
+		return err
+	}
+
+	known := seen
+	if !seen {
+		if known, err = s.deviceRepo.HasDevices(ctx, u.ID); err != nil {
+			return err
+		}
+	}
+
+	err = s.deviceRepo.SaveDevice(ctx, &user.Device{
+		UserID:      u.ID,
+		Fingerprint: fingerprint,
+		UserAgent:   source.UserAgent,
+		IP:          source.IP,
+		CreatedAt:   now,
+		LastSeenAt:  now,
+	})
+	if err != nil || seen || !known {
+		return err
+	}
+
+	return s.notifier.Notify(ctx, &notification.Notification{
+		UserID: u.ID,
+		Type:   notification.TypeNewLogin,
+		Title:  "New login to your account",
+		Body: fmt.Sprintf(
+			"Your account was signed in from a new device: %s (IP %s).",
+			source.UserAgent,
+			source.IP,
+		),
+		CreatedAt: now,
+	})
+}
+
+// consumeInviteCode registers the use of the invitation code by the user. The code is locked before its uses
+// are counted, so concurrent sign-ups with the code take its uses one by one.
+// Returns auth.ErrInviteCodeInvalid if the code doesn't exist, is expired, exhausted or bound to another email.
+func (s *authService) consumeInviteCode(ctx context.Context, value string, u *user.User) (*invite.Code, error) {
+	err := s.inviteRepo.Lock(ctx, value)
+	if errors.Is(err, invite.ErrNotFound) {
+		return nil, auth.ErrInviteCodeInvalid
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.inviteRepo.GetByCode(ctx, value)
+	if err != nil {
+		if errors.Is(err, invite.ErrNotFound) {
+			return nil, auth.ErrInviteCodeInvalid
+		}
+
+		return nil, err
+	}
+
+	if !code.IsUsable(u.Email, time.Now()) {
+		return nil, fmt.Errorf("%w (code %s)", auth.ErrInviteCodeInvalid, code.ID)
+	}
+
+	code.Uses++
+	err = s.inviteRepo.SaveUse(ctx, &invite.Use{
+		CodeID:    code.ID,
+		Number:    code.Uses,
+		UserID:    u.ID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.inviteRepo.Save(ctx, code); err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
 // GenerateTokens creates and returns new access and refresh tokens associated with the given user.
 func (s *authService) GenerateTokens(user *user.User) (*auth.Tokens, error) {
 	accessToken, err := s.tokenizer.CreateAccessToken(user)
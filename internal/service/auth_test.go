@@ -10,13 +10,19 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/audit"
 	"github.com/xsqrty/notes/internal/domain/auth"
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/domain/notification"
 	"github.com/xsqrty/notes/internal/domain/role"
 	"github.com/xsqrty/notes/internal/domain/user"
 	"github.com/xsqrty/notes/mocks/app/mock_tx"
 	"github.com/xsqrty/notes/mocks/domain/mock_auth"
+	"github.com/xsqrty/notes/mocks/domain/mock_invite"
+	"github.com/xsqrty/notes/mocks/domain/mock_notification"
 	"github.com/xsqrty/notes/mocks/domain/mock_role"
 	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/pkg/config/registration"
 )
 
 func TestAuthService_Login(t *testing.T) {
@@ -85,6 +91,9 @@ func TestAuthService_Login(t *testing.T) {
 				UserRepo:  repo,
 				Tokenizer: tokenizer,
 				PassGen:   passgen,
+				TxManager: mock_tx.NewMockTxManager(),
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
 			})
 
 			result, err := service.Login(context.Background(), &auth.Login{
@@ -102,6 +111,124 @@ func TestAuthService_Login(t *testing.T) {
 	}
 }
 
+func TestAuthService_LoginDevice(t *testing.T) {
+	t.Parallel()
+
+	password := gofakeit.Password(true, true, true, true, true, 20)
+	u := &user.User{
+		ID:             uuid.Must(uuid.NewV7()),
+		Email:          gofakeit.Email(),
+		HashedPassword: gofakeit.LetterN(32),
+	}
+	source := audit.Source{IP: "203.0.113.7", UserAgent: "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0"}
+	fingerprint := user.DeviceFingerprint(source.UserAgent)
+
+	cases := []struct {
+		name          string
+		device        *user.Device
+		known         bool
+		notified      bool
+		getErr        error
+		notifyErr     error
+		expectedError string
+	}{
+		{
+			name:   "known_device",
+			device: &user.Device{ID: uuid.Must(uuid.NewV7()), UserID: u.ID, Fingerprint: fingerprint, IP: "192.0.2.1"},
+		},
+		{
+			name:     "new_device",
+			known:    true,
+			notified: true,
+		},
+		{
+			name: "first_device",
+		},
+		{
+			name:          "device_error",
+			getErr:        errors.New("db error"),
+			expectedError: fmt.Sprintf("record device: db error (user %s)", u.ID),
+		},
+		{
+			name:          "notify_error",
+			known:         true,
+			notified:      true,
+			notifyErr:     errors.New("notify error"),
+			expectedError: fmt.Sprintf("record device: notify error (user %s)", u.ID),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_user.NewRepository(t)
+			devices := mock_user.NewDeviceRepository(t)
+			notifier := mock_notification.NewNotifier(t)
+			tokenizer := mock_auth.NewTokenizer(t)
+			passgen := mock_auth.NewPasswordGenerator(t)
+			var reported []string
+
+			repo.EXPECT().GetByEmail(mock.Anything, u.Email).Return(u, nil).Once()
+			passgen.EXPECT().Compare(u.HashedPassword, password).Return(true).Once()
+			tokenizer.EXPECT().CreateAccessToken(u).Return("access", nil).Once()
+			tokenizer.EXPECT().CreateRefreshToken(u).Return("refresh", nil).Once()
+			switch {
+			case tc.getErr != nil:
+				devices.EXPECT().GetDevice(mock.Anything, u.ID, fingerprint).Return(nil, tc.getErr).Once()
+			case tc.device != nil:
+				devices.EXPECT().GetDevice(mock.Anything, u.ID, fingerprint).Return(tc.device, nil).Once()
+			default:
+				devices.EXPECT().GetDevice(mock.Anything, u.ID, fingerprint).Return(nil, user.ErrDeviceNotFound).Once()
+				devices.EXPECT().HasDevices(mock.Anything, u.ID).Return(tc.known, nil).Once()
+			}
+
+			if tc.getErr == nil {
+				devices.EXPECT().SaveDevice(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, d *user.Device) error {
+						require.Equal(t, u.ID, d.UserID)
+						require.Equal(t, fingerprint, d.Fingerprint)
+						require.Equal(t, source.IP, d.IP)
+						require.False(t, d.LastSeenAt.IsZero())
+						return nil
+					}).Once()
+			}
+
+			if tc.notified {
+				notifier.EXPECT().Notify(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, n *notification.Notification) error {
+						require.Equal(t, u.ID, n.UserID)
+						require.Equal(t, notification.TypeNewLogin, n.Type)
+						require.Contains(t, n.Body, source.UserAgent)
+						return tc.notifyErr
+					}).Once()
+			}
+
+			service := NewAuthService(&AuthServiceDeps{
+				UserRepo:   repo,
+				Tokenizer:  tokenizer,
+				PassGen:    passgen,
+				TxManager:  mock_tx.NewMockTxManager(),
+				Audit:      newAuditRecorder(t),
+				Events:     newEventPublisher(t),
+				DeviceRepo: devices,
+				Notifier:   notifier,
+				OnError:    func(err error) { reported = append(reported, err.Error()) },
+			})
+
+			ctx := audit.WithSource(context.Background(), source)
+			tokens, err := service.Login(ctx, &auth.Login{Email: u.Email, Password: password})
+			require.NoError(t, err)
+			require.Equal(t, "access", tokens.AccessToken)
+			if tc.expectedError != "" {
+				require.Equal(t, []string{tc.expectedError}, reported)
+			} else {
+				require.Empty(t, reported)
+			}
+		})
+	}
+}
+
 func TestAuthService_SignUp(t *testing.T) {
 	t.Parallel()
 
@@ -208,6 +335,8 @@ func TestAuthService_SignUp(t *testing.T) {
 				UserRepo:  repo,
 				Tokenizer: tokenizer,
 				PassGen:   passgen,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
 			})
 
 			result, err := service.SignUp(context.Background(), &auth.SignUp{
@@ -234,6 +363,165 @@ func TestAuthService_SignUp(t *testing.T) {
 	}
 }
 
+// signUpMocks groups the mocked dependencies of the sign-up flow.
+type signUpMocks struct {
+	repo       *mock_user.Repository
+	roleRepo   *mock_role.Repository
+	inviteRepo *mock_invite.Repository
+	tokenizer  *mock_auth.Tokenizer
+	passgen    *mock_auth.PasswordGenerator
+}
+
+func TestAuthService_SignUpInviteCode(t *testing.T) {
+	t.Parallel()
+
+	email := gofakeit.Email()
+	password := gofakeit.Password(true, true, true, true, true, 20)
+
+	code := func(maxUses, uses int, bound string) *invite.Code {
+		return &invite.Code{
+			ID:        uuid.Must(uuid.NewV7()),
+			Code:      "CODE",
+			Email:     bound,
+			RoleLabel: "staff",
+			MaxUses:   maxUses,
+			Uses:      uses,
+		}
+	}
+
+	exhausted := code(1, 1, "")
+	foreign := code(0, 0, "other@example.com")
+
+	cases := []struct {
+		name         string
+		registration registration.Mode
+		inviteCode   string
+		expectedErr  string
+		mocker       func(m *signUpMocks)
+	}{
+		{
+			name:         "successful_signup",
+			registration: registration.InviteOnly,
+			inviteCode:   "CODE",
+			mocker: func(m *signUpMocks) {
+				m.repo.EXPECT().EmailExists(mock.Anything, email).Return(false, nil).Once()
+				m.passgen.EXPECT().Generate(password).Return(password, nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				m.inviteRepo.EXPECT().Lock(mock.Anything, "CODE").Return(nil).Once()
+				m.inviteRepo.EXPECT().GetByCode(mock.Anything, "CODE").Return(code(2, 1, email), nil).Once()
+				m.inviteRepo.EXPECT().
+					SaveUse(mock.Anything, mock.MatchedBy(func(u *invite.Use) bool { return u.Number == 2 })).
+					Return(nil).
+					Once()
+				m.inviteRepo.EXPECT().
+					Save(mock.Anything, mock.MatchedBy(func(c *invite.Code) bool { return c.Uses == 2 })).
+					Return(nil).
+					Once()
+				m.roleRepo.EXPECT().
+					AttachUserRolesByLabel(mock.Anything, role.Label("staff"), mock.Anything).
+					Return(nil).
+					Once()
+				m.tokenizer.EXPECT().CreateRefreshToken(mock.Anything).Return(gofakeit.LetterN(50), nil).Once()
+				m.tokenizer.EXPECT().CreateAccessToken(mock.Anything).Return(gofakeit.LetterN(50), nil).Once()
+			},
+		},
+		{
+			name:         "registration_closed",
+			registration: registration.Closed,
+			inviteCode:   "CODE",
+			expectedErr:  "signup: registration is closed",
+			mocker: func(m *signUpMocks) {
+			},
+		},
+		{
+			name:         "code_required",
+			registration: registration.InviteOnly,
+			expectedErr:  "signup: invitation code is required",
+			mocker: func(m *signUpMocks) {
+			},
+		},
+		{
+			name:         "code_not_found",
+			registration: registration.InviteOnly,
+			inviteCode:   "UNKNOWN",
+			expectedErr:  "signup: invitation code is invalid",
+			mocker: func(m *signUpMocks) {
+				m.repo.EXPECT().EmailExists(mock.Anything, email).Return(false, nil).Once()
+				m.passgen.EXPECT().Generate(password).Return(password, nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				m.inviteRepo.EXPECT().Lock(mock.Anything, "UNKNOWN").Return(invite.ErrNotFound).Once()
+			},
+		},
+		{
+			name:         "code_exhausted",
+			registration: registration.InviteOnly,
+			inviteCode:   "CODE",
+			expectedErr:  fmt.Sprintf("signup: invitation code is invalid (code %s)", exhausted.ID),
+			mocker: func(m *signUpMocks) {
+				m.repo.EXPECT().EmailExists(mock.Anything, email).Return(false, nil).Once()
+				m.passgen.EXPECT().Generate(password).Return(password, nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				m.inviteRepo.EXPECT().Lock(mock.Anything, "CODE").Return(nil).Once()
+				m.inviteRepo.EXPECT().GetByCode(mock.Anything, "CODE").Return(exhausted, nil).Once()
+			},
+		},
+		{
+			name:         "code_bound_to_another_email",
+			registration: registration.Open,
+			inviteCode:   "CODE",
+			expectedErr:  fmt.Sprintf("signup: invitation code is invalid (code %s)", foreign.ID),
+			mocker: func(m *signUpMocks) {
+				m.repo.EXPECT().EmailExists(mock.Anything, email).Return(false, nil).Once()
+				m.passgen.EXPECT().Generate(password).Return(password, nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				m.inviteRepo.EXPECT().Lock(mock.Anything, "CODE").Return(nil).Once()
+				m.inviteRepo.EXPECT().GetByCode(mock.Anything, "CODE").Return(foreign, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &signUpMocks{
+				repo:       mock_user.NewRepository(t),
+				roleRepo:   mock_role.NewRepository(t),
+				inviteRepo: mock_invite.NewRepository(t),
+				tokenizer:  mock_auth.NewTokenizer(t),
+				passgen:    mock_auth.NewPasswordGenerator(t),
+			}
+			tc.mocker(m)
+
+			service := NewAuthService(&AuthServiceDeps{
+				TxManager:    mock_tx.NewMockTxManager(),
+				RoleRepo:     m.roleRepo,
+				UserRepo:     m.repo,
+				InviteRepo:   m.inviteRepo,
+				Tokenizer:    m.tokenizer,
+				PassGen:      m.passgen,
+				Audit:        newAuditRecorder(t),
+				Events:       newEventPublisher(t),
+				Registration: tc.registration,
+			})
+
+			result, err := service.SignUp(context.Background(), &auth.SignUp{
+				Email:      email,
+				Password:   password,
+				InviteCode: tc.inviteCode,
+			})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, email, result.User.Email)
+			mock.AssertExpectationsForObjects(t, m.repo, m.roleRepo, m.inviteRepo, m.tokenizer, m.passgen)
+		})
+	}
+}
+
 func TestAuthService_GenerateTokens(t *testing.T) {
 	t.Parallel()
 
@@ -297,6 +585,8 @@ func TestAuthService_GenerateTokens(t *testing.T) {
 				UserRepo:  repo,
 				Tokenizer: tokenizer,
 				PassGen:   passgen,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
 			})
 
 			result, err := service.GenerateTokens(u)
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+// ChecklistServiceDeps represents the dependencies required to construct a checklist service.
+type ChecklistServiceDeps struct {
+	TxManager     tx.Manager
+	ChecklistRepo checklist.Repository
+	NoteRepo      note.Repository
+	UserRepo      user.Repository
+	NoteGuard     note.Guarder
+	Audit         audit.Recorder
+	Events        event.Publisher
+	MaxItems      int
+}
+
+// checklistService is a struct that implements the checklist.Service interface for managing checklists of notes.
+type checklistService struct {
+	tx            tx.Manager
+	checklistRepo checklist.Repository
+	noteRepo      note.Repository
+	userRepo      user.Repository
+	guard         note.Guarder
+	audit         audit.Recorder
+	events        event.Publisher
+	maxItems      int
+}
+
+// checklistChange changes the items of the note, saving the changed items. Returns the items of the note after
+// the change.
+type checklistChange func(ctx context.Context, n *note.Note, items []*checklist.Item) ([]*checklist.Item, error)
+
+// NewChecklistService initializes and returns a new implementation of the checklist.Service interface.
+func NewChecklistService(deps *ChecklistServiceDeps) checklist.Service {
+	return &checklistService{
+		tx:            deps.TxManager,
+		checklistRepo: deps.ChecklistRepo,
+		noteRepo:      deps.NoteRepo,
+		userRepo:      deps.UserRepo,
+		guard:         deps.NoteGuard,
+		audit:         deps.Audit,
+		events:        deps.Events,
+		maxItems:      deps.MaxItems,
+	}
+}
+
+// List returns the checklist items of the note ordered by the position if the user may read the note.
+func (s *checklistService) List(ctx context.Context, u *user.User, noteID uuid.UUID) ([]*checklist.Item, error) {
+	if _, err := s.getNote(ctx, u, noteID, rbac.READ); err != nil {
+		return nil, fmt.Errorf("list checklist items: %w", err)
+	}
+
+	items, err := s.checklistRepo.GetByNote(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("list checklist items: %w (user %s)", err, u.ID)
+	}
+
+	return items, nil
+}
+
+// Add appends the item to the checklist of the note if the user may update the note.
+func (s *checklistService) Add(ctx context.Context, u *user.User, data *checklist.ItemData) (*checklist.Item, error) {
+	var item *checklist.Item
+	err := s.change(ctx, u, data.NoteID, func(
+		ctx context.Context,
+		n *note.Note,
+		items []*checklist.Item,
+	) ([]*checklist.Item, error) {
+		if len(items) >= s.maxItems {
+			return nil, fmt.Errorf("%w: %d items at most", checklist.ErrTooManyItems, s.maxItems)
+		}
+
+		if err := s.checkAssignee(ctx, n, data.AssigneeID); err != nil {
+			return nil, err
+		}
+
+		item = &checklist.Item{
+			NoteID:     n.ID,
+			Text:       data.Text,
+			AssigneeID: data.AssigneeID,
+			DueAt:      driver.ZeroTime(data.DueAt),
+			CreatedAt:  time.Now(),
+		}
+		if len(items) > 0 {
+			item.Position = items[len(items)-1].Position + 1
+		}
+
+		if err := s.checklistRepo.Save(ctx, item); err != nil {
+			return nil, err
+		}
+
+		return append(items, item), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add checklist item: %w", err)
+	}
+
+	return item, nil
+}
+
+// Update sets the text, the assignee and the due date of the item if the user may update the note.
+func (s *checklistService) Update(
+	ctx context.Context,
+	u *user.User,
+	id uuid.UUID,
+	data *checklist.ItemData,
+) (*checklist.Item, error) {
+	var item *checklist.Item
+	err := s.change(ctx, u, data.NoteID, func(
+		ctx context.Context,
+		n *note.Note,
+		items []*checklist.Item,
+	) ([]*checklist.Item, error) {
+		var err error
+		if item, err = findItem(items, id); err != nil {
+			return nil, err
+		}
+
+		if err := s.checkAssignee(ctx, n, data.AssigneeID); err != nil {
+			return nil, err
+		}
+
+		item.Text = data.Text
+		item.AssigneeID = data.AssigneeID
+		item.DueAt = driver.ZeroTime(data.DueAt)
+		item.UpdatedAt = driver.ZeroTime(time.Now())
+		return items, s.checklistRepo.Save(ctx, item)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update checklist item: %w (item %s)", err, id)
+	}
+
+	return item, nil
+}
+
+// SetDone marks the item done or not done if the user may update the note. Done items keep the time they were done.
+func (s *checklistService) SetDone(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	id uuid.UUID,
+	done bool,
+) (*checklist.Item, error) {
+	var item *checklist.Item
+	err := s.change(ctx, u, noteID, func(
+		ctx context.Context,
+		_ *note.Note,
+		items []*checklist.Item,
+	) ([]*checklist.Item, error) {
+		var err error
+		if item, err = findItem(items, id); err != nil {
+			return nil, err
+		}
+
+		if item.Done == done {
+			return items, nil
+		}
+
+		now := time.Now()
+		item.Done = done
+		item.DoneAt = driver.ZeroTime{}
+		if done {
+			item.DoneAt = driver.ZeroTime(now)
+		}
+
+		item.UpdatedAt = driver.ZeroTime(now)
+		return items, s.checklistRepo.Save(ctx, item)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set checklist item done: %w (item %s)", err, id)
+	}
+
+	return item, nil
+}
+
+// Reorder orders the items of the note as listed if the user may update the note. The identifiers must list every
+// item of the note once.
+func (s *checklistService) Reorder(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	ids []uuid.UUID,
+) ([]*checklist.Item, error) {
+	var ordered []*checklist.Item
+	err := s.change(ctx, u, noteID, func(
+		ctx context.Context,
+		_ *note.Note,
+		items []*checklist.Item,
+	) ([]*checklist.Item, error) {
+		if len(ids) != len(items) {
+			return nil, fmt.Errorf("%w: %d of %d items listed", checklist.ErrInvalidOrder, len(ids), len(items))
+		}
+
+		ordered = make([]*checklist.Item, len(ids))
+		for i, id := range ids {
+			item, err := findItem(items, id)
+			if err != nil || slices.Contains(ordered[:i], item) {
+				return nil, fmt.Errorf("%w: item %s", checklist.ErrInvalidOrder, id)
+			}
+
+			ordered[i] = item
+		}
+
+		now := time.Now()
+		for i, item := range ordered {
+			if item.Position == i {
+				continue
+			}
+
+			item.Position = i
+			item.UpdatedAt = driver.ZeroTime(now)
+			if err := s.checklistRepo.Save(ctx, item); err != nil {
+				return nil, err
+			}
+		}
+
+		return ordered, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reorder checklist items: %w", err)
+	}
+
+	return ordered, nil
+}
+
+// Delete removes the item from the checklist of the note if the user may update the note.
+func (s *checklistService) Delete(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	id uuid.UUID,
+) (*checklist.Item, error) {
+	var item *checklist.Item
+	err := s.change(ctx, u, noteID, func(
+		ctx context.Context,
+		_ *note.Note,
+		items []*checklist.Item,
+	) ([]*checklist.Item, error) {
+		var err error
+		if item, err = findItem(items, id); err != nil {
+			return nil, err
+		}
+
+		if err := s.checklistRepo.Delete(ctx, item); err != nil {
+			return nil, err
+		}
+
+		return slices.DeleteFunc(items, func(i *checklist.Item) bool { return i.ID == id }), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete checklist item: %w (item %s)", err, id)
+	}
+
+	return item, nil
+}
+
+// change applies the change to the checklist of the note within a transaction holding the checklist lock and saves
+// the completion of the checklist with the note, recording the change and publishing the note event. The completion
+// is not a part of the note content, so the version, the update time and the revisions of the note are kept, and
+// the content updates of the note never overwrite it. The change is applied again to the note changed concurrently.
+func (s *checklistService) change(ctx context.Context, u *user.User, noteID uuid.UUID, fn checklistChange) error {
+	for attempt := 1; ; attempt++ {
+		err := s.tx.Transact(ctx, func(ctx context.Context) error {
+			n, err := s.getNote(ctx, u, noteID, rbac.UPDATE)
+			if err != nil {
+				return err
+			}
+
+			if err := s.checklistRepo.Lock(ctx, n.ID); err != nil {
+				return err
+			}
+
+			items, err := s.checklistRepo.GetByNote(ctx, n.ID)
+			if err != nil {
+				return err
+			}
+
+			if items, err = fn(ctx, n, items); err != nil {
+				return err
+			}
+
+			n.ChecklistTotal, n.ChecklistDone = checklist.Count(items)
+			n.HasOpenItems = n.ChecklistDone < n.ChecklistTotal
+			if err := s.noteRepo.SaveFields(ctx, n, []note.Field{note.FieldChecklist}); err != nil {
+				return err
+			}
+
+			e := audit.NewEvent(ctx, audit.ActionNoteChecklist, u.ID, audit.TargetNote, n.ID)
+			if err := s.audit.Record(ctx, e); err != nil {
+				return err
+			}
+
+			ev, err := event.NewNoteEvent(event.TypeNoteUpdated, n)
+			if err != nil {
+				return err
+			}
+
+			return s.events.Publish(ctx, ev)
+		})
+		if errors.Is(err, note.ErrVersionConflict) && attempt < noteUpdateAttempts {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w (user %s, note %s)", err, u.ID, noteID)
+		}
+
+		return nil
+	}
+}
+
+// checkAssignee checks that the assignee, if any, may read the note.
+func (s *checklistService) checkAssignee(ctx context.Context, n *note.Note, assigneeID uuid.NullUUID) error {
+	if !assigneeID.Valid {
+		return nil
+	}
+
+	assignee, err := s.userRepo.GetByID(ctx, assigneeID.UUID)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return fmt.Errorf("%w: %s", checklist.ErrInvalidAssignee, assigneeID.UUID)
+		}
+
+		return err
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, n, assignee)
+	if err != nil {
+		return fmt.Errorf("check assignee granted: %w (assignee %s)", err, assignee.ID)
+	}
+
+	if !granted {
+		return fmt.Errorf("%w: %s", checklist.ErrInvalidAssignee, assignee.ID)
+	}
+
+	return nil
+}
+
+// getNote returns the note if the user is granted the operation on the note.
+func (s *checklistService) getNote(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	operation rbac.Operation,
+) (*note.Note, error) {
+	n, err := s.noteRepo.GetByID(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, noteID)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, operation, n, u)
+	if err != nil {
+		return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf("%w (user %s, note %s)", note.ErrOperationForbiddenForUser, u.ID, noteID)
+	}
+
+	return n, nil
+}
+
+// findItem returns the item with the identifier among the items, otherwise it returns checklist.ErrItemNotFound.
+func findItem(items []*checklist.Item, id uuid.UUID) (*checklist.Item, error) {
+	for _, item := range items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", checklist.ErrItemNotFound, id)
+}
@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/checklist"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_audit"
+	"github.com/xsqrty/notes/mocks/domain/mock_checklist"
+	"github.com/xsqrty/notes/mocks/domain/mock_event"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+type checklistMocks struct {
+	repo   *mock_checklist.Repository
+	notes  *mock_note.Repository
+	users  *mock_user.Repository
+	guard  *mock_note.Guarder
+	audit  *mock_audit.Recorder
+	events *mock_event.Publisher
+}
+
+func TestChecklistService_Add(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	assignee := &user.User{ID: uuid.Must(uuid.NewV7())}
+	noteID := uuid.Must(uuid.NewV7())
+	dueAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	newNote := func() *note.Note {
+		return &note.Note{ID: noteID, UserId: u.ID, Version: 4, ChecklistTotal: 2, ChecklistDone: 2}
+	}
+	newItems := func() []*checklist.Item {
+		return []*checklist.Item{
+			{ID: uuid.Must(uuid.NewV7()), NoteID: noteID, Position: 0, Done: true},
+			{ID: uuid.Must(uuid.NewV7()), NoteID: noteID, Position: 3, Done: true},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		data        *checklist.ItemData
+		maxItems    int
+		expectedErr error
+		mocker      func(m *checklistMocks)
+	}{
+		{
+			name:     "successful_add",
+			data:     &checklist.ItemData{NoteID: noteID, Text: "Book flights"},
+			maxItems: 10,
+			mocker: func(m *checklistMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(newNote(), nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				m.repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Once()
+				m.repo.EXPECT().GetByNote(mock.Anything, noteID).Return(newItems(), nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, item *checklist.Item) error {
+						require.Equal(t, 4, item.Position)
+						require.False(t, item.Done)
+						require.False(t, item.CreatedAt.IsZero())
+						return nil
+					}).Once()
+				m.notes.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldChecklist}).
+					RunAndReturn(func(_ context.Context, n *note.Note, _ []note.Field) error {
+						require.Equal(t, int64(4), n.Version)
+						require.True(t, time.Time(n.UpdatedAt).IsZero())
+						require.Equal(t, 3, n.ChecklistTotal)
+						require.Equal(t, 2, n.ChecklistDone)
+						require.True(t, n.HasOpenItems)
+						return nil
+					}).Once()
+				m.audit.EXPECT().
+					Record(mock.Anything, mock.MatchedBy(func(e *audit.Event) bool {
+						return e.Action == audit.ActionNoteChecklist
+					})).
+					Return(nil).
+					Once()
+				m.events.EXPECT().
+					Publish(mock.Anything, mock.MatchedBy(func(e *event.Event) bool {
+						return e.Type == event.TypeNoteUpdated && e.AggregateID == noteID
+					})).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name: "assigned",
+			data: &checklist.ItemData{
+				NoteID:     noteID,
+				Text:       "Book flights",
+				AssigneeID: uuid.NullUUID{UUID: assignee.ID, Valid: true},
+				DueAt:      dueAt,
+			},
+			maxItems: 10,
+			mocker: func(m *checklistMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(newNote(), nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				m.repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Once()
+				m.repo.EXPECT().GetByNote(mock.Anything, noteID).Return(nil, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, assignee.ID).Return(assignee, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, mock.Anything, assignee).Return(true, nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, item *checklist.Item) error {
+						require.Equal(t, 0, item.Position)
+						require.Equal(t, assignee.ID, item.AssigneeID.UUID)
+						require.Equal(t, dueAt, time.Time(item.DueAt))
+						return nil
+					}).Once()
+				m.notes.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldChecklist}).
+					Return(nil).Once()
+			},
+		},
+		{
+			name: "assignee_not_granted",
+			data: &checklist.ItemData{
+				NoteID:     noteID,
+				Text:       "Book flights",
+				AssigneeID: uuid.NullUUID{UUID: assignee.ID, Valid: true},
+			},
+			maxItems:    10,
+			expectedErr: checklist.ErrInvalidAssignee,
+			mocker: func(m *checklistMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(newNote(), nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				m.repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Once()
+				m.repo.EXPECT().GetByNote(mock.Anything, noteID).Return(nil, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, assignee.ID).Return(assignee, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, mock.Anything, assignee).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "too_many_items",
+			data:        &checklist.ItemData{NoteID: noteID, Text: "Book flights"},
+			maxItems:    2,
+			expectedErr: checklist.ErrTooManyItems,
+			mocker: func(m *checklistMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(newNote(), nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				m.repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Once()
+				m.repo.EXPECT().GetByNote(mock.Anything, noteID).Return(newItems(), nil).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			data:        &checklist.ItemData{NoteID: noteID, Text: "Book flights"},
+			maxItems:    10,
+			expectedErr: note.ErrOperationForbiddenForUser,
+			mocker: func(m *checklistMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(newNote(), nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:     "retried_on_version_conflict",
+			data:     &checklist.ItemData{NoteID: noteID, Text: "Book flights"},
+			maxItems: 10,
+			mocker: func(m *checklistMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(newNote(), nil).Twice()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Twice()
+				m.repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Twice()
+				m.repo.EXPECT().GetByNote(mock.Anything, noteID).Return(nil, nil).Twice()
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Twice()
+				m.notes.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldChecklist}).
+					Return(fmt.Errorf("save note fields: %w", note.ErrVersionConflict)).Once()
+				m.notes.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldChecklist}).
+					Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &checklistMocks{
+				repo:   mock_checklist.NewRepository(t),
+				notes:  mock_note.NewRepository(t),
+				users:  mock_user.NewRepository(t),
+				guard:  mock_note.NewGuarder(t),
+				audit:  mock_audit.NewRecorder(t),
+				events: mock_event.NewPublisher(t),
+			}
+			tc.mocker(m)
+			m.audit.EXPECT().Record(mock.Anything, mock.Anything).Return(nil).Maybe()
+			m.events.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			service := NewChecklistService(&ChecklistServiceDeps{
+				TxManager:     mock_tx.NewMockTxManager(),
+				ChecklistRepo: m.repo,
+				NoteRepo:      m.notes,
+				UserRepo:      m.users,
+				NoteGuard:     m.guard,
+				Audit:         m.audit,
+				Events:        m.events,
+				MaxItems:      tc.maxItems,
+			})
+
+			item, err := service.Add(context.Background(), u, tc.data)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.data.Text, item.Text)
+			require.Equal(t, noteID, item.NoteID)
+		})
+	}
+}
+
+func TestChecklistService_SetDone(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	noteID := uuid.Must(uuid.NewV7())
+	itemID := uuid.Must(uuid.NewV7())
+	doneAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	cases := []struct {
+		name         string
+		done         bool
+		item         *checklist.Item
+		expectedDone int
+		expectedOpen bool
+		expectedErr  error
+	}{
+		{
+			name:         "complete",
+			done:         true,
+			item:         &checklist.Item{ID: itemID, NoteID: noteID, Position: 1},
+			expectedDone: 2,
+		},
+		{
+			name: "reopen",
+			item: &checklist.Item{
+				ID:       itemID,
+				NoteID:   noteID,
+				Position: 1,
+				Done:     true,
+				DoneAt:   driver.ZeroTime(doneAt),
+			},
+			expectedDone: 1,
+			expectedOpen: true,
+		},
+		{
+			name: "already_done",
+			done: true,
+			item: &checklist.Item{
+				ID:       itemID,
+				NoteID:   noteID,
+				Position: 1,
+				Done:     true,
+				DoneAt:   driver.ZeroTime(doneAt),
+			},
+			expectedDone: 2,
+		},
+		{
+			name:        "item_not_found",
+			done:        true,
+			item:        &checklist.Item{ID: uuid.Must(uuid.NewV7()), NoteID: noteID, Position: 1},
+			expectedErr: checklist.ErrItemNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			items := []*checklist.Item{{ID: uuid.Must(uuid.NewV7()), NoteID: noteID, Done: true}, tc.item}
+			repo := mock_checklist.NewRepository(t)
+			notes := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+
+			notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(&note.Note{ID: noteID, UserId: u.ID}, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+			repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Once()
+			repo.EXPECT().GetByNote(mock.Anything, noteID).Return(items, nil).Once()
+			if tc.expectedErr == nil {
+				if tc.item.Done != tc.done {
+					repo.EXPECT().Save(mock.Anything, tc.item).Return(nil).Once()
+				}
+
+				notes.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldChecklist}).
+					RunAndReturn(func(_ context.Context, n *note.Note, _ []note.Field) error {
+						require.Equal(t, 2, n.ChecklistTotal)
+						require.Equal(t, tc.expectedDone, n.ChecklistDone)
+						require.Equal(t, tc.expectedOpen, n.HasOpenItems)
+						return nil
+					}).Once()
+			}
+
+			service := NewChecklistService(&ChecklistServiceDeps{
+				TxManager:     mock_tx.NewMockTxManager(),
+				ChecklistRepo: repo,
+				NoteRepo:      notes,
+				NoteGuard:     guard,
+				Audit:         newAuditRecorder(t),
+				Events:        newEventPublisher(t),
+			})
+
+			item, err := service.SetDone(context.Background(), u, noteID, itemID, tc.done)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.done, item.Done)
+			require.Equal(t, tc.done, !time.Time(item.DoneAt).IsZero())
+			if tc.name == "already_done" {
+				require.Equal(t, doneAt, time.Time(item.DoneAt))
+			}
+		})
+	}
+}
+
+func TestChecklistService_Reorder(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	noteID := uuid.Must(uuid.NewV7())
+	ids := []uuid.UUID{uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())}
+
+	cases := []struct {
+		name        string
+		order       []uuid.UUID
+		saved       int
+		expectedErr error
+	}{
+		{
+			name:  "successful_reorder",
+			order: []uuid.UUID{ids[2], ids[0], ids[1]},
+			saved: 3,
+		},
+		{
+			name:  "swap",
+			order: []uuid.UUID{ids[0], ids[2], ids[1]},
+			saved: 2,
+		},
+		{
+			name:        "missing_item",
+			order:       []uuid.UUID{ids[2], ids[0]},
+			expectedErr: checklist.ErrInvalidOrder,
+		},
+		{
+			name:        "duplicate_item",
+			order:       []uuid.UUID{ids[2], ids[0], ids[2]},
+			expectedErr: checklist.ErrInvalidOrder,
+		},
+		{
+			name:        "unknown_item",
+			order:       []uuid.UUID{ids[2], ids[0], uuid.Must(uuid.NewV7())},
+			expectedErr: checklist.ErrInvalidOrder,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			items := make([]*checklist.Item, len(ids))
+			for i, id := range ids {
+				items[i] = &checklist.Item{ID: id, NoteID: noteID, Position: i}
+			}
+
+			repo := mock_checklist.NewRepository(t)
+			notes := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+
+			notes.EXPECT().GetByID(mock.Anything, u, noteID).Return(&note.Note{ID: noteID, UserId: u.ID}, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+			repo.EXPECT().Lock(mock.Anything, noteID).Return(nil).Once()
+			repo.EXPECT().GetByNote(mock.Anything, noteID).Return(items, nil).Once()
+			if tc.expectedErr == nil {
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Times(tc.saved)
+				notes.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldChecklist}).
+					Return(nil).Once()
+			}
+
+			service := NewChecklistService(&ChecklistServiceDeps{
+				TxManager:     mock_tx.NewMockTxManager(),
+				ChecklistRepo: repo,
+				NoteRepo:      notes,
+				NoteGuard:     guard,
+				Audit:         newAuditRecorder(t),
+				Events:        newEventPublisher(t),
+			})
+
+			res, err := service.Reorder(context.Background(), u, noteID, tc.order)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for i, item := range res {
+				require.Equal(t, tc.order[i], item.ID)
+				require.Equal(t, i, item.Position)
+			}
+		})
+	}
+}
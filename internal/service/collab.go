@@ -0,0 +1,867 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/collab"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/crdt"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+// collabRestSite is the site of the elements inserted to merge the note text changed outside the collaboration.
+const collabRestSite = "rest"
+
+// CollabServiceDeps represents the dependencies required to construct a collaboration service.
+type CollabServiceDeps struct {
+	TxManager       tx.Manager
+	NoteRepo        note.Repository
+	NoteGuard       note.Guarder
+	CollabRepo      collab.Repository
+	Events          event.Publisher
+	Notifier        collab.Notifier
+	Listener        collab.Listener
+	CompactInterval time.Duration
+	MaxTextLength   int
+	BufferSize      int
+	RetryDelay      time.Duration
+}
+
+// collabService is a struct that implements the collab.Service interface.
+// The editors of a note connected to the instance share a room holding the document of the note.
+type collabService struct {
+	tx              tx.Manager
+	noteRepo        note.Repository
+	guard           note.Guarder
+	collabRepo      collab.Repository
+	events          event.Publisher
+	notifier        collab.Notifier
+	listener        collab.Listener
+	compactInterval time.Duration
+	maxTextLength   int
+	bufferSize      int
+	retryDelay      time.Duration
+	instance        uuid.UUID
+
+	mu      sync.Mutex
+	rooms   map[uuid.UUID]*collabRoom
+	closed  bool
+	onError func(error)
+}
+
+// collabRoom holds the document of the note and its editors. Remote peers are editors of other instances.
+type collabRoom struct {
+	noteID   uuid.UUID
+	mu       sync.Mutex
+	doc      *crdt.Doc
+	sessions map[uuid.UUID]*collabSession
+	remote   map[uuid.UUID]*collab.Peer
+	dirty    bool
+}
+
+// collabSession is a struct that implements the collab.Session interface.
+type collabSession struct {
+	service *collabService
+	room    *collabRoom
+	peer    *collab.Peer
+	site    string
+	out     chan *collab.ServerMessage
+	closed  bool
+	once    sync.Once
+}
+
+// NewCollabService initializes and returns a new implementation of the collab.Service interface.
+func NewCollabService(deps *CollabServiceDeps) collab.Service {
+	return &collabService{
+		tx:              deps.TxManager,
+		noteRepo:        deps.NoteRepo,
+		guard:           deps.NoteGuard,
+		collabRepo:      deps.CollabRepo,
+		events:          deps.Events,
+		notifier:        deps.Notifier,
+		listener:        deps.Listener,
+		compactInterval: deps.CompactInterval,
+		maxTextLength:   deps.MaxTextLength,
+		bufferSize:      deps.BufferSize,
+		retryDelay:      deps.RetryDelay,
+		instance:        uuid.New(),
+		rooms:           make(map[uuid.UUID]*collabRoom),
+		onError:         func(error) {},
+	}
+}
+
+// Join connects the user to the note. Users who may read the note join as viewers,
+// users who may update it join as editors. The first message of the session carries the document.
+func (s *collabService) Join(ctx context.Context, u *user.User, noteID uuid.UUID) (collab.Session, error) {
+	n, err := s.noteRepo.GetByID(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("join collab: %w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, noteID)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, n, u)
+	if err != nil {
+		return nil, fmt.Errorf("join collab: check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf("join collab: %w (user %s, note %s)", note.ErrOperationForbiddenForUser, u.ID, noteID)
+	}
+
+	canEdit, err := s.guard.IsGranted(ctx, rbac.UPDATE, n, u)
+	if err != nil {
+		return nil, fmt.Errorf("join collab: check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	sessionID := uuid.New()
+	sess := &collabSession{
+		service: s,
+		peer:    &collab.Peer{SessionID: sessionID, UserID: u.ID, Name: u.Name, CanEdit: canEdit},
+		site:    sessionID.String(),
+		out:     make(chan *collab.ServerMessage, s.bufferSize),
+	}
+
+	created, err := s.enter(ctx, n, sess)
+	if err != nil {
+		return nil, fmt.Errorf("join collab: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if created {
+		// updates saved by other instances before the room was registered are not notified to it
+		if err := s.refresh(ctx, sess.room); err != nil {
+			sess.Close()
+			return nil, fmt.Errorf("join collab: %w (user %s, note %s)", err, u.ID, noteID)
+		}
+
+		s.notify(ctx, &collab.Notice{Type: collab.MessageSync, NoteID: noteID})
+	}
+
+	s.notify(ctx, &collab.Notice{Type: collab.MessagePresence, NoteID: noteID, Peer: sess.peer})
+	return sess, nil
+}
+
+// Run listens to the notices of other instances and compacts the documents of the rooms with the compaction
+// interval until the context is done. Rooms without editors are removed after the compaction.
+// Errors are reported to onError.
+func (s *collabService) Run(ctx context.Context, onError func(error)) {
+	s.mu.Lock()
+	s.onError = onError
+	s.mu.Unlock()
+	defer s.close()
+
+	listening := make(chan struct{})
+	go func() {
+		defer close(listening)
+		s.listen(ctx)
+	}()
+
+	ticker := time.NewTicker(s.compactInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			<-listening
+			s.compactAll(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			s.compactAll(ctx)
+		}
+	}
+}
+
+// Messages returns the channel of messages for the editor.
+func (sess *collabSession) Messages() <-chan *collab.ServerMessage {
+	return sess.out
+}
+
+// Handle applies the update or the presence of the editor and shares it with the other editors.
+func (sess *collabSession) Handle(ctx context.Context, msg *collab.ClientMessage) error {
+	switch msg.Type {
+	case collab.MessageUpdate:
+		return sess.update(ctx, msg.Ops)
+	case collab.MessagePresence:
+		return sess.presence(ctx, msg.Cursor)
+	}
+
+	return fmt.Errorf("handle collab message: %w: unknown type %q", collab.ErrInvalidMessage, msg.Type)
+}
+
+// Close disconnects the editor, it is safe to call Close several times.
+func (sess *collabSession) Close() {
+	sess.once.Do(func() {
+		s := sess.service
+		r := sess.room
+
+		r.mu.Lock()
+		delete(r.sessions, sess.peer.SessionID)
+		sess.stop()
+		r.broadcast(&collab.ServerMessage{Type: collab.MessageLeave, SessionID: sess.peer.SessionID}, nil)
+		r.mu.Unlock()
+
+		s.notify(context.Background(), &collab.Notice{
+			Type:      collab.MessageLeave,
+			NoteID:    r.noteID,
+			SessionID: sess.peer.SessionID,
+		})
+	})
+}
+
+// update applies the operations of the editor, saves the applied ones and shares them with the other editors.
+func (sess *collabSession) update(ctx context.Context, ops []crdt.Op) error {
+	if !sess.peer.CanEdit {
+		return fmt.Errorf("update collab: %w (session %s)", collab.ErrReadOnly, sess.peer.SessionID)
+	}
+
+	for _, op := range ops {
+		if err := op.Validate(); err != nil {
+			return fmt.Errorf("update collab: %w: %w (session %s)", collab.ErrInvalidMessage, err, sess.peer.SessionID)
+		}
+
+		if op.Kind == crdt.OpInsert && op.ID.Site != sess.site {
+			return fmt.Errorf(
+				"update collab: %w: elements must be inserted with site %s (session %s)",
+				collab.ErrInvalidMessage,
+				sess.site,
+				sess.peer.SessionID,
+			)
+		}
+	}
+
+	r := sess.room
+	r.mu.Lock()
+	if sess.closed {
+		r.mu.Unlock()
+		return fmt.Errorf("update collab: %w (session %s)", collab.ErrClosed, sess.peer.SessionID)
+	}
+
+	inserted := 0
+	for _, op := range ops {
+		if op.Kind == crdt.OpInsert && !r.doc.Has(op.ID) {
+			inserted++
+		}
+	}
+
+	if r.doc.Len()+inserted > sess.service.maxTextLength {
+		r.mu.Unlock()
+		return fmt.Errorf("update collab: %w (session %s)", collab.ErrTextTooLong, sess.peer.SessionID)
+	}
+
+	applied := r.doc.Apply(ops...)
+	if len(applied) > 0 {
+		r.dirty = true
+		r.broadcast(&collab.ServerMessage{Type: collab.MessageUpdate, Ops: applied}, sess)
+	}
+	r.mu.Unlock()
+
+	if len(applied) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(applied)
+	if err != nil {
+		return fmt.Errorf("update collab: encode ops: %w (session %s)", err, sess.peer.SessionID)
+	}
+
+	upd := &collab.Update{NoteID: r.noteID, Ops: payload, CreatedAt: time.Now()}
+	if err := sess.service.collabRepo.SaveUpdate(ctx, upd); err != nil {
+		return fmt.Errorf("update collab: %w (session %s)", err, sess.peer.SessionID)
+	}
+
+	sess.service.notify(ctx, &collab.Notice{Type: collab.MessageUpdate, NoteID: r.noteID, UpdateID: upd.ID})
+	return nil
+}
+
+// presence updates the cursor of the editor and shares it with the other editors.
+func (sess *collabSession) presence(ctx context.Context, cursor *collab.Cursor) error {
+	if cursor == nil {
+		return fmt.Errorf("update collab presence: %w: cursor is required", collab.ErrInvalidMessage)
+	}
+
+	r := sess.room
+	r.mu.Lock()
+	peer := *sess.peer
+	peer.Cursor = cursor
+	sess.peer = &peer
+	r.broadcast(&collab.ServerMessage{Type: collab.MessagePresence, Peer: &peer}, sess)
+	r.mu.Unlock()
+
+	sess.service.notify(ctx, &collab.Notice{Type: collab.MessagePresence, NoteID: r.noteID, Peer: &peer})
+	return nil
+}
+
+// stop closes the messages of the session, the caller must hold the room lock.
+func (sess *collabSession) stop() {
+	if !sess.closed {
+		sess.closed = true
+		close(sess.out)
+	}
+}
+
+// enter adds the session to the room of the note, loading the room when the note has none.
+// It reports whether the room was created.
+func (s *collabService) enter(ctx context.Context, n *note.Note, sess *collabSession) (bool, error) {
+	s.mu.Lock()
+	_, ok := s.rooms[n.ID]
+	s.mu.Unlock()
+
+	var loaded *collabRoom
+	if !ok {
+		var err error
+		if loaded, err = s.load(ctx, n); err != nil {
+			return false, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false, collab.ErrClosed
+	}
+
+	r, ok := s.rooms[n.ID]
+	if !ok {
+		if loaded == nil {
+			// the room was removed meanwhile, the caller joins again
+			return false, fmt.Errorf("%w: room is removed", collab.ErrClosed)
+		}
+
+		r = loaded
+		s.rooms[n.ID] = r
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	peers := make([]*collab.Peer, 0, len(r.sessions)+len(r.remote))
+	for _, other := range r.sessions {
+		peers = append(peers, other.peer)
+	}
+
+	for _, peer := range r.remote {
+		peers = append(peers, peer)
+	}
+
+	sess.room = r
+	sess.out <- &collab.ServerMessage{
+		Type:      collab.MessageInit,
+		SessionID: sess.peer.SessionID,
+		Site:      sess.site,
+		CanEdit:   sess.peer.CanEdit,
+		Elements:  r.doc.Elements(),
+		Peers:     peers,
+	}
+
+	r.broadcast(&collab.ServerMessage{Type: collab.MessagePresence, Peer: sess.peer}, nil)
+	r.sessions[sess.peer.SessionID] = sess
+	return !ok, nil
+}
+
+// load builds the room of the note from its state and updates. The state of a note edited for the first time
+// is created from its text, so all instances start from the same elements.
+func (s *collabService) load(ctx context.Context, n *note.Note) (*collabRoom, error) {
+	r := &collabRoom{
+		noteID:   n.ID,
+		doc:      crdt.New(),
+		sessions: make(map[uuid.UUID]*collabSession),
+		remote:   make(map[uuid.UUID]*collab.Peer),
+	}
+
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.collabRepo.Lock(ctx); err != nil {
+			return err
+		}
+
+		state, err := s.collabRepo.GetState(ctx, n.ID)
+		if err != nil && !errors.Is(err, collab.ErrStateNotFound) {
+			return err
+		}
+
+		if state == nil {
+			r.doc = crdt.FromText("", n.Text)
+			return s.saveState(ctx, n.ID, r.doc)
+		}
+
+		_, err = s.mergeStored(ctx, r, state)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load collab room: %w", err)
+	}
+
+	return r, nil
+}
+
+// refresh merges the updates saved by other instances into the document of the room.
+func (s *collabService) refresh(ctx context.Context, r *collabRoom) error {
+	updates, err := s.collabRepo.GetUpdates(ctx, r.noteID)
+	if err != nil {
+		return fmt.Errorf("refresh collab room: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied, err := r.applyUpdates(updates)
+	if err != nil {
+		return fmt.Errorf("refresh collab room: %w", err)
+	}
+
+	r.broadcast(&collab.ServerMessage{Type: collab.MessageUpdate, Ops: applied}, nil)
+	return nil
+}
+
+// compactAll compacts the rooms, closing the rooms of deleted notes and removing the rooms without editors.
+func (s *collabService) compactAll(ctx context.Context) {
+	s.mu.Lock()
+	rooms := make([]*collabRoom, 0, len(s.rooms))
+	for _, r := range s.rooms {
+		rooms = append(rooms, r)
+	}
+	s.mu.Unlock()
+
+	for _, r := range rooms {
+		err := s.compact(ctx, r)
+		if err != nil && !errors.Is(err, note.ErrNotFound) {
+			s.report(err)
+			continue
+		}
+
+		s.mu.Lock()
+		r.mu.Lock()
+		if err != nil || len(r.sessions) == 0 {
+			for _, sess := range r.sessions {
+				sess.stop()
+			}
+
+			delete(s.rooms, r.noteID)
+		}
+		r.mu.Unlock()
+		s.mu.Unlock()
+	}
+}
+
+// compact folds the stored state and updates into the document of the room and saves it as the state and the text
+// of the note. Text changed outside the collaboration since the last compaction is merged into the document.
+func (s *collabService) compact(ctx context.Context, r *collabRoom) error {
+	var rebased uuid.NullUUID
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.collabRepo.Lock(ctx); err != nil {
+			return err
+		}
+
+		n, err := s.collabRepo.GetNote(ctx, r.noteID)
+		if err != nil {
+			return err
+		}
+
+		state, err := s.collabRepo.GetState(ctx, r.noteID)
+		if err != nil && !errors.Is(err, collab.ErrStateNotFound) {
+			return err
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		updateIDs, err := s.mergeStored(ctx, r, state)
+		if err != nil {
+			return err
+		}
+
+		var stored *crdt.Doc
+		if state != nil {
+			if stored, err = decodeCollabState(state); err != nil {
+				return err
+			}
+		}
+
+		if stored != nil && stored.Text() != n.Text {
+			ops := rebaseCollabText(stored, n.Text, r.doc.Clock())
+			if rebased, err = s.saveRebase(ctx, r, ops); err != nil {
+				return err
+			}
+		}
+
+		if !r.dirty && len(updateIDs) == 0 && !rebased.Valid {
+			return nil
+		}
+
+		if err := s.saveState(ctx, r.noteID, r.doc); err != nil {
+			return err
+		}
+
+		if err := s.collabRepo.DeleteUpdates(ctx, updateIDs); err != nil {
+			return err
+		}
+
+		r.dirty = false
+		if text := r.doc.Text(); text != n.Text {
+			n.Text = text
+			n.Version++
+			n.UpdatedAt = driver.ZeroTime(time.Now())
+			if err := setPlainText(n); err != nil {
+				return err
+			}
+
+			if err := s.noteRepo.SaveFields(ctx, n, []note.Field{note.FieldText}); err != nil {
+				return err
+			}
+
+			e, err := event.NewNoteEvent(event.TypeNoteUpdated, n)
+			if err != nil {
+				return err
+			}
+
+			return s.events.Publish(ctx, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("compact collab: %w (note %s)", err, r.noteID)
+	}
+
+	if rebased.Valid {
+		s.notify(ctx, &collab.Notice{Type: collab.MessageUpdate, NoteID: r.noteID, UpdateID: rebased.UUID})
+	}
+
+	return nil
+}
+
+// mergeStored merges the state and the updates of the note into the document of the room and shares the new
+// operations with the editors. It returns the identifiers of the merged updates, the caller must hold the room lock.
+func (s *collabService) mergeStored(ctx context.Context, r *collabRoom, state *collab.State) ([]uuid.UUID, error) {
+	var applied []crdt.Op
+	if state != nil {
+		var elems []crdt.Element
+		if err := json.Unmarshal(state.Elements, &elems); err != nil {
+			return nil, fmt.Errorf("decode collab state: %w", err)
+		}
+
+		before := r.doc.Elements()
+		if err := r.doc.Merge(elems); err != nil {
+			return nil, fmt.Errorf("merge collab state: %w", err)
+		}
+
+		applied = collabStateDiff(before, r.doc)
+	}
+
+	updates, err := s.collabRepo.GetUpdates(ctx, r.noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := r.applyUpdates(updates)
+	if err != nil {
+		return nil, err
+	}
+
+	r.broadcast(&collab.ServerMessage{Type: collab.MessageUpdate, Ops: append(applied, ops...)}, nil)
+
+	ids := make([]uuid.UUID, len(updates))
+	for i, upd := range updates {
+		ids[i] = upd.ID
+	}
+
+	return ids, nil
+}
+
+// saveRebase applies the operations merging the text changed outside the collaboration, and saves them as an update
+// for the rooms of other instances. It returns the saved update, if any. The caller must hold the room lock.
+func (s *collabService) saveRebase(ctx context.Context, r *collabRoom, ops []crdt.Op) (uuid.NullUUID, error) {
+	applied := r.doc.Apply(ops...)
+	if len(applied) == 0 {
+		return uuid.NullUUID{}, nil
+	}
+
+	payload, err := json.Marshal(applied)
+	if err != nil {
+		return uuid.NullUUID{}, fmt.Errorf("encode collab ops: %w", err)
+	}
+
+	upd := &collab.Update{NoteID: r.noteID, Ops: payload, CreatedAt: time.Now()}
+	if err := s.collabRepo.SaveUpdate(ctx, upd); err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	r.dirty = true
+	r.broadcast(&collab.ServerMessage{Type: collab.MessageUpdate, Ops: applied}, nil)
+	return uuid.NullUUID{UUID: upd.ID, Valid: true}, nil
+}
+
+// saveState saves the document as the state of the note.
+func (s *collabService) saveState(ctx context.Context, noteID uuid.UUID, doc *crdt.Doc) error {
+	elems, err := json.Marshal(doc.Elements())
+	if err != nil {
+		return fmt.Errorf("encode collab state: %w", err)
+	}
+
+	return s.collabRepo.SaveState(ctx, &collab.State{NoteID: noteID, Elements: elems, UpdatedAt: time.Now()})
+}
+
+// listen passes the notices of other instances to the rooms until the context is done, listening again after
+// the retry delay on failures. Notices sent meanwhile are lost, so the rooms are refreshed from the stored updates.
+func (s *collabService) listen(ctx context.Context) {
+	handle := func(payload []byte) {
+		if err := s.receive(ctx, payload); err != nil {
+			s.report(err)
+		}
+	}
+
+	for {
+		err := s.listener.Listen(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.report(fmt.Errorf("listen collab: %w", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.retryDelay):
+		}
+
+		s.mu.Lock()
+		rooms := make([]*collabRoom, 0, len(s.rooms))
+		for _, r := range s.rooms {
+			rooms = append(rooms, r)
+		}
+		s.mu.Unlock()
+
+		for _, r := range rooms {
+			if err := s.refresh(ctx, r); err != nil {
+				s.report(err)
+			}
+		}
+	}
+}
+
+// receive applies the notice of another instance to the room of the note.
+func (s *collabService) receive(ctx context.Context, payload []byte) error {
+	var notice collab.Notice
+	if err := json.Unmarshal(payload, &notice); err != nil {
+		return fmt.Errorf("receive collab notice: decode: %w", err)
+	}
+
+	if notice.Origin == s.instance {
+		return nil
+	}
+
+	s.mu.Lock()
+	r, ok := s.rooms[notice.NoteID]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	switch notice.Type {
+	case collab.MessageUpdate:
+		upd, err := s.collabRepo.GetUpdate(ctx, notice.UpdateID)
+		if err != nil {
+			// compacted updates are merged from the state by the next compaction
+			if errors.Is(err, collab.ErrUpdateNotFound) {
+				return nil
+			}
+
+			return fmt.Errorf("receive collab notice: %w (note %s)", err, notice.NoteID)
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		applied, err := r.applyUpdates([]*collab.Update{upd})
+		if err != nil {
+			return fmt.Errorf("receive collab notice: %w (note %s)", err, notice.NoteID)
+		}
+
+		r.broadcast(&collab.ServerMessage{Type: collab.MessageUpdate, Ops: applied}, nil)
+	case collab.MessagePresence:
+		if notice.Peer == nil {
+			return nil
+		}
+
+		r.mu.Lock()
+		r.remote[notice.Peer.SessionID] = notice.Peer
+		r.broadcast(&collab.ServerMessage{Type: collab.MessagePresence, Peer: notice.Peer}, nil)
+		r.mu.Unlock()
+	case collab.MessageLeave:
+		r.mu.Lock()
+		delete(r.remote, notice.SessionID)
+		r.broadcast(&collab.ServerMessage{Type: collab.MessageLeave, SessionID: notice.SessionID}, nil)
+		r.mu.Unlock()
+	case collab.MessageSync:
+		r.mu.Lock()
+		peers := make([]*collab.Peer, 0, len(r.sessions))
+		for _, sess := range r.sessions {
+			peers = append(peers, sess.peer)
+		}
+		r.mu.Unlock()
+
+		for _, peer := range peers {
+			s.notify(ctx, &collab.Notice{Type: collab.MessagePresence, NoteID: notice.NoteID, Peer: peer})
+		}
+	}
+
+	return nil
+}
+
+// notify sends the notice to other instances. Failures are reported, the updates reach other instances
+// with the next compaction anyway.
+func (s *collabService) notify(ctx context.Context, notice *collab.Notice) {
+	notice.Origin = s.instance
+	payload, err := json.Marshal(notice)
+	if err == nil {
+		err = s.notifier.Notify(ctx, payload)
+	}
+
+	if err != nil {
+		s.report(fmt.Errorf("notify collab: %w (note %s)", err, notice.NoteID))
+	}
+}
+
+// report passes the error to the error handler of Run.
+func (s *collabService) report(err error) {
+	s.mu.Lock()
+	onError := s.onError
+	s.mu.Unlock()
+
+	onError(err)
+}
+
+// close ends the sessions and rejects new ones.
+func (s *collabService) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for id, r := range s.rooms {
+		r.mu.Lock()
+		for _, sess := range r.sessions {
+			sess.stop()
+		}
+		r.mu.Unlock()
+
+		delete(s.rooms, id)
+	}
+}
+
+// applyUpdates applies the operations of the updates to the document, the caller must hold the room lock.
+func (r *collabRoom) applyUpdates(updates []*collab.Update) ([]crdt.Op, error) {
+	var applied []crdt.Op
+	for _, upd := range updates {
+		var ops []crdt.Op
+		if err := json.Unmarshal(upd.Ops, &ops); err != nil {
+			return nil, fmt.Errorf("decode collab update %s: %w", upd.ID, err)
+		}
+
+		applied = append(applied, r.doc.Apply(ops...)...)
+	}
+
+	return applied, nil
+}
+
+// broadcast queues the message to the sessions of the room except the given one, the caller must hold the room lock.
+// Sessions which have fallen behind are stopped instead of blocking the room, their editors join again.
+func (r *collabRoom) broadcast(msg *collab.ServerMessage, except *collabSession) {
+	if msg.Type == collab.MessageUpdate && len(msg.Ops) == 0 {
+		return
+	}
+
+	for _, sess := range r.sessions {
+		if sess == except || sess.closed {
+			continue
+		}
+
+		select {
+		case sess.out <- msg:
+		default:
+			sess.stop()
+		}
+	}
+}
+
+// decodeCollabState returns the document of the state.
+func decodeCollabState(state *collab.State) (*crdt.Doc, error) {
+	var elems []crdt.Element
+	if err := json.Unmarshal(state.Elements, &elems); err != nil {
+		return nil, fmt.Errorf("decode collab state: %w", err)
+	}
+
+	return crdt.FromElements(elems)
+}
+
+// collabStateDiff returns the operations turning the elements into the elements of the document.
+func collabStateDiff(before []crdt.Element, doc *crdt.Doc) []crdt.Op {
+	known := make(map[crdt.ID]bool, len(before))
+	for _, e := range before {
+		known[e.ID] = e.Deleted
+	}
+
+	var ops []crdt.Op
+	for _, e := range doc.Elements() {
+		deleted, ok := known[e.ID]
+		if !ok {
+			ops = append(ops, crdt.Op{Kind: crdt.OpInsert, ID: e.ID, Origin: e.Origin, Value: e.Value})
+		}
+
+		if e.Deleted && !deleted {
+			ops = append(ops, crdt.Op{Kind: crdt.OpDelete, ID: e.ID})
+		}
+	}
+
+	return ops
+}
+
+// rebaseCollabText returns the operations changing the text of the stored document into the text.
+// The changed range is found by the common prefix and suffix, elements are inserted with clocks above the clock.
+func rebaseCollabText(stored *crdt.Doc, text string, clock uint64) []crdt.Op {
+	var visible []crdt.Element
+	for _, e := range stored.Elements() {
+		if !e.Deleted {
+			visible = append(visible, e)
+		}
+	}
+
+	runes := []rune(text)
+	prefix := 0
+	for prefix < len(visible) && prefix < len(runes) && visible[prefix].Value == string(runes[prefix]) {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(visible)-prefix && suffix < len(runes)-prefix &&
+		visible[len(visible)-1-suffix].Value == string(runes[len(runes)-1-suffix]) {
+		suffix++
+	}
+
+	var ops []crdt.Op
+	for _, e := range visible[prefix : len(visible)-suffix] {
+		ops = append(ops, crdt.Op{Kind: crdt.OpDelete, ID: e.ID})
+	}
+
+	origin := crdt.ID{}
+	if prefix > 0 {
+		origin = visible[prefix-1].ID
+	}
+
+	clock = max(clock, stored.Clock())
+	for _, r := range runes[prefix : len(runes)-suffix] {
+		clock++
+		id := crdt.ID{Clock: clock, Site: collabRestSite}
+		ops = append(ops, crdt.Op{Kind: crdt.OpInsert, ID: id, Origin: origin, Value: string(r)})
+		origin = id
+	}
+
+	return ops
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/collab"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_collab"
+	"github.com/xsqrty/notes/mocks/domain/mock_event"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/pkg/crdt"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+type collabMocks struct {
+	noteRepo   *mock_note.Repository
+	guard      *mock_note.Guarder
+	collabRepo *mock_collab.Repository
+	events     *mock_event.Publisher
+	notifier   *mock_collab.Notifier
+}
+
+func newCollabTestService(t *testing.T, n *note.Note, editors ...uuid.UUID) (*collabService, *collabMocks) {
+	t.Helper()
+
+	m := &collabMocks{
+		noteRepo:   mock_note.NewRepository(t),
+		guard:      mock_note.NewGuarder(t),
+		collabRepo: mock_collab.NewRepository(t),
+		events:     mock_event.NewPublisher(t),
+		notifier:   mock_collab.NewNotifier(t),
+	}
+
+	m.noteRepo.EXPECT().GetByID(mock.Anything, mock.Anything, n.ID).Return(n, nil).Maybe()
+	m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, mock.Anything).Return(true, nil).Maybe()
+	m.guard.EXPECT().
+		IsGranted(mock.Anything, rbac.UPDATE, n, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ rbac.Operation, _ *note.Note, u *user.User) (bool, error) {
+			for _, id := range editors {
+				if id == u.ID {
+					return true, nil
+				}
+			}
+
+			return false, nil
+		}).
+		Maybe()
+	m.collabRepo.EXPECT().Lock(mock.Anything).Return(nil).Maybe()
+	m.notifier.EXPECT().Notify(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	s := NewCollabService(&CollabServiceDeps{
+		TxManager:     mock_tx.NewMockTxManager(),
+		NoteRepo:      m.noteRepo,
+		NoteGuard:     m.guard,
+		CollabRepo:    m.collabRepo,
+		Events:        m.events,
+		Notifier:      m.notifier,
+		MaxTextLength: 20,
+		BufferSize:    10,
+	}).(*collabService)
+
+	return s, m
+}
+
+func insertOps(init *collab.ServerMessage, origin crdt.ID, text string) []crdt.Op {
+	var clock uint64
+	for _, e := range init.Elements {
+		clock = max(clock, e.ID.Clock)
+	}
+
+	ops := make([]crdt.Op, 0, len(text))
+	for _, r := range text {
+		clock++
+		id := crdt.ID{Clock: clock, Site: init.Site}
+		ops = append(ops, crdt.Op{Kind: crdt.OpInsert, ID: id, Origin: origin, Value: string(r)})
+		origin = id
+	}
+
+	return ops
+}
+
+func collabTestDoc(t *testing.T, elems []crdt.Element) *crdt.Doc {
+	t.Helper()
+
+	doc, err := crdt.FromElements(elems)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestCollabService_Update(t *testing.T) {
+	t.Parallel()
+
+	editor := &user.User{ID: uuid.New(), Name: "editor"}
+	viewer := &user.User{ID: uuid.New(), Name: "viewer"}
+	n := &note.Note{ID: uuid.New(), UserId: editor.ID, Text: "hello"}
+
+	s, m := newCollabTestService(t, n, editor.ID)
+	m.collabRepo.EXPECT().GetState(mock.Anything, n.ID).Return(nil, collab.ErrStateNotFound).Once()
+	m.collabRepo.EXPECT().SaveState(mock.Anything, mock.AnythingOfType("*collab.State")).Return(nil).Once()
+	m.collabRepo.EXPECT().GetUpdates(mock.Anything, n.ID).Return(nil, nil).Once()
+
+	var saved *collab.Update
+	m.collabRepo.EXPECT().
+		SaveUpdate(mock.Anything, mock.AnythingOfType("*collab.Update")).
+		RunAndReturn(func(_ context.Context, upd *collab.Update) error {
+			upd.ID = uuid.New()
+			saved = upd
+			return nil
+		}).
+		Once()
+
+	editorSess, err := s.Join(context.Background(), editor, n.ID)
+	require.NoError(t, err)
+	defer editorSess.Close()
+
+	viewerSess, err := s.Join(context.Background(), viewer, n.ID)
+	require.NoError(t, err)
+	defer viewerSess.Close()
+
+	init := <-editorSess.Messages()
+	require.Equal(t, collab.MessageInit, init.Type)
+	require.True(t, init.CanEdit)
+	require.Equal(t, "hello", collabTestDoc(t, init.Elements).Text())
+
+	viewerInit := <-viewerSess.Messages()
+	require.False(t, viewerInit.CanEdit)
+	require.Len(t, viewerInit.Peers, 1)
+	require.Equal(t, editor.ID, viewerInit.Peers[0].UserID)
+
+	presence := <-editorSess.Messages()
+	require.Equal(t, collab.MessagePresence, presence.Type)
+	require.Equal(t, viewer.ID, presence.Peer.UserID)
+
+	last := init.Elements[len(init.Elements)-1].ID
+	err = viewerSess.Handle(context.Background(), &collab.ClientMessage{
+		Type: collab.MessageUpdate,
+		Ops:  insertOps(viewerInit, last, "!"),
+	})
+	require.ErrorIs(t, err, collab.ErrReadOnly)
+
+	err = editorSess.Handle(context.Background(), &collab.ClientMessage{
+		Type: collab.MessageUpdate,
+		Ops:  insertOps(viewerInit, last, "!"),
+	})
+	require.ErrorIs(t, err, collab.ErrInvalidMessage)
+
+	err = editorSess.Handle(context.Background(), &collab.ClientMessage{
+		Type: collab.MessageUpdate,
+		Ops:  insertOps(init, last, " world, too long text"),
+	})
+	require.ErrorIs(t, err, collab.ErrTextTooLong)
+
+	ops := insertOps(init, last, " world")
+	err = editorSess.Handle(context.Background(), &collab.ClientMessage{Type: collab.MessageUpdate, Ops: ops})
+	require.NoError(t, err)
+
+	update := <-viewerSess.Messages()
+	require.Equal(t, collab.MessageUpdate, update.Type)
+	require.Equal(t, ops, update.Ops)
+
+	doc := collabTestDoc(t, viewerInit.Elements)
+	doc.Apply(update.Ops...)
+	require.Equal(t, "hello world", doc.Text())
+
+	require.NotNil(t, saved)
+	var savedOps []crdt.Op
+	require.NoError(t, json.Unmarshal(saved.Ops, &savedOps))
+	require.Equal(t, ops, savedOps)
+}
+
+func TestCollabService_Compact(t *testing.T) {
+	t.Parallel()
+
+	editor := &user.User{ID: uuid.New()}
+	n := &note.Note{ID: uuid.New(), UserId: editor.ID, Text: "hello"}
+
+	cases := []struct {
+		name     string
+		restText string
+		expected string
+	}{
+		{
+			name:     "collab_update",
+			restText: "hello",
+			expected: "hello world",
+		},
+		{
+			name:     "rest_update_merged",
+			restText: "hi hello",
+			expected: "hi hello world",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, m := newCollabTestService(t, n, editor.ID)
+
+			text := tc.restText
+			var state *collab.State
+			var updates []*collab.Update
+			m.collabRepo.EXPECT().
+				GetState(mock.Anything, n.ID).
+				RunAndReturn(func(context.Context, uuid.UUID) (*collab.State, error) {
+					if state == nil {
+						return nil, collab.ErrStateNotFound
+					}
+
+					return state, nil
+				})
+			m.collabRepo.EXPECT().
+				SaveState(mock.Anything, mock.AnythingOfType("*collab.State")).
+				RunAndReturn(func(_ context.Context, s *collab.State) error {
+					state = s
+					return nil
+				})
+			m.collabRepo.EXPECT().
+				GetUpdates(mock.Anything, n.ID).
+				RunAndReturn(func(context.Context, uuid.UUID) ([]*collab.Update, error) {
+					return updates, nil
+				})
+			m.collabRepo.EXPECT().
+				SaveUpdate(mock.Anything, mock.AnythingOfType("*collab.Update")).
+				RunAndReturn(func(_ context.Context, upd *collab.Update) error {
+					upd.ID = uuid.New()
+					updates = append(updates, upd)
+					return nil
+				})
+			m.collabRepo.EXPECT().
+				DeleteUpdates(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, ids []uuid.UUID) error {
+					updates = slices.DeleteFunc(updates, func(upd *collab.Update) bool {
+						return slices.Contains(ids, upd.ID)
+					})
+					return nil
+				})
+			m.collabRepo.EXPECT().
+				GetNote(mock.Anything, n.ID).
+				RunAndReturn(func(context.Context, uuid.UUID) (*note.Note, error) {
+					return &note.Note{ID: n.ID, UserId: n.UserId, Text: text}, nil
+				})
+			m.noteRepo.EXPECT().
+				SaveFields(mock.Anything, mock.AnythingOfType("*note.Note"), []note.Field{note.FieldText}).
+				RunAndReturn(func(_ context.Context, saved *note.Note, _ []note.Field) error {
+					require.Equal(t, tc.expected, saved.Text)
+					require.False(t, time.Time(saved.UpdatedAt).IsZero())
+					text = saved.Text
+					return nil
+				}).
+				Once()
+			m.events.EXPECT().
+				Publish(mock.Anything, mock.MatchedBy(func(e *event.Event) bool {
+					return e.Type == event.TypeNoteUpdated
+				})).
+				Return(nil).
+				Once()
+
+			sess, err := s.Join(context.Background(), editor, n.ID)
+			require.NoError(t, err)
+
+			init := <-sess.Messages()
+			last := init.Elements[len(init.Elements)-1].ID
+			err = sess.Handle(context.Background(), &collab.ClientMessage{
+				Type: collab.MessageUpdate,
+				Ops:  insertOps(init, last, " world"),
+			})
+			require.NoError(t, err)
+
+			s.compactAll(context.Background())
+			require.NotNil(t, state)
+			doc, err := decodeCollabState(state)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, doc.Text())
+
+			// the room without editors is removed after the next compaction
+			sess.Close()
+			s.compactAll(context.Background())
+			require.Empty(t, s.rooms)
+		})
+	}
+}
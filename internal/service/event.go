@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/op/driver"
+)
+
+// EventDispatcherDeps represents the dependencies required to construct an outbox events dispatcher.
+type EventDispatcherDeps struct {
+	TxManager     tx.Manager
+	EventRepo     event.Repository
+	PollInterval  time.Duration
+	BatchSize     uint64
+	MaxAttempts   int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	ClaimTimeout  time.Duration
+}
+
+// errEventClaimExpired is the error of events whose last claimed delivery was not completed.
+var errEventClaimExpired = errors.New("delivery attempts are exhausted by expired claims")
+
+// eventSubscriber represents the handler subscribed to the event types, empty types match all events.
+type eventSubscriber struct {
+	name    string
+	handler event.Handler
+	types   []event.Type
+}
+
+// eventDispatcher is a struct that implements the event.Dispatcher interface polling the outbox.
+type eventDispatcher struct {
+	tx            tx.Manager
+	eventRepo     event.Repository
+	pollInterval  time.Duration
+	batchSize     uint64
+	maxAttempts   int
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+	claimTimeout  time.Duration
+	mu            sync.RWMutex
+	subscribers   []*eventSubscriber
+}
+
+// NewEventDispatcher initializes and returns a new implementation of the event.Dispatcher interface using the provided dependencies.
+func NewEventDispatcher(deps *EventDispatcherDeps) event.Dispatcher {
+	return &eventDispatcher{
+		tx:            deps.TxManager,
+		eventRepo:     deps.EventRepo,
+		pollInterval:  deps.PollInterval,
+		batchSize:     deps.BatchSize,
+		maxAttempts:   deps.MaxAttempts,
+		retryDelay:    deps.RetryDelay,
+		maxRetryDelay: deps.MaxRetryDelay,
+		claimTimeout:  deps.ClaimTimeout,
+	}
+}
+
+// Subscribe registers the handler for the event types, the handler receives all events when no types are given.
+func (d *eventDispatcher) Subscribe(name string, h event.Handler, types ...event.Type) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.subscribers = append(d.subscribers, &eventSubscriber{name: name, handler: h, types: types})
+}
+
+// Dispatch claims a batch of pending events and delivers each of them in its own transaction, returning the number
+// of claimed events. The delivered state is saved along with the changes of the subscribers, failed events
+// are retried with exponential backoff and marked dead once attempts are exhausted. An event waiting for retry
+// holds back later events of the same aggregate to keep their order. Delivery failures are returned joined.
+func (d *eventDispatcher) Dispatch(ctx context.Context) (int, error) {
+	events, err := d.claim(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("dispatch events: %w", err)
+	}
+
+	var failures []error
+	blocked := make(map[uuid.UUID]struct{})
+	for _, e := range events {
+		if _, ok := blocked[e.AggregateID]; ok {
+			e.Attempts--
+			e.AvailableAt = time.Now()
+			if err := d.eventRepo.Save(ctx, e); err != nil {
+				failures = append(failures, fmt.Errorf("dispatch events: release: %w", err))
+			}
+
+			continue
+		}
+
+		if err := d.process(ctx, e); err != nil {
+			failures = append(failures, err)
+			if e.Status == event.StatusPending {
+				blocked[e.AggregateID] = struct{}{}
+			}
+		}
+	}
+
+	return len(events), errors.Join(failures...)
+}
+
+// Run dispatches events with the poll interval until the context is done. A full batch is followed by the next one
+// without waiting. Errors are reported to onError.
+func (d *eventDispatcher) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				processed, err := d.Dispatch(ctx)
+				if err != nil {
+					onError(err)
+				}
+
+				if uint64(processed) < d.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// claim returns the pending events due for delivery in the publishing order, the aggregates holding back their
+// events are left out by the repository. Claimed events count an attempt and are postponed for the claim timeout, so concurrent dispatchers
+// skip them and events left undelivered by a crashed dispatcher become due again afterwards. Events claimed
+// as many times as the attempts allow are marked dead.
+func (d *eventDispatcher) claim(ctx context.Context) ([]*event.Event, error) {
+	var claimed []*event.Event
+	err := d.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := d.eventRepo.Lock(ctx); err != nil {
+			return err
+		}
+
+		events, err := d.eventRepo.GetPending(ctx, d.batchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, e := range events {
+			if e.Attempts >= d.maxAttempts {
+				e.Status = event.StatusDead
+				e.LastError = errEventClaimExpired.Error()
+				e.ProcessedAt = driver.ZeroTime(now)
+			} else {
+				e.Attempts++
+				e.AvailableAt = now.Add(d.claimTimeout)
+				claimed = append(claimed, e)
+			}
+
+			if err := d.eventRepo.Save(ctx, e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim events: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// process delivers the claimed event in a transaction saving it delivered. A failed delivery is rolled back
+// and the event is saved for the retry, or dead once attempts are exhausted.
+func (d *eventDispatcher) process(ctx context.Context, e *event.Event) error {
+	err := d.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := d.deliver(ctx, e); err != nil {
+			return err
+		}
+
+		e.Status = event.StatusDelivered
+		e.LastError = ""
+		e.ProcessedAt = driver.ZeroTime(time.Now())
+		return d.eventRepo.Save(ctx, e)
+	})
+	if err == nil {
+		return nil
+	}
+
+	now := time.Now()
+	e.LastError = err.Error()
+	if e.Attempts >= d.maxAttempts {
+		e.Status = event.StatusDead
+		e.ProcessedAt = driver.ZeroTime(now)
+	} else {
+		e.Status = event.StatusPending
+		e.ProcessedAt = driver.ZeroTime{}
+		e.AvailableAt = now.Add(d.backoff(e.Attempts))
+	}
+
+	failure := fmt.Errorf("deliver event %s: %w (type %s, attempt %d)", e.ID, err, e.Type, e.Attempts)
+	if err := d.eventRepo.Save(ctx, e); err != nil {
+		return errors.Join(failure, fmt.Errorf("save event %s: %w", e.ID, err))
+	}
+
+	return failure
+}
+
+// deliver passes the event to every matching subscriber and returns their errors joined.
+func (d *eventDispatcher) deliver(ctx context.Context, e *event.Event) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var errs []error
+	for _, s := range d.subscribers {
+		if len(s.types) > 0 && !slices.Contains(s.types, e.Type) {
+			continue
+		}
+
+		if err := s.handler(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("subscriber %s: %w", s.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// backoff returns the delay before the next attempt, doubled after each failed attempt and capped by the max delay.
+func (d *eventDispatcher) backoff(attempts int) time.Duration {
+	delay := d.retryDelay
+	for i := 1; i < attempts && delay < d.maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, d.maxRetryDelay)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_event"
+)
+
+// newEventPublisher returns an events publisher accepting any events.
+func newEventPublisher(t *testing.T) *mock_event.Publisher {
+	publisher := mock_event.NewPublisher(t)
+	publisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return publisher
+}
+
+func TestEventDispatcher_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	first, second := uuid.New(), uuid.New()
+	newEvent := func(aggregateID uuid.UUID, typ event.Type, attempts int) *event.Event {
+		return &event.Event{
+			ID:          uuid.New(),
+			AggregateID: aggregateID,
+			Type:        typ,
+			Status:      event.StatusPending,
+			Attempts:    attempts,
+			AvailableAt: time.Now(),
+		}
+	}
+
+	cases := []struct {
+		name      string
+		events    []*event.Event
+		expected  []event.Status
+		attempts  []int
+		delivered []event.Type
+		failed    bool
+	}{
+		{
+			name: "successful_dispatch",
+			events: []*event.Event{
+				newEvent(first, event.TypeNoteCreated, 0),
+				newEvent(first, event.TypeNoteUpdated, 0),
+			},
+			expected:  []event.Status{event.StatusDelivered, event.StatusDelivered},
+			attempts:  []int{1, 1},
+			delivered: []event.Type{event.TypeNoteCreated, event.TypeNoteUpdated},
+		},
+		{
+			name: "failure_holds_back_aggregate",
+			events: []*event.Event{
+				newEvent(first, event.TypeNoteDeleted, 0),
+				newEvent(second, event.TypeNoteCreated, 0),
+				newEvent(first, event.TypeNoteCreated, 0),
+			},
+			expected:  []event.Status{event.StatusPending, event.StatusDelivered, event.StatusPending},
+			attempts:  []int{1, 1, 0},
+			delivered: []event.Type{event.TypeNoteDeleted, event.TypeNoteCreated},
+			failed:    true,
+		},
+		{
+			name: "dead_letter",
+			events: []*event.Event{
+				newEvent(first, event.TypeNoteDeleted, 2),
+				newEvent(first, event.TypeNoteCreated, 0),
+			},
+			expected:  []event.Status{event.StatusDead, event.StatusDelivered},
+			attempts:  []int{3, 1},
+			delivered: []event.Type{event.TypeNoteDeleted, event.TypeNoteCreated},
+			failed:    true,
+		},
+		{
+			name: "expired_claims_dead_letter",
+			events: []*event.Event{
+				newEvent(first, event.TypeNoteUpdated, 3),
+				newEvent(first, event.TypeNoteCreated, 0),
+			},
+			expected:  []event.Status{event.StatusDead, event.StatusDelivered},
+			attempts:  []int{3, 1},
+			delivered: []event.Type{event.TypeNoteCreated},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_event.NewRepository(t)
+			repo.EXPECT().Lock(mock.Anything).Return(nil).Once()
+			repo.EXPECT().GetPending(mock.Anything, uint64(10)).Return(tc.events, nil).Once()
+			repo.EXPECT().Save(mock.Anything, mock.AnythingOfType("*event.Event")).Return(nil)
+
+			dispatcher := NewEventDispatcher(&EventDispatcherDeps{
+				TxManager:     mock_tx.NewMockTxManager(),
+				EventRepo:     repo,
+				BatchSize:     10,
+				MaxAttempts:   3,
+				RetryDelay:    time.Second,
+				MaxRetryDelay: time.Minute,
+				ClaimTimeout:  time.Minute,
+			})
+
+			var delivered []event.Type
+			dispatcher.Subscribe("recorder", func(_ context.Context, e *event.Event) error {
+				delivered = append(delivered, e.Type)
+				if e.Type == event.TypeNoteDeleted {
+					return errors.New("unavailable")
+				}
+
+				return nil
+			})
+
+			_, err := dispatcher.Dispatch(context.Background())
+			if tc.failed {
+				require.ErrorContains(t, err, "subscriber recorder: unavailable")
+			} else {
+				require.NoError(t, err)
+			}
+
+			statuses := make([]event.Status, len(tc.events))
+			attempts := make([]int, len(tc.events))
+			for i, e := range tc.events {
+				statuses[i] = e.Status
+				attempts[i] = e.Attempts
+			}
+
+			require.Equal(t, tc.expected, statuses)
+			require.Equal(t, tc.attempts, attempts)
+			require.Equal(t, tc.delivered, delivered)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xsqrty/notes/internal/domain/export"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/notefile"
+	"github.com/xsqrty/notes/pkg/pdf"
+)
+
+const (
+	// exportTimeLayout is the layout of the timestamps of exported notes.
+	exportTimeLayout = "2006-01-02 15:04 MST"
+	// exportPDFTitle is the title of PDF documents of several notes.
+	exportPDFTitle = "Notes"
+	// exportNameLength is the maximum number of characters of file names derived from note names.
+	exportNameLength = 100
+	// exportDefaultName is the file name of notes without a usable name.
+	exportDefaultName = "note"
+	// exportNotesName is the file name of exports of several notes.
+	exportNotesName = "notes"
+	// exportNotesPage is the number of notes read at once by exports of notes.
+	exportNotesPage = 100
+)
+
+// exportNotesFormat describes the file of a format of exported notes and the writer of the notes.
+type exportNotesFormat struct {
+	ext         string
+	contentType string
+	writer      func(w io.Writer) notefile.Writer
+}
+
+// exportNotesFormats are the formats of exported notes.
+var exportNotesFormats = map[export.Format]exportNotesFormat{
+	export.FormatMarkdown: {ext: ".zip", contentType: "application/zip", writer: notefile.NewMarkdownWriter},
+	export.FormatJSON:     {ext: ".json", contentType: "application/json", writer: notefile.NewJSONWriter},
+	export.FormatNDJSON:   {ext: ".ndjson", contentType: "application/x-ndjson", writer: notefile.NewNDJSONWriter},
+}
+
+// ExportServiceDeps represents the dependencies required to construct an export service.
+// Notes are read by the note service, so every exported note is guarded as if it was read alone.
+type ExportServiceDeps struct {
+	Notes note.Service
+}
+
+// exportService is a struct that implements the export.Service interface.
+type exportService struct {
+	notes note.Service
+}
+
+// NewExportService initializes and returns a new implementation of the export.Service interface.
+func NewExportService(deps *ExportServiceDeps) export.Service {
+	return &exportService{
+		notes: deps.Notes,
+	}
+}
+
+// PDF writes the notes to w as a PDF document, each note starting on a new page. Documents of several notes open
+// with a table of contents. Nothing is written unless all the notes may be read by the user.
+func (s *exportService) PDF(
+	ctx context.Context,
+	u *user.User,
+	w io.Writer,
+	req *export.PDFRequest,
+) (*export.File, error) {
+	sections := make([]pdf.Section, len(req.IDs))
+	title := exportPDFTitle
+	for i, id := range req.IDs {
+		n, err := s.notes.Get(ctx, u, id)
+		if err != nil {
+			return nil, fmt.Errorf("export pdf: %w", err)
+		}
+
+		if len(req.IDs) == 1 {
+			title = n.Name
+		}
+
+		sections[i] = pdfSection(n)
+	}
+
+	err := pdf.Render(w, sections, pdf.Options{
+		Title:    title,
+		PageSize: req.PageSize,
+		Contents: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export pdf: %w (user %s)", err, u.ID)
+	}
+
+	return &export.File{Name: exportFileName(title) + ".pdf", ContentType: "application/pdf"}, nil
+}
+
+// Notes checks the export of the personal notes of the user matching the filters by reading the first page
+// of the notes, and returns the stream writing the notes in the order of creation. Notes are read and written
+// a page at a time, so exports of any size are not kept in memory.
+func (s *exportService) Notes(ctx context.Context, u *user.User, req *export.NotesRequest) (*export.Stream, error) {
+	format, ok := exportNotesFormats[req.Format]
+	if !ok {
+		return nil, fmt.Errorf("export notes: %w: %s (user %s)", export.ErrUnknownFormat, req.Format, u.ID)
+	}
+
+	page, err := s.notesPage(ctx, u, req.Filters, 0)
+	if err != nil {
+		return nil, fmt.Errorf("export notes: %w", err)
+	}
+
+	return &export.Stream{
+		File: &export.File{Name: exportNotesName + format.ext, ContentType: format.contentType},
+		Write: func(w io.Writer) error {
+			if err := s.writeNotes(ctx, u, req.Filters, page, format.writer(w), req.Format); err != nil {
+				return fmt.Errorf("export notes: %w (user %s)", err, u.ID)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// writeNotes writes the notes starting with the first page, reading the following pages as the notes are written.
+// Markdown files are named after the notes.
+func (s *exportService) writeNotes(
+	ctx context.Context,
+	u *user.User,
+	filters search.Filters,
+	page *search.Result[note.Note],
+	nw notefile.Writer,
+	format export.Format,
+) error {
+	names := exportNames{}
+	for offset := uint64(0); ; {
+		for _, n := range page.Rows {
+			e := &notefile.Entry{
+				Title:     n.Name,
+				Text:      n.Text,
+				Markdown:  n.Format == note.FormatMarkdown,
+				CreatedAt: n.CreatedAt,
+				UpdatedAt: time.Time(n.UpdatedAt),
+			}
+			if format == export.FormatMarkdown {
+				e.Source = names.unique(exportFileName(n.Name), ".md")
+			}
+
+			if err := nw.Write(e); err != nil {
+				return err
+			}
+		}
+
+		offset += uint64(len(page.Rows))
+		if len(page.Rows) < exportNotesPage || offset >= page.TotalRows {
+			break
+		}
+
+		var err error
+		if page, err = s.notesPage(ctx, u, filters, offset); err != nil {
+			return err
+		}
+	}
+
+	return nw.Close()
+}
+
+// notesPage reads the page of the personal notes of the user matching the filters, in the order of creation.
+func (s *exportService) notesPage(
+	ctx context.Context,
+	u *user.User,
+	filters search.Filters,
+	offset uint64,
+) (*search.Result[note.Note], error) {
+	return s.notes.Search(ctx, u, &search.Request{
+		Orders:  []search.Order{{Key: "created_at"}, {Key: "id"}},
+		Filters: filters,
+		Limit:   exportNotesPage,
+		Offset:  offset,
+	})
+}
+
+// pdfSection returns the section of the PDF document with the name, the timestamps and the text of the note.
+func pdfSection(n *note.Note) pdf.Section {
+	meta := []string{"Created " + n.CreatedAt.UTC().Format(exportTimeLayout)}
+	if updatedAt := time.Time(n.UpdatedAt); !updatedAt.IsZero() {
+		meta = append(meta, "Updated "+updatedAt.UTC().Format(exportTimeLayout))
+	}
+
+	return pdf.Section{
+		Title: n.Name,
+		Meta:  meta,
+		Body:  n.Text,
+	}
+}
+
+// exportNames keeps the file names taken in an archive, names differing in case only are taken as the same name.
+type exportNames map[string]struct{}
+
+// unique returns the file name of the extension, numbering the name when it is already taken.
+func (names exportNames) unique(name, ext string) string {
+	file := name + ext
+	for i := 2; ; i++ {
+		key := strings.ToLower(file)
+		if _, ok := names[key]; !ok {
+			names[key] = struct{}{}
+			return file
+		}
+
+		file = fmt.Sprintf("%s (%d)%s", name, i, ext)
+	}
+}
+
+// exportFileName derives a file name from the note name, keeping it free of path separators, control and reserved
+// characters of common file systems.
+func exportFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	name = strings.Trim(strings.Join(strings.Fields(name), " "), ". ")
+	if utf8.RuneCountInString(name) > exportNameLength {
+		name = strings.TrimRight(string([]rune(name)[:exportNameLength]), ". ")
+	}
+
+	if name == "" {
+		return exportDefaultName
+	}
+
+	return name
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/export"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/pkg/notefile"
+	"github.com/xsqrty/notes/pkg/pdf"
+	"github.com/xsqrty/op/driver"
+)
+
+func TestExportService_PDF(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	first := &note.Note{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Q3: plans / goals?",
+		Text:      "Первая заметка\n\n- item",
+		CreatedAt: time.Now(),
+		UpdatedAt: driver.ZeroTime(time.Now()),
+	}
+	second := &note.Note{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Second",
+		Text:      "Second note",
+		CreatedAt: time.Now(),
+	}
+
+	cases := []struct {
+		name         string
+		notes        []*note.Note
+		forbidden    bool
+		expectedName string
+	}{
+		{
+			name:         "single_note",
+			notes:        []*note.Note{first},
+			expectedName: "Q3_ plans _ goals_.pdf",
+		},
+		{
+			name:         "several_notes",
+			notes:        []*note.Note{first, second},
+			expectedName: "Notes.pdf",
+		},
+		{
+			name:      "forbidden_note",
+			notes:     []*note.Note{first, second},
+			forbidden: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			notes := mock_note.NewService(t)
+			ids := make([]uuid.UUID, len(tc.notes))
+			for i, n := range tc.notes {
+				ids[i] = n.ID
+				if tc.forbidden && i == len(tc.notes)-1 {
+					notes.EXPECT().Get(mock.Anything, u, n.ID).Return(nil, note.ErrOperationForbiddenForUser).Once()
+					continue
+				}
+
+				notes.EXPECT().Get(mock.Anything, u, n.ID).Return(n, nil).Once()
+			}
+
+			var doc bytes.Buffer
+			file, err := NewExportService(&ExportServiceDeps{Notes: notes}).PDF(
+				context.Background(),
+				u,
+				&doc,
+				&export.PDFRequest{IDs: ids, PageSize: pdf.Letter},
+			)
+			if tc.forbidden {
+				require.ErrorIs(t, err, note.ErrOperationForbiddenForUser)
+				require.Zero(t, doc.Len())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedName, file.Name)
+			require.True(t, bytes.HasPrefix(doc.Bytes(), []byte("%PDF-")))
+		})
+	}
+}
+
+func TestExportService_Notes(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	createdAt := time.Date(2025, 8, 4, 9, 30, 0, 0, time.UTC)
+	notes := make([]*note.Note, exportNotesPage+2)
+	for i := range notes {
+		notes[i] = &note.Note{
+			ID:        uuid.Must(uuid.NewV7()),
+			Name:      fmt.Sprintf("Note %d", i),
+			Text:      fmt.Sprintf("Text of note %d", i),
+			Format:    note.FormatPlain,
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+		}
+	}
+
+	notes[0].Name = "Plan"
+	notes[0].Format = note.FormatMarkdown
+	notes[0].UpdatedAt = driver.ZeroTime(createdAt.Add(time.Hour))
+	notes[1].Name = "plan"
+	notes[exportNotesPage+1].Name = "Plan: Q3"
+	filters := search.Filters{"name": "Plan"}
+
+	cases := []struct {
+		name        string
+		format      export.Format
+		forbidden   bool
+		expectedErr error
+		expected    *export.File
+		expectedSrc []string
+	}{
+		{
+			name:        "markdown_export",
+			format:      export.FormatMarkdown,
+			expected:    &export.File{Name: "notes.zip", ContentType: "application/zip"},
+			expectedSrc: []string{"Plan.md", "plan (2).md", "Note 2.md"},
+		},
+		{
+			name:     "json_export",
+			format:   export.FormatJSON,
+			expected: &export.File{Name: "notes.json", ContentType: "application/json"},
+		},
+		{
+			name:     "ndjson_export",
+			format:   export.FormatNDJSON,
+			expected: &export.File{Name: "notes.ndjson", ContentType: "application/x-ndjson"},
+		},
+		{
+			name:        "unknown_format",
+			format:      "pdf",
+			expectedErr: export.ErrUnknownFormat,
+		},
+		{
+			name:        "forbidden",
+			format:      export.FormatJSON,
+			forbidden:   true,
+			expectedErr: note.ErrOperationForbiddenForUser,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := mock_note.NewService(t)
+			page := func(offset uint64) *search.Request {
+				return &search.Request{
+					Orders:  []search.Order{{Key: "created_at"}, {Key: "id"}},
+					Filters: filters,
+					Limit:   exportNotesPage,
+					Offset:  offset,
+				}
+			}
+
+			switch {
+			case tc.forbidden:
+				service.EXPECT().Search(mock.Anything, u, page(0)).
+					Return(nil, note.ErrOperationForbiddenForUser).Once()
+			case tc.expectedErr == nil:
+				service.EXPECT().Search(mock.Anything, u, page(0)).Return(&search.Result[note.Note]{
+					TotalRows: uint64(len(notes)),
+					Rows:      notes[:exportNotesPage],
+				}, nil).Once()
+				service.EXPECT().Search(mock.Anything, u, page(exportNotesPage)).Return(&search.Result[note.Note]{
+					TotalRows: uint64(len(notes)),
+					Rows:      notes[exportNotesPage:],
+				}, nil).Once()
+			}
+
+			stream, err := NewExportService(&ExportServiceDeps{Notes: service}).Notes(
+				context.Background(),
+				u,
+				&export.NotesRequest{Format: tc.format, Filters: filters},
+			)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, stream.File)
+
+			var buf bytes.Buffer
+			require.NoError(t, stream.Write(&buf))
+
+			var entries []*notefile.Entry
+			read := func(e *notefile.Entry, err error) error {
+				require.NoError(t, err)
+				entries = append(entries, e)
+				return nil
+			}
+
+			switch tc.format {
+			case export.FormatMarkdown:
+				require.NoError(t, notefile.ReadMarkdown(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 1024, read))
+			case export.FormatJSON:
+				require.NoError(t, notefile.ReadJSON(&buf, read))
+			case export.FormatNDJSON:
+				lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+				require.NoError(t, notefile.ReadJSON(strings.NewReader("["+strings.Join(lines, ",")+"]"), read))
+			}
+
+			require.Len(t, entries, len(notes))
+			for i, e := range entries {
+				if i < len(tc.expectedSrc) {
+					require.Equal(t, tc.expectedSrc[i], e.Source)
+				}
+
+				require.Equal(t, notes[i].Name, e.Title)
+				require.Equal(t, notes[i].Text, e.Text)
+				markdown := notes[i].Format == note.FormatMarkdown || tc.format == export.FormatMarkdown
+				require.Equal(t, markdown, e.Markdown)
+				require.True(t, notes[i].CreatedAt.Equal(e.CreatedAt))
+				require.True(t, time.Time(notes[i].UpdatedAt).Equal(e.UpdatedAt))
+			}
+
+			if tc.format == export.FormatMarkdown {
+				require.Equal(t, "Plan_ Q3.md", entries[len(entries)-1].Source)
+			}
+		})
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "Meeting notes", exportFileName("  Meeting\tnotes  "))
+	require.Equal(t, "_etc_passwd", exportFileName("../etc/passwd"))
+	require.Equal(t, "note", exportFileName(" .. "))
+	require.Len(t, []rune(exportFileName(string(make([]rune, 300)))), 100)
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/keywords"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// GraphServiceDeps represents the dependencies required to construct a graph service.
+type GraphServiceDeps struct {
+	GraphRepo    graph.Repository
+	NoteGuard    note.Guarder
+	Nodes        int
+	MaxNodes     int
+	MaxEdges     int
+	MaxDepth     int
+	SimilarLimit int
+}
+
+// graphService is a struct that implements the graph.Service interface for building note graphs.
+type graphService struct {
+	graphRepo    graph.Repository
+	guard        note.Guarder
+	nodes        int
+	maxNodes     int
+	maxEdges     int
+	maxDepth     int
+	similarLimit int
+}
+
+// NewGraphService initializes and returns a new implementation of the graph.Service interface.
+func NewGraphService(deps *GraphServiceDeps) graph.Service {
+	return &graphService{
+		graphRepo:    deps.GraphRepo,
+		guard:        deps.NoteGuard,
+		nodes:        deps.Nodes,
+		maxNodes:     deps.MaxNodes,
+		maxEdges:     deps.MaxEdges,
+		maxDepth:     deps.MaxDepth,
+		similarLimit: deps.SimilarLimit,
+	}
+}
+
+// Build returns the graph of the latest notes the user may read, or the neighbourhood of the requested note
+// if the user may read the note. The depth and the number of nodes and edges are bounded by the limits.
+func (s *graphService) Build(ctx context.Context, u *user.User, req *graph.Request) (*graph.Graph, error) {
+	if req.Depth < 0 || req.Limit < 0 || req.Similarity < 0 || req.Similarity > 1 {
+		return nil, fmt.Errorf("build graph: %w (user %s)", graph.ErrInvalidRequest, u.ID)
+	}
+
+	var (
+		nodes     []*note.Note
+		truncated bool
+		err       error
+	)
+
+	limit := min(cmp.Or(req.Limit, s.nodes), s.maxNodes)
+	if req.NoteID.Valid {
+		depth := min(cmp.Or(req.Depth, 1), s.maxDepth)
+		nodes, truncated, err = s.neighbourhood(ctx, u, req.NoteID.UUID, depth, req.Similarity, limit)
+	} else {
+		nodes, truncated, err = s.latest(ctx, u, limit)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("build graph: %w", err)
+	}
+
+	edges, edgesTruncated, err := s.edges(ctx, nodes, req.Similarity)
+	if err != nil {
+		return nil, fmt.Errorf("build graph: %w (user %s)", err, u.ID)
+	}
+
+	return &graph.Graph{Nodes: nodes, Edges: edges, Truncated: truncated || edgesTruncated}, nil
+}
+
+// latest returns up to the limit of the latest notes the user may read, reporting whether more notes are left.
+func (s *graphService) latest(ctx context.Context, u *user.User, limit int) ([]*note.Note, bool, error) {
+	rows, err := s.graphRepo.GetNodes(ctx, u, uint64(limit)+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	truncated := len(rows) > limit
+	nodes, err := s.readable(ctx, u, rows[:min(limit, len(rows))])
+	if err != nil {
+		return nil, false, err
+	}
+
+	return nodes, truncated, nil
+}
+
+// neighbourhood returns up to the limit of the notes the user may read within the depth of connections
+// from the note, nearer notes first, reporting whether notes were left out.
+func (s *graphService) neighbourhood(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	depth int,
+	similarity float64,
+	limit int,
+) ([]*note.Note, bool, error) {
+	root, err := s.graphRepo.GetNodesByIDs(ctx, u, []uuid.UUID{noteID})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(root) == 0 {
+		return nil, false, fmt.Errorf("%w (user %s, note %s)", note.ErrNotFound, u.ID, noteID)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, root[0], u)
+	if err != nil {
+		return nil, false, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if !granted {
+		return nil, false, fmt.Errorf("%w (user %s, note %s)", note.ErrOperationForbiddenForUser, u.ID, noteID)
+	}
+
+	nodes := root
+	seen := map[uuid.UUID]struct{}{noteID: {}}
+	frontier := root
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		neighbours, err := s.neighbours(ctx, u, frontier, seen, similarity)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(nodes)+len(neighbours) > limit {
+			return append(nodes, neighbours[:limit-len(nodes)]...), true, nil
+		}
+
+		for _, n := range neighbours {
+			seen[n.ID] = struct{}{}
+		}
+
+		nodes = append(nodes, neighbours...)
+		frontier = neighbours
+	}
+
+	return nodes, false, nil
+}
+
+// neighbours returns the notes the user may read not seen yet, linked to or from the frontier notes
+// and similar to them.
+func (s *graphService) neighbours(
+	ctx context.Context,
+	u *user.User,
+	frontier []*note.Note,
+	seen map[uuid.UUID]struct{},
+	similarity float64,
+) ([]*note.Note, error) {
+	ids := make([]uuid.UUID, len(frontier))
+	for i, n := range frontier {
+		ids[i] = n.ID
+	}
+
+	links, err := s.graphRepo.GetLinks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var linked []uuid.UUID
+	added := make(map[uuid.UUID]struct{})
+	for _, l := range links {
+		if !l.TargetID.Valid {
+			continue
+		}
+
+		for _, id := range []uuid.UUID{l.SourceID, l.TargetID.UUID} {
+			_, ok := seen[id]
+			if _, dup := added[id]; !ok && !dup {
+				added[id] = struct{}{}
+				linked = append(linked, id)
+			}
+		}
+	}
+
+	candidates, err := s.graphRepo.GetNodesByIDs(ctx, u, linked)
+	if err != nil {
+		return nil, err
+	}
+
+	if similarity > 0 {
+		if candidates, err = s.similar(ctx, u, frontier, seen, similarity, candidates); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.readable(ctx, u, candidates)
+}
+
+// similar appends the notes not seen yet at least as similar as the similarity to any of the frontier notes
+// to the candidates.
+func (s *graphService) similar(
+	ctx context.Context,
+	u *user.User,
+	frontier []*note.Note,
+	seen map[uuid.UUID]struct{},
+	similarity float64,
+	candidates []*note.Note,
+) ([]*note.Note, error) {
+	for _, n := range frontier {
+		similar, err := s.graphRepo.GetSimilar(ctx, u, n.Terms, uint64(s.similarLimit))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range similar {
+			_, ok := seen[c.ID]
+			if !ok && keywords.Similarity(n.Terms, c.Terms) >= similarity && !containsNote(candidates, c.ID) {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+// edges returns the links between the notes followed by the connections of the notes at least as similar
+// as the similarity, most similar first, reporting whether edges were left out by the limit.
+func (s *graphService) edges(
+	ctx context.Context,
+	nodes []*note.Note,
+	similarity float64,
+) ([]*graph.Edge, bool, error) {
+	ids := make([]uuid.UUID, len(nodes))
+	in := make(map[uuid.UUID]struct{}, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+		in[n.ID] = struct{}{}
+	}
+
+	links, err := s.graphRepo.GetLinks(ctx, ids)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var edges []*graph.Edge
+	connected := make(map[[2]uuid.UUID]struct{})
+	for _, l := range links {
+		pair := [2]uuid.UUID{l.SourceID, l.TargetID.UUID}
+		if !l.TargetID.Valid || pair[0] == pair[1] {
+			continue
+		}
+
+		_, source := in[pair[0]]
+		_, target := in[pair[1]]
+		if _, ok := connected[pair]; ok || !source || !target {
+			continue
+		}
+
+		connected[pair] = struct{}{}
+		edges = append(edges, &graph.Edge{Source: pair[0], Target: pair[1], Kind: graph.EdgeLink, Weight: 1})
+	}
+
+	edges = append(edges, similarEdges(nodes, similarity)...)
+	if len(edges) > s.maxEdges {
+		return edges[:s.maxEdges], true, nil
+	}
+
+	return edges, false, nil
+}
+
+// readable returns the notes the user may read among the notes.
+func (s *graphService) readable(ctx context.Context, u *user.User, notes []*note.Note) ([]*note.Note, error) {
+	readable := make([]*note.Note, 0, len(notes))
+	for _, n := range notes {
+		granted, err := s.guard.IsGranted(ctx, rbac.READ, n, u)
+		if err != nil {
+			return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, n.ID)
+		}
+
+		if granted {
+			readable = append(readable, n)
+		}
+	}
+
+	return readable, nil
+}
+
+// similarEdges returns the connections of the notes at least as similar as the similarity, most similar first.
+// Zero similarity connects no notes.
+func similarEdges(nodes []*note.Note, similarity float64) []*graph.Edge {
+	if similarity == 0 {
+		return nil
+	}
+
+	var edges []*graph.Edge
+	for i := range nodes {
+		for _, n := range nodes[i+1:] {
+			if w := keywords.Similarity(nodes[i].Terms, n.Terms); w >= similarity {
+				edges = append(edges, &graph.Edge{
+					Source: nodes[i].ID,
+					Target: n.ID,
+					Kind:   graph.EdgeSimilar,
+					Weight: w,
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(edges, func(a, b *graph.Edge) int {
+		return cmp.Compare(b.Weight, a.Weight)
+	})
+
+	return edges
+}
+
+// containsNote reports whether the note with the identifier is among the notes.
+func containsNote(notes []*note.Note, id uuid.UUID) bool {
+	return slices.ContainsFunc(notes, func(n *note.Note) bool { return n.ID == id })
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/graph"
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_graph"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+type graphMocks struct {
+	repo  *mock_graph.Repository
+	guard *mock_note.Guarder
+}
+
+func TestGraphService_Build(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	root := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Trip", Terms: []string{"beach", "flights", "hotel"}}
+	linked := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Budget", Terms: []string{"money"}}
+	similar := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Hotels", Terms: []string{"beach", "hotel"}}
+	unrelated := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Recipes", Terms: []string{"hotel", "pasta", "salt"}}
+	hidden := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Salaries"}
+	second := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Packing"}
+	newLink := func(source, target *note.Note) *link.Link {
+		return &link.Link{SourceID: source.ID, TargetID: uuid.NullUUID{UUID: target.ID, Valid: true}}
+	}
+	granted := func(m *graphMocks, readable bool, notes ...*note.Note) {
+		for _, n := range notes {
+			m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(readable, nil).Once()
+		}
+	}
+
+	cases := []struct {
+		name        string
+		req         *graph.Request
+		expected    *graph.Graph
+		expectedErr error
+		mocker      func(m *graphMocks)
+	}{
+		{
+			name: "latest_notes",
+			req:  &graph.Request{Limit: 2},
+			expected: &graph.Graph{
+				Nodes:     []*note.Note{root, linked},
+				Edges:     []*graph.Edge{{Source: root.ID, Target: linked.ID, Kind: graph.EdgeLink, Weight: 1}},
+				Truncated: true,
+			},
+			mocker: func(m *graphMocks) {
+				m.repo.EXPECT().GetNodes(mock.Anything, u, uint64(3)).
+					Return([]*note.Note{root, linked, similar}, nil).Once()
+				granted(m, true, root, linked)
+				m.repo.EXPECT().GetLinks(mock.Anything, []uuid.UUID{root.ID, linked.ID}).Return([]*link.Link{
+					newLink(root, linked),
+					newLink(root, linked),
+					newLink(root, similar),
+					{SourceID: root.ID, Target: "Packing list"},
+				}, nil).Once()
+			},
+		},
+		{
+			name: "neighbourhood",
+			req:  &graph.Request{NoteID: uuid.NullUUID{UUID: root.ID, Valid: true}, Depth: 2, Similarity: 0.5},
+			expected: &graph.Graph{
+				Nodes: []*note.Note{root, linked, similar, second},
+				Edges: []*graph.Edge{
+					{Source: root.ID, Target: linked.ID, Kind: graph.EdgeLink, Weight: 1},
+					{Source: linked.ID, Target: second.ID, Kind: graph.EdgeLink, Weight: 1},
+					{Source: root.ID, Target: similar.ID, Kind: graph.EdgeSimilar, Weight: 2.0 / 3},
+				},
+			},
+			mocker: func(m *graphMocks) {
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{root.ID}).
+					Return([]*note.Note{root}, nil).Once()
+				granted(m, true, root)
+				m.repo.EXPECT().GetLinks(mock.Anything, []uuid.UUID{root.ID}).
+					Return([]*link.Link{newLink(root, linked), newLink(hidden, root)}, nil).Once()
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{linked.ID, hidden.ID}).
+					Return([]*note.Note{linked, hidden}, nil).Once()
+				m.repo.EXPECT().GetSimilar(mock.Anything, u, root.Terms, uint64(10)).
+					Return([]*note.Note{root, similar, unrelated}, nil).Once()
+				granted(m, true, linked, similar)
+				granted(m, false, hidden)
+				m.repo.EXPECT().GetLinks(mock.Anything, []uuid.UUID{linked.ID, similar.ID}).
+					Return([]*link.Link{newLink(root, linked), newLink(linked, second)}, nil).Once()
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{second.ID}).
+					Return([]*note.Note{second}, nil).Once()
+				m.repo.EXPECT().GetSimilar(mock.Anything, u, linked.Terms, uint64(10)).Return(nil, nil).Once()
+				m.repo.EXPECT().GetSimilar(mock.Anything, u, similar.Terms, uint64(10)).
+					Return([]*note.Note{root, similar}, nil).Once()
+				granted(m, true, second)
+				m.repo.EXPECT().GetLinks(mock.Anything, []uuid.UUID{root.ID, linked.ID, similar.ID, second.ID}).
+					Return([]*link.Link{
+						newLink(root, linked),
+						newLink(linked, second),
+						newLink(hidden, root),
+					}, nil).Once()
+			},
+		},
+		{
+			name: "neighbourhood_limit",
+			req:  &graph.Request{NoteID: uuid.NullUUID{UUID: root.ID, Valid: true}, Depth: 5, Limit: 2},
+			expected: &graph.Graph{
+				Nodes:     []*note.Note{root, linked},
+				Edges:     []*graph.Edge{{Source: root.ID, Target: linked.ID, Kind: graph.EdgeLink, Weight: 1}},
+				Truncated: true,
+			},
+			mocker: func(m *graphMocks) {
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{root.ID}).
+					Return([]*note.Note{root}, nil).Once()
+				granted(m, true, root)
+				m.repo.EXPECT().GetLinks(mock.Anything, []uuid.UUID{root.ID}).
+					Return([]*link.Link{newLink(root, linked), newLink(root, second)}, nil).Once()
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{linked.ID, second.ID}).
+					Return([]*note.Note{linked, second}, nil).Once()
+				granted(m, true, linked, second)
+				m.repo.EXPECT().GetLinks(mock.Anything, []uuid.UUID{root.ID, linked.ID}).
+					Return([]*link.Link{newLink(root, linked), newLink(root, second)}, nil).Once()
+			},
+		},
+		{
+			name:        "note_not_granted",
+			req:         &graph.Request{NoteID: uuid.NullUUID{UUID: hidden.ID, Valid: true}},
+			expectedErr: note.ErrOperationForbiddenForUser,
+			mocker: func(m *graphMocks) {
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{hidden.ID}).
+					Return([]*note.Note{hidden}, nil).Once()
+				granted(m, false, hidden)
+			},
+		},
+		{
+			name:        "note_not_found",
+			req:         &graph.Request{NoteID: uuid.NullUUID{UUID: hidden.ID, Valid: true}},
+			expectedErr: note.ErrNotFound,
+			mocker: func(m *graphMocks) {
+				m.repo.EXPECT().GetNodesByIDs(mock.Anything, u, []uuid.UUID{hidden.ID}).Return(nil, nil).Once()
+			},
+		},
+		{
+			name:        "invalid_similarity",
+			req:         &graph.Request{Similarity: 1.5},
+			expectedErr: graph.ErrInvalidRequest,
+			mocker:      func(_ *graphMocks) {},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &graphMocks{
+				repo:  mock_graph.NewRepository(t),
+				guard: mock_note.NewGuarder(t),
+			}
+			tc.mocker(m)
+
+			service := NewGraphService(&GraphServiceDeps{
+				GraphRepo:    m.repo,
+				NoteGuard:    m.guard,
+				Nodes:        50,
+				MaxNodes:     100,
+				MaxEdges:     100,
+				MaxDepth:     3,
+				SimilarLimit: 10,
+			})
+
+			g, err := service.Build(context.Background(), u, tc.req)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, g)
+		})
+	}
+}
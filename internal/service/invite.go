@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/invite"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+// inviteCodeLength is the number of random bytes of generated invitation codes.
+const inviteCodeLength = 15
+
+// InviteServiceDeps represents the dependencies required to construct an invitation codes service.
+type InviteServiceDeps struct {
+	TxManager   tx.Manager
+	InviteRepo  invite.Repository
+	RoleRepo    role.Repository
+	InviteGuard invite.Guarder
+	Audit       audit.Recorder
+}
+
+// inviteService is a struct that implements the invite.Service interface for invitation codes administration.
+type inviteService struct {
+	tx         tx.Manager
+	inviteRepo invite.Repository
+	roleRepo   role.Repository
+	guard      invite.Guarder
+	audit      audit.Recorder
+}
+
+// NewInviteService initializes and returns a new implementation of the invite.Service interface using the provided dependencies.
+func NewInviteService(deps *InviteServiceDeps) invite.Service {
+	return &inviteService{
+		tx:         deps.TxManager,
+		inviteRepo: deps.InviteRepo,
+		roleRepo:   deps.RoleRepo,
+		guard:      deps.InviteGuard,
+		audit:      deps.Audit,
+	}
+}
+
+// List returns all invitation codes if the user has the required permission to read them.
+func (s *inviteService) List(ctx context.Context, u *user.User) ([]*invite.Code, error) {
+	if err := s.checkGranted(ctx, rbac.READ, nil, u); err != nil {
+		return nil, fmt.Errorf("list invitation codes: %w (user %s)", err, u.ID)
+	}
+
+	codes, err := s.inviteRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list invitation codes: %w (user %s)", err, u.ID)
+	}
+
+	return codes, nil
+}
+
+// Create mints a new invitation code if the user is authorized to do so.
+// Empty role label makes the code attach the default role of created users.
+func (s *inviteService) Create(ctx context.Context, u *user.User, data *invite.CreateData) (*invite.Code, error) {
+	if err := s.checkGranted(ctx, rbac.CREATE, nil, u); err != nil {
+		return nil, fmt.Errorf("create invitation code: %w (user %s)", err, u.ID)
+	}
+
+	label := data.RoleLabel
+	if label == "" {
+		label = role.LabelOnCreated
+	}
+
+	if err := s.checkRoleLabel(ctx, label); err != nil {
+		return nil, fmt.Errorf("create invitation code: %w (user %s)", err, u.ID)
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, fmt.Errorf("create invitation code: %w (user %s)", err, u.ID)
+	}
+
+	c := &invite.Code{
+		Code:      code,
+		Email:     strings.ToLower(data.Email),
+		RoleLabel: string(label),
+		MaxUses:   data.MaxUses,
+		ExpiresAt: driver.ZeroTime(data.ExpiresAt),
+		CreatedBy: u.ID,
+		CreatedAt: time.Now(),
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.inviteRepo.Save(ctx, c); err != nil {
+			return err
+		}
+
+		e := audit.NewEvent(ctx, audit.ActionInviteCodeCreate, u.ID, audit.TargetInviteCode, c.ID)
+		return s.audit.Record(ctx, e)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create invitation code: %w (user %s)", err, u.ID)
+	}
+
+	return c, nil
+}
+
+// Revoke removes the invitation code by its ID if the user has the required permission and returns the removed code.
+func (s *inviteService) Revoke(ctx context.Context, u *user.User, id uuid.UUID) (*invite.Code, error) {
+	c, err := s.inviteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"revoke invitation code: %w (user %s, code %s)",
+			errors.Join(invite.ErrNotFound, err),
+			u.ID,
+			id,
+		)
+	}
+
+	if err := s.checkGranted(ctx, rbac.DELETE, c, u); err != nil {
+		return nil, fmt.Errorf("revoke invitation code: %w (user %s, code %s)", err, u.ID, id)
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.inviteRepo.Delete(ctx, c); err != nil {
+			return err
+		}
+
+		e := audit.NewEvent(ctx, audit.ActionInviteCodeRevoke, u.ID, audit.TargetInviteCode, c.ID)
+		return s.audit.Record(ctx, e)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revoke invitation code: %w (user %s, code %s)", err, u.ID, id)
+	}
+
+	return c, nil
+}
+
+// checkRoleLabel returns invite.ErrUnknownRoleLabel if there is no role with the label.
+func (s *inviteService) checkRoleLabel(ctx context.Context, label role.Label) error {
+	roles, err := s.roleRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(roles, func(r *role.Role) bool { return r.Label == string(label) }) {
+		return fmt.Errorf("%w %q", invite.ErrUnknownRoleLabel, label)
+	}
+
+	return nil
+}
+
+// checkGranted returns invite.ErrOperationForbiddenForUser if the operation is not granted for the user.
+func (s *inviteService) checkGranted(ctx context.Context, op rbac.Operation, c *invite.Code, u *user.User) error {
+	granted, err := s.guard.IsGranted(ctx, op, c, u)
+	if err != nil {
+		return fmt.Errorf("check granted: %w", err)
+	}
+
+	if !granted {
+		return invite.ErrOperationForbiddenForUser
+	}
+
+	return nil
+}
+
+// generateInviteCode returns a random human-typeable invitation code.
+func generateInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate code: %w", err)
+	}
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
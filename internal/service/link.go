@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/notes/pkg/wikilink"
+	"github.com/xsqrty/op/driver"
+)
+
+// LinkServiceDeps represents the dependencies required to construct a link service.
+type LinkServiceDeps struct {
+	LinkRepo  link.Repository
+	NoteRepo  note.Repository
+	NoteGuard note.Guarder
+	Events    event.Publisher
+	TxManager tx.Manager
+}
+
+// linkService is a struct that implements the link.Service interface for managing links between notes.
+type linkService struct {
+	linkRepo link.Repository
+	noteRepo note.Repository
+	guard    note.Guarder
+	events   event.Publisher
+	tx       tx.Manager
+}
+
+// NewLinkService initializes and returns a new implementation of the link.Service interface.
+func NewLinkService(deps *LinkServiceDeps) link.Service {
+	return &linkService{
+		linkRepo: deps.LinkRepo,
+		noteRepo: deps.NoteRepo,
+		guard:    deps.NoteGuard,
+		events:   deps.Events,
+		tx:       deps.TxManager,
+	}
+}
+
+// Links returns the links written in the note resolved for the user if the user may read the note.
+// Links to notes the user may not read are returned dangling.
+func (s *linkService) Links(ctx context.Context, u *user.User, noteID uuid.UUID) ([]*link.Resolved, error) {
+	if _, err := s.getNote(ctx, u, noteID); err != nil {
+		return nil, fmt.Errorf("get note links: %w", err)
+	}
+
+	links, err := s.linkRepo.GetBySource(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("get note links: %w (user %s)", err, u.ID)
+	}
+
+	ids := make([]uuid.UUID, 0, len(links))
+	for _, l := range links {
+		if l.TargetID.Valid {
+			ids = append(ids, l.TargetID.UUID)
+		}
+	}
+
+	notes, err := s.readable(ctx, u, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get note links: %w", err)
+	}
+
+	resolved := make([]*link.Resolved, len(links))
+	for i, l := range links {
+		resolved[i] = &link.Resolved{Target: l.Target}
+		if l.TargetID.Valid {
+			resolved[i].Note = notes[l.TargetID.UUID]
+		}
+	}
+
+	return resolved, nil
+}
+
+// Backlinks returns the notes the user may read linking to the note if the user may read the note.
+func (s *linkService) Backlinks(ctx context.Context, u *user.User, noteID uuid.UUID) ([]*note.Note, error) {
+	if _, err := s.getNote(ctx, u, noteID); err != nil {
+		return nil, fmt.Errorf("get note backlinks: %w", err)
+	}
+
+	links, err := s.linkRepo.GetByTarget(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("get note backlinks: %w (user %s)", err, u.ID)
+	}
+
+	ids := make([]uuid.UUID, len(links))
+	for i, l := range links {
+		ids[i] = l.SourceID
+	}
+
+	notes, err := s.readable(ctx, u, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get note backlinks: %w", err)
+	}
+
+	backlinks := make([]*note.Note, 0, len(notes))
+	for _, id := range ids {
+		if n, ok := notes[id]; ok {
+			backlinks = append(backlinks, n)
+			delete(notes, id)
+		}
+	}
+
+	return backlinks, nil
+}
+
+// Dangling returns the links resolving to no note written in the notes the user may read.
+func (s *linkService) Dangling(ctx context.Context, u *user.User) ([]*link.Dangling, error) {
+	links, err := s.linkRepo.GetDangling(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("get dangling links: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(links))
+	for i, l := range links {
+		ids[i] = l.SourceID
+	}
+
+	notes, err := s.readable(ctx, u, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get dangling links: %w", err)
+	}
+
+	dangling := make([]*link.Dangling, 0, len(links))
+	for _, l := range links {
+		if n, ok := notes[l.SourceID]; ok {
+			dangling = append(dangling, &link.Dangling{Source: n, Target: l.Target})
+		}
+	}
+
+	return dangling, nil
+}
+
+// HandleEvent keeps the links of the saved and deleted notes. The current state of the saved note is taken,
+// so stale events change nothing: references to the former title of the note are renamed in the linking notes,
+// the links of the note are resolved again and dangling links to its title resolve to it. Dangling links
+// to the title of the deleted note resolve to the remaining note with the title, if any.
+func (s *linkService) HandleEvent(ctx context.Context, ev *event.Event) error {
+	var payload event.NotePayload
+	if err := ev.Decode(&payload); err != nil {
+		return fmt.Errorf("handle link event: decode payload: %w (event %s)", err, ev.ID)
+	}
+
+	if ev.Type == event.TypeNoteDeleted {
+		scope := link.Scope{UserID: payload.UserID}
+		if payload.OrgID != uuid.Nil {
+			scope.OrgID = uuid.NullUUID{UUID: payload.OrgID, Valid: true}
+		}
+
+		if err := s.resolveDangling(ctx, scope, payload.Name); err != nil {
+			return fmt.Errorf("handle link event: %w (event %s)", err, ev.ID)
+		}
+
+		return nil
+	}
+
+	notes, err := s.linkRepo.GetNotes(ctx, []uuid.UUID{payload.ID})
+	if err != nil {
+		return fmt.Errorf("handle link event: %w (event %s)", err, ev.ID)
+	}
+
+	if len(notes) == 0 {
+		return nil
+	}
+
+	n := notes[0]
+	if err := s.rename(ctx, n); err != nil {
+		return fmt.Errorf("handle link event: %w (event %s)", err, ev.ID)
+	}
+
+	if err := s.index(ctx, n); err != nil {
+		return fmt.Errorf("handle link event: %w (event %s)", err, ev.ID)
+	}
+
+	if err := s.resolveDangling(ctx, noteScope(n), n.Name); err != nil {
+		return fmt.Errorf("handle link event: %w (event %s)", err, ev.ID)
+	}
+
+	return nil
+}
+
+// rename replaces the former title of the note with the current one in the notes linking to it by the title.
+// The text of each changed note is saved as its next version, the renamed links are kept again from its event.
+// The links are left as they are when the current title cannot be written as a link target.
+func (s *linkService) rename(ctx context.Context, n *note.Note) error {
+	if !wikilink.ValidTarget(n.Name) {
+		return nil
+	}
+
+	links, err := s.linkRepo.GetByTarget(ctx, n.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range links {
+		if !l.ByTitle() || l.Target == n.Name {
+			continue
+		}
+
+		source := n
+		if l.SourceID != n.ID {
+			sources, err := s.linkRepo.GetNotes(ctx, []uuid.UUID{l.SourceID})
+			if err != nil {
+				return err
+			}
+
+			if len(sources) == 0 {
+				continue
+			}
+
+			source = sources[0]
+		}
+
+		if err := s.renameIn(ctx, source, l.Target, n.Name); err != nil {
+			return err
+		}
+
+		if err := s.linkRepo.Delete(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renameIn replaces the links to the title in the text of the note, saving the text and publishing the changed
+// note. Each attempt runs in a nested transaction, so the note changed concurrently rolls back the attempt only
+// and the links are renamed again in the current text of the note.
+func (s *linkService) renameIn(ctx context.Context, n *note.Note, from, to string) error {
+	for attempt := 1; ; attempt++ {
+		err := s.tx.Transact(ctx, func(ctx context.Context) error {
+			return s.renameText(ctx, n, from, to)
+		})
+		if !errors.Is(err, note.ErrVersionConflict) || attempt >= noteUpdateAttempts {
+			return err
+		}
+
+		notes, err := s.linkRepo.GetNotes(ctx, []uuid.UUID{n.ID})
+		if err != nil {
+			return fmt.Errorf("rename links: %w (note %s)", err, n.ID)
+		}
+
+		if len(notes) == 0 {
+			return nil
+		}
+
+		*n = *notes[0]
+	}
+}
+
+// renameText replaces the links to the title in the text of the note, saving the text as the next version
+// of the note and publishing the changed note.
+func (s *linkService) renameText(ctx context.Context, n *note.Note, from, to string) error {
+	text := wikilink.Rename(n.Text, from, to)
+	if text == n.Text {
+		return nil
+	}
+
+	n.Text = text
+	if err := setPlainText(n); err != nil {
+		return fmt.Errorf("rename links: %w (note %s)", err, n.ID)
+	}
+
+	n.UpdatedAt = driver.ZeroTime(time.Now())
+	n.Version++
+	if err := s.noteRepo.SaveFields(ctx, n, []note.Field{note.FieldText}); err != nil {
+		return fmt.Errorf("rename links: %w", err)
+	}
+
+	e, err := event.NewNoteEvent(event.TypeNoteUpdated, n)
+	if err != nil {
+		return err
+	}
+
+	return s.events.Publish(ctx, e)
+}
+
+// index resolves the links written in the text of the note, replacing the links kept for the note.
+func (s *linkService) index(ctx context.Context, n *note.Note) error {
+	existing, err := s.linkRepo.GetBySource(ctx, n.ID)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[string]*link.Link, len(existing))
+	for _, l := range existing {
+		kept[l.Target] = l
+	}
+
+	scope := noteScope(n)
+	for _, wl := range wikilink.Parse(n.Text) {
+		l, ok := kept[wl.Target]
+		if !ok {
+			l = &link.Link{SourceID: n.ID, Target: wl.Target, CreatedAt: time.Now()}
+		}
+
+		delete(kept, wl.Target)
+		if l.TargetID, err = s.resolve(ctx, scope, wl.Target); err != nil {
+			return err
+		}
+
+		l.UserID, l.OrgID = scope.UserID, scope.OrgID
+		if err := s.linkRepo.Save(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range kept {
+		if err := s.linkRepo.Delete(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the note of the scope the target refers to, by the identifier or by the title.
+func (s *linkService) resolve(ctx context.Context, scope link.Scope, target string) (uuid.NullUUID, error) {
+	id, err := uuid.Parse(target)
+	if err == nil {
+		id, err = s.linkRepo.FindByID(ctx, scope, id)
+	} else {
+		id, err = s.linkRepo.FindByTitle(ctx, scope, target)
+	}
+
+	if errors.Is(err, link.ErrTargetNotFound) {
+		return uuid.NullUUID{}, nil
+	}
+
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	return uuid.NullUUID{UUID: id, Valid: true}, nil
+}
+
+// resolveDangling resolves the dangling links of the scope to the title, if a note of the scope has the title.
+func (s *linkService) resolveDangling(ctx context.Context, scope link.Scope, title string) error {
+	links, err := s.linkRepo.GetDanglingByTarget(ctx, scope, title)
+	if err != nil || len(links) == 0 {
+		return err
+	}
+
+	targetID, err := s.resolve(ctx, scope, title)
+	if err != nil || !targetID.Valid {
+		return err
+	}
+
+	for _, l := range links {
+		l.TargetID = targetID
+		if err := s.linkRepo.Save(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readable returns the notes the user may read among the identifiers by the identifier.
+func (s *linkService) readable(ctx context.Context, u *user.User, ids []uuid.UUID) (map[uuid.UUID]*note.Note, error) {
+	notes, err := s.noteRepo.GetByIDs(ctx, u, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	readable := make(map[uuid.UUID]*note.Note, len(notes))
+	for _, n := range notes {
+		granted, err := s.guard.IsGranted(ctx, rbac.READ, n, u)
+		if err != nil {
+			return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, n.ID)
+		}
+
+		if granted {
+			readable[n.ID] = n
+		}
+	}
+
+	return readable, nil
+}
+
+// getNote returns the note if the user may read the note.
+func (s *linkService) getNote(ctx context.Context, u *user.User, noteID uuid.UUID) (*note.Note, error) {
+	n, err := s.noteRepo.GetByID(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, noteID)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, n, u)
+	if err != nil {
+		return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf("%w (user %s, note %s)", note.ErrOperationForbiddenForUser, u.ID, noteID)
+	}
+
+	return n, nil
+}
+
+// noteScope returns the scope titles of the links written in the note resolve in.
+func noteScope(n *note.Note) link.Scope {
+	return link.Scope{UserID: n.UserId, OrgID: n.OrgID}
+}
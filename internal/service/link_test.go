@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/link"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_link"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+type linkMocks struct {
+	repo  *mock_link.Repository
+	notes *mock_note.Repository
+	guard *mock_note.Guarder
+}
+
+func TestLinkService_Links(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	source := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	readable := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Trip"}
+	hidden := &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Salaries"}
+	links := []*link.Link{
+		{SourceID: source.ID, Target: "Trip", TargetID: uuid.NullUUID{UUID: readable.ID, Valid: true}},
+		{SourceID: source.ID, Target: hidden.ID.String(), TargetID: uuid.NullUUID{UUID: hidden.ID, Valid: true}},
+		{SourceID: source.ID, Target: "Packing list"},
+	}
+
+	cases := []struct {
+		name        string
+		expected    []*link.Resolved
+		expectedErr error
+		mocker      func(m *linkMocks)
+	}{
+		{
+			name: "successful_links",
+			expected: []*link.Resolved{
+				{Target: "Trip", Note: readable},
+				{Target: hidden.ID.String()},
+				{Target: "Packing list"},
+			},
+			mocker: func(m *linkMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, source.ID).Return(source, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, source, u).Return(true, nil).Once()
+				m.repo.EXPECT().GetBySource(mock.Anything, source.ID).Return(links, nil).Once()
+				m.notes.EXPECT().GetByIDs(mock.Anything, u, []uuid.UUID{readable.ID, hidden.ID}).
+					Return([]*note.Note{readable, hidden}, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, readable, u).Return(true, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, hidden, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			expectedErr: note.ErrOperationForbiddenForUser,
+			mocker: func(m *linkMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, source.ID).Return(source, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, source, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "note_not_found",
+			expectedErr: note.ErrNotFound,
+			mocker: func(m *linkMocks) {
+				m.notes.EXPECT().GetByID(mock.Anything, u, source.ID).Return(nil, note.ErrNotFound).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &linkMocks{
+				repo:  mock_link.NewRepository(t),
+				notes: mock_note.NewRepository(t),
+				guard: mock_note.NewGuarder(t),
+			}
+			tc.mocker(m)
+
+			service := NewLinkService(&LinkServiceDeps{LinkRepo: m.repo, NoteRepo: m.notes, NoteGuard: m.guard})
+			resolved, err := service.Links(context.Background(), u, source.ID)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestLinkService_Backlinks(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	target := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	first := &note.Note{ID: uuid.Must(uuid.NewV7())}
+	second := &note.Note{ID: uuid.Must(uuid.NewV7())}
+	hidden := &note.Note{ID: uuid.Must(uuid.NewV7())}
+	ids := []uuid.UUID{first.ID, hidden.ID, second.ID}
+
+	m := &linkMocks{
+		repo:  mock_link.NewRepository(t),
+		notes: mock_note.NewRepository(t),
+		guard: mock_note.NewGuarder(t),
+	}
+	m.notes.EXPECT().GetByID(mock.Anything, u, target.ID).Return(target, nil).Once()
+	m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, target, u).Return(true, nil).Once()
+	m.repo.EXPECT().GetByTarget(mock.Anything, target.ID).Return([]*link.Link{
+		{SourceID: first.ID, TargetID: uuid.NullUUID{UUID: target.ID, Valid: true}},
+		{SourceID: hidden.ID, TargetID: uuid.NullUUID{UUID: target.ID, Valid: true}},
+		{SourceID: second.ID, TargetID: uuid.NullUUID{UUID: target.ID, Valid: true}},
+	}, nil).Once()
+	m.notes.EXPECT().GetByIDs(mock.Anything, u, ids).Return([]*note.Note{second, first, hidden}, nil).Once()
+	m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, first, u).Return(true, nil).Once()
+	m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, second, u).Return(true, nil).Once()
+	m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, hidden, u).Return(false, nil).Once()
+
+	service := NewLinkService(&LinkServiceDeps{LinkRepo: m.repo, NoteRepo: m.notes, NoteGuard: m.guard})
+	backlinks, err := service.Backlinks(context.Background(), u, target.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*note.Note{first, second}, backlinks)
+}
+
+func TestLinkService_HandleEvent(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.Must(uuid.NewV7())
+	scope := link.Scope{UserID: userID}
+	otherID := uuid.Must(uuid.NewV7())
+	tripID := uuid.Must(uuid.NewV7())
+
+	cases := []struct {
+		name   string
+		typ    event.Type
+		note   *note.Note
+		mocker func(m *linkMocks, n *note.Note)
+	}{
+		{
+			name: "index_links",
+			typ:  event.TypeNoteCreated,
+			note: &note.Note{
+				ID:     uuid.Must(uuid.NewV7()),
+				Name:   "Plans",
+				Text:   "See [[Trip|the trip]], [[" + otherID.String() + "]] and [[Packing list]]",
+				UserId: userID,
+			},
+			mocker: func(m *linkMocks, n *note.Note) {
+				stale := &link.Link{ID: uuid.Must(uuid.NewV7()), SourceID: n.ID, Target: "Old"}
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{n.ID}).Return([]*note.Note{n}, nil).Once()
+				m.repo.EXPECT().GetByTarget(mock.Anything, n.ID).Return(nil, nil).Once()
+				m.repo.EXPECT().GetBySource(mock.Anything, n.ID).Return([]*link.Link{stale}, nil).Once()
+				m.repo.EXPECT().FindByTitle(mock.Anything, scope, "Trip").Return(tripID, nil).Once()
+				m.repo.EXPECT().FindByID(mock.Anything, scope, otherID).Return(uuid.Nil, link.ErrTargetNotFound).Once()
+				m.repo.EXPECT().FindByTitle(mock.Anything, scope, "Packing list").
+					Return(uuid.Nil, link.ErrTargetNotFound).Once()
+				expected := map[string]uuid.NullUUID{
+					"Trip":           {UUID: tripID, Valid: true},
+					otherID.String(): {},
+					"Packing list":   {},
+				}
+				m.repo.EXPECT().Save(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, l *link.Link) error {
+						require.Equal(t, n.ID, l.SourceID)
+						require.Equal(t, userID, l.UserID)
+						require.Contains(t, expected, l.Target)
+						require.Equal(t, expected[l.Target], l.TargetID)
+						return nil
+					}).Times(3)
+				m.repo.EXPECT().Delete(mock.Anything, stale).Return(nil).Once()
+				m.repo.EXPECT().GetDanglingByTarget(mock.Anything, scope, "Plans").Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "rename_references",
+			typ:  event.TypeNoteUpdated,
+			note: &note.Note{ID: tripID, Name: "Summer trip", UserId: userID},
+			mocker: func(m *linkMocks, n *note.Note) {
+				source := &note.Note{
+					ID:      uuid.Must(uuid.NewV7()),
+					Text:    "Read [[Trip|the trip]] and [[Trip]]",
+					UserId:  userID,
+					Version: 2,
+				}
+				renamed := &link.Link{
+					SourceID: source.ID,
+					Target:   "Trip",
+					TargetID: uuid.NullUUID{UUID: n.ID, Valid: true},
+				}
+				byID := &link.Link{
+					SourceID: uuid.Must(uuid.NewV7()),
+					Target:   n.ID.String(),
+					TargetID: uuid.NullUUID{UUID: n.ID, Valid: true},
+				}
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{n.ID}).Return([]*note.Note{n}, nil).Once()
+				m.repo.EXPECT().GetByTarget(mock.Anything, n.ID).Return([]*link.Link{renamed, byID}, nil).Once()
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{source.ID}).Return([]*note.Note{source}, nil).Once()
+				m.notes.EXPECT().SaveFields(mock.Anything, source, []note.Field{note.FieldText}).
+					RunAndReturn(func(_ context.Context, s *note.Note, _ []note.Field) error {
+						require.Equal(t, "Read [[Summer trip|the trip]] and [[Summer trip]]", s.Text)
+						require.Equal(t, int64(3), s.Version)
+						require.False(t, time.Time(s.UpdatedAt).IsZero())
+						return nil
+					}).Once()
+				m.repo.EXPECT().Delete(mock.Anything, renamed).Return(nil).Once()
+				m.repo.EXPECT().GetBySource(mock.Anything, n.ID).Return(nil, nil).Once()
+				m.repo.EXPECT().GetDanglingByTarget(mock.Anything, scope, "Summer trip").Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "rename_changed_concurrently",
+			typ:  event.TypeNoteUpdated,
+			note: &note.Note{ID: tripID, Name: "Summer trip", UserId: userID},
+			mocker: func(m *linkMocks, n *note.Note) {
+				sourceID := uuid.Must(uuid.NewV7())
+				stale := &note.Note{ID: sourceID, Text: "Read [[Trip]]", UserId: userID, Version: 2}
+				current := &note.Note{ID: sourceID, Text: "Packed for [[Trip]]", UserId: userID, Version: 3}
+				renamed := &link.Link{
+					SourceID: sourceID,
+					Target:   "Trip",
+					TargetID: uuid.NullUUID{UUID: n.ID, Valid: true},
+				}
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{n.ID}).Return([]*note.Note{n}, nil).Once()
+				m.repo.EXPECT().GetByTarget(mock.Anything, n.ID).Return([]*link.Link{renamed}, nil).Once()
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{sourceID}).Return([]*note.Note{stale}, nil).Once()
+				m.notes.EXPECT().SaveFields(mock.Anything, stale, []note.Field{note.FieldText}).
+					Return(note.ErrVersionConflict).Once()
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{sourceID}).Return([]*note.Note{current}, nil).Once()
+				m.notes.EXPECT().SaveFields(mock.Anything, stale, []note.Field{note.FieldText}).
+					RunAndReturn(func(_ context.Context, s *note.Note, _ []note.Field) error {
+						require.Equal(t, "Packed for [[Summer trip]]", s.Text)
+						require.Equal(t, int64(4), s.Version)
+						return nil
+					}).Once()
+				m.repo.EXPECT().Delete(mock.Anything, renamed).Return(nil).Once()
+				m.repo.EXPECT().GetBySource(mock.Anything, n.ID).Return(nil, nil).Once()
+				m.repo.EXPECT().GetDanglingByTarget(mock.Anything, scope, "Summer trip").Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "rename_to_title_with_pipe",
+			typ:  event.TypeNoteUpdated,
+			note: &note.Note{ID: tripID, Name: "Trip | 2025", UserId: userID},
+			mocker: func(m *linkMocks, n *note.Note) {
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{n.ID}).Return([]*note.Note{n}, nil).Once()
+				m.repo.EXPECT().GetBySource(mock.Anything, n.ID).Return(nil, nil).Once()
+				m.repo.EXPECT().GetDanglingByTarget(mock.Anything, scope, "Trip | 2025").Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "resolve_dangling",
+			typ:  event.TypeNoteCreated,
+			note: &note.Note{ID: tripID, Name: "Trip", UserId: userID},
+			mocker: func(m *linkMocks, n *note.Note) {
+				dangling := &link.Link{SourceID: uuid.Must(uuid.NewV7()), Target: "Trip", UserID: userID}
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{n.ID}).Return([]*note.Note{n}, nil).Once()
+				m.repo.EXPECT().GetByTarget(mock.Anything, n.ID).Return(nil, nil).Once()
+				m.repo.EXPECT().GetBySource(mock.Anything, n.ID).Return(nil, nil).Once()
+				m.repo.EXPECT().GetDanglingByTarget(mock.Anything, scope, "Trip").
+					Return([]*link.Link{dangling}, nil).Once()
+				m.repo.EXPECT().FindByTitle(mock.Anything, scope, "Trip").Return(n.ID, nil).Once()
+				m.repo.EXPECT().Save(mock.Anything, dangling).RunAndReturn(func(_ context.Context, l *link.Link) error {
+					require.Equal(t, uuid.NullUUID{UUID: n.ID, Valid: true}, l.TargetID)
+					return nil
+				}).Once()
+			},
+		},
+		{
+			name: "stale_event",
+			typ:  event.TypeNoteUpdated,
+			note: &note.Note{ID: tripID, Name: "Trip", UserId: userID},
+			mocker: func(m *linkMocks, n *note.Note) {
+				m.repo.EXPECT().GetNotes(mock.Anything, []uuid.UUID{n.ID}).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "deleted_note",
+			typ:  event.TypeNoteDeleted,
+			note: &note.Note{ID: tripID, Name: "Trip", UserId: userID},
+			mocker: func(m *linkMocks, _ *note.Note) {
+				m.repo.EXPECT().GetDanglingByTarget(mock.Anything, scope, "Trip").
+					Return([]*link.Link{{Target: "Trip"}}, nil).Once()
+				m.repo.EXPECT().FindByTitle(mock.Anything, scope, "Trip").
+					Return(uuid.Nil, link.ErrTargetNotFound).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &linkMocks{
+				repo:  mock_link.NewRepository(t),
+				notes: mock_note.NewRepository(t),
+			}
+			tc.mocker(m, tc.note)
+
+			service := NewLinkService(&LinkServiceDeps{
+				LinkRepo:  m.repo,
+				NoteRepo:  m.notes,
+				Events:    newEventPublisher(t),
+				TxManager: mock_tx.NewMockTxManager(),
+			})
+
+			ev, err := event.NewNoteEvent(tc.typ, tc.note)
+			require.NoError(t, err)
+			require.NoError(t, service.HandleEvent(context.Background(), ev))
+		})
+	}
+}
@@ -1,42 +1,71 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/event"
 	"github.com/xsqrty/notes/internal/domain/note"
 	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/tx"
 	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/diff3"
+	"github.com/xsqrty/notes/pkg/keywords"
+	"github.com/xsqrty/notes/pkg/lru"
+	"github.com/xsqrty/notes/pkg/markup"
 	"github.com/xsqrty/notes/pkg/rbac"
 	"github.com/xsqrty/op/driver"
 )
 
+// noteUpdateAttempts is the number of attempts to update the note changed concurrently.
+const noteUpdateAttempts = 3
+
+// contentFields are the versioned fields of the note saved by the content updates.
+var contentFields = []note.Field{note.FieldName, note.FieldText, note.FieldFormat}
+
 // NoteServiceDeps represents the dependencies required to construct a note service.
 type NoteServiceDeps struct {
+	TxManager tx.Manager
 	NoteRepo  note.Repository
 	NoteGuard note.Guarder
+	Audit     audit.Recorder
+	Events    event.Publisher
+	// RenderCache holds the rendered versions of notes, versions are immutable so entries are never invalidated.
+	RenderCache *lru.Cache[note.RenderKey, *note.Rendered]
 }
 
 // noteService is a struct that implements the note.Service interface for managing notes.
 type noteService struct {
-	noteRepo note.Repository
-	guard    note.Guarder
+	tx          tx.Manager
+	noteRepo    note.Repository
+	guard       note.Guarder
+	audit       audit.Recorder
+	events      event.Publisher
+	renderCache *lru.Cache[note.RenderKey, *note.Rendered]
 }
 
 // NewNoteService initializes and returns a new implementation of the note.Service interface using the provided dependencies.
 func NewNoteService(deps *NoteServiceDeps) note.Service {
 	return &noteService{
-		noteRepo: deps.NoteRepo,
-		guard:    deps.NoteGuard,
+		tx:          deps.TxManager,
+		noteRepo:    deps.NoteRepo,
+		guard:       deps.NoteGuard,
+		audit:       deps.Audit,
+		events:      deps.Events,
+		renderCache: deps.RenderCache,
 	}
 }
 
 // Create generates a new note using the provided data for a user, ensuring that the user has the required permissions.
 func (s *noteService) Create(ctx context.Context, u *user.User, data *note.CreateData) (*note.Note, error) {
-	granted, err := s.guard.IsGranted(ctx, rbac.CREATE, nil, u)
+	n := newNote(u, data)
+	granted, err := s.guard.IsGranted(ctx, rbac.CREATE, n, u)
 	if err != nil {
 		return nil, fmt.Errorf("create note: check granted: %w (user %s)", err, u.ID)
 	}
@@ -45,14 +74,7 @@ func (s *noteService) Create(ctx context.Context, u *user.User, data *note.Creat
 		return nil, fmt.Errorf("create note: %w (user %s)", note.ErrOperationForbiddenForUser, u.ID)
 	}
 
-	n := &note.Note{
-		Name:      data.Name,
-		Text:      data.Text,
-		UserId:    u.ID,
-		CreatedAt: time.Now(),
-	}
-
-	if err := s.noteRepo.Save(ctx, n); err != nil {
+	if err := s.create(ctx, u, n); err != nil {
 		return nil, fmt.Errorf("create note: %w (user %s)", err, u.ID)
 	}
 
@@ -61,7 +83,7 @@ func (s *noteService) Create(ctx context.Context, u *user.User, data *note.Creat
 
 // Get retrieves a note by its ID if the user has the required permission to access it.
 func (s *noteService) Get(ctx context.Context, u *user.User, id uuid.UUID) (*note.Note, error) {
-	curNote, err := s.noteRepo.GetByID(ctx, id)
+	curNote, err := s.noteRepo.GetByID(ctx, u, id)
 	if err != nil {
 		return nil, fmt.Errorf("get note: %w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, id)
 	}
@@ -83,46 +105,84 @@ func (s *noteService) Get(ctx context.Context, u *user.User, id uuid.UUID) (*not
 	return curNote, nil
 }
 
-// Update modifies an existing note with the provided data if the user is authorized and the note exists. Returns the updated note.
-func (s *noteService) Update(ctx context.Context, u *user.User, data *note.UpdateData) (*note.Note, error) {
-	curNote, err := s.noteRepo.GetByID(ctx, data.ID)
+// Render returns the text of the note rendered to sanitized HTML if the user has the permission to read the note.
+// Rendered versions are cached.
+func (s *noteService) Render(ctx context.Context, u *user.User, id uuid.UUID) (*note.Rendered, error) {
+	n, err := s.Get(ctx, u, id)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"update note: %w (user %s, note %s)",
-			errors.Join(note.ErrNotFound, err),
-			u.ID,
-			data.ID,
-		)
+		return nil, fmt.Errorf("render note: %w", err)
 	}
 
-	granted, err := s.guard.IsGranted(ctx, rbac.UPDATE, curNote, u)
+	key := note.RenderKey{NoteID: n.ID, Version: n.Version}
+	if rendered, ok := s.renderCache.Get(key); ok {
+		return rendered, nil
+	}
+
+	rendered, err := render(n)
 	if err != nil {
-		return nil, fmt.Errorf("update note: check granted: %w (user %s, note %s)", err, u.ID, curNote.ID)
+		return nil, fmt.Errorf("render note: %w (user %s, note %s)", err, u.ID, n.ID)
 	}
 
-	if !granted {
-		return nil, fmt.Errorf(
-			"update note: %w (user %s, note %s)",
-			note.ErrOperationForbiddenForUser,
-			u.ID,
-			curNote.ID,
-		)
+	s.renderCache.Set(key, rendered)
+	return rendered, nil
+}
+
+// Update modifies an existing note with the provided data if the user is authorized and the note exists. Returns the updated note.
+// The update made to an outdated version of the note is merged with the changes made since; the update is retried
+// when the note is changed concurrently.
+func (s *noteService) Update(ctx context.Context, u *user.User, data *note.UpdateData) (*note.Note, error) {
+	for attempt := 1; ; attempt++ {
+		n, err := s.update(ctx, u, data)
+		if errors.Is(err, note.ErrVersionConflict) && attempt < noteUpdateAttempts {
+			continue
+		}
+
+		return n, err
 	}
+}
 
-	curNote.UpdatedAt = driver.ZeroTime(time.Now())
-	curNote.Name = data.Name
-	curNote.Text = data.Text
+// Patch applies the partial update returned by the patcher for the current version of the note if the user
+// is authorized, writing the changed fields only. The patch is applied again to the note changed concurrently.
+func (s *noteService) Patch(ctx context.Context, u *user.User, id uuid.UUID, patcher note.Patcher) (*note.Note, error) {
+	for attempt := 1; ; attempt++ {
+		n, err := s.patch(ctx, u, id, patcher)
+		if errors.Is(err, note.ErrVersionConflict) && attempt < noteUpdateAttempts {
+			continue
+		}
 
-	if err := s.noteRepo.Save(ctx, curNote); err != nil {
-		return nil, fmt.Errorf("update note: %w (user %s, note %s)", err, u.ID, curNote.ID)
+		return n, err
 	}
+}
 
-	return curNote, nil
+// SetFlag sets or clears the flag of the note if the user is authorized to update the note. Flags are patched
+// like the other fields, but keep the update time and the version of the note and publish no event.
+func (s *noteService) SetFlag(
+	ctx context.Context,
+	u *user.User,
+	id uuid.UUID,
+	flag note.Field,
+	value bool,
+) (*note.Note, error) {
+	data := &note.PatchData{}
+	switch flag {
+	case note.FieldPinned:
+		data.Pinned = &value
+	case note.FieldArchived:
+		data.Archived = &value
+	case note.FieldFavourite:
+		data.Favourite = &value
+	default:
+		return nil, fmt.Errorf("set note flag: %w: %s (user %s, note %s)", note.ErrUnknownFlag, flag, u.ID, id)
+	}
+
+	return s.Patch(ctx, u, id, func(*note.Note) (*note.PatchData, error) {
+		return data, nil
+	})
 }
 
 // Delete removes a note by its ID if the user has the required permissions and returns the deleted note or an error.
 func (s *noteService) Delete(ctx context.Context, u *user.User, id uuid.UUID) (*note.Note, error) {
-	curNote, err := s.noteRepo.GetByID(ctx, id)
+	curNote, err := s.noteRepo.GetByID(ctx, u, id)
 	if err != nil {
 		return nil, fmt.Errorf("delete note: %w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, id)
 	}
@@ -141,13 +201,96 @@ func (s *noteService) Delete(ctx context.Context, u *user.User, id uuid.UUID) (*
 		)
 	}
 
-	if err := s.noteRepo.Delete(ctx, curNote); err != nil {
+	if err := s.remove(ctx, u, curNote); err != nil {
 		return nil, fmt.Errorf("delete note: %w (user %s, note %s)", err, u.ID, curNote.ID)
 	}
 
 	return curNote, nil
 }
 
+// Batch applies the operations of the batch, returning the results in the order of the operations. The notes
+// changed by the batch are read at once and every operation is checked before any is applied: operations on notes
+// which are not found or not allowed, and further operations on the same note, fail. Atomic batches are applied
+// in a single transaction only when all the operations are allowed, and the failure of an operation aborts
+// the others. Best-effort batches apply every allowed operation in its own transaction. Updates of notes changed
+// concurrently are not retried.
+func (s *noteService) Batch(ctx context.Context, u *user.User, req *note.BatchRequest) ([]*note.BatchResult, error) {
+	notes, err := s.batchNotes(ctx, u, req.Ops)
+	if err != nil {
+		return nil, fmt.Errorf("batch notes: %w (user %s)", err, u.ID)
+	}
+
+	results := make([]*note.BatchResult, len(req.Ops))
+	targets := make([]*note.Note, len(req.Ops))
+	changed := make(map[uuid.UUID]struct{}, len(notes))
+	for i, o := range req.Ops {
+		var (
+			n         *note.Note
+			operation rbac.Operation
+		)
+
+		switch o.Kind {
+		case note.BatchCreate:
+			n, operation = newNote(u, o.Create), rbac.CREATE
+		case note.BatchUpdate:
+			n, operation = notes[o.NoteID()], rbac.UPDATE
+		case note.BatchDelete:
+			n, operation = notes[o.NoteID()], rbac.DELETE
+		default:
+			return nil, fmt.Errorf("batch notes: operation %q is not described (user %s)", o.Kind, u.ID)
+		}
+
+		if o.Kind != note.BatchCreate {
+			id := o.NoteID()
+			if _, ok := changed[id]; ok {
+				results[i] = &note.BatchResult{Err: fmt.Errorf("%w (note %s)", note.ErrBatchDuplicate, id)}
+				continue
+			}
+
+			changed[id] = struct{}{}
+			if n == nil {
+				results[i] = &note.BatchResult{Err: fmt.Errorf("%w (note %s)", note.ErrNotFound, id)}
+				continue
+			}
+		}
+
+		granted, err := s.guard.IsGranted(ctx, operation, n, u)
+		if err != nil {
+			return nil, fmt.Errorf("batch notes: check granted: %w (user %s)", err, u.ID)
+		}
+
+		if !granted {
+			results[i] = &note.BatchResult{
+				Err: fmt.Errorf("%w (note %s)", note.ErrOperationForbiddenForUser, n.ID),
+			}
+			continue
+		}
+
+		targets[i] = n
+	}
+
+	if req.Mode == note.BatchAtomic {
+		if err := s.batchAtomic(ctx, u, req.Ops, targets, results); err != nil {
+			return nil, fmt.Errorf("batch notes: %w (user %s)", err, u.ID)
+		}
+
+		return results, nil
+	}
+
+	for i, o := range req.Ops {
+		if targets[i] == nil {
+			continue
+		}
+
+		results[i] = &note.BatchResult{Note: targets[i]}
+		if err := s.batchApply(ctx, u, o, targets[i]); err != nil {
+			results[i] = &note.BatchResult{Err: err}
+		}
+	}
+
+	return results, nil
+}
+
 // Search performs a search operation for notes belonging to the specified user based on the given request parameters.
 func (s *noteService) Search(
 	ctx context.Context,
@@ -170,3 +313,436 @@ func (s *noteService) Search(
 
 	return res, nil
 }
+
+// SearchByOrg performs a search operation for notes of the organisation the user is a member of.
+func (s *noteService) SearchByOrg(
+	ctx context.Context,
+	u *user.User,
+	orgID uuid.UUID,
+	req *search.Request,
+) (*search.Result[note.Note], error) {
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, nil, u)
+	if err != nil {
+		return nil, fmt.Errorf("search org note: check granted: %w (user %s, org %s)", err, u.ID, orgID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf(
+			"search org note: %w (user %s, org %s)",
+			note.ErrOperationForbiddenForUser,
+			u.ID,
+			orgID,
+		)
+	}
+
+	res, err := s.noteRepo.SearchByOrg(ctx, u, orgID, req)
+	if err != nil {
+		return nil, fmt.Errorf("search org note: %w (user %s, org %s)", err, u.ID, orgID)
+	}
+
+	return res, nil
+}
+
+// update applies the update to the current version of the note.
+func (s *noteService) update(ctx context.Context, u *user.User, data *note.UpdateData) (*note.Note, error) {
+	curNote, err := s.noteRepo.GetByID(ctx, u, data.ID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"update note: %w (user %s, note %s)",
+			errors.Join(note.ErrNotFound, err),
+			u.ID,
+			data.ID,
+		)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.UPDATE, curNote, u)
+	if err != nil {
+		return nil, fmt.Errorf("update note: check granted: %w (user %s, note %s)", err, u.ID, curNote.ID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf(
+			"update note: %w (user %s, note %s)",
+			note.ErrOperationForbiddenForUser,
+			u.ID,
+			curNote.ID,
+		)
+	}
+
+	if err := s.apply(ctx, u, curNote, data); err != nil {
+		return nil, fmt.Errorf("update note: %w (user %s, note %s)", err, u.ID, curNote.ID)
+	}
+
+	return curNote, nil
+}
+
+// patch applies the partial update to the current version of the note.
+func (s *noteService) patch(ctx context.Context, u *user.User, id uuid.UUID, patcher note.Patcher) (*note.Note, error) {
+	curNote, err := s.noteRepo.GetByID(ctx, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("patch note: %w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, id)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.UPDATE, curNote, u)
+	if err != nil {
+		return nil, fmt.Errorf("patch note: check granted: %w (user %s, note %s)", err, u.ID, curNote.ID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf(
+			"patch note: %w (user %s, note %s)",
+			note.ErrOperationForbiddenForUser,
+			u.ID,
+			curNote.ID,
+		)
+	}
+
+	data, err := patcher(curNote)
+	if err != nil {
+		return nil, fmt.Errorf("patch note: %w (user %s, note %s)", err, u.ID, curNote.ID)
+	}
+
+	var fields []note.Field
+	if data.Name != nil && *data.Name != curNote.Name {
+		curNote.Name = *data.Name
+		fields = append(fields, note.FieldName)
+	}
+
+	if data.Text != nil && *data.Text != curNote.Text {
+		curNote.Text = *data.Text
+		fields = append(fields, note.FieldText)
+	}
+
+	if data.Format != nil && *data.Format != curNote.Format {
+		curNote.Format = *data.Format
+		fields = append(fields, note.FieldFormat)
+	}
+
+	content := len(fields) > 0
+	if patchFlag(&curNote.Pinned, data.Pinned) {
+		fields = append(fields, note.FieldPinned)
+	}
+
+	if patchFlag(&curNote.Archived, data.Archived) {
+		fields = append(fields, note.FieldArchived)
+	}
+
+	if patchFlag(&curNote.Favourite, data.Favourite) {
+		fields = append(fields, note.FieldFavourite)
+	}
+
+	if len(fields) == 0 {
+		return curNote, nil
+	}
+
+	// flags are not versioned, so writing only them keeps the version and the revisions of the note
+	action := audit.ActionNoteFlag
+	if content {
+		action = audit.ActionNoteUpdate
+		curNote.Version++
+		curNote.UpdatedAt = driver.ZeroTime(time.Now())
+		if err := setPlainText(curNote); err != nil {
+			return nil, fmt.Errorf("patch note: %w (user %s, note %s)", err, u.ID, curNote.ID)
+		}
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.noteRepo.SaveFields(ctx, curNote, fields); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, audit.NewEvent(ctx, action, u.ID, audit.TargetNote, curNote.ID)); err != nil {
+			return err
+		}
+
+		if !content {
+			return nil
+		}
+
+		return s.publish(ctx, event.TypeNoteUpdated, curNote)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch note: %w (user %s, note %s)", err, u.ID, curNote.ID)
+	}
+
+	return curNote, nil
+}
+
+// patchFlag sets the flag to the value unless the value is nil, reporting whether the flag is changed.
+func patchFlag(flag *bool, value *bool) bool {
+	if value == nil || *value == *flag {
+		return false
+	}
+
+	*flag = *value
+	return true
+}
+
+// create saves the new note, recording the creation and publishing the event within a transaction.
+func (s *noteService) create(ctx context.Context, u *user.User, n *note.Note) error {
+	if err := setPlainText(n); err != nil {
+		return err
+	}
+
+	return s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.noteRepo.Save(ctx, n); err != nil {
+			return err
+		}
+
+		e := audit.NewEvent(ctx, audit.ActionNoteCreate, u.ID, audit.TargetNote, n.ID)
+		if err := s.audit.Record(ctx, e); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, event.TypeNoteCreated, n)
+	})
+}
+
+// apply merges the update into the current note and saves the next version of its content, recording the update and
+// publishing the event within a transaction. The flags and the checklist counters are left as they are stored, since
+// they are written without a version bump and the current note may be read before them.
+func (s *noteService) apply(ctx context.Context, u *user.User, curNote *note.Note, data *note.UpdateData) error {
+	name, text, err := s.merge(ctx, curNote, data)
+	if err != nil {
+		return err
+	}
+
+	curNote.UpdatedAt = driver.ZeroTime(time.Now())
+	curNote.Name = name
+	curNote.Text = text
+	curNote.Format = cmp.Or(data.Format, curNote.Format)
+	curNote.Version++
+	if err := setPlainText(curNote); err != nil {
+		return err
+	}
+
+	return s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.noteRepo.SaveFields(ctx, curNote, contentFields); err != nil {
+			return err
+		}
+
+		e := audit.NewEvent(ctx, audit.ActionNoteUpdate, u.ID, audit.TargetNote, curNote.ID)
+		if err := s.audit.Record(ctx, e); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, event.TypeNoteUpdated, curNote)
+	})
+}
+
+// remove deletes the note, recording the deletion and publishing the event within a transaction.
+func (s *noteService) remove(ctx context.Context, u *user.User, curNote *note.Note) error {
+	return s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.noteRepo.Delete(ctx, curNote); err != nil {
+			return err
+		}
+
+		e := audit.NewEvent(ctx, audit.ActionNoteDelete, u.ID, audit.TargetNote, curNote.ID)
+		if err := s.audit.Record(ctx, e); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, event.TypeNoteDeleted, curNote)
+	})
+}
+
+// batchNotes reads the notes updated and deleted by the operations, keyed by the identifiers.
+func (s *noteService) batchNotes(
+	ctx context.Context,
+	u *user.User,
+	ops []*note.BatchOp,
+) (map[uuid.UUID]*note.Note, error) {
+	ids := make([]uuid.UUID, 0, len(ops))
+	for _, o := range ops {
+		if o.Kind != note.BatchCreate {
+			ids = append(ids, o.NoteID())
+		}
+	}
+
+	notes, err := s.noteRepo.GetByIDs(ctx, u, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uuid.UUID]*note.Note, len(notes))
+	for _, n := range notes {
+		res[n.ID] = n
+	}
+
+	return res, nil
+}
+
+// batchAtomic applies the operations of the checked targets in a single transaction, filling the results.
+// Nothing is applied when any operation has failed the checks, and the operations not failed are aborted
+// when an operation fails. The error is returned when the transaction fails without a failed operation.
+func (s *noteService) batchAtomic(
+	ctx context.Context,
+	u *user.User,
+	ops []*note.BatchOp,
+	targets []*note.Note,
+	results []*note.BatchResult,
+) error {
+	if slices.Contains(targets, nil) {
+		abortBatch(results)
+		return nil
+	}
+
+	failed := false
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		for i, o := range ops {
+			if err := s.batchApply(ctx, u, o, targets[i]); err != nil {
+				results[i] = &note.BatchResult{Err: err}
+				failed = true
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if !failed {
+			return err
+		}
+
+		abortBatch(results)
+		return nil
+	}
+
+	for i := range results {
+		results[i] = &note.BatchResult{Note: targets[i]}
+	}
+
+	return nil
+}
+
+// batchApply applies the operation to the checked target note.
+func (s *noteService) batchApply(ctx context.Context, u *user.User, o *note.BatchOp, n *note.Note) error {
+	var err error
+	switch o.Kind {
+	case note.BatchCreate:
+		err = s.create(ctx, u, n)
+	case note.BatchUpdate:
+		err = s.apply(ctx, u, n, o.Update)
+	case note.BatchDelete:
+		err = s.remove(ctx, u, n)
+	}
+	if err != nil {
+		return fmt.Errorf("%s note: %w (note %s)", o.Kind, err, n.ID)
+	}
+
+	return nil
+}
+
+// abortBatch fails the operations without a result with note.ErrBatchAborted.
+func abortBatch(results []*note.BatchResult) {
+	for i := range results {
+		if results[i] == nil {
+			results[i] = &note.BatchResult{Err: note.ErrBatchAborted}
+		}
+	}
+}
+
+// merge returns the name and the text of the update merged with the changes made to the note since the base version
+// of the update. The revision missing for the base version is merged as an empty note.
+func (s *noteService) merge(ctx context.Context, n *note.Note, data *note.UpdateData) (string, string, error) {
+	if data.BaseVersion == 0 || data.BaseVersion == n.Version {
+		return data.Name, data.Text, nil
+	}
+
+	base, err := s.noteRepo.GetRevision(ctx, n.ID, data.BaseVersion)
+	if err != nil {
+		if !errors.Is(err, note.ErrRevisionNotFound) {
+			return "", "", err
+		}
+
+		base = &note.Revision{NoteID: n.ID, Version: data.BaseVersion}
+	}
+
+	name, nameConflicts := diff3.Merge(base.Name, data.Name, n.Name)
+	text, textConflicts := diff3.Merge(base.Text, data.Text, n.Text)
+	if len(nameConflicts) == 0 && len(textConflicts) == 0 {
+		return name, text, nil
+	}
+
+	conflicts := make([]note.Conflict, 0, len(nameConflicts)+len(textConflicts))
+	for _, c := range nameConflicts {
+		conflicts = append(conflicts, note.Conflict{
+			Field:  note.FieldName,
+			Base:   c.Base,
+			Client: c.Ours,
+			Server: c.Theirs,
+		})
+	}
+
+	for _, c := range textConflicts {
+		conflicts = append(conflicts, note.Conflict{
+			Field:  note.FieldText,
+			Base:   c.Base,
+			Client: c.Ours,
+			Server: c.Theirs,
+		})
+	}
+
+	return "", "", &note.MergeConflictError{Note: n, Conflicts: conflicts}
+}
+
+// newNote returns the first version of the note created by the user from the data.
+func newNote(u *user.User, data *note.CreateData) *note.Note {
+	n := &note.Note{
+		Name:      data.Name,
+		Text:      data.Text,
+		Format:    cmp.Or(data.Format, note.FormatPlain),
+		UserId:    u.ID,
+		OrgID:     data.OrgID,
+		Version:   1,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: driver.ZeroTime(data.UpdatedAt),
+	}
+
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	return n
+}
+
+// render renders the text of the note to sanitized HTML according to the format of the note.
+func render(n *note.Note) (*note.Rendered, error) {
+	rendered := &note.Rendered{NoteID: n.ID, Version: n.Version, Format: n.Format}
+	switch n.Format {
+	case note.FormatMarkdown:
+		html, err := markup.Markdown(n.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered.HTML = html
+	default:
+		rendered.HTML = markup.Plain(n.Text)
+	}
+
+	rendered.PlainText = markup.Text(rendered.HTML)
+	return rendered, nil
+}
+
+// setPlainText sets the text of the note rendered without the markup and the keywords of the plain text.
+func setPlainText(n *note.Note) error {
+	rendered, err := render(n)
+	if err != nil {
+		return err
+	}
+
+	n.PlainText = rendered.PlainText
+	n.Terms = keywords.Extract(n.PlainText, note.MaxTerms)
+	return nil
+}
+
+// publish writes the event of the note to the outbox.
+func (s *noteService) publish(ctx context.Context, typ event.Type, n *note.Note) error {
+	e, err := event.NewNoteEvent(typ, n)
+	if err != nil {
+		return err
+	}
+
+	return s.events.Publish(ctx, e)
+}
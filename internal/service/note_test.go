@@ -5,16 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/audit"
 	"github.com/xsqrty/notes/internal/domain/note"
 	"github.com/xsqrty/notes/internal/domain/search"
 	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_audit"
+	"github.com/xsqrty/notes/mocks/domain/mock_event"
 	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/pkg/lru"
 	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
 )
 
 func TestNoteService_Create(t *testing.T) {
@@ -42,7 +49,10 @@ func TestNoteService_Create(t *testing.T) {
 				Text: text,
 			},
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*note.Note)(nil), u).Return(true, nil).Once()
+				guard.EXPECT().
+					IsGranted(mock.Anything, rbac.CREATE, mock.AnythingOfType("*note.Note"), u).
+					Return(true, nil).
+					Once()
 				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
 			},
 		},
@@ -52,7 +62,10 @@ func TestNoteService_Create(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("create note: save err (user %s)", u.ID),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*note.Note)(nil), u).Return(true, nil).Once()
+				guard.EXPECT().
+					IsGranted(mock.Anything, rbac.CREATE, mock.AnythingOfType("*note.Note"), u).
+					Return(true, nil).
+					Once()
 				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(errors.New("save err")).Once()
 			},
 		},
@@ -62,7 +75,10 @@ func TestNoteService_Create(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("create note: note operation is forbidden for user (user %s)", u.ID),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*note.Note)(nil), u).Return(false, nil).Once()
+				guard.EXPECT().
+					IsGranted(mock.Anything, rbac.CREATE, mock.AnythingOfType("*note.Note"), u).
+					Return(false, nil).
+					Once()
 			},
 		},
 		{
@@ -72,7 +88,7 @@ func TestNoteService_Create(t *testing.T) {
 			expectedErr: fmt.Sprintf("create note: check granted: granted err (user %s)", u.ID),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
 				guard.EXPECT().
-					IsGranted(mock.Anything, rbac.CREATE, (*note.Note)(nil), u).
+					IsGranted(mock.Anything, rbac.CREATE, mock.AnythingOfType("*note.Note"), u).
 					Return(false, errors.New("granted err")).
 					Once()
 			},
@@ -87,7 +103,13 @@ func TestNoteService_Create(t *testing.T) {
 			repo := mock_note.NewRepository(t)
 			tc.mocker(repo, guard)
 
-			service := NewNoteService(&NoteServiceDeps{NoteRepo: repo, NoteGuard: guard})
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
 			result, err := service.Create(context.Background(), tc.user, &note.CreateData{
 				Name: name,
 				Text: text,
@@ -140,7 +162,7 @@ func TestNoteService_Get(t *testing.T) {
 			expected: n,
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
 				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(true, nil).Once()
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 			},
 		},
 		{
@@ -150,7 +172,7 @@ func TestNoteService_Get(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("get note: note not found\nno rows (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(nil, errors.New("no rows")).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(nil, errors.New("no rows")).Once()
 			},
 		},
 		{
@@ -160,7 +182,7 @@ func TestNoteService_Get(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("get note: note operation is forbidden for user (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(false, nil).Once()
 			},
 		},
@@ -171,7 +193,7 @@ func TestNoteService_Get(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("get note: check granted: granted error (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().
 					IsGranted(mock.Anything, rbac.READ, n, u).
 					Return(false, errors.New("granted error")).
@@ -188,7 +210,13 @@ func TestNoteService_Get(t *testing.T) {
 			repo := mock_note.NewRepository(t)
 			tc.mocker(repo, guard)
 
-			service := NewNoteService(&NoteServiceDeps{NoteRepo: repo, NoteGuard: guard})
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
 			result, err := service.Get(context.Background(), tc.user, tc.id)
 
 			if tc.expectedErr != "" {
@@ -201,6 +229,78 @@ func TestNoteService_Get(t *testing.T) {
 	}
 }
 
+func TestNoteService_Render(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+
+	cases := []struct {
+		name     string
+		note     *note.Note
+		expected *note.Rendered
+	}{
+		{
+			name: "markdown",
+			note: &note.Note{
+				ID:      uuid.Must(uuid.NewV7()),
+				Text:    "# Title\n\n**bold** <script>alert(1)</script> [link](javascript:alert(1))",
+				Format:  note.FormatMarkdown,
+				UserId:  u.ID,
+				Version: 2,
+			},
+			expected: &note.Rendered{
+				Version:   2,
+				Format:    note.FormatMarkdown,
+				HTML:      "<h1>Title</h1>\n<p><strong>bold</strong> alert(1) link</p>\n",
+				PlainText: "Title bold alert(1) link",
+			},
+		},
+		{
+			name: "plain",
+			note: &note.Note{
+				ID:      uuid.Must(uuid.NewV7()),
+				Text:    "# Title\n<b>bold</b>\n\nnext",
+				Format:  note.FormatPlain,
+				UserId:  u.ID,
+				Version: 1,
+			},
+			expected: &note.Rendered{
+				Version:   1,
+				Format:    note.FormatPlain,
+				HTML:      "<p># Title<br>\n&lt;b&gt;bold&lt;/b&gt;</p>\n<p>next</p>\n",
+				PlainText: "# Title <b>bold</b> next",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_note.NewGuarder(t)
+			repo := mock_note.NewRepository(t)
+			repo.EXPECT().GetByID(mock.Anything, u, tc.note.ID).Return(tc.note, nil).Times(2)
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, tc.note, u).Return(true, nil).Times(2)
+
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager:   mock_tx.NewMockTxManager(),
+				NoteRepo:    repo,
+				NoteGuard:   guard,
+				RenderCache: lru.New[note.RenderKey, *note.Rendered](10, time.Minute),
+			})
+
+			tc.expected.NoteID = tc.note.ID
+			rendered, err := service.Render(context.Background(), u, tc.note.ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rendered)
+
+			cached, err := service.Render(context.Background(), u, tc.note.ID)
+			require.NoError(t, err)
+			require.Same(t, rendered, cached)
+		})
+	}
+}
+
 func TestNoteService_Update(t *testing.T) {
 	t.Parallel()
 
@@ -235,9 +335,9 @@ func TestNoteService_Update(t *testing.T) {
 			expected: createNote(),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
 				n := createNote()
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, n, u).Return(true, nil).Once()
-				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				repo.EXPECT().SaveFields(mock.Anything, mock.Anything, contentFields).Return(nil).Once()
 			},
 		},
 		{
@@ -245,7 +345,7 @@ func TestNoteService_Update(t *testing.T) {
 			user:        u,
 			expectedErr: fmt.Sprintf("update note: note not found\nno rows (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(nil, errors.New("no rows")).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(nil, errors.New("no rows")).Once()
 			},
 		},
 		{
@@ -254,7 +354,7 @@ func TestNoteService_Update(t *testing.T) {
 			expectedErr: fmt.Sprintf("update note: note operation is forbidden for user (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
 				n := createNote()
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, n, u).Return(false, nil).Once()
 			},
 		},
@@ -264,7 +364,7 @@ func TestNoteService_Update(t *testing.T) {
 			expectedErr: fmt.Sprintf("update note: check granted: granted error (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
 				n := createNote()
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().
 					IsGranted(mock.Anything, rbac.UPDATE, n, u).
 					Return(false, errors.New("granted error")).
@@ -277,9 +377,12 @@ func TestNoteService_Update(t *testing.T) {
 			expectedErr: fmt.Sprintf("update note: connection unavailable (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
 				n := createNote()
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, n, u).Return(true, nil).Once()
-				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(errors.New("connection unavailable")).Once()
+				repo.EXPECT().
+					SaveFields(mock.Anything, mock.Anything, contentFields).
+					Return(errors.New("connection unavailable")).
+					Once()
 			},
 		},
 	}
@@ -292,7 +395,13 @@ func TestNoteService_Update(t *testing.T) {
 			repo := mock_note.NewRepository(t)
 			tc.mocker(repo, guard)
 
-			service := NewNoteService(&NoteServiceDeps{NoteRepo: repo, NoteGuard: guard})
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
 			result, err := service.Update(context.Background(), tc.user, &note.UpdateData{
 				ID:   id,
 				Name: name,
@@ -315,6 +424,384 @@ func TestNoteService_Update(t *testing.T) {
 	}
 }
 
+func TestNoteService_UpdateMerge(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	id := uuid.Must(uuid.NewV7())
+	base := &note.Revision{NoteID: id, Version: 1, Name: "shopping list", Text: "milk\nbread\neggs\n"}
+
+	cases := []struct {
+		name              string
+		data              *note.UpdateData
+		current           *note.Note
+		expected          *note.Note
+		expectedConflicts []note.Conflict
+	}{
+		{
+			name:     "current_version",
+			data:     &note.UpdateData{ID: id, Name: "list", Text: "milk\n", BaseVersion: 2},
+			current:  &note.Note{ID: id, UserId: u.ID, Name: "shopping list", Text: "milk\nbread\n", Version: 2},
+			expected: &note.Note{ID: id, UserId: u.ID, Name: "list", Text: "milk\n", Version: 3},
+		},
+		{
+			name: "merged",
+			data: &note.UpdateData{
+				ID:          id,
+				Name:        "weekly shopping list",
+				Text:        "milk\nbread\neggs\ntea\n",
+				BaseVersion: 1,
+			},
+			current: &note.Note{
+				ID:      id,
+				UserId:  u.ID,
+				Name:    "shopping list!",
+				Text:    "oat milk\nbread\neggs\n",
+				Version: 2,
+			},
+			expected: &note.Note{
+				ID:      id,
+				UserId:  u.ID,
+				Name:    "weekly shopping list!",
+				Text:    "oat milk\nbread\neggs\ntea\n",
+				Version: 3,
+			},
+		},
+		{
+			name: "conflict",
+			data: &note.UpdateData{
+				ID:          id,
+				Name:        "shopping list",
+				Text:        "milk\nwhite bread\neggs\n",
+				BaseVersion: 1,
+			},
+			current: &note.Note{
+				ID:      id,
+				UserId:  u.ID,
+				Name:    "shopping list",
+				Text:    "milk\nrye bread\neggs\n",
+				Version: 2,
+			},
+			expectedConflicts: []note.Conflict{
+				{Field: note.FieldText, Base: "bread\n", Client: "white bread\n", Server: "rye bread\n"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_note.NewGuarder(t)
+			repo := mock_note.NewRepository(t)
+			repo.EXPECT().GetByID(mock.Anything, u, id).Return(tc.current, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, tc.current, u).Return(true, nil).Once()
+			if tc.data.BaseVersion != tc.current.Version {
+				repo.EXPECT().GetRevision(mock.Anything, id, tc.data.BaseVersion).Return(base, nil).Once()
+			}
+
+			if tc.expected != nil {
+				repo.EXPECT().SaveFields(mock.Anything, tc.current, contentFields).Return(nil).Once()
+			}
+
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
+			result, err := service.Update(context.Background(), u, tc.data)
+			if tc.expectedConflicts != nil {
+				var conflictErr *note.MergeConflictError
+				require.ErrorAs(t, err, &conflictErr)
+				require.ErrorIs(t, err, note.ErrMergeConflict)
+				require.Equal(t, tc.expectedConflicts, conflictErr.Conflicts)
+				require.Equal(t, tc.current, conflictErr.Note)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.Name, result.Name)
+			require.Equal(t, tc.expected.Text, result.Text)
+			require.Equal(t, tc.expected.Version, result.Version)
+		})
+	}
+}
+
+func TestNoteService_UpdateRetry(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	id := uuid.Must(uuid.NewV7())
+
+	guard := mock_note.NewGuarder(t)
+	repo := mock_note.NewRepository(t)
+	stale := &note.Note{ID: id, UserId: u.ID, Name: "name", Text: "text", Version: 1}
+	current := &note.Note{ID: id, UserId: u.ID, Name: "name", Text: "text\nmore", Version: 2}
+	repo.EXPECT().GetByID(mock.Anything, u, id).Return(stale, nil).Once()
+	repo.EXPECT().GetByID(mock.Anything, u, id).Return(current, nil).Once()
+	guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Times(2)
+	repo.EXPECT().
+		GetRevision(mock.Anything, id, int64(1)).
+		Return(&note.Revision{NoteID: id, Version: 1, Name: "name", Text: "text"}, nil).
+		Once()
+	repo.EXPECT().SaveFields(mock.Anything, stale, contentFields).Return(note.ErrVersionConflict).Once()
+	repo.EXPECT().SaveFields(mock.Anything, current, contentFields).Return(nil).Once()
+
+	service := NewNoteService(&NoteServiceDeps{
+		TxManager: mock_tx.NewMockTxManager(),
+		NoteRepo:  repo,
+		NoteGuard: guard,
+		Audit:     newAuditRecorder(t),
+		Events:    newEventPublisher(t),
+	})
+	result, err := service.Update(context.Background(), u, &note.UpdateData{
+		ID:          id,
+		Name:        "new name",
+		Text:        "text",
+		BaseVersion: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "new name", result.Name)
+	require.Equal(t, "text\nmore", result.Text)
+	require.Equal(t, int64(3), result.Version)
+}
+
+func TestNoteService_Patch(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	id := uuid.Must(uuid.NewV7())
+	name := "Monthly plan"
+	newNote := func() *note.Note {
+		return &note.Note{ID: id, UserId: u.ID, Name: "Weekly plan", Text: "Call the bank", Version: 2}
+	}
+	rename := func(n *note.Note) (*note.PatchData, error) {
+		return &note.PatchData{Name: &name, Text: &n.Text}, nil
+	}
+
+	cases := []struct {
+		name        string
+		patcher     note.Patcher
+		expected    *note.Note
+		expectedErr string
+		mocker      func(repo *mock_note.Repository, guard *mock_note.Guarder)
+	}{
+		{
+			name:     "successful_patch",
+			patcher:  rename,
+			expected: &note.Note{Name: name, Text: "Call the bank", Version: 3},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				repo.EXPECT().SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldName}).Return(nil).Once()
+			},
+		},
+		{
+			name: "nothing_changed",
+			patcher: func(n *note.Note) (*note.PatchData, error) {
+				return &note.PatchData{Name: &n.Name}, nil
+			},
+			expected: &note.Note{Name: "Weekly plan", Text: "Call the bank", Version: 2},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:     "retried_on_version_conflict",
+			patcher:  rename,
+			expected: &note.Note{Name: name, Text: "Call the bank", Version: 3},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Times(2)
+				repo.EXPECT().
+					SaveFields(mock.Anything, mock.Anything, mock.Anything).
+					Return(note.ErrVersionConflict).
+					Once()
+				repo.EXPECT().SaveFields(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "patcher_error",
+			patcher: func(n *note.Note) (*note.PatchData, error) {
+				return nil, errors.New("invalid patch")
+			},
+			expectedErr: fmt.Sprintf("patch note: invalid patch (user %s, note %s)", u.ID, id),
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			patcher:     rename,
+			expectedErr: fmt.Sprintf("patch note: note operation is forbidden for user (user %s, note %s)", u.ID, id),
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "note_not_found",
+			patcher:     rename,
+			expectedErr: fmt.Sprintf("patch note: note not found\nno rows (user %s, note %s)", u.ID, id),
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(nil, errors.New("no rows")).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_note.NewGuarder(t)
+			repo := mock_note.NewRepository(t)
+			tc.mocker(repo, guard)
+
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
+			result, err := service.Patch(context.Background(), u, id, tc.patcher)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				require.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.Name, result.Name)
+			require.Equal(t, tc.expected.Text, result.Text)
+			require.Equal(t, tc.expected.Version, result.Version)
+		})
+	}
+}
+
+func TestNoteService_SetFlag(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	id := uuid.Must(uuid.NewV7())
+	updatedAt := driver.ZeroTime(time.Now().Add(-time.Hour))
+	newNote := func() *note.Note {
+		return &note.Note{ID: id, UserId: u.ID, Name: "Weekly plan", Version: 2, Pinned: true, UpdatedAt: updatedAt}
+	}
+
+	cases := []struct {
+		name        string
+		flag        note.Field
+		value       bool
+		expected    *note.Note
+		expectedErr string
+		written     bool
+		mocker      func(repo *mock_note.Repository, guard *mock_note.Guarder)
+	}{
+		{
+			name:     "successful_set",
+			flag:     note.FieldArchived,
+			value:    true,
+			expected: &note.Note{Version: 2, Pinned: true, Archived: true, UpdatedAt: updatedAt},
+			written:  true,
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				repo.EXPECT().
+					SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldArchived}).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:     "successful_clear",
+			flag:     note.FieldPinned,
+			expected: &note.Note{Version: 2, UpdatedAt: updatedAt},
+			written:  true,
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				repo.EXPECT().
+					SaveFields(mock.Anything, mock.Anything, []note.Field{note.FieldPinned}).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:     "already_set",
+			flag:     note.FieldPinned,
+			value:    true,
+			expected: &note.Note{Version: 2, Pinned: true, UpdatedAt: updatedAt},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:        "unknown_flag",
+			flag:        note.FieldName,
+			value:       true,
+			expectedErr: fmt.Sprintf("set note flag: unknown note flag: name (user %s, note %s)", u.ID, id),
+			mocker:      func(repo *mock_note.Repository, guard *mock_note.Guarder) {},
+		},
+		{
+			name:        "not_granted",
+			flag:        note.FieldFavourite,
+			value:       true,
+			expectedErr: fmt.Sprintf("patch note: note operation is forbidden for user (user %s, note %s)", u.ID, id),
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(newNote(), nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(false, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_note.NewGuarder(t)
+			repo := mock_note.NewRepository(t)
+			recorder := mock_audit.NewRecorder(t)
+			tc.mocker(repo, guard)
+			if tc.written {
+				recorder.EXPECT().
+					Record(mock.Anything, mock.MatchedBy(func(e *audit.Event) bool {
+						return e.Action == audit.ActionNoteFlag
+					})).
+					Return(nil).
+					Once()
+			}
+
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     recorder,
+				Events:    mock_event.NewPublisher(t),
+			})
+			result, err := service.SetFlag(context.Background(), u, id, tc.flag, tc.value)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				require.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.Version, result.Version)
+			require.Equal(t, tc.expected.Pinned, result.Pinned)
+			require.Equal(t, tc.expected.Archived, result.Archived)
+			require.Equal(t, tc.expected.Favourite, result.Favourite)
+			require.Equal(t, tc.expected.UpdatedAt, result.UpdatedAt)
+		})
+	}
+}
+
 func TestNoteService_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -345,7 +832,7 @@ func TestNoteService_Delete(t *testing.T) {
 			user:     u,
 			expected: n,
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, n, u).Return(true, nil).Once()
 				repo.EXPECT().Delete(mock.Anything, n).Return(nil).Once()
 			},
@@ -356,7 +843,7 @@ func TestNoteService_Delete(t *testing.T) {
 			user:        u,
 			expectedErr: fmt.Sprintf("delete note: can`t delete (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, n, u).Return(true, nil).Once()
 				repo.EXPECT().Delete(mock.Anything, n).Return(errors.New("can`t delete")).Once()
 			},
@@ -368,7 +855,7 @@ func TestNoteService_Delete(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("delete note: note not found\nno rows (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(nil, errors.New("no rows")).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(nil, errors.New("no rows")).Once()
 			},
 		},
 		{
@@ -378,7 +865,7 @@ func TestNoteService_Delete(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("delete note: note operation is forbidden for user (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, n, u).Return(false, nil).Once()
 			},
 		},
@@ -389,7 +876,7 @@ func TestNoteService_Delete(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Sprintf("delete note: check granted: granted error (user %s, note %s)", u.ID, id),
 			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
-				repo.EXPECT().GetByID(mock.Anything, id).Return(n, nil).Once()
+				repo.EXPECT().GetByID(mock.Anything, u, id).Return(n, nil).Once()
 				guard.EXPECT().
 					IsGranted(mock.Anything, rbac.DELETE, n, u).
 					Return(false, errors.New("granted error")).
@@ -406,7 +893,13 @@ func TestNoteService_Delete(t *testing.T) {
 			repo := mock_note.NewRepository(t)
 			tc.mocker(repo, guard)
 
-			service := NewNoteService(&NoteServiceDeps{NoteRepo: repo, NoteGuard: guard})
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
 			result, err := service.Delete(context.Background(), tc.user, tc.id)
 
 			if tc.expectedErr != "" {
@@ -419,6 +912,148 @@ func TestNoteService_Delete(t *testing.T) {
 	}
 }
 
+func TestNoteService_Batch(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	first := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID, Name: "first", Text: "first text", Version: 1}
+	second := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID, Name: "second", Text: "second text", Version: 1}
+	missing := uuid.Must(uuid.NewV7())
+	errDelete := errors.New("can`t delete")
+
+	create := &note.BatchOp{Kind: note.BatchCreate, Create: &note.CreateData{Name: "created", Text: "created text"}}
+	update := &note.BatchOp{
+		Kind:   note.BatchUpdate,
+		Update: &note.UpdateData{ID: first.ID, Name: "first updated", Text: "first text"},
+	}
+	remove := &note.BatchOp{Kind: note.BatchDelete, ID: second.ID}
+
+	cases := []struct {
+		name         string
+		mode         note.BatchMode
+		ops          []*note.BatchOp
+		expectedErrs []error
+		expectedErr  string
+		mocker       func(repo *mock_note.Repository, guard *mock_note.Guarder)
+	}{
+		{
+			name:         "successful_atomic",
+			mode:         note.BatchAtomic,
+			ops:          []*note.BatchOp{create, update, remove},
+			expectedErrs: []error{nil, nil, nil},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().
+					GetByIDs(mock.Anything, u, []uuid.UUID{first.ID, second.ID}).
+					Return([]*note.Note{{ID: first.ID, UserId: u.ID, Version: 1}, {ID: second.ID, UserId: u.ID}}, nil).
+					Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, mock.Anything, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, mock.Anything, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, mock.Anything, u).Return(true, nil).Once()
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				repo.EXPECT().SaveFields(mock.Anything, mock.Anything, contentFields).Return(nil).Once()
+				repo.EXPECT().Delete(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:         "atomic_not_found",
+			mode:         note.BatchAtomic,
+			ops:          []*note.BatchOp{{Kind: note.BatchDelete, ID: missing}, remove},
+			expectedErrs: []error{note.ErrNotFound, note.ErrBatchAborted},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().
+					GetByIDs(mock.Anything, u, []uuid.UUID{missing, second.ID}).
+					Return([]*note.Note{second}, nil).
+					Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, second, u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:         "atomic_apply_error",
+			mode:         note.BatchAtomic,
+			ops:          []*note.BatchOp{create, remove},
+			expectedErrs: []error{note.ErrBatchAborted, errDelete},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().
+					GetByIDs(mock.Anything, u, []uuid.UUID{second.ID}).
+					Return([]*note.Note{second}, nil).
+					Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, mock.Anything, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, second, u).Return(true, nil).Once()
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+				repo.EXPECT().Delete(mock.Anything, second).Return(errDelete).Once()
+			},
+		},
+		{
+			name:         "best_effort",
+			mode:         note.BatchBestEffort,
+			ops:          []*note.BatchOp{update, {Kind: note.BatchDelete, ID: missing}, remove, remove},
+			expectedErrs: []error{note.ErrOperationForbiddenForUser, note.ErrNotFound, nil, note.ErrBatchDuplicate},
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().
+					GetByIDs(mock.Anything, u, []uuid.UUID{first.ID, missing, second.ID, second.ID}).
+					Return([]*note.Note{first, second}, nil).
+					Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, first, u).Return(false, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, second, u).Return(true, nil).Once()
+				repo.EXPECT().Delete(mock.Anything, second).Return(nil).Once()
+			},
+		},
+		{
+			name:        "granted_error",
+			mode:        note.BatchBestEffort,
+			ops:         []*note.BatchOp{remove},
+			expectedErr: fmt.Sprintf("batch notes: check granted: granted error (user %s)", u.ID),
+			mocker: func(repo *mock_note.Repository, guard *mock_note.Guarder) {
+				repo.EXPECT().
+					GetByIDs(mock.Anything, u, []uuid.UUID{second.ID}).
+					Return([]*note.Note{second}, nil).
+					Once()
+				guard.EXPECT().
+					IsGranted(mock.Anything, rbac.DELETE, second, u).
+					Return(false, errors.New("granted error")).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_note.NewGuarder(t)
+			repo := mock_note.NewRepository(t)
+			tc.mocker(repo, guard)
+
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
+			results, err := service.Batch(context.Background(), u, &note.BatchRequest{Mode: tc.mode, Ops: tc.ops})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				require.Nil(t, results)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, results, len(tc.expectedErrs))
+			for i, expectedErr := range tc.expectedErrs {
+				if expectedErr == nil {
+					require.NoError(t, results[i].Err)
+					require.NotNil(t, results[i].Note)
+					continue
+				}
+
+				require.ErrorIs(t, results[i].Err, expectedErr)
+				require.Nil(t, results[i].Note)
+			}
+		})
+	}
+}
+
 func TestNoteService_Search(t *testing.T) {
 	t.Parallel()
 
@@ -499,7 +1134,13 @@ func TestNoteService_Search(t *testing.T) {
 			repo := mock_note.NewRepository(t)
 			tc.mocker(repo, guard)
 
-			service := NewNoteService(&NoteServiceDeps{NoteRepo: repo, NoteGuard: guard})
+			service := NewNoteService(&NoteServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				NoteRepo:  repo,
+				NoteGuard: guard,
+				Audit:     newAuditRecorder(t),
+				Events:    newEventPublisher(t),
+			})
 			result, err := service.Search(context.Background(), tc.user, tc.req)
 
 			if tc.expectedErr != "" {
@@ -0,0 +1,547 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/attachment"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/notefile"
+	"github.com/xsqrty/op/driver"
+)
+
+const (
+	// noteImportNameLength is the maximum number of characters kept of imported note names.
+	noteImportNameLength = 200
+	// noteImportUntitled is the name of imported notes without a title.
+	noteImportUntitled = "Untitled"
+	// noteImportContentType is the content type of import files kept in the blob store.
+	noteImportContentType = "application/octet-stream"
+)
+
+// errImportAttempts fails the job which was claimed too many times without finishing.
+var errImportAttempts = errors.New("import attempts are exhausted")
+
+// NoteImportServiceDeps represents the dependencies required to construct a note import service.
+type NoteImportServiceDeps struct {
+	TxManager     tx.Manager
+	ImportRepo    noteimport.Repository
+	UserRepo      user.Repository
+	Notes         note.Service
+	Blobs         attachment.BlobStore
+	MaxFileSize   int64
+	MaxNoteSize   int64
+	MaxNotes      int
+	MaxTextLength int
+	MaxAttempts   int
+	PollInterval  time.Duration
+	ClaimTimeout  time.Duration
+}
+
+// noteImportService is a struct that implements the noteimport.Service interface importing files of notes.
+type noteImportService struct {
+	tx            tx.Manager
+	importRepo    noteimport.Repository
+	userRepo      user.Repository
+	notes         note.Service
+	blobs         attachment.BlobStore
+	maxFileSize   int64
+	maxNoteSize   int64
+	maxNotes      int
+	maxTextLength int
+	maxAttempts   int
+	pollInterval  time.Duration
+	claimTimeout  time.Duration
+}
+
+// NewNoteImportService initializes and returns a new implementation of the noteimport.Service interface.
+func NewNoteImportService(deps *NoteImportServiceDeps) noteimport.Service {
+	return &noteImportService{
+		tx:            deps.TxManager,
+		importRepo:    deps.ImportRepo,
+		userRepo:      deps.UserRepo,
+		notes:         deps.Notes,
+		blobs:         deps.Blobs,
+		maxFileSize:   deps.MaxFileSize,
+		maxNoteSize:   deps.MaxNoteSize,
+		maxNotes:      deps.MaxNotes,
+		maxTextLength: deps.MaxTextLength,
+		maxAttempts:   deps.MaxAttempts,
+		pollInterval:  deps.PollInterval,
+		claimTimeout:  deps.ClaimTimeout,
+	}
+}
+
+// Start queues the import of the uploaded file, keeping the file in the blob store until the job finishes.
+// Uploading the file of an unfinished job of the user again returns that job instead of queueing another one.
+func (s *noteImportService) Start(
+	ctx context.Context,
+	u *user.User,
+	data *noteimport.UploadData,
+) (*noteimport.Job, error) {
+	format := data.Format
+	if format == "" {
+		var err error
+		if format, err = notefile.FormatOf(data.Name); err != nil {
+			return nil, fmt.Errorf("start import: %w (user %s)", err, u.ID)
+		}
+	}
+
+	file, size, checksum, err := s.spool(data.Content)
+	if err != nil {
+		return nil, fmt.Errorf("start import: %w (user %s)", err, u.ID)
+	}
+	defer os.Remove(file.Name()) // nolint: errcheck
+	defer file.Close()           // nolint: errcheck
+
+	j, err := s.importRepo.GetUnfinishedByChecksum(ctx, u.ID, checksum)
+	if err == nil {
+		return j, nil
+	}
+
+	if !errors.Is(err, noteimport.ErrNotFound) {
+		return nil, fmt.Errorf("start import: %w (user %s)", err, u.ID)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("start import (generate uuid): %w (user %s)", err, u.ID)
+	}
+
+	now := time.Now()
+	j = &noteimport.Job{
+		ID:           id,
+		UserID:       u.ID,
+		Name:         attachmentName(data.Name),
+		Format:       format,
+		Checksum:     checksum,
+		Size:         size,
+		Status:       noteimport.StatusPending,
+		ClaimedUntil: now,
+		CreatedAt:    now,
+	}
+
+	if err := s.blobs.Put(ctx, j.Key(), file, size, noteImportContentType); err != nil {
+		return nil, fmt.Errorf("start import: %w (user %s, import %s)", err, u.ID, j.ID)
+	}
+
+	if err := s.importRepo.Save(ctx, j); err != nil {
+		if delErr := s.blobs.Delete(ctx, j.Key()); delErr != nil {
+			err = errors.Join(err, delErr)
+		}
+
+		return nil, fmt.Errorf("start import: %w (user %s, import %s)", err, u.ID, j.ID)
+	}
+
+	return j, nil
+}
+
+// Get returns the import job with its progress if the user started it.
+func (s *noteImportService) Get(ctx context.Context, u *user.User, id uuid.UUID) (*noteimport.Job, error) {
+	j, err := s.getJob(ctx, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("get import: %w", err)
+	}
+
+	return j, nil
+}
+
+// Items returns the outcomes of the imported notes of the job if the user started it.
+// Empty status stands for items of any status.
+func (s *noteImportService) Items(
+	ctx context.Context,
+	u *user.User,
+	id uuid.UUID,
+	status noteimport.ItemStatus,
+	limit uint64,
+	offset uint64,
+) (*search.Result[noteimport.Item], error) {
+	j, err := s.getJob(ctx, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("list import items: %w", err)
+	}
+
+	res, err := s.importRepo.GetItems(ctx, j, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list import items: %w (user %s, import %s)", err, u.ID, j.ID)
+	}
+
+	return res, nil
+}
+
+// Run imports due jobs with the poll interval until the context is done, one job at a time.
+// Errors are reported to onError.
+func (s *noteImportService) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for ctx.Err() == nil {
+				j, err := s.claim(ctx)
+				if err != nil {
+					onError(err)
+					break
+				}
+
+				if j == nil {
+					break
+				}
+
+				if err := s.process(ctx, j); err != nil {
+					onError(err)
+				}
+			}
+		}
+	}
+}
+
+// getJob retrieves the job of the user, jobs of other users are reported as not found.
+func (s *noteImportService) getJob(ctx context.Context, u *user.User, id uuid.UUID) (*noteimport.Job, error) {
+	j, err := s.importRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, import %s)", err, u.ID, id)
+	}
+
+	if j.UserID != u.ID {
+		return nil, fmt.Errorf("%w (user %s, import %s)", noteimport.ErrNotFound, u.ID, id)
+	}
+
+	return j, nil
+}
+
+// claim takes the oldest due job marking it running for the claim timeout, it returns nil when no job is due.
+// The claim is extended as the notes are imported, so the job of a crashed worker is resumed after the timeout.
+// Claims are counted, so a job which keeps failing is given up after the maximum attempts.
+func (s *noteImportService) claim(ctx context.Context) (*noteimport.Job, error) {
+	var job *noteimport.Job
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.importRepo.Lock(ctx); err != nil {
+			return err
+		}
+
+		j, err := s.importRepo.GetDue(ctx, time.Now())
+		if err != nil {
+			if errors.Is(err, noteimport.ErrNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		now := time.Now()
+		j.Attempts++
+		j.Status = noteimport.StatusRunning
+		j.ClaimedUntil = now.Add(s.claimTimeout)
+		if time.Time(j.StartedAt).IsZero() {
+			j.StartedAt = driver.ZeroTime(now)
+		}
+
+		if err := s.importRepo.Save(ctx, j); err != nil {
+			return err
+		}
+
+		job = j
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim import: %w", err)
+	}
+
+	return job, nil
+}
+
+// process imports the notes of the claimed job on behalf of its user. Notes processed under a previous claim
+// are skipped. A broken file or a user not allowed to create notes fails the job, other errors leave it
+// to be resumed when the claim expires until the attempts are exhausted.
+func (s *noteImportService) process(ctx context.Context, j *noteimport.Job) error {
+	if j.Attempts > s.maxAttempts {
+		return s.finish(ctx, j, errImportAttempts)
+	}
+
+	u, err := s.userRepo.GetByID(ctx, j.UserID)
+	if err != nil {
+		return fmt.Errorf("import: %w (import %s)", err, j.ID)
+	}
+
+	file, err := s.download(ctx, j)
+	if err != nil {
+		return fmt.Errorf("import: %w (user %s, import %s)", err, u.ID, j.ID)
+	}
+	defer os.Remove(file.Name()) // nolint: errcheck
+	defer file.Close()           // nolint: errcheck
+
+	total := 0
+	err = notefile.Read(j.Format, file, j.Size, s.maxNoteSize, func(*notefile.Entry, error) error {
+		total++
+		if total > s.maxNotes {
+			return fmt.Errorf("file has more than %d notes", s.maxNotes)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return s.finish(ctx, j, err)
+	}
+
+	j.Total = total
+	j.ClaimedUntil = time.Now().Add(s.claimTimeout)
+	if err := s.importRepo.Save(ctx, j); err != nil {
+		return fmt.Errorf("import: %w (user %s, import %s)", err, u.ID, j.ID)
+	}
+
+	var importErr error
+	position := 0
+	err = notefile.Read(j.Format, file, j.Size, s.maxNoteSize, func(e *notefile.Entry, entryErr error) error {
+		defer func() { position++ }()
+		if position < j.Processed {
+			return nil
+		}
+
+		importErr = s.importNote(ctx, u, j, position, e, entryErr)
+		return importErr
+	})
+	switch {
+	case errors.Is(importErr, note.ErrOperationForbiddenForUser):
+		return s.finish(ctx, j, note.ErrOperationForbiddenForUser)
+	case importErr != nil:
+		return fmt.Errorf("import: %w (user %s, import %s)", importErr, u.ID, j.ID)
+	default:
+		return s.finish(ctx, j, err)
+	}
+}
+
+// importNote imports the note at the position of the file and saves its outcome with the progress of the job.
+// Notes imported before by the user are skipped, notes which can not be read, are too long or can not be created
+// fail.
+func (s *noteImportService) importNote(
+	ctx context.Context,
+	u *user.User,
+	j *noteimport.Job,
+	position int,
+	e *notefile.Entry,
+	entryErr error,
+) error {
+	it := &noteimport.Item{
+		JobID:     j.ID,
+		UserID:    u.ID,
+		Position:  position,
+		Source:    cmp.Or(e.Source, e.Title),
+		CreatedAt: time.Now(),
+	}
+
+	data, err := s.noteData(e, entryErr)
+	if err != nil {
+		it.Status = noteimport.ItemFailed
+		it.Error = err.Error()
+		return s.saveItem(ctx, j, it)
+	}
+
+	hash := noteImportHash(u.ID, data)
+	imported, err := s.importRepo.GetImported(ctx, hash)
+	if err == nil {
+		it.Status = noteimport.ItemSkipped
+		it.NoteID = uuid.NullUUID{UUID: imported.NoteID, Valid: true}
+		return s.saveItem(ctx, j, it)
+	}
+
+	if !errors.Is(err, noteimport.ErrNotFound) {
+		return err
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		n, err := s.notes.Create(ctx, u, data)
+		if err != nil {
+			return err
+		}
+
+		err = s.importRepo.SaveImported(ctx, &noteimport.Imported{
+			Hash:      hash,
+			UserID:    u.ID,
+			NoteID:    n.ID,
+			CreatedAt: it.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		created := *it
+		created.Status = noteimport.ItemCreated
+		created.NoteID = uuid.NullUUID{UUID: n.ID, Valid: true}
+		return s.saveItem(ctx, j, &created)
+	})
+	if err == nil || errors.Is(err, note.ErrOperationForbiddenForUser) || ctx.Err() != nil {
+		return err
+	}
+
+	// the note the service fails to create fails the item only, the next notes are imported
+	it.Status = noteimport.ItemFailed
+	it.Error = err.Error()
+	return s.saveItem(ctx, j, it)
+}
+
+// saveItem saves the item and counts it in the progress of the job, extending the claim of the job.
+// The progress is restored when the item is not saved, so the item is not counted twice.
+func (s *noteImportService) saveItem(ctx context.Context, j *noteimport.Job, it *noteimport.Item) error {
+	prev := *j
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.importRepo.SaveItem(ctx, it); err != nil {
+			return err
+		}
+
+		j.Processed++
+		switch it.Status {
+		case noteimport.ItemCreated:
+			j.Created++
+		case noteimport.ItemSkipped:
+			j.Skipped++
+		case noteimport.ItemFailed:
+			j.Failed++
+		}
+
+		j.ClaimedUntil = time.Now().Add(s.claimTimeout)
+		return s.importRepo.Save(ctx, j)
+	})
+	if err != nil {
+		*j = prev
+	}
+
+	return err
+}
+
+// finish completes the job, or fails it with the cause, and removes its file from the blob store.
+func (s *noteImportService) finish(ctx context.Context, j *noteimport.Job, cause error) error {
+	j.Status = noteimport.StatusCompleted
+	if cause != nil {
+		j.Status = noteimport.StatusFailed
+		j.Error = cause.Error()
+	}
+
+	j.FinishedAt = driver.ZeroTime(time.Now())
+	if err := s.importRepo.Save(ctx, j); err != nil {
+		return fmt.Errorf("finish import: %w (user %s, import %s)", err, j.UserID, j.ID)
+	}
+
+	if err := s.blobs.Delete(ctx, j.Key()); err != nil {
+		return fmt.Errorf("finish import: %w (user %s, import %s)", err, j.UserID, j.ID)
+	}
+
+	return nil
+}
+
+// noteData returns the data of the note created for the entry, failing entries which can not be read
+// or whose text is too long. Names are cut to the maximum length of note names.
+func (s *noteImportService) noteData(e *notefile.Entry, entryErr error) (*note.CreateData, error) {
+	if entryErr != nil {
+		return nil, entryErr
+	}
+
+	if utf8.RuneCountInString(e.Text) > s.maxTextLength {
+		return nil, fmt.Errorf("text is longer than %d characters", s.maxTextLength)
+	}
+
+	name := cmp.Or(strings.TrimSpace(e.Title), noteImportUntitled)
+	if utf8.RuneCountInString(name) > noteImportNameLength {
+		name = string([]rune(name)[:noteImportNameLength])
+	}
+
+	format := note.FormatPlain
+	if e.Markdown {
+		format = note.FormatMarkdown
+	}
+
+	return &note.CreateData{
+		Name:      name,
+		Text:      e.Text,
+		Format:    format,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}, nil
+}
+
+// spool copies the uploaded file to a temporary file rewound to the start, returning its size and checksum.
+func (s *noteImportService) spool(content io.Reader) (*os.File, int64, string, error) {
+	file, err := os.CreateTemp("", "import-*")
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("create temp file: %w", err)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(content, s.maxFileSize+1))
+	switch {
+	case err != nil:
+		err = fmt.Errorf("spool content: %w", err)
+	case size > s.maxFileSize:
+		err = noteimport.ErrFileTooLarge
+	case size == 0:
+		err = noteimport.ErrEmptyFile
+	}
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()           // nolint: errcheck, gosec
+		os.Remove(file.Name()) // nolint: errcheck, gosec
+		return nil, 0, "", err
+	}
+
+	return file, size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// download copies the file of the job from the blob store to a temporary file.
+func (s *noteImportService) download(ctx context.Context, j *noteimport.Job) (*os.File, error) {
+	content, err := s.blobs.Open(ctx, j.Key())
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close() // nolint: errcheck
+
+	file, err := os.CreateTemp("", "import-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp file: %w", err)
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()           // nolint: errcheck, gosec
+		os.Remove(file.Name()) // nolint: errcheck, gosec
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+
+	return file, nil
+}
+
+// noteImportHash returns the hash identifying the note of the user by its name, text, format and creation time.
+func noteImportHash(userID uuid.UUID, data *note.CreateData) string {
+	hash := sha256.New()
+	for _, part := range []string{
+		userID.String(),
+		data.Name,
+		data.Text,
+		string(data.Format),
+		data.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		hash.Write([]byte(part)) // nolint: errcheck, gosec
+		hash.Write([]byte{0})    // nolint: errcheck, gosec
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
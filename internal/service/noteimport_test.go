@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/noteimport"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_attachment"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_noteimport"
+	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/pkg/notefile"
+)
+
+// nopSeekCloser adapts the reader of the test content to the opened blob.
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
+
+func TestNoteImportService_Start(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	unfinished := &noteimport.Job{ID: uuid.Must(uuid.NewV7()), UserID: u.ID, Status: noteimport.StatusRunning}
+
+	cases := []struct {
+		name        string
+		fileName    string
+		format      notefile.Format
+		content     string
+		existing    *noteimport.Job
+		expectedErr error
+		expected    *noteimport.Job
+	}{
+		{
+			name:     "successful_start",
+			fileName: "../backup\\notes.enex",
+			content:  "<en-export></en-export>",
+			expected: &noteimport.Job{
+				UserID:   u.ID,
+				Name:     "notes.enex",
+				Format:   notefile.ENEX,
+				Checksum: "0e1a10ca9506c3edfe9e541e6a90f3f74697fef4097b67917580161ebce89120",
+				Size:     23,
+				Status:   noteimport.StatusPending,
+			},
+		},
+		{
+			name:     "format_is_set",
+			fileName: "notes.txt",
+			format:   notefile.JSON,
+			content:  "[]",
+			expected: &noteimport.Job{
+				UserID:   u.ID,
+				Name:     "notes.txt",
+				Format:   notefile.JSON,
+				Checksum: "4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945",
+				Size:     2,
+				Status:   noteimport.StatusPending,
+			},
+		},
+		{
+			name:     "unfinished_import",
+			fileName: "notes.json",
+			content:  "[]",
+			existing: unfinished,
+			expected: unfinished,
+		},
+		{
+			name:        "unknown_format",
+			fileName:    "notes.txt",
+			content:     "text",
+			expectedErr: notefile.ErrUnknownFormat,
+		},
+		{
+			name:        "file_too_large",
+			fileName:    "notes.json",
+			content:     strings.Repeat("x", 33),
+			expectedErr: noteimport.ErrFileTooLarge,
+		},
+		{
+			name:        "empty_file",
+			fileName:    "notes.json",
+			expectedErr: noteimport.ErrEmptyFile,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_noteimport.NewRepository(t)
+			blobs := mock_attachment.NewBlobStore(t)
+
+			if tc.expected != nil {
+				existingErr := noteimport.ErrNotFound
+				if tc.existing != nil {
+					existingErr = nil
+				}
+
+				repo.EXPECT().GetUnfinishedByChecksum(mock.Anything, u.ID, mock.Anything).
+					Return(tc.existing, existingErr).Once()
+			}
+
+			var key string
+			if tc.expected != nil && tc.existing == nil {
+				blobs.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything, int64(len(tc.content)), mock.Anything).
+					RunAndReturn(func(_ context.Context, k string, r io.Reader, _ int64, _ string) error {
+						content, err := io.ReadAll(r)
+						require.NoError(t, err)
+						require.Equal(t, tc.content, string(content))
+						key = k
+						return nil
+					}).Once()
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			service := NewNoteImportService(&NoteImportServiceDeps{
+				ImportRepo:  repo,
+				Blobs:       blobs,
+				MaxFileSize: 32,
+			})
+
+			j, err := service.Start(context.Background(), u, &noteimport.UploadData{
+				Name:    tc.fileName,
+				Format:  tc.format,
+				Content: strings.NewReader(tc.content),
+			})
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.existing != nil {
+				require.Same(t, tc.existing, j)
+				return
+			}
+
+			require.Equal(t, j.Key(), key)
+			require.NotEqual(t, uuid.Nil, j.ID)
+			require.False(t, j.CreatedAt.IsZero())
+
+			tc.expected.ID = j.ID
+			tc.expected.ClaimedUntil = j.ClaimedUntil
+			tc.expected.CreatedAt = j.CreatedAt
+			require.Equal(t, tc.expected, j)
+		})
+	}
+}
+
+func TestNoteImportService_Process(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	content := `[
+		{"name": "First", "text": "hello"},
+		{"name": "Second", "text": "again"},
+		{"name": "Long", "text": "longer than ten"}
+	]`
+	first := &note.CreateData{Name: "First", Text: "hello", Format: note.FormatPlain}
+	second := &note.CreateData{Name: "Second", Text: "again", Format: note.FormatPlain}
+	created := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	imported := &noteimport.Imported{NoteID: uuid.Must(uuid.NewV7())}
+
+	cases := []struct {
+		name      string
+		processed int
+		createErr error
+		expected  *noteimport.Job
+		items     []*noteimport.Item
+	}{
+		{
+			name: "successful_import",
+			expected: &noteimport.Job{
+				Status:    noteimport.StatusCompleted,
+				Total:     3,
+				Processed: 3,
+				Created:   1,
+				Skipped:   1,
+				Failed:    1,
+			},
+			items: []*noteimport.Item{
+				{
+					Position: 0,
+					Source:   "First",
+					Status:   noteimport.ItemCreated,
+					NoteID:   uuid.NullUUID{UUID: created.ID, Valid: true},
+				},
+				{
+					Position: 1,
+					Source:   "Second",
+					Status:   noteimport.ItemSkipped,
+					NoteID:   uuid.NullUUID{UUID: imported.NoteID, Valid: true},
+				},
+				{
+					Position: 2,
+					Source:   "Long",
+					Status:   noteimport.ItemFailed,
+					Error:    "text is longer than 10 characters",
+				},
+			},
+		},
+		{
+			name:      "resumed_import",
+			processed: 2,
+			expected: &noteimport.Job{
+				Status:    noteimport.StatusCompleted,
+				Total:     3,
+				Processed: 3,
+				Failed:    1,
+			},
+			items: []*noteimport.Item{
+				{
+					Position: 2,
+					Source:   "Long",
+					Status:   noteimport.ItemFailed,
+					Error:    "text is longer than 10 characters",
+				},
+			},
+		},
+		{
+			name:      "create_failed",
+			createErr: errors.New("create failed"),
+			expected: &noteimport.Job{
+				Status:    noteimport.StatusCompleted,
+				Total:     3,
+				Processed: 3,
+				Skipped:   1,
+				Failed:    2,
+			},
+			items: []*noteimport.Item{
+				{
+					Position: 0,
+					Source:   "First",
+					Status:   noteimport.ItemFailed,
+					Error:    "create failed",
+				},
+				{
+					Position: 1,
+					Source:   "Second",
+					Status:   noteimport.ItemSkipped,
+					NoteID:   uuid.NullUUID{UUID: imported.NoteID, Valid: true},
+				},
+				{
+					Position: 2,
+					Source:   "Long",
+					Status:   noteimport.ItemFailed,
+					Error:    "text is longer than 10 characters",
+				},
+			},
+		},
+		{
+			name:      "not_granted",
+			createErr: note.ErrOperationForbiddenForUser,
+			expected: &noteimport.Job{
+				Status: noteimport.StatusFailed,
+				Total:  3,
+				Error:  note.ErrOperationForbiddenForUser.Error(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			j := &noteimport.Job{
+				ID:        uuid.Must(uuid.NewV7()),
+				UserID:    u.ID,
+				Format:    notefile.JSON,
+				Size:      int64(len(content)),
+				Status:    noteimport.StatusRunning,
+				Processed: tc.processed,
+			}
+
+			repo := mock_noteimport.NewRepository(t)
+			userRepo := mock_user.NewRepository(t)
+			notes := mock_note.NewService(t)
+			blobs := mock_attachment.NewBlobStore(t)
+
+			userRepo.EXPECT().GetByID(mock.Anything, u.ID).Return(u, nil).Once()
+			blobs.EXPECT().Open(mock.Anything, j.Key()).
+				Return(nopSeekCloser{strings.NewReader(content)}, nil).Once()
+			blobs.EXPECT().Delete(mock.Anything, j.Key()).Return(nil).Once()
+			repo.EXPECT().Save(mock.Anything, j).Return(nil)
+
+			var items []*noteimport.Item
+			repo.EXPECT().SaveItem(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, it *noteimport.Item) error {
+					items = append(items, it)
+					return nil
+				}).Maybe()
+
+			if tc.processed == 0 {
+				repo.EXPECT().GetImported(mock.Anything, noteImportHash(u.ID, first)).
+					Return(nil, noteimport.ErrNotFound).Once()
+				notes.EXPECT().Create(mock.Anything, u, first).Return(created, tc.createErr).Once()
+			}
+
+			if tc.processed == 0 && tc.createErr == nil {
+				repo.EXPECT().SaveImported(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, imp *noteimport.Imported) error {
+						require.Equal(t, noteImportHash(u.ID, first), imp.Hash)
+						require.Equal(t, u.ID, imp.UserID)
+						require.Equal(t, created.ID, imp.NoteID)
+						return nil
+					}).Once()
+			}
+
+			if tc.processed == 0 && !errors.Is(tc.createErr, note.ErrOperationForbiddenForUser) {
+				repo.EXPECT().GetImported(mock.Anything, noteImportHash(u.ID, second)).
+					Return(imported, nil).Once()
+			}
+
+			service := NewNoteImportService(&NoteImportServiceDeps{
+				TxManager:     mock_tx.NewMockTxManager(),
+				ImportRepo:    repo,
+				UserRepo:      userRepo,
+				Notes:         notes,
+				Blobs:         blobs,
+				MaxNoteSize:   1024,
+				MaxNotes:      10,
+				MaxTextLength: 10,
+				MaxAttempts:   3,
+			}).(*noteImportService)
+
+			require.NoError(t, service.process(context.Background(), j))
+			require.False(t, j.ClaimedUntil.IsZero())
+			require.False(t, time.Time(j.FinishedAt).IsZero())
+
+			tc.expected.ID = j.ID
+			tc.expected.UserID = u.ID
+			tc.expected.Format = notefile.JSON
+			tc.expected.Size = j.Size
+			tc.expected.ClaimedUntil = j.ClaimedUntil
+			tc.expected.FinishedAt = j.FinishedAt
+			require.Equal(t, tc.expected, j)
+
+			require.Len(t, items, len(tc.items))
+			for i, it := range items {
+				require.False(t, it.CreatedAt.IsZero())
+				tc.items[i].JobID = j.ID
+				tc.items[i].UserID = u.ID
+				tc.items[i].CreatedAt = it.CreatedAt
+				require.Equal(t, tc.items[i], it)
+			}
+		})
+	}
+}
+
+func TestNoteImportService_ProcessAttemptsExhausted(t *testing.T) {
+	t.Parallel()
+
+	j := &noteimport.Job{
+		ID:       uuid.Must(uuid.NewV7()),
+		UserID:   uuid.Must(uuid.NewV7()),
+		Status:   noteimport.StatusRunning,
+		Attempts: 4,
+	}
+
+	repo := mock_noteimport.NewRepository(t)
+	blobs := mock_attachment.NewBlobStore(t)
+	repo.EXPECT().Save(mock.Anything, j).Return(nil).Once()
+	blobs.EXPECT().Delete(mock.Anything, j.Key()).Return(nil).Once()
+
+	service := NewNoteImportService(&NoteImportServiceDeps{
+		ImportRepo:  repo,
+		Blobs:       blobs,
+		MaxAttempts: 3,
+	}).(*noteImportService)
+
+	require.NoError(t, service.process(context.Background(), j))
+	require.Equal(t, noteimport.StatusFailed, j.Status)
+	require.Equal(t, errImportAttempts.Error(), j.Error)
+	require.False(t, time.Time(j.FinishedAt).IsZero())
+}
+
+func TestNoteImportService_Get(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	own := &noteimport.Job{ID: uuid.Must(uuid.NewV7()), UserID: u.ID}
+	foreign := &noteimport.Job{ID: uuid.Must(uuid.NewV7()), UserID: uuid.Must(uuid.NewV7())}
+
+	repo := mock_noteimport.NewRepository(t)
+	repo.EXPECT().GetByID(mock.Anything, own.ID).Return(own, nil).Once()
+	repo.EXPECT().GetByID(mock.Anything, foreign.ID).Return(foreign, nil).Once()
+	repo.EXPECT().GetByID(mock.Anything, mock.Anything).Return(nil, errors.New("get failed")).Once()
+
+	service := NewNoteImportService(&NoteImportServiceDeps{ImportRepo: repo})
+
+	j, err := service.Get(context.Background(), u, own.ID)
+	require.NoError(t, err)
+	require.Same(t, own, j)
+
+	_, err = service.Get(context.Background(), u, foreign.ID)
+	require.ErrorIs(t, err, noteimport.ErrNotFound)
+
+	_, err = service.Get(context.Background(), u, uuid.Must(uuid.NewV7()))
+	require.ErrorContains(t, err, "get failed")
+}
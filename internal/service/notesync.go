@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/notesync"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// NoteSyncServiceDeps represents the dependencies required to construct a note sync service.
+// Client changes are applied by the note service, so they are guarded, audited and published as any other change.
+type NoteSyncServiceDeps struct {
+	Notes     note.Service
+	SyncRepo  notesync.Repository
+	NoteGuard note.Guarder
+	PageSize  uint64
+}
+
+// noteSyncService is a struct that implements the notesync.Service interface.
+type noteSyncService struct {
+	notes    note.Service
+	syncRepo notesync.Repository
+	guard    note.Guarder
+	pageSize uint64
+}
+
+// NewNoteSyncService initializes and returns a new implementation of the notesync.Service interface.
+func NewNoteSyncService(deps *NoteSyncServiceDeps) notesync.Service {
+	return &noteSyncService{
+		notes:    deps.Notes,
+		syncRepo: deps.SyncRepo,
+		guard:    deps.NoteGuard,
+		pageSize: deps.PageSize,
+	}
+}
+
+// Sync applies the client changes one by one and returns their outcomes with the server changes since the token.
+// A change made to a note which has changed on the server since its base version is not applied
+// and is reported as a conflict with the server state of the note. Updates made concurrently with the sync are merged.
+func (s *noteSyncService) Sync(ctx context.Context, u *user.User, req *notesync.Request) (*notesync.Response, error) {
+	res := &notesync.Response{Results: make([]*notesync.Result, len(req.Changes))}
+	for i, change := range req.Changes {
+		result, err := s.apply(ctx, u, change)
+		if err != nil {
+			return nil, fmt.Errorf("sync notes: %w (user %s, change %d)", err, u.ID, i)
+		}
+
+		res.Results[i] = result
+	}
+
+	horizon := req.Token.Horizon
+	if horizon == 0 {
+		var err error
+		if horizon, err = s.syncRepo.Horizon(ctx); err != nil {
+			return nil, fmt.Errorf("sync notes: %w (user %s)", err, u.ID)
+		}
+	}
+
+	versions, err := s.syncRepo.GetChanges(ctx, u, req.Token.Cursor, s.pageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("sync notes: %w (user %s)", err, u.ID)
+	}
+
+	if res.HasMore = uint64(len(versions)) > s.pageSize; res.HasMore {
+		versions = versions[:s.pageSize]
+		last := versions[len(versions)-1]
+		res.Token = notesync.Token{Cursor: notesync.Cursor{TxID: last.TxID, NoteID: last.NoteID}, Horizon: horizon}
+	} else {
+		res.Token = notesync.Token{Cursor: notesync.Cursor{TxID: horizon - 1, NoteID: uuid.Max}}
+	}
+
+	if res.Changes, err = s.serverChanges(ctx, u, versions); err != nil {
+		return nil, fmt.Errorf("sync notes: %w (user %s)", err, u.ID)
+	}
+
+	return res, nil
+}
+
+// apply applies the client change. Failures caused by the change itself are reported in the result.
+func (s *noteSyncService) apply(ctx context.Context, u *user.User, change *notesync.Change) (*notesync.Result, error) {
+	result, err := s.applyChange(ctx, u, change)
+	switch {
+	case errors.Is(err, notesync.ErrVersionNotFound), errors.Is(err, note.ErrNotFound):
+		return &notesync.Result{Status: notesync.StatusNotFound, NoteID: change.NoteID}, nil
+	case errors.Is(err, note.ErrOperationForbiddenForUser):
+		return &notesync.Result{Status: notesync.StatusForbidden, NoteID: change.NoteID}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// applyChange checks the base version of the change and writes it. Only the versions of the notes visible
+// to the user are read, so changes of other notes are not found. The note service merges the update with
+// the changes made since the check, so a concurrent change is not lost, overlapping ones are reported as a conflict.
+func (s *noteSyncService) applyChange(
+	ctx context.Context,
+	u *user.User,
+	change *notesync.Change,
+) (*notesync.Result, error) {
+	if change.Kind != notesync.ChangeCreate {
+		v, err := s.syncRepo.GetVersion(ctx, u, change.NoteID)
+		if err != nil {
+			return nil, err
+		}
+
+		if v.Version != change.BaseVersion && !(v.Deleted && change.Kind == notesync.ChangeDelete) {
+			return s.conflict(ctx, u, v)
+		}
+
+		if v.Deleted {
+			// deleting a deleted note is applied already
+			return &notesync.Result{Status: notesync.StatusApplied, NoteID: change.NoteID, Version: v.Version}, nil
+		}
+	}
+
+	n, err := s.write(ctx, u, change)
+	if errors.Is(err, note.ErrMergeConflict) || errors.Is(err, note.ErrVersionConflict) {
+		v, err := s.syncRepo.GetVersion(ctx, u, change.NoteID)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.conflict(ctx, u, v)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &notesync.Result{Status: notesync.StatusApplied, NoteID: n.ID, Version: n.Version}
+	if change.Kind == notesync.ChangeDelete {
+		// the version of the tombstone is bumped by the deletion
+		v, err := s.syncRepo.GetVersion(ctx, u, n.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Version = v.Version
+	}
+
+	return result, nil
+}
+
+// conflict returns the conflict result with the server state of the note.
+func (s *noteSyncService) conflict(ctx context.Context, u *user.User, v *notesync.Version) (*notesync.Result, error) {
+	server, err := s.serverChange(ctx, u, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notesync.Result{Status: notesync.StatusConflict, NoteID: v.NoteID, Version: v.Version, Server: server}, nil
+}
+
+// write makes the client change with the note service. Updates carry the base version, so the changes made
+// to the note since are merged.
+func (s *noteSyncService) write(ctx context.Context, u *user.User, change *notesync.Change) (*note.Note, error) {
+	switch change.Kind {
+	case notesync.ChangeCreate:
+		return s.notes.Create(ctx, u, &note.CreateData{Name: change.Name, Text: change.Text, OrgID: change.OrgID})
+	case notesync.ChangeUpdate:
+		return s.notes.Update(ctx, u, &note.UpdateData{
+			ID:          change.NoteID,
+			Name:        change.Name,
+			Text:        change.Text,
+			BaseVersion: change.BaseVersion,
+		})
+	case notesync.ChangeDelete:
+		return s.notes.Delete(ctx, u, change.NoteID)
+	}
+
+	return nil, fmt.Errorf("unknown change kind %q", change.Kind)
+}
+
+// serverChange returns the server state of the note for a conflict.
+func (s *noteSyncService) serverChange(
+	ctx context.Context,
+	u *user.User,
+	v *notesync.Version,
+) (*notesync.ServerChange, error) {
+	if v.Deleted {
+		return &notesync.ServerChange{NoteID: v.NoteID, Version: v.Version, Deleted: true}, nil
+	}
+
+	n, err := s.notes.Get(ctx, u, v.NoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &notesync.ServerChange{NoteID: v.NoteID, Version: v.Version, Note: n}, nil
+}
+
+// serverChanges returns the changes of the versions. Notes the user may not read, or which are deleted
+// since the versions were read, are returned as tombstones.
+func (s *noteSyncService) serverChanges(
+	ctx context.Context,
+	u *user.User,
+	versions []*notesync.Version,
+) ([]*notesync.ServerChange, error) {
+	ids := make([]uuid.UUID, 0, len(versions))
+	for _, v := range versions {
+		if !v.Deleted {
+			ids = append(ids, v.NoteID)
+		}
+	}
+
+	notes, err := s.syncRepo.GetNotes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*note.Note, len(notes))
+	for _, n := range notes {
+		byID[n.ID] = n
+	}
+
+	changes := make([]*notesync.ServerChange, len(versions))
+	for i, v := range versions {
+		changes[i] = &notesync.ServerChange{NoteID: v.NoteID, Version: v.Version, Deleted: true}
+		n, ok := byID[v.NoteID]
+		if v.Deleted || !ok {
+			continue
+		}
+
+		granted, err := s.guard.IsGranted(ctx, rbac.READ, n, u)
+		if err != nil {
+			return nil, fmt.Errorf("check granted: %w (note %s)", err, n.ID)
+		}
+
+		if granted {
+			changes[i].Deleted = false
+			changes[i].Note = n
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/notesync"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_notesync"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+func TestNoteSyncService_Apply(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.New()}
+	n := &note.Note{ID: uuid.New(), Name: "server name", Text: "server text", UserId: u.ID}
+
+	cases := []struct {
+		name     string
+		change   *notesync.Change
+		expected *notesync.Result
+		mocker   func(notes *mock_note.Service, repo *mock_notesync.Repository)
+	}{
+		{
+			name:     "create_applied",
+			change:   &notesync.Change{Kind: notesync.ChangeCreate, Name: n.Name, Text: n.Text},
+			expected: &notesync.Result{Status: notesync.StatusApplied, NoteID: n.ID, Version: 1},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				notes.EXPECT().
+					Create(mock.Anything, u, &note.CreateData{Name: n.Name, Text: n.Text}).
+					Return(&note.Note{ID: n.ID, Version: 1}, nil).
+					Once()
+			},
+		},
+		{
+			name:     "update_applied",
+			change:   &notesync.Change{Kind: notesync.ChangeUpdate, NoteID: n.ID, BaseVersion: 3, Name: "a", Text: "b"},
+			expected: &notesync.Result{Status: notesync.StatusApplied, NoteID: n.ID, Version: 4},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().GetVersion(mock.Anything, u, n.ID).Return(&notesync.Version{Version: 3}, nil).Once()
+				notes.EXPECT().
+					Update(mock.Anything, u, &note.UpdateData{ID: n.ID, Name: "a", Text: "b", BaseVersion: 3}).
+					Return(&note.Note{ID: n.ID, Version: 4}, nil).
+					Once()
+			},
+		},
+		{
+			name:   "update_concurrent_conflict",
+			change: &notesync.Change{Kind: notesync.ChangeUpdate, NoteID: n.ID, BaseVersion: 3, Name: "a", Text: "b"},
+			expected: &notesync.Result{
+				Status:  notesync.StatusConflict,
+				NoteID:  n.ID,
+				Version: 4,
+				Server:  &notesync.ServerChange{NoteID: n.ID, Version: 4, Note: n},
+			},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 3}, nil).
+					Once()
+				notes.EXPECT().
+					Update(mock.Anything, u, &note.UpdateData{ID: n.ID, Name: "a", Text: "b", BaseVersion: 3}).
+					Return(nil, &note.MergeConflictError{Note: n}).
+					Once()
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 4}, nil).
+					Once()
+				notes.EXPECT().Get(mock.Anything, u, n.ID).Return(n, nil).Once()
+			},
+		},
+		{
+			name:     "delete_applied",
+			change:   &notesync.Change{Kind: notesync.ChangeDelete, NoteID: n.ID, BaseVersion: 2},
+			expected: &notesync.Result{Status: notesync.StatusApplied, NoteID: n.ID, Version: 3},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 2}, nil).
+					Once()
+				notes.EXPECT().Delete(mock.Anything, u, n.ID).Return(n, nil).Once()
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 3, Deleted: true}, nil).
+					Once()
+			},
+		},
+		{
+			name:   "update_conflict",
+			change: &notesync.Change{Kind: notesync.ChangeUpdate, NoteID: n.ID, BaseVersion: 2, Name: "a", Text: "b"},
+			expected: &notesync.Result{
+				Status:  notesync.StatusConflict,
+				NoteID:  n.ID,
+				Version: 3,
+				Server:  &notesync.ServerChange{NoteID: n.ID, Version: 3, Note: n},
+			},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 3}, nil).
+					Once()
+				notes.EXPECT().Get(mock.Anything, u, n.ID).Return(n, nil).Once()
+			},
+		},
+		{
+			name:   "update_deleted_conflict",
+			change: &notesync.Change{Kind: notesync.ChangeUpdate, NoteID: n.ID, BaseVersion: 2, Name: "a", Text: "b"},
+			expected: &notesync.Result{
+				Status:  notesync.StatusConflict,
+				NoteID:  n.ID,
+				Version: 3,
+				Server:  &notesync.ServerChange{NoteID: n.ID, Version: 3, Deleted: true},
+			},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 3, Deleted: true}, nil).
+					Once()
+			},
+		},
+		{
+			name:     "delete_deleted",
+			change:   &notesync.Change{Kind: notesync.ChangeDelete, NoteID: n.ID, BaseVersion: 2},
+			expected: &notesync.Result{Status: notesync.StatusApplied, NoteID: n.ID, Version: 3},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().
+					GetVersion(mock.Anything, u, n.ID).
+					Return(&notesync.Version{NoteID: n.ID, Version: 3, Deleted: true}, nil).
+					Once()
+			},
+		},
+		{
+			name:     "update_forbidden",
+			change:   &notesync.Change{Kind: notesync.ChangeUpdate, NoteID: n.ID, BaseVersion: 3, Name: "a", Text: "b"},
+			expected: &notesync.Result{Status: notesync.StatusForbidden, NoteID: n.ID},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().GetVersion(mock.Anything, u, n.ID).Return(&notesync.Version{Version: 3}, nil).Once()
+				notes.EXPECT().
+					Update(mock.Anything, u, mock.Anything).
+					Return(nil, note.ErrOperationForbiddenForUser).
+					Once()
+			},
+		},
+		{
+			name:     "not_found",
+			change:   &notesync.Change{Kind: notesync.ChangeDelete, NoteID: n.ID, BaseVersion: 1},
+			expected: &notesync.Result{Status: notesync.StatusNotFound, NoteID: n.ID},
+			mocker: func(notes *mock_note.Service, repo *mock_notesync.Repository) {
+				repo.EXPECT().GetVersion(mock.Anything, u, n.ID).Return(nil, notesync.ErrVersionNotFound).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			notes := mock_note.NewService(t)
+			repo := mock_notesync.NewRepository(t)
+			tc.mocker(notes, repo)
+
+			s := NewNoteSyncService(&NoteSyncServiceDeps{
+				Notes:    notes,
+				SyncRepo: repo,
+			}).(*noteSyncService)
+
+			result, err := s.apply(context.Background(), u, tc.change)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestNoteSyncService_Sync(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.New()}
+	readable := &note.Note{ID: uuid.New(), UserId: u.ID}
+	hidden := &note.Note{ID: uuid.New(), UserId: u.ID}
+	versions := []*notesync.Version{
+		{NoteID: readable.ID, Version: 2, TxID: 10},
+		{NoteID: uuid.New(), Version: 5, Deleted: true, TxID: 11},
+		{NoteID: hidden.ID, Version: 1, TxID: 12},
+		{NoteID: uuid.New(), Version: 1, TxID: 13},
+	}
+
+	cases := []struct {
+		name            string
+		token           notesync.Token
+		versions        []*notesync.Version
+		expectedChanges []*notesync.ServerChange
+		expectedToken   notesync.Token
+		hasMore         bool
+	}{
+		{
+			name:     "last_page",
+			token:    notesync.Token{Cursor: notesync.Cursor{TxID: 9, NoteID: uuid.Max}},
+			versions: versions[:3],
+			expectedChanges: []*notesync.ServerChange{
+				{NoteID: readable.ID, Version: 2, Note: readable},
+				{NoteID: versions[1].NoteID, Version: 5, Deleted: true},
+				{NoteID: hidden.ID, Version: 1, Deleted: true},
+			},
+			expectedToken: notesync.Token{Cursor: notesync.Cursor{TxID: 19, NoteID: uuid.Max}},
+		},
+		{
+			name:     "paged",
+			token:    notesync.Token{Cursor: notesync.Cursor{TxID: 9, NoteID: uuid.Max}, Horizon: 15},
+			versions: versions,
+			expectedChanges: []*notesync.ServerChange{
+				{NoteID: readable.ID, Version: 2, Note: readable},
+				{NoteID: versions[1].NoteID, Version: 5, Deleted: true},
+				{NoteID: hidden.ID, Version: 1, Deleted: true},
+			},
+			expectedToken: notesync.Token{Cursor: notesync.Cursor{TxID: 12, NoteID: hidden.ID}, Horizon: 15},
+			hasMore:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_notesync.NewRepository(t)
+			if tc.token.Horizon == 0 {
+				repo.EXPECT().Horizon(mock.Anything).Return(20, nil).Once()
+			}
+
+			repo.EXPECT().GetChanges(mock.Anything, u, tc.token.Cursor, uint64(4)).Return(tc.versions, nil).Once()
+			repo.EXPECT().
+				GetNotes(mock.Anything, []uuid.UUID{readable.ID, hidden.ID}).
+				Return([]*note.Note{readable, hidden}, nil).
+				Once()
+
+			guard := mock_note.NewGuarder(t)
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, readable, u).Return(true, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, hidden, u).Return(false, nil).Once()
+
+			s := NewNoteSyncService(&NoteSyncServiceDeps{
+				SyncRepo:  repo,
+				NoteGuard: guard,
+				PageSize:  3,
+			})
+
+			res, err := s.Sync(context.Background(), u, &notesync.Request{Token: tc.token})
+			require.NoError(t, err)
+			require.Empty(t, res.Results)
+			require.Equal(t, tc.expectedChanges, res.Changes)
+			require.Equal(t, tc.expectedToken, res.Token)
+			require.Equal(t, tc.hasMore, res.HasMore)
+
+			parsed, err := notesync.ParseToken(res.Token.String())
+			require.NoError(t, err)
+			require.Equal(t, res.Token, parsed)
+		})
+	}
+}
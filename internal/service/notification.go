@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/op/driver"
+)
+
+// NotificationServiceDeps represents the dependencies required to construct a notification service.
+type NotificationServiceDeps struct {
+	TxManager        tx.Manager
+	NotificationRepo notification.Repository
+	PageSize         uint64
+	MaxPageSize      uint64
+	Retention        time.Duration
+	CleanupInterval  time.Duration
+}
+
+// notificationService is a struct that implements the notification.Service interface for the inbox of the users.
+type notificationService struct {
+	tx               tx.Manager
+	notificationRepo notification.Repository
+	pageSize         uint64
+	maxPageSize      uint64
+	retention        time.Duration
+	cleanupInterval  time.Duration
+}
+
+// NewNotificationService initializes and returns a new implementation of the notification.Service interface.
+func NewNotificationService(deps *NotificationServiceDeps) notification.Service {
+	return &notificationService{
+		tx:               deps.TxManager,
+		notificationRepo: deps.NotificationRepo,
+		pageSize:         deps.PageSize,
+		maxPageSize:      deps.MaxPageSize,
+		retention:        deps.Retention,
+		cleanupInterval:  deps.CleanupInterval,
+	}
+}
+
+// Notify stores the notification in the inbox of its user unless the user disabled notifications of the type.
+func (s *notificationService) Notify(ctx context.Context, n *notification.Notification) error {
+	prefs, err := s.notificationRepo.GetPreferences(ctx, n.UserID)
+	if err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	for _, p := range prefs {
+		if p.Type == n.Type && !p.Enabled {
+			return nil
+		}
+	}
+
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	if err := s.notificationRepo.Save(ctx, n); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the page of notifications of the user newest first with the number of unread notifications.
+func (s *notificationService) List(
+	ctx context.Context,
+	u *user.User,
+	req *notification.ListRequest,
+) (*notification.Page, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = s.pageSize
+	}
+
+	limit = min(limit, s.maxPageSize)
+	rows, err := s.notificationRepo.GetPage(ctx, u.ID, req.Cursor, req.UnreadOnly, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+
+	page := &notification.Page{Rows: rows, Unread: unread}
+	if uint64(len(rows)) > limit {
+		page.Rows = rows[:limit]
+		page.Next = notification.Cursor{ID: page.Rows[limit-1].ID}
+		page.HasMore = true
+	}
+
+	return page, nil
+}
+
+// MarkRead marks the notification of the user read, notifications read before keep the time they were read.
+func (s *notificationService) MarkRead(
+	ctx context.Context,
+	u *user.User,
+	id uuid.UUID,
+) (*notification.Notification, error) {
+	n, err := s.notificationRepo.GetByID(ctx, u.ID, id)
+	if err != nil {
+		return nil, fmt.Errorf("mark notification read: %w", err)
+	}
+
+	if !time.Time(n.ReadAt).IsZero() {
+		return n, nil
+	}
+
+	n.ReadAt = driver.ZeroTime(time.Now())
+	if err := s.notificationRepo.Save(ctx, n); err != nil {
+		return nil, fmt.Errorf("mark notification read: %w", err)
+	}
+
+	return n, nil
+}
+
+// MarkAllRead marks all unread notifications of the user read and returns their number.
+func (s *notificationService) MarkAllRead(ctx context.Context, u *user.User) (int64, error) {
+	marked, err := s.notificationRepo.MarkAllRead(ctx, u.ID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("mark all notifications read: %w", err)
+	}
+
+	return marked, nil
+}
+
+// Preferences returns the preferences of the user for every notification type, types without a preference set
+// are enabled.
+func (s *notificationService) Preferences(ctx context.Context, u *user.User) ([]*notification.Preference, error) {
+	prefs, err := s.notificationRepo.GetPreferences(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get notification preferences: %w", err)
+	}
+
+	return completePreferences(u, prefs), nil
+}
+
+// SetPreferences enables and disables notification types for the user, other types are left as they are.
+// Returns the preferences for every type.
+func (s *notificationService) SetPreferences(
+	ctx context.Context,
+	u *user.User,
+	enabled map[notification.Type]bool,
+) ([]*notification.Preference, error) {
+	for t := range enabled {
+		if _, err := notification.ParseType(string(t)); err != nil {
+			return nil, fmt.Errorf("set notification preferences: %w (user %s)", err, u.ID)
+		}
+	}
+
+	var prefs []*notification.Preference
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		prefs, err = s.notificationRepo.GetPreferences(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		prefs = completePreferences(u, prefs)
+		now := time.Now()
+		for _, p := range prefs {
+			value, ok := enabled[p.Type]
+			if !ok || (p.Enabled == value && p.ID != uuid.Nil) {
+				continue
+			}
+
+			p.Enabled = value
+			p.UpdatedAt = now
+			if err := s.notificationRepo.SavePreference(ctx, p); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// Run removes notifications older than the retention with the cleanup interval until the context is done.
+// Errors are reported to onError.
+func (s *notificationService) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.cleanup(ctx); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// cleanup removes notifications older than the retention.
+func (s *notificationService) cleanup(ctx context.Context) error {
+	if _, err := s.notificationRepo.DeleteBefore(ctx, time.Now().Add(-s.retention)); err != nil {
+		return fmt.Errorf("clean up notifications: %w", err)
+	}
+
+	return nil
+}
+
+// completePreferences returns the preferences of the user for every notification type in the order of the types,
+// missing preferences are enabled.
+func completePreferences(u *user.User, prefs []*notification.Preference) []*notification.Preference {
+	res := make([]*notification.Preference, 0, len(notification.Types()))
+	for _, t := range notification.Types() {
+		p := &notification.Preference{UserID: u.ID, Type: t, Enabled: true}
+		for _, set := range prefs {
+			if set.Type == t {
+				p = set
+				break
+			}
+		}
+
+		res = append(res, p)
+	}
+
+	return res
+}
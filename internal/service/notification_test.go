@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_notification"
+	"github.com/xsqrty/op/driver"
+)
+
+func TestNotificationService_Notify(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.Must(uuid.NewV7())
+	cases := []struct {
+		name  string
+		prefs []*notification.Preference
+		saved bool
+	}{
+		{
+			name:  "no_preferences",
+			saved: true,
+		},
+		{
+			name: "other_type_disabled",
+			prefs: []*notification.Preference{
+				{UserID: userID, Type: notification.TypeOrgInvite, Enabled: false},
+			},
+			saved: true,
+		},
+		{
+			name: "type_disabled",
+			prefs: []*notification.Preference{
+				{UserID: userID, Type: notification.TypeReminder, Enabled: false},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_notification.NewRepository(t)
+			repo.EXPECT().GetPreferences(mock.Anything, userID).Return(tc.prefs, nil).Once()
+			if tc.saved {
+				repo.EXPECT().Save(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, n *notification.Notification) error {
+						require.False(t, n.CreatedAt.IsZero())
+						return nil
+					}).Once()
+			}
+
+			service := NewNotificationService(&NotificationServiceDeps{NotificationRepo: repo})
+			err := service.Notify(context.Background(), &notification.Notification{
+				ID:     uuid.Must(uuid.NewV7()),
+				UserID: userID,
+				Type:   notification.TypeReminder,
+				Title:  "Reminder",
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNotificationService_List(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	rows := make([]*notification.Notification, 3)
+	for i := range rows {
+		rows[i] = &notification.Notification{ID: uuid.Must(uuid.NewV7()), UserID: u.ID}
+	}
+
+	cursor := notification.Cursor{ID: uuid.Must(uuid.NewV7())}
+	cases := []struct {
+		name          string
+		req           *notification.ListRequest
+		rows          []*notification.Notification
+		expectedRows  int
+		expectedNext  notification.Cursor
+		expectedMore  bool
+		expectedFetch uint64
+	}{
+		{
+			name:          "default_limit",
+			req:           &notification.ListRequest{},
+			rows:          rows,
+			expectedRows:  3,
+			expectedFetch: 21,
+		},
+		{
+			name:          "has_more",
+			req:           &notification.ListRequest{Cursor: cursor, Limit: 2, UnreadOnly: true},
+			rows:          rows,
+			expectedRows:  2,
+			expectedNext:  notification.Cursor{ID: rows[1].ID},
+			expectedMore:  true,
+			expectedFetch: 3,
+		},
+		{
+			name:          "max_limit",
+			req:           &notification.ListRequest{Limit: 1000},
+			rows:          rows[:1],
+			expectedRows:  1,
+			expectedFetch: 101,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_notification.NewRepository(t)
+			repo.EXPECT().GetPage(mock.Anything, u.ID, tc.req.Cursor, tc.req.UnreadOnly, tc.expectedFetch).
+				Return(tc.rows, nil).Once()
+			repo.EXPECT().CountUnread(mock.Anything, u.ID).Return(2, nil).Once()
+
+			service := NewNotificationService(&NotificationServiceDeps{
+				NotificationRepo: repo,
+				PageSize:         20,
+				MaxPageSize:      100,
+			})
+
+			page, err := service.List(context.Background(), u, tc.req)
+			require.NoError(t, err)
+			require.Len(t, page.Rows, tc.expectedRows)
+			require.Equal(t, uint64(2), page.Unread)
+			require.Equal(t, tc.expectedNext, page.Next)
+			require.Equal(t, tc.expectedMore, page.HasMore)
+		})
+	}
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	readAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	cases := []struct {
+		name   string
+		readAt time.Time
+		saved  bool
+	}{
+		{
+			name:  "unread",
+			saved: true,
+		},
+		{
+			name:   "already_read",
+			readAt: readAt,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := &notification.Notification{
+				ID:     uuid.Must(uuid.NewV7()),
+				UserID: u.ID,
+				ReadAt: driver.ZeroTime(tc.readAt),
+			}
+			repo := mock_notification.NewRepository(t)
+			repo.EXPECT().GetByID(mock.Anything, u.ID, n.ID).Return(n, nil).Once()
+			if tc.saved {
+				repo.EXPECT().Save(mock.Anything, n).Return(nil).Once()
+			}
+
+			service := NewNotificationService(&NotificationServiceDeps{NotificationRepo: repo})
+			res, err := service.MarkRead(context.Background(), u, n.ID)
+			require.NoError(t, err)
+			require.False(t, time.Time(res.ReadAt).IsZero())
+			if !tc.saved {
+				require.Equal(t, readAt, time.Time(res.ReadAt))
+			}
+		})
+	}
+}
+
+func TestNotificationService_SetPreferences(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	stored := &notification.Preference{
+		ID:      uuid.Must(uuid.NewV7()),
+		UserID:  u.ID,
+		Type:    notification.TypeReminder,
+		Enabled: false,
+	}
+
+	t.Run("unknown_type", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewNotificationService(&NotificationServiceDeps{
+			TxManager:        mock_tx.NewMockTxManager(),
+			NotificationRepo: mock_notification.NewRepository(t),
+		})
+
+		_, err := service.SetPreferences(context.Background(), u, map[notification.Type]bool{"digest": true})
+		require.ErrorIs(t, err, notification.ErrUnknownType)
+	})
+
+	t.Run("saves_changed", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mock_notification.NewRepository(t)
+		repo.EXPECT().GetPreferences(mock.Anything, u.ID).
+			Return([]*notification.Preference{stored}, nil).Once()
+		repo.EXPECT().SavePreference(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, p *notification.Preference) error {
+				require.Equal(t, notification.TypeNewLogin, p.Type)
+				require.False(t, p.Enabled)
+				require.False(t, p.UpdatedAt.IsZero())
+				return nil
+			}).Once()
+
+		service := NewNotificationService(&NotificationServiceDeps{
+			TxManager:        mock_tx.NewMockTxManager(),
+			NotificationRepo: repo,
+		})
+
+		prefs, err := service.SetPreferences(context.Background(), u, map[notification.Type]bool{
+			notification.TypeReminder: false,
+			notification.TypeNewLogin: false,
+		})
+		require.NoError(t, err)
+		require.Len(t, prefs, len(notification.Types()))
+		for _, p := range prefs {
+			require.Equal(t, p.Type == notification.TypeOrgInvite, p.Enabled)
+		}
+	})
+}
@@ -0,0 +1,503 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+// OrgServiceDeps represents the dependencies required to construct an organisation service.
+type OrgServiceDeps struct {
+	TxManager tx.Manager
+	OrgRepo   org.Repository
+	UserRepo  user.Repository
+	OrgGuard  org.Guarder
+	Audit     audit.Recorder
+	Notifier  notification.Notifier
+}
+
+// orgService is a struct that implements the org.Service interface for managing organisations.
+type orgService struct {
+	tx       tx.Manager
+	orgRepo  org.Repository
+	userRepo user.Repository
+	guard    org.Guarder
+	audit    audit.Recorder
+	notifier notification.Notifier
+}
+
+// NewOrgService initializes and returns a new implementation of the org.Service interface.
+func NewOrgService(deps *OrgServiceDeps) org.Service {
+	return &orgService{
+		tx:       deps.TxManager,
+		orgRepo:  deps.OrgRepo,
+		userRepo: deps.UserRepo,
+		guard:    deps.OrgGuard,
+		audit:    deps.Audit,
+		notifier: deps.Notifier,
+	}
+}
+
+// List returns all organisations the user is a member of.
+func (s *orgService) List(ctx context.Context, u *user.User) ([]*org.Org, error) {
+	orgs, err := s.orgRepo.GetByUser(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("list orgs: %w (user %s)", err, u.ID)
+	}
+
+	return orgs, nil
+}
+
+// Get retrieves an organisation by its ID if the user is a member of it.
+func (s *orgService) Get(ctx context.Context, u *user.User, id uuid.UUID) (*org.Org, error) {
+	o, err := s.getGranted(ctx, rbac.READ, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("get org: %w", err)
+	}
+
+	return o, nil
+}
+
+// Create creates a new organisation making the user its owner.
+func (s *orgService) Create(ctx context.Context, u *user.User, data *org.CreateData) (*org.Org, error) {
+	if err := s.checkGranted(ctx, rbac.CREATE, nil, u); err != nil {
+		return nil, fmt.Errorf("create org: %w (user %s)", err, u.ID)
+	}
+
+	o := &org.Org{
+		Name:      data.Name,
+		CreatedAt: time.Now(),
+	}
+
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.Save(ctx, o); err != nil {
+			return err
+		}
+
+		return s.orgRepo.SaveMember(ctx, &org.Member{
+			OrgID:     o.ID,
+			UserID:    u.ID,
+			Role:      org.MemberRoleOwner,
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create org: %w (user %s)", err, u.ID)
+	}
+
+	return o, nil
+}
+
+// Update modifies the name of the organisation if the user is its administrator.
+func (s *orgService) Update(ctx context.Context, u *user.User, data *org.UpdateData) (*org.Org, error) {
+	o, err := s.getGranted(ctx, rbac.UPDATE, u, data.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update org: %w", err)
+	}
+
+	o.Name = data.Name
+	o.UpdatedAt = driver.ZeroTime(time.Now())
+	if err := s.orgRepo.Save(ctx, o); err != nil {
+		return nil, fmt.Errorf("update org: %w (user %s, org %s)", err, u.ID, o.ID)
+	}
+
+	return o, nil
+}
+
+// Delete removes the organisation with all its notes if the user is its owner.
+func (s *orgService) Delete(ctx context.Context, u *user.User, id uuid.UUID) (*org.Org, error) {
+	o, err := s.getGranted(ctx, rbac.DELETE, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete org: %w", err)
+	}
+
+	if err := s.orgRepo.Delete(ctx, o); err != nil {
+		return nil, fmt.Errorf("delete org: %w (user %s, org %s)", err, u.ID, o.ID)
+	}
+
+	return o, nil
+}
+
+// Members returns the members of the organisation if the user is a member of it.
+func (s *orgService) Members(ctx context.Context, u *user.User, id uuid.UUID) ([]*org.Member, error) {
+	o, err := s.getGranted(ctx, rbac.READ, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("list org members: %w", err)
+	}
+
+	members, err := s.orgRepo.GetMembers(ctx, o)
+	if err != nil {
+		return nil, fmt.Errorf("list org members: %w (user %s, org %s)", err, u.ID, o.ID)
+	}
+
+	return members, nil
+}
+
+// UpdateMember changes the role of the organisation member. Only owners may grant or revoke the owner role.
+func (s *orgService) UpdateMember(ctx context.Context, u *user.User, data *org.MemberData) (*org.Member, error) {
+	if !data.Role.IsValid() {
+		return nil, fmt.Errorf("update org member: %w %q (user %s)", org.ErrUnknownMemberRole, data.Role, u.ID)
+	}
+
+	o, m, err := s.getManagedMember(ctx, u, data.OrgID, data.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("update org member: %w", err)
+	}
+
+	if data.Role == org.MemberRoleOwner || m.Role == org.MemberRoleOwner {
+		if err := s.checkGranted(ctx, rbac.DELETE, o, u); err != nil {
+			return nil, fmt.Errorf("update org member: %w (user %s, org %s)", err, u.ID, o.ID)
+		}
+
+		if m.Role == org.MemberRoleOwner && data.Role != org.MemberRoleOwner {
+			if err := s.checkNotLastOwner(ctx, o); err != nil {
+				return nil, fmt.Errorf("update org member: %w (user %s, org %s)", err, u.ID, o.ID)
+			}
+		}
+	}
+
+	m.Role = data.Role
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.SaveMember(ctx, m); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, orgMemberEvent(ctx, audit.ActionOrgMemberUpdate, u, m))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update org member: %w (user %s)", err, u.ID)
+	}
+
+	return m, nil
+}
+
+// RemoveMember removes the user from the organisation. Members may leave the organisation themselves.
+func (s *orgService) RemoveMember(ctx context.Context, u *user.User, orgID uuid.UUID, userID uuid.UUID) error {
+	var (
+		o   *org.Org
+		m   *org.Member
+		err error
+	)
+
+	if userID == u.ID {
+		o, m, err = s.getOwnMember(ctx, u, orgID)
+	} else {
+		o, m, err = s.getManagedMember(ctx, u, orgID, userID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("remove org member: %w", err)
+	}
+
+	if m.Role == org.MemberRoleOwner {
+		if userID != u.ID {
+			if err := s.checkGranted(ctx, rbac.DELETE, o, u); err != nil {
+				return fmt.Errorf("remove org member: %w (user %s, org %s)", err, u.ID, o.ID)
+			}
+		}
+
+		if err := s.checkNotLastOwner(ctx, o); err != nil {
+			return fmt.Errorf("remove org member: %w (user %s, org %s)", err, u.ID, o.ID)
+		}
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.DeleteMember(ctx, m); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, orgMemberEvent(ctx, audit.ActionOrgMemberRemove, u, m))
+	})
+	if err != nil {
+		return fmt.Errorf("remove org member: %w (user %s)", err, u.ID)
+	}
+
+	return nil
+}
+
+// Invite creates an invitation of the user with the email to the organisation. Registered users are notified
+// of the invitation.
+func (s *orgService) Invite(ctx context.Context, u *user.User, data *org.InviteData) (*org.Invitation, error) {
+	if !data.Role.IsValid() {
+		return nil, fmt.Errorf("invite to org: %w %q (user %s)", org.ErrUnknownMemberRole, data.Role, u.ID)
+	}
+
+	o, err := s.getGranted(ctx, rbac.UPDATE, u, data.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("invite to org: %w", err)
+	}
+
+	if data.Role == org.MemberRoleOwner {
+		if err := s.checkGranted(ctx, rbac.DELETE, o, u); err != nil {
+			return nil, fmt.Errorf("invite to org: %w (user %s, org %s)", err, u.ID, o.ID)
+		}
+	}
+
+	email := strings.ToLower(data.Email)
+	invited, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		return nil, fmt.Errorf("invite to org: %w (user %s, org %s)", err, u.ID, o.ID)
+	}
+
+	if invited != nil {
+		_, err := s.orgRepo.GetMember(ctx, o.ID, invited.ID)
+		if err == nil {
+			return nil, fmt.Errorf("invite to org: %w (user %s, org %s)", org.ErrAlreadyMember, u.ID, o.ID)
+		}
+
+		if !errors.Is(err, org.ErrMemberNotFound) {
+			return nil, fmt.Errorf("invite to org: %w (user %s, org %s)", err, u.ID, o.ID)
+		}
+	}
+
+	invitation := &org.Invitation{
+		OrgID:     o.ID,
+		Email:     email,
+		Role:      data.Role,
+		InvitedBy: u.ID,
+		CreatedAt: time.Now(),
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.SaveInvitation(ctx, invitation); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, orgInvitationEvent(ctx, audit.ActionOrgInvite, u, invitation)); err != nil {
+			return err
+		}
+
+		if invited == nil {
+			return nil
+		}
+
+		return s.notifier.Notify(ctx, &notification.Notification{
+			UserID:    invited.ID,
+			Type:      notification.TypeOrgInvite,
+			Title:     "Invitation to " + o.Name,
+			Body:      fmt.Sprintf("%s invited you to the organisation %q sharing its notes.", u.Name, o.Name),
+			CreatedAt: invitation.CreatedAt,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invite to org: %w (user %s)", err, u.ID)
+	}
+
+	return invitation, nil
+}
+
+// Invitations returns the pending invitations of the organisation if the user is its administrator.
+func (s *orgService) Invitations(ctx context.Context, u *user.User, id uuid.UUID) ([]*org.Invitation, error) {
+	o, err := s.getGranted(ctx, rbac.UPDATE, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("list org invitations: %w", err)
+	}
+
+	invitations, err := s.orgRepo.GetInvitations(ctx, o)
+	if err != nil {
+		return nil, fmt.Errorf("list org invitations: %w (user %s, org %s)", err, u.ID, o.ID)
+	}
+
+	return invitations, nil
+}
+
+// RevokeInvitation removes the pending invitation of the organisation if the user is its administrator.
+func (s *orgService) RevokeInvitation(ctx context.Context, u *user.User, orgID uuid.UUID, id uuid.UUID) error {
+	o, err := s.getGranted(ctx, rbac.UPDATE, u, orgID)
+	if err != nil {
+		return fmt.Errorf("revoke org invitation: %w", err)
+	}
+
+	invitation, err := s.orgRepo.GetInvitation(ctx, id)
+	if err != nil || invitation.OrgID != o.ID {
+		return fmt.Errorf(
+			"revoke org invitation: %w (user %s, org %s, invitation %s)",
+			errors.Join(org.ErrInvitationNotFound, err),
+			u.ID,
+			o.ID,
+			id,
+		)
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.DeleteInvitation(ctx, invitation); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, orgInvitationEvent(ctx, audit.ActionOrgInviteRevoke, u, invitation))
+	})
+	if err != nil {
+		return fmt.Errorf("revoke org invitation: %w (user %s)", err, u.ID)
+	}
+
+	return nil
+}
+
+// MyInvitations returns the pending invitations sent to the email of the user.
+func (s *orgService) MyInvitations(ctx context.Context, u *user.User) ([]*org.Invitation, error) {
+	invitations, err := s.orgRepo.GetInvitationsByEmail(ctx, strings.ToLower(u.Email))
+	if err != nil {
+		return nil, fmt.Errorf("list user invitations: %w (user %s)", err, u.ID)
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation makes the user a member of the organisation according to the invitation sent to its email.
+func (s *orgService) AcceptInvitation(ctx context.Context, u *user.User, id uuid.UUID) (*org.Member, error) {
+	invitation, err := s.orgRepo.GetInvitation(ctx, id)
+	if err != nil || !strings.EqualFold(invitation.Email, u.Email) {
+		return nil, fmt.Errorf(
+			"accept org invitation: %w (user %s, invitation %s)",
+			errors.Join(org.ErrInvitationNotFound, err),
+			u.ID,
+			id,
+		)
+	}
+
+	m := &org.Member{
+		OrgID:     invitation.OrgID,
+		UserID:    u.ID,
+		Role:      invitation.Role,
+		CreatedAt: time.Now(),
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		_, err := s.orgRepo.GetMember(ctx, invitation.OrgID, u.ID)
+		if err == nil {
+			return org.ErrAlreadyMember
+		}
+
+		if !errors.Is(err, org.ErrMemberNotFound) {
+			return err
+		}
+
+		if err := s.orgRepo.SaveMember(ctx, m); err != nil {
+			return err
+		}
+
+		if err := s.orgRepo.DeleteInvitation(ctx, invitation); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, orgInvitationEvent(ctx, audit.ActionOrgInviteAccept, u, invitation))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("accept org invitation: %w (user %s, invitation %s)", err, u.ID, id)
+	}
+
+	return m, nil
+}
+
+// getGranted loads the organisation ensuring the operation is granted for the user.
+// Organisations the user is not a member of are reported as not found.
+func (s *orgService) getGranted(ctx context.Context, op rbac.Operation, u *user.User, id uuid.UUID) (*org.Org, error) {
+	o, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, org %s)", errors.Join(org.ErrNotFound, err), u.ID, id)
+	}
+
+	if err := s.checkGranted(ctx, rbac.READ, o, u); err != nil {
+		if errors.Is(err, org.ErrOperationForbiddenForUser) {
+			err = org.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("%w (user %s, org %s)", err, u.ID, id)
+	}
+
+	if op != rbac.READ {
+		if err := s.checkGranted(ctx, op, o, u); err != nil {
+			return nil, fmt.Errorf("%w (user %s, org %s)", err, u.ID, id)
+		}
+	}
+
+	return o, nil
+}
+
+// getManagedMember loads the organisation and its member ensuring the user may manage members.
+func (s *orgService) getManagedMember(
+	ctx context.Context,
+	u *user.User,
+	orgID uuid.UUID,
+	userID uuid.UUID,
+) (*org.Org, *org.Member, error) {
+	o, err := s.getGranted(ctx, rbac.UPDATE, u, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := s.orgRepo.GetMember(ctx, o.ID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w (user %s)", err, u.ID)
+	}
+
+	return o, m, nil
+}
+
+// getOwnMember loads the organisation and the membership of the user in it.
+func (s *orgService) getOwnMember(ctx context.Context, u *user.User, orgID uuid.UUID) (*org.Org, *org.Member, error) {
+	o, err := s.getGranted(ctx, rbac.READ, u, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := s.orgRepo.GetMember(ctx, o.ID, u.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w (user %s)", err, u.ID)
+	}
+
+	return o, m, nil
+}
+
+// checkNotLastOwner returns org.ErrLastOwner if the organisation has a single owner.
+func (s *orgService) checkNotLastOwner(ctx context.Context, o *org.Org) error {
+	owners, err := s.orgRepo.CountMembersByRole(ctx, o, org.MemberRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return org.ErrLastOwner
+	}
+
+	return nil
+}
+
+// checkGranted returns org.ErrOperationForbiddenForUser if the operation is not granted for the user.
+func (s *orgService) checkGranted(ctx context.Context, op rbac.Operation, o *org.Org, u *user.User) error {
+	granted, err := s.guard.IsGranted(ctx, op, o, u)
+	if err != nil {
+		return fmt.Errorf("check granted: %w", err)
+	}
+
+	if !granted {
+		return org.ErrOperationForbiddenForUser
+	}
+
+	return nil
+}
+
+// orgMemberEvent creates the audit event of the change of the organisation member.
+func orgMemberEvent(ctx context.Context, action audit.Action, u *user.User, m *org.Member) *audit.Event {
+	e := audit.NewEvent(ctx, action, u.ID, audit.TargetOrg, m.OrgID)
+	e.Details = fmt.Sprintf("user %s, role %s", m.UserID, m.Role)
+	return e
+}
+
+// orgInvitationEvent creates the audit event of the change of the organisation invitation.
+func orgInvitationEvent(ctx context.Context, action audit.Action, u *user.User, i *org.Invitation) *audit.Event {
+	e := audit.NewEvent(ctx, action, u.ID, audit.TargetOrg, i.OrgID)
+	e.Details = fmt.Sprintf("email %s, role %s", i.Email, i.Role)
+	return e
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_notification"
+	"github.com/xsqrty/notes/mocks/domain/mock_org"
+	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+func TestOrgService_Create(t *testing.T) {
+	t.Parallel()
+
+	name := gofakeit.Company()
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		mocker      func(repo *mock_org.Repository, guard *mock_org.Guarder)
+	}{
+		{
+			name: "successful_create",
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*org.Org)(nil), u).Return(true, nil).Once()
+				repo.EXPECT().Save(mock.Anything, mock.AnythingOfType("*org.Org")).Return(nil).Once()
+				repo.EXPECT().
+					SaveMember(mock.Anything, mock.MatchedBy(func(m *org.Member) bool {
+						return m.UserID == u.ID && m.Role == org.MemberRoleOwner
+					})).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			expectedErr: fmt.Sprintf("create org: organisation operation is forbidden for user (user %s)", u.ID),
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*org.Org)(nil), u).Return(false, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_org.NewRepository(t)
+			guard := mock_org.NewGuarder(t)
+			tc.mocker(repo, guard)
+
+			service := NewOrgService(&OrgServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				OrgRepo:   repo,
+				OrgGuard:  guard,
+				Audit:     newAuditRecorder(t),
+			})
+
+			result, err := service.Create(context.Background(), u, &org.CreateData{Name: name})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, name, result.Name)
+			mock.AssertExpectationsForObjects(t, repo, guard)
+		})
+	}
+}
+
+func TestOrgService_UpdateMember(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	o := &org.Org{
+		ID:   uuid.Must(uuid.NewV7()),
+		Name: gofakeit.Company(),
+	}
+
+	memberID := uuid.Must(uuid.NewV7())
+	member := func(role org.MemberRole) *org.Member {
+		return &org.Member{ID: uuid.Must(uuid.NewV7()), OrgID: o.ID, UserID: memberID, Role: role}
+	}
+
+	cases := []struct {
+		name        string
+		role        org.MemberRole
+		expected    org.MemberRole
+		expectedErr string
+		mocker      func(repo *mock_org.Repository, guard *mock_org.Guarder)
+	}{
+		{
+			name:     "successful_update",
+			role:     org.MemberRoleAdmin,
+			expected: org.MemberRoleAdmin,
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, o.ID).Return(o, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, o, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, o, u).Return(true, nil).Once()
+				repo.EXPECT().GetMember(mock.Anything, o.ID, memberID).Return(member(org.MemberRoleMember), nil).Once()
+				repo.EXPECT().SaveMember(mock.Anything, mock.AnythingOfType("*org.Member")).Return(nil).Once()
+			},
+		},
+		{
+			name: "last_owner",
+			role: org.MemberRoleMember,
+			expectedErr: fmt.Sprintf(
+				"update org member: organisation must keep at least one owner (user %s, org %s)",
+				u.ID,
+				o.ID,
+			),
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, o.ID).Return(o, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, o, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, o, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, o, u).Return(true, nil).Once()
+				repo.EXPECT().GetMember(mock.Anything, o.ID, memberID).Return(member(org.MemberRoleOwner), nil).Once()
+				repo.EXPECT().CountMembersByRole(mock.Anything, o, org.MemberRoleOwner).Return(1, nil).Once()
+			},
+		},
+		{
+			name: "owner_granted_by_admin",
+			role: org.MemberRoleOwner,
+			expectedErr: fmt.Sprintf(
+				"update org member: organisation operation is forbidden for user (user %s, org %s)",
+				u.ID,
+				o.ID,
+			),
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, o.ID).Return(o, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, o, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, o, u).Return(true, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, o, u).Return(false, nil).Once()
+				repo.EXPECT().GetMember(mock.Anything, o.ID, memberID).Return(member(org.MemberRoleMember), nil).Once()
+			},
+		},
+		{
+			name:        "not_member",
+			role:        org.MemberRoleAdmin,
+			expectedErr: fmt.Sprintf("update org member: organisation not found (user %s, org %s)", u.ID, o.ID),
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, o.ID).Return(o, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, o, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name: "unknown_role",
+			role: "guest",
+			expectedErr: fmt.Sprintf(
+				"update org member: unknown organisation member role \"guest\" (user %s)",
+				u.ID,
+			),
+			mocker: func(repo *mock_org.Repository, guard *mock_org.Guarder) {},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_org.NewRepository(t)
+			guard := mock_org.NewGuarder(t)
+			tc.mocker(repo, guard)
+
+			service := NewOrgService(&OrgServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				OrgRepo:   repo,
+				OrgGuard:  guard,
+				Audit:     newAuditRecorder(t),
+			})
+
+			result, err := service.UpdateMember(context.Background(), u, &org.MemberData{
+				OrgID:  o.ID,
+				UserID: memberID,
+				Role:   tc.role,
+			})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result.Role)
+			mock.AssertExpectationsForObjects(t, repo, guard)
+		})
+	}
+}
+
+func TestOrgService_Invite(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	o := &org.Org{
+		ID:   uuid.Must(uuid.NewV7()),
+		Name: gofakeit.Company(),
+	}
+
+	invited := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Email: "member@example.com",
+	}
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		mocker      func(repo *mock_org.Repository, notifier *mock_notification.Notifier)
+	}{
+		{
+			name: "successful_invite",
+			mocker: func(repo *mock_org.Repository, notifier *mock_notification.Notifier) {
+				repo.EXPECT().GetMember(mock.Anything, o.ID, invited.ID).Return(nil, org.ErrMemberNotFound).Once()
+				repo.EXPECT().SaveInvitation(mock.Anything, mock.AnythingOfType("*org.Invitation")).Return(nil).Once()
+				notifier.EXPECT().
+					Notify(mock.Anything, mock.AnythingOfType("*notification.Notification")).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			name: "already_member",
+			expectedErr: fmt.Sprintf(
+				"invite to org: user is already a member of the organisation (user %s, org %s)",
+				u.ID,
+				o.ID,
+			),
+			mocker: func(repo *mock_org.Repository, notifier *mock_notification.Notifier) {
+				repo.EXPECT().GetMember(mock.Anything, o.ID, invited.ID).Return(&org.Member{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_org.NewRepository(t)
+			guard := mock_org.NewGuarder(t)
+			users := mock_user.NewRepository(t)
+			notifier := mock_notification.NewNotifier(t)
+			repo.EXPECT().GetByID(mock.Anything, o.ID).Return(o, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, o, u).Return(true, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, o, u).Return(true, nil).Once()
+			users.EXPECT().GetByEmail(mock.Anything, invited.Email).Return(invited, nil).Once()
+			tc.mocker(repo, notifier)
+
+			service := NewOrgService(&OrgServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				OrgRepo:   repo,
+				UserRepo:  users,
+				OrgGuard:  guard,
+				Audit:     newAuditRecorder(t),
+				Notifier:  notifier,
+			})
+
+			result, err := service.Invite(context.Background(), u, &org.InviteData{
+				OrgID: o.ID,
+				Email: "Member@Example.com",
+				Role:  org.MemberRoleMember,
+			})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, invited.Email, result.Email)
+		})
+	}
+}
+
+func TestOrgService_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID:    uuid.Must(uuid.NewV7()),
+		Email: "member@example.com",
+	}
+
+	invitation := &org.Invitation{
+		ID:    uuid.Must(uuid.NewV7()),
+		OrgID: uuid.Must(uuid.NewV7()),
+		Email: u.Email,
+		Role:  org.MemberRoleAdmin,
+	}
+
+	cases := []struct {
+		name        string
+		email       string
+		expectedErr string
+		mocker      func(repo *mock_org.Repository)
+	}{
+		{
+			name:  "successful_accept",
+			email: "Member@Example.com",
+			mocker: func(repo *mock_org.Repository) {
+				repo.EXPECT().GetInvitation(mock.Anything, invitation.ID).Return(invitation, nil).Once()
+				repo.EXPECT().
+					GetMember(mock.Anything, invitation.OrgID, u.ID).
+					Return(nil, org.ErrMemberNotFound).
+					Once()
+				repo.EXPECT().SaveMember(mock.Anything, mock.AnythingOfType("*org.Member")).Return(nil).Once()
+				repo.EXPECT().DeleteInvitation(mock.Anything, invitation).Return(nil).Once()
+			},
+		},
+		{
+			name:  "already_member",
+			email: u.Email,
+			expectedErr: fmt.Sprintf(
+				"accept org invitation: user is already a member of the organisation (user %s, invitation %s)",
+				u.ID,
+				invitation.ID,
+			),
+			mocker: func(repo *mock_org.Repository) {
+				repo.EXPECT().GetInvitation(mock.Anything, invitation.ID).Return(invitation, nil).Once()
+				repo.EXPECT().GetMember(mock.Anything, invitation.OrgID, u.ID).Return(&org.Member{}, nil).Once()
+			},
+		},
+		{
+			name:  "another_email",
+			email: "other@example.com",
+			expectedErr: fmt.Sprintf(
+				"accept org invitation: organisation invitation not found (user %s, invitation %s)",
+				u.ID,
+				invitation.ID,
+			),
+			mocker: func(repo *mock_org.Repository) {
+				repo.EXPECT().GetInvitation(mock.Anything, invitation.ID).Return(invitation, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mock_org.NewRepository(t)
+			tc.mocker(repo)
+
+			service := NewOrgService(&OrgServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				OrgRepo:   repo,
+				UserRepo:  mock_user.NewRepository(t),
+				OrgGuard:  mock_org.NewGuarder(t),
+				Audit:     newAuditRecorder(t),
+			})
+
+			subject := &user.User{ID: u.ID, Email: tc.email}
+			result, err := service.AcceptInvitation(context.Background(), subject, invitation.ID)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, invitation.OrgID, result.OrgID)
+			require.Equal(t, invitation.Role, result.Role)
+			mock.AssertExpectationsForObjects(t, repo)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/policy"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+// PolicyServiceDeps represents the dependencies required to construct a policy service.
+type PolicyServiceDeps struct {
+	Engine         *rbac.PolicyEngine
+	PolicyGuard    policy.Guarder
+	UserRepo       user.Repository
+	NoteRepo       note.Repository
+	NoteGuard      note.Guarder
+	NoteAttributer rbac.Attributer[*note.Note, *user.User]
+}
+
+// policyService is a struct that implements the policy.Service interface for policies inspection.
+type policyService struct {
+	engine         *rbac.PolicyEngine
+	guard          policy.Guarder
+	userRepo       user.Repository
+	noteRepo       note.Repository
+	noteGuard      note.Guarder
+	noteAttributer rbac.Attributer[*note.Note, *user.User]
+}
+
+// NewPolicyService initializes and returns a new implementation of the policy.Service interface using the provided dependencies.
+func NewPolicyService(deps *PolicyServiceDeps) policy.Service {
+	return &policyService{
+		engine:         deps.Engine,
+		guard:          deps.PolicyGuard,
+		userRepo:       deps.UserRepo,
+		noteRepo:       deps.NoteRepo,
+		noteGuard:      deps.NoteGuard,
+		noteAttributer: deps.NoteAttributer,
+	}
+}
+
+// List returns the currently loaded policies if the user is authorized to read them.
+func (s *policyService) List(ctx context.Context, u *user.User) ([]rbac.Policy, error) {
+	if err := s.checkGranted(ctx, u); err != nil {
+		return nil, fmt.Errorf("list policies: %w (user %s)", err, u.ID)
+	}
+
+	return s.engine.Policies(), nil
+}
+
+// Explain evaluates the operation in a dry run and reports the final access decision with the matched policy.
+func (s *policyService) Explain(ctx context.Context, u *user.User, data *policy.ExplainData) (*policy.Explanation, error) {
+	if err := s.checkGranted(ctx, u); err != nil {
+		return nil, fmt.Errorf("explain policies: %w (user %s)", err, u.ID)
+	}
+
+	subject := u
+	if data.UserID != uuid.Nil && data.UserID != u.ID {
+		target, err := s.userRepo.GetByID(ctx, data.UserID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"explain policies: %w (user %s, target %s)",
+				errors.Join(user.ErrNotFound, err),
+				u.ID,
+				data.UserID,
+			)
+		}
+
+		subject = target
+	}
+
+	switch data.Resource {
+	case policy.ResourceNote:
+		res, err := s.explainNote(ctx, subject, data)
+		if err != nil {
+			return nil, fmt.Errorf("explain policies: %w (user %s, target %s)", err, u.ID, subject.ID)
+		}
+
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("explain policies: %w %q (user %s)", policy.ErrUnknownResource, data.Resource, u.ID)
+}
+
+// explainNote evaluates the operation on the note for the subject.
+func (s *policyService) explainNote(
+	ctx context.Context,
+	subject *user.User,
+	data *policy.ExplainData,
+) (*policy.Explanation, error) {
+	var n *note.Note
+	if data.ResourceID != uuid.Nil {
+		var err error
+		n, err = s.noteRepo.GetByID(ctx, subject, data.ResourceID)
+		if err != nil {
+			return nil, fmt.Errorf("%w (note %s)", errors.Join(note.ErrNotFound, err), data.ResourceID)
+		}
+	}
+
+	attrs, err := s.noteAttributer(ctx, n, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := s.noteGuard.IsGranted(ctx, data.Operation, n, subject)
+	if err != nil {
+		return nil, fmt.Errorf("check granted: %w", err)
+	}
+
+	return &policy.Explanation{
+		Granted:    granted,
+		Decision:   s.engine.Evaluate(policy.ResourceNote, data.Operation, attrs),
+		Attributes: attrs,
+	}, nil
+}
+
+// checkGranted returns policy.ErrOperationForbiddenForUser if reading policies is not granted for the user.
+func (s *policyService) checkGranted(ctx context.Context, u *user.User) error {
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, nil, u)
+	if err != nil {
+		return fmt.Errorf("check granted: %w", err)
+	}
+
+	if !granted {
+		return policy.ErrOperationForbiddenForUser
+	}
+
+	return nil
+}
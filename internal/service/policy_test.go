@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/policy"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_policy"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+func TestPolicyService_Explain(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	n := &note.Note{
+		ID:     uuid.Must(uuid.NewV7()),
+		Name:   "draft",
+		UserId: uuid.Must(uuid.NewV7()),
+	}
+
+	allowOwner := rbac.Policy{
+		Name:       "allow-owner",
+		Effect:     rbac.EffectAllow,
+		Resource:   policy.ResourceNote,
+		Operations: []string{"update"},
+		Conditions: []rbac.Condition{{Attribute: "resource.user_id", Operator: rbac.OperatorEq, Ref: "subject.id"}},
+	}
+
+	allowAny := rbac.Policy{
+		Name:       "allow-any",
+		Effect:     rbac.EffectAllow,
+		Resource:   rbac.AnyResource,
+		Operations: []string{"update", "read"},
+	}
+
+	denyDrafts := rbac.Policy{
+		Name:       "deny-drafts",
+		Effect:     rbac.EffectDeny,
+		Resource:   policy.ResourceNote,
+		Operations: []string{"update"},
+		Conditions: []rbac.Condition{{Attribute: "resource.name", Operator: rbac.OperatorIn, Value: []any{"draft"}}},
+	}
+
+	cases := []struct {
+		name        string
+		policies    []rbac.Policy
+		data        *policy.ExplainData
+		expected    rbac.Decision
+		expectedErr string
+		mocker      func(guard *mock_policy.Guarder, noteRepo *mock_note.Repository, noteGuard *mock_note.Guarder)
+	}{
+		{
+			name:     "deny_overrides_allow",
+			policies: []rbac.Policy{allowAny, denyDrafts},
+			data:     &policy.ExplainData{Resource: policy.ResourceNote, Operation: rbac.UPDATE, ResourceID: n.ID},
+			expected: rbac.Decision{Effect: rbac.EffectDeny, Policy: denyDrafts.Name},
+			mocker: func(guard *mock_policy.Guarder, noteRepo *mock_note.Repository, noteGuard *mock_note.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, (*rbac.Policy)(nil), u).Return(true, nil).Once()
+				noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+				noteGuard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, n, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:     "not_matched",
+			policies: []rbac.Policy{allowOwner},
+			data:     &policy.ExplainData{Resource: policy.ResourceNote, Operation: rbac.UPDATE, ResourceID: n.ID},
+			expected: rbac.Decision{},
+			mocker: func(guard *mock_policy.Guarder, noteRepo *mock_note.Repository, noteGuard *mock_note.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, (*rbac.Policy)(nil), u).Return(true, nil).Once()
+				noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+				noteGuard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, n, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:     "allow_without_resource",
+			policies: []rbac.Policy{allowAny, denyDrafts},
+			data:     &policy.ExplainData{Resource: policy.ResourceNote, Operation: rbac.UPDATE},
+			expected: rbac.Decision{Effect: rbac.EffectAllow, Policy: allowAny.Name},
+			mocker: func(guard *mock_policy.Guarder, noteRepo *mock_note.Repository, noteGuard *mock_note.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, (*rbac.Policy)(nil), u).Return(true, nil).Once()
+				noteGuard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, (*note.Note)(nil), u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:        "unknown_resource",
+			data:        &policy.ExplainData{Resource: "unknown", Operation: rbac.READ},
+			expectedErr: fmt.Sprintf("explain policies: unknown policy resource \"unknown\" (user %s)", u.ID),
+			mocker: func(guard *mock_policy.Guarder, noteRepo *mock_note.Repository, noteGuard *mock_note.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, (*rbac.Policy)(nil), u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			data:        &policy.ExplainData{Resource: policy.ResourceNote, Operation: rbac.READ},
+			expectedErr: fmt.Sprintf("explain policies: policy operation is forbidden for user (user %s)", u.ID),
+			mocker: func(guard *mock_policy.Guarder, noteRepo *mock_note.Repository, noteGuard *mock_note.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.READ, (*rbac.Policy)(nil), u).Return(false, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_policy.NewGuarder(t)
+			noteRepo := mock_note.NewRepository(t)
+			noteGuard := mock_note.NewGuarder(t)
+			tc.mocker(guard, noteRepo, noteGuard)
+
+			engine := rbac.NewPolicyEngine()
+			require.NoError(t, engine.Load(tc.policies))
+
+			service := NewPolicyService(&PolicyServiceDeps{
+				Engine:      engine,
+				PolicyGuard: guard,
+				NoteRepo:    noteRepo,
+				NoteGuard:   noteGuard,
+				NoteAttributer: func(_ context.Context, n *note.Note, u *user.User) (rbac.Attributes, error) {
+					attrs := rbac.Attributes{"subject.id": u.ID}
+					if n != nil {
+						attrs["resource.user_id"] = n.UserId
+						attrs["resource.name"] = n.Name
+					}
+
+					return attrs, nil
+				},
+			})
+
+			result, err := service.Explain(context.Background(), u, tc.data)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result.Decision)
+			mock.AssertExpectationsForObjects(t, guard, noteRepo, noteGuard)
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/notes/pkg/rrule"
+	"github.com/xsqrty/op/driver"
+)
+
+// ReminderServiceDeps represents the dependencies required to construct a reminder service.
+type ReminderServiceDeps struct {
+	TxManager    tx.Manager
+	ReminderRepo reminder.Repository
+	UserRepo     user.Repository
+	NoteRepo     note.Repository
+	NoteGuard    note.Guarder
+	Notifiers    []reminder.Notifier
+	PollInterval time.Duration
+	BatchSize    int
+	RetryDelay   time.Duration
+	MaxAttempts  int
+}
+
+// reminderService is a struct that implements the reminder.Service interface for managing reminders of notes.
+type reminderService struct {
+	tx           tx.Manager
+	reminderRepo reminder.Repository
+	userRepo     user.Repository
+	noteRepo     note.Repository
+	guard        note.Guarder
+	notifiers    []reminder.Notifier
+	pollInterval time.Duration
+	batchSize    int
+	retryDelay   time.Duration
+	maxAttempts  int
+}
+
+// NewReminderService initializes and returns a new implementation of the reminder.Service interface.
+func NewReminderService(deps *ReminderServiceDeps) reminder.Service {
+	return &reminderService{
+		tx:           deps.TxManager,
+		reminderRepo: deps.ReminderRepo,
+		userRepo:     deps.UserRepo,
+		noteRepo:     deps.NoteRepo,
+		guard:        deps.NoteGuard,
+		notifiers:    deps.Notifiers,
+		pollInterval: deps.PollInterval,
+		batchSize:    deps.BatchSize,
+		retryDelay:   deps.RetryDelay,
+		maxAttempts:  deps.MaxAttempts,
+	}
+}
+
+// Get returns the reminder of the note set by the user if the user may read the note.
+func (s *reminderService) Get(ctx context.Context, u *user.User, noteID uuid.UUID) (*reminder.Reminder, error) {
+	r, err := s.getReminder(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("get reminder: %w", err)
+	}
+
+	return r, nil
+}
+
+// Set sets the reminder of the note if the user may read the note, replacing the previous reminder of the user.
+// One-off reminders must be in the future, recurring reminders fire at the first occurrence after now.
+func (s *reminderService) Set(ctx context.Context, u *user.User, data *reminder.SetData) (*reminder.Reminder, error) {
+	if _, err := s.getNote(ctx, u, data.NoteID); err != nil {
+		return nil, fmt.Errorf("set reminder: %w", err)
+	}
+
+	r, err := s.reminderRepo.GetByNote(ctx, data.NoteID, u.ID)
+	if err != nil {
+		if !errors.Is(err, reminder.ErrNotFound) {
+			return nil, fmt.Errorf("set reminder: %w (user %s, note %s)", err, u.ID, data.NoteID)
+		}
+
+		r = &reminder.Reminder{NoteID: data.NoteID, UserID: u.ID, CreatedAt: time.Now()}
+	} else {
+		r.UpdatedAt = driver.ZeroTime(time.Now())
+	}
+
+	r.RemindAt = data.RemindAt
+	r.TimeZone = data.TimeZone
+	r.Rule = data.Rule
+	r.SnoozedUntil = driver.ZeroTime{}
+	r.FiredAt = driver.ZeroTime{}
+	r.Fired = 0
+	r.Attempts = 0
+	r.LastError = ""
+	if r.TimeZone == "" {
+		r.TimeZone = time.UTC.String()
+	}
+
+	if r.Rule != "" {
+		rule, err := rrule.Parse(r.Rule)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"set reminder: %w: %w (user %s, note %s)",
+				reminder.ErrInvalidRule,
+				err,
+				u.ID,
+				r.NoteID,
+			)
+		}
+
+		r.Rule = rule.String()
+	}
+
+	now, after := time.Now(), r.RemindAt.Add(-time.Nanosecond)
+	if now.After(after) {
+		after = now
+	}
+
+	next, err := s.next(r, after)
+	if err != nil {
+		return nil, fmt.Errorf("set reminder: %w (user %s, note %s)", err, u.ID, r.NoteID)
+	}
+
+	if next.IsZero() || !next.After(now) {
+		return nil, fmt.Errorf("set reminder: %w (user %s, note %s)", reminder.ErrNoOccurrence, u.ID, r.NoteID)
+	}
+
+	r.NextAt = driver.ZeroTime(next)
+	r.Schedule(reminder.StatusFired)
+	if err := s.reminderRepo.Save(ctx, r); err != nil {
+		return nil, fmt.Errorf("set reminder: %w (user %s, note %s)", err, u.ID, r.NoteID)
+	}
+
+	return r, nil
+}
+
+// Snooze fires the last firing of the reminder again at the given time, replacing the previous snooze.
+// The next occurrences of recurring reminders are kept.
+func (s *reminderService) Snooze(
+	ctx context.Context,
+	u *user.User,
+	noteID uuid.UUID,
+	until time.Time,
+) (*reminder.Reminder, error) {
+	r, err := s.getReminder(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("snooze reminder: %w", err)
+	}
+
+	if time.Time(r.FiredAt).IsZero() {
+		return nil, fmt.Errorf("snooze reminder: %w (user %s, note %s)", reminder.ErrNotFired, u.ID, noteID)
+	}
+
+	if !until.After(time.Now()) {
+		return nil, fmt.Errorf("snooze reminder: %w (user %s, note %s)", reminder.ErrSnoozeInPast, u.ID, noteID)
+	}
+
+	r.SnoozedUntil = driver.ZeroTime(until)
+	r.UpdatedAt = driver.ZeroTime(time.Now())
+	r.Schedule(reminder.StatusFired)
+	if err := s.reminderRepo.Save(ctx, r); err != nil {
+		return nil, fmt.Errorf("snooze reminder: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return r, nil
+}
+
+// Dismiss cancels the snooze of the reminder and the occurrence of one-off reminders. The next occurrences of
+// recurring reminders are kept, they are removed with Delete.
+func (s *reminderService) Dismiss(ctx context.Context, u *user.User, noteID uuid.UUID) (*reminder.Reminder, error) {
+	r, err := s.getReminder(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("dismiss reminder: %w", err)
+	}
+
+	r.SnoozedUntil = driver.ZeroTime{}
+	if r.Rule == "" {
+		r.NextAt = driver.ZeroTime{}
+	}
+
+	r.UpdatedAt = driver.ZeroTime(time.Now())
+	r.Schedule(reminder.StatusDismissed)
+	if err := s.reminderRepo.Save(ctx, r); err != nil {
+		return nil, fmt.Errorf("dismiss reminder: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return r, nil
+}
+
+// Delete removes the reminder of the note set by the user.
+func (s *reminderService) Delete(ctx context.Context, u *user.User, noteID uuid.UUID) (*reminder.Reminder, error) {
+	r, err := s.getReminder(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("delete reminder: %w", err)
+	}
+
+	if err := s.reminderRepo.Delete(ctx, r); err != nil {
+		return nil, fmt.Errorf("delete reminder: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return r, nil
+}
+
+// Run fires due reminders with the poll interval until the context is done, up to the batch size per tick.
+// Errors are reported to onError.
+func (s *reminderService) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for i := 0; i < s.batchSize && ctx.Err() == nil; i++ {
+				fired, err := s.fire(ctx)
+				if err != nil {
+					onError(err)
+				}
+
+				if !fired {
+					break
+				}
+			}
+		}
+	}
+}
+
+// fire fires the earliest due reminder and reports whether a due reminder was found. The reminder stays locked
+// until the transaction ends, so every firing is delivered by one instance, and the notifiers only write within
+// the transaction, so the firing is committed along with its notifications. Reminders of notes the user may no
+// longer read are dismissed. A failed firing is retried after the retry delay up to the max attempts.
+func (s *reminderService) fire(ctx context.Context) (bool, error) {
+	var r *reminder.Reminder
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.reminderRepo.GetDue(ctx)
+		if err != nil {
+			return err
+		}
+
+		n, err := s.notification(ctx, r)
+		if err != nil {
+			if !errors.Is(err, note.ErrNotFound) && !errors.Is(err, note.ErrOperationForbiddenForUser) {
+				return err
+			}
+
+			r.NextAt, r.SnoozedUntil, r.LastError = driver.ZeroTime{}, driver.ZeroTime{}, err.Error()
+			r.Schedule(reminder.StatusDismissed)
+			return s.reminderRepo.Save(ctx, r)
+		}
+
+		for _, notifier := range s.notifiers {
+			if err := notifier.Notify(ctx, n); err != nil {
+				return err
+			}
+		}
+
+		if err := s.advance(r, n.FiredAt); err != nil {
+			return err
+		}
+
+		return s.reminderRepo.Save(ctx, r)
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, reminder.ErrNotFound) {
+		return false, nil
+	}
+
+	if r == nil {
+		return false, fmt.Errorf("fire reminder: %w", err)
+	}
+
+	if retryErr := s.retry(ctx, r.ID, err); retryErr != nil {
+		err = errors.Join(err, retryErr)
+	}
+
+	return true, fmt.Errorf("fire reminder: %w (user %s, note %s)", err, r.UserID, r.NoteID)
+}
+
+// notification returns the notification of the firing reminder if its user may still read the note.
+func (s *reminderService) notification(ctx context.Context, r *reminder.Reminder) (*reminder.Notification, error) {
+	u, err := s.userRepo.GetByID(ctx, r.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.getNote(ctx, u, r.NoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reminder.Notification{Reminder: r, User: u, Note: n, FiredAt: time.Now()}, nil
+}
+
+// advance marks the reminder fired at the time and moves past the due occurrence and snooze.
+func (s *reminderService) advance(r *reminder.Reminder, firedAt time.Time) error {
+	if err := s.pass(r, firedAt); err != nil {
+		return err
+	}
+
+	r.FiredAt = driver.ZeroTime(firedAt)
+	r.Fired++
+	r.Attempts = 0
+	r.LastError = ""
+	r.UpdatedAt = driver.ZeroTime(firedAt)
+	r.Schedule(reminder.StatusFired)
+	return nil
+}
+
+// pass moves the reminder past the snooze and the occurrence due at the time.
+func (s *reminderService) pass(r *reminder.Reminder, at time.Time) error {
+	if !time.Time(r.SnoozedUntil).After(at) {
+		r.SnoozedUntil = driver.ZeroTime{}
+	}
+
+	if !time.Time(r.NextAt).After(at) {
+		next, err := s.next(r, at)
+		if err != nil {
+			return err
+		}
+
+		r.NextAt = driver.ZeroTime(next)
+	}
+
+	return nil
+}
+
+// retry postpones the reminder by the retry delay after the failed firing. Once the firing failed as many times
+// as the attempts allow, the due occurrence is skipped and the reminder is dismissed unless it occurs again.
+func (s *reminderService) retry(ctx context.Context, id uuid.UUID, cause error) error {
+	return s.tx.Transact(ctx, func(ctx context.Context) error {
+		r, err := s.reminderRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		r.Attempts++
+		r.LastError = cause.Error()
+		if r.Attempts < s.maxAttempts {
+			r.DueAt = driver.ZeroTime(now.Add(s.retryDelay))
+			return s.reminderRepo.Save(ctx, r)
+		}
+
+		if err := s.pass(r, now); err != nil {
+			r.NextAt, r.SnoozedUntil = driver.ZeroTime{}, driver.ZeroTime{}
+			r.LastError = errors.Join(cause, err).Error()
+		}
+
+		r.Attempts = 0
+		r.UpdatedAt = driver.ZeroTime(now)
+		r.Schedule(reminder.StatusDismissed)
+		return s.reminderRepo.Save(ctx, r)
+	})
+}
+
+// next returns the first occurrence of the reminder after the time, zero when the reminder has no more
+// occurrences. One-off reminders occur once at the remind time.
+func (s *reminderService) next(r *reminder.Reminder, after time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", reminder.ErrInvalidTimeZone, err)
+	}
+
+	if r.Rule == "" {
+		if r.RemindAt.After(after) {
+			return r.RemindAt, nil
+		}
+
+		return time.Time{}, nil
+	}
+
+	rule, err := rrule.Parse(r.Rule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", reminder.ErrInvalidRule, err)
+	}
+
+	next, _ := rule.Next(r.RemindAt.In(loc), after)
+	return next, nil
+}
+
+// getReminder retrieves the reminder of the note set by the user if the user may read the note.
+func (s *reminderService) getReminder(ctx context.Context, u *user.User, noteID uuid.UUID) (*reminder.Reminder, error) {
+	if _, err := s.getNote(ctx, u, noteID); err != nil {
+		return nil, err
+	}
+
+	r, err := s.reminderRepo.GetByNote(ctx, noteID, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	return r, nil
+}
+
+// getNote retrieves the note if the user may read it.
+func (s *reminderService) getNote(ctx context.Context, u *user.User, noteID uuid.UUID) (*note.Note, error) {
+	n, err := s.noteRepo.GetByID(ctx, u, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, note %s)", errors.Join(note.ErrNotFound, err), u.ID, noteID)
+	}
+
+	granted, err := s.guard.IsGranted(ctx, rbac.READ, n, u)
+	if err != nil {
+		return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, noteID)
+	}
+
+	if !granted {
+		return nil, fmt.Errorf("%w (user %s, note %s)", note.ErrOperationForbiddenForUser, u.ID, noteID)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/notification"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+)
+
+// inboxNotifier delivers fired reminders to the in-app inbox of the user.
+type inboxNotifier struct {
+	notifications notification.Notifier
+}
+
+// webhookNotifier delivers fired reminders to the webhooks of the user through the outbox.
+type webhookNotifier struct {
+	events event.Publisher
+}
+
+// emailNotifier delivers fired reminders to the email address of the user through the outbox.
+type emailNotifier struct {
+	events event.Publisher
+}
+
+// NewInboxNotifier initializes and returns a reminder.Notifier storing notifications in the inbox of the user.
+func NewInboxNotifier(notifications notification.Notifier) reminder.Notifier {
+	return &inboxNotifier{notifications: notifications}
+}
+
+// NewWebhookNotifier initializes and returns a reminder.Notifier publishing reminder.fired events.
+func NewWebhookNotifier(events event.Publisher) reminder.Notifier {
+	return &webhookNotifier{events: events}
+}
+
+// NewEmailNotifier initializes and returns a reminder.Notifier publishing reminder.email events, the emails
+// are sent by the handler returned by NewReminderEmailHandler once the firing is committed.
+func NewEmailNotifier(events event.Publisher) reminder.Notifier {
+	return &emailNotifier{events: events}
+}
+
+// NewReminderEmailHandler returns the event.Handler sending the emails of reminder.email events through the mailer.
+// Failed emails are retried by the outbox until its delivery attempts are exhausted.
+func NewReminderEmailHandler(mailer reminder.Mailer) event.Handler {
+	return func(ctx context.Context, e *event.Event) error {
+		var payload event.EmailPayload
+		if err := e.Decode(&payload); err != nil {
+			return fmt.Errorf("send reminder email: decode payload: %w", err)
+		}
+
+		if err := mailer.Send(ctx, payload.To, payload.Subject, payload.Body); err != nil {
+			return fmt.Errorf("send reminder email: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// Notify stores the notification of the fired reminder in the inbox of the user, unless the user disabled
+// reminder notifications.
+func (n *inboxNotifier) Notify(ctx context.Context, rn *reminder.Notification) error {
+	err := n.notifications.Notify(ctx, &notification.Notification{
+		UserID:    rn.User.ID,
+		Type:      notification.TypeReminder,
+		Title:     reminderTitle(rn),
+		Body:      reminderBody(rn),
+		NoteID:    uuid.NullUUID{UUID: rn.Note.ID, Valid: true},
+		CreatedAt: rn.FiredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("notify inbox: %w", err)
+	}
+
+	return nil
+}
+
+// Notify publishes the reminder.fired event delivered to the webhooks subscribed by the user.
+func (n *webhookNotifier) Notify(ctx context.Context, rn *reminder.Notification) error {
+	ev, err := event.NewReminderFiredEvent(rn)
+	if err != nil {
+		return fmt.Errorf("notify webhook: %w", err)
+	}
+
+	if err := n.events.Publish(ctx, ev); err != nil {
+		return fmt.Errorf("notify webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Notify publishes the reminder.email event with the email of the fired reminder to the user.
+func (n *emailNotifier) Notify(ctx context.Context, rn *reminder.Notification) error {
+	ev, err := event.New(event.TypeReminderEmail, rn.Reminder.ID, &event.EmailPayload{
+		To:      rn.User.Email,
+		Subject: reminderTitle(rn),
+		Body:    reminderBody(rn),
+	})
+	if err != nil {
+		return fmt.Errorf("notify email: %w", err)
+	}
+
+	if err := n.events.Publish(ctx, ev); err != nil {
+		return fmt.Errorf("notify email: %w", err)
+	}
+
+	return nil
+}
+
+// reminderTitle returns the title of the notification of the fired reminder.
+func reminderTitle(rn *reminder.Notification) string {
+	return "Reminder: " + rn.Note.Name
+}
+
+// reminderBody returns the body of the notification of the fired reminder, with the occurrence in the time zone
+// of the reminder.
+func reminderBody(rn *reminder.Notification) string {
+	at := rn.FiredAt
+	if loc, err := time.LoadLocation(rn.Reminder.TimeZone); err == nil {
+		at = at.In(loc)
+	}
+
+	return fmt.Sprintf(
+		"You asked to be reminded of the note %q at %s.",
+		rn.Note.Name,
+		at.Format("2006-01-02 15:04 MST"),
+	)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_event"
+	"github.com/xsqrty/notes/mocks/domain/mock_reminder"
+)
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	rn := &reminder.Notification{
+		Reminder: &reminder.Reminder{ID: uuid.Must(uuid.NewV7()), TimeZone: "UTC"},
+		User:     &user.User{ID: uuid.Must(uuid.NewV7()), Email: "user@example.com"},
+		Note:     &note.Note{ID: uuid.Must(uuid.NewV7()), Name: "Weekly review"},
+		FiredAt:  time.Date(2025, 8, 4, 9, 30, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		name        string
+		sendErr     error
+		expectedErr string
+	}{
+		{
+			name: "sent",
+		},
+		{
+			name:        "send_failed",
+			sendErr:     errors.New("smtp unavailable"),
+			expectedErr: "send reminder email: smtp unavailable",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var published *event.Event
+			events := mock_event.NewPublisher(t)
+			events.EXPECT().Publish(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, e ...*event.Event) error {
+					published = e[0]
+					return nil
+				}).Once()
+
+			require.NoError(t, NewEmailNotifier(events).Notify(context.Background(), rn))
+			require.Equal(t, event.TypeReminderEmail, published.Type)
+			require.Equal(t, rn.Reminder.ID, published.AggregateID)
+
+			mailer := mock_reminder.NewMailer(t)
+			mailer.EXPECT().
+				Send(
+					mock.Anything,
+					"user@example.com",
+					"Reminder: Weekly review",
+					`You asked to be reminded of the note "Weekly review" at 2025-08-04 09:30 UTC.`,
+				).
+				Return(tc.sendErr).
+				Once()
+
+			err := NewReminderEmailHandler(mailer)(context.Background(), published)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_reminder"
+	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+func TestReminderService_Set(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	n := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour).Truncate(time.Second)
+	lastWeek := now.Add(-7 * 24 * time.Hour).Truncate(time.Second)
+
+	cases := []struct {
+		name         string
+		data         *reminder.SetData
+		existing     *reminder.Reminder
+		granted      bool
+		expectedErr  error
+		expectedRule string
+		expectedNext time.Time
+	}{
+		{
+			name:         "one_off",
+			data:         &reminder.SetData{NoteID: n.ID, RemindAt: tomorrow},
+			granted:      true,
+			expectedNext: tomorrow,
+		},
+		{
+			name:         "recurring_started_in_past",
+			data:         &reminder.SetData{NoteID: n.ID, RemindAt: lastWeek, Rule: "RRULE:freq=daily;interval=1"},
+			granted:      true,
+			expectedRule: "FREQ=DAILY",
+			expectedNext: lastWeek.Add(8 * 24 * time.Hour),
+		},
+		{
+			name: "replaces_existing",
+			data: &reminder.SetData{NoteID: n.ID, RemindAt: tomorrow},
+			existing: &reminder.Reminder{
+				ID:      uuid.Must(uuid.NewV7()),
+				NoteID:  n.ID,
+				UserID:  u.ID,
+				Rule:    "FREQ=WEEKLY",
+				FiredAt: driver.ZeroTime(lastWeek),
+				Fired:   3,
+			},
+			granted:      true,
+			expectedNext: tomorrow,
+		},
+		{
+			name:        "one_off_in_past",
+			data:        &reminder.SetData{NoteID: n.ID, RemindAt: lastWeek},
+			granted:     true,
+			expectedErr: reminder.ErrNoOccurrence,
+		},
+		{
+			name:        "rule_ended",
+			data:        &reminder.SetData{NoteID: n.ID, RemindAt: lastWeek, Rule: "FREQ=DAILY;COUNT=2"},
+			granted:     true,
+			expectedErr: reminder.ErrNoOccurrence,
+		},
+		{
+			name:        "invalid_rule",
+			data:        &reminder.SetData{NoteID: n.ID, RemindAt: tomorrow, Rule: "FREQ=HOURLY"},
+			granted:     true,
+			expectedErr: reminder.ErrInvalidRule,
+		},
+		{
+			name:        "invalid_time_zone",
+			data:        &reminder.SetData{NoteID: n.ID, RemindAt: tomorrow, TimeZone: "Mars/Olympus"},
+			granted:     true,
+			expectedErr: reminder.ErrInvalidTimeZone,
+		},
+		{
+			name:        "not_granted",
+			data:        &reminder.SetData{NoteID: n.ID, RemindAt: tomorrow},
+			expectedErr: note.ErrOperationForbiddenForUser,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			noteRepo := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+			repo := mock_reminder.NewRepository(t)
+
+			noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(tc.granted, nil).Once()
+			if tc.granted {
+				if tc.existing != nil {
+					repo.EXPECT().GetByNote(mock.Anything, n.ID, u.ID).Return(tc.existing, nil).Once()
+				} else {
+					repo.EXPECT().GetByNote(mock.Anything, n.ID, u.ID).Return(nil, reminder.ErrNotFound).Once()
+				}
+			}
+
+			if tc.expectedErr == nil {
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			service := NewReminderService(&ReminderServiceDeps{
+				ReminderRepo: repo,
+				NoteRepo:     noteRepo,
+				NoteGuard:    guard,
+			})
+
+			r, err := service.Set(context.Background(), u, tc.data)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, n.ID, r.NoteID)
+			require.Equal(t, u.ID, r.UserID)
+			require.Equal(t, "UTC", r.TimeZone)
+			require.Equal(t, tc.expectedRule, r.Rule)
+			require.Equal(t, reminder.StatusScheduled, r.Status)
+			require.True(t, tc.expectedNext.Equal(time.Time(r.NextAt)))
+			require.True(t, tc.expectedNext.Equal(time.Time(r.DueAt)))
+			require.True(t, time.Time(r.FiredAt).IsZero())
+			require.Zero(t, r.Fired)
+			if tc.existing != nil {
+				require.Equal(t, tc.existing.ID, r.ID)
+			}
+		})
+	}
+}
+
+func TestReminderService_Snooze(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	n := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	now := time.Now()
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	cases := []struct {
+		name        string
+		reminder    *reminder.Reminder
+		until       time.Time
+		expectedErr error
+		expectedDue time.Time
+	}{
+		{
+			name: "snooze_before_next",
+			reminder: &reminder.Reminder{
+				Rule:    "FREQ=WEEKLY",
+				Status:  reminder.StatusScheduled,
+				NextAt:  driver.ZeroTime(nextWeek),
+				FiredAt: driver.ZeroTime(now.Add(-time.Minute)),
+			},
+			until:       now.Add(time.Hour),
+			expectedDue: now.Add(time.Hour),
+		},
+		{
+			name: "snooze_fired_one_off",
+			reminder: &reminder.Reminder{
+				Status:  reminder.StatusFired,
+				FiredAt: driver.ZeroTime(now.Add(-time.Minute)),
+			},
+			until:       now.Add(time.Hour),
+			expectedDue: now.Add(time.Hour),
+		},
+		{
+			name:        "not_fired",
+			reminder:    &reminder.Reminder{Status: reminder.StatusScheduled, NextAt: driver.ZeroTime(nextWeek)},
+			until:       now.Add(time.Hour),
+			expectedErr: reminder.ErrNotFired,
+		},
+		{
+			name: "in_past",
+			reminder: &reminder.Reminder{
+				Status:  reminder.StatusFired,
+				FiredAt: driver.ZeroTime(now.Add(-time.Minute)),
+			},
+			until:       now.Add(-time.Hour),
+			expectedErr: reminder.ErrSnoozeInPast,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			noteRepo := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+			repo := mock_reminder.NewRepository(t)
+
+			noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(true, nil).Once()
+			repo.EXPECT().GetByNote(mock.Anything, n.ID, u.ID).Return(tc.reminder, nil).Once()
+			if tc.expectedErr == nil {
+				repo.EXPECT().Save(mock.Anything, tc.reminder).Return(nil).Once()
+			}
+
+			service := NewReminderService(&ReminderServiceDeps{
+				ReminderRepo: repo,
+				NoteRepo:     noteRepo,
+				NoteGuard:    guard,
+			})
+
+			r, err := service.Snooze(context.Background(), u, n.ID, tc.until)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, reminder.StatusScheduled, r.Status)
+			require.True(t, tc.until.Equal(time.Time(r.SnoozedUntil)))
+			require.True(t, tc.expectedDue.Equal(time.Time(r.DueAt)))
+		})
+	}
+}
+
+func TestReminderService_Dismiss(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7())}
+	n := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID}
+	now := time.Now()
+
+	cases := []struct {
+		name           string
+		reminder       *reminder.Reminder
+		expectedStatus reminder.Status
+		expectedDue    time.Time
+	}{
+		{
+			name: "one_off",
+			reminder: &reminder.Reminder{
+				NextAt:       driver.ZeroTime(now.Add(time.Hour)),
+				SnoozedUntil: driver.ZeroTime(now.Add(time.Minute)),
+			},
+			expectedStatus: reminder.StatusDismissed,
+		},
+		{
+			name: "recurring_keeps_next",
+			reminder: &reminder.Reminder{
+				Rule:         "FREQ=DAILY",
+				NextAt:       driver.ZeroTime(now.Add(time.Hour)),
+				SnoozedUntil: driver.ZeroTime(now.Add(time.Minute)),
+			},
+			expectedStatus: reminder.StatusScheduled,
+			expectedDue:    now.Add(time.Hour),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			noteRepo := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+			repo := mock_reminder.NewRepository(t)
+
+			noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(true, nil).Once()
+			repo.EXPECT().GetByNote(mock.Anything, n.ID, u.ID).Return(tc.reminder, nil).Once()
+			repo.EXPECT().Save(mock.Anything, tc.reminder).Return(nil).Once()
+
+			service := NewReminderService(&ReminderServiceDeps{
+				ReminderRepo: repo,
+				NoteRepo:     noteRepo,
+				NoteGuard:    guard,
+			})
+
+			r, err := service.Dismiss(context.Background(), u, n.ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, r.Status)
+			require.True(t, time.Time(r.SnoozedUntil).IsZero())
+			require.True(t, tc.expectedDue.Equal(time.Time(r.DueAt)))
+		})
+	}
+}
+
+func TestReminderService_Fire(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.Must(uuid.NewV7()), Email: "user@example.com"}
+	n := &note.Note{ID: uuid.Must(uuid.NewV7()), UserId: u.ID, Name: "Weekly review"}
+	now := time.Now()
+
+	cases := []struct {
+		name             string
+		reminder         *reminder.Reminder
+		granted          bool
+		notifyErr        error
+		expectedErr      error
+		expectedStatus   reminder.Status
+		expectedFired    int
+		expectedAttempts int
+		expectedNext     bool
+	}{
+		{
+			name: "one_off",
+			reminder: &reminder.Reminder{
+				RemindAt: now.Add(-time.Second),
+				TimeZone: "UTC",
+				NextAt:   driver.ZeroTime(now.Add(-time.Second)),
+			},
+			granted:        true,
+			expectedStatus: reminder.StatusFired,
+			expectedFired:  1,
+		},
+		{
+			name: "recurring",
+			reminder: &reminder.Reminder{
+				RemindAt: now.Add(-time.Second),
+				TimeZone: "Europe/Berlin",
+				Rule:     "FREQ=DAILY",
+				NextAt:   driver.ZeroTime(now.Add(-time.Second)),
+				Fired:    4,
+			},
+			granted:        true,
+			expectedStatus: reminder.StatusScheduled,
+			expectedFired:  5,
+			expectedNext:   true,
+		},
+		{
+			name: "snoozed",
+			reminder: &reminder.Reminder{
+				RemindAt:     now.Add(-time.Hour),
+				TimeZone:     "UTC",
+				Rule:         "FREQ=DAILY",
+				NextAt:       driver.ZeroTime(now.Add(23 * time.Hour)),
+				SnoozedUntil: driver.ZeroTime(now.Add(-time.Second)),
+				Fired:        1,
+			},
+			granted:        true,
+			expectedStatus: reminder.StatusScheduled,
+			expectedFired:  2,
+			expectedNext:   true,
+		},
+		{
+			name: "note_forbidden",
+			reminder: &reminder.Reminder{
+				RemindAt: now.Add(-time.Second),
+				TimeZone: "UTC",
+				Rule:     "FREQ=DAILY",
+				NextAt:   driver.ZeroTime(now.Add(-time.Second)),
+			},
+			expectedStatus: reminder.StatusDismissed,
+		},
+		{
+			name: "notify_failed",
+			reminder: &reminder.Reminder{
+				RemindAt: now.Add(-time.Second),
+				TimeZone: "UTC",
+				NextAt:   driver.ZeroTime(now.Add(-time.Second)),
+			},
+			granted:          true,
+			notifyErr:        errors.New("db unavailable"),
+			expectedErr:      errors.New("db unavailable"),
+			expectedStatus:   reminder.StatusScheduled,
+			expectedAttempts: 1,
+		},
+		{
+			name: "notify_failed_attempts_exhausted",
+			reminder: &reminder.Reminder{
+				RemindAt: now.Add(-time.Second),
+				TimeZone: "UTC",
+				NextAt:   driver.ZeroTime(now.Add(-time.Second)),
+				Attempts: 2,
+			},
+			granted:        true,
+			notifyErr:      errors.New("db unavailable"),
+			expectedErr:    errors.New("db unavailable"),
+			expectedStatus: reminder.StatusDismissed,
+		},
+		{
+			name: "recurring_notify_failed_attempts_exhausted",
+			reminder: &reminder.Reminder{
+				RemindAt: now.Add(-time.Second),
+				TimeZone: "UTC",
+				Rule:     "FREQ=DAILY",
+				NextAt:   driver.ZeroTime(now.Add(-time.Second)),
+				Fired:    3,
+				Attempts: 2,
+			},
+			granted:        true,
+			notifyErr:      errors.New("db unavailable"),
+			expectedErr:    errors.New("db unavailable"),
+			expectedStatus: reminder.StatusScheduled,
+			expectedFired:  3,
+			expectedNext:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.reminder.ID = uuid.Must(uuid.NewV7())
+			tc.reminder.NoteID = n.ID
+			tc.reminder.UserID = u.ID
+			tc.reminder.Schedule(reminder.StatusFired)
+
+			userRepo := mock_user.NewRepository(t)
+			noteRepo := mock_note.NewRepository(t)
+			guard := mock_note.NewGuarder(t)
+			repo := mock_reminder.NewRepository(t)
+			notifier := mock_reminder.NewNotifier(t)
+
+			repo.EXPECT().GetDue(mock.Anything).Return(tc.reminder, nil).Once()
+			userRepo.EXPECT().GetByID(mock.Anything, u.ID).Return(u, nil).Once()
+			noteRepo.EXPECT().GetByID(mock.Anything, u, n.ID).Return(n, nil).Once()
+			guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, u).Return(tc.granted, nil).Once()
+			if tc.granted {
+				notifier.EXPECT().Notify(mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, rn *reminder.Notification) error {
+						require.Equal(t, tc.reminder, rn.Reminder)
+						require.Equal(t, u, rn.User)
+						require.Equal(t, n, rn.Note)
+						return tc.notifyErr
+					}).Once()
+			}
+
+			if tc.notifyErr != nil {
+				repo.EXPECT().GetByID(mock.Anything, tc.reminder.ID).Return(tc.reminder, nil).Once()
+			}
+
+			repo.EXPECT().Save(mock.Anything, tc.reminder).Return(nil).Once()
+
+			service := &reminderService{
+				tx:           mock_tx.NewMockTxManager(),
+				reminderRepo: repo,
+				userRepo:     userRepo,
+				noteRepo:     noteRepo,
+				guard:        guard,
+				notifiers:    []reminder.Notifier{notifier},
+				retryDelay:   time.Minute,
+				maxAttempts:  3,
+			}
+
+			fired, err := service.fire(context.Background())
+			require.True(t, fired)
+			require.Equal(t, tc.expectedStatus, tc.reminder.Status)
+			require.Equal(t, tc.expectedFired, tc.reminder.Fired)
+			require.Equal(t, tc.expectedAttempts, tc.reminder.Attempts)
+			require.True(t, time.Time(tc.reminder.SnoozedUntil).IsZero())
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+				require.Equal(t, tc.notifyErr.Error(), tc.reminder.LastError)
+				require.True(t, time.Time(tc.reminder.FiredAt).IsZero())
+				if tc.expectedAttempts > 0 {
+					require.True(t, time.Time(tc.reminder.DueAt).After(now.Add(59*time.Second)))
+					return
+				}
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.expectedNext {
+				require.True(t, time.Time(tc.reminder.NextAt).After(now))
+				require.Equal(t, tc.reminder.NextAt, tc.reminder.DueAt)
+			} else {
+				require.True(t, time.Time(tc.reminder.DueAt).IsZero())
+			}
+
+			if tc.expectedErr != nil {
+				return
+			}
+
+			if tc.granted {
+				require.Empty(t, tc.reminder.LastError)
+				require.False(t, time.Time(tc.reminder.FiredAt).IsZero())
+			} else {
+				require.NotEmpty(t, tc.reminder.LastError)
+			}
+		})
+	}
+}
+
+func TestReminderService_FireNoneDue(t *testing.T) {
+	t.Parallel()
+
+	repo := mock_reminder.NewRepository(t)
+	repo.EXPECT().GetDue(mock.Anything).Return(nil, reminder.ErrNotFound).Once()
+
+	service := &reminderService{tx: mock_tx.NewMockTxManager(), reminderRepo: repo}
+	fired, err := service.fire(context.Background())
+	require.NoError(t, err)
+	require.False(t, fired)
+}
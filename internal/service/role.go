@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/audit"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+// RoleServiceDeps represents the dependencies required to construct a role service.
+type RoleServiceDeps struct {
+	TxManager tx.Manager
+	RoleRepo  role.Repository
+	UserRepo  user.Repository
+	RoleGuard role.Guarder
+	Registry  role.Registry
+	Audit     audit.Recorder
+}
+
+// roleService is a struct that implements the role.Service interface for roles administration.
+type roleService struct {
+	tx       tx.Manager
+	roleRepo role.Repository
+	userRepo user.Repository
+	guard    role.Guarder
+	registry role.Registry
+	audit    audit.Recorder
+}
+
+// NewRoleService initializes and returns a new implementation of the role.Service interface using the provided dependencies.
+func NewRoleService(deps *RoleServiceDeps) role.Service {
+	return &roleService{
+		tx:       deps.TxManager,
+		roleRepo: deps.RoleRepo,
+		userRepo: deps.UserRepo,
+		guard:    deps.RoleGuard,
+		registry: deps.Registry,
+		audit:    deps.Audit,
+	}
+}
+
+// List returns all roles if the user has the required permission to read them.
+func (s *roleService) List(ctx context.Context, u *user.User) ([]*role.Role, error) {
+	if err := s.checkGranted(ctx, rbac.READ, nil, u); err != nil {
+		return nil, fmt.Errorf("list roles: %w (user %s)", err, u.ID)
+	}
+
+	roles, err := s.roleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list roles: %w (user %s)", err, u.ID)
+	}
+
+	return roles, nil
+}
+
+// Get retrieves a role by its ID if the user has the required permission to read it.
+func (s *roleService) Get(ctx context.Context, u *user.User, id uuid.UUID) (*role.Role, error) {
+	curRole, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get role: %w (user %s, role %s)", errors.Join(role.ErrNotFound, err), u.ID, id)
+	}
+
+	if err := s.checkGranted(ctx, rbac.READ, curRole, u); err != nil {
+		return nil, fmt.Errorf("get role: %w (user %s, role %s)", err, u.ID, id)
+	}
+
+	return curRole, nil
+}
+
+// Create validates the permissions and creates a new role if the user is authorized to do so.
+func (s *roleService) Create(ctx context.Context, u *user.User, data *role.CreateData) (*role.Role, error) {
+	if err := s.checkGranted(ctx, rbac.CREATE, nil, u); err != nil {
+		return nil, fmt.Errorf("create role: %w (user %s)", err, u.ID)
+	}
+
+	permissions, err := s.normalizePermissions(data.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("create role: %w (user %s)", err, u.ID)
+	}
+
+	r := &role.Role{
+		Description: data.Description,
+		Label:       string(data.Label),
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.Save(ctx, r); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, audit.NewEvent(ctx, audit.ActionRoleCreate, u.ID, audit.TargetRole, r.ID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create role: %w (user %s)", err, u.ID)
+	}
+
+	return r, nil
+}
+
+// Update modifies the description, label and permissions of an existing role if the user is authorized to do so.
+func (s *roleService) Update(ctx context.Context, u *user.User, data *role.UpdateData) (*role.Role, error) {
+	curRole, err := s.roleRepo.GetByID(ctx, data.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update role: %w (user %s, role %s)", errors.Join(role.ErrNotFound, err), u.ID, data.ID)
+	}
+
+	if err := s.checkGranted(ctx, rbac.UPDATE, curRole, u); err != nil {
+		return nil, fmt.Errorf("update role: %w (user %s, role %s)", err, u.ID, data.ID)
+	}
+
+	permissions, err := s.normalizePermissions(data.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("update role: %w (user %s, role %s)", err, u.ID, data.ID)
+	}
+
+	curRole.Description = data.Description
+	curRole.Label = string(data.Label)
+	curRole.Permissions = permissions
+	curRole.UpdatedAt = driver.ZeroTime(time.Now())
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.Save(ctx, curRole); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, audit.NewEvent(ctx, audit.ActionRoleUpdate, u.ID, audit.TargetRole, curRole.ID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update role: %w (user %s, role %s)", err, u.ID, data.ID)
+	}
+
+	return curRole, nil
+}
+
+// Delete removes a role by its ID if the user has the required permission and returns the deleted role.
+func (s *roleService) Delete(ctx context.Context, u *user.User, id uuid.UUID) (*role.Role, error) {
+	curRole, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete role: %w (user %s, role %s)", errors.Join(role.ErrNotFound, err), u.ID, id)
+	}
+
+	if err := s.checkGranted(ctx, rbac.DELETE, curRole, u); err != nil {
+		return nil, fmt.Errorf("delete role: %w (user %s, role %s)", err, u.ID, id)
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.Delete(ctx, curRole); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, audit.NewEvent(ctx, audit.ActionRoleDelete, u.ID, audit.TargetRole, curRole.ID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete role: %w (user %s, role %s)", err, u.ID, id)
+	}
+
+	return curRole, nil
+}
+
+// Assign attaches the role to the target user if the user is authorized to update the role.
+func (s *roleService) Assign(ctx context.Context, u *user.User, data *role.Assignment) error {
+	curRole, target, err := s.getAssignment(ctx, u, data)
+	if err != nil {
+		return fmt.Errorf("assign role: %w", err)
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.AttachUser(ctx, curRole, target); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, roleAssignmentEvent(ctx, audit.ActionRoleAssign, u, curRole, target))
+	})
+	if err != nil {
+		return fmt.Errorf("assign role: %w (user %s, role %s)", err, u.ID, data.RoleID)
+	}
+
+	return nil
+}
+
+// Unassign detaches the role from the target user if the user is authorized to update the role.
+func (s *roleService) Unassign(ctx context.Context, u *user.User, data *role.Assignment) error {
+	curRole, target, err := s.getAssignment(ctx, u, data)
+	if err != nil {
+		return fmt.Errorf("unassign role: %w", err)
+	}
+
+	err = s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.DetachUser(ctx, curRole, target); err != nil {
+			return err
+		}
+
+		return s.audit.Record(ctx, roleAssignmentEvent(ctx, audit.ActionRoleUnassign, u, curRole, target))
+	})
+	if err != nil {
+		return fmt.Errorf("unassign role: %w (user %s, role %s)", err, u.ID, data.RoleID)
+	}
+
+	return nil
+}
+
+// Permissions returns the list of permissions known to the application if the user is authorized to read roles.
+func (s *roleService) Permissions(ctx context.Context, u *user.User) ([]role.Permission, error) {
+	if err := s.checkGranted(ctx, rbac.READ, nil, u); err != nil {
+		return nil, fmt.Errorf("list permissions: %w (user %s)", err, u.ID)
+	}
+
+	return s.registry.List(), nil
+}
+
+// getAssignment loads the role and the target user of the assignment, ensuring the user may update the role.
+func (s *roleService) getAssignment(
+	ctx context.Context,
+	u *user.User,
+	data *role.Assignment,
+) (*role.Role, *user.User, error) {
+	curRole, err := s.roleRepo.GetByID(ctx, data.RoleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w (user %s, role %s)", errors.Join(role.ErrNotFound, err), u.ID, data.RoleID)
+	}
+
+	if err := s.checkGranted(ctx, rbac.UPDATE, curRole, u); err != nil {
+		return nil, nil, fmt.Errorf("%w (user %s, role %s)", err, u.ID, data.RoleID)
+	}
+
+	target, err := s.userRepo.GetByID(ctx, data.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"%w (user %s, role %s, target %s)",
+			errors.Join(user.ErrNotFound, err),
+			u.ID,
+			data.RoleID,
+			data.UserID,
+		)
+	}
+
+	return curRole, target, nil
+}
+
+// checkGranted returns role.ErrOperationForbiddenForUser if the operation is not granted for the user.
+func (s *roleService) checkGranted(ctx context.Context, op rbac.Operation, r *role.Role, u *user.User) error {
+	granted, err := s.guard.IsGranted(ctx, op, r, u)
+	if err != nil {
+		return fmt.Errorf("check granted: %w", err)
+	}
+
+	if !granted {
+		return role.ErrOperationForbiddenForUser
+	}
+
+	return nil
+}
+
+// normalizePermissions validates permissions against the registry and returns them sorted without duplicates.
+func (s *roleService) normalizePermissions(permissions []role.Permission) ([]string, error) {
+	res := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if !s.registry.Has(p) {
+			return nil, fmt.Errorf("%w %q", role.ErrUnknownPermission, p)
+		}
+
+		res = append(res, string(p))
+	}
+
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
+
+// roleAssignmentEvent creates the audit event of the role assignment change of the target user.
+func roleAssignmentEvent(
+	ctx context.Context,
+	action audit.Action,
+	u *user.User,
+	r *role.Role,
+	target *user.User,
+) *audit.Event {
+	e := audit.NewEvent(ctx, action, u.ID, audit.TargetRole, r.ID)
+	e.Details = "user " + target.ID.String()
+	return e
+}
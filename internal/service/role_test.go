@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/role"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_role"
+	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+func TestRoleService_Create(t *testing.T) {
+	t.Parallel()
+
+	description := gofakeit.Sentence(5)
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []struct {
+		name        string
+		permissions []role.Permission
+		expected    *role.Role
+		expectedErr string
+		mocker      func(repo *mock_role.Repository, guard *mock_role.Guarder)
+	}{
+		{
+			name:        "successful_create",
+			permissions: []role.Permission{note.PermissionUpdate, note.PermissionRead, note.PermissionRead},
+			expected: &role.Role{
+				Description: description,
+				Label:       string(role.LabelAdmin),
+				Permissions: []string{string(note.PermissionRead), string(note.PermissionUpdate)},
+			},
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*role.Role)(nil), u).Return(true, nil).Once()
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:        "unknown_permission",
+			permissions: []role.Permission{note.PermissionRead, "notes.unknown"},
+			expectedErr: fmt.Sprintf("create role: unknown permission \"notes.unknown\" (user %s)", u.ID),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*role.Role)(nil), u).Return(true, nil).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			permissions: []role.Permission{note.PermissionRead},
+			expectedErr: fmt.Sprintf("create role: role operation is forbidden for user (user %s)", u.ID),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*role.Role)(nil), u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "granted_error",
+			permissions: []role.Permission{note.PermissionRead},
+			expectedErr: fmt.Sprintf("create role: check granted: granted err (user %s)", u.ID),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				guard.EXPECT().
+					IsGranted(mock.Anything, rbac.CREATE, (*role.Role)(nil), u).
+					Return(false, errors.New("granted err")).
+					Once()
+			},
+		},
+		{
+			name:        "save_error",
+			permissions: []role.Permission{note.PermissionRead},
+			expectedErr: fmt.Sprintf("create role: save err (user %s)", u.ID),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				guard.EXPECT().IsGranted(mock.Anything, rbac.CREATE, (*role.Role)(nil), u).Return(true, nil).Once()
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(errors.New("save err")).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_role.NewGuarder(t)
+			repo := mock_role.NewRepository(t)
+			tc.mocker(repo, guard)
+
+			service := NewRoleService(&RoleServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				RoleRepo:  repo,
+				RoleGuard: guard,
+				Registry:  role.NewRegistry(note.Permissions()...),
+				Audit:     newAuditRecorder(t),
+			})
+			result, err := service.Create(context.Background(), u, &role.CreateData{
+				Description: description,
+				Label:       role.LabelAdmin,
+				Permissions: tc.permissions,
+			})
+
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			}
+
+			if tc.expected != nil {
+				require.Equal(t, tc.expected.Description, result.Description)
+				require.Equal(t, tc.expected.Label, result.Label)
+				require.Equal(t, tc.expected.Permissions, result.Permissions)
+				require.NotZero(t, result.CreatedAt)
+			}
+
+			mock.AssertExpectationsForObjects(t, repo, guard)
+		})
+	}
+}
+
+func TestRoleService_Update(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+	description := gofakeit.Sentence(5)
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	createRole := func() *role.Role {
+		return &role.Role{
+			ID:          id,
+			Description: gofakeit.Sentence(3),
+			Permissions: []string{string(note.PermissionRead)},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		expected    *role.Role
+		expectedErr string
+		mocker      func(repo *mock_role.Repository, guard *mock_role.Guarder)
+	}{
+		{
+			name: "successful_update",
+			expected: &role.Role{
+				ID:          id,
+				Description: description,
+				Permissions: []string{string(note.PermissionDelete)},
+			},
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				r := createRole()
+				repo.EXPECT().GetByID(mock.Anything, id).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, r, u).Return(true, nil).Once()
+				repo.EXPECT().Save(mock.Anything, r).Return(nil).Once()
+			},
+		},
+		{
+			name:        "role_not_found",
+			expectedErr: fmt.Sprintf("update role: role not found\nno rows (user %s, role %s)", u.ID, id),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, id).Return(nil, errors.New("no rows")).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			expectedErr: fmt.Sprintf("update role: role operation is forbidden for user (user %s, role %s)", u.ID, id),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				r := createRole()
+				repo.EXPECT().GetByID(mock.Anything, id).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, r, u).Return(false, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_role.NewGuarder(t)
+			repo := mock_role.NewRepository(t)
+			tc.mocker(repo, guard)
+
+			service := NewRoleService(&RoleServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				RoleRepo:  repo,
+				RoleGuard: guard,
+				Registry:  role.NewRegistry(note.Permissions()...),
+				Audit:     newAuditRecorder(t),
+			})
+			result, err := service.Update(context.Background(), u, &role.UpdateData{
+				ID:          id,
+				Description: description,
+				Permissions: []role.Permission{note.PermissionDelete},
+			})
+
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			}
+
+			if tc.expected != nil {
+				require.Equal(t, tc.expected.Description, result.Description)
+				require.Equal(t, tc.expected.Permissions, result.Permissions)
+				require.NotZero(t, result.UpdatedAt)
+			}
+
+			mock.AssertExpectationsForObjects(t, repo, guard)
+		})
+	}
+}
+
+func TestRoleService_Delete(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+	r := &role.Role{
+		ID:          id,
+		Description: gofakeit.Sentence(3),
+	}
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []struct {
+		name        string
+		expected    *role.Role
+		expectedErr string
+		mocker      func(repo *mock_role.Repository, guard *mock_role.Guarder)
+	}{
+		{
+			name:     "successful_delete",
+			expected: r,
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, id).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, r, u).Return(true, nil).Once()
+				repo.EXPECT().Delete(mock.Anything, r).Return(nil).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			expectedErr: fmt.Sprintf("delete role: role operation is forbidden for user (user %s, role %s)", u.ID, id),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, id).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, r, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "delete_error",
+			expectedErr: fmt.Sprintf("delete role: delete err (user %s, role %s)", u.ID, id),
+			mocker: func(repo *mock_role.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, id).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.DELETE, r, u).Return(true, nil).Once()
+				repo.EXPECT().Delete(mock.Anything, r).Return(errors.New("delete err")).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_role.NewGuarder(t)
+			repo := mock_role.NewRepository(t)
+			tc.mocker(repo, guard)
+
+			service := NewRoleService(&RoleServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				RoleRepo:  repo,
+				RoleGuard: guard,
+				Audit:     newAuditRecorder(t),
+			})
+			result, err := service.Delete(context.Background(), u, id)
+
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			}
+
+			require.Equal(t, tc.expected, result)
+			mock.AssertExpectationsForObjects(t, repo, guard)
+		})
+	}
+}
+
+func TestRoleService_Assign(t *testing.T) {
+	t.Parallel()
+
+	r := &role.Role{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+	target := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+	u := &user.User{
+		ID: uuid.Must(uuid.NewV7()),
+	}
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		mocker      func(repo *mock_role.Repository, userRepo *mock_user.Repository, guard *mock_role.Guarder)
+	}{
+		{
+			name: "successful_assign",
+			mocker: func(repo *mock_role.Repository, userRepo *mock_user.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, r.ID).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, r, u).Return(true, nil).Once()
+				userRepo.EXPECT().GetByID(mock.Anything, target.ID).Return(target, nil).Once()
+				repo.EXPECT().AttachUser(mock.Anything, r, target).Return(nil).Once()
+			},
+		},
+		{
+			name: "user_not_found",
+			expectedErr: fmt.Sprintf(
+				"assign role: user not found\nno rows (user %s, role %s, target %s)",
+				u.ID,
+				r.ID,
+				target.ID,
+			),
+			mocker: func(repo *mock_role.Repository, userRepo *mock_user.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, r.ID).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, r, u).Return(true, nil).Once()
+				userRepo.EXPECT().GetByID(mock.Anything, target.ID).Return(nil, errors.New("no rows")).Once()
+			},
+		},
+		{
+			name:        "not_granted",
+			expectedErr: fmt.Sprintf("assign role: role operation is forbidden for user (user %s, role %s)", u.ID, r.ID),
+			mocker: func(repo *mock_role.Repository, userRepo *mock_user.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, r.ID).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, r, u).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "attach_error",
+			expectedErr: fmt.Sprintf("assign role: attach err (user %s, role %s)", u.ID, r.ID),
+			mocker: func(repo *mock_role.Repository, userRepo *mock_user.Repository, guard *mock_role.Guarder) {
+				repo.EXPECT().GetByID(mock.Anything, r.ID).Return(r, nil).Once()
+				guard.EXPECT().IsGranted(mock.Anything, rbac.UPDATE, r, u).Return(true, nil).Once()
+				userRepo.EXPECT().GetByID(mock.Anything, target.ID).Return(target, nil).Once()
+				repo.EXPECT().AttachUser(mock.Anything, r, target).Return(errors.New("attach err")).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_role.NewGuarder(t)
+			repo := mock_role.NewRepository(t)
+			userRepo := mock_user.NewRepository(t)
+			tc.mocker(repo, userRepo, guard)
+
+			service := NewRoleService(&RoleServiceDeps{
+				TxManager: mock_tx.NewMockTxManager(),
+				RoleRepo:  repo,
+				UserRepo:  userRepo,
+				RoleGuard: guard,
+				Audit:     newAuditRecorder(t),
+			})
+			err := service.Assign(context.Background(), u, &role.Assignment{RoleID: r.ID, UserID: target.ID})
+
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mock.AssertExpectationsForObjects(t, repo, userRepo, guard)
+		})
+	}
+}
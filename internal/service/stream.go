@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/stream"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+// NoteStreamDeps represents the dependencies required to construct a note stream.
+type NoteStreamDeps struct {
+	Listener   stream.Listener
+	NoteGuard  note.Guarder
+	ReplaySize int
+	BufferSize int
+	RetryDelay time.Duration
+}
+
+// noteStream is a struct that implements the stream.Service interface fanning out the listened note events.
+type noteStream struct {
+	listener   stream.Listener
+	guard      note.Guarder
+	replaySize int
+	bufferSize int
+	retryDelay time.Duration
+
+	mu     sync.Mutex
+	replay []*stream.Event
+	subs   map[*noteSubscription]struct{}
+	closed bool
+}
+
+// noteSubscription is a struct that implements the stream.Subscription interface.
+// Events are queued to in by the stream and passed to out when the subscriber may read them.
+type noteSubscription struct {
+	in     chan *stream.Event
+	out    chan *stream.Event
+	done   chan struct{}
+	once   sync.Once
+	missed bool
+	remove func()
+}
+
+// NewNoteStream initializes and returns a new implementation of the stream.Service interface.
+func NewNoteStream(deps *NoteStreamDeps) stream.Service {
+	return &noteStream{
+		listener:   deps.Listener,
+		guard:      deps.NoteGuard,
+		replaySize: deps.ReplaySize,
+		bufferSize: deps.BufferSize,
+		retryDelay: deps.RetryDelay,
+		subs:       make(map[*noteSubscription]struct{}),
+	}
+}
+
+// Subscribe registers the subscriber of the note events the user may read.
+// Events published after the last event id are replayed when they are still kept in the replay buffer.
+func (s *noteStream) Subscribe(
+	ctx context.Context,
+	u *user.User,
+	lastEventID uuid.NullUUID,
+) (stream.Subscription, error) {
+	sub := &noteSubscription{
+		in:   make(chan *stream.Event, s.replaySize+s.bufferSize),
+		out:  make(chan *stream.Event),
+		done: make(chan struct{}),
+	}
+	sub.remove = func() { s.unsubscribe(sub) }
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("subscribe note stream: %w (user %s)", stream.ErrClosed, u.ID)
+	}
+
+	if lastEventID.Valid {
+		i := slices.IndexFunc(s.replay, func(e *stream.Event) bool { return e.ID == lastEventID.UUID })
+		sub.missed = i < 0
+		if i >= 0 {
+			for _, e := range s.replay[i+1:] {
+				sub.in <- e
+			}
+		}
+	}
+
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	go s.filter(ctx, u, sub)
+	return sub, nil
+}
+
+// Run listens to the note events until the context is done, reconnecting after the retry delay on failures.
+// Events published while reconnecting are lost, so the replay buffer is cleared and the subscribers are dropped
+// to make them resume and learn about the missed events. Errors are reported to onError.
+func (s *noteStream) Run(ctx context.Context, onError func(error)) {
+	defer s.close()
+
+	handle := func(payload []byte) {
+		if err := s.publish(payload); err != nil {
+			onError(err)
+		}
+	}
+
+	for {
+		err := s.listener.Listen(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		onError(fmt.Errorf("listen note stream: %w", err))
+		s.reset()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.retryDelay):
+		}
+	}
+}
+
+// publish decodes the event, appends it to the replay buffer and queues it to the subscribers.
+// Subscribers which have fallen behind are dropped instead of blocking the stream.
+func (s *noteStream) publish(payload []byte) error {
+	e := &stream.Event{}
+	if err := json.Unmarshal(payload, e); err != nil {
+		return fmt.Errorf("publish note stream: decode event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replay = append(s.replay, e)
+	if len(s.replay) > s.replaySize {
+		s.replay = slices.Delete(s.replay, 0, len(s.replay)-s.replaySize)
+	}
+
+	for sub := range s.subs {
+		select {
+		case sub.in <- e:
+		default:
+			delete(s.subs, sub)
+			close(sub.in)
+		}
+	}
+
+	return nil
+}
+
+// filter passes the queued events readable by the user to the subscriber until the subscription is closed.
+// Failed permission checks end the subscription, so the subscriber resumes instead of missing the event.
+func (s *noteStream) filter(ctx context.Context, u *user.User, sub *noteSubscription) {
+	defer close(sub.out)
+
+	for {
+		select {
+		case <-sub.done:
+			return
+		case e, ok := <-sub.in:
+			if !ok {
+				return
+			}
+
+			granted, err := s.guard.IsGranted(ctx, rbac.READ, streamEventNote(e), u)
+			if err != nil {
+				return
+			}
+
+			if !granted {
+				continue
+			}
+
+			select {
+			case sub.out <- e:
+			case <-sub.done:
+				return
+			}
+		}
+	}
+}
+
+// reset clears the replay buffer and drops the subscribers.
+func (s *noteStream) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replay = nil
+	s.dropAll()
+}
+
+// close drops the subscribers and rejects new ones.
+func (s *noteStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.dropAll()
+}
+
+// dropAll ends all subscriptions, the caller must hold the lock.
+func (s *noteStream) dropAll() {
+	for sub := range s.subs {
+		delete(s.subs, sub)
+		close(sub.in)
+	}
+}
+
+// unsubscribe removes the subscriber from the stream.
+func (s *noteStream) unsubscribe(sub *noteSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subs, sub)
+}
+
+// Events returns the channel of events readable by the subscriber.
+func (sub *noteSubscription) Events() <-chan *stream.Event {
+	return sub.out
+}
+
+// Missed reports whether events after the last event id are no longer available for replay.
+func (sub *noteSubscription) Missed() bool {
+	return sub.missed
+}
+
+// Close removes the subscriber from the stream, it is safe to call Close several times.
+func (sub *noteSubscription) Close() {
+	sub.once.Do(func() {
+		close(sub.done)
+		sub.remove()
+	})
+}
+
+// streamEventNote returns the note of the event with the attributes needed for permission checks.
+func streamEventNote(e *stream.Event) *note.Note {
+	return &note.Note{
+		ID:        e.Note.ID,
+		Name:      e.Note.Name,
+		UserId:    e.Note.UserID,
+		OrgID:     uuid.NullUUID{UUID: e.Note.OrgID, Valid: e.Note.OrgID != uuid.Nil},
+		CreatedAt: e.Note.CreatedAt,
+		UpdatedAt: driver.ZeroTime(e.Note.UpdatedAt),
+	}
+}
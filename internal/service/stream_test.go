@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/stream"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_stream"
+	"github.com/xsqrty/notes/pkg/rbac"
+)
+
+func TestNoteStream_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.New()}
+	other := uuid.New()
+	newPayload := func(owner uuid.UUID) (uuid.UUID, []byte) {
+		e := &stream.Event{
+			ID:        uuid.New(),
+			Type:      event.TypeNoteUpdated,
+			CreatedAt: time.Now(),
+			Note:      event.NotePayload{ID: uuid.New(), UserID: owner},
+		}
+
+		payload, err := json.Marshal(e)
+		require.NoError(t, err)
+		return e.ID, payload
+	}
+
+	first, firstPayload := newPayload(u.ID)
+	_, otherPayload := newPayload(other)
+	second, secondPayload := newPayload(u.ID)
+	third, thirdPayload := newPayload(u.ID)
+	payloads := [][]byte{firstPayload, otherPayload, secondPayload, thirdPayload}
+
+	cases := []struct {
+		name        string
+		lastEventID uuid.NullUUID
+		expected    []uuid.UUID
+		missed      bool
+	}{
+		{
+			name:        "replay_after_last_event",
+			lastEventID: uuid.NullUUID{UUID: second, Valid: true},
+			expected:    []uuid.UUID{third},
+		},
+		{
+			name:        "last_event_evicted",
+			lastEventID: uuid.NullUUID{UUID: first, Valid: true},
+			missed:      true,
+		},
+		{
+			name: "no_last_event",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := mock_note.NewGuarder(t)
+			guard.EXPECT().
+				IsGranted(mock.Anything, rbac.READ, mock.AnythingOfType("*note.Note"), u).
+				RunAndReturn(func(_ context.Context, _ rbac.Operation, n *note.Note, u *user.User) (bool, error) {
+					return n.UserId == u.ID, nil
+				}).
+				Maybe()
+
+			s := NewNoteStream(&NoteStreamDeps{
+				NoteGuard:  guard,
+				ReplaySize: 3,
+				BufferSize: 10,
+			}).(*noteStream)
+
+			for _, payload := range payloads {
+				require.NoError(t, s.publish(payload))
+			}
+
+			sub, err := s.Subscribe(context.Background(), u, tc.lastEventID)
+			require.NoError(t, err)
+			defer sub.Close()
+
+			require.Equal(t, tc.missed, sub.Missed())
+
+			fourth, fourthPayload := newPayload(u.ID)
+			require.NoError(t, s.publish(fourthPayload))
+
+			var received []uuid.UUID
+			for e := range sub.Events() {
+				received = append(received, e.ID)
+				if e.ID == fourth {
+					break
+				}
+			}
+
+			require.Equal(t, append(tc.expected, fourth), received)
+		})
+	}
+}
+
+func TestNoteStream_Run(t *testing.T) {
+	t.Parallel()
+
+	u := &user.User{ID: uuid.New()}
+	e := &stream.Event{
+		ID:   uuid.New(),
+		Type: event.TypeNoteCreated,
+		Note: event.NotePayload{ID: uuid.New(), UserID: u.ID},
+	}
+	payload, err := json.Marshal(e)
+	require.NoError(t, err)
+
+	guard := mock_note.NewGuarder(t)
+	guard.EXPECT().IsGranted(mock.Anything, rbac.READ, mock.AnythingOfType("*note.Note"), u).Return(true, nil).Once()
+
+	subscribed := make(chan struct{})
+	listener := mock_stream.NewListener(t)
+	listener.EXPECT().
+		Listen(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, handle func([]byte)) error {
+			<-subscribed
+			handle([]byte("{"))
+			handle(payload)
+			return errors.New("connection lost")
+		}).
+		Once()
+	listener.EXPECT().
+		Listen(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, handle func([]byte)) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+		Maybe()
+
+	s := NewNoteStream(&NoteStreamDeps{
+		Listener:   listener,
+		NoteGuard:  guard,
+		ReplaySize: 10,
+		BufferSize: 10,
+		RetryDelay: time.Millisecond,
+	})
+
+	sub, err := s.Subscribe(context.Background(), u, uuid.NullUUID{})
+	require.NoError(t, err)
+	defer sub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Run(ctx, func(err error) { errs <- err })
+	}()
+
+	close(subscribed)
+	received, ok := <-sub.Events()
+	require.True(t, ok)
+	require.Equal(t, e.ID, received.ID)
+
+	_, ok = <-sub.Events()
+	require.False(t, ok, "subscribers are dropped when listening fails")
+	require.ErrorContains(t, <-errs, "decode event")
+	require.ErrorContains(t, <-errs, "connection lost")
+
+	resumed, err := s.Subscribe(context.Background(), u, uuid.NullUUID{UUID: e.ID, Valid: true})
+	require.NoError(t, err)
+	require.True(t, resumed.Missed(), "replay buffer is cleared when listening fails")
+	resumed.Close()
+
+	cancel()
+	<-done
+
+	_, err = s.Subscribe(context.Background(), u, uuid.NullUUID{})
+	require.ErrorIs(t, err, stream.ErrClosed)
+}
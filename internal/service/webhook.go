@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/search"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/op/driver"
+)
+
+const (
+	// webhookSecretLength is the number of random bytes of generated endpoint secrets.
+	webhookSecretLength = 32
+	// webhookSecretPrefix makes endpoint secrets recognizable.
+	webhookSecretPrefix = "whsec_"
+)
+
+// WebhookServiceDeps represents the dependencies required to construct a webhook service.
+type WebhookServiceDeps struct {
+	WebhookRepo  webhook.Repository
+	WebhookGuard webhook.Guarder
+	Sender       webhook.Sender
+	UserRepo     user.Repository
+	OrgRepo      org.Repository
+	NoteGuard    note.Guarder
+}
+
+// webhookService is a struct that implements the webhook.Service interface for managing webhook endpoints.
+type webhookService struct {
+	webhookRepo webhook.Repository
+	guard       webhook.Guarder
+	sender      webhook.Sender
+	userRepo    user.Repository
+	orgRepo     org.Repository
+	noteGuard   note.Guarder
+}
+
+// NewWebhookService initializes and returns a new implementation of the webhook.Service interface.
+func NewWebhookService(deps *WebhookServiceDeps) webhook.Service {
+	return &webhookService{
+		webhookRepo: deps.WebhookRepo,
+		guard:       deps.WebhookGuard,
+		sender:      deps.Sender,
+		userRepo:    deps.UserRepo,
+		orgRepo:     deps.OrgRepo,
+		noteGuard:   deps.NoteGuard,
+	}
+}
+
+// List returns all webhook endpoints of the user.
+func (s *webhookService) List(ctx context.Context, u *user.User) ([]*webhook.Endpoint, error) {
+	endpoints, err := s.webhookRepo.GetByUser(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks: %w (user %s)", err, u.ID)
+	}
+
+	return endpoints, nil
+}
+
+// Get retrieves a webhook endpoint by its ID if the user owns it.
+func (s *webhookService) Get(ctx context.Context, u *user.User, id uuid.UUID) (*webhook.Endpoint, error) {
+	e, err := s.getGranted(ctx, rbac.READ, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	return e, nil
+}
+
+// Create registers a new enabled webhook endpoint of the user with a generated secret.
+func (s *webhookService) Create(
+	ctx context.Context,
+	u *user.User,
+	data *webhook.CreateData,
+) (*webhook.Endpoint, error) {
+	if err := s.checkGranted(ctx, rbac.CREATE, nil, u); err != nil {
+		return nil, fmt.Errorf("create webhook: %w (user %s)", err, u.ID)
+	}
+
+	types, err := webhookEventTypes(data.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w (user %s)", err, u.ID)
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w (user %s)", err, u.ID)
+	}
+
+	e := &webhook.Endpoint{
+		UserID:     u.ID,
+		URL:        data.URL,
+		Secret:     secret,
+		EventTypes: types,
+		Enabled:    true,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.webhookRepo.Save(ctx, e); err != nil {
+		return nil, fmt.Errorf("create webhook: %w (user %s)", err, u.ID)
+	}
+
+	return e, nil
+}
+
+// Update modifies the URL, the subscribed event types and the state of the endpoint if the user owns it.
+func (s *webhookService) Update(
+	ctx context.Context,
+	u *user.User,
+	data *webhook.UpdateData,
+) (*webhook.Endpoint, error) {
+	e, err := s.getGranted(ctx, rbac.UPDATE, u, data.ID)
+	if err != nil {
+		return nil, fmt.Errorf("update webhook: %w", err)
+	}
+
+	types, err := webhookEventTypes(data.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("update webhook: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	now := time.Now()
+	switch {
+	case data.Enabled && !e.Enabled:
+		e.Failures = 0
+		e.DisabledAt = driver.ZeroTime{}
+	case !data.Enabled && e.Enabled:
+		e.DisabledAt = driver.ZeroTime(now)
+	}
+
+	e.URL = data.URL
+	e.EventTypes = types
+	e.Enabled = data.Enabled
+	e.UpdatedAt = driver.ZeroTime(now)
+	if err := s.webhookRepo.Save(ctx, e); err != nil {
+		return nil, fmt.Errorf("update webhook: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	return e, nil
+}
+
+// Delete removes the webhook endpoint with its deliveries if the user owns it.
+func (s *webhookService) Delete(ctx context.Context, u *user.User, id uuid.UUID) (*webhook.Endpoint, error) {
+	e, err := s.getGranted(ctx, rbac.DELETE, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete webhook: %w", err)
+	}
+
+	if err := s.webhookRepo.Delete(ctx, e); err != nil {
+		return nil, fmt.Errorf("delete webhook: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	return e, nil
+}
+
+// Deliveries returns the delivery log of the endpoint if the user owns it.
+func (s *webhookService) Deliveries(
+	ctx context.Context,
+	u *user.User,
+	id uuid.UUID,
+	limit uint64,
+	offset uint64,
+) (*search.Result[webhook.Delivery], error) {
+	e, err := s.getGranted(ctx, rbac.READ, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+
+	res, err := s.webhookRepo.GetDeliveries(ctx, e, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	return res, nil
+}
+
+// SendTest posts a test event to the endpoint right away and returns the delivery. Test deliveries are not retried.
+func (s *webhookService) SendTest(ctx context.Context, u *user.User, id uuid.UUID) (*webhook.Delivery, error) {
+	e, err := s.getGranted(ctx, rbac.UPDATE, u, id)
+	if err != nil {
+		return nil, fmt.Errorf("send test webhook: %w", err)
+	}
+
+	messageID, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("send test webhook (generate uuid): %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	d, err := newWebhookDelivery(e, uuid.NullUUID{}, &webhook.Message{
+		ID:        messageID,
+		Type:      webhook.TypeTest,
+		CreatedAt: time.Now(),
+		Data:      map[string]uuid.UUID{"webhook_id": e.ID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("send test webhook: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	if err := s.webhookRepo.SaveDelivery(ctx, d); err != nil {
+		return nil, fmt.Errorf("send test webhook: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	if err := s.sender.Send(ctx, e, d); err != nil {
+		return nil, fmt.Errorf("send test webhook: %w (user %s, webhook %s)", err, u.ID, e.ID)
+	}
+
+	return d, nil
+}
+
+// HandleEvent queues deliveries of the event to the enabled endpoints subscribed to it. Note events are delivered
+// to the users who may read the note: the note owner and the members of the organisation owning the note.
+// Reminder events are delivered to the user of the reminder, checked when the reminder fired.
+// The message id is the event id, so receivers may use it to skip repeated deliveries.
+func (s *webhookService) HandleEvent(ctx context.Context, ev *event.Event) error {
+	userIDs, n, err := s.audience(ctx, ev)
+	if err != nil {
+		return fmt.Errorf("handle webhook event: %w (event %s)", err, ev.ID)
+	}
+
+	endpoints, err := s.webhookRepo.GetEnabledByUserIDs(ctx, userIDs)
+	if err != nil {
+		return fmt.Errorf("handle webhook event: %w (event %s)", err, ev.ID)
+	}
+
+	if n != nil {
+		if endpoints, err = s.readable(ctx, endpoints, n); err != nil {
+			return fmt.Errorf("handle webhook event: %w (event %s)", err, ev.ID)
+		}
+	}
+
+	message := &webhook.Message{
+		ID:        ev.ID,
+		Type:      ev.Type,
+		CreatedAt: ev.CreatedAt,
+		Data:      json.RawMessage(ev.Payload),
+	}
+
+	for _, e := range endpoints {
+		if !e.Subscribed(ev.Type) {
+			continue
+		}
+
+		d, err := newWebhookDelivery(e, uuid.NullUUID{UUID: ev.ID, Valid: true}, message)
+		if err != nil {
+			return fmt.Errorf("handle webhook event: %w (event %s)", err, ev.ID)
+		}
+
+		if err := s.webhookRepo.SaveDelivery(ctx, d); err != nil {
+			return fmt.Errorf("handle webhook event: %w (event %s)", err, ev.ID)
+		}
+	}
+
+	return nil
+}
+
+// getGranted loads the endpoint ensuring the operation is granted, endpoints of other users are reported as not found.
+func (s *webhookService) getGranted(
+	ctx context.Context,
+	op rbac.Operation,
+	u *user.User,
+	id uuid.UUID,
+) (*webhook.Endpoint, error) {
+	e, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w (user %s, webhook %s)", errors.Join(webhook.ErrNotFound, err), u.ID, id)
+	}
+
+	if err := s.checkGranted(ctx, op, e, u); err != nil {
+		if errors.Is(err, webhook.ErrOperationForbiddenForUser) {
+			err = webhook.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("%w (user %s, webhook %s)", err, u.ID, id)
+	}
+
+	return e, nil
+}
+
+// checkGranted returns webhook.ErrOperationForbiddenForUser if the operation is not granted for the user.
+func (s *webhookService) checkGranted(ctx context.Context, op rbac.Operation, e *webhook.Endpoint, u *user.User) error {
+	granted, err := s.guard.IsGranted(ctx, op, e, u)
+	if err != nil {
+		return fmt.Errorf("check granted: %w", err)
+	}
+
+	if !granted {
+		return webhook.ErrOperationForbiddenForUser
+	}
+
+	return nil
+}
+
+// audience returns the identifiers of the users who may receive the event along with the note the users must
+// be allowed to read, nil for reminder events. Note events may be received by the note owner and the members
+// of the organisation owning the note.
+func (s *webhookService) audience(ctx context.Context, ev *event.Event) ([]uuid.UUID, *note.Note, error) {
+	if ev.Type == event.TypeReminderFired {
+		var payload event.ReminderPayload
+		if err := ev.Decode(&payload); err != nil {
+			return nil, nil, fmt.Errorf("decode payload: %w", err)
+		}
+
+		return []uuid.UUID{payload.UserID}, nil, nil
+	}
+
+	var payload event.NotePayload
+	if err := ev.Decode(&payload); err != nil {
+		return nil, nil, fmt.Errorf("decode payload: %w", err)
+	}
+
+	userIDs := []uuid.UUID{payload.UserID}
+	if payload.OrgID == uuid.Nil {
+		return userIDs, notePayloadNote(&payload), nil
+	}
+
+	members, err := s.orgRepo.GetMembers(ctx, &org.Org{ID: payload.OrgID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, m := range members {
+		if m.UserID != payload.UserID {
+			userIDs = append(userIDs, m.UserID)
+		}
+	}
+
+	return userIDs, notePayloadNote(&payload), nil
+}
+
+// readable returns the endpoints of the users who may read the note, removed users may not.
+func (s *webhookService) readable(
+	ctx context.Context,
+	endpoints []*webhook.Endpoint,
+	n *note.Note,
+) ([]*webhook.Endpoint, error) {
+	readers := make(map[uuid.UUID]bool)
+	res := make([]*webhook.Endpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		granted, ok := readers[e.UserID]
+		if !ok {
+			u, err := s.userRepo.GetByID(ctx, e.UserID)
+			if err != nil && !errors.Is(err, user.ErrNotFound) {
+				return nil, err
+			}
+
+			if u != nil {
+				if granted, err = s.noteGuard.IsGranted(ctx, rbac.READ, n, u); err != nil {
+					return nil, fmt.Errorf("check granted: %w (user %s, note %s)", err, u.ID, n.ID)
+				}
+			}
+
+			readers[e.UserID] = granted
+		}
+
+		if granted {
+			res = append(res, e)
+		}
+	}
+
+	return res, nil
+}
+
+// newWebhookDelivery creates a pending delivery of the message to the endpoint.
+func newWebhookDelivery(e *webhook.Endpoint, eventID uuid.NullUUID, m *webhook.Message) (*webhook.Delivery, error) {
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+
+	now := time.Now()
+	return &webhook.Delivery{
+		EndpointID:    e.ID,
+		EventID:       eventID,
+		EventType:     m.Type,
+		Payload:       payload,
+		Status:        webhook.DeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// webhookEventTypes validates the event types and returns them deduplicated.
+func webhookEventTypes(types []event.Type) ([]string, error) {
+	known := webhook.EventTypes()
+	res := make([]string, 0, len(types))
+	for _, typ := range types {
+		if !slices.Contains(known, typ) {
+			return nil, fmt.Errorf("%w %q", webhook.ErrUnknownEventType, typ)
+		}
+
+		if !slices.Contains(res, string(typ)) {
+			res = append(res, string(typ))
+		}
+	}
+
+	return res, nil
+}
+
+// generateWebhookSecret returns a random endpoint secret.
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// notePayloadNote returns the note described by the payload of the note event.
+func notePayloadNote(p *event.NotePayload) *note.Note {
+	return &note.Note{
+		ID:        p.ID,
+		Name:      p.Name,
+		Text:      p.Text,
+		UserId:    p.UserID,
+		OrgID:     uuid.NullUUID{UUID: p.OrgID, Valid: p.OrgID != uuid.Nil},
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: driver.ZeroTime(p.UpdatedAt),
+	}
+}
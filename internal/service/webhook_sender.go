@@ -0,0 +1,269 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/xsqrty/notes/internal/domain/tx"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/pkg/signature"
+	"github.com/xsqrty/op/driver"
+)
+
+const (
+	// WebhookSignatureHeader is the request header carrying the timestamped HMAC signature of the body.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookEventHeader is the request header carrying the event type.
+	WebhookEventHeader = "X-Webhook-Event"
+	// WebhookDeliveryHeader is the request header carrying the delivery id.
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+	// webhookResponseLimit is the number of response body bytes read before the connection is released.
+	webhookResponseLimit = 64 << 10
+	// webhookClaimMargin is added to the time a batch of claimed deliveries may take to be sent.
+	webhookClaimMargin = time.Minute
+)
+
+// errWebhookEndpointDisabled is the error of deliveries dropped because their endpoint was disabled.
+var errWebhookEndpointDisabled = errors.New("endpoint is disabled")
+
+// WebhookSenderDeps represents the dependencies required to construct a webhook sender.
+type WebhookSenderDeps struct {
+	TxManager     tx.Manager
+	WebhookRepo   webhook.Repository
+	Client        *http.Client
+	PollInterval  time.Duration
+	BatchSize     uint64
+	MaxAttempts   int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	DisableAfter  int
+}
+
+// webhookSender is a struct that implements the webhook.Sender interface posting signed deliveries.
+type webhookSender struct {
+	tx            tx.Manager
+	webhookRepo   webhook.Repository
+	client        *http.Client
+	pollInterval  time.Duration
+	batchSize     uint64
+	maxAttempts   int
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+	disableAfter  int
+}
+
+// NewWebhookSender initializes and returns a new implementation of the webhook.Sender interface.
+func NewWebhookSender(deps *WebhookSenderDeps) webhook.Sender {
+	return &webhookSender{
+		tx:            deps.TxManager,
+		webhookRepo:   deps.WebhookRepo,
+		client:        deps.Client,
+		pollInterval:  deps.PollInterval,
+		batchSize:     deps.BatchSize,
+		maxAttempts:   deps.MaxAttempts,
+		retryDelay:    deps.RetryDelay,
+		maxRetryDelay: deps.MaxRetryDelay,
+		disableAfter:  deps.DisableAfter,
+	}
+}
+
+// Send makes an attempt to post the delivery to the endpoint and saves its result.
+// Failed event deliveries are retried with jittered exponential backoff until attempts are exhausted,
+// and each failed attempt counts towards disabling the endpoint. Test deliveries are final after one attempt.
+// The returned error reports failures to save the result, the outcome of the attempt is kept in the delivery.
+func (s *webhookSender) Send(ctx context.Context, e *webhook.Endpoint, d *webhook.Delivery) error {
+	d.Attempts++
+	code, err := s.post(ctx, e, d)
+	d.ResponseCode = code
+
+	now := time.Now()
+	succeeded := err == nil
+	switch {
+	case succeeded:
+		d.Status = webhook.DeliverySucceeded
+		d.LastError = ""
+		d.DeliveredAt = driver.ZeroTime(now)
+	case !d.EventID.Valid || d.Attempts >= s.maxAttempts:
+		d.Status = webhook.DeliveryFailed
+		d.LastError = err.Error()
+	default:
+		d.LastError = err.Error()
+		d.NextAttemptAt = now.Add(s.backoff(d.Attempts))
+	}
+
+	saveErr := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.SaveDelivery(ctx, d); err != nil {
+			return err
+		}
+
+		if !d.EventID.Valid {
+			return nil
+		}
+
+		return s.updateFailures(ctx, e, succeeded, now)
+	})
+	if saveErr != nil {
+		return fmt.Errorf("send webhook: %w (webhook %s, delivery %s)", saveErr, e.ID, d.ID)
+	}
+
+	return nil
+}
+
+// Run sends due deliveries with the poll interval until the context is done. A full batch is followed by the next one
+// without waiting. Errors are reported to onError.
+func (s *webhookSender) Run(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				sent, err := s.sendDue(ctx)
+				if err != nil {
+					onError(err)
+				}
+
+				if uint64(sent) < s.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// sendDue claims a batch of due deliveries and sends them, returning the number of claimed deliveries.
+// Claimed deliveries are postponed for the time of sending, so concurrent senders skip them,
+// and deliveries left unsent by a crashed sender become due again afterwards.
+func (s *webhookSender) sendDue(ctx context.Context) (int, error) {
+	var deliveries []*webhook.Delivery
+	err := s.tx.Transact(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.Lock(ctx); err != nil {
+			return err
+		}
+
+		due, err := s.webhookRepo.GetDueDeliveries(ctx, time.Now(), s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		claimedUntil := time.Now().Add(time.Duration(len(due))*s.client.Timeout + webhookClaimMargin)
+		for _, d := range due {
+			d.NextAttemptAt = claimedUntil
+			if err := s.webhookRepo.SaveDelivery(ctx, d); err != nil {
+				return err
+			}
+		}
+
+		deliveries = due
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	var errs []error
+	for _, d := range deliveries {
+		e, err := s.webhookRepo.GetByID(ctx, d.EndpointID)
+		if err != nil {
+			if !errors.Is(err, webhook.ErrNotFound) {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		if !e.Enabled {
+			d.Status = webhook.DeliveryFailed
+			d.LastError = errWebhookEndpointDisabled.Error()
+			if err := s.webhookRepo.SaveDelivery(ctx, d); err != nil {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		if err := s.Send(ctx, e, d); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// post sends the signed delivery payload to the endpoint and returns the response status code.
+// Responses other than 2xx are reported as errors.
+func (s *webhookSender) post(ctx context.Context, e *webhook.Endpoint, d *webhook.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(d.EventType))
+	req.Header.Set(WebhookDeliveryHeader, d.ID.String())
+	req.Header.Set(WebhookSignatureHeader, signature.Sign(e.Secret, time.Now(), d.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, webhookResponseLimit))
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// updateFailures resets or increments the consecutive failures of the endpoint, disabling it at the limit.
+// The endpoint is reloaded to keep changes made by its owner meanwhile.
+func (s *webhookSender) updateFailures(ctx context.Context, e *webhook.Endpoint, succeeded bool, now time.Time) error {
+	cur, err := s.webhookRepo.GetByID(ctx, e.ID)
+	if err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if succeeded {
+		if cur.Failures == 0 {
+			return nil
+		}
+
+		cur.Failures = 0
+	} else {
+		cur.Failures++
+		if cur.Enabled && cur.Failures >= s.disableAfter {
+			cur.Enabled = false
+			cur.DisabledAt = driver.ZeroTime(now)
+		}
+	}
+
+	*e = *cur
+	return s.webhookRepo.Save(ctx, cur)
+}
+
+// backoff returns the delay before the next attempt, doubled after each failed attempt and capped by the max delay.
+// The delay is jittered between a half and the full value to spread retries of failed endpoints.
+func (s *webhookSender) backoff(attempts int) time.Duration {
+	delay := s.retryDelay
+	for i := 1; i < attempts && delay < s.maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, s.maxRetryDelay)
+	return delay/2 + rand.N(delay/2+1)
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/notes/internal/domain/event"
+	"github.com/xsqrty/notes/internal/domain/note"
+	"github.com/xsqrty/notes/internal/domain/org"
+	"github.com/xsqrty/notes/internal/domain/reminder"
+	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/internal/domain/webhook"
+	"github.com/xsqrty/notes/mocks/app/mock_tx"
+	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_org"
+	"github.com/xsqrty/notes/mocks/domain/mock_user"
+	"github.com/xsqrty/notes/mocks/domain/mock_webhook"
+	"github.com/xsqrty/notes/pkg/rbac"
+	"github.com/xsqrty/notes/pkg/signature"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	t.Parallel()
+
+	const secret = "whsec_test"
+	eventID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	cases := []struct {
+		name             string
+		status           int
+		eventID          uuid.NullUUID
+		attempts         int
+		failures         int
+		expectedStatus   webhook.DeliveryStatus
+		expectedFailures int
+		expectedEnabled  bool
+		retried          bool
+	}{
+		{
+			name:             "successful_send",
+			status:           http.StatusOK,
+			eventID:          eventID,
+			failures:         3,
+			expectedStatus:   webhook.DeliverySucceeded,
+			expectedFailures: 0,
+			expectedEnabled:  true,
+		},
+		{
+			name:             "failure_retried",
+			status:           http.StatusInternalServerError,
+			eventID:          eventID,
+			failures:         1,
+			expectedStatus:   webhook.DeliveryPending,
+			expectedFailures: 2,
+			expectedEnabled:  true,
+			retried:          true,
+		},
+		{
+			name:             "attempts_exhausted",
+			status:           http.StatusBadGateway,
+			eventID:          eventID,
+			attempts:         2,
+			expectedStatus:   webhook.DeliveryFailed,
+			expectedFailures: 1,
+			expectedEnabled:  true,
+		},
+		{
+			name:             "endpoint_disabled",
+			status:           http.StatusInternalServerError,
+			eventID:          eventID,
+			failures:         4,
+			expectedStatus:   webhook.DeliveryPending,
+			expectedFailures: 5,
+			expectedEnabled:  false,
+			retried:          true,
+		},
+		{
+			name:             "test_delivery_final",
+			status:           http.StatusNotFound,
+			expectedStatus:   webhook.DeliveryFailed,
+			expectedFailures: 0,
+			expectedEnabled:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload := []byte(`{"id":"` + eventID.UUID.String() + `","type":"note.created"}`)
+			received := make(chan struct{}, 1)
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer func() { received <- struct{}{} }()
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil || string(body) != string(payload) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				err = signature.Verify(secret, r.Header.Get(WebhookSignatureHeader), body, time.Now(), time.Minute)
+				if err != nil || r.Header.Get(WebhookEventHeader) != string(event.TypeNoteCreated) {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.WriteHeader(tc.status)
+			}))
+			t.Cleanup(receiver.Close)
+
+			e := &webhook.Endpoint{
+				ID:       uuid.New(),
+				URL:      receiver.URL,
+				Secret:   secret,
+				Enabled:  true,
+				Failures: tc.failures,
+			}
+			d := &webhook.Delivery{
+				ID:            uuid.New(),
+				EndpointID:    e.ID,
+				EventID:       tc.eventID,
+				EventType:     event.TypeNoteCreated,
+				Payload:       payload,
+				Status:        webhook.DeliveryPending,
+				Attempts:      tc.attempts,
+				NextAttemptAt: time.Now(),
+			}
+
+			stored := *e
+			repo := mock_webhook.NewRepository(t)
+			repo.EXPECT().SaveDelivery(mock.Anything, d).Return(nil).Once()
+			repo.EXPECT().GetByID(mock.Anything, e.ID).Return(&stored, nil).Maybe()
+			repo.EXPECT().Save(mock.Anything, mock.AnythingOfType("*webhook.Endpoint")).Return(nil).Maybe()
+
+			sender := NewWebhookSender(&WebhookSenderDeps{
+				TxManager:     mock_tx.NewMockTxManager(),
+				WebhookRepo:   repo,
+				Client:        receiver.Client(),
+				MaxAttempts:   3,
+				RetryDelay:    time.Minute,
+				MaxRetryDelay: time.Hour,
+				DisableAfter:  5,
+			})
+
+			before := time.Now()
+			require.NoError(t, sender.Send(context.Background(), e, d))
+			<-received
+
+			require.Equal(t, tc.status, d.ResponseCode)
+			require.Equal(t, tc.attempts+1, d.Attempts)
+			require.Equal(t, tc.expectedStatus, d.Status)
+			require.Equal(t, tc.expectedFailures, stored.Failures)
+			require.Equal(t, tc.expectedEnabled, stored.Enabled)
+			require.Equal(t, !tc.expectedEnabled, !time.Time(stored.DisabledAt).IsZero())
+			if tc.retried {
+				require.True(t, d.NextAttemptAt.After(before.Add(time.Minute/2-time.Second)))
+			}
+
+			if tc.expectedStatus == webhook.DeliverySucceeded {
+				require.Empty(t, d.LastError)
+				require.False(t, time.Time(d.DeliveredAt).IsZero())
+			} else {
+				require.NotEmpty(t, d.LastError)
+			}
+		})
+	}
+}
+
+func TestWebhookService_HandleEvent(t *testing.T) {
+	t.Parallel()
+
+	owner := &user.User{ID: uuid.New()}
+	member := &user.User{ID: uuid.New()}
+	orgID := uuid.New()
+	newEndpoint := func(u *user.User, types ...event.Type) *webhook.Endpoint {
+		eventTypes := make([]string, len(types))
+		for i, typ := range types {
+			eventTypes[i] = string(typ)
+		}
+
+		return &webhook.Endpoint{ID: uuid.New(), UserID: u.ID, Enabled: true, EventTypes: eventTypes}
+	}
+
+	subscribed := newEndpoint(owner, event.TypeNoteCreated, event.TypeNoteUpdated)
+	other := newEndpoint(owner, event.TypeNoteDeleted)
+	memberEndpoint := newEndpoint(member, event.TypeNoteUpdated)
+	reminderEndpoint := newEndpoint(owner, event.TypeReminderFired)
+
+	cases := []struct {
+		name      string
+		note      *note.Note
+		reminder  bool
+		delivered []*webhook.Endpoint
+		mocker    func(m *webhookMocks, n *note.Note)
+	}{
+		{
+			name:      "personal_note",
+			note:      &note.Note{ID: uuid.New(), UserId: owner.ID, Name: "name", CreatedAt: time.Now()},
+			delivered: []*webhook.Endpoint{subscribed},
+			mocker: func(m *webhookMocks, n *note.Note) {
+				m.repo.EXPECT().
+					GetEnabledByUserIDs(mock.Anything, []uuid.UUID{owner.ID}).
+					Return([]*webhook.Endpoint{subscribed, other}, nil).
+					Once()
+				m.users.EXPECT().GetByID(mock.Anything, owner.ID).Return(owner, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, owner).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "org_note_of_removed_member",
+			note: &note.Note{
+				ID:        uuid.New(),
+				UserId:    owner.ID,
+				OrgID:     uuid.NullUUID{UUID: orgID, Valid: true},
+				Name:      "name",
+				CreatedAt: time.Now(),
+			},
+			delivered: []*webhook.Endpoint{memberEndpoint},
+			mocker: func(m *webhookMocks, n *note.Note) {
+				m.orgs.EXPECT().
+					GetMembers(mock.Anything, &org.Org{ID: orgID}).
+					Return([]*org.Member{{OrgID: orgID, UserID: member.ID}}, nil).
+					Once()
+				m.repo.EXPECT().
+					GetEnabledByUserIDs(mock.Anything, []uuid.UUID{owner.ID, member.ID}).
+					Return([]*webhook.Endpoint{subscribed, other, memberEndpoint}, nil).
+					Once()
+				m.users.EXPECT().GetByID(mock.Anything, owner.ID).Return(owner, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, member.ID).Return(member, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, owner).Return(false, nil).Once()
+				m.guard.EXPECT().IsGranted(mock.Anything, rbac.READ, n, member).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "reminder_fired",
+			note: &note.Note{
+				ID:        uuid.New(),
+				UserId:    member.ID,
+				OrgID:     uuid.NullUUID{UUID: orgID, Valid: true},
+				Name:      "name",
+				CreatedAt: time.Now(),
+			},
+			reminder:  true,
+			delivered: []*webhook.Endpoint{reminderEndpoint},
+			mocker: func(m *webhookMocks, _ *note.Note) {
+				m.repo.EXPECT().
+					GetEnabledByUserIDs(mock.Anything, []uuid.UUID{owner.ID}).
+					Return([]*webhook.Endpoint{subscribed, reminderEndpoint}, nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			typ := event.TypeNoteUpdated
+			ev, err := event.NewNoteEvent(typ, tc.note)
+			if tc.reminder {
+				typ = event.TypeReminderFired
+				ev, err = event.NewReminderFiredEvent(&reminder.Notification{
+					Reminder: &reminder.Reminder{ID: uuid.New()},
+					Note:     tc.note,
+					User:     owner,
+					FiredAt:  time.Now(),
+				})
+			}
+			require.NoError(t, err)
+
+			m := &webhookMocks{
+				repo:  mock_webhook.NewRepository(t),
+				users: mock_user.NewRepository(t),
+				orgs:  mock_org.NewRepository(t),
+				guard: mock_note.NewGuarder(t),
+			}
+			tc.mocker(m, notePayloadNote(mustDecodeNotePayload(t, ev)))
+
+			var delivered []*webhook.Endpoint
+			m.repo.EXPECT().
+				SaveDelivery(mock.Anything, mock.AnythingOfType("*webhook.Delivery")).
+				RunAndReturn(func(_ context.Context, d *webhook.Delivery) error {
+					require.Equal(t, ev.ID, d.EventID.UUID)
+					require.Equal(t, typ, d.EventType)
+					require.Equal(t, webhook.DeliveryPending, d.Status)
+					for _, e := range []*webhook.Endpoint{subscribed, other, memberEndpoint, reminderEndpoint} {
+						if e.ID == d.EndpointID {
+							delivered = append(delivered, e)
+						}
+					}
+
+					return nil
+				}).
+				Times(len(tc.delivered))
+
+			service := NewWebhookService(&WebhookServiceDeps{
+				WebhookRepo:  m.repo,
+				WebhookGuard: mock_webhook.NewGuarder(t),
+				Sender:       mock_webhook.NewSender(t),
+				UserRepo:     m.users,
+				OrgRepo:      m.orgs,
+				NoteGuard:    m.guard,
+			})
+
+			require.NoError(t, service.HandleEvent(context.Background(), ev))
+			require.Equal(t, tc.delivered, delivered)
+		})
+	}
+}
+
+// webhookMocks holds the mocked dependencies of the webhook service handling events.
+type webhookMocks struct {
+	repo  *mock_webhook.Repository
+	users *mock_user.Repository
+	orgs  *mock_org.Repository
+	guard *mock_note.Guarder
+}
+
+// mustDecodeNotePayload returns the decoded payload of the note event.
+func mustDecodeNotePayload(t *testing.T, ev *event.Event) *event.NotePayload {
+	var payload event.NotePayload
+	require.NoError(t, ev.Decode(&payload))
+	return &payload
+}
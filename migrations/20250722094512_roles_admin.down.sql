@@ -0,0 +1 @@
+delete from public.roles where label = 'admin';
@@ -0,0 +1,8 @@
+-- create administrator role
+insert into public.roles
+    (id, description, permissions, label, created_at)
+values (gen_random_uuid(),
+        'Administrator role',
+        '{notes.read,notes.create,notes.update,notes.delete,roles.read,roles.create,roles.update,roles.delete}'::text[],
+        'admin',
+        current_timestamp);
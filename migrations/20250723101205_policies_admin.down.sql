@@ -0,0 +1,3 @@
+update public.roles
+set permissions = array_remove(permissions, 'policies.read')
+where label = 'admin';
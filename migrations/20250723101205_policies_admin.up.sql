@@ -0,0 +1,5 @@
+-- allow administrators to inspect access policies
+update public.roles
+set permissions = array_append(permissions, 'policies.read')
+where label = 'admin'
+  and not ('policies.read' = any (permissions));
@@ -0,0 +1,5 @@
+alter table public.notes
+    drop column org_id;
+drop table public.org_invitations;
+drop table public.org_members;
+drop table public.orgs;
\ No newline at end of file
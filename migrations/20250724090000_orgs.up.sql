@@ -0,0 +1,40 @@
+create table public.orgs
+(
+    id         uuid primary key,
+    name       text        not null default '',
+    created_at timestamptz not null,
+    updated_at timestamptz
+);
+
+create table public.org_members
+(
+    id         uuid primary key,
+    org_id     uuid        not null references public.orgs (id) on delete cascade,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    role       text        not null,
+    created_at timestamptz not null,
+    unique (org_id, user_id)
+);
+
+create table public.org_invitations
+(
+    id         uuid primary key,
+    org_id     uuid        not null references public.orgs (id) on delete cascade,
+    email      text        not null,
+    role       text        not null,
+    invited_by uuid        not null references public.users (id) on delete cascade,
+    created_at timestamptz not null,
+    unique (org_id, email)
+);
+
+alter table public.notes
+    add column org_id uuid references public.orgs (id) on delete cascade;
+
+-- org_members indexes
+create index idx_org_members_user_id on public.org_members (user_id);
+
+-- org_invitations indexes
+create index idx_org_invitations_email on public.org_invitations (email);
+
+-- notes indexes
+create index idx_notes_org_id on public.notes (org_id);
@@ -0,0 +1,6 @@
+update public.roles
+set permissions = array_remove(array_remove(array_remove(permissions, 'invites.read'), 'invites.create'),
+                               'invites.delete')
+where label = 'admin';
+drop table public.invite_code_uses;
+drop table public.invite_codes;
@@ -0,0 +1,31 @@
+create table public.invite_codes
+(
+    id         uuid primary key,
+    code       text        not null,
+    email      text        not null default '',
+    role_label text        not null,
+    max_uses   integer     not null default 0,
+    uses       integer     not null default 0,
+    expires_at timestamptz,
+    created_by uuid        not null references public.users (id) on delete cascade,
+    created_at timestamptz not null
+);
+
+create table public.invite_code_uses
+(
+    id         uuid primary key,
+    code_id    uuid        not null references public.invite_codes (id) on delete cascade,
+    number     integer     not null,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    created_at timestamptz not null,
+    unique (code_id, number)
+);
+
+-- invite_codes indexes
+create unique index idx_unique_invite_codes_code on public.invite_codes (code);
+
+-- allow administrators to manage invitation codes
+update public.roles
+set permissions = permissions || '{invites.read,invites.create,invites.delete}'::text[]
+where label = 'admin'
+  and not ('invites.read' = any (permissions));
@@ -0,0 +1,7 @@
+update public.roles
+set permissions = array_remove(permissions, 'audit.read')
+where label = 'admin';
+drop trigger audit_events_append_only on public.audit_events;
+drop function public.audit_events_append_only();
+drop table public.audit_head;
+drop table public.audit_events;
@@ -0,0 +1,55 @@
+create table public.audit_events
+(
+    id          uuid primary key,
+    seq         bigint      not null unique,
+    action      text        not null,
+    actor_id    uuid,
+    target_type text        not null,
+    target_id   uuid,
+    details     text        not null default '',
+    request_id  uuid,
+    ip          text        not null default '',
+    user_agent  text        not null default '',
+    prev_hash   text        not null,
+    hash        text        not null,
+    created_at  timestamptz not null
+);
+
+-- single row holding the last event of the hash chain, writers lock it to serialize appends
+create table public.audit_head
+(
+    id        integer primary key,
+    seq       bigint not null default 0,
+    hash      text   not null default '',
+    locked_at timestamptz
+);
+
+insert into public.audit_head (id, seq, hash)
+values (1, 0, '');
+
+-- audit_events indexes
+create index idx_audit_events_actor_id on public.audit_events (actor_id);
+create index idx_audit_events_target on public.audit_events (target_type, target_id);
+create index idx_audit_events_action on public.audit_events (action);
+create index idx_audit_events_created_at on public.audit_events (created_at);
+
+-- the audit log is append-only
+create function public.audit_events_append_only() returns trigger
+    language plpgsql as
+$$
+begin
+    raise exception 'audit_events is append-only';
+end;
+$$;
+
+create trigger audit_events_append_only
+    before update or delete
+    on public.audit_events
+    for each row
+execute function public.audit_events_append_only();
+
+-- allow administrators to read the audit log
+update public.roles
+set permissions = permissions || '{audit.read}'::text[]
+where label = 'admin'
+  and not ('audit.read' = any (permissions));
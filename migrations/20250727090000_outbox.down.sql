@@ -0,0 +1,2 @@
+drop table public.outbox_lock;
+drop table public.outbox_events;
@@ -0,0 +1,27 @@
+create table public.outbox_events
+(
+    id           uuid primary key,
+    aggregate_id uuid        not null,
+    type         text        not null,
+    payload      jsonb       not null,
+    status       text        not null,
+    attempts     integer     not null default 0,
+    last_error   text        not null default '',
+    available_at timestamptz not null,
+    created_at   timestamptz not null,
+    processed_at timestamptz
+);
+
+-- single row locked by the dispatcher to keep the delivery order across application instances
+create table public.outbox_lock
+(
+    id        integer primary key,
+    locked_at timestamptz
+);
+
+insert into public.outbox_lock (id)
+values (1);
+
+-- outbox_events indexes
+create index idx_outbox_events_pending on public.outbox_events (id) where status = 'pending';
+create index idx_outbox_events_aggregate_id on public.outbox_events (aggregate_id);
@@ -0,0 +1,3 @@
+drop table public.webhook_lock;
+drop table public.webhook_deliveries;
+drop table public.webhook_endpoints;
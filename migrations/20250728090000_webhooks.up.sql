@@ -0,0 +1,46 @@
+create table public.webhook_endpoints
+(
+    id          uuid primary key,
+    user_id     uuid        not null references public.users (id) on delete cascade,
+    url         text        not null,
+    secret      text        not null,
+    event_types text[]      not null,
+    enabled     boolean     not null default true,
+    failures    integer     not null default 0,
+    disabled_at timestamptz,
+    created_at  timestamptz not null,
+    updated_at  timestamptz
+);
+
+create table public.webhook_deliveries
+(
+    id              uuid primary key,
+    endpoint_id     uuid        not null references public.webhook_endpoints (id) on delete cascade,
+    event_id        uuid,
+    event_type      text        not null,
+    payload         jsonb       not null,
+    status          text        not null,
+    attempts        integer     not null default 0,
+    response_code   integer     not null default 0,
+    last_error      text        not null default '',
+    next_attempt_at timestamptz not null,
+    created_at      timestamptz not null,
+    delivered_at    timestamptz
+);
+
+-- single row locked by the senders to claim due deliveries across application instances
+create table public.webhook_lock
+(
+    id        integer primary key,
+    locked_at timestamptz
+);
+
+insert into public.webhook_lock (id)
+values (1);
+
+-- webhook_endpoints indexes
+create index idx_webhook_endpoints_user_id on public.webhook_endpoints (user_id);
+
+-- webhook_deliveries indexes
+create index idx_webhook_deliveries_endpoint_id on public.webhook_deliveries (endpoint_id);
+create index idx_webhook_deliveries_pending on public.webhook_deliveries (next_attempt_at) where status = 'pending';
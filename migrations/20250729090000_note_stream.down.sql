@@ -0,0 +1,2 @@
+drop trigger outbox_events_notify_note on public.outbox_events;
+drop function public.notify_note_event();
@@ -0,0 +1,23 @@
+-- notifies the note stream listeners of the instances when a note event is committed,
+-- the text is left out to keep the payload within the notification size limit
+create function public.notify_note_event() returns trigger
+    language plpgsql as
+$$
+begin
+    perform pg_notify('note_events', json_build_object(
+            'id', new.id,
+            'type', new.type,
+            'created_at', new.created_at,
+            'note', new.payload - 'text'
+        )::text);
+
+    return new;
+end;
+$$;
+
+create trigger outbox_events_notify_note
+    after insert
+    on public.outbox_events
+    for each row
+    when (new.type like 'note.%')
+execute function public.notify_note_event();
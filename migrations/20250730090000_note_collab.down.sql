@@ -0,0 +1,3 @@
+drop table public.note_collab_lock;
+drop table public.note_collab_updates;
+drop table public.note_collab_states;
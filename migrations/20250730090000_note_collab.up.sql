@@ -0,0 +1,26 @@
+create table public.note_collab_states
+(
+    note_id    uuid primary key references public.notes (id) on delete cascade,
+    elements   jsonb       not null,
+    updated_at timestamptz not null
+);
+
+create table public.note_collab_updates
+(
+    id         uuid primary key,
+    note_id    uuid        not null references public.notes (id) on delete cascade,
+    ops        jsonb       not null,
+    created_at timestamptz not null
+);
+
+create index idx_note_collab_updates_note_id on public.note_collab_updates (note_id);
+
+-- single row locked by the application instances to load and compact collaborative documents one at a time
+create table public.note_collab_lock
+(
+    id        integer primary key,
+    locked_at timestamptz
+);
+
+insert into public.note_collab_lock (id)
+values (1);
@@ -0,0 +1,4 @@
+drop view public.note_sync_horizon;
+drop trigger notes_track_version on public.notes;
+drop function public.track_note_version();
+drop table public.note_versions;
@@ -0,0 +1,56 @@
+-- server versions of the notes for offline sync, rows of deleted notes are kept as tombstones
+create table public.note_versions
+(
+    note_id    uuid primary key,
+    user_id    uuid        not null,
+    org_id     uuid,
+    version    bigint      not null,
+    deleted    boolean     not null default false,
+    txid       bigint      not null,
+    changed_at timestamptz not null
+);
+
+create index idx_note_versions_txid_note_id on public.note_versions (txid, note_id);
+
+create function public.track_note_version() returns trigger
+    language plpgsql as
+$$
+begin
+    if tg_op = 'DELETE' then
+        update public.note_versions
+        set version    = version + 1,
+            deleted    = true,
+            txid       = pg_current_xact_id()::text::bigint,
+            changed_at = now()
+        where note_id = old.id;
+
+        return old;
+    end if;
+
+    insert into public.note_versions (note_id, user_id, org_id, version, txid, changed_at)
+    values (new.id, new.user_id, new.org_id, 1, pg_current_xact_id()::text::bigint, now())
+    on conflict (note_id) do update
+        set user_id    = excluded.user_id,
+            org_id     = excluded.org_id,
+            version    = note_versions.version + 1,
+            deleted    = false,
+            txid       = excluded.txid,
+            changed_at = excluded.changed_at;
+
+    return new;
+end;
+$$;
+
+create trigger notes_track_version
+    after insert or update or delete
+    on public.notes
+    for each row
+execute function public.track_note_version();
+
+insert into public.note_versions (note_id, user_id, org_id, version, txid, changed_at)
+select id, user_id, org_id, 1, pg_current_xact_id()::text::bigint, now()
+from public.notes;
+
+-- transactions from the horizon on may be not committed yet, their changes are synced again on the next sync
+create view public.note_sync_horizon as
+select pg_snapshot_xmin(pg_current_snapshot())::text::bigint as xmin;
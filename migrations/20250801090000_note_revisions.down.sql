@@ -0,0 +1,38 @@
+drop trigger notes_record_revision on public.notes;
+drop function public.record_note_revision();
+drop table public.note_revisions;
+
+create or replace function public.track_note_version() returns trigger
+    language plpgsql as
+$$
+begin
+    if tg_op = 'DELETE' then
+        update public.note_versions
+        set version    = version + 1,
+            deleted    = true,
+            txid       = pg_current_xact_id()::text::bigint,
+            changed_at = now()
+        where note_id = old.id;
+
+        return old;
+    end if;
+
+    insert into public.note_versions (note_id, user_id, org_id, version, txid, changed_at)
+    values (new.id, new.user_id, new.org_id, 1, pg_current_xact_id()::text::bigint, now())
+    on conflict (note_id) do update
+        set user_id    = excluded.user_id,
+            org_id     = excluded.org_id,
+            version    = note_versions.version + 1,
+            deleted    = false,
+            txid       = excluded.txid,
+            changed_at = excluded.changed_at;
+
+    return new;
+end;
+$$;
+
+drop trigger notes_check_version on public.notes;
+drop function public.check_note_version();
+
+alter table public.notes
+    drop column version;
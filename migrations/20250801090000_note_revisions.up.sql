@@ -0,0 +1,102 @@
+alter table public.notes
+    add column version bigint not null default 1;
+
+alter table public.notes
+    disable trigger notes_track_version;
+
+update public.notes n
+set version = v.version
+from public.note_versions v
+where v.note_id = n.id;
+
+alter table public.notes
+    enable trigger notes_track_version;
+
+-- an update must bump the version it read, so concurrent updates of the same version fail
+create function public.check_note_version() returns trigger
+    language plpgsql as
+$$
+begin
+    if new.version <> old.version + 1 then
+        raise exception 'note % version % is stale', new.id, new.version - 1
+            using errcode = 'serialization_failure';
+    end if;
+
+    return new;
+end;
+$$;
+
+create trigger notes_check_version
+    before update
+    on public.notes
+    for each row
+execute function public.check_note_version();
+
+create or replace function public.track_note_version() returns trigger
+    language plpgsql as
+$$
+begin
+    if tg_op = 'DELETE' then
+        update public.note_versions
+        set version    = old.version + 1,
+            deleted    = true,
+            txid       = pg_current_xact_id()::text::bigint,
+            changed_at = now()
+        where note_id = old.id;
+
+        return old;
+    end if;
+
+    insert into public.note_versions (note_id, user_id, org_id, version, txid, changed_at)
+    values (new.id, new.user_id, new.org_id, new.version, pg_current_xact_id()::text::bigint, now())
+    on conflict (note_id) do update
+        set user_id    = excluded.user_id,
+            org_id     = excluded.org_id,
+            version    = excluded.version,
+            deleted    = false,
+            txid       = excluded.txid,
+            changed_at = excluded.changed_at;
+
+    return new;
+end;
+$$;
+
+-- previous versions of the notes used as the base of three-way merges, the latest 100 are kept
+create table public.note_revisions
+(
+    note_id    uuid        not null references public.notes (id) on delete cascade,
+    version    bigint      not null,
+    name       text        not null,
+    text       text        not null,
+    created_at timestamptz not null default now(),
+    primary key (note_id, version)
+);
+
+create function public.record_note_revision() returns trigger
+    language plpgsql as
+$$
+begin
+    insert into public.note_revisions (note_id, version, name, text)
+    values (new.id, new.version, new.name, new.text)
+    on conflict (note_id, version) do update
+        set name = excluded.name,
+            text = excluded.text;
+
+    delete
+    from public.note_revisions
+    where note_id = new.id
+      and version <= new.version - 100;
+
+    return new;
+end;
+$$;
+
+create trigger notes_record_revision
+    after insert or update
+    on public.notes
+    for each row
+execute function public.record_note_revision();
+
+insert into public.note_revisions (note_id, version, name, text)
+select id, version, name, text
+from public.notes;
@@ -0,0 +1,3 @@
+alter table public.notes
+    drop column plain_text,
+    drop column format;
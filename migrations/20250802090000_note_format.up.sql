@@ -0,0 +1,25 @@
+alter table public.notes
+    add column format     text not null default 'plain' check (format in ('plain', 'markdown')),
+    add column plain_text text not null default '';
+
+alter table public.notes
+    disable trigger notes_check_version;
+
+alter table public.notes
+    disable trigger notes_track_version;
+
+alter table public.notes
+    disable trigger notes_record_revision;
+
+-- existing notes are plain texts, their plain text is the text with collapsed spaces
+update public.notes
+set plain_text = regexp_replace(btrim(text), '\s+', ' ', 'g');
+
+alter table public.notes
+    enable trigger notes_record_revision;
+
+alter table public.notes
+    enable trigger notes_track_version;
+
+alter table public.notes
+    enable trigger notes_check_version;
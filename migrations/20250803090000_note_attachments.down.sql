@@ -0,0 +1,2 @@
+drop view public.note_attachment_usage;
+drop table public.note_attachments;
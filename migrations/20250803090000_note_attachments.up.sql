@@ -0,0 +1,19 @@
+-- attachments of deleted notes are removed with their blobs on the note.deleted event, so there is no foreign key
+create table public.note_attachments
+(
+    id           uuid primary key,
+    note_id      uuid        not null,
+    user_id      uuid        not null,
+    name         text        not null,
+    content_type text        not null,
+    size         bigint      not null,
+    created_at   timestamptz not null
+);
+
+create index idx_note_attachments_note_id on public.note_attachments (note_id);
+create index idx_note_attachments_user_id on public.note_attachments (user_id);
+
+create view public.note_attachment_usage as
+select user_id, sum(size)::bigint as size
+from public.note_attachments
+group by user_id;
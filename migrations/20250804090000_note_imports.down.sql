@@ -0,0 +1,4 @@
+drop table public.note_import_lock;
+drop table public.note_imported;
+drop table public.note_import_items;
+drop table public.note_import_jobs;
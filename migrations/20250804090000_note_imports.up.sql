@@ -0,0 +1,64 @@
+create table public.note_import_jobs
+(
+    id            uuid primary key,
+    user_id       uuid        not null references public.users (id) on delete cascade,
+    name          text        not null,
+    format        text        not null,
+    checksum      text        not null,
+    size          bigint      not null,
+    status        text        not null,
+    total         integer     not null default 0,
+    processed     integer     not null default 0,
+    created       integer     not null default 0,
+    skipped       integer     not null default 0,
+    failed        integer     not null default 0,
+    error         text        not null default '',
+    claimed_until timestamptz not null,
+    created_at    timestamptz not null,
+    started_at    timestamptz,
+    finished_at   timestamptz
+);
+
+-- note_id is cleared when the note is deleted, so the note may be imported again
+create table public.note_import_items
+(
+    id         uuid primary key,
+    job_id     uuid        not null references public.note_import_jobs (id) on delete cascade,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    position   integer     not null,
+    source     text        not null,
+    status     text        not null,
+    note_id    uuid references public.notes (id) on delete set null,
+    error      text        not null default '',
+    created_at timestamptz not null
+);
+
+-- hashes of the user and the content of imported notes, removed with the notes so they may be imported again
+create table public.note_imported
+(
+    hash       text primary key,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    note_id    uuid        not null references public.notes (id) on delete cascade,
+    created_at timestamptz not null
+);
+
+-- single row locked by the workers to claim due jobs across application instances
+create table public.note_import_lock
+(
+    id        integer primary key,
+    locked_at timestamptz
+);
+
+insert into public.note_import_lock (id)
+values (1);
+
+-- note_import_jobs indexes
+create index idx_note_import_jobs_user_id_checksum on public.note_import_jobs (user_id, checksum);
+create index idx_note_import_jobs_unfinished on public.note_import_jobs (claimed_until)
+    where status in ('pending', 'running');
+
+-- note_import_items indexes
+create index idx_note_import_items_job_id on public.note_import_items (job_id, position);
+
+-- note_imported indexes
+create index idx_note_imported_note_id on public.note_imported (note_id);
@@ -0,0 +1,6 @@
+drop index public.idx_notes_user_id_pinned;
+
+alter table public.notes
+    drop column favourite,
+    drop column archived,
+    drop column pinned;
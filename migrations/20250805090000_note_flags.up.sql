@@ -0,0 +1,7 @@
+alter table public.notes
+    add column pinned    boolean not null default false,
+    add column archived  boolean not null default false,
+    add column favourite boolean not null default false;
+
+-- searches leave archived notes out and list pinned notes first by default
+create index idx_notes_user_id_pinned on public.notes (user_id, pinned desc, created_at desc) where not archived;
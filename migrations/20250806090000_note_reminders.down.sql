@@ -0,0 +1,3 @@
+drop table public.notifications;
+drop view public.note_reminders_due;
+drop table public.note_reminders;
@@ -0,0 +1,48 @@
+-- reminders of the notes, one per note and user; due_at is the earliest of next_at and snoozed_until
+create table public.note_reminders
+(
+    id            uuid primary key,
+    note_id       uuid        not null references public.notes (id) on delete cascade,
+    user_id       uuid        not null references public.users (id) on delete cascade,
+    remind_at     timestamptz not null,
+    time_zone     text        not null,
+    rule          text        not null default '',
+    status        text        not null,
+    next_at       timestamptz,
+    snoozed_until timestamptz,
+    due_at        timestamptz,
+    fired_at      timestamptz,
+    fired         integer     not null default 0,
+    last_error    text        not null default '',
+    created_at    timestamptz not null,
+    updated_at    timestamptz,
+    unique (note_id, user_id)
+);
+
+create index idx_note_reminders_user_id on public.note_reminders (user_id);
+create index idx_note_reminders_due_at on public.note_reminders (due_at) where status = 'scheduled';
+
+-- due reminders locked by the reading transaction, reminders locked by other schedulers are skipped,
+-- so every reminder is fired by a single instance; recreate the view when columns are added
+create view public.note_reminders_due as
+select *
+from public.note_reminders
+where status = 'scheduled'
+  and due_at <= now()
+order by due_at
+    for update skip locked;
+
+-- in-app notifications of the users
+create table public.notifications
+(
+    id         uuid primary key,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    type       text        not null,
+    title      text        not null,
+    body       text        not null default '',
+    note_id    uuid references public.notes (id) on delete set null,
+    created_at timestamptz not null,
+    read_at    timestamptz
+);
+
+create index idx_notifications_user_id_created_at on public.notifications (user_id, created_at desc);
@@ -0,0 +1,5 @@
+drop table public.user_devices;
+drop table public.notification_preferences;
+
+drop index public.idx_notifications_created_at;
+drop index public.idx_notifications_user_id_unread;
@@ -0,0 +1,27 @@
+-- unread counts and retention cleanup of the inbox
+create index idx_notifications_user_id_unread on public.notifications (user_id) where read_at is null;
+create index idx_notifications_created_at on public.notifications (created_at);
+
+-- notification types disabled or enabled by the users, types without a row are enabled
+create table public.notification_preferences
+(
+    id         uuid primary key,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    type       text        not null,
+    enabled    boolean     not null,
+    updated_at timestamptz not null,
+    unique (user_id, type)
+);
+
+-- devices the users signed in from, a login from an unknown device is notified
+create table public.user_devices
+(
+    id           uuid primary key,
+    user_id      uuid        not null references public.users (id) on delete cascade,
+    fingerprint  text        not null,
+    user_agent   text        not null,
+    ip           text        not null default '',
+    created_at   timestamptz not null,
+    last_seen_at timestamptz not null,
+    unique (user_id, fingerprint)
+);
@@ -0,0 +1,8 @@
+drop index public.idx_notes_user_id_open_items;
+
+alter table public.notes
+    drop column has_open_items,
+    drop column checklist_done,
+    drop column checklist_total;
+
+drop table public.note_checklist_items;
@@ -0,0 +1,25 @@
+-- checklist items of the notes ordered by position, assignee_id is a user reading the note
+create table public.note_checklist_items
+(
+    id          uuid primary key,
+    note_id     uuid        not null references public.notes (id) on delete cascade,
+    position    integer     not null,
+    text        text        not null,
+    done        boolean     not null default false,
+    assignee_id uuid references public.users (id) on delete set null,
+    due_at      timestamptz,
+    done_at     timestamptz,
+    created_at  timestamptz not null,
+    updated_at  timestamptz
+);
+
+create index idx_note_checklist_items_note_id on public.note_checklist_items (note_id, position);
+create index idx_note_checklist_items_assignee_id on public.note_checklist_items (assignee_id) where not done;
+
+-- completion of the checklist kept with the note, updated along with the items as the next version of the note
+alter table public.notes
+    add column checklist_total integer not null default 0,
+    add column checklist_done  integer not null default 0,
+    add column has_open_items  boolean not null default false;
+
+create index idx_notes_user_id_open_items on public.notes (user_id, created_at desc) where has_open_items;
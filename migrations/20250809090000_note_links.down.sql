@@ -0,0 +1 @@
+drop table public.note_links;
@@ -0,0 +1,16 @@
+-- wiki-style links written in the notes, target is the identifier or the title of the linked note as written,
+-- target_id is null for dangling links. user_id and org_id are the scope of the source note titles resolve in
+create table public.note_links
+(
+    id         uuid primary key,
+    source_id  uuid        not null references public.notes (id) on delete cascade,
+    target     text        not null,
+    target_id  uuid references public.notes (id) on delete set null,
+    user_id    uuid        not null references public.users (id) on delete cascade,
+    org_id     uuid references public.orgs (id) on delete cascade,
+    created_at timestamptz not null,
+    unique (source_id, target)
+);
+
+create index idx_note_links_target_id on public.note_links (target_id);
+create index idx_note_links_dangling on public.note_links (user_id, org_id, target) where target_id is null;
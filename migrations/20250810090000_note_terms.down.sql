@@ -0,0 +1,4 @@
+drop index public.idx_notes_terms;
+
+alter table public.notes
+    drop column terms;
@@ -0,0 +1,33 @@
+-- keywords of the plain text of the notes kept on every write, notes sharing keywords are similar
+alter table public.notes
+    add column terms text[] not null default '{}'::text[];
+
+create index idx_notes_terms on public.notes using gin (terms);
+
+alter table public.notes
+    disable trigger notes_check_version;
+
+alter table public.notes
+    disable trigger notes_track_version;
+
+alter table public.notes
+    disable trigger notes_record_revision;
+
+-- existing notes get the 16 most frequent words of at least 3 letters, stop words are left out on the next write
+update public.notes n
+set terms = coalesce((select array_agg(t.word order by t.word)
+                      from (select w.word
+                            from regexp_split_to_table(lower(n.plain_text), '[^[:alnum:]]+') as w(word)
+                            where char_length(w.word) >= 3
+                            group by w.word
+                            order by count(*) desc, w.word
+                            limit 16) t), '{}'::text[]);
+
+alter table public.notes
+    enable trigger notes_record_revision;
+
+alter table public.notes
+    enable trigger notes_track_version;
+
+alter table public.notes
+    enable trigger notes_check_version;
@@ -0,0 +1,6 @@
+-- rows locked by uploads of the user, so the usage checked before saving an attachment is not changed concurrently
+create table public.note_attachment_locks
+(
+    user_id   uuid primary key,
+    locked_at timestamptz
+);
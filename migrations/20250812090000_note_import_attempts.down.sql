@@ -0,0 +1,2 @@
+alter table public.note_import_jobs
+    drop column attempts;
@@ -0,0 +1,3 @@
+-- claims of the import jobs, jobs claimed too many times without finishing are failed
+alter table public.note_import_jobs
+    add column attempts integer not null default 0;
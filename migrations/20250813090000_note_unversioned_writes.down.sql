@@ -0,0 +1,21 @@
+drop trigger notes_record_revision_update on public.notes;
+drop trigger notes_record_revision on public.notes;
+
+create trigger notes_record_revision
+    after insert or update
+    on public.notes
+    for each row
+execute function public.record_note_revision();
+
+create or replace function public.check_note_version() returns trigger
+    language plpgsql as
+$$
+begin
+    if new.version <> old.version + 1 then
+        raise exception 'note % version % is stale', new.id, new.version - 1
+            using errcode = 'serialization_failure';
+    end if;
+
+    return new;
+end;
+$$;
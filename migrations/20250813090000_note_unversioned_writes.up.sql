@@ -0,0 +1,34 @@
+-- writes keeping the name, the text and the format of the note, as flags and checklist counters, may keep the version
+create or replace function public.check_note_version() returns trigger
+    language plpgsql as
+$$
+begin
+    if new.version = old.version
+        and (new.name, new.text, new.format) is not distinct from (old.name, old.text, old.format) then
+        return new;
+    end if;
+
+    if new.version <> old.version + 1 then
+        raise exception 'note % version % is stale', new.id, new.version - 1
+            using errcode = 'serialization_failure';
+    end if;
+
+    return new;
+end;
+$$;
+
+-- revisions are recorded for new versions only, so unversioned writes do not evict merge bases
+drop trigger notes_record_revision on public.notes;
+
+create trigger notes_record_revision
+    after insert
+    on public.notes
+    for each row
+execute function public.record_note_revision();
+
+create trigger notes_record_revision_update
+    after update
+    on public.notes
+    for each row
+    when (old.version is distinct from new.version)
+execute function public.record_note_revision();
@@ -0,0 +1,12 @@
+drop view public.note_reminders_due;
+
+alter table public.note_reminders
+    drop column attempts;
+
+create view public.note_reminders_due as
+select *
+from public.note_reminders
+where status = 'scheduled'
+  and due_at <= now()
+order by due_at
+    for update skip locked;
@@ -0,0 +1,14 @@
+-- failed firings of the due occurrence, the occurrence is skipped once the attempts are exhausted;
+-- the view selecting * is recreated to include the column
+alter table public.note_reminders
+    add column attempts integer not null default 0;
+
+drop view public.note_reminders_due;
+
+create view public.note_reminders_due as
+select *
+from public.note_reminders
+where status = 'scheduled'
+  and due_at <= now()
+order by due_at
+    for update skip locked;
@@ -0,0 +1 @@
+drop table public.note_checklist_locks;
@@ -0,0 +1,6 @@
+-- rows locked by checklist changes of the note, so the completion saved with the note is counted from current items
+create table public.note_checklist_locks
+(
+    note_id   uuid primary key references public.notes (id) on delete cascade,
+    locked_at timestamptz
+);
@@ -0,0 +1,2 @@
+drop index public.idx_outbox_events_pending_aggregate;
+drop view public.outbox_events_due;
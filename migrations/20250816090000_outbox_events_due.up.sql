@@ -0,0 +1,17 @@
+-- pending events due for delivery in the publishing order; an event waiting for retry or claimed by a dispatcher
+-- holds back the later events of its aggregate, so they are left out to keep the order of the aggregate
+create view public.outbox_events_due as
+select e.*
+from public.outbox_events e
+where e.status = 'pending'
+  and e.available_at <= now()
+  and not exists (select
+                  from public.outbox_events p
+                  where p.aggregate_id = e.aggregate_id
+                    and p.status = 'pending'
+                    and p.available_at > now()
+                    and p.id < e.id)
+order by e.id;
+
+-- outbox_events indexes
+create index idx_outbox_events_pending_aggregate on public.outbox_events (aggregate_id, id) where status = 'pending';
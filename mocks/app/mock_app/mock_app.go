@@ -10,8 +10,20 @@ import (
 	"github.com/xsqrty/notes/internal/app"
 	"github.com/xsqrty/notes/internal/config"
 	"github.com/xsqrty/notes/internal/logger"
+	"github.com/xsqrty/notes/mocks/domain/mock_attachment"
+	"github.com/xsqrty/notes/mocks/domain/mock_audit"
 	"github.com/xsqrty/notes/mocks/domain/mock_auth"
+	"github.com/xsqrty/notes/mocks/domain/mock_collab"
+	"github.com/xsqrty/notes/mocks/domain/mock_export"
+	"github.com/xsqrty/notes/mocks/domain/mock_invite"
 	"github.com/xsqrty/notes/mocks/domain/mock_note"
+	"github.com/xsqrty/notes/mocks/domain/mock_noteimport"
+	"github.com/xsqrty/notes/mocks/domain/mock_notesync"
+	"github.com/xsqrty/notes/mocks/domain/mock_org"
+	"github.com/xsqrty/notes/mocks/domain/mock_policy"
+	"github.com/xsqrty/notes/mocks/domain/mock_role"
+	"github.com/xsqrty/notes/mocks/domain/mock_stream"
+	"github.com/xsqrty/notes/mocks/domain/mock_webhook"
 	"github.com/xsqrty/notes/pkg/config/size"
 )
 
@@ -26,8 +38,20 @@ func NewDeps(t *testing.T, mocker func(deps *app.Deps)) *app.Deps {
 			},
 		},
 		Service: app.ServicesSet{
-			AuthService: mock_auth.NewService(t),
-			NoteService: mock_note.NewService(t),
+			AuthService:       mock_auth.NewService(t),
+			NoteService:       mock_note.NewService(t),
+			RoleService:       mock_role.NewService(t),
+			PolicyService:     mock_policy.NewService(t),
+			OrgService:        mock_org.NewService(t),
+			InviteService:     mock_invite.NewService(t),
+			AuditService:      mock_audit.NewService(t),
+			WebhookService:    mock_webhook.NewService(t),
+			StreamService:     mock_stream.NewService(t),
+			CollabService:     mock_collab.NewService(t),
+			NoteSyncService:   mock_notesync.NewService(t),
+			AttachmentService: mock_attachment.NewService(t),
+			ExportService:     mock_export.NewService(t),
+			NoteImportService: mock_noteimport.NewService(t),
 		},
 	}
 
@@ -205,8 +205,8 @@ func (_c *Repository_Delete_Call) RunAndReturn(run func(ctx context.Context, n *
 }
 
 // GetByID provides a mock function for the type Repository
-func (_mock *Repository) GetByID(ctx context.Context, id uuid.UUID) (*note.Note, error) {
-	ret := _mock.Called(ctx, id)
+func (_mock *Repository) GetByID(ctx context.Context, user1 *user.User, id uuid.UUID) (*note.Note, error) {
+	ret := _mock.Called(ctx, user1, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByID")
@@ -214,18 +214,18 @@ func (_mock *Repository) GetByID(ctx context.Context, id uuid.UUID) (*note.Note,
 
 	var r0 *note.Note
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*note.Note, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) (*note.Note, error)); ok {
+		return returnFunc(ctx, user1, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *note.Note); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) *note.Note); ok {
+		r0 = returnFunc(ctx, user1, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*note.Note)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, user1, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -239,24 +239,30 @@ type Repository_GetByID_Call struct {
 
 // GetByID is a helper method to define mock.On call
 //   - ctx context.Context
+//   - user1 *user.User
 //   - id uuid.UUID
-func (_e *Repository_Expecter) GetByID(ctx interface{}, id interface{}) *Repository_GetByID_Call {
-	return &Repository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+func (_e *Repository_Expecter) GetByID(ctx interface{}, user1 interface{}, id interface{}) *Repository_GetByID_Call {
+	return &Repository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, user1, id)}
 }
 
-func (_c *Repository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Repository_GetByID_Call {
+func (_c *Repository_GetByID_Call) Run(run func(ctx context.Context, user1 *user.User, id uuid.UUID)) *Repository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 *user.User
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -267,7 +273,155 @@ func (_c *Repository_GetByID_Call) Return(note1 *note.Note, err error) *Reposito
 	return _c
 }
 
-func (_c *Repository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*note.Note, error)) *Repository_GetByID_Call {
+func (_c *Repository_GetByID_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, id uuid.UUID) (*note.Note, error)) *Repository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function for the type Repository
+func (_mock *Repository) GetByIDs(ctx context.Context, user1 *user.User, ids []uuid.UUID) ([]*note.Note, error) {
+	ret := _mock.Called(ctx, user1, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []*note.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, []uuid.UUID) ([]*note.Note, error)); ok {
+		return returnFunc(ctx, user1, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, []uuid.UUID) []*note.Note); ok {
+		r0 = returnFunc(ctx, user1, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*note.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, []uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, user1, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type Repository_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - ids []uuid.UUID
+func (_e *Repository_Expecter) GetByIDs(ctx interface{}, user1 interface{}, ids interface{}) *Repository_GetByIDs_Call {
+	return &Repository_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, user1, ids)}
+}
+
+func (_c *Repository_GetByIDs_Call) Run(run func(ctx context.Context, user1 *user.User, ids []uuid.UUID)) *Repository_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 []uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_GetByIDs_Call) Return(notes []*note.Note, err error) *Repository_GetByIDs_Call {
+	_c.Call.Return(notes, err)
+	return _c
+}
+
+func (_c *Repository_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, ids []uuid.UUID) ([]*note.Note, error)) *Repository_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevision provides a mock function for the type Repository
+func (_mock *Repository) GetRevision(ctx context.Context, noteID uuid.UUID, version int64) (*note.Revision, error) {
+	ret := _mock.Called(ctx, noteID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *note.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) (*note.Revision, error)); ok {
+		return returnFunc(ctx, noteID, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) *note.Revision); ok {
+		r0 = returnFunc(ctx, noteID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*note.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) error); ok {
+		r1 = returnFunc(ctx, noteID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type Repository_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - noteID uuid.UUID
+//   - version int64
+func (_e *Repository_Expecter) GetRevision(ctx interface{}, noteID interface{}, version interface{}) *Repository_GetRevision_Call {
+	return &Repository_GetRevision_Call{Call: _e.mock.On("GetRevision", ctx, noteID, version)}
+}
+
+func (_c *Repository_GetRevision_Call) Run(run func(ctx context.Context, noteID uuid.UUID, version int64)) *Repository_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_GetRevision_Call) Return(revision *note.Revision, err error) *Repository_GetRevision_Call {
+	_c.Call.Return(revision, err)
+	return _c
+}
+
+func (_c *Repository_GetRevision_Call) RunAndReturn(run func(ctx context.Context, noteID uuid.UUID, version int64) (*note.Revision, error)) *Repository_GetRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -395,6 +549,149 @@ func (_c *Repository_Save_Call) RunAndReturn(run func(ctx context.Context, n *no
 	return _c
 }
 
+// SaveFields provides a mock function for the type Repository
+func (_mock *Repository) SaveFields(ctx context.Context, n *note.Note, fields []note.Field) error {
+	ret := _mock.Called(ctx, n, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFields")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *note.Note, []note.Field) error); ok {
+		r0 = returnFunc(ctx, n, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_SaveFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveFields'
+type Repository_SaveFields_Call struct {
+	*mock.Call
+}
+
+// SaveFields is a helper method to define mock.On call
+//   - ctx context.Context
+//   - n *note.Note
+//   - fields []note.Field
+func (_e *Repository_Expecter) SaveFields(ctx interface{}, n interface{}, fields interface{}) *Repository_SaveFields_Call {
+	return &Repository_SaveFields_Call{Call: _e.mock.On("SaveFields", ctx, n, fields)}
+}
+
+func (_c *Repository_SaveFields_Call) Run(run func(ctx context.Context, n *note.Note, fields []note.Field)) *Repository_SaveFields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *note.Note
+		if args[1] != nil {
+			arg1 = args[1].(*note.Note)
+		}
+		var arg2 []note.Field
+		if args[2] != nil {
+			arg2 = args[2].([]note.Field)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_SaveFields_Call) Return(err error) *Repository_SaveFields_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_SaveFields_Call) RunAndReturn(run func(ctx context.Context, n *note.Note, fields []note.Field) error) *Repository_SaveFields_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchByOrg provides a mock function for the type Repository
+func (_mock *Repository) SearchByOrg(ctx context.Context, u *user.User, orgID uuid.UUID, r *search.Request) (*search.Result[note.Note], error) {
+	ret := _mock.Called(ctx, u, orgID, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchByOrg")
+	}
+
+	var r0 *search.Result[note.Note]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, *search.Request) (*search.Result[note.Note], error)); ok {
+		return returnFunc(ctx, u, orgID, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, *search.Request) *search.Result[note.Note]); ok {
+		r0 = returnFunc(ctx, u, orgID, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*search.Result[note.Note])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID, *search.Request) error); ok {
+		r1 = returnFunc(ctx, u, orgID, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_SearchByOrg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchByOrg'
+type Repository_SearchByOrg_Call struct {
+	*mock.Call
+}
+
+// SearchByOrg is a helper method to define mock.On call
+//   - ctx context.Context
+//   - u *user.User
+//   - orgID uuid.UUID
+//   - r *search.Request
+func (_e *Repository_Expecter) SearchByOrg(ctx interface{}, u interface{}, orgID interface{}, r interface{}) *Repository_SearchByOrg_Call {
+	return &Repository_SearchByOrg_Call{Call: _e.mock.On("SearchByOrg", ctx, u, orgID, r)}
+}
+
+func (_c *Repository_SearchByOrg_Call) Run(run func(ctx context.Context, u *user.User, orgID uuid.UUID, r *search.Request)) *Repository_SearchByOrg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 *search.Request
+		if args[3] != nil {
+			arg3 = args[3].(*search.Request)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_SearchByOrg_Call) Return(result *search.Result[note.Note], err error) *Repository_SearchByOrg_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *Repository_SearchByOrg_Call) RunAndReturn(run func(ctx context.Context, u *user.User, orgID uuid.UUID, r *search.Request) (*search.Result[note.Note], error)) *Repository_SearchByOrg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchByUser provides a mock function for the type Repository
 func (_mock *Repository) SearchByUser(ctx context.Context, u *user.User, r *search.Request) (*search.Result[note.Note], error) {
 	ret := _mock.Called(ctx, u, r)
@@ -496,48 +793,48 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type Service
-func (_mock *Service) Create(ctx context.Context, user1 *user.User, data *note.CreateData) (*note.Note, error) {
-	ret := _mock.Called(ctx, user1, data)
+// Batch provides a mock function for the type Service
+func (_mock *Service) Batch(ctx context.Context, user1 *user.User, req *note.BatchRequest) ([]*note.BatchResult, error) {
+	ret := _mock.Called(ctx, user1, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for Batch")
 	}
 
-	var r0 *note.Note
+	var r0 []*note.BatchResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *note.CreateData) (*note.Note, error)); ok {
-		return returnFunc(ctx, user1, data)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *note.BatchRequest) ([]*note.BatchResult, error)); ok {
+		return returnFunc(ctx, user1, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *note.CreateData) *note.Note); ok {
-		r0 = returnFunc(ctx, user1, data)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *note.BatchRequest) []*note.BatchResult); ok {
+		r0 = returnFunc(ctx, user1, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*note.Note)
+			r0 = ret.Get(0).([]*note.BatchResult)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, *note.CreateData) error); ok {
-		r1 = returnFunc(ctx, user1, data)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, *note.BatchRequest) error); ok {
+		r1 = returnFunc(ctx, user1, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Service_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type Service_Create_Call struct {
+// Service_Batch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Batch'
+type Service_Batch_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// Batch is a helper method to define mock.On call
 //   - ctx context.Context
 //   - user1 *user.User
-//   - data *note.CreateData
-func (_e *Service_Expecter) Create(ctx interface{}, user1 interface{}, data interface{}) *Service_Create_Call {
-	return &Service_Create_Call{Call: _e.mock.On("Create", ctx, user1, data)}
+//   - req *note.BatchRequest
+func (_e *Service_Expecter) Batch(ctx interface{}, user1 interface{}, req interface{}) *Service_Batch_Call {
+	return &Service_Batch_Call{Call: _e.mock.On("Batch", ctx, user1, req)}
 }
 
-func (_c *Service_Create_Call) Run(run func(ctx context.Context, user1 *user.User, data *note.CreateData)) *Service_Create_Call {
+func (_c *Service_Batch_Call) Run(run func(ctx context.Context, user1 *user.User, req *note.BatchRequest)) *Service_Batch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -547,9 +844,9 @@ func (_c *Service_Create_Call) Run(run func(ctx context.Context, user1 *user.Use
 		if args[1] != nil {
 			arg1 = args[1].(*user.User)
 		}
-		var arg2 *note.CreateData
+		var arg2 *note.BatchRequest
 		if args[2] != nil {
-			arg2 = args[2].(*note.CreateData)
+			arg2 = args[2].(*note.BatchRequest)
 		}
 		run(
 			arg0,
@@ -560,18 +857,92 @@ func (_c *Service_Create_Call) Run(run func(ctx context.Context, user1 *user.Use
 	return _c
 }
 
-func (_c *Service_Create_Call) Return(note1 *note.Note, err error) *Service_Create_Call {
-	_c.Call.Return(note1, err)
+func (_c *Service_Batch_Call) Return(batchResults []*note.BatchResult, err error) *Service_Batch_Call {
+	_c.Call.Return(batchResults, err)
 	return _c
 }
 
-func (_c *Service_Create_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, data *note.CreateData) (*note.Note, error)) *Service_Create_Call {
+func (_c *Service_Batch_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, req *note.BatchRequest) ([]*note.BatchResult, error)) *Service_Batch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type Service
-func (_mock *Service) Delete(ctx context.Context, user1 *user.User, id uuid.UUID) (*note.Note, error) {
+// Create provides a mock function for the type Service
+func (_mock *Service) Create(ctx context.Context, user1 *user.User, data *note.CreateData) (*note.Note, error) {
+	ret := _mock.Called(ctx, user1, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *note.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *note.CreateData) (*note.Note, error)); ok {
+		return returnFunc(ctx, user1, data)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *note.CreateData) *note.Note); ok {
+		r0 = returnFunc(ctx, user1, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*note.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, *note.CreateData) error); ok {
+		r1 = returnFunc(ctx, user1, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type Service_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - data *note.CreateData
+func (_e *Service_Expecter) Create(ctx interface{}, user1 interface{}, data interface{}) *Service_Create_Call {
+	return &Service_Create_Call{Call: _e.mock.On("Create", ctx, user1, data)}
+}
+
+func (_c *Service_Create_Call) Run(run func(ctx context.Context, user1 *user.User, data *note.CreateData)) *Service_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 *note.CreateData
+		if args[2] != nil {
+			arg2 = args[2].(*note.CreateData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Create_Call) Return(note1 *note.Note, err error) *Service_Create_Call {
+	_c.Call.Return(note1, err)
+	return _c
+}
+
+func (_c *Service_Create_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, data *note.CreateData) (*note.Note, error)) *Service_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type Service
+func (_mock *Service) Delete(ctx context.Context, user1 *user.User, id uuid.UUID) (*note.Note, error) {
 	ret := _mock.Called(ctx, user1, id)
 
 	if len(ret) == 0 {
@@ -718,6 +1089,160 @@ func (_c *Service_Get_Call) RunAndReturn(run func(ctx context.Context, user1 *us
 	return _c
 }
 
+// Patch provides a mock function for the type Service
+func (_mock *Service) Patch(ctx context.Context, user1 *user.User, id uuid.UUID, patcher note.Patcher) (*note.Note, error) {
+	ret := _mock.Called(ctx, user1, id, patcher)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *note.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, note.Patcher) (*note.Note, error)); ok {
+		return returnFunc(ctx, user1, id, patcher)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, note.Patcher) *note.Note); ok {
+		r0 = returnFunc(ctx, user1, id, patcher)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*note.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID, note.Patcher) error); ok {
+		r1 = returnFunc(ctx, user1, id, patcher)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type Service_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - id uuid.UUID
+//   - patcher note.Patcher
+func (_e *Service_Expecter) Patch(ctx interface{}, user1 interface{}, id interface{}, patcher interface{}) *Service_Patch_Call {
+	return &Service_Patch_Call{Call: _e.mock.On("Patch", ctx, user1, id, patcher)}
+}
+
+func (_c *Service_Patch_Call) Run(run func(ctx context.Context, user1 *user.User, id uuid.UUID, patcher note.Patcher)) *Service_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 note.Patcher
+		if args[3] != nil {
+			arg3 = args[3].(note.Patcher)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Patch_Call) Return(note1 *note.Note, err error) *Service_Patch_Call {
+	_c.Call.Return(note1, err)
+	return _c
+}
+
+func (_c *Service_Patch_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, id uuid.UUID, patcher note.Patcher) (*note.Note, error)) *Service_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Render provides a mock function for the type Service
+func (_mock *Service) Render(ctx context.Context, user1 *user.User, id uuid.UUID) (*note.Rendered, error) {
+	ret := _mock.Called(ctx, user1, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 *note.Rendered
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) (*note.Rendered, error)); ok {
+		return returnFunc(ctx, user1, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) *note.Rendered); ok {
+		r0 = returnFunc(ctx, user1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*note.Rendered)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, user1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Render_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Render'
+type Service_Render_Call struct {
+	*mock.Call
+}
+
+// Render is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - id uuid.UUID
+func (_e *Service_Expecter) Render(ctx interface{}, user1 interface{}, id interface{}) *Service_Render_Call {
+	return &Service_Render_Call{Call: _e.mock.On("Render", ctx, user1, id)}
+}
+
+func (_c *Service_Render_Call) Run(run func(ctx context.Context, user1 *user.User, id uuid.UUID)) *Service_Render_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Render_Call) Return(rendered *note.Rendered, err error) *Service_Render_Call {
+	_c.Call.Return(rendered, err)
+	return _c
+}
+
+func (_c *Service_Render_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, id uuid.UUID) (*note.Rendered, error)) *Service_Render_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function for the type Service
 func (_mock *Service) Search(ctx context.Context, user1 *user.User, req *search.Request) (*search.Result[note.Note], error) {
 	ret := _mock.Called(ctx, user1, req)
@@ -792,6 +1317,172 @@ func (_c *Service_Search_Call) RunAndReturn(run func(ctx context.Context, user1
 	return _c
 }
 
+// SearchByOrg provides a mock function for the type Service
+func (_mock *Service) SearchByOrg(ctx context.Context, user1 *user.User, orgID uuid.UUID, req *search.Request) (*search.Result[note.Note], error) {
+	ret := _mock.Called(ctx, user1, orgID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchByOrg")
+	}
+
+	var r0 *search.Result[note.Note]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, *search.Request) (*search.Result[note.Note], error)); ok {
+		return returnFunc(ctx, user1, orgID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, *search.Request) *search.Result[note.Note]); ok {
+		r0 = returnFunc(ctx, user1, orgID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*search.Result[note.Note])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID, *search.Request) error); ok {
+		r1 = returnFunc(ctx, user1, orgID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_SearchByOrg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchByOrg'
+type Service_SearchByOrg_Call struct {
+	*mock.Call
+}
+
+// SearchByOrg is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - orgID uuid.UUID
+//   - req *search.Request
+func (_e *Service_Expecter) SearchByOrg(ctx interface{}, user1 interface{}, orgID interface{}, req interface{}) *Service_SearchByOrg_Call {
+	return &Service_SearchByOrg_Call{Call: _e.mock.On("SearchByOrg", ctx, user1, orgID, req)}
+}
+
+func (_c *Service_SearchByOrg_Call) Run(run func(ctx context.Context, user1 *user.User, orgID uuid.UUID, req *search.Request)) *Service_SearchByOrg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 *search.Request
+		if args[3] != nil {
+			arg3 = args[3].(*search.Request)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_SearchByOrg_Call) Return(result *search.Result[note.Note], err error) *Service_SearchByOrg_Call {
+	_c.Call.Return(result, err)
+	return _c
+}
+
+func (_c *Service_SearchByOrg_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, orgID uuid.UUID, req *search.Request) (*search.Result[note.Note], error)) *Service_SearchByOrg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFlag provides a mock function for the type Service
+func (_mock *Service) SetFlag(ctx context.Context, user1 *user.User, id uuid.UUID, flag note.Field, value bool) (*note.Note, error) {
+	ret := _mock.Called(ctx, user1, id, flag, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFlag")
+	}
+
+	var r0 *note.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, note.Field, bool) (*note.Note, error)); ok {
+		return returnFunc(ctx, user1, id, flag, value)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID, note.Field, bool) *note.Note); ok {
+		r0 = returnFunc(ctx, user1, id, flag, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*note.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID, note.Field, bool) error); ok {
+		r1 = returnFunc(ctx, user1, id, flag, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_SetFlag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFlag'
+type Service_SetFlag_Call struct {
+	*mock.Call
+}
+
+// SetFlag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - id uuid.UUID
+//   - flag note.Field
+//   - value bool
+func (_e *Service_Expecter) SetFlag(ctx interface{}, user1 interface{}, id interface{}, flag interface{}, value interface{}) *Service_SetFlag_Call {
+	return &Service_SetFlag_Call{Call: _e.mock.On("SetFlag", ctx, user1, id, flag, value)}
+}
+
+func (_c *Service_SetFlag_Call) Run(run func(ctx context.Context, user1 *user.User, id uuid.UUID, flag note.Field, value bool)) *Service_SetFlag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 note.Field
+		if args[3] != nil {
+			arg3 = args[3].(note.Field)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_SetFlag_Call) Return(note1 *note.Note, err error) *Service_SetFlag_Call {
+	_c.Call.Return(note1, err)
+	return _c
+}
+
+func (_c *Service_SetFlag_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, id uuid.UUID, flag note.Field, value bool) (*note.Note, error)) *Service_SetFlag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type Service
 func (_mock *Service) Update(ctx context.Context, user1 *user.User, data *note.UpdateData) (*note.Note, error) {
 	ret := _mock.Called(ctx, user1, data)
@@ -7,11 +7,118 @@ package mock_role
 import (
 	"context"
 
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/xsqrty/notes/internal/domain/role"
 	"github.com/xsqrty/notes/internal/domain/user"
+	"github.com/xsqrty/notes/pkg/rbac"
 )
 
+// NewGuarder creates a new instance of Guarder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuarder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Guarder {
+	mock := &Guarder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Guarder is an autogenerated mock type for the Guarder type
+type Guarder struct {
+	mock.Mock
+}
+
+type Guarder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Guarder) EXPECT() *Guarder_Expecter {
+	return &Guarder_Expecter{mock: &_m.Mock}
+}
+
+// IsGranted provides a mock function for the type Guarder
+func (_mock *Guarder) IsGranted(ctx context.Context, op rbac.Operation, role1 *role.Role, user1 *user.User) (bool, error) {
+	ret := _mock.Called(ctx, op, role1, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsGranted")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, rbac.Operation, *role.Role, *user.User) (bool, error)); ok {
+		return returnFunc(ctx, op, role1, user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, rbac.Operation, *role.Role, *user.User) bool); ok {
+		r0 = returnFunc(ctx, op, role1, user1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, rbac.Operation, *role.Role, *user.User) error); ok {
+		r1 = returnFunc(ctx, op, role1, user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Guarder_IsGranted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsGranted'
+type Guarder_IsGranted_Call struct {
+	*mock.Call
+}
+
+// IsGranted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - op rbac.Operation
+//   - role1 *role.Role
+//   - user1 *user.User
+func (_e *Guarder_Expecter) IsGranted(ctx interface{}, op interface{}, role1 interface{}, user1 interface{}) *Guarder_IsGranted_Call {
+	return &Guarder_IsGranted_Call{Call: _e.mock.On("IsGranted", ctx, op, role1, user1)}
+}
+
+func (_c *Guarder_IsGranted_Call) Run(run func(ctx context.Context, op rbac.Operation, role1 *role.Role, user1 *user.User)) *Guarder_IsGranted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 rbac.Operation
+		if args[1] != nil {
+			arg1 = args[1].(rbac.Operation)
+		}
+		var arg2 *role.Role
+		if args[2] != nil {
+			arg2 = args[2].(*role.Role)
+		}
+		var arg3 *user.User
+		if args[3] != nil {
+			arg3 = args[3].(*user.User)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Guarder_IsGranted_Call) Return(b bool, err error) *Guarder_IsGranted_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *Guarder_IsGranted_Call) RunAndReturn(run func(ctx context.Context, op rbac.Operation, role1 *role.Role, user1 *user.User) (bool, error)) *Guarder_IsGranted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -39,6 +146,69 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// AttachUser provides a mock function for the type Repository
+func (_mock *Repository) AttachUser(ctx context.Context, r *role.Role, user1 *user.User) error {
+	ret := _mock.Called(ctx, r, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *role.Role, *user.User) error); ok {
+		r0 = returnFunc(ctx, r, user1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AttachUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachUser'
+type Repository_AttachUser_Call struct {
+	*mock.Call
+}
+
+// AttachUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *role.Role
+//   - user1 *user.User
+func (_e *Repository_Expecter) AttachUser(ctx interface{}, r interface{}, user1 interface{}) *Repository_AttachUser_Call {
+	return &Repository_AttachUser_Call{Call: _e.mock.On("AttachUser", ctx, r, user1)}
+}
+
+func (_c *Repository_AttachUser_Call) Run(run func(ctx context.Context, r *role.Role, user1 *user.User)) *Repository_AttachUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *role.Role
+		if args[1] != nil {
+			arg1 = args[1].(*role.Role)
+		}
+		var arg2 *user.User
+		if args[2] != nil {
+			arg2 = args[2].(*user.User)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AttachUser_Call) Return(err error) *Repository_AttachUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AttachUser_Call) RunAndReturn(run func(ctx context.Context, r *role.Role, user1 *user.User) error) *Repository_AttachUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AttachUserRolesByLabel provides a mock function for the type Repository
 func (_mock *Repository) AttachUserRolesByLabel(ctx context.Context, label role.Label, user1 *user.User) error {
 	ret := _mock.Called(ctx, label, user1)
@@ -102,54 +272,102 @@ func (_c *Repository_AttachUserRolesByLabel_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
-// HasPermissions provides a mock function for the type Repository
-func (_mock *Repository) HasPermissions(ctx context.Context, permissions []role.Permission, user1 *user.User) (bool, error) {
-	ret := _mock.Called(ctx, permissions, user1)
+// Delete provides a mock function for the type Repository
+func (_mock *Repository) Delete(ctx context.Context, r *role.Role) error {
+	ret := _mock.Called(ctx, r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasPermissions")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 bool
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []role.Permission, *user.User) (bool, error)); ok {
-		return returnFunc(ctx, permissions, user1)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []role.Permission, *user.User) bool); ok {
-		r0 = returnFunc(ctx, permissions, user1)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *role.Role) error); ok {
+		r0 = returnFunc(ctx, r)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []role.Permission, *user.User) error); ok {
-		r1 = returnFunc(ctx, permissions, user1)
+	return r0
+}
+
+// Repository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Repository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *role.Role
+func (_e *Repository_Expecter) Delete(ctx interface{}, r interface{}) *Repository_Delete_Call {
+	return &Repository_Delete_Call{Call: _e.mock.On("Delete", ctx, r)}
+}
+
+func (_c *Repository_Delete_Call) Run(run func(ctx context.Context, r *role.Role)) *Repository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *role.Role
+		if args[1] != nil {
+			arg1 = args[1].(*role.Role)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_Delete_Call) Return(err error) *Repository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_Delete_Call) RunAndReturn(run func(ctx context.Context, r *role.Role) error) *Repository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetachUser provides a mock function for the type Repository
+func (_mock *Repository) DetachUser(ctx context.Context, r *role.Role, user1 *user.User) error {
+	ret := _mock.Called(ctx, r, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetachUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *role.Role, *user.User) error); ok {
+		r0 = returnFunc(ctx, r, user1)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// Repository_HasPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasPermissions'
-type Repository_HasPermissions_Call struct {
+// Repository_DetachUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachUser'
+type Repository_DetachUser_Call struct {
 	*mock.Call
 }
 
-// HasPermissions is a helper method to define mock.On call
+// DetachUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - permissions []role.Permission
+//   - r *role.Role
 //   - user1 *user.User
-func (_e *Repository_Expecter) HasPermissions(ctx interface{}, permissions interface{}, user1 interface{}) *Repository_HasPermissions_Call {
-	return &Repository_HasPermissions_Call{Call: _e.mock.On("HasPermissions", ctx, permissions, user1)}
+func (_e *Repository_Expecter) DetachUser(ctx interface{}, r interface{}, user1 interface{}) *Repository_DetachUser_Call {
+	return &Repository_DetachUser_Call{Call: _e.mock.On("DetachUser", ctx, r, user1)}
 }
 
-func (_c *Repository_HasPermissions_Call) Run(run func(ctx context.Context, permissions []role.Permission, user1 *user.User)) *Repository_HasPermissions_Call {
+func (_c *Repository_DetachUser_Call) Run(run func(ctx context.Context, r *role.Role, user1 *user.User)) *Repository_DetachUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []role.Permission
+		var arg1 *role.Role
 		if args[1] != nil {
-			arg1 = args[1].([]role.Permission)
+			arg1 = args[1].(*role.Role)
 		}
 		var arg2 *user.User
 		if args[2] != nil {
@@ -164,12 +382,924 @@ func (_c *Repository_HasPermissions_Call) Run(run func(ctx context.Context, perm
 	return _c
 }
 
-func (_c *Repository_HasPermissions_Call) Return(b bool, err error) *Repository_HasPermissions_Call {
-	_c.Call.Return(b, err)
+func (_c *Repository_DetachUser_Call) Return(err error) *Repository_DetachUser_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_HasPermissions_Call) RunAndReturn(run func(ctx context.Context, permissions []role.Permission, user1 *user.User) (bool, error)) *Repository_HasPermissions_Call {
+func (_c *Repository_DetachUser_Call) RunAndReturn(run func(ctx context.Context, r *role.Role, user1 *user.User) error) *Repository_DetachUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type Repository
+func (_mock *Repository) GetAll(ctx context.Context) ([]*role.Role, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*role.Role, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*role.Role); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type Repository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) GetAll(ctx interface{}) *Repository_GetAll_Call {
+	return &Repository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *Repository_GetAll_Call) Run(run func(ctx context.Context)) *Repository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_GetAll_Call) Return(roles []*role.Role, err error) *Repository_GetAll_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *Repository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]*role.Role, error)) *Repository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type Repository
+func (_mock *Repository) GetByID(ctx context.Context, id uuid.UUID) (*role.Role, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*role.Role, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *role.Role); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type Repository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Repository_Expecter) GetByID(ctx interface{}, id interface{}) *Repository_GetByID_Call {
+	return &Repository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *Repository_GetByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Repository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_GetByID_Call) Return(role1 *role.Role, err error) *Repository_GetByID_Call {
+	_c.Call.Return(role1, err)
+	return _c
+}
+
+func (_c *Repository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*role.Role, error)) *Repository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserPermissions provides a mock function for the type Repository
+func (_mock *Repository) GetUserPermissions(ctx context.Context, user1 *user.User) ([]role.Permission, error) {
+	ret := _mock.Called(ctx, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserPermissions")
+	}
+
+	var r0 []role.Permission
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User) ([]role.Permission, error)); ok {
+		return returnFunc(ctx, user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User) []role.Permission); ok {
+		r0 = returnFunc(ctx, user1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]role.Permission)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User) error); ok {
+		r1 = returnFunc(ctx, user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_GetUserPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserPermissions'
+type Repository_GetUserPermissions_Call struct {
+	*mock.Call
+}
+
+// GetUserPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+func (_e *Repository_Expecter) GetUserPermissions(ctx interface{}, user1 interface{}) *Repository_GetUserPermissions_Call {
+	return &Repository_GetUserPermissions_Call{Call: _e.mock.On("GetUserPermissions", ctx, user1)}
+}
+
+func (_c *Repository_GetUserPermissions_Call) Run(run func(ctx context.Context, user1 *user.User)) *Repository_GetUserPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_GetUserPermissions_Call) Return(permissions []role.Permission, err error) *Repository_GetUserPermissions_Call {
+	_c.Call.Return(permissions, err)
+	return _c
+}
+
+func (_c *Repository_GetUserPermissions_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User) ([]role.Permission, error)) *Repository_GetUserPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasPermissions provides a mock function for the type Repository
+func (_mock *Repository) HasPermissions(ctx context.Context, permissions []role.Permission, user1 *user.User) (bool, error) {
+	ret := _mock.Called(ctx, permissions, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasPermissions")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []role.Permission, *user.User) (bool, error)); ok {
+		return returnFunc(ctx, permissions, user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []role.Permission, *user.User) bool); ok {
+		r0 = returnFunc(ctx, permissions, user1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []role.Permission, *user.User) error); ok {
+		r1 = returnFunc(ctx, permissions, user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_HasPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasPermissions'
+type Repository_HasPermissions_Call struct {
+	*mock.Call
+}
+
+// HasPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - permissions []role.Permission
+//   - user1 *user.User
+func (_e *Repository_Expecter) HasPermissions(ctx interface{}, permissions interface{}, user1 interface{}) *Repository_HasPermissions_Call {
+	return &Repository_HasPermissions_Call{Call: _e.mock.On("HasPermissions", ctx, permissions, user1)}
+}
+
+func (_c *Repository_HasPermissions_Call) Run(run func(ctx context.Context, permissions []role.Permission, user1 *user.User)) *Repository_HasPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []role.Permission
+		if args[1] != nil {
+			arg1 = args[1].([]role.Permission)
+		}
+		var arg2 *user.User
+		if args[2] != nil {
+			arg2 = args[2].(*user.User)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_HasPermissions_Call) Return(b bool, err error) *Repository_HasPermissions_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *Repository_HasPermissions_Call) RunAndReturn(run func(ctx context.Context, permissions []role.Permission, user1 *user.User) (bool, error)) *Repository_HasPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type Repository
+func (_mock *Repository) Save(ctx context.Context, r *role.Role) error {
+	ret := _mock.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *role.Role) error); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type Repository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *role.Role
+func (_e *Repository_Expecter) Save(ctx interface{}, r interface{}) *Repository_Save_Call {
+	return &Repository_Save_Call{Call: _e.mock.On("Save", ctx, r)}
+}
+
+func (_c *Repository_Save_Call) Run(run func(ctx context.Context, r *role.Role)) *Repository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *role.Role
+		if args[1] != nil {
+			arg1 = args[1].(*role.Role)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_Save_Call) Return(err error) *Repository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_Save_Call) RunAndReturn(run func(ctx context.Context, r *role.Role) error) *Repository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+type Service_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Service) EXPECT() *Service_Expecter {
+	return &Service_Expecter{mock: &_m.Mock}
+}
+
+// Assign provides a mock function for the type Service
+func (_mock *Service) Assign(ctx context.Context, user1 *user.User, data *role.Assignment) error {
+	ret := _mock.Called(ctx, user1, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *role.Assignment) error); ok {
+		r0 = returnFunc(ctx, user1, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Assign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Assign'
+type Service_Assign_Call struct {
+	*mock.Call
+}
+
+// Assign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - data *role.Assignment
+func (_e *Service_Expecter) Assign(ctx interface{}, user1 interface{}, data interface{}) *Service_Assign_Call {
+	return &Service_Assign_Call{Call: _e.mock.On("Assign", ctx, user1, data)}
+}
+
+func (_c *Service_Assign_Call) Run(run func(ctx context.Context, user1 *user.User, data *role.Assignment)) *Service_Assign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 *role.Assignment
+		if args[2] != nil {
+			arg2 = args[2].(*role.Assignment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Assign_Call) Return(err error) *Service_Assign_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Assign_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, data *role.Assignment) error) *Service_Assign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type Service
+func (_mock *Service) Create(ctx context.Context, user1 *user.User, data *role.CreateData) (*role.Role, error) {
+	ret := _mock.Called(ctx, user1, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *role.CreateData) (*role.Role, error)); ok {
+		return returnFunc(ctx, user1, data)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *role.CreateData) *role.Role); ok {
+		r0 = returnFunc(ctx, user1, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, *role.CreateData) error); ok {
+		r1 = returnFunc(ctx, user1, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type Service_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - data *role.CreateData
+func (_e *Service_Expecter) Create(ctx interface{}, user1 interface{}, data interface{}) *Service_Create_Call {
+	return &Service_Create_Call{Call: _e.mock.On("Create", ctx, user1, data)}
+}
+
+func (_c *Service_Create_Call) Run(run func(ctx context.Context, user1 *user.User, data *role.CreateData)) *Service_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 *role.CreateData
+		if args[2] != nil {
+			arg2 = args[2].(*role.CreateData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Create_Call) Return(role1 *role.Role, err error) *Service_Create_Call {
+	_c.Call.Return(role1, err)
+	return _c
+}
+
+func (_c *Service_Create_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, data *role.CreateData) (*role.Role, error)) *Service_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type Service
+func (_mock *Service) Delete(ctx context.Context, user1 *user.User, id uuid.UUID) (*role.Role, error) {
+	ret := _mock.Called(ctx, user1, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) (*role.Role, error)); ok {
+		return returnFunc(ctx, user1, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) *role.Role); ok {
+		r0 = returnFunc(ctx, user1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, user1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Service_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - id uuid.UUID
+func (_e *Service_Expecter) Delete(ctx interface{}, user1 interface{}, id interface{}) *Service_Delete_Call {
+	return &Service_Delete_Call{Call: _e.mock.On("Delete", ctx, user1, id)}
+}
+
+func (_c *Service_Delete_Call) Run(run func(ctx context.Context, user1 *user.User, id uuid.UUID)) *Service_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Delete_Call) Return(role1 *role.Role, err error) *Service_Delete_Call {
+	_c.Call.Return(role1, err)
+	return _c
+}
+
+func (_c *Service_Delete_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, id uuid.UUID) (*role.Role, error)) *Service_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type Service
+func (_mock *Service) Get(ctx context.Context, user1 *user.User, id uuid.UUID) (*role.Role, error) {
+	ret := _mock.Called(ctx, user1, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) (*role.Role, error)); ok {
+		return returnFunc(ctx, user1, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, uuid.UUID) *role.Role); ok {
+		r0 = returnFunc(ctx, user1, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, user1, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type Service_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - id uuid.UUID
+func (_e *Service_Expecter) Get(ctx interface{}, user1 interface{}, id interface{}) *Service_Get_Call {
+	return &Service_Get_Call{Call: _e.mock.On("Get", ctx, user1, id)}
+}
+
+func (_c *Service_Get_Call) Run(run func(ctx context.Context, user1 *user.User, id uuid.UUID)) *Service_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Get_Call) Return(role1 *role.Role, err error) *Service_Get_Call {
+	_c.Call.Return(role1, err)
+	return _c
+}
+
+func (_c *Service_Get_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, id uuid.UUID) (*role.Role, error)) *Service_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type Service
+func (_mock *Service) List(ctx context.Context, user1 *user.User) ([]*role.Role, error) {
+	ret := _mock.Called(ctx, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User) ([]*role.Role, error)); ok {
+		return returnFunc(ctx, user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User) []*role.Role); ok {
+		r0 = returnFunc(ctx, user1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User) error); ok {
+		r1 = returnFunc(ctx, user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type Service_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+func (_e *Service_Expecter) List(ctx interface{}, user1 interface{}) *Service_List_Call {
+	return &Service_List_Call{Call: _e.mock.On("List", ctx, user1)}
+}
+
+func (_c *Service_List_Call) Run(run func(ctx context.Context, user1 *user.User)) *Service_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_List_Call) Return(roles []*role.Role, err error) *Service_List_Call {
+	_c.Call.Return(roles, err)
+	return _c
+}
+
+func (_c *Service_List_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User) ([]*role.Role, error)) *Service_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Permissions provides a mock function for the type Service
+func (_mock *Service) Permissions(ctx context.Context, user1 *user.User) ([]role.Permission, error) {
+	ret := _mock.Called(ctx, user1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Permissions")
+	}
+
+	var r0 []role.Permission
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User) ([]role.Permission, error)); ok {
+		return returnFunc(ctx, user1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User) []role.Permission); ok {
+		r0 = returnFunc(ctx, user1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]role.Permission)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User) error); ok {
+		r1 = returnFunc(ctx, user1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Permissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Permissions'
+type Service_Permissions_Call struct {
+	*mock.Call
+}
+
+// Permissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+func (_e *Service_Expecter) Permissions(ctx interface{}, user1 interface{}) *Service_Permissions_Call {
+	return &Service_Permissions_Call{Call: _e.mock.On("Permissions", ctx, user1)}
+}
+
+func (_c *Service_Permissions_Call) Run(run func(ctx context.Context, user1 *user.User)) *Service_Permissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Permissions_Call) Return(permissions []role.Permission, err error) *Service_Permissions_Call {
+	_c.Call.Return(permissions, err)
+	return _c
+}
+
+func (_c *Service_Permissions_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User) ([]role.Permission, error)) *Service_Permissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unassign provides a mock function for the type Service
+func (_mock *Service) Unassign(ctx context.Context, user1 *user.User, data *role.Assignment) error {
+	ret := _mock.Called(ctx, user1, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unassign")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *role.Assignment) error); ok {
+		r0 = returnFunc(ctx, user1, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Unassign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unassign'
+type Service_Unassign_Call struct {
+	*mock.Call
+}
+
+// Unassign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - data *role.Assignment
+func (_e *Service_Expecter) Unassign(ctx interface{}, user1 interface{}, data interface{}) *Service_Unassign_Call {
+	return &Service_Unassign_Call{Call: _e.mock.On("Unassign", ctx, user1, data)}
+}
+
+func (_c *Service_Unassign_Call) Run(run func(ctx context.Context, user1 *user.User, data *role.Assignment)) *Service_Unassign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 *role.Assignment
+		if args[2] != nil {
+			arg2 = args[2].(*role.Assignment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Unassign_Call) Return(err error) *Service_Unassign_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Unassign_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, data *role.Assignment) error) *Service_Unassign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type Service
+func (_mock *Service) Update(ctx context.Context, user1 *user.User, data *role.UpdateData) (*role.Role, error) {
+	ret := _mock.Called(ctx, user1, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *role.Role
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *role.UpdateData) (*role.Role, error)); ok {
+		return returnFunc(ctx, user1, data)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.User, *role.UpdateData) *role.Role); ok {
+		r0 = returnFunc(ctx, user1, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*role.Role)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *user.User, *role.UpdateData) error); ok {
+		r1 = returnFunc(ctx, user1, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type Service_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user1 *user.User
+//   - data *role.UpdateData
+func (_e *Service_Expecter) Update(ctx interface{}, user1 interface{}, data interface{}) *Service_Update_Call {
+	return &Service_Update_Call{Call: _e.mock.On("Update", ctx, user1, data)}
+}
+
+func (_c *Service_Update_Call) Run(run func(ctx context.Context, user1 *user.User, data *role.UpdateData)) *Service_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.User
+		if args[1] != nil {
+			arg1 = args[1].(*user.User)
+		}
+		var arg2 *role.UpdateData
+		if args[2] != nil {
+			arg2 = args[2].(*role.UpdateData)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Update_Call) Return(role1 *role.Role, err error) *Service_Update_Call {
+	_c.Call.Return(role1, err)
+	return _c
+}
+
+func (_c *Service_Update_Call) RunAndReturn(run func(ctx context.Context, user1 *user.User, data *role.UpdateData) (*role.Role, error)) *Service_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -297,3 +297,227 @@ func (_c *Repository_Save_Call) RunAndReturn(run func(ctx context.Context, u *us
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewDeviceRepository creates a new instance of DeviceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeviceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeviceRepository {
+	mock := &DeviceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// DeviceRepository is an autogenerated mock type for the DeviceRepository type
+type DeviceRepository struct {
+	mock.Mock
+}
+
+type DeviceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeviceRepository) EXPECT() *DeviceRepository_Expecter {
+	return &DeviceRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetDevice provides a mock function for the type DeviceRepository
+func (_mock *DeviceRepository) GetDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (*user.Device, error) {
+	ret := _mock.Called(ctx, userID, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevice")
+	}
+
+	var r0 *user.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*user.Device, error)); ok {
+		return returnFunc(ctx, userID, fingerprint)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *user.Device); ok {
+		r0 = returnFunc(ctx, userID, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, userID, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// DeviceRepository_GetDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDevice'
+type DeviceRepository_GetDevice_Call struct {
+	*mock.Call
+}
+
+// GetDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - fingerprint string
+func (_e *DeviceRepository_Expecter) GetDevice(ctx interface{}, userID interface{}, fingerprint interface{}) *DeviceRepository_GetDevice_Call {
+	return &DeviceRepository_GetDevice_Call{Call: _e.mock.On("GetDevice", ctx, userID, fingerprint)}
+}
+
+func (_c *DeviceRepository_GetDevice_Call) Run(run func(ctx context.Context, userID uuid.UUID, fingerprint string)) *DeviceRepository_GetDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *DeviceRepository_GetDevice_Call) Return(device *user.Device, err error) *DeviceRepository_GetDevice_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *DeviceRepository_GetDevice_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, fingerprint string) (*user.Device, error)) *DeviceRepository_GetDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasDevices provides a mock function for the type DeviceRepository
+func (_mock *DeviceRepository) HasDevices(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasDevices")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// DeviceRepository_HasDevices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasDevices'
+type DeviceRepository_HasDevices_Call struct {
+	*mock.Call
+}
+
+// HasDevices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *DeviceRepository_Expecter) HasDevices(ctx interface{}, userID interface{}) *DeviceRepository_HasDevices_Call {
+	return &DeviceRepository_HasDevices_Call{Call: _e.mock.On("HasDevices", ctx, userID)}
+}
+
+func (_c *DeviceRepository_HasDevices_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *DeviceRepository_HasDevices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *DeviceRepository_HasDevices_Call) Return(b bool, err error) *DeviceRepository_HasDevices_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *DeviceRepository_HasDevices_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (bool, error)) *DeviceRepository_HasDevices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDevice provides a mock function for the type DeviceRepository
+func (_mock *DeviceRepository) SaveDevice(ctx context.Context, d *user.Device) error {
+	ret := _mock.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *user.Device) error); ok {
+		r0 = returnFunc(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// DeviceRepository_SaveDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDevice'
+type DeviceRepository_SaveDevice_Call struct {
+	*mock.Call
+}
+
+// SaveDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *user.Device
+func (_e *DeviceRepository_Expecter) SaveDevice(ctx interface{}, d interface{}) *DeviceRepository_SaveDevice_Call {
+	return &DeviceRepository_SaveDevice_Call{Call: _e.mock.On("SaveDevice", ctx, d)}
+}
+
+func (_c *DeviceRepository_SaveDevice_Call) Run(run func(ctx context.Context, d *user.Device)) *DeviceRepository_SaveDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *user.Device
+		if args[1] != nil {
+			arg1 = args[1].(*user.Device)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *DeviceRepository_SaveDevice_Call) Return(err error) *DeviceRepository_SaveDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *DeviceRepository_SaveDevice_Call) RunAndReturn(run func(ctx context.Context, d *user.Device) error) *DeviceRepository_SaveDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
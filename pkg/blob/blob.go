@@ -0,0 +1,26 @@
+// Package blob implements stores of binary objects addressed by slash separated keys: a local directory and
+// a bucket of an S3-compatible storage. Objects are opened as io.ReadSeekCloser, so they can be served with
+// range requests.
+package blob
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// validKey reports whether the key is a relative slash separated path without empty, dot and dot-dot elements.
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for _, elem := range strings.Split(key, "/") {
+		if elem == "" || elem == "." || elem == ".." || strings.ContainsRune(elem, '\\') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS stores objects as files of the directory, the key is the path of the file relative to the directory.
+type FS struct {
+	dir string
+}
+
+// NewFS creates a store keeping objects in the directory, the directory is created if missing.
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create blob dir: %w", err)
+	}
+
+	return &FS{dir: dir}, nil
+}
+
+// Put writes the object to a temporary file renamed to the key once complete, so readers never see partial objects.
+func (s *FS) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+	defer os.Remove(f.Name()) // nolint: errcheck
+
+	_, err = io.Copy(f, r)
+	if err = errors.Join(err, f.Close()); err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Open opens the file of the object for reading.
+func (s *FS) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("open blob %s: %w", key, err)
+	}
+
+	return f, nil
+}
+
+// Delete removes the file of the object, deleting missing objects succeeds.
+func (s *FS) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete blob %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// path returns the path of the file of the object.
+func (s *FS) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,275 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// unsignedPayload is the payload hash of requests with bodies not covered by the signature.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config holds the location and the credentials of the bucket.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3 stores objects in a bucket of an S3-compatible storage, addressed path-style as MinIO and most other
+// implementations expect. Requests are signed with AWS Signature Version 4.
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// s3Object reads the object lazily with range requests starting at the current offset, so seeking is free.
+type s3Object struct {
+	ctx    context.Context
+	store  *S3
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+// NewS3 creates a store keeping objects in the bucket, requests are made with the client.
+func NewS3(cfg S3Config, client *http.Client) (*S3, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse s3 endpoint: %w", err)
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("parse s3 endpoint: invalid url %q", cfg.Endpoint)
+	}
+
+	return &S3{cfg: cfg, endpoint: endpoint, client: client, now: time.Now}, nil
+}
+
+// Put uploads the object of the given size.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size == 0 {
+		r = http.NoBody
+	}
+
+	req, err := s.request(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	res, err := s.do(req, http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("put blob %s: %w", key, err)
+	}
+
+	return res.Body.Close()
+}
+
+// Open returns the reader of the object, the object is not downloaded until read.
+func (s *S3) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	req, err := s.request(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open blob %s: %w", key, err)
+	}
+
+	res, err := s.do(req, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("open blob %s: %w", key, err)
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	return &s3Object{ctx: ctx, store: s, key: key, size: res.ContentLength}, nil
+}
+
+// Delete removes the object, deleting missing objects succeeds.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return fmt.Errorf("delete blob %s: %w", key, err)
+	}
+
+	res, err := s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return fmt.Errorf("delete blob %s: %w", key, err)
+	}
+
+	return res.Body.Close()
+}
+
+// request creates the request of the object.
+func (s *S3) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	path := strings.TrimSuffix(s.endpoint.Path, "/") + "/" + s.cfg.Bucket + "/" + key
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL.Path = path
+	req.URL.RawPath = escape(path)
+	return req, nil
+}
+
+// do signs and sends the request, responses with other status codes than expected are errors.
+func (s *S3) do(req *http.Request, expected ...int) (*http.Response, error) {
+	s.sign(req)
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range expected {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+
+	defer res.Body.Close() // nolint: errcheck
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+	return nil, fmt.Errorf("unexpected s3 response %s: %s", res.Status, strings.TrimSpace(string(msg)))
+}
+
+// sign adds the AWS Signature Version 4 authorization of the request. The payload is not signed,
+// so bodies are streamed as they are.
+func (s *S3) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + unsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + s.cfg.SecretKey)
+	for _, part := range []string{now.Format("20060102"), s.cfg.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey,
+		scope,
+		signedHeaders,
+		hex.EncodeToString(hmacSHA256(key, stringToSign)),
+	))
+}
+
+// Read reads the object from the current offset, the range request is made on the first read after a seek.
+func (o *s3Object) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+
+	if o.body == nil {
+		req, err := o.store.request(o.ctx, http.MethodGet, o.key, nil)
+		if err != nil {
+			return 0, fmt.Errorf("read blob %s: %w", o.key, err)
+		}
+
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", o.offset))
+		res, err := o.store.do(req, http.StatusPartialContent, http.StatusOK)
+		if err != nil {
+			return 0, fmt.Errorf("read blob %s: %w", o.key, err)
+		}
+
+		if res.StatusCode == http.StatusOK && o.offset > 0 {
+			res.Body.Close() // nolint: errcheck, gosec
+			return 0, fmt.Errorf("read blob %s: range requests are not supported", o.key)
+		}
+
+		o.body = res.Body
+	}
+
+	n, err := o.body.Read(p)
+	o.offset += int64(n)
+	return n, err
+}
+
+// Seek sets the offset of the next read.
+func (o *s3Object) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset += o.size
+	}
+
+	if offset < 0 {
+		return 0, errors.New("seek blob: negative offset")
+	}
+
+	if offset != o.offset && o.body != nil {
+		o.body.Close() // nolint: errcheck, gosec
+		o.body = nil
+	}
+
+	o.offset = offset
+	return offset, nil
+}
+
+// Close closes the pending response of the object.
+func (o *s3Object) Close() error {
+	if o.body == nil {
+		return nil
+	}
+
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
+
+// escape escapes the path as AWS Signature Version 4 requires: everything but unreserved characters and slashes.
+func escape(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		unreserved := 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9'
+		if unreserved || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data.
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data)) // nolint: errcheck, gosec
+	return h.Sum(nil)
+}
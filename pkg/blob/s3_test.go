@@ -0,0 +1,343 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "eu-central-1"
+	testBucket    = "notes"
+)
+
+var testNow = time.Date(2025, 8, 3, 12, 30, 45, 0, time.UTC)
+
+// fakeS3 is an in-memory S3 server verifying the signatures of the requests.
+type fakeS3 struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string][]byte
+	ranges  []string
+	status  int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3) {
+	t.Helper()
+
+	f := &fakeS3{t: t, objects: make(map[string][]byte)}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3(S3Config{
+		Endpoint:  srv.URL + "/storage/",
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	}, srv.Client())
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return testNow }
+	return f, s
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := verifySignature(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/storage/"+testBucket+"/")
+	require.True(f.t, ok, r.URL.Path)
+
+	data, found := f.objects[key]
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		require.Equal(f.t, r.ContentLength, int64(len(body)))
+		f.objects[key] = body
+	case http.MethodHead, http.MethodGet:
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		rng := r.Header.Get("Range")
+		if r.Method == http.MethodHead || rng == "" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.Write(data) // nolint: errcheck
+			return
+		}
+
+		f.ranges = append(f.ranges, rng)
+		start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		require.NoError(f.t, err)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(data)-1, len(data)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(data[start:]) // nolint: errcheck
+	case http.MethodDelete:
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// verifySignature checks the AWS Signature Version 4 of the request as the storage computes it.
+func verifySignature(r *http.Request) error {
+	date := r.Header.Get("X-Amz-Date")
+	if date != testNow.Format("20060102T150405Z") {
+		return fmt.Errorf("unexpected date %q", date)
+	}
+
+	scope := testNow.Format("20060102") + "/" + testRegion + "/s3/aws4_request"
+	path, query, _ := strings.Cut(r.RequestURI, "?")
+	canonical := r.Method + "\n" + path + "\n" + query + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-amz-content-sha256:" + r.Header.Get("X-Amz-Content-Sha256") + "\n" +
+		"x-amz-date:" + date + "\n\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" +
+		r.Header.Get("X-Amz-Content-Sha256")
+	hash := sha256.Sum256([]byte(canonical))
+
+	sig := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{testNow.Format("20060102"), testRegion, "s3", "aws4_request", ""} {
+		if part == "" {
+			part = "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+		}
+
+		mac := hmac.New(sha256.New, sig)
+		mac.Write([]byte(part))
+		sig = mac.Sum(nil)
+	}
+
+	expected := "AWS4-HMAC-SHA256 Credential=" + testAccessKey + "/" + scope +
+		", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" + hex.EncodeToString(sig)
+	if got := r.Header.Get("Authorization"); got != expected {
+		return fmt.Errorf("signature mismatch: %q", got)
+	}
+
+	return nil
+}
+
+func TestS3_Signature(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		key         string
+		secretKey   string
+		expectedErr string
+	}{
+		{
+			name:      "plain_key",
+			key:       "notes/a/b",
+			secretKey: testSecretKey,
+		},
+		{
+			name:      "escaped_key",
+			key:       "notes/a b+c/ü~(1).txt",
+			secretKey: testSecretKey,
+		},
+		{
+			name:        "wrong_secret",
+			key:         "notes/a/b",
+			secretKey:   "wrong",
+			expectedErr: "403 Forbidden",
+		},
+		{
+			name:        "invalid_key",
+			key:         "notes/../b",
+			secretKey:   testSecretKey,
+			expectedErr: ErrInvalidKey.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, s := newFakeS3(t)
+			s.cfg.SecretKey = tc.secretKey
+
+			err := s.Put(context.Background(), tc.key, strings.NewReader("data"), 4, "text/plain")
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				require.Empty(t, f.objects)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, map[string][]byte{tc.key: []byte("data")}, f.objects)
+		})
+	}
+}
+
+func TestS3_Open(t *testing.T) {
+	t.Parallel()
+
+	content := "hello, range requests"
+
+	cases := []struct {
+		name           string
+		offset         int64
+		whence         int
+		expected       string
+		expectedRanges []string
+	}{
+		{
+			name:           "from_start",
+			expected:       content,
+			expectedRanges: []string{"bytes=0-"},
+		},
+		{
+			name:           "seek_start",
+			offset:         7,
+			whence:         io.SeekStart,
+			expected:       content[7:],
+			expectedRanges: []string{"bytes=7-"},
+		},
+		{
+			name:           "seek_end",
+			offset:         -8,
+			whence:         io.SeekEnd,
+			expected:       content[len(content)-8:],
+			expectedRanges: []string{fmt.Sprintf("bytes=%d-", len(content)-8)},
+		},
+		{
+			name:     "seek_past_end",
+			offset:   int64(len(content)),
+			whence:   io.SeekStart,
+			expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, s := newFakeS3(t)
+			f.objects["notes/a/b"] = []byte(content)
+
+			obj, err := s.Open(context.Background(), "notes/a/b")
+			require.NoError(t, err)
+			defer obj.Close() // nolint: errcheck
+
+			_, err = obj.Seek(tc.offset, tc.whence)
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(obj)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+			require.Equal(t, tc.expectedRanges, f.ranges)
+		})
+	}
+}
+
+func TestS3_OpenSeekAfterRead(t *testing.T) {
+	t.Parallel()
+
+	f, s := newFakeS3(t)
+	f.objects["notes/a/b"] = []byte("0123456789")
+
+	obj, err := s.Open(context.Background(), "notes/a/b")
+	require.NoError(t, err)
+	defer obj.Close() // nolint: errcheck
+
+	buf := make([]byte, 3)
+	_, err = io.ReadFull(obj, buf)
+	require.NoError(t, err)
+	require.Equal(t, "012", string(buf))
+
+	pos, err := obj.Seek(2, io.SeekCurrent)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), pos)
+
+	rest, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	require.Equal(t, "56789", string(rest))
+	require.Equal(t, []string{"bytes=0-", "bytes=5-"}, f.ranges)
+
+	_, err = obj.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
+
+func TestS3_OpenMissing(t *testing.T) {
+	t.Parallel()
+
+	_, s := newFakeS3(t)
+	_, err := s.Open(context.Background(), "notes/missing")
+	require.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestS3_Delete(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		objects     map[string][]byte
+		status      int
+		expectedErr bool
+	}{
+		{
+			name:    "existing",
+			objects: map[string][]byte{"notes/a/b": []byte("data")},
+		},
+		{
+			name: "missing",
+		},
+		{
+			name:        "server_error",
+			status:      http.StatusInternalServerError,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, s := newFakeS3(t)
+			for k, v := range tc.objects {
+				f.objects[k] = v
+			}
+
+			f.status = tc.status
+			err := s.Delete(context.Background(), "notes/a/b")
+			if tc.expectedErr {
+				require.ErrorContains(t, err, "500 Internal Server Error")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Empty(t, f.objects)
+		})
+	}
+}
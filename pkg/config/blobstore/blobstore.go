@@ -0,0 +1,38 @@
+package blobstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind represents the kind of the blob store keeping the files of the application.
+type Kind string
+
+const (
+	// FS keeps the files in a local directory.
+	FS = "fs"
+	// S3 keeps the files in a bucket of an S3-compatible storage.
+	S3 = "s3"
+)
+
+// UnmarshalText parses the input byte slice and assigns the corresponding Kind value, returning an error if invalid.
+func (k *Kind) UnmarshalText(kind []byte) error {
+	val, err := ParseKind(string(kind))
+	if err != nil {
+		return err
+	}
+
+	*k = val
+	return nil
+}
+
+// ParseKind parses a string and returns it as a Kind type if it matches predefined kinds, otherwise it returns an error.
+func ParseKind(kind string) (Kind, error) {
+	kind = strings.ToLower(kind)
+	switch kind {
+	case FS, S3:
+		return Kind(kind), nil
+	default:
+		return "", fmt.Errorf("unknown blob store: %s", kind)
+	}
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind represents the kind of the notifier delivering notifications to the users.
+type Kind string
+
+const (
+	// Inbox keeps the notifications in the in-app inbox of the user.
+	Inbox = "inbox"
+	// Webhook publishes the notifications as events delivered to the webhooks of the user.
+	Webhook = "webhook"
+	// Email sends the notifications to the email address of the user.
+	Email = "email"
+)
+
+// UnmarshalText parses the input byte slice and assigns the corresponding Kind value, returning an error if invalid.
+func (k *Kind) UnmarshalText(kind []byte) error {
+	val, err := ParseKind(string(kind))
+	if err != nil {
+		return err
+	}
+
+	*k = val
+	return nil
+}
+
+// ParseKind parses a string and returns it as a Kind type if it matches predefined kinds, otherwise it returns an error.
+func ParseKind(kind string) (Kind, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	switch kind {
+	case Inbox, Webhook, Email:
+		return Kind(kind), nil
+	default:
+		return "", fmt.Errorf("unknown notifier: %s", kind)
+	}
+}
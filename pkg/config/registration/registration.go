@@ -0,0 +1,40 @@
+package registration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mode represents the registration mode of the application, defining who is allowed to sign up.
+type Mode string
+
+const (
+	// Open allows anyone to sign up.
+	Open = "open"
+	// InviteOnly allows signing up only with a valid invitation code.
+	InviteOnly = "invite_only"
+	// Closed disallows signing up.
+	Closed = "closed"
+)
+
+// UnmarshalText parses the input byte slice and assigns the corresponding Mode value, returning an error if invalid.
+func (m *Mode) UnmarshalText(mode []byte) error {
+	val, err := ParseMode(string(mode))
+	if err != nil {
+		return err
+	}
+
+	*m = val
+	return nil
+}
+
+// ParseMode parses a string and returns it as a Mode type if it matches predefined modes; otherwise, it returns an error.
+func ParseMode(mode string) (Mode, error) {
+	mode = strings.ToLower(mode)
+	switch mode {
+	case Open, InviteOnly, Closed:
+		return Mode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown registration mode: %s", mode)
+	}
+}
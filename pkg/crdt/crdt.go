@@ -0,0 +1,303 @@
+// Package crdt implements the replicated growable array (RGA), a sequence CRDT of text characters.
+//
+// Every character is an element identified by a Lamport clock and the site of the replica which inserted it, and
+// remembers its origin, the element it was inserted after. Deleted elements stay as tombstones, so replicas applying
+// the same operations in any causal order converge to the same text.
+package crdt
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// OpKind is the kind of the operation.
+type OpKind string
+
+const (
+	OpInsert OpKind = "insert"
+	OpDelete OpKind = "delete"
+)
+
+// maxPending limits the operations waiting for their dependencies, the oldest ones are dropped beyond it.
+const maxPending = 10000
+
+var ErrInvalidOp = errors.New("invalid crdt operation")
+
+// ID identifies an element. The zero ID stands for the head of the document.
+type ID struct {
+	Clock uint64 `json:"clock"`
+	Site  string `json:"site"`
+}
+
+// Op is an operation on the document. Insert adds the element with the ID and the value after the origin,
+// delete removes the element with the ID.
+type Op struct {
+	Kind   OpKind `json:"kind"`
+	ID     ID     `json:"id"`
+	Origin ID     `json:"origin,omitzero"`
+	Value  string `json:"value,omitempty"`
+}
+
+// Element is an element of the document in the document order.
+type Element struct {
+	ID      ID     `json:"id"`
+	Origin  ID     `json:"origin,omitzero"`
+	Value   string `json:"value"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// Doc is a text document. It is not safe for concurrent use.
+type Doc struct {
+	elems   []*Element
+	index   map[ID]*Element
+	pending []Op
+	clock   uint64
+	length  int
+}
+
+// New returns an empty document.
+func New() *Doc {
+	return &Doc{index: make(map[ID]*Element)}
+}
+
+// FromText returns the document of the text inserted by the site. The same text and site give the same elements,
+// so replicas may build the initial document independently.
+func FromText(site string, text string) *Doc {
+	d := New()
+	origin := ID{}
+	for _, r := range text {
+		id := ID{Clock: d.clock + 1, Site: site}
+		d.Apply(Op{Kind: OpInsert, ID: id, Origin: origin, Value: string(r)})
+		origin = id
+	}
+
+	return d
+}
+
+// FromElements returns the document of the elements in the document order, as returned by Elements.
+func FromElements(elems []Element) (*Doc, error) {
+	d := New()
+	if err := d.Merge(elems); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// IsZero reports whether the ID is the head of the document.
+func (id ID) IsZero() bool {
+	return id == ID{}
+}
+
+// Less reports whether the ID precedes the other one, ordering by the clock and then by the site.
+func (id ID) Less(other ID) bool {
+	if id.Clock != other.Clock {
+		return id.Clock < other.Clock
+	}
+
+	return id.Site < other.Site
+}
+
+// String returns the ID in the clock@site form.
+func (id ID) String() string {
+	return fmt.Sprintf("%d@%s", id.Clock, id.Site)
+}
+
+// Validate checks the operation is well-formed. Inserted elements must have greater clocks than their origins.
+func (op *Op) Validate() error {
+	if op.ID.IsZero() || op.ID.Clock == 0 {
+		return fmt.Errorf("%w: element id is required", ErrInvalidOp)
+	}
+
+	switch op.Kind {
+	case OpInsert:
+		if utf8.RuneCountInString(op.Value) != 1 {
+			return fmt.Errorf("%w: inserted value must be a single character", ErrInvalidOp)
+		}
+
+		if op.Origin.Clock >= op.ID.Clock {
+			return fmt.Errorf("%w: element %s must follow its origin %s", ErrInvalidOp, op.ID, op.Origin)
+		}
+	case OpDelete:
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidOp, op.Kind)
+	}
+
+	return nil
+}
+
+// Apply integrates the operations and returns the ones which changed the document. Operations already applied are
+// skipped, operations which depend on unknown elements wait until the elements are inserted.
+func (d *Doc) Apply(ops ...Op) []Op {
+	var applied []Op
+	for _, op := range ops {
+		if op.Validate() != nil {
+			continue
+		}
+
+		switch d.integrate(op) {
+		case integrated:
+			applied = append(applied, op)
+		case missing:
+			d.pending = append(d.pending, op)
+			if len(d.pending) > maxPending {
+				d.pending = slices.Delete(d.pending, 0, len(d.pending)-maxPending)
+			}
+		}
+	}
+
+	if len(applied) > 0 && len(d.pending) > 0 {
+		applied = append(applied, d.retryPending()...)
+	}
+
+	return applied
+}
+
+// Merge integrates the elements of another replica of the document, given in its document order.
+// It returns ErrInvalidOp when an element refers to an origin which is neither known nor merged before it.
+func (d *Doc) Merge(elems []Element) error {
+	for _, e := range elems {
+		op := Op{Kind: OpInsert, ID: e.ID, Origin: e.Origin, Value: e.Value}
+		if err := op.Validate(); err != nil {
+			return err
+		}
+
+		if d.integrate(op) == missing {
+			return fmt.Errorf("%w: unknown origin %s of element %s", ErrInvalidOp, e.Origin, e.ID)
+		}
+
+		if e.Deleted {
+			d.integrate(Op{Kind: OpDelete, ID: e.ID})
+		}
+	}
+
+	if len(d.pending) > 0 {
+		d.retryPending()
+	}
+
+	return nil
+}
+
+// Elements returns copies of the elements in the document order, tombstones included.
+func (d *Doc) Elements() []Element {
+	elems := make([]Element, len(d.elems))
+	for i, e := range d.elems {
+		elems[i] = *e
+	}
+
+	return elems
+}
+
+// Text returns the text of the document.
+func (d *Doc) Text() string {
+	var sb strings.Builder
+	for _, e := range d.elems {
+		if !e.Deleted {
+			sb.WriteString(e.Value)
+		}
+	}
+
+	return sb.String()
+}
+
+// Len returns the number of characters of the text.
+func (d *Doc) Len() int {
+	return d.length
+}
+
+// Clock returns the greatest clock seen by the document. Sites insert elements with greater clocks.
+func (d *Doc) Clock() uint64 {
+	return d.clock
+}
+
+// Has reports whether the element is in the document, deleted or not. The head of the document is always present.
+func (d *Doc) Has(id ID) bool {
+	if id.IsZero() {
+		return true
+	}
+
+	_, ok := d.index[id]
+	return ok
+}
+
+// integration is the outcome of integrating an operation.
+type integration int
+
+const (
+	integrated integration = iota
+	skipped
+	missing
+)
+
+// integrate applies the single operation.
+func (d *Doc) integrate(op Op) integration {
+	switch op.Kind {
+	case OpInsert:
+		if d.Has(op.ID) {
+			return skipped
+		}
+
+		pos := 0
+		if !op.Origin.IsZero() {
+			origin, ok := d.index[op.Origin]
+			if !ok {
+				return missing
+			}
+
+			pos = slices.Index(d.elems, origin) + 1
+		}
+
+		// elements inserted concurrently after the same origin are ordered by descending ids,
+		// and elements inserted after them have greater clocks, so they are skipped as well
+		for pos < len(d.elems) && op.ID.Less(d.elems[pos].ID) {
+			pos++
+		}
+
+		e := &Element{ID: op.ID, Origin: op.Origin, Value: op.Value}
+		d.elems = slices.Insert(d.elems, pos, e)
+		d.index[op.ID] = e
+		d.clock = max(d.clock, op.ID.Clock)
+		d.length++
+		return integrated
+	case OpDelete:
+		e, ok := d.index[op.ID]
+		if !ok {
+			return missing
+		}
+
+		if e.Deleted {
+			return skipped
+		}
+
+		e.Deleted = true
+		d.length--
+		return integrated
+	}
+
+	return skipped
+}
+
+// retryPending integrates the waiting operations until none of them can be integrated.
+func (d *Doc) retryPending() []Op {
+	var applied []Op
+	for progress := true; progress; {
+		progress = false
+		pending := d.pending[:0]
+		for _, op := range d.pending {
+			switch d.integrate(op) {
+			case integrated:
+				applied = append(applied, op)
+				progress = true
+			case missing:
+				pending = append(pending, op)
+			}
+		}
+
+		d.pending = pending
+	}
+
+	return applied
+}
@@ -0,0 +1,237 @@
+package crdt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoc_Apply(t *testing.T) {
+	t.Parallel()
+
+	a1 := ID{Clock: 1, Site: "a"}
+	a2 := ID{Clock: 2, Site: "a"}
+	b2 := ID{Clock: 2, Site: "b"}
+	b3 := ID{Clock: 3, Site: "b"}
+
+	cases := []struct {
+		name            string
+		ops             []Op
+		expectedText    string
+		expectedApplied int
+		expectedClock   uint64
+	}{
+		{
+			name: "sequential_inserts",
+			ops: []Op{
+				{Kind: OpInsert, ID: a1, Value: "h"},
+				{Kind: OpInsert, ID: a2, Origin: a1, Value: "i"},
+			},
+			expectedText:    "hi",
+			expectedApplied: 2,
+			expectedClock:   2,
+		},
+		{
+			name: "concurrent_inserts_ordered_by_descending_id",
+			ops: []Op{
+				{Kind: OpInsert, ID: a1, Value: "x"},
+				{Kind: OpInsert, ID: a2, Origin: a1, Value: "a"},
+				{Kind: OpInsert, ID: b2, Origin: a1, Value: "b"},
+			},
+			expectedText:    "xba",
+			expectedApplied: 3,
+			expectedClock:   2,
+		},
+		{
+			name: "insert_after_concurrent_sibling_stays_with_it",
+			ops: []Op{
+				{Kind: OpInsert, ID: a1, Value: "x"},
+				{Kind: OpInsert, ID: b2, Origin: a1, Value: "b"},
+				{Kind: OpInsert, ID: b3, Origin: b2, Value: "c"},
+				{Kind: OpInsert, ID: a2, Origin: a1, Value: "a"},
+			},
+			expectedText:    "xbca",
+			expectedApplied: 4,
+			expectedClock:   3,
+		},
+		{
+			name: "delete",
+			ops: []Op{
+				{Kind: OpInsert, ID: a1, Value: "h"},
+				{Kind: OpInsert, ID: a2, Origin: a1, Value: "i"},
+				{Kind: OpDelete, ID: a1},
+			},
+			expectedText:    "i",
+			expectedApplied: 3,
+			expectedClock:   2,
+		},
+		{
+			name: "duplicates_skipped",
+			ops: []Op{
+				{Kind: OpInsert, ID: a1, Value: "h"},
+				{Kind: OpInsert, ID: a1, Value: "h"},
+				{Kind: OpDelete, ID: a1},
+				{Kind: OpDelete, ID: a1},
+			},
+			expectedText:    "",
+			expectedApplied: 2,
+			expectedClock:   1,
+		},
+		{
+			name: "out_of_order_ops_wait_for_dependencies",
+			ops: []Op{
+				{Kind: OpDelete, ID: a2},
+				{Kind: OpInsert, ID: a2, Origin: a1, Value: "i"},
+				{Kind: OpInsert, ID: a1, Value: "h"},
+			},
+			expectedText:    "h",
+			expectedApplied: 3,
+			expectedClock:   2,
+		},
+		{
+			name: "invalid_ops_ignored",
+			ops: []Op{
+				{Kind: OpInsert, ID: a1, Value: "ab"},
+				{Kind: OpInsert, ID: a1, Origin: a2, Value: "a"},
+				{Kind: OpInsert, Value: "a"},
+				{Kind: "move", ID: a1},
+			},
+			expectedText:    "",
+			expectedApplied: 0,
+			expectedClock:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := New()
+			applied := d.Apply(tc.ops...)
+
+			require.Len(t, applied, tc.expectedApplied)
+			require.Equal(t, tc.expectedText, d.Text())
+			require.Equal(t, len([]rune(tc.expectedText)), d.Len())
+			require.Equal(t, tc.expectedClock, d.Clock())
+		})
+	}
+}
+
+func TestDoc_Converge(t *testing.T) {
+	t.Parallel()
+
+	base := FromText("s", "ac")
+	origin := base.Elements()[0].ID
+	last := base.Elements()[1].ID
+
+	ops := []Op{
+		{Kind: OpInsert, ID: ID{Clock: 3, Site: "a"}, Origin: origin, Value: "b"},
+		{Kind: OpInsert, ID: ID{Clock: 3, Site: "b"}, Origin: origin, Value: "B"},
+		{Kind: OpInsert, ID: ID{Clock: 4, Site: "b"}, Origin: ID{Clock: 3, Site: "b"}, Value: "!"},
+		{Kind: OpDelete, ID: last},
+	}
+
+	orders := [][]int{
+		{0, 1, 2, 3},
+		{3, 2, 1, 0},
+		{2, 0, 3, 1},
+		{1, 3, 0, 2},
+	}
+
+	var expected string
+	for i, order := range orders {
+		d, err := FromElements(base.Elements())
+		require.NoError(t, err)
+
+		for _, j := range order {
+			d.Apply(ops[j])
+		}
+
+		if i == 0 {
+			expected = d.Text()
+			continue
+		}
+
+		require.Equal(t, expected, d.Text(), "order %v", order)
+	}
+
+	require.Equal(t, "aB!b", expected)
+}
+
+func TestDoc_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		local        *Doc
+		remote       func() []Element
+		expectedText string
+		expectedErr  error
+	}{
+		{
+			name:  "remote_edits",
+			local: FromText("s", "abc"),
+			remote: func() []Element {
+				d := FromText("s", "abc")
+				elems := d.Elements()
+				d.Apply(
+					Op{Kind: OpInsert, ID: ID{Clock: 4, Site: "r"}, Origin: elems[2].ID, Value: "d"},
+					Op{Kind: OpDelete, ID: elems[0].ID},
+				)
+				return d.Elements()
+			},
+			expectedText: "bcd",
+		},
+		{
+			name:  "idempotent",
+			local: FromText("s", "abc"),
+			remote: func() []Element {
+				return FromText("s", "abc").Elements()
+			},
+			expectedText: "abc",
+		},
+		{
+			name:  "unknown_origin",
+			local: New(),
+			remote: func() []Element {
+				return []Element{{ID: ID{Clock: 2, Site: "r"}, Origin: ID{Clock: 1, Site: "r"}, Value: "x"}}
+			},
+			expectedErr: ErrInvalidOp,
+		},
+		{
+			name:  "invalid_element",
+			local: New(),
+			remote: func() []Element {
+				return []Element{{ID: ID{Clock: 1, Site: "r"}, Value: "xy"}}
+			},
+			expectedErr: ErrInvalidOp,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.local.Merge(tc.remote())
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedText, tc.local.Text())
+		})
+	}
+}
+
+func TestFromText(t *testing.T) {
+	t.Parallel()
+
+	a := FromText("s", "héllo")
+	b := FromText("s", "héllo")
+
+	require.Equal(t, "héllo", a.Text())
+	require.Equal(t, 5, a.Len())
+	require.Equal(t, uint64(5), a.Clock())
+	require.Equal(t, a.Elements(), b.Elements())
+}
@@ -0,0 +1,186 @@
+package diff3
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Conflict is a region of the base changed differently by both sides.
+type Conflict struct {
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+// resolver merges a region changed by both sides, it reports whether the region is merged.
+type resolver func(base, ours, theirs []string) ([]string, bool)
+
+// Merge merges the changes made to the base by both sides line by line. Lines changed by both sides
+// are merged word by word; regions which still overlap are conflicts, our side of them is kept in the result.
+func Merge(base, ours, theirs string) (string, []Conflict) {
+	var conflicts []Conflict
+	merged := merge(lines(base), lines(ours), lines(theirs), func(b, o, t []string) ([]string, bool) {
+		words := merge(
+			words(strings.Join(b, "")),
+			words(strings.Join(o, "")),
+			words(strings.Join(t, "")),
+			nil,
+		)
+		if words != nil {
+			return words, true
+		}
+
+		conflicts = append(conflicts, Conflict{
+			Base:   strings.Join(b, ""),
+			Ours:   strings.Join(o, ""),
+			Theirs: strings.Join(t, ""),
+		})
+		return o, false
+	})
+
+	return strings.Join(merged, ""), conflicts
+}
+
+// merge merges the tokens of both sides. Regions changed by both sides are passed to resolve,
+// merge returns nil when a region is not resolved.
+func merge(base, ours, theirs []string, resolve resolver) []string {
+	ma := matches(base, ours)
+	mb := matches(base, theirs)
+
+	merged := make([]string, 0, max(len(ours), len(theirs)))
+	clean := true
+	i, a, b := 0, 0, 0
+	for {
+		// the next base token kept by both sides ends the region changed since the current position
+		m := i
+		for m < len(base) && (ma[m] < 0 || mb[m] < 0) {
+			m++
+		}
+
+		endA, endB := len(ours), len(theirs)
+		if m < len(base) {
+			endA, endB = ma[m], mb[m]
+		}
+
+		if m == i && endA == a && endB == b {
+			if m == len(base) {
+				break
+			}
+
+			merged = append(merged, base[i])
+			i, a, b = i+1, a+1, b+1
+			continue
+		}
+
+		region, o, t := base[i:m], ours[a:endA], theirs[b:endB]
+		switch {
+		case slices.Equal(o, region):
+			merged = append(merged, t...)
+		case slices.Equal(t, region), slices.Equal(o, t):
+			merged = append(merged, o...)
+		default:
+			var resolved []string
+			ok := false
+			if resolve != nil {
+				resolved, ok = resolve(region, o, t)
+			}
+
+			clean = clean && ok
+			merged = append(merged, resolved...)
+		}
+
+		i, a, b = m, endA, endB
+	}
+
+	if !clean && resolve == nil {
+		return nil
+	}
+
+	return merged
+}
+
+// matches returns for each token of a the index of the same token of b in their longest common subsequence,
+// or -1 for tokens missing in b. The common prefix and suffix are matched first to keep the quadratic part small.
+func matches(a, b []string) []int {
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		m[prefix] = prefix
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		m[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	ra, rb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(ra) == 0 || len(rb) == 0 {
+		return m
+	}
+
+	// lcs[i*w+j] is the length of the longest common subsequence of ra[i:] and rb[j:]
+	w := len(rb) + 1
+	lcs := make([]int32, (len(ra)+1)*w)
+	for i := len(ra) - 1; i >= 0; i-- {
+		for j := len(rb) - 1; j >= 0; j-- {
+			if ra[i] == rb[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else {
+				lcs[i*w+j] = max(lcs[(i+1)*w+j], lcs[i*w+j+1])
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(ra) && j < len(rb); {
+		switch {
+		case ra[i] == rb[j]:
+			m[prefix+i] = prefix + j
+			i, j = i+1, j+1
+		case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return m
+}
+
+// lines splits the text into lines keeping the line breaks.
+func lines(s string) []string {
+	res := strings.SplitAfter(s, "\n")
+	if res[len(res)-1] == "" {
+		res = res[:len(res)-1]
+	}
+
+	return res
+}
+
+// words splits the text into runs of spaces and runs of other characters.
+func words(s string) []string {
+	var res []string
+	start, space := 0, false
+	for i, r := range s {
+		if i > start && unicode.IsSpace(r) != space {
+			res = append(res, s[start:i])
+			start = i
+		}
+
+		if i == start {
+			space = unicode.IsSpace(r)
+		}
+	}
+
+	if start < len(s) {
+		res = append(res, s[start:])
+	}
+
+	return res
+}
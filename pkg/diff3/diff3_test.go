@@ -0,0 +1,136 @@
+package diff3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name              string
+		base              string
+		ours              string
+		theirs            string
+		expected          string
+		expectedConflicts []Conflict
+	}{
+		{
+			name:     "unchanged",
+			base:     "a\nb\n",
+			ours:     "a\nb\n",
+			theirs:   "a\nb\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:     "changed_by_ours",
+			base:     "a\nb\nc\n",
+			ours:     "a\nB\nc\n",
+			theirs:   "a\nb\nc\n",
+			expected: "a\nB\nc\n",
+		},
+		{
+			name:     "changed_by_theirs",
+			base:     "a\nb\nc\n",
+			ours:     "a\nb\nc\n",
+			theirs:   "a\nb\nC\n",
+			expected: "a\nb\nC\n",
+		},
+		{
+			name:     "different_lines",
+			base:     "a\nb\nc\n",
+			ours:     "A\nb\nc\n",
+			theirs:   "a\nb\nC\n",
+			expected: "A\nb\nC\n",
+		},
+		{
+			name:     "same_change",
+			base:     "a\nb\nc\n",
+			ours:     "a\nX\nc\n",
+			theirs:   "a\nX\nc\n",
+			expected: "a\nX\nc\n",
+		},
+		{
+			name:     "deleted_and_added",
+			base:     "a\nb\nc\n",
+			ours:     "a\nc\n",
+			theirs:   "a\nb\nc\nd\n",
+			expected: "a\nc\nd\n",
+		},
+		{
+			name:     "different_words_of_line",
+			base:     "the quick fox\n",
+			ours:     "the slow fox\n",
+			theirs:   "the quick dog\n",
+			expected: "the slow dog\n",
+		},
+		{
+			name:     "missing_line_break",
+			base:     "a",
+			ours:     "a\nb",
+			theirs:   "a",
+			expected: "a\nb",
+		},
+		{
+			name:              "same_word_changed",
+			base:              "a b\n",
+			ours:              "a c\n",
+			theirs:            "a d\n",
+			expected:          "a c\n",
+			expectedConflicts: []Conflict{{Base: "a b\n", Ours: "a c\n", Theirs: "a d\n"}},
+		},
+		{
+			name:              "both_added_to_empty",
+			base:              "",
+			ours:              "x",
+			theirs:            "y",
+			expected:          "x",
+			expectedConflicts: []Conflict{{Base: "", Ours: "x", Theirs: "y"}},
+		},
+		{
+			name:     "conflict_kept_apart_from_merged_lines",
+			base:     "a\nb\nc\n",
+			ours:     "A\nb\nours\n",
+			theirs:   "a\nb\ntheirs\n",
+			expected: "A\nb\nours\n",
+			expectedConflicts: []Conflict{
+				{Base: "c\n", Ours: "ours\n", Theirs: "theirs\n"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, conflicts := Merge(tc.base, tc.ours, tc.theirs)
+			require.Equal(t, tc.expected, merged)
+			require.Equal(t, tc.expectedConflicts, conflicts)
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "empty", text: "", expected: nil},
+		{name: "words", text: "ab  cd\n", expected: []string{"ab", "  ", "cd", "\n"}},
+		{name: "leading_space", text: " ab", expected: []string{" ", "ab"}},
+		{name: "multibyte", text: "héllo wörld", expected: []string{"héllo", " ", "wörld"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, words(tc.text))
+		})
+	}
+}
@@ -1,15 +1,27 @@
 package errx
 
 const (
-	CodeUnknown          = "errors.unknown"
-	CodeEmailExists      = "errors.emailExists"
-	CodeBadRequest       = "errors.badRequest"
-	CodeForbidden        = "errors.forbidden"
-	CodeUnauthorized     = "errors.unauthorized"
-	CodeNotFound         = "errors.notFound"
-	CodeBodyTooLarge     = "errors.bodyTooLarge"
-	CodeValidation       = "errors.validation"
-	CodeJsonParse        = "errors.jsonParse"
-	CodeMethodNotAllowed = "errors.methodNotAllowed"
-	CodeTokenExpired     = "errors.tokenExpired" // nolint: gosec
+	CodeUnknown            = "errors.unknown"
+	CodeEmailExists        = "errors.emailExists"
+	CodeBadRequest         = "errors.badRequest"
+	CodeForbidden          = "errors.forbidden"
+	CodeUnauthorized       = "errors.unauthorized"
+	CodeNotFound           = "errors.notFound"
+	CodeBodyTooLarge       = "errors.bodyTooLarge"
+	CodeValidation         = "errors.validation"
+	CodeJsonParse          = "errors.jsonParse"
+	CodeMethodNotAllowed   = "errors.methodNotAllowed"
+	CodeTokenExpired       = "errors.tokenExpired" // nolint: gosec
+	CodeUnknownPermission  = "errors.unknownPermission"
+	CodeAlreadyMember      = "errors.alreadyMember"
+	CodeLastOwner          = "errors.lastOwner"
+	CodeRegistrationClosed = "errors.registrationClosed"
+	CodeInviteCodeRequired = "errors.inviteCodeRequired"
+	CodeInviteCodeInvalid  = "errors.inviteCodeInvalid"
+	CodeUnknownRoleLabel   = "errors.unknownRoleLabel"
+	CodeUnavailable        = "errors.unavailable"
+	CodeConflict           = "errors.conflict"
+	CodeQuotaExceeded      = "errors.quotaExceeded"
+	CodeAborted            = "errors.aborted"
+	CodeUnsupportedMedia   = "errors.unsupportedMedia"
 )
@@ -22,6 +22,8 @@ type ResponseDecorator interface {
 }
 
 // resDec is a custom implementation of http.ResponseWriter, tracking status code and number of bytes written.
+// It implements http.Flusher, reports through FlushError whether the underlying writer supports flushing and
+// unwraps to the underlying writer, so streaming responses keep working.
 type resDec struct {
 	http.ResponseWriter
 	statusCode   int
@@ -104,3 +106,21 @@ func (w *resDec) BytesWritten() int {
 func (w *resDec) StatusCode() int {
 	return w.statusCode
 }
+
+// Flush sends any buffered data to the client when the underlying ResponseWriter supports flushing.
+func (w *resDec) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// FlushError sends any buffered data to the client, returning an error wrapping http.ErrNotSupported when
+// the underlying ResponseWriter doesn't support flushing. It is preferred by http.ResponseController over Flush.
+func (w *resDec) FlushError() error {
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter, used by http.ResponseController to reach its optional interfaces.
+func (w *resDec) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
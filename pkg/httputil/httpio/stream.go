@@ -0,0 +1,47 @@
+package httpio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Stream starts the server-sent events response. It fails when the ResponseWriter doesn't support flushing.
+func Stream(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	return http.NewResponseController(w).Flush()
+}
+
+// Event writes the server-sent event with the JSON encoded data and flushes it to the client.
+// The id is omitted when empty.
+func Event(w http.ResponseWriter, id string, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if id != "" {
+		fmt.Fprintf(&buf, "id: %s\n", id)
+	}
+
+	fmt.Fprintf(&buf, "event: %s\ndata: %s\n\n", event, data)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	return http.NewResponseController(w).Flush()
+}
+
+// Comment writes the server-sent events comment, which keeps the connection alive, and flushes it to the client.
+func Comment(w http.ResponseWriter, text string) error {
+	if _, err := fmt.Fprintf(w, ": %s\n\n", text); err != nil {
+		return err
+	}
+
+	return http.NewResponseController(w).Flush()
+}
@@ -0,0 +1,63 @@
+package httpio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// plainWriter is a ResponseWriter which doesn't support flushing.
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		writer      func(rec *httptest.ResponseRecorder) http.ResponseWriter
+		expectedErr error
+	}{
+		{
+			name:   "flushed",
+			writer: func(rec *httptest.ResponseRecorder) http.ResponseWriter { return rec },
+		},
+		{
+			name:   "flushed_through_decorator",
+			writer: func(rec *httptest.ResponseRecorder) http.ResponseWriter { return NewResponseDecorator(rec) },
+		},
+		{
+			name:        "flushing_not_supported",
+			writer:      func(rec *httptest.ResponseRecorder) http.ResponseWriter { return plainWriter{rec} },
+			expectedErr: http.ErrNotSupported,
+		},
+		{
+			name: "flushing_not_supported_through_decorator",
+			writer: func(rec *httptest.ResponseRecorder) http.ResponseWriter {
+				return NewResponseDecorator(plainWriter{rec})
+			},
+			expectedErr: http.ErrNotSupported,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			err := Stream(tc.writer(rec))
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.False(t, rec.Flushed)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, rec.Flushed)
+			require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		})
+	}
+}
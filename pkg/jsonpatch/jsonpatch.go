@@ -0,0 +1,356 @@
+// Package jsonpatch applies JSON Merge Patch (RFC 7396) and JSON Patch (RFC 6902) documents to JSON documents.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid json patch")
+	ErrTestFailed   = errors.New("json patch test failed")
+)
+
+// errPathNotFound is returned for paths of operations missing in the document.
+var errPathNotFound = fmt.Errorf("%w: path is not found", ErrInvalidPatch)
+
+// operation is an operation of a JSON Patch document. Value is kept raw, so a null value is told from no value.
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MergePatch applies the JSON Merge Patch to the document and returns the patched document. Members of the patch
+// replace the members of the document, null members remove them and objects are merged recursively.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, p any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("merge patch: %w", err)
+	}
+
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("merge patch: %w: %w", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(mergePatch(target, p))
+}
+
+// Apply applies the operations of the JSON Patch to the document in order and returns the patched document.
+// Nothing is applied if any operation fails, failed test operations return ErrTestFailed.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("apply json patch: %w", err)
+	}
+
+	var ops []operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("apply json patch: %w: %w", ErrInvalidPatch, err)
+	}
+
+	for i := range ops {
+		var err error
+		if target, err = apply(target, &ops[i]); err != nil {
+			return nil, fmt.Errorf("apply json patch: %w (operation %d)", err, i)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+// mergePatch returns the target merged with the patch.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+
+		t[k] = mergePatch(t[k], v)
+	}
+
+	return t
+}
+
+// apply applies the operation to the document and returns the document.
+func apply(doc any, op *operation) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: %s without path", ErrInvalidPatch, op.Op)
+	}
+
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		value, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "test" {
+			return doc, test(doc, path, value)
+		}
+
+		return set(doc, path, value, op.Op == "add")
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: %s without from", ErrInvalidPatch, op.Op)
+		}
+
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		if op.Op == "copy" {
+			if value, err = get(doc, from); err != nil {
+				return nil, err
+			}
+
+			if value, err = deepCopy(value); err != nil {
+				return nil, err
+			}
+		} else {
+			if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+				return nil, fmt.Errorf("%w: %q is moved into itself", ErrInvalidPatch, *op.From)
+			}
+
+			if doc, value, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		}
+
+		return set(doc, path, value, true)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// value decodes the value of the operation.
+func (op *operation) value() (any, error) {
+	if op.Value == nil {
+		return nil, fmt.Errorf("%w: %s without value", ErrInvalidPatch, op.Op)
+	}
+
+	var v any
+	if err := json.Unmarshal(op.Value, &v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	return v, nil
+}
+
+// test compares the value at the path with the expected value.
+func test(doc any, path []string, expected any) error {
+	v, err := get(doc, path)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(v, expected) {
+		return ErrTestFailed
+	}
+
+	return nil
+}
+
+// get returns the value at the path.
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := doc.(type) {
+		case map[string]any:
+			v, ok := n[token]
+			if !ok {
+				return nil, errPathNotFound
+			}
+
+			doc = v
+		case []any:
+			idx, err := index(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+
+			doc = n[idx]
+		default:
+			return nil, errPathNotFound
+		}
+	}
+
+	return doc, nil
+}
+
+// set sets the value at the path and returns the document. Added values are inserted into arrays, replaced values
+// must exist.
+func set(doc any, path []string, value any, add bool) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token, last := path[0], len(path) == 1
+	switch n := doc.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok && (!last || !add) {
+			return nil, errPathNotFound
+		}
+
+		if last {
+			n[token] = value
+			return n, nil
+		}
+
+		v, err := set(child, path[1:], value, add)
+		if err != nil {
+			return nil, err
+		}
+
+		n[token] = v
+		return n, nil
+	case []any:
+		idx, err := index(token, len(n), last && add)
+		if err != nil {
+			return nil, err
+		}
+
+		if last && add {
+			return slices.Insert(n, idx, value), nil
+		}
+
+		if last {
+			n[idx] = value
+			return n, nil
+		}
+
+		v, err := set(n[idx], path[1:], value, add)
+		if err != nil {
+			return nil, err
+		}
+
+		n[idx] = v
+		return n, nil
+	default:
+		return nil, errPathNotFound
+	}
+}
+
+// remove removes the value at the path and returns the document and the removed value.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: the document root can not be removed", ErrInvalidPatch)
+	}
+
+	token, last := path[0], len(path) == 1
+	switch n := doc.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, errPathNotFound
+		}
+
+		if last {
+			delete(n, token)
+			return n, child, nil
+		}
+
+		v, removed, err := remove(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		n[token] = v
+		return n, removed, nil
+	case []any:
+		idx, err := index(token, len(n), false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if last {
+			removed := n[idx]
+			return slices.Delete(n, idx, idx+1), removed, nil
+		}
+
+		v, removed, err := remove(n[idx], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		n[idx] = v
+		return n, removed, nil
+	default:
+		return nil, nil, errPathNotFound
+	}
+}
+
+// index parses the array index of the token. The index past the last element, written as "-" or as the length
+// of the array, is allowed for insertions only.
+func index(token string, length int, insert bool) (int, error) {
+	if token == "-" && insert {
+		return length, nil
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+
+	if idx > length || (idx == length && !insert) {
+		return 0, fmt.Errorf("%w: array index %d is out of range", ErrInvalidPatch, idx)
+	}
+
+	return idx, nil
+}
+
+// parsePointer splits the JSON Pointer (RFC 6901) into its unescaped reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+
+	if p[0] != '/' {
+		return nil, fmt.Errorf("%w: invalid pointer %q", ErrInvalidPatch, p)
+	}
+
+	tokens := strings.Split(p[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// deepCopy returns a copy of the decoded JSON value sharing nothing with the value.
+func deepCopy(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var res any
+	return res, json.Unmarshal(data, &res)
+}
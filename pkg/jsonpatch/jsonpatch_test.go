@@ -0,0 +1,251 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		doc         string
+		patch       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "replace", doc: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "add", doc: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "remove", doc: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		{name: "remove_keeps_others", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "array_replaced", doc: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "value_replaced_by_array", doc: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		{
+			name:     "nested",
+			doc:      `{"a":{"b":"c"}}`,
+			patch:    `{"a":{"b":"d","c":null}}`,
+			expected: `{"a":{"b":"d"}}`,
+		},
+		{name: "arrays_not_merged", doc: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		{name: "document_replaced", doc: `["a","b"]`, patch: `["c","d"]`, expected: `["c","d"]`},
+		{name: "object_replaced", doc: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+		{name: "null_document", doc: `{"a":"foo"}`, patch: `null`, expected: `null`},
+		{name: "string_document", doc: `{"a":"foo"}`, patch: `"bar"`, expected: `"bar"`},
+		{name: "null_member_added", doc: `{"e":null}`, patch: `{"a":1}`, expected: `{"a":1,"e":null}`},
+		{name: "object_of_array", doc: `[1,2]`, patch: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+		{
+			name:     "nested_null_removed",
+			doc:      `{}`,
+			patch:    `{"a":{"bb":{"ccc":null}}}`,
+			expected: `{"a":{"bb":{}}}`,
+		},
+		{name: "invalid_patch", doc: `{}`, patch: `{"a":`, expectedErr: ErrInvalidPatch},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := MergePatch([]byte(tc.doc), []byte(tc.patch))
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(res))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		doc         string
+		patch       string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "add_member",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":"qux"}]`,
+			expected: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:     "add_array_element",
+			doc:      `{"foo":["bar","baz"]}`,
+			patch:    `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			expected: `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:     "add_to_array_end",
+			doc:      `{"foo":["bar"]}`,
+			patch:    `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`,
+			expected: `{"foo":["bar",["abc","def"]]}`,
+		},
+		{
+			name:     "add_null_value",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":null}]`,
+			expected: `{"baz":null,"foo":"bar"}`,
+		},
+		{
+			name:     "remove_member",
+			doc:      `{"baz":"qux","foo":"bar"}`,
+			patch:    `[{"op":"remove","path":"/baz"}]`,
+			expected: `{"foo":"bar"}`,
+		},
+		{
+			name:     "remove_array_element",
+			doc:      `{"foo":["bar","qux","baz"]}`,
+			patch:    `[{"op":"remove","path":"/foo/1"}]`,
+			expected: `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:     "replace",
+			doc:      `{"baz":"qux","foo":"bar"}`,
+			patch:    `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			expected: `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:     "move_member",
+			doc:      `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:     "move_array_element",
+			doc:      `{"foo":["all","grass","cows","eat"]}`,
+			patch:    `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			expected: `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:     "copy_is_deep",
+			doc:      `{"a":{"b":1}}`,
+			patch:    `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`,
+			expected: `{"a":{"b":1},"c":{"b":2}}`,
+		},
+		{
+			name:     "test_passed",
+			doc:      `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch:    `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			expected: `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			name:     "escaped_pointer",
+			doc:      `{"/":9,"~1":10}`,
+			patch:    `[{"op":"test","path":"/~01","value":10},{"op":"replace","path":"/~1","value":1}]`,
+			expected: `{"/":1,"~1":10}`,
+		},
+		{
+			name:     "replace_root",
+			doc:      `{"a":1}`,
+			patch:    `[{"op":"replace","path":"","value":[1]}]`,
+			expected: `[1]`,
+		},
+		{
+			name:        "test_failed",
+			doc:         `{"baz":"qux"}`,
+			patch:       `[{"op":"test","path":"/baz","value":"bar"}]`,
+			expectedErr: ErrTestFailed,
+		},
+		{
+			name:        "test_number_string",
+			doc:         `{"foo":1}`,
+			patch:       `[{"op":"test","path":"/foo","value":"1"}]`,
+			expectedErr: ErrTestFailed,
+		},
+		{
+			name:        "add_to_missing_parent",
+			doc:         `{"foo":"bar"}`,
+			patch:       `[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "replace_missing",
+			doc:         `{"foo":"bar"}`,
+			patch:       `[{"op":"replace","path":"/baz","value":"qux"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "array_index_out_of_range",
+			doc:         `{"foo":["bar"]}`,
+			patch:       `[{"op":"add","path":"/foo/2","value":"qux"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "array_index_leading_zero",
+			doc:         `{"foo":["bar","baz"]}`,
+			patch:       `[{"op":"remove","path":"/foo/01"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "move_into_itself",
+			doc:         `{"a":{"b":{}}}`,
+			patch:       `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "remove_root",
+			doc:         `{"a":1}`,
+			patch:       `[{"op":"remove","path":""}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "missing_value",
+			doc:         `{"a":1}`,
+			patch:       `[{"op":"add","path":"/b"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "missing_path",
+			doc:         `{"a":1}`,
+			patch:       `[{"op":"remove"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "missing_from",
+			doc:         `{"a":1}`,
+			patch:       `[{"op":"copy","path":"/b"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "unknown_operation",
+			doc:         `{"a":1}`,
+			patch:       `[{"op":"swap","path":"/a"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "invalid_pointer",
+			doc:         `{"a":1}`,
+			patch:       `[{"op":"remove","path":"a"}]`,
+			expectedErr: ErrInvalidPatch,
+		},
+		{
+			name:        "not_an_array",
+			doc:         `{"a":1}`,
+			patch:       `{"op":"remove","path":"/a"}`,
+			expectedErr: ErrInvalidPatch,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Apply([]byte(tc.doc), []byte(tc.patch))
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(res))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Package keywords extracts the most frequent words of texts and compares texts by their keywords.
+package keywords
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minWordLength is the shortest word taken for a keyword, in runes.
+const minWordLength = 3
+
+// stopWords are the frequent English words carrying no meaning of the text.
+var stopWords = map[string]struct{}{
+	"the": {}, "and": {}, "for": {}, "are": {}, "but": {}, "not": {}, "you": {}, "all": {}, "any": {}, "can": {},
+	"had": {}, "her": {}, "was": {}, "one": {}, "our": {}, "out": {}, "has": {}, "have": {}, "him": {}, "his": {},
+	"how": {}, "its": {}, "let": {}, "may": {}, "who": {}, "did": {}, "get": {}, "got": {}, "she": {}, "too": {},
+	"use": {}, "that": {}, "with": {}, "this": {}, "from": {}, "they": {}, "will": {}, "would": {}, "there": {},
+	"their": {}, "what": {}, "about": {}, "which": {}, "when": {}, "were": {}, "been": {}, "them": {}, "then": {},
+	"than": {}, "into": {}, "also": {}, "some": {}, "just": {}, "only": {}, "over": {}, "such": {}, "these": {},
+	"those": {}, "your": {}, "each": {}, "more": {}, "most": {}, "other": {}, "should": {}, "could": {}, "very": {},
+}
+
+// Extract returns up to n most frequent words of the text lower cased and sorted. Short words and stop words
+// are left out, words of the same frequency are taken in the alphabetical order.
+func Extract(text string, n int) []string {
+	counts := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(word) < minWordLength {
+			continue
+		}
+
+		if _, ok := stopWords[word]; ok {
+			continue
+		}
+
+		counts[word]++
+	}
+
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+
+	slices.SortFunc(words, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	words = words[:min(n, len(words))]
+	slices.Sort(words)
+	return words
+}
+
+// Similarity returns the Jaccard index of the sorted keywords: the share of the keywords the texts have in common
+// among the keywords of both. Texts without keywords are not similar.
+func Similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch cmp.Compare(a[i], b[j]) {
+		case 0:
+			common++
+			i++
+			j++
+		case -1:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
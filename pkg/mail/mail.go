@@ -0,0 +1,150 @@
+// Package mail sends plain text emails through an SMTP server.
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidHeader = errors.New("line break in the mail address or subject")
+
+// SMTPConfig represents the SMTP server and the sender address. Username and password are used when the username
+// is set, the connection is upgraded with STARTTLS when the server supports it.
+type SMTPConfig struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// SMTP sends emails through the SMTP server.
+type SMTP struct {
+	config SMTPConfig
+}
+
+// NewSMTP initializes and returns a new SMTP sender.
+func NewSMTP(config SMTPConfig) *SMTP {
+	return &SMTP{config: config}
+}
+
+// Send sends the plain text email to the address, the subject is encoded when it is not ASCII.
+func (s *SMTP) Send(ctx context.Context, to, subject, body string) error {
+	if s.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeout)
+		defer cancel()
+	}
+
+	msg, err := s.message(to, subject, body)
+	if err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	defer conn.Close() // nolint: errcheck
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("send mail: %w", err)
+		}
+	}
+
+	if err := s.send(conn, to, msg); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+
+	return nil
+}
+
+// send delivers the message over the connection to the SMTP server.
+func (s *SMTP) send(conn net.Conn, to string, msg []byte) error {
+	host, _, err := net.SplitHostPort(s.config.Addr)
+	if err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close() // nolint: errcheck
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if s.config.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(s.config.From); err != nil {
+		return err
+	}
+
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// message returns the email with its headers and the quoted-printable body.
+func (s *SMTP) message(to, subject, body string) ([]byte, error) {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return nil, ErrInvalidHeader
+	}
+
+	_, domain, _ := strings.Cut(s.config.From, "@")
+	var b strings.Builder
+	b.WriteString("From: " + s.config.From + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("Message-ID: <" + uuid.NewString() + "@" + domain + ">\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return []byte(b.String()), nil
+}
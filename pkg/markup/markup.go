@@ -0,0 +1,69 @@
+// Package markup renders note texts to HTML safe to embed into pages and extracts their plain text.
+//
+// Markdown is rendered as CommonMark with the GitHub Flavored Markdown extensions. Raw HTML of the source is not
+// rendered, and the output is passed through an allow-list sanitizer anyway, so links and images can not carry
+// scripts whatever the renderer produces.
+package markup
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	// policy allows the user generated content elements, including the disabled checkboxes of task lists.
+	policy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy()
+		p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+		p.AllowAttrs("checked", "disabled").OnElements("input")
+		return p
+	}()
+	// strict strips all the elements keeping their text, adjacent elements stay separated.
+	strict = func() *bluemonday.Policy {
+		p := bluemonday.StrictPolicy()
+		p.AddSpaceWhenStrippingTag(true)
+		return p
+	}()
+	// blankLines separates paragraphs of plain texts.
+	blankLines = regexp.MustCompile(`\n(?:[ \t]*\n)+`)
+)
+
+// Markdown renders the markdown source to sanitized HTML.
+func Markdown(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(src), &buf); err != nil {
+		return "", fmt.Errorf("render markdown: %w", err)
+	}
+
+	return policy.Sanitize(buf.String()), nil
+}
+
+// Plain renders the plain text to HTML, paragraphs are separated by blank lines and line breaks are kept.
+func Plain(src string) string {
+	src = strings.TrimSpace(strings.ReplaceAll(src, "\r\n", "\n"))
+	if src == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, p := range blankLines.Split(src, -1) {
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(p), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+
+	return b.String()
+}
+
+// Text returns the text of the HTML without the markup, runs of spaces are collapsed.
+func Text(src string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(strict.Sanitize(src))), " ")
+}
@@ -0,0 +1,157 @@
+package markup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "commonmark",
+			src:      "# Title\n\nSome **bold** and _em_ text.",
+			expected: "<h1>Title</h1>\n<p>Some <strong>bold</strong> and <em>em</em> text.</p>\n",
+		},
+		{
+			name:     "link",
+			src:      "[docs](https://example.com/docs)",
+			expected: "<p><a href=\"https://example.com/docs\" rel=\"nofollow\">docs</a></p>\n",
+		},
+		{
+			name: "gfm_task_list",
+			src:  "- [x] done\n- [ ] todo",
+			expected: "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> done</li>\n" +
+				"<li><input disabled=\"\" type=\"checkbox\"> todo</li>\n</ul>\n",
+		},
+		{
+			name: "gfm_table",
+			src:  "| a | b |\n|---|---|\n| 1 | 2 |",
+			expected: "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n" +
+				"<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name: "gfm_strikethrough_and_autolink",
+			src:  "~~gone~~ https://example.com",
+			expected: "<p><del>gone</del> " +
+				"<a href=\"https://example.com\" rel=\"nofollow\">https://example.com</a></p>\n",
+		},
+		{
+			name:     "script_dropped",
+			src:      "<script>alert(1)</script>\n\nafter",
+			expected: "\n<p>after</p>\n",
+		},
+		{
+			name:     "raw_html_dropped",
+			src:      "<div onclick=\"steal()\">raw</div>",
+			expected: "\n",
+		},
+		{
+			name:     "raw_image_with_handler_dropped",
+			src:      "<img src=x onerror=alert(1)>",
+			expected: "\n",
+		},
+		{
+			name:     "inline_raw_link_dropped",
+			src:      "<a href=\"javascript:alert(1)\">x</a>",
+			expected: "<p>x</p>\n",
+		},
+		{
+			name:     "javascript_link",
+			src:      "[x](javascript:alert(1))",
+			expected: "<p>x</p>\n",
+		},
+		{
+			name:     "javascript_link_mixed_case",
+			src:      "[x](JaVaScRiPt:alert(1))",
+			expected: "<p>x</p>\n",
+		},
+		{
+			name:     "data_link",
+			src:      "[x](data:text/html;base64,PHNjcmlwdD4=)",
+			expected: "<p>x</p>\n",
+		},
+		{
+			name:     "javascript_image",
+			src:      "![img](javascript:alert(1))",
+			expected: "<p><img alt=\"img\"></p>\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rendered, err := Markdown(tc.src)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rendered)
+		})
+	}
+}
+
+func TestPlain(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{name: "empty", src: " \n\t"},
+		{name: "paragraph", src: "hello", expected: "<p>hello</p>\n"},
+		{
+			name:     "line_breaks_and_blank_lines",
+			src:      "first\r\nline\n\n \nnext",
+			expected: "<p>first<br>\nline</p>\n<p>next</p>\n",
+		},
+		{
+			name:     "markup_escaped",
+			src:      `<script>alert("x")</script> & <b>`,
+			expected: "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; &lt;b&gt;</p>\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, Plain(tc.src))
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{name: "empty", src: ""},
+		{
+			name:     "elements_separated",
+			src:      "<h1>Title</h1><p>Hello&amp;<b>bold</b></p><p>next   para</p>",
+			expected: "Title Hello& bold next para",
+		},
+		{
+			name:     "script_dropped",
+			src:      "<p>before</p><script>alert(1)</script><p>after</p>",
+			expected: "before after",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, Text(tc.src))
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package notefile
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// enexRoot is the root element of ENEX exports.
+	enexRoot = "en-export"
+	// enexTimeLayout is the layout of the times of ENEX notes.
+	enexTimeLayout = "20060102T150405Z"
+	// enexEncrypted replaces encrypted parts of notes.
+	enexEncrypted = "[encrypted]"
+)
+
+var (
+	errNotENEX = errors.New("not an Evernote export")
+	// enmlBlocks are the elements of ENML content starting on a new line.
+	enmlBlocks = map[string]bool{
+		"div": true, "p": true, "br": true, "hr": true, "li": true, "tr": true, "table": true, "ul": true, "ol": true,
+		"blockquote": true, "pre": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	}
+	// enmlSkipped are the elements of ENML content whose text is not kept.
+	enmlSkipped = map[string]bool{"en-crypt": true, "script": true, "style": true, "title": true}
+	// spaces matches runs of white space collapsed in ENML texts.
+	spaces = regexp.MustCompile(`[ \t\r\n]+`)
+	// extraBlankLines matches blank lines following a blank line.
+	extraBlankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// enexNote represents a note of ENEX exports. Resources of the notes are not decoded.
+type enexNote struct {
+	Title   string `xml:"title"`
+	Content string `xml:"content"`
+	Created string `xml:"created"`
+	Updated string `xml:"updated"`
+}
+
+// ReadENEX reads the notes of the Evernote export. The ENML content of the notes is converted to plain text,
+// media are left out and lists and to-do items are kept as text.
+func ReadENEX(r io.Reader, fn Func) error {
+	d := xml.NewDecoder(r)
+	root := true
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			if root {
+				return fmt.Errorf("read enex: %w", errNotENEX)
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("read enex: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if root {
+			if start.Name.Local != enexRoot {
+				return fmt.Errorf("read enex: %w", errNotENEX)
+			}
+
+			root = false
+			continue
+		}
+
+		if start.Name.Local != "note" {
+			if err := d.Skip(); err != nil {
+				return fmt.Errorf("read enex: %w", err)
+			}
+
+			continue
+		}
+
+		var n enexNote
+		if err := d.DecodeElement(&n, &start); err != nil {
+			return fmt.Errorf("read enex: %w", err)
+		}
+
+		e, err := n.entry()
+		if err := fn(sanitize(e), err); err != nil {
+			return err
+		}
+	}
+}
+
+// entry converts the note to the entry.
+func (n *enexNote) entry() (*Entry, error) {
+	e := &Entry{Title: strings.TrimSpace(n.Title)}
+	text, err := enmlText(n.Content)
+	if err != nil {
+		return e, fmt.Errorf("content: %w", err)
+	}
+
+	e.Text = text
+	if n.Created != "" {
+		if e.CreatedAt, err = parseTime(strings.TrimSpace(n.Created), enexTimeLayout); err != nil {
+			return e, fmt.Errorf("created: %w", err)
+		}
+	}
+
+	if n.Updated != "" {
+		if e.UpdatedAt, err = parseTime(strings.TrimSpace(n.Updated), enexTimeLayout); err != nil {
+			return e, fmt.Errorf("updated: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// enmlText returns the text of the ENML content. Block elements start new lines, list items are prefixed with
+// dashes and to-do checkboxes with brackets.
+func enmlText(content string) (string, error) {
+	var b strings.Builder
+	newLine := func() {
+		if s := b.String(); s != "" && !strings.HasSuffix(s, "\n") {
+			b.WriteString("\n")
+		}
+	}
+
+	z := html.NewTokenizer(strings.NewReader(content))
+	skipped := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if errors.Is(z.Err(), io.EOF) {
+				text := extraBlankLines.ReplaceAllString(trimLines(b.String()), "\n\n")
+				return strings.TrimSpace(text), nil
+			}
+
+			return "", z.Err()
+		case html.TextToken:
+			if skipped > 0 {
+				continue
+			}
+
+			text := spaces.ReplaceAllString(string(z.Text()), " ")
+			if s := b.String(); s == "" || strings.HasSuffix(s, "\n") || strings.HasSuffix(s, " ") {
+				text = strings.TrimLeft(text, " ")
+			}
+
+			b.WriteString(text)
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			switch {
+			case enmlSkipped[tag]:
+				if tag == "en-crypt" && tt == html.StartTagToken {
+					b.WriteString(enexEncrypted)
+				}
+
+				if tt == html.StartTagToken {
+					skipped++
+				} else if tt == html.EndTagToken && skipped > 0 {
+					skipped--
+				}
+			case tag == "br":
+				b.WriteString("\n")
+			case tag == "en-todo" && tt != html.EndTagToken:
+				b.WriteString(todoMark(z, hasAttr))
+			case enmlBlocks[tag]:
+				newLine()
+				if tag == "li" && tt == html.StartTagToken {
+					b.WriteString("- ")
+				}
+			}
+		}
+	}
+}
+
+// todoMark returns the text of the to-do checkbox.
+func todoMark(z *html.Tokenizer, hasAttr bool) string {
+	for hasAttr {
+		var key, val []byte
+		key, val, hasAttr = z.TagAttr()
+		if string(key) == "checked" && strings.EqualFold(string(val), "true") {
+			return "[x] "
+		}
+	}
+
+	return "[ ] "
+}
+
+// trimLines removes trailing spaces of the lines.
+func trimLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+package notefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// errNotArray is returned for JSON documents other than arrays.
+var errNotArray = errors.New("array of notes is expected")
+
+// jsonNote represents a note of JSON exports, the name is taken for the title when the title is absent.
+type jsonNote struct {
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	Text      string    `json:"text"`
+	Format    string    `json:"format"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReadJSON reads the array of notes. Notes are objects with the name or the title, the text, the format ("plain"
+// or "markdown") and the created_at and updated_at RFC 3339 times, as the notes are returned by the API.
+func ReadJSON(r io.Reader, fn Func) error {
+	d := json.NewDecoder(r)
+	tok, err := d.Token()
+	if err != nil {
+		return fmt.Errorf("read json: %w", err)
+	}
+
+	if tok != json.Delim('[') {
+		return fmt.Errorf("read json: %w", errNotArray)
+	}
+
+	for d.More() {
+		var raw json.RawMessage
+		if err := d.Decode(&raw); err != nil {
+			return fmt.Errorf("read json: %w", err)
+		}
+
+		e, err := jsonEntry(raw)
+		if err := fn(sanitize(e), err); err != nil {
+			return err
+		}
+	}
+
+	if _, err := d.Token(); err != nil {
+		return fmt.Errorf("read json: %w", err)
+	}
+
+	return nil
+}
+
+// jsonEntry decodes the note to the entry.
+func jsonEntry(raw json.RawMessage) (*Entry, error) {
+	var n jsonNote
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return &Entry{}, err
+	}
+
+	e := &Entry{
+		Title:     strings.TrimSpace(n.Title),
+		Text:      n.Text,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+	if e.Title == "" {
+		e.Title = strings.TrimSpace(n.Name)
+	}
+
+	switch n.Format {
+	case "", "plain":
+	case "markdown":
+		e.Markdown = true
+	default:
+		return e, fmt.Errorf("unknown format %q", n.Format)
+	}
+
+	return e, nil
+}
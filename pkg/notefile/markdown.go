@@ -0,0 +1,190 @@
+package notefile
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// frontMatterDelimiter opens and closes the YAML front matter at the start of Markdown files.
+	frontMatterDelimiter = "---"
+	// frontMatterEnd may close the front matter as well.
+	frontMatterEnd = "..."
+	// byteOrderMark is skipped at the start of files.
+	byteOrderMark = "\ufeff"
+)
+
+var (
+	// markdownExtensions are the extensions of the files read from zip archives.
+	markdownExtensions = []string{".md", ".markdown"}
+	// frontMatterTimeLayouts are the layouts of the times in front matter.
+	frontMatterTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		time.DateOnly,
+	}
+	// frontMatterTitleKeys, frontMatterCreatedKeys and frontMatterUpdatedKeys are the keys of the title and the times
+	// in front matter, the first present key is used.
+	frontMatterTitleKeys   = []string{"title"}
+	frontMatterCreatedKeys = []string{"created", "created_at", "date"}
+	frontMatterUpdatedKeys = []string{"updated", "updated_at", "modified", "lastmod"}
+)
+
+// ReadMarkdown reads the Markdown files of the zip archive in the order of the archive. Directories, hidden files
+// and files of other types are skipped. The front matter may set the title and the times of the note, the title
+// defaults to the name of the file.
+func ReadMarkdown(r io.ReaderAt, size int64, maxEntrySize int64, fn Func) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+		return fmt.Errorf("read zip: %w", err)
+	}
+
+	for _, f := range zr.File {
+		if !isMarkdownFile(f) {
+			continue
+		}
+
+		name := path.Base(f.Name)
+		e := &Entry{Source: f.Name, Title: strings.TrimSuffix(name, path.Ext(name)), Markdown: true}
+		readErr := readMarkdownFile(f, maxEntrySize, e)
+		if err := fn(sanitize(e), readErr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isMarkdownFile reports whether the file of the archive is a Markdown file outside of hidden directories.
+func isMarkdownFile(f *zip.File) bool {
+	if f.FileInfo().IsDir() {
+		return false
+	}
+
+	for _, part := range strings.Split(f.Name, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return false
+		}
+	}
+
+	return slices.Contains(markdownExtensions, strings.ToLower(path.Ext(f.Name)))
+}
+
+// readMarkdownFile reads the text of the file into the entry and applies its front matter.
+func readMarkdownFile(f *zip.File, maxEntrySize int64, e *Entry) error {
+	if f.UncompressedSize64 > uint64(maxEntrySize) {
+		return ErrEntryTooLarge
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer rc.Close() // nolint: errcheck
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxEntrySize+1))
+	switch {
+	case err != nil:
+		return fmt.Errorf("read file: %w", err)
+	case int64(len(content)) > maxEntrySize:
+		return ErrEntryTooLarge
+	case !utf8.Valid(content):
+		return ErrInvalidText
+	}
+
+	text := strings.ReplaceAll(strings.TrimPrefix(string(content), byteOrderMark), "\r\n", "\n")
+	matter, body, ok := splitFrontMatter(text)
+	if !ok {
+		e.Text = text
+		return nil
+	}
+
+	e.Text = strings.TrimLeft(body, "\n")
+	if err := applyFrontMatter(matter, e); err != nil {
+		return fmt.Errorf("front matter: %w", err)
+	}
+
+	return nil
+}
+
+// splitFrontMatter splits the text into the front matter and the body. The front matter is opened by the delimiter
+// on the first line and closed by the next delimiter line.
+func splitFrontMatter(text string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(text, frontMatterDelimiter+"\n")
+	if !ok {
+		return "", text, false
+	}
+
+	for offset := 0; offset < len(rest); {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		if l := strings.TrimRight(line, " \t"); l == frontMatterDelimiter || l == frontMatterEnd {
+			return rest[:offset], rest[min(offset+len(line)+1, len(rest)):], true
+		}
+
+		offset += len(line) + 1
+	}
+
+	return "", text, false
+}
+
+// applyFrontMatter sets the title and the times of the entry present in the front matter.
+func applyFrontMatter(matter string, e *Entry) error {
+	var values map[string]any
+	if err := yaml.Unmarshal([]byte(matter), &values); err != nil {
+		return err
+	}
+
+	if v, ok := lookup(values, frontMatterTitleKeys); ok && v != nil {
+		e.Title = strings.TrimSpace(fmt.Sprint(v))
+	}
+
+	var err error
+	if e.CreatedAt, err = frontMatterTime(values, frontMatterCreatedKeys); err != nil {
+		return err
+	}
+
+	if e.UpdatedAt, err = frontMatterTime(values, frontMatterUpdatedKeys); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// frontMatterTime returns the time of the first present key, the time is zero when none of the keys is present.
+func frontMatterTime(values map[string]any, keys []string) (time.Time, error) {
+	v, ok := lookup(values, keys)
+	if !ok || v == nil {
+		return time.Time{}, nil
+	}
+
+	if t, ok := v.(time.Time); ok {
+		return t, nil
+	}
+
+	return parseTime(strings.TrimSpace(fmt.Sprint(v)), frontMatterTimeLayouts...)
+}
+
+// lookup returns the value of the first present key, keys are matched case-insensitively.
+func lookup(values map[string]any, keys []string) (any, bool) {
+	for _, key := range keys {
+		for k, v := range values {
+			if strings.EqualFold(k, key) {
+				return v, true
+			}
+		}
+	}
+
+	return nil, false
+}
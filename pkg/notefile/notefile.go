@@ -0,0 +1,112 @@
+// Package notefile reads notes exported by note-taking applications: zip archives of Markdown files with optional
+// front matter, Evernote ENEX exports and JSON arrays of notes.
+//
+// Readers stream the notes to a callback in the order of the source. A note which can not be read is passed
+// along with its error and reading goes on, the error returned by the reader means the source itself is broken.
+// Notes are passed without NUL characters and with invalid UTF-8 sequences replaced, so they can be stored as text.
+// Writers write the Markdown and JSON formats, and JSON objects one per line, so exported notes read back.
+package notefile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format represents the format of the exported notes.
+type Format string
+
+const (
+	// Markdown is a zip archive of Markdown files.
+	Markdown Format = "markdown"
+	// ENEX is the XML export of Evernote.
+	ENEX Format = "enex"
+	// JSON is an array of note objects.
+	JSON Format = "json"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown notes format")
+	ErrEntryTooLarge = errors.New("note is too large")
+	ErrInvalidText   = errors.New("note text is not valid UTF-8")
+)
+
+// Entry represents a note read from the source. Source is the path of the file in the archive, empty for formats
+// keeping notes in a single file. Zero times are unknown.
+type Entry struct {
+	Source    string
+	Title     string
+	Text      string
+	Markdown  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Func receives the notes read from the source, err is set when the note can not be read. A returned error stops
+// reading and is returned by the reader.
+type Func func(e *Entry, err error) error
+
+// ParseFormat parses a string and returns it as a Format if it matches predefined formats, otherwise it returns
+// ErrUnknownFormat.
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case Markdown, ENEX, JSON:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// FormatOf returns the format of the exported notes file by the extension of its name, otherwise it returns
+// ErrUnknownFormat.
+func FormatOf(name string) (Format, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".zip":
+		return Markdown, nil
+	case ".enex":
+		return ENEX, nil
+	case ".json":
+		return JSON, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+	}
+}
+
+// Read reads the notes of the source in the format. Files of zip archives over maxEntrySize bytes are reported
+// as too large without being read.
+func Read(format Format, r io.ReaderAt, size int64, maxEntrySize int64, fn Func) error {
+	switch format {
+	case Markdown:
+		return ReadMarkdown(r, size, maxEntrySize, fn)
+	case ENEX:
+		return ReadENEX(io.NewSectionReader(r, 0, size), fn)
+	case JSON:
+		return ReadJSON(io.NewSectionReader(r, 0, size), fn)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// sanitize removes NUL characters and replaces invalid UTF-8 sequences of the texts of the entry.
+func sanitize(e *Entry) *Entry {
+	for _, s := range []*string{&e.Source, &e.Title, &e.Text} {
+		*s = strings.ReplaceAll(strings.ToValidUTF8(*s, string(utf8.RuneError)), "\x00", "")
+	}
+
+	return e
+}
+
+// parseTime parses the time in one of the layouts, times without the zone are taken as UTC.
+func parseTime(value string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,84 @@
+package notefile
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead_Sanitized(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		format   Format
+		content  func(t *testing.T) []byte
+		expected []Entry
+	}{
+		{
+			name:   "json_nul",
+			format: JSON,
+			content: func(*testing.T) []byte {
+				return []byte(`[{"title": "a\u0000b", "text": "te\u0000xt"}]`)
+			},
+			expected: []Entry{{Title: "ab", Text: "text"}},
+		},
+		{
+			name:   "json_invalid_utf8",
+			format: JSON,
+			content: func(*testing.T) []byte {
+				return []byte("[{\"title\": \"a\xffb\", \"text\": \"text\"}]")
+			},
+			expected: []Entry{{Title: "a�b", Text: "text"}},
+		},
+		{
+			name:   "markdown_nul_and_invalid_name",
+			format: Markdown,
+			content: func(t *testing.T) []byte {
+				var buf bytes.Buffer
+				zw := zip.NewWriter(&buf)
+				w, err := zw.Create("dir\xff/no\x00te.md")
+				require.NoError(t, err)
+				_, err = w.Write([]byte("line\x00\n"))
+				require.NoError(t, err)
+				require.NoError(t, zw.Close())
+				return buf.Bytes()
+			},
+			expected: []Entry{{Source: "dir�/note.md", Title: "note", Text: "line\n", Markdown: true}},
+		},
+		{
+			name:   "enex_clean",
+			format: ENEX,
+			content: func(*testing.T) []byte {
+				return []byte(`<en-export><note><title>Title</title>` +
+					`<content><![CDATA[<en-note><div>text</div></en-note>]]></content></note></en-export>`)
+			},
+			expected: []Entry{{Title: "Title", Text: "text"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			content := tc.content(t)
+			var entries []Entry
+			read := func(e *Entry, err error) error {
+				require.NoError(t, err)
+				entries = append(entries, *e)
+				return nil
+			}
+
+			err := Read(tc.format, bytes.NewReader(content), int64(len(content)), 1024, read)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, entries)
+
+			for _, e := range entries {
+				require.False(t, strings.ContainsRune(e.Title+e.Text+e.Source, 0))
+			}
+		})
+	}
+}
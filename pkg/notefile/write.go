@@ -0,0 +1,175 @@
+package notefile
+
+import (
+	"archive/zip"
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errNoSource is returned for entries of zip archives without the path of the file.
+var errNoSource = errors.New("path of the note file is required")
+
+// Writer writes notes one by one as they are given. Close finishes the export without closing
+// the underlying writer.
+type Writer interface {
+	Write(e *Entry) error
+	Close() error
+}
+
+// frontMatter represents the front matter of written Markdown files.
+type frontMatter struct {
+	Title   string    `yaml:"title"`
+	Created time.Time `yaml:"created,omitempty"`
+	Updated time.Time `yaml:"updated,omitempty"`
+}
+
+// jsonEntryNote represents a written note of JSON exports.
+type jsonEntryNote struct {
+	Name      string    `json:"name"`
+	Text      string    `json:"text"`
+	Format    string    `json:"format"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// markdownWriter writes notes as Markdown files of a zip archive.
+type markdownWriter struct {
+	zw *zip.Writer
+}
+
+// jsonWriter writes notes as an array of JSON objects or as JSON objects separated by new lines.
+type jsonWriter struct {
+	w     *bufio.Writer
+	lines bool
+	notes int
+}
+
+// NewMarkdownWriter returns the writer of a zip archive of Markdown files, the source of the entries is the path
+// of the file. The title and the times of the notes are kept in the front matter, so the archive reads back
+// with ReadMarkdown.
+func NewMarkdownWriter(w io.Writer) Writer {
+	return &markdownWriter{zw: zip.NewWriter(w)}
+}
+
+// NewJSONWriter returns the writer of an array of notes which reads back with ReadJSON.
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+// NewNDJSONWriter returns the writer of notes as JSON objects of ReadJSON, one object per line.
+func NewNDJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: bufio.NewWriter(w), lines: true}
+}
+
+// Write adds the Markdown file of the note to the archive.
+func (mw *markdownWriter) Write(e *Entry) error {
+	if e.Source == "" {
+		return fmt.Errorf("write markdown: %w", errNoSource)
+	}
+
+	matter, err := yaml.Marshal(&frontMatter{Title: e.Title, Created: e.CreatedAt, Updated: e.UpdatedAt})
+	if err != nil {
+		return fmt.Errorf("write markdown: %w (note %s)", err, e.Source)
+	}
+
+	header := &zip.FileHeader{Name: e.Source, Method: zip.Deflate}
+	if modified := cmp.Or(e.UpdatedAt, e.CreatedAt); !modified.IsZero() {
+		header.Modified = modified
+	}
+
+	fw, err := mw.zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("write markdown: %w (note %s)", err, e.Source)
+	}
+
+	for _, part := range []string{frontMatterDelimiter + "\n", string(matter), frontMatterDelimiter + "\n\n", e.Text} {
+		if _, err := io.WriteString(fw, part); err != nil {
+			return fmt.Errorf("write markdown: %w (note %s)", err, e.Source)
+		}
+	}
+
+	return nil
+}
+
+// Close writes the central directory of the archive.
+func (mw *markdownWriter) Close() error {
+	if err := mw.zw.Close(); err != nil {
+		return fmt.Errorf("write markdown: %w", err)
+	}
+
+	return nil
+}
+
+// Write adds the note object, notes are flushed as the buffer fills.
+func (jw *jsonWriter) Write(e *Entry) error {
+	format := "plain"
+	if e.Markdown {
+		format = "markdown"
+	}
+
+	data, err := json.Marshal(&jsonEntryNote{
+		Name:      e.Title,
+		Text:      e.Text,
+		Format:    format,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("write json: %w", err)
+	}
+
+	var sep string
+	switch {
+	case jw.lines:
+	case jw.notes == 0:
+		sep = "[\n"
+	default:
+		sep = ",\n"
+	}
+
+	jw.notes++
+	if _, err := jw.w.WriteString(sep); err != nil {
+		return fmt.Errorf("write json: %w", err)
+	}
+
+	if _, err := jw.w.Write(data); err != nil {
+		return fmt.Errorf("write json: %w", err)
+	}
+
+	if jw.lines {
+		if err := jw.w.WriteByte('\n'); err != nil {
+			return fmt.Errorf("write json: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the array and flushes the buffered notes.
+func (jw *jsonWriter) Close() error {
+	var end string
+	switch {
+	case jw.lines:
+	case jw.notes == 0:
+		end = "[]\n"
+	default:
+		end = "\n]\n"
+	}
+
+	if _, err := jw.w.WriteString(end); err != nil {
+		return fmt.Errorf("write json: %w", err)
+	}
+
+	if err := jw.w.Flush(); err != nil {
+		return fmt.Errorf("write json: %w", err)
+	}
+
+	return nil
+}
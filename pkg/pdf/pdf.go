@@ -0,0 +1,210 @@
+// Package pdf lays out documents of titled text sections into paginated PDF files.
+//
+// Texts are set in the embedded Go fonts, so any text the fonts cover renders without fonts installed on the host.
+// Every section starts on a new page, documents of several sections may open with a table of contents linking to
+// the sections. Documents are laid out twice: the first pass finds the pages of the sections and the page count
+// printed by the second one.
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// PageSize represents the size of the pages of the document.
+type PageSize string
+
+const (
+	// A4 is the ISO A4 size, 210 x 297 mm.
+	A4 PageSize = "a4"
+	// A5 is the ISO A5 size, 148 x 210 mm.
+	A5 PageSize = "a5"
+	// Letter is the US Letter size, 8.5 x 11 in.
+	Letter PageSize = "letter"
+	// Legal is the US Legal size, 8.5 x 14 in.
+	Legal PageSize = "legal"
+)
+
+const (
+	fontFamily   = "go"
+	margin       = 20.0
+	titleSize    = 16.0
+	metaSize     = 9.0
+	bodySize     = 11.0
+	footerSize   = 8.0
+	lineSpacing  = 1.4
+	pointToMM    = 25.4 / 72
+	tabSpaces    = "    "
+	pageNumWidth = 15.0
+	untitled     = "Untitled"
+	contents     = "Contents"
+)
+
+var ErrUnknownPageSize = errors.New("unknown page size")
+
+// Section represents a part of the document starting on a new page.
+type Section struct {
+	Title string
+	Meta  []string
+	Body  string
+}
+
+// Options holds the settings of the document.
+type Options struct {
+	Title    string
+	PageSize PageSize
+	Contents bool
+}
+
+// layout holds the first pages of the sections and the page count of the document.
+type layout struct {
+	pages []int
+	total int
+}
+
+// ParsePageSize parses a string and returns it as a PageSize if it matches predefined sizes, otherwise it returns
+// ErrUnknownPageSize.
+func ParsePageSize(size string) (PageSize, error) {
+	switch PageSize(strings.ToLower(size)) {
+	case A4, A5, Letter, Legal:
+		return PageSize(strings.ToLower(size)), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownPageSize, size)
+	}
+}
+
+// Render writes the document of the sections to w. The table of contents is added to documents of several sections
+// when the options ask for it.
+func Render(w io.Writer, sections []Section, opts Options) error {
+	if _, err := ParsePageSize(string(opts.PageSize)); err != nil {
+		return fmt.Errorf("render pdf: %w", err)
+	}
+
+	_, first, err := render(sections, opts, &layout{pages: make([]int, len(sections))})
+	if err != nil {
+		return fmt.Errorf("render pdf: %w", err)
+	}
+
+	doc, _, err := render(sections, opts, first)
+	if err != nil {
+		return fmt.Errorf("render pdf: %w", err)
+	}
+
+	if err := doc.Output(w); err != nil {
+		return fmt.Errorf("render pdf: %w", err)
+	}
+
+	return nil
+}
+
+// render lays out the document numbering pages with the previous layout and returns the layout of the document.
+func render(sections []Section, opts Options, prev *layout) (*fpdf.Fpdf, *layout, error) {
+	next := &layout{pages: make([]int, len(sections))}
+	doc := fpdf.New("P", "mm", string(opts.PageSize), "")
+	doc.AddUTF8FontFromBytes(fontFamily, "", goregular.TTF)
+	doc.AddUTF8FontFromBytes(fontFamily, "B", gobold.TTF)
+	doc.SetMargins(margin, margin, margin)
+	doc.SetAutoPageBreak(true, margin)
+	doc.SetTitle(opts.Title, true)
+	doc.SetFooterFunc(func() {
+		doc.SetY(-margin / 2)
+		doc.SetFont(fontFamily, "", footerSize)
+		doc.SetTextColor(128, 128, 128)
+		number := fmt.Sprintf("%d / %d", doc.PageNo(), prev.total)
+		doc.CellFormat(0, footerSize*pointToMM, number, "", 0, "C", false, 0, "")
+	})
+
+	links := make([]int, len(sections))
+	for i := range sections {
+		links[i] = doc.AddLink()
+	}
+
+	if opts.Contents && len(sections) > 1 {
+		writeContents(doc, sections, links, prev)
+	}
+
+	for i, s := range sections {
+		doc.AddPage()
+		doc.SetLink(links[i], 0, doc.PageNo())
+		next.pages[i] = doc.PageNo()
+		writeSection(doc, s)
+	}
+
+	next.total = doc.PageNo()
+	return doc, next, doc.Error()
+}
+
+// writeContents writes the table of contents, each entry links to its section. Titles are cut to a single line,
+// so the numbers of the pages never change the layout.
+func writeContents(doc *fpdf.Fpdf, sections []Section, links []int, l *layout) {
+	doc.AddPage()
+	doc.SetFont(fontFamily, "B", titleSize)
+	doc.Bookmark(contents, 0, 0)
+	doc.SetTextColor(0, 0, 0)
+	doc.CellFormat(0, titleSize*pointToMM*lineSpacing, contents, "", 1, "L", false, 0, "")
+	doc.Ln(metaSize * pointToMM)
+
+	doc.SetFont(fontFamily, "", bodySize)
+	width, _ := doc.GetPageSize()
+	titleWidth := width - 2*margin - pageNumWidth
+	height := bodySize * pointToMM * lineSpacing
+	for i, s := range sections {
+		doc.CellFormat(titleWidth, height, fit(doc, title(s), titleWidth), "", 0, "L", false, links[i], "")
+		doc.CellFormat(pageNumWidth, height, fmt.Sprint(l.pages[i]), "", 1, "R", false, links[i], "")
+	}
+}
+
+// writeSection writes the title, the meta lines and the body of the section. The bookmark is added once
+// the UTF-8 font is set, so its title is encoded as UTF-8 text.
+func writeSection(doc *fpdf.Fpdf, s Section) {
+	doc.SetFont(fontFamily, "B", titleSize)
+	doc.Bookmark(title(s), 0, 0)
+	doc.SetTextColor(0, 0, 0)
+	doc.MultiCell(0, titleSize*pointToMM*lineSpacing, title(s), "", "L", false)
+
+	doc.SetFont(fontFamily, "", metaSize)
+	doc.SetTextColor(110, 110, 110)
+	for _, m := range s.Meta {
+		doc.MultiCell(0, metaSize*pointToMM*lineSpacing, m, "", "L", false)
+	}
+
+	doc.Ln(metaSize * pointToMM / 2)
+	width, _ := doc.GetPageSize()
+	doc.SetDrawColor(200, 200, 200)
+	doc.Line(margin, doc.GetY(), width-margin, doc.GetY())
+	doc.Ln(metaSize * pointToMM)
+
+	body := strings.ReplaceAll(strings.ReplaceAll(s.Body, "\r\n", "\n"), "\t", tabSpaces)
+	doc.SetFont(fontFamily, "", bodySize)
+	doc.SetTextColor(0, 0, 0)
+	doc.MultiCell(0, bodySize*pointToMM*lineSpacing, body, "", "L", false)
+}
+
+// title returns the title of the section, untitled sections get a placeholder.
+func title(s Section) string {
+	if t := strings.TrimSpace(s.Title); t != "" {
+		return t
+	}
+
+	return untitled
+}
+
+// fit cuts the text with an ellipsis to fit the width in the current font.
+func fit(doc *fpdf.Fpdf, text string, width float64) string {
+	if doc.GetStringWidth(text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && doc.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "…"
+}
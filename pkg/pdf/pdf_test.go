@@ -0,0 +1,145 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pageObject matches the page objects of the rendered document.
+var pageObject = regexp.MustCompile(`(?m)^<</Type /Page$`)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	title := "Заметки — 日本語 ✓"
+	long := strings.Repeat("Строка длинного текста, which wraps across the page width more than once.\n", 200)
+
+	cases := []struct {
+		name        string
+		sections    []Section
+		opts        Options
+		minPages    int
+		maxPages    int
+		titles      []string
+		expectedErr error
+	}{
+		{
+			name:     "long_note",
+			sections: []Section{{Title: title, Meta: []string{"Updated 2025-08-04"}, Body: long}},
+			opts:     Options{Title: title, PageSize: A4},
+			minPages: 3,
+			maxPages: 100,
+			titles:   []string{title},
+		},
+		{
+			name: "sections_with_contents",
+			sections: []Section{
+				{Title: title, Body: "first"},
+				{Body: "\tuntitled"},
+				{Title: "Third", Body: "third"},
+			},
+			opts:     Options{Title: "Export", PageSize: Letter, Contents: true},
+			minPages: 4,
+			maxPages: 4,
+			titles:   []string{title, untitled, "Third", contents},
+		},
+		{
+			name:     "empty_body",
+			sections: []Section{{Title: "Empty"}},
+			opts:     Options{PageSize: A5},
+			minPages: 1,
+			maxPages: 1,
+			titles:   []string{"Empty"},
+		},
+		{
+			name:        "unknown_page_size",
+			sections:    []Section{{Title: "Note"}},
+			opts:        Options{PageSize: "a3"},
+			expectedErr: ErrUnknownPageSize,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			err := Render(&buf, tc.sections, tc.opts)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Zero(t, buf.Len())
+				return
+			}
+
+			require.NoError(t, err)
+			doc := buf.String()
+			require.True(t, strings.HasPrefix(doc, "%PDF-1."), "header")
+			require.True(t, strings.HasSuffix(doc, "%%EOF\n"), "trailer")
+			require.Contains(t, doc, "startxref")
+
+			pages := len(pageObject.FindAllString(doc, -1))
+			require.GreaterOrEqual(t, pages, tc.minPages)
+			require.LessOrEqual(t, pages, tc.maxPages)
+
+			for _, title := range tc.titles {
+				require.Contains(t, doc, "<</Title "+textString(title), "bookmark %q", title)
+			}
+		})
+	}
+}
+
+func TestParsePageSize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		size        string
+		expected    PageSize
+		expectedErr error
+	}{
+		{name: "a4", size: "a4", expected: A4},
+		{name: "upper_case", size: "LETTER", expected: Letter},
+		{name: "legal", size: "Legal", expected: Legal},
+		{name: "unknown", size: "b5", expectedErr: ErrUnknownPageSize},
+		{name: "empty", expectedErr: ErrUnknownPageSize},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			size, err := ParsePageSize(tc.size)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, size)
+		})
+	}
+}
+
+// textString returns the UTF-16 PDF text string of s the way the document writes it.
+func textString(s string) string {
+	b := []byte{'(', 0xFE, 0xFF}
+	for _, r := range s {
+		if r > 0xFFFF {
+			panic("runes outside the basic plane are not expected")
+		}
+
+		for _, c := range []byte{byte(r >> 8), byte(r)} {
+			if c == '(' || c == ')' || c == '\\' || c == '\r' {
+				b = append(b, '\\')
+			}
+
+			b = append(b, c)
+		}
+	}
+
+	return string(append(b, ')'))
+}
@@ -0,0 +1,100 @@
+package pgnotify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Listener receives Postgres notifications of a channel on a dedicated connection.
+type Listener struct {
+	dsn     string
+	channel string
+}
+
+// NewListener returns a Listener of the channel connecting with the data source name.
+func NewListener(dsn string, channel string) *Listener {
+	return &Listener{
+		dsn:     dsn,
+		channel: channel,
+	}
+}
+
+// Listen connects and passes payloads of the channel notifications to handle until the context is done
+// or the connection fails. Notifications sent while no connection is listening are lost.
+func (l *Listener) Listen(ctx context.Context, handle func(payload []byte)) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close(context.Background()) // nolint: errcheck
+
+	if _, err := conn.Exec(ctx, "listen "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen %s: %w", l.channel, err)
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait notification: %w", err)
+		}
+
+		handle([]byte(n.Payload))
+	}
+}
+
+// Notifier sends Postgres notifications to a channel on a dedicated connection, which is opened on the first
+// notification and reopened after failures.
+type Notifier struct {
+	dsn     string
+	channel string
+	mu      sync.Mutex
+	conn    *pgx.Conn
+}
+
+// NewNotifier returns a Notifier of the channel connecting with the data source name.
+func NewNotifier(dsn string, channel string) *Notifier {
+	return &Notifier{
+		dsn:     dsn,
+		channel: channel,
+	}
+}
+
+// Notify sends the payload to the listeners of the channel. Payloads are limited to 8000 bytes by Postgres.
+func (n *Notifier) Notify(ctx context.Context, payload []byte) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		conn, err := pgx.Connect(ctx, n.dsn)
+		if err != nil {
+			return fmt.Errorf("connect: %w", err)
+		}
+
+		n.conn = conn
+	}
+
+	if _, err := n.conn.Exec(ctx, "select pg_notify($1, $2)", n.channel, string(payload)); err != nil {
+		n.conn.Close(context.Background()) // nolint: errcheck
+		n.conn = nil
+		return fmt.Errorf("notify %s: %w", n.channel, err)
+	}
+
+	return nil
+}
+
+// Close closes the connection of the notifier.
+func (n *Notifier) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	err := n.conn.Close(context.Background())
+	n.conn = nil
+	return err
+}
@@ -0,0 +1,325 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// Effect represents the outcome of a matched policy.
+type Effect string
+
+// Operator represents a comparison applied by a policy condition.
+type Operator string
+
+// Attributes represents a flat set of subject, resource and environment attributes, e.g. "subject.id" or "env.hour".
+type Attributes map[string]any
+
+const (
+	// EffectAllow grants the operation when the policy matches.
+	EffectAllow Effect = "allow"
+	// EffectDeny forbids the operation when the policy matches, overriding any allow.
+	EffectDeny Effect = "deny"
+)
+
+const (
+	// OperatorEq checks that the attribute equals the value.
+	OperatorEq Operator = "eq"
+	// OperatorNe checks that the attribute does not equal the value.
+	OperatorNe Operator = "ne"
+	// OperatorIn checks that the attribute is one of the listed values.
+	OperatorIn Operator = "in"
+	// OperatorNotIn checks that the attribute is none of the listed values.
+	OperatorNotIn Operator = "not_in"
+	// OperatorContains checks that the list attribute contains the value.
+	OperatorContains Operator = "contains"
+	// OperatorGt checks that the numeric attribute is greater than the value.
+	OperatorGt Operator = "gt"
+	// OperatorGte checks that the numeric attribute is greater than or equal to the value.
+	OperatorGte Operator = "gte"
+	// OperatorLt checks that the numeric attribute is less than the value.
+	OperatorLt Operator = "lt"
+	// OperatorLte checks that the numeric attribute is less than or equal to the value.
+	OperatorLte Operator = "lte"
+)
+
+// AnyResource matches policies against every resource type.
+const AnyResource = "*"
+
+var (
+	ErrInvalidPolicy    = errors.New("invalid policy")
+	ErrUnknownOperation = errors.New("unknown operation")
+)
+
+// Condition describes a single predicate over attributes. The attribute is compared either with the static
+// Value or, when Ref is set, with the value of another attribute.
+type Condition struct {
+	Attribute string   `json:"attribute"`
+	Operator  Operator `json:"operator"`
+	Value     any      `json:"value,omitempty"`
+	Ref       string   `json:"ref,omitempty"`
+}
+
+// Policy is a declarative rule applied to operations on a resource type when all its conditions are satisfied.
+type Policy struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Effect      Effect      `json:"effect"`
+	Resource    string      `json:"resource"`
+	Operations  []string    `json:"operations"`
+	Conditions  []Condition `json:"conditions,omitempty"`
+}
+
+// Decision is the result of policies evaluation. Empty Effect means no policy matched.
+type Decision struct {
+	Effect Effect `json:"effect,omitempty"`
+	Policy string `json:"policy,omitempty"`
+}
+
+// Attributer represents a function collecting attributes of the resource and its owner for policies evaluation.
+type Attributer[R, O any] func(context.Context, R, O) (Attributes, error)
+
+// PolicyEngine evaluates policies with deny-overrides semantics. Policies can be replaced at any time.
+type PolicyEngine struct {
+	policies atomic.Pointer[[]Policy]
+}
+
+// NewPolicyEngine initializes a new PolicyEngine without policies.
+func NewPolicyEngine() *PolicyEngine {
+	engine := &PolicyEngine{}
+	engine.policies.Store(&[]Policy{})
+	return engine
+}
+
+// Load validates the policies and atomically replaces the current ones.
+func (e *PolicyEngine) Load(policies []Policy) error {
+	for i := range policies {
+		if err := policies[i].validate(); err != nil {
+			return fmt.Errorf("load policies: %w", err)
+		}
+	}
+
+	policies = slices.Clone(policies)
+	e.policies.Store(&policies)
+	return nil
+}
+
+// Policies returns the currently loaded policies.
+func (e *PolicyEngine) Policies() []Policy {
+	return slices.Clone(*e.policies.Load())
+}
+
+// Len returns the number of currently loaded policies.
+func (e *PolicyEngine) Len() int {
+	return len(*e.policies.Load())
+}
+
+// Evaluate applies the loaded policies to the operation on the resource type. A matched deny policy
+// overrides any matched allow policy.
+func (e *PolicyEngine) Evaluate(resource string, op Operation, attrs Attributes) Decision {
+	var decision Decision
+	for _, p := range *e.policies.Load() {
+		if !p.matches(resource, op, attrs) {
+			continue
+		}
+
+		if p.Effect == EffectDeny {
+			return Decision{Effect: EffectDeny, Policy: p.Name}
+		}
+
+		if decision.Effect == "" {
+			decision = Decision{Effect: EffectAllow, Policy: p.Name}
+		}
+	}
+
+	return decision
+}
+
+// WithPolicies wraps the guarder with the policy engine. A matched policy decides the result, the guarder
+// is used as a fallback when no policy matches the operation.
+func WithPolicies[R, O any](
+	engine *PolicyEngine,
+	resource string,
+	attributer Attributer[R, O],
+	guarder Guarder[R, O],
+) Guarder[R, O] {
+	return func(ctx context.Context, op Operation, r R, o O) (bool, error) {
+		if engine.Len() == 0 {
+			return guarder(ctx, op, r, o)
+		}
+
+		attrs, err := attributer(ctx, r, o)
+		if err != nil {
+			return false, fmt.Errorf("collect attributes: %w", err)
+		}
+
+		switch engine.Evaluate(resource, op, attrs).Effect {
+		case EffectDeny:
+			return false, nil
+		case EffectAllow:
+			return true, nil
+		}
+
+		return guarder(ctx, op, r, o)
+	}
+}
+
+// ParseOperation converts the string representation of an operation into Operation.
+func ParseOperation(s string) (Operation, error) {
+	for _, op := range []Operation{READ, UPDATE, CREATE, DELETE} {
+		if op.String() == s {
+			return op, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w %q", ErrUnknownOperation, s)
+}
+
+// validate checks that the policy is well-formed.
+func (p *Policy) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPolicy)
+	}
+
+	if p.Effect != EffectAllow && p.Effect != EffectDeny {
+		return fmt.Errorf("%w: unknown effect %q (policy %s)", ErrInvalidPolicy, p.Effect, p.Name)
+	}
+
+	if p.Resource == "" {
+		return fmt.Errorf("%w: resource is required (policy %s)", ErrInvalidPolicy, p.Name)
+	}
+
+	if len(p.Operations) == 0 {
+		return fmt.Errorf("%w: operations are required (policy %s)", ErrInvalidPolicy, p.Name)
+	}
+
+	for _, op := range p.Operations {
+		if _, err := ParseOperation(op); err != nil {
+			return fmt.Errorf("%w: %w (policy %s)", ErrInvalidPolicy, err, p.Name)
+		}
+	}
+
+	for _, c := range p.Conditions {
+		if c.Attribute == "" {
+			return fmt.Errorf("%w: condition attribute is required (policy %s)", ErrInvalidPolicy, p.Name)
+		}
+
+		switch c.Operator {
+		case OperatorEq, OperatorNe, OperatorIn, OperatorNotIn, OperatorContains,
+			OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		default:
+			return fmt.Errorf("%w: unknown operator %q (policy %s)", ErrInvalidPolicy, c.Operator, p.Name)
+		}
+	}
+
+	return nil
+}
+
+// matches reports whether the policy applies to the operation on the resource type with the given attributes.
+func (p *Policy) matches(resource string, op Operation, attrs Attributes) bool {
+	if p.Resource != AnyResource && p.Resource != resource {
+		return false
+	}
+
+	if !slices.Contains(p.Operations, op.String()) {
+		return false
+	}
+
+	for _, c := range p.Conditions {
+		if !c.matches(attrs) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches reports whether the condition is satisfied by the attributes. Missing attributes never match.
+func (c *Condition) matches(attrs Attributes) bool {
+	actual, ok := attrs[c.Attribute]
+	if !ok {
+		return false
+	}
+
+	expected := c.Value
+	if c.Ref != "" {
+		if expected, ok = attrs[c.Ref]; !ok {
+			return false
+		}
+	}
+
+	actual, expected = normalize(actual), normalize(expected)
+	switch c.Operator {
+	case OperatorEq:
+		return actual == expected
+	case OperatorNe:
+		return actual != expected
+	case OperatorIn:
+		list, ok := expected.([]any)
+		return ok && slices.Contains(list, actual)
+	case OperatorNotIn:
+		list, ok := expected.([]any)
+		return ok && !slices.Contains(list, actual)
+	case OperatorContains:
+		list, ok := actual.([]any)
+		return ok && slices.Contains(list, expected)
+	case OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		a, aok := actual.(float64)
+		b, bok := expected.(float64)
+		if !aok || !bok {
+			return false
+		}
+
+		switch c.Operator {
+		case OperatorGt:
+			return a > b
+		case OperatorGte:
+			return a >= b
+		case OperatorLt:
+			return a < b
+		default:
+			return a <= b
+		}
+	}
+
+	return false
+}
+
+// normalize converts attribute values to comparable forms: numbers to float64, stringers and
+// string-based types to string, slices to []any of normalized values.
+func normalize(v any) any {
+	switch val := v.(type) {
+	case nil, bool, string, float64:
+		return val
+	case int:
+		return float64(val)
+	case int32:
+		return float64(val)
+	case int64:
+		return float64(val)
+	case uint:
+		return float64(val)
+	case uint64:
+		return float64(val)
+	case float32:
+		return float64(val)
+	case fmt.Stringer:
+		return val.String()
+	case []string:
+		list := make([]any, len(val))
+		for i := range val {
+			list[i] = val[i]
+		}
+		return list
+	case []any:
+		list := make([]any, len(val))
+		for i := range val {
+			list[i] = normalize(val[i])
+		}
+		return list
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,64 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// policyFile represents the JSON document containing policies.
+type policyFile struct {
+	Policies []Policy `json:"policies"`
+}
+
+// LoadPolicyFile reads policies from the JSON file at the given path and loads them into the engine.
+func (e *PolicyEngine) LoadPolicyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read policy file: %w", err)
+	}
+
+	var file policyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parse policy file: %w", err)
+	}
+
+	return e.Load(file.Policies)
+}
+
+// WatchPolicyFile polls the policy file with the given interval and reloads the engine whenever the file
+// modification time changes. Invalid files are reported to onError and the previous policies are kept.
+// Blocks until the context is done.
+func (e *PolicyEngine) WatchPolicyFile(ctx context.Context, path string, interval time.Duration, onError func(error)) {
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				onError(fmt.Errorf("stat policy file: %w", err))
+				continue
+			}
+
+			if info.ModTime().Equal(modTime) {
+				continue
+			}
+
+			modTime = info.ModTime()
+			if err := e.LoadPolicyFile(path); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyEngine_LoadPolicyFile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		content     string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name: "loaded",
+			content: `{"policies": [
+				{"name": "a", "effect": "allow", "resource": "note", "operations": ["read"]},
+				{"name": "b", "effect": "deny", "resource": "*", "operations": ["delete"]}
+			]}`,
+			expected: []string{"a", "b"},
+		},
+		{
+			name:        "invalid_json",
+			content:     `{"policies": [`,
+			expectedErr: "parse policy file",
+		},
+		{
+			name:        "invalid_policy",
+			content:     `{"policies": [{"name": "a", "effect": "allow", "resource": "note"}]}`,
+			expectedErr: "load policies: invalid policy: operations are required (policy a)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "policies.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			engine := NewPolicyEngine()
+			err := engine.LoadPolicyFile(path)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				require.Zero(t, engine.Len())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, policyNames(engine))
+		})
+	}
+}
+
+func TestPolicyEngine_WatchPolicyFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "policies.json")
+	modTime := time.Now().Add(-time.Hour)
+	// write changes the file with a later modification time, so the watcher sees the change whenever it started
+	write := func(content string) bool {
+		modTime = modTime.Add(time.Minute)
+		return os.WriteFile(path, []byte(content), 0o600) == nil && os.Chtimes(path, modTime, modTime) == nil
+	}
+
+	initial := `{"policies": [{"name": "initial", "effect": "allow", "resource": "note", "operations": ["read"]}]}`
+	require.True(t, write(initial))
+	engine := NewPolicyEngine()
+	require.NoError(t, engine.LoadPolicyFile(path))
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		engine.WatchPolicyFile(ctx, path, 5*time.Millisecond, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	require.Eventually(t, func() bool {
+		names := policyNames(engine)
+		if len(names) == 1 && names[0] == "reloaded" {
+			return true
+		}
+
+		write(`{"policies": [{"name": "reloaded", "effect": "deny", "resource": "note", "operations": ["update"]}]}`)
+		return false
+	}, time.Second, 20*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, err := range errs {
+			if errors.Is(err, ErrInvalidPolicy) {
+				return true
+			}
+		}
+
+		write(`{"policies": [{"name": "broken", "effect": "maybe", "resource": "note", "operations": ["update"]}]}`)
+		return false
+	}, time.Second, 20*time.Millisecond)
+
+	require.Equal(t, []string{"reloaded"}, policyNames(engine))
+}
+
+// policyNames returns the names of the policies loaded into the engine.
+func policyNames(engine *PolicyEngine) []string {
+	var names []string
+	for _, p := range engine.Policies() {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
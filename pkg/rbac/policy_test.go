@@ -0,0 +1,288 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyEngine_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	allowOwner := Policy{
+		Name:       "allow-owner",
+		Effect:     EffectAllow,
+		Resource:   "note",
+		Operations: []string{"read", "update"},
+		Conditions: []Condition{{Attribute: "resource.user_id", Operator: OperatorEq, Ref: "subject.id"}},
+	}
+	denyNight := Policy{
+		Name:       "deny-night",
+		Effect:     EffectDeny,
+		Resource:   AnyResource,
+		Operations: []string{"update"},
+		Conditions: []Condition{{Attribute: "env.hour", Operator: OperatorLt, Value: 6}},
+	}
+	allowAdmins := Policy{
+		Name:       "allow-admins",
+		Effect:     EffectAllow,
+		Resource:   "note",
+		Operations: []string{"read", "update"},
+		Conditions: []Condition{{Attribute: "subject.permissions", Operator: OperatorContains, Value: "notes.admin"}},
+	}
+	owner := Attributes{"subject.id": "u1", "resource.user_id": "u1", "env.hour": 12}
+
+	cases := []struct {
+		name     string
+		policies []Policy
+		resource string
+		op       Operation
+		attrs    Attributes
+		expected Decision
+	}{
+		{
+			name:     "no_policies",
+			resource: "note",
+			op:       READ,
+			attrs:    owner,
+		},
+		{
+			name:     "allow_matched",
+			policies: []Policy{allowOwner},
+			resource: "note",
+			op:       READ,
+			attrs:    owner,
+			expected: Decision{Effect: EffectAllow, Policy: "allow-owner"},
+		},
+		{
+			name:     "first_allow_reported",
+			policies: []Policy{allowOwner, allowAdmins},
+			resource: "note",
+			op:       UPDATE,
+			attrs:    Attributes{"subject.id": "u1", "resource.user_id": "u1", "subject.permissions": []string{"notes.admin"}},
+			expected: Decision{Effect: EffectAllow, Policy: "allow-owner"},
+		},
+		{
+			name:     "deny_overrides_earlier_allow",
+			policies: []Policy{allowOwner, denyNight},
+			resource: "note",
+			op:       UPDATE,
+			attrs:    Attributes{"subject.id": "u1", "resource.user_id": "u1", "env.hour": 3},
+			expected: Decision{Effect: EffectDeny, Policy: "deny-night"},
+		},
+		{
+			name:     "deny_overrides_later_allow",
+			policies: []Policy{denyNight, allowOwner},
+			resource: "note",
+			op:       UPDATE,
+			attrs:    Attributes{"subject.id": "u1", "resource.user_id": "u1", "env.hour": int64(3)},
+			expected: Decision{Effect: EffectDeny, Policy: "deny-night"},
+		},
+		{
+			name:     "deny_not_matched",
+			policies: []Policy{denyNight, allowOwner},
+			resource: "note",
+			op:       UPDATE,
+			attrs:    owner,
+			expected: Decision{Effect: EffectAllow, Policy: "allow-owner"},
+		},
+		{
+			name:     "operation_not_matched",
+			policies: []Policy{allowOwner},
+			resource: "note",
+			op:       DELETE,
+			attrs:    owner,
+		},
+		{
+			name:     "resource_not_matched",
+			policies: []Policy{allowOwner},
+			resource: "org",
+			op:       READ,
+			attrs:    owner,
+		},
+		{
+			name:     "any_resource",
+			policies: []Policy{denyNight},
+			resource: "org",
+			op:       UPDATE,
+			attrs:    Attributes{"env.hour": 0},
+			expected: Decision{Effect: EffectDeny, Policy: "deny-night"},
+		},
+		{
+			name:     "ref_not_matched",
+			policies: []Policy{allowOwner},
+			resource: "note",
+			op:       READ,
+			attrs:    Attributes{"subject.id": "u2", "resource.user_id": "u1"},
+		},
+		{
+			name:     "missing_attribute",
+			policies: []Policy{denyNight},
+			resource: "note",
+			op:       UPDATE,
+			attrs:    Attributes{},
+		},
+		{
+			name: "not_in_with_json_numbers",
+			policies: []Policy{{
+				Name:       "business-hours",
+				Effect:     EffectDeny,
+				Resource:   "note",
+				Operations: []string{"create"},
+				Conditions: []Condition{{Attribute: "env.hour", Operator: OperatorNotIn, Value: []any{9.0, 10.0}}},
+			}},
+			resource: "note",
+			op:       CREATE,
+			attrs:    Attributes{"env.hour": 20},
+			expected: Decision{Effect: EffectDeny, Policy: "business-hours"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			engine := NewPolicyEngine()
+			require.NoError(t, engine.Load(tc.policies))
+			require.Equal(t, tc.expected, engine.Evaluate(tc.resource, tc.op, tc.attrs))
+		})
+	}
+}
+
+func TestPolicyEngine_Load(t *testing.T) {
+	t.Parallel()
+
+	valid := Policy{Name: "valid", Effect: EffectAllow, Resource: "note", Operations: []string{"read"}}
+
+	cases := []struct {
+		name   string
+		policy Policy
+	}{
+		{name: "missing_name", policy: Policy{Effect: EffectAllow, Resource: "note", Operations: []string{"read"}}},
+		{name: "unknown_effect", policy: Policy{Name: "p", Effect: "maybe", Resource: "note", Operations: []string{"read"}}},
+		{name: "missing_resource", policy: Policy{Name: "p", Effect: EffectDeny, Operations: []string{"read"}}},
+		{name: "missing_operations", policy: Policy{Name: "p", Effect: EffectDeny, Resource: "note"}},
+		{
+			name:   "unknown_operation",
+			policy: Policy{Name: "p", Effect: EffectDeny, Resource: "note", Operations: []string{"share"}},
+		},
+		{
+			name: "unknown_operator",
+			policy: Policy{
+				Name:       "p",
+				Effect:     EffectDeny,
+				Resource:   "note",
+				Operations: []string{"read"},
+				Conditions: []Condition{{Attribute: "env.hour", Operator: "like"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			engine := NewPolicyEngine()
+			require.NoError(t, engine.Load([]Policy{valid}))
+
+			err := engine.Load([]Policy{valid, tc.policy})
+			require.ErrorIs(t, err, ErrInvalidPolicy)
+			require.Equal(t, []Policy{valid}, engine.Policies())
+		})
+	}
+}
+
+func TestWithPolicies(t *testing.T) {
+	t.Parallel()
+
+	deny := Policy{
+		Name:       "deny-blocked",
+		Effect:     EffectDeny,
+		Resource:   "note",
+		Operations: []string{"read"},
+		Conditions: []Condition{{Attribute: "subject.blocked", Operator: OperatorEq, Value: true}},
+	}
+	allow := Policy{
+		Name:       "allow-shared",
+		Effect:     EffectAllow,
+		Resource:   "note",
+		Operations: []string{"read"},
+		Conditions: []Condition{{Attribute: "resource.shared", Operator: OperatorEq, Value: true}},
+	}
+
+	cases := []struct {
+		name           string
+		policies       []Policy
+		attrs          Attributes
+		attrsErr       error
+		guarded        bool
+		expected       bool
+		expectedErr    string
+		expectedGuards int
+	}{
+		{
+			name:           "no_policies_fall_back",
+			guarded:        true,
+			expected:       true,
+			expectedGuards: 1,
+		},
+		{
+			name:     "deny_skips_guarder",
+			policies: []Policy{deny, allow},
+			attrs:    Attributes{"subject.blocked": true, "resource.shared": true},
+			guarded:  true,
+		},
+		{
+			name:     "allow_skips_guarder",
+			policies: []Policy{deny, allow},
+			attrs:    Attributes{"subject.blocked": false, "resource.shared": true},
+			expected: true,
+		},
+		{
+			name:           "no_match_falls_back",
+			policies:       []Policy{deny, allow},
+			attrs:          Attributes{"subject.blocked": false, "resource.shared": false},
+			guarded:        false,
+			expected:       false,
+			expectedGuards: 1,
+		},
+		{
+			name:        "attributes_failed",
+			policies:    []Policy{deny},
+			attrsErr:    errors.New("user not found"),
+			expectedErr: "collect attributes: user not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			engine := NewPolicyEngine()
+			require.NoError(t, engine.Load(tc.policies))
+
+			guards := 0
+			guarder := WithPolicies(
+				engine,
+				"note",
+				func(context.Context, string, string) (Attributes, error) { return tc.attrs, tc.attrsErr },
+				func(context.Context, Operation, string, string) (bool, error) {
+					guards++
+					return tc.guarded, nil
+				},
+			)
+
+			granted, err := guarder(context.Background(), READ, "note", "owner")
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, granted)
+			require.Equal(t, tc.expectedGuards, guards)
+		})
+	}
+}
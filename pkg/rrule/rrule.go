@@ -0,0 +1,483 @@
+// Package rrule evaluates iCalendar recurrence rules (RFC 5545) of daily, weekly, monthly and yearly frequency
+// with the INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST parts.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the period of the recurrence.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+const (
+	// maxInterval is the largest interval between the periods of a rule.
+	maxInterval = 1000
+	// maxPeriods bounds the periods looked through for the next occurrence, so rules matching no date end.
+	maxPeriods = 100000
+	// rulePrefix is the optional property name in front of the rule.
+	rulePrefix = "RRULE:"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// weekdays are the iCalendar names of the days of the week.
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// untilLayouts are the layouts of UTC, local and date UNTIL values.
+var untilLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// Weekday is a day of BYDAY, N is the ordinal of the day within the month counted from the end when negative,
+// 0 for every such day.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is a parsed recurrence rule. Until is zero when the rule is not limited in time; local and date UNTIL values
+// are kept as the wall clock of UTC and read in the location of the start.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+	until      string
+}
+
+// date is a day of the calendar.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// Parse parses the rule, with or without the RRULE: prefix. Parts of other frequencies and BYSETPOS, BYHOUR and
+// similar parts are not supported.
+func Parse(s string) (*Rule, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, rulePrefix)
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for part := range strings.SplitSeq(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q is not a key and a value", ErrInvalidRule, part)
+		}
+
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s is repeated", ErrInvalidRule, key)
+		}
+
+		seen[key] = true
+		if err := r.parsePart(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if r.Count > 0 && r.until != "" {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are exclusive", ErrInvalidRule)
+	}
+
+	if r.Freq == Yearly && len(r.ByDay) > 0 && len(r.ByMonth) == 0 {
+		return nil, fmt.Errorf("%w: BYDAY of yearly rules requires BYMONTH", ErrInvalidRule)
+	}
+
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return nil, fmt.Errorf("%w: BYDAY ordinals require monthly or yearly rules", ErrInvalidRule)
+		}
+	}
+
+	return r, nil
+}
+
+// parsePart parses the value of the part of the rule.
+func (r *Rule) parsePart(key, value string) error {
+	var err error
+	switch key {
+	case "FREQ":
+		r.Freq = Frequency(value)
+		if !slices.Contains([]Frequency{Daily, Weekly, Monthly, Yearly}, r.Freq) {
+			return fmt.Errorf("%w: frequency %s is not supported", ErrInvalidRule, value)
+		}
+	case "INTERVAL":
+		r.Interval, err = parseInt(key, value, 1, maxInterval)
+	case "COUNT":
+		r.Count, err = parseInt(key, value, 1, maxPeriods)
+	case "UNTIL":
+		r.until = value
+		r.Until, err = parseUntil(value)
+	case "BYDAY":
+		r.ByDay, err = parseList(value, parseWeekday)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseList(value, parseMonthDay)
+	case "BYMONTH":
+		r.ByMonth, err = parseList(value, parseMonth)
+	case "WKST":
+		day, ok := weekdays[value]
+		if !ok {
+			return fmt.Errorf("%w: unknown week start %s", ErrInvalidRule, value)
+		}
+
+		r.WeekStart = day
+	default:
+		return fmt.Errorf("%w: %s is not supported", ErrInvalidRule, key)
+	}
+
+	return err
+}
+
+// String returns the rule in its canonical form without the prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.until != "" {
+		parts = append(parts, "UNTIL="+r.until)
+	}
+
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = strconv.Itoa(int(m))
+		}
+
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = weekdayName(d.Day)
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayName(r.WeekStart))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence of the rule strictly after the time, reporting false when the rule has
+// no more occurrences. Occurrences keep the wall clock of the start in its location, the start is the first
+// occurrence when it matches the rule.
+func (r *Rule) Next(start, after time.Time) (time.Time, bool) {
+	loc := start.Location()
+	hour, minute, sec := start.Clock()
+	until := r.Until
+	if r.until != "" && !strings.HasSuffix(r.until, "Z") {
+		year, month, day := until.Date()
+		until = time.Date(year, month, day, until.Hour(), until.Minute(), until.Second(), 0, loc)
+		if len(r.until) == len(untilLayouts[2]) {
+			until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	count := 0
+	for p := range maxPeriods {
+		for _, d := range r.dates(start, p) {
+			t := time.Date(d.year, d.month, d.day, hour, minute, sec, start.Nanosecond(), loc)
+			if t.Before(start) {
+				continue
+			}
+
+			if !until.IsZero() && t.After(until) {
+				return time.Time{}, false
+			}
+
+			count++
+			if r.Count > 0 && count > r.Count {
+				return time.Time{}, false
+			}
+
+			if t.After(after) {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// dates returns the matching dates of the period of the rule in ascending order, the period 0 contains the start.
+func (r *Rule) dates(start time.Time, period int) []date {
+	year, month, day := start.Date()
+	step := period * r.Interval
+	var candidates []date
+	switch r.Freq {
+	case Daily:
+		candidates = []date{dateOf(time.Date(year, month, day+step, 0, 0, 0, 0, time.UTC))}
+	case Weekly:
+		candidates = r.weekDates(start, step)
+	case Monthly:
+		first := time.Date(year, month+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		candidates = r.monthDates(first.Year(), first.Month(), day)
+	case Yearly:
+		candidates = r.yearDates(year+step, month, day)
+	}
+
+	return slices.DeleteFunc(candidates, func(d date) bool { return !r.matches(d) })
+}
+
+// weekDates returns the dates of the week of the start moved by the number of weeks matching BYDAY, the weekday
+// of the start when BYDAY is not given.
+func (r *Rule) weekDates(start time.Time, weeks int) []date {
+	year, month, day := start.Date()
+	offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+	first := time.Date(year, month, day-offset+weeks*7, 0, 0, 0, 0, time.UTC)
+	days := r.ByDay
+	if len(days) == 0 {
+		days = []Weekday{{Day: start.Weekday()}}
+	}
+
+	var dates []date
+	for i := range 7 {
+		d := first.AddDate(0, 0, i)
+		if slices.ContainsFunc(days, func(w Weekday) bool { return w.Day == d.Weekday() }) {
+			dates = append(dates, dateOf(d))
+		}
+	}
+
+	return dates
+}
+
+// yearDates returns the dates of the year in the months of BYMONTH, in every month for BYMONTHDAY alone and
+// in the month of the start otherwise.
+func (r *Rule) yearDates(year int, startMonth time.Month, startDay int) []date {
+	months := slices.Sorted(slices.Values(r.ByMonth))
+	if len(months) == 0 && len(r.ByMonthDay) > 0 {
+		for m := time.January; m <= time.December; m++ {
+			months = append(months, m)
+		}
+	} else if len(months) == 0 {
+		months = []time.Month{startMonth}
+	}
+
+	var dates []date
+	for _, m := range months {
+		dates = append(dates, r.monthDates(year, m, startDay)...)
+	}
+
+	return dates
+}
+
+// monthDates returns the dates of the month matching BYMONTHDAY and BYDAY in ascending order, the day of the start
+// when neither is given.
+func (r *Rule) monthDates(year int, month time.Month, startDay int) []date {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if startDay > last {
+			return nil
+		}
+
+		return []date{{year, month, startDay}}
+	}
+
+	var days []int
+	for day := 1; day <= last; day++ {
+		d := date{year, month, day}
+		if len(r.ByMonthDay) > 0 && !slices.ContainsFunc(r.ByMonthDay, func(md int) bool {
+			return md == day || md == day-last-1
+		}) {
+			continue
+		}
+
+		if len(r.ByDay) > 0 && !slices.ContainsFunc(r.ByDay, func(w Weekday) bool { return w.matches(d, last) }) {
+			continue
+		}
+
+		days = append(days, day)
+	}
+
+	dates := make([]date, len(days))
+	for i, day := range days {
+		dates[i] = date{year, month, day}
+	}
+
+	return dates
+}
+
+// matches reports whether the date matches the parts of the rule limiting the dates of its periods.
+func (r *Rule) matches(d date) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, d.month) {
+		return false
+	}
+
+	if r.Freq != Daily {
+		return true
+	}
+
+	last := time.Date(d.year, d.month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if len(r.ByMonthDay) > 0 && !slices.ContainsFunc(r.ByMonthDay, func(md int) bool {
+		return md == d.day || md == d.day-last-1
+	}) {
+		return false
+	}
+
+	return len(r.ByDay) == 0 || slices.ContainsFunc(r.ByDay, func(w Weekday) bool { return w.matches(d, last) })
+}
+
+// matches reports whether the date of the month with the last day is the weekday of the ordinal.
+func (w Weekday) matches(d date, last int) bool {
+	if time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC).Weekday() != w.Day {
+		return false
+	}
+
+	switch {
+	case w.N > 0:
+		return (d.day-1)/7+1 == w.N
+	case w.N < 0:
+		return (last-d.day)/7+1 == -w.N
+	default:
+		return true
+	}
+}
+
+// dateOf returns the date of the time.
+func dateOf(t time.Time) date {
+	year, month, day := t.Date()
+	return date{year, month, day}
+}
+
+// parseInt parses the integer value of the part within the bounds.
+func parseInt(key, value string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("%w: %s %q is not an integer from %d to %d", ErrInvalidRule, key, value, lo, hi)
+	}
+
+	return n, nil
+}
+
+// parseList parses the comma separated values of the part.
+func parseList[T any](value string, parse func(string) (T, error)) ([]T, error) {
+	var values []T
+	for v := range strings.SplitSeq(value, ",") {
+		parsed, err := parse(v)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, parsed)
+	}
+
+	return values, nil
+}
+
+// parseMonthDay parses the day of BYMONTHDAY, counted from the end of the month when negative.
+func parseMonthDay(value string) (int, error) {
+	day, err := parseInt("BYMONTHDAY", value, -31, 31)
+	if err == nil && day == 0 {
+		return 0, fmt.Errorf("%w: BYMONTHDAY 0", ErrInvalidRule)
+	}
+
+	return day, err
+}
+
+// parseMonth parses the month of BYMONTH.
+func parseMonth(value string) (time.Month, error) {
+	month, err := parseInt("BYMONTH", value, 1, 12)
+	return time.Month(month), err
+}
+
+// parseUntil parses the UTC, local or date UNTIL value.
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range untilLayouts {
+		if len(value) != len(layout) {
+			continue
+		}
+
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: UNTIL %q is not a date or a date-time", ErrInvalidRule, value)
+}
+
+// parseWeekday parses the day of BYDAY with its optional ordinal.
+func parseWeekday(value string) (Weekday, error) {
+	if len(value) < 2 {
+		return Weekday{}, fmt.Errorf("%w: unknown BYDAY %q", ErrInvalidRule, value)
+	}
+
+	day, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return Weekday{}, fmt.Errorf("%w: unknown BYDAY %q", ErrInvalidRule, value)
+	}
+
+	w := Weekday{Day: day}
+	if ordinal := value[:len(value)-2]; ordinal != "" {
+		n, err := parseInt("BYDAY", strings.TrimPrefix(ordinal, "+"), -5, 5)
+		if err != nil || n == 0 {
+			return Weekday{}, fmt.Errorf("%w: BYDAY ordinal %q is not from -5 to 5", ErrInvalidRule, ordinal)
+		}
+
+		w.N = n
+	}
+
+	return w, nil
+}
+
+// weekdayName returns the iCalendar name of the day of the week.
+func weekdayName(day time.Weekday) string {
+	for name, d := range weekdays {
+		if d == day {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,233 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		rule        string
+		expected    string
+		expectedErr error
+	}{
+		{name: "daily", rule: "FREQ=DAILY", expected: "FREQ=DAILY"},
+		{name: "default_interval_dropped", rule: "FREQ=DAILY;INTERVAL=1", expected: "FREQ=DAILY"},
+		{
+			name:     "prefix_and_lower_case",
+			rule:     " rrule:freq=monthly;interval=2;byday=mo,+2fr,-1su;wkst=su ",
+			expected: "FREQ=MONTHLY;INTERVAL=2;BYDAY=MO,2FR,-1SU;WKST=SU",
+		},
+		{
+			name:     "canonical_order",
+			rule:     "BYMONTHDAY=1,-1;BYMONTH=12,1;UNTIL=20301231;FREQ=YEARLY",
+			expected: "FREQ=YEARLY;UNTIL=20301231;BYMONTH=12,1;BYMONTHDAY=1,-1",
+		},
+		{name: "count", rule: "FREQ=WEEKLY;COUNT=10;BYDAY=TU", expected: "FREQ=WEEKLY;COUNT=10;BYDAY=TU"},
+		{name: "empty", rule: " ", expectedErr: ErrInvalidRule},
+		{name: "not_key_value", rule: "FREQ", expectedErr: ErrInvalidRule},
+		{name: "missing_freq", rule: "INTERVAL=2", expectedErr: ErrInvalidRule},
+		{name: "unsupported_freq", rule: "FREQ=HOURLY", expectedErr: ErrInvalidRule},
+		{name: "repeated_part", rule: "FREQ=DAILY;FREQ=DAILY", expectedErr: ErrInvalidRule},
+		{name: "unsupported_part", rule: "FREQ=MONTHLY;BYSETPOS=1", expectedErr: ErrInvalidRule},
+		{name: "zero_interval", rule: "FREQ=DAILY;INTERVAL=0", expectedErr: ErrInvalidRule},
+		{name: "count_and_until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20250101", expectedErr: ErrInvalidRule},
+		{name: "invalid_until", rule: "FREQ=DAILY;UNTIL=2025", expectedErr: ErrInvalidRule},
+		{name: "zero_month_day", rule: "FREQ=MONTHLY;BYMONTHDAY=0", expectedErr: ErrInvalidRule},
+		{name: "invalid_month", rule: "FREQ=YEARLY;BYMONTH=13", expectedErr: ErrInvalidRule},
+		{name: "unknown_weekday", rule: "FREQ=WEEKLY;BYDAY=M", expectedErr: ErrInvalidRule},
+		{name: "ordinal_out_of_range", rule: "FREQ=MONTHLY;BYDAY=6MO", expectedErr: ErrInvalidRule},
+		{name: "weekly_ordinal", rule: "FREQ=WEEKLY;BYDAY=1MO", expectedErr: ErrInvalidRule},
+		{name: "yearly_byday_without_bymonth", rule: "FREQ=YEARLY;BYDAY=MO", expectedErr: ErrInvalidRule},
+		{name: "unknown_week_start", rule: "FREQ=WEEKLY;WKST=XX", expectedErr: ErrInvalidRule},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := Parse(tc.rule)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				require.Nil(t, r)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, r.String())
+
+			again, err := Parse(r.String())
+			require.NoError(t, err)
+			require.Equal(t, r, again)
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	utc := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "daily_count",
+			rule:     "FREQ=DAILY;COUNT=3",
+			start:    utc(2025, 1, 30, 9),
+			expected: []time.Time{utc(2025, 1, 30, 9), utc(2025, 1, 31, 9), utc(2025, 2, 1, 9)},
+		},
+		{
+			name:     "daily_byday",
+			rule:     "FREQ=DAILY;BYDAY=SA,SU;COUNT=3",
+			start:    utc(2025, 1, 3, 9),
+			expected: []time.Time{utc(2025, 1, 4, 9), utc(2025, 1, 5, 9), utc(2025, 1, 11, 9)},
+		},
+		{
+			name:     "weekly_byday_start_not_matching",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3",
+			start:    utc(2025, 1, 1, 10),
+			expected: []time.Time{utc(2025, 1, 3, 10), utc(2025, 1, 6, 10), utc(2025, 1, 10, 10)},
+		},
+		{
+			name:     "weekly_interval",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			start:    utc(2025, 1, 6, 8),
+			expected: []time.Time{utc(2025, 1, 6, 8), utc(2025, 1, 20, 8), utc(2025, 2, 3, 8)},
+		},
+		{
+			name:     "weekly_week_start_monday",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			start:    utc(1997, 8, 5, 9),
+			expected: []time.Time{utc(1997, 8, 5, 9), utc(1997, 8, 10, 9), utc(1997, 8, 19, 9), utc(1997, 8, 24, 9)},
+		},
+		{
+			name:     "weekly_week_start_sunday",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			start:    utc(1997, 8, 5, 9),
+			expected: []time.Time{utc(1997, 8, 5, 9), utc(1997, 8, 17, 9), utc(1997, 8, 19, 9), utc(1997, 8, 31, 9)},
+		},
+		{
+			name:     "monthly_missing_day_skipped",
+			rule:     "FREQ=MONTHLY;COUNT=3",
+			start:    utc(2025, 1, 31, 12),
+			expected: []time.Time{utc(2025, 1, 31, 12), utc(2025, 3, 31, 12), utc(2025, 5, 31, 12)},
+		},
+		{
+			name:     "monthly_last_day",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			start:    utc(2025, 1, 15, 12),
+			expected: []time.Time{utc(2025, 1, 31, 12), utc(2025, 2, 28, 12), utc(2025, 3, 31, 12)},
+		},
+		{
+			name:     "monthly_last_friday",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start:    utc(2025, 1, 1, 17),
+			expected: []time.Time{utc(2025, 1, 31, 17), utc(2025, 2, 28, 17), utc(2025, 3, 28, 17)},
+		},
+		{
+			name:     "monthly_second_tuesday",
+			rule:     "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			start:    utc(2025, 1, 1, 17),
+			expected: []time.Time{utc(2025, 1, 14, 17), utc(2025, 2, 11, 17), utc(2025, 3, 11, 17)},
+		},
+		{
+			name:     "yearly_leap_day",
+			rule:     "FREQ=YEARLY;COUNT=3",
+			start:    utc(2024, 2, 29, 7),
+			expected: []time.Time{utc(2024, 2, 29, 7), utc(2028, 2, 29, 7), utc(2032, 2, 29, 7)},
+		},
+		{
+			name:     "yearly_bymonth",
+			rule:     "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=1;COUNT=3",
+			start:    utc(2025, 3, 10, 0),
+			expected: []time.Time{utc(2025, 7, 1, 0), utc(2026, 1, 1, 0), utc(2026, 7, 1, 0)},
+		},
+		{
+			name:     "until_utc",
+			rule:     "FREQ=DAILY;UNTIL=20250102T090000Z",
+			start:    utc(2025, 1, 1, 9),
+			expected: []time.Time{utc(2025, 1, 1, 9), utc(2025, 1, 2, 9)},
+		},
+		{
+			name:  "until_date_in_start_location",
+			rule:  "FREQ=DAILY;UNTIL=20250102",
+			start: time.Date(2025, 1, 1, 23, 0, 0, 0, berlin),
+			expected: []time.Time{
+				time.Date(2025, 1, 1, 23, 0, 0, 0, berlin),
+				time.Date(2025, 1, 2, 23, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:  "wall_clock_kept_across_dst",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2025, 3, 29, 9, 0, 0, 0, berlin),
+			expected: []time.Time{
+				time.Date(2025, 3, 29, 9, 0, 0, 0, berlin),
+				time.Date(2025, 3, 30, 9, 0, 0, 0, berlin),
+				time.Date(2025, 3, 31, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:  "no_matching_date",
+			rule:  "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start: utc(2025, 1, 1, 0),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := Parse(tc.rule)
+			require.NoError(t, err)
+
+			var occurrences []time.Time
+			after := tc.start.Add(-time.Nanosecond)
+			for {
+				next, ok := r.Next(tc.start, after)
+				if !ok {
+					break
+				}
+
+				require.Less(t, len(occurrences), len(tc.expected), "unexpected occurrence %s", next)
+				occurrences = append(occurrences, next)
+				after = next
+			}
+
+			require.Len(t, occurrences, len(tc.expected))
+			for i, expected := range tc.expected {
+				require.True(t, expected.Equal(occurrences[i]), "occurrence %d: %s", i, occurrences[i])
+			}
+		})
+	}
+}
+
+func TestRule_NextAfter(t *testing.T) {
+	t.Parallel()
+
+	r, err := Parse("FREQ=WEEKLY;BYDAY=MO,WE")
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	next, ok := r.Next(start, time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), next)
+
+	next, ok = r.Next(start, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, start, next)
+}
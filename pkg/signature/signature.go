@@ -0,0 +1,67 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed = errors.New("malformed signature")
+	ErrMismatch  = errors.New("signature mismatch")
+	ErrExpired   = errors.New("signature timestamp is out of tolerance")
+)
+
+// Sign returns the signature header of the body sent at the given time in the form "t=<unix>,v1=<hex>".
+// The HMAC-SHA256 digest covers "<unix>.<body>", so a captured body cannot be replayed with another timestamp.
+func Sign(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unix, digest(secret, unix, body))
+}
+
+// Verify checks the signature header of the body and that its timestamp is within the tolerance from now.
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var unix, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return ErrMalformed
+		}
+
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			sig = value
+		}
+	}
+
+	ts, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || sig == "" {
+		return ErrMalformed
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(digest(secret, unix, body))) {
+		return ErrMismatch
+	}
+
+	if now.Sub(time.Unix(ts, 0)).Abs() > tolerance {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// digest returns the hex encoded HMAC-SHA256 of the timestamp and the body.
+func digest(secret string, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
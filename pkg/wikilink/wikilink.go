@@ -0,0 +1,68 @@
+// Package wikilink parses and rewrites wiki-style links of the form [[Target]] and [[Target|Label]] in text.
+package wikilink
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxTargetLength is the longest target taken for a link, longer brackets are left as text.
+const MaxTargetLength = 200
+
+// linkPattern matches a link with the target and the optional label, neither spanning lines or brackets.
+var linkPattern = regexp.MustCompile(`\[\[([^\[\]|\n]+)(?:\|([^\[\]\n]*))?\]\]`)
+
+// Link is a link found in the text. Target is trimmed of the surrounding spaces.
+type Link struct {
+	Target string
+	Label  string
+}
+
+// Parse returns the links of the text in the order of their first appearance, each target once.
+func Parse(text string) []Link {
+	var links []Link
+	seen := make(map[string]struct{})
+	for _, m := range linkPattern.FindAllStringSubmatch(text, -1) {
+		target := strings.TrimSpace(m[1])
+		if target == "" || len(target) > MaxTargetLength {
+			continue
+		}
+
+		if _, ok := seen[target]; ok {
+			continue
+		}
+
+		seen[target] = struct{}{}
+		links = append(links, Link{Target: target, Label: strings.TrimSpace(m[2])})
+	}
+
+	return links
+}
+
+// ValidTarget reports whether the title can be written as the target of a link, i.e. it is not blank, not longer
+// than MaxTargetLength and holds no brackets, pipes or line breaks.
+func ValidTarget(title string) bool {
+	title = strings.TrimSpace(title)
+	return title != "" && len(title) <= MaxTargetLength && !strings.ContainsAny(title, "[]|\n\r")
+}
+
+// Rename replaces the target of the links to from with to, keeping the labels of the links. The text is returned
+// unchanged when to is not a valid target, since writing it would break the links.
+func Rename(text, from, to string) string {
+	if !ValidTarget(to) {
+		return text
+	}
+
+	return linkPattern.ReplaceAllStringFunc(text, func(s string) string {
+		m := linkPattern.FindStringSubmatch(s)
+		if strings.TrimSpace(m[1]) != from {
+			return s
+		}
+
+		if label := strings.TrimSpace(m[2]); label != "" {
+			return "[[" + to + "|" + label + "]]"
+		}
+
+		return "[[" + to + "]]"
+	})
+}
@@ -0,0 +1,76 @@
+package wikilink
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		text     string
+		expected []Link
+	}{
+		{name: "no_links", text: "plain [text] and [[]]"},
+		{
+			name:     "targets_and_labels",
+			text:     "See [[ Trip | the trip ]], [[Packing list]] and [[Trip]] again",
+			expected: []Link{{Target: "Trip", Label: "the trip"}, {Target: "Packing list"}},
+		},
+		{
+			name:     "brackets_and_lines_left_as_text",
+			text:     "[[a\nb]] [[a[b]] [[ok]]",
+			expected: []Link{{Target: "ok"}},
+		},
+		{
+			name: "long_target_skipped",
+			text: "[[" + strings.Repeat("a", MaxTargetLength+1) + "]]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, Parse(tc.text))
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	t.Parallel()
+
+	text := "Read [[Trip|the trip]], [[ Trip ]] and [[Other]]"
+
+	cases := []struct {
+		name     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "renamed",
+			to:       "Summer trip",
+			expected: "Read [[Summer trip|the trip]], [[Summer trip]] and [[Other]]",
+		},
+		{name: "title_with_pipe", to: "Trip | 2025", expected: text},
+		{name: "title_with_brackets", to: "Trip [draft]", expected: text},
+		{name: "title_with_line_break", to: "Trip\n2025", expected: text},
+		{name: "blank_title", to: " ", expected: text},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			renamed := Rename(text, "Trip", tc.to)
+			require.Equal(t, tc.expected, renamed)
+			if tc.expected != text {
+				require.Equal(t, []Link{{Target: tc.to, Label: "the trip"}, {Target: "Other"}}, Parse(renamed))
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -63,9 +64,21 @@ func TestMain(m *testing.M) {
 		log.Panicf("failed to load config: %v", err)
 	}
 
+	blobDir, err := os.MkdirTemp("", "notes-blobs-*")
+	if err != nil {
+		log.Panicf("failed to create blob dir: %v", err)
+	}
+	defer os.RemoveAll(blobDir) // nolint: errcheck
+
+	cfg.Blob.FSDir = blobDir
+	blobs, err := app.NewBlobStore(&cfg.Blob)
+	if err != nil {
+		log.Panicf("failed to create blob store: %v", err)
+	}
+
 	deps := app.NewDeps(cfg, &logger.Logger{
 		Logger: zerolog.Nop(),
-	}, pool)
+	}, pool, blobs)
 	defer deps.Close() // nolint: errcheck
 
 	testutil.Server = httptest.NewServer(rest.NewRest(deps).Routes())
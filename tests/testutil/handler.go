@@ -21,6 +21,7 @@ import (
 type HandlerCase[REQ, RES, DEPS any] struct {
 	Name        string
 	ID          string
+	Params      map[string]string
 	Req         REQ
 	StatusCode  int
 	Expected    RES
@@ -51,11 +52,16 @@ func (tc *HandlerCase[REQ, RES, DEPS]) Run(
 	r := httptest.NewRequest(method, url, body)
 	w := httptest.NewRecorder()
 
+	params := map[string]string{
+		"id": tc.ID,
+	}
+	for k, v := range tc.Params {
+		params[k] = v
+	}
+
 	middleware.Logger(mock_app.NewDeps(t, func(deps *app.Deps) {}).Logger)(
 		handler(deps),
-	).ServeHTTP(w, AddUrlParams(r, map[string]string{
-		"id": tc.ID,
-	}))
+	).ServeHTTP(w, AddUrlParams(r, params))
 	res := w.Result()
 
 	require.Equal(t, tc.StatusCode, res.StatusCode)